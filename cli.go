@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/export"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+)
+
+// runExport writes the identifiers extracted by an analysis to a file or the standard output, using the storage
+// configured for the server. It returns the exit code for the process.
+//
+// Usage: src-reader export -analysis <id> [-format csv|jsonl|parquet] [-output <file>] [-workspace <id>]
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	analysis := flags.String("analysis", "", "ID of the analysis to export")
+	format := flags.String("format", entity.ExportFormatCSV, "output format: csv, jsonl or parquet")
+	output := flags.String("output", "", "file to write, instead of the standard output")
+	workspace := flags.String("workspace", "", "ID of the workspace holding the analysis, instead of the default one")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	analysisID, err := uuid.Parse(*analysis)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid analysis ID '%s'\n", *analysis)
+		flags.Usage()
+		return 2
+	}
+
+	ctx, err := workspaceContext(*workspace)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return 2
+	}
+
+	repos := newRepositories(loadConfig().Storage)
+	uc := usecase.NewExportAnalysisUsecase(repos.identifier, repos.analysis, export.NewIdentifierWriterFactory())
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if *output != "" {
+		f, err = os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to create %s: %v\n", *output, err)
+			return 1
+		}
+		w = f
+	}
+	buffered := bufio.NewWriter(w)
+
+	err = uc.Process(ctx, analysisID, *format, buffered)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if f != nil {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		// a partial export is removed, so it isn't mistaken for a complete one
+		if err != nil {
+			os.Remove(*output)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to export analysis %v: %v\n", analysisID, err)
+		return 1
+	}
+
+	return 0
+}
+
+// runArchive writes an analysis, along with its project, identifiers and insights, to a file or the standard output,
+// so it can be restored later on any storage. It returns the exit code for the process.
+//
+// Usage: src-reader archive -analysis <id> [-output <file>] [-workspace <id>]
+func runArchive(args []string) int {
+	flags := flag.NewFlagSet("archive", flag.ContinueOnError)
+	analysis := flags.String("analysis", "", "ID of the analysis to archive")
+	output := flags.String("output", "", "file to write, instead of the standard output")
+	workspace := flags.String("workspace", "", "ID of the workspace holding the analysis, instead of the default one")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	analysisID, err := uuid.Parse(*analysis)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid analysis ID '%s'\n", *analysis)
+		flags.Usage()
+		return 2
+	}
+
+	ctx, err := workspaceContext(*workspace)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return 2
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to create %s: %v\n", *output, err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	repos := newRepositories(loadConfig().Storage)
+	uc := usecase.NewArchiveAnalysisUsecase(repos.project, repos.analysis, repos.identifier, repos.insight,
+		export.NewJSONLArchiveFormat())
+	if err := uc.Process(ctx, analysisID, w); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to archive analysis %v: %v\n", analysisID, err)
+		return 1
+	}
+
+	return 0
+}
+
+// runRestore restores an archived analysis from a file or the standard input, using the storage configured for
+// the server. It returns the exit code for the process.
+//
+// Usage: src-reader restore [-input <file>] [-workspace <id>]
+func runRestore(args []string) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	input := flags.String("input", "", "file to read, instead of the standard input")
+	workspace := flags.String("workspace", "", "ID of the workspace to restore into, instead of the default one")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx, err := workspaceContext(*workspace)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.Usage()
+		return 2
+	}
+
+	var r io.Reader = os.Stdin
+	if *input != "" {
+		f, err := os.Open(*input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to open %s: %v\n", *input, err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	storage := loadConfig().Storage
+	repos := newRepositories(storage)
+	indexAnalysisUsecase := usecase.NewIndexAnalysisUsecase(repos.identifier, repos.search)
+	uc := usecase.NewRestoreAnalysisUsecase(repos.project, repos.analysis, repos.identifier, repos.insight,
+		indexAnalysisUsecase, export.NewJSONLArchiveFormat())
+	analysis, err := uc.Process(ctx, r)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to restore archive: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(os.Stdout, "restored analysis %v for project %s\n", analysis.ID, analysis.ProjectName)
+	return 0
+}
+
+// workspaceContext returns a context bound to the given workspace ID, or to the default workspace when it's empty.
+func workspaceContext(workspace string) (context.Context, error) {
+	if workspace == "" {
+		return context.Background(), nil
+	}
+
+	workspaceID, err := uuid.Parse(workspace)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workspace ID '%s'", workspace)
+	}
+
+	return entity.WithWorkspace(context.Background(), workspaceID), nil
+}
+
+// runWebhook sends a stored push payload to a running server, signed as the given provider does using the given
+// secret, or the configured webhook secret for the default workspace, so push notifications can be tested locally.
+// It returns the exit code for the process.
+//
+// Usage: src-reader webhook [-provider github|gitlab|gitea] [-payload <file>] [-url <url>] [-secret <secret>]
+func runWebhook(args []string) int {
+	flags := flag.NewFlagSet("webhook", flag.ContinueOnError)
+	provider := flags.String("provider", entity.ProviderGitHub, "provider sending the push: github, gitlab or gitea")
+	payload := flags.String("payload", "", "file with the push payload, instead of the provider fixture")
+	url := flags.String("url", "http://localhost:8080/webhooks/git", "URL for the webhook on the running server")
+	secret := flags.String("secret", "", "webhook secret of the workspace, instead of the configured one")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *payload == "" {
+		*payload = fmt.Sprintf("config/webhooks/%s_push.json", *provider)
+	}
+	body, err := ioutil.ReadFile(*payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to read %s: %v\n", *payload, err)
+		return 1
+	}
+
+	if *secret == "" {
+		*secret = loadConfig().Auth.WebhookSecret
+	}
+	req, err := rest.NewWebhookRequest(*url, *provider, body, *secret)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create push notification: %v\n", err)
+		flags.Usage()
+		return 2
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to send push notification: %v\n", err)
+		return 1
+	}
+	defer resp.Body.Close()
+
+	fmt.Fprintf(os.Stdout, "%s\n", resp.Status)
+	io.Copy(os.Stdout, resp.Body)
+	fmt.Fprintln(os.Stdout)
+	if resp.StatusCode >= http.StatusBadRequest {
+		return 1
+	}
+
+	return 0
+}
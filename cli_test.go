@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunExport_WhenExportFails_ShouldRemoveTheOutputFile(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("STORAGE", "memory")
+	output := filepath.Join(t.TempDir(), "identifiers.csv")
+
+	code := runExport([]string{"-analysis", uuid.New().String(), "-output", output})
+
+	assert.Equal(t, 1, code)
+	_, err := os.Stat(output)
+	assert.True(t, os.IsNotExist(err))
+}
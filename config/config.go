@@ -0,0 +1,541 @@
+// Package config defines the settings of the server, read from a YAML file and overridden by the environment.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// redacted replaces the secrets when the configuration is shown.
+const redacted = "xxxxx"
+
+// Config holds every setting of the server. Each setting can be overridden by the environment variable named on its
+// env tag. Secrets are tagged with redact, and hidden by Redacted.
+type Config struct {
+	Server   Server   `yaml:"server" json:"server"`
+	Storage  Storage  `yaml:"storage" json:"storage"`
+	Source   Source   `yaml:"source" json:"source"`
+	Pipeline Pipeline `yaml:"pipeline" json:"pipeline"`
+	Limits   Limits   `yaml:"limits" json:"limits"`
+	Auth     Auth     `yaml:"auth" json:"auth"`
+	Notifier Notifier `yaml:"notifier" json:"notifier"`
+	Log      Log      `yaml:"log" json:"log"`
+	Tracing  Tracing  `yaml:"tracing" json:"tracing"`
+}
+
+// Server defines where the REST API listens, and how long a shutdown waits for the requests and analyses in
+// progress.
+type Server struct {
+	Address             string `yaml:"address" json:"address" env:"LISTEN_ADDRESS"`
+	DrainTimeoutSeconds int    `yaml:"drain_timeout_seconds" json:"drain_timeout_seconds" env:"DRAIN_TIMEOUT_SECONDS"`
+}
+
+// Storage defines the backend holding projects, analyses, identifiers and insights, and the search index.
+type Storage struct {
+	// Backend is one of "mongodb", "sqlite", "postgres" or "memory".
+	Backend     string `yaml:"backend" json:"backend" env:"STORAGE"`
+	Mongo       Mongo  `yaml:"mongo" json:"mongo"`
+	SQLitePath  string `yaml:"sqlite_path" json:"sqlite_path" env:"SQLITE_PATH" redact:"url"`
+	PostgresURL string `yaml:"postgres_url" json:"postgres_url" env:"POSTGRES_URL" redact:"url"`
+}
+
+// Mongo defines the connection to MongoDB. The URI takes precedence over the host and credentials.
+type Mongo struct {
+	URI          string `yaml:"uri" json:"uri" env:"MONGODB_URI" redact:"url"`
+	Host         string `yaml:"host" json:"host" env:"MONGODB_HOST"`
+	User         string `yaml:"user" json:"user" env:"MONGODB_USER"`
+	Password     string `yaml:"password" json:"password" env:"MONGODB_PASSWORD" redact:"secret"`
+	AuthDatabase string `yaml:"auth_database" json:"auth_database" env:"MONGODB_DATABASE"`
+	Database     string `yaml:"database" json:"database" env:"MONGODB_NAME"`
+}
+
+// ConnectionURI retrieves the URI used to connect to MongoDB.
+func (m Mongo) ConnectionURI() string {
+	if m.URI != "" {
+		return m.URI
+	}
+
+	return fmt.Sprintf("mongodb://%s:%s@%s:27017/%s", m.User, m.Password, m.Host, m.AuthDatabase)
+}
+
+// Source defines where the metadata of the projects is retrieved from, and where their source code is cloned.
+type Source struct {
+	CloneDir     string `yaml:"clone_dir" json:"clone_dir" env:"CLONE_DIR"`
+	GitHubAPIURL string `yaml:"github_api_url" json:"github_api_url" env:"GITHUB_API_URL"`
+	GitHubToken  string `yaml:"github_token" json:"github_token" env:"GITHUB_TOKEN" redact:"secret"`
+}
+
+// Pipeline defines the miners, splitters, expanders and rules applied by default on each analysis. Lists are
+// overridden by comma-separated environment variables, and RuleSeverities by comma-separated rule=severity pairs,
+// such as initialisms=error. WordsDir holds the word lists, such as es.txt, extending the seed dictionaries of the
+// languages other than English.
+type Pipeline struct {
+	Miners         []string          `yaml:"miners" json:"miners" env:"PIPELINE_MINERS"`
+	Splitters      []string          `yaml:"splitters" json:"splitters" env:"PIPELINE_SPLITTERS"`
+	Expanders      []string          `yaml:"expanders" json:"expanders" env:"PIPELINE_EXPANDERS"`
+	Rules          []string          `yaml:"rules" json:"rules" env:"PIPELINE_RULES"`
+	RuleSeverities map[string]string `yaml:"rule_severities" json:"rule_severities" env:"PIPELINE_RULE_SEVERITIES"`
+	IncludeTests   bool              `yaml:"include_tests" json:"include_tests" env:"PIPELINE_INCLUDE_TESTS"`
+	WordsDir       string            `yaml:"words_dir" json:"words_dir" env:"PIPELINE_WORDS_DIR"`
+	Stages         Stages            `yaml:"stages" json:"stages"`
+}
+
+// Stages defines the concurrency of each stage of the analysis pipeline. The settings of each stage are overridden
+// by the environment variables prefixed by the env tag of the stage, such as PIPELINE_STAGES_READ_WORKERS.
+type Stages struct {
+	Read      Stage `yaml:"read" json:"read" env:"PIPELINE_STAGES_READ"`
+	Parse     Stage `yaml:"parse" json:"parse" env:"PIPELINE_STAGES_PARSE"`
+	Split     Stage `yaml:"split" json:"split" env:"PIPELINE_STAGES_SPLIT"`
+	Expand    Stage `yaml:"expand" json:"expand" env:"PIPELINE_STAGES_EXPAND"`
+	Normalize Stage `yaml:"normalize" json:"normalize" env:"PIPELINE_STAGES_NORMALIZE"`
+}
+
+// Stage defines how many workers process the elements of a pipeline stage, and how many processed elements can be
+// kept in memory ahead of the next stage. Zero workers run one worker per CPU.
+type Stage struct {
+	Workers int `yaml:"workers" json:"workers" env:"WORKERS"`
+	Buffer  int `yaml:"buffer" json:"buffer" env:"BUFFER"`
+}
+
+// Limits defines the quotas on the requests and analyses, and the guards on the files read. A zero value disables
+// any limit.
+type Limits struct {
+	RateLimitPerMinute                int `yaml:"rate_limit_per_minute" json:"rate_limit_per_minute" env:"RATE_LIMIT_PER_MINUTE"`
+	MaxConcurrentAnalyses             int `yaml:"max_concurrent_analyses" json:"max_concurrent_analyses" env:"MAX_CONCURRENT_ANALYSES"`
+	MaxConcurrentAnalysesPerWorkspace int `yaml:"max_concurrent_analyses_per_workspace" json:"max_concurrent_analyses_per_workspace" env:"MAX_CONCURRENT_ANALYSES_PER_WORKSPACE"`
+	MaxRepositorySizeKB               int `yaml:"max_repository_size_kb" json:"max_repository_size_kb" env:"MAX_REPOSITORY_SIZE_KB"`
+	MaxRepositoryFiles                int `yaml:"max_repository_files" json:"max_repository_files" env:"MAX_REPOSITORY_FILES"`
+	MaxFileSizeKB                     int `yaml:"max_file_size_kb" json:"max_file_size_kb" env:"MAX_FILE_SIZE_KB"`
+	MaxAnalyzedFiles                  int `yaml:"max_analyzed_files" json:"max_analyzed_files" env:"MAX_ANALYZED_FILES"`
+	MaxAnalyzedSizeMB                 int `yaml:"max_analyzed_size_mb" json:"max_analyzed_size_mb" env:"MAX_ANALYZED_SIZE_MB"`
+}
+
+// Auth defines the secrets accepted by the server.
+type Auth struct {
+	AdminAPIKey   string `yaml:"admin_api_key" json:"admin_api_key" env:"ADMIN_API_KEY" redact:"secret"`
+	WebhookSecret string `yaml:"webhook_secret" json:"webhook_secret" env:"WEBHOOK_SECRET" redact:"secret"`
+}
+
+// Notifier defines the destination for the review comments.
+type Notifier struct {
+	// Destination is one of "file", "github" or "webhook".
+	Destination string `yaml:"destination" json:"destination" env:"NOTIFIER"`
+	WebhookURL  string `yaml:"webhook_url" json:"webhook_url" env:"NOTIFIER_WEBHOOK_URL" redact:"secret"`
+	FilePath    string `yaml:"file_path" json:"file_path" env:"NOTIFIER_FILE_PATH"`
+	// SentFilePath records the comments posted by the webhook notifier, since the webhook can't be queried.
+	SentFilePath string `yaml:"sent_file_path" json:"sent_file_path" env:"NOTIFIER_SENT_FILE_PATH"`
+}
+
+// Log defines the level and the format of the logs.
+type Log struct {
+	Level string `yaml:"level" json:"level" env:"LOG_LEVEL"`
+	// Format is either "text" or "json".
+	Format string `yaml:"format" json:"format" env:"LOG_FORMAT"`
+}
+
+// Tracing defines where the spans of the requests and analyses are exported.
+type Tracing struct {
+	// Exporter is one of "none", "otlp", "stdout" or "file".
+	Exporter     string `yaml:"exporter" json:"exporter" env:"TRACING_EXPORTER"`
+	OTLPEndpoint string `yaml:"otlp_endpoint" json:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" redact:"url"`
+	// OTLPProtocol is one of "http/protobuf" or "grpc".
+	OTLPProtocol string `yaml:"otlp_protocol" json:"otlp_protocol" env:"OTEL_EXPORTER_OTLP_PROTOCOL"`
+	FilePath     string `yaml:"file_path" json:"file_path" env:"TRACING_FILE_PATH"`
+}
+
+// Default creates the configuration applied when neither the file nor the environment set a value.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Address:             ":8080",
+			DrainTimeoutSeconds: 30,
+		},
+		Storage: Storage{
+			Backend:    "mongodb",
+			Mongo:      Mongo{Database: "reader"},
+			SQLitePath: "reader.db",
+		},
+		Source: Source{
+			CloneDir:     "/tmp/repositories/github.com",
+			GitHubAPIURL: "https://api.github.com",
+		},
+		Pipeline: Pipeline{
+			Miners: []string{"wordcount", "scoped-declarations", "comments", "declarations",
+				"global-frequency-table", "abbreviations"},
+			Splitters: []string{"conserv", "greedy", "samurai"},
+			Expanders: []string{"noexp", "basic", "amap", "learned"},
+			Rules: []string{"initialisms", "package-stutter", "snake-case", "getter-prefix", "name-length",
+				"receiver-consistency"},
+			Stages: Stages{
+				Read:      Stage{Workers: 4, Buffer: 16},
+				Parse:     Stage{Buffer: 16},
+				Split:     Stage{Buffer: 256},
+				Expand:    Stage{Buffer: 256},
+				Normalize: Stage{Workers: 1, Buffer: 256},
+			},
+		},
+		Limits: Limits{
+			RateLimitPerMinute:                60,
+			MaxConcurrentAnalyses:             4,
+			MaxConcurrentAnalysesPerWorkspace: 2,
+			MaxFileSizeKB:                     1024,
+		},
+		Notifier: Notifier{
+			Destination:  "file",
+			FilePath:     "review.jsonl",
+			SentFilePath: "review-sent.jsonl",
+		},
+		Log: Log{
+			Level:  "info",
+			Format: "text",
+		},
+		Tracing: Tracing{
+			Exporter:     "none",
+			OTLPEndpoint: "http://localhost:4318",
+			OTLPProtocol: "http/protobuf",
+			FilePath:     "traces.jsonl",
+		},
+	}
+}
+
+// ValidationError lists every problem found on a configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load creates the configuration from the defaults, the YAML file on the given path, if any, and the environment
+// variables found by lookupEnv, in that order. The resulting configuration is validated.
+func Load(path string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("unable to read configuration file %s: %v", path, err)
+		}
+
+		// unknown keys are rejected, so typos don't go unnoticed
+		if err := yaml.UnmarshalStrict(content, &cfg); err != nil {
+			return Config{}, fmt.Errorf("unable to parse configuration file %s: %v", path, err)
+		}
+	}
+
+	problems := applyEnv(reflect.ValueOf(&cfg).Elem(), "", lookupEnv)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return Config{}, ValidationError{Problems: problems}
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides the fields of the given struct with the environment variables named on their env tag, preceded
+// by the given prefix. The env tag of a nested struct prefixes the names of its fields.
+func applyEnv(v reflect.Value, prefix string, lookupEnv func(string) (string, bool)) []string {
+	problems := make([]string, 0)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		tag := v.Type().Field(i).Tag
+		if field.Kind() == reflect.Struct {
+			nested := prefix
+			if name := tag.Get("env"); name != "" {
+				nested = prefix + name + "_"
+			}
+			problems = append(problems, applyEnv(field, nested, lookupEnv)...)
+			continue
+		}
+
+		if tag.Get("env") == "" {
+			continue
+		}
+		name := prefix + tag.Get("env")
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int:
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s must be a number, found %q", name, value))
+				continue
+			}
+			field.SetInt(int64(number))
+		case reflect.Bool:
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s must be true or false, found %q", name, value))
+				continue
+			}
+			field.SetBool(enabled)
+		case reflect.Slice:
+			values := make([]string, 0)
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					values = append(values, item)
+				}
+			}
+			field.Set(reflect.ValueOf(values))
+		case reflect.Map:
+			pairs := make(map[string]string)
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item == "" {
+					continue
+				}
+				key := strings.SplitN(item, "=", 2)
+				if len(key) != 2 || strings.TrimSpace(key[0]) == "" {
+					problems = append(problems, fmt.Sprintf("%s must hold key=value pairs, found %q", name, item))
+					continue
+				}
+				pairs[strings.TrimSpace(key[0])] = strings.TrimSpace(key[1])
+			}
+			field.Set(reflect.ValueOf(pairs))
+		}
+	}
+
+	return problems
+}
+
+// validate looks for missing or unsupported settings.
+func (c Config) validate() []string {
+	problems := make([]string, 0)
+	if c.Server.Address == "" {
+		problems = append(problems, "server.address (LISTEN_ADDRESS) is required")
+	}
+	if c.Server.DrainTimeoutSeconds <= 0 {
+		problems = append(problems, fmt.Sprintf(
+			"server.drain_timeout_seconds (DRAIN_TIMEOUT_SECONDS) must be positive, found %d", c.Server.DrainTimeoutSeconds))
+	}
+
+	switch c.Storage.Backend {
+	case "memory":
+		// do nothing
+	case "mongodb":
+		if c.Storage.Mongo.URI == "" && c.Storage.Mongo.Host == "" {
+			problems = append(problems,
+				"storage.mongo.uri (MONGODB_URI) or storage.mongo.host (MONGODB_HOST) is required by the mongodb storage")
+		}
+		if c.Storage.Mongo.Database == "" {
+			problems = append(problems, "storage.mongo.database (MONGODB_NAME) is required by the mongodb storage")
+		}
+	case "sqlite":
+		if c.Storage.SQLitePath == "" {
+			problems = append(problems, "storage.sqlite_path (SQLITE_PATH) is required by the sqlite storage")
+		}
+	case "postgres":
+		if c.Storage.PostgresURL == "" {
+			problems = append(problems, "storage.postgres_url (POSTGRES_URL) is required by the postgres storage")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf(
+			"storage.backend (STORAGE) must be one of mongodb, sqlite, postgres or memory, found %q", c.Storage.Backend))
+	}
+
+	if c.Source.CloneDir == "" {
+		problems = append(problems, "source.clone_dir (CLONE_DIR) is required")
+	}
+	if u, err := url.Parse(c.Source.GitHubAPIURL); err != nil || !u.IsAbs() {
+		problems = append(problems, fmt.Sprintf("source.github_api_url (GITHUB_API_URL) must be an absolute URL, found %q",
+			c.Source.GitHubAPIURL))
+	}
+
+	if len(c.Pipeline.Splitters) == 0 {
+		problems = append(problems, "pipeline.splitters (PIPELINE_SPLITTERS) requires at least one splitter")
+	}
+	if len(c.Pipeline.Expanders) == 0 {
+		problems = append(problems, "pipeline.expanders (PIPELINE_EXPANDERS) requires at least one expander")
+	}
+
+	rules := make(map[string]bool, len(c.Pipeline.Rules))
+	for _, rule := range c.Pipeline.Rules {
+		rules[rule] = true
+	}
+	for _, rule := range sortedKeys(c.Pipeline.RuleSeverities) {
+		if !rules[rule] {
+			problems = append(problems, fmt.Sprintf(
+				"pipeline.rule_severities (PIPELINE_RULE_SEVERITIES) sets a severity for %q, which isn't on pipeline.rules",
+				rule))
+		}
+		switch severity := c.Pipeline.RuleSeverities[rule]; severity {
+		case "info", "warning", "error":
+			// do nothing
+		default:
+			problems = append(problems, fmt.Sprintf(
+				"pipeline.rule_severities (PIPELINE_RULE_SEVERITIES) must set info, warning or error for %q, found %q",
+				rule, severity))
+		}
+	}
+
+	stages := reflect.ValueOf(c.Pipeline.Stages)
+	for i := 0; i < stages.NumField(); i++ {
+		stage := stages.Type().Field(i)
+		settings := stages.Field(i)
+		for j := 0; j < settings.NumField(); j++ {
+			if settings.Field(j).Int() < 0 {
+				field := settings.Type().Field(j)
+				problems = append(problems, fmt.Sprintf(
+					"pipeline.stages.%s.%s (%s_%s) must be zero or a positive number, found %d", stage.Tag.Get("yaml"),
+					field.Tag.Get("yaml"), stage.Tag.Get("env"), field.Tag.Get("env"), settings.Field(j).Int()))
+			}
+		}
+	}
+
+	limits := reflect.ValueOf(c.Limits)
+	for i := 0; i < limits.NumField(); i++ {
+		if limits.Field(i).Int() < 0 {
+			field := limits.Type().Field(i)
+			problems = append(problems, fmt.Sprintf("limits.%s (%s) must be zero or a positive number, found %d",
+				field.Tag.Get("yaml"), field.Tag.Get("env"), limits.Field(i).Int()))
+		}
+	}
+
+	switch c.Notifier.Destination {
+	case "file":
+		if c.Notifier.FilePath == "" {
+			problems = append(problems, "notifier.file_path (NOTIFIER_FILE_PATH) is required by the file notifier")
+		}
+	case "github":
+		if c.Source.GitHubToken == "" {
+			problems = append(problems, "source.github_token (GITHUB_TOKEN) is required by the github notifier")
+		}
+	case "webhook":
+		if c.Notifier.WebhookURL == "" {
+			problems = append(problems, "notifier.webhook_url (NOTIFIER_WEBHOOK_URL) is required by the webhook notifier")
+		}
+		if c.Notifier.SentFilePath == "" {
+			problems = append(problems,
+				"notifier.sent_file_path (NOTIFIER_SENT_FILE_PATH) is required by the webhook notifier")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf(
+			"notifier.destination (NOTIFIER) must be one of file, github or webhook, found %q", c.Notifier.Destination))
+	}
+
+	if _, err := log.ParseLevel(c.Log.Level); err != nil {
+		problems = append(problems, fmt.Sprintf(
+			"log.level (LOG_LEVEL) must be one of trace, debug, info, warn, error, fatal or panic, found %q", c.Log.Level))
+	}
+	if c.Log.Format != "text" && c.Log.Format != "json" {
+		problems = append(problems, fmt.Sprintf("log.format (LOG_FORMAT) must be either text or json, found %q",
+			c.Log.Format))
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+		// do nothing
+	case "otlp":
+		if u, err := url.Parse(c.Tracing.OTLPEndpoint); err != nil || !u.IsAbs() {
+			problems = append(problems, fmt.Sprintf(
+				"tracing.otlp_endpoint (OTEL_EXPORTER_OTLP_ENDPOINT) must be an absolute URL, found %q", c.Tracing.OTLPEndpoint))
+		}
+		if c.Tracing.OTLPProtocol != "http/protobuf" && c.Tracing.OTLPProtocol != "grpc" {
+			problems = append(problems, fmt.Sprintf(
+				"tracing.otlp_protocol (OTEL_EXPORTER_OTLP_PROTOCOL) must be one of http/protobuf or grpc, found %q",
+				c.Tracing.OTLPProtocol))
+		}
+	case "file":
+		if c.Tracing.FilePath == "" {
+			problems = append(problems, "tracing.file_path (TRACING_FILE_PATH) is required by the file exporter")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf(
+			"tracing.exporter (TRACING_EXPORTER) must be one of none, otlp, stdout or file, found %q", c.Tracing.Exporter))
+	}
+
+	return problems
+}
+
+// Redacted creates a copy of the configuration with its secrets hidden, including the passwords on URLs.
+func (c Config) Redacted() Config {
+	cfg := c
+	cfg.Pipeline.Miners = append([]string{}, c.Pipeline.Miners...)
+	cfg.Pipeline.Splitters = append([]string{}, c.Pipeline.Splitters...)
+	cfg.Pipeline.Expanders = append([]string{}, c.Pipeline.Expanders...)
+	cfg.Pipeline.Rules = append([]string{}, c.Pipeline.Rules...)
+	cfg.Pipeline.RuleSeverities = make(map[string]string, len(c.Pipeline.RuleSeverities))
+	for rule, severity := range c.Pipeline.RuleSeverities {
+		cfg.Pipeline.RuleSeverities[rule] = severity
+	}
+	redact(reflect.ValueOf(&cfg).Elem())
+
+	return cfg
+}
+
+func redact(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			redact(field)
+			continue
+		}
+		if field.Kind() != reflect.String || field.String() == "" {
+			continue
+		}
+
+		switch v.Type().Field(i).Tag.Get("redact") {
+		case "secret":
+			field.SetString(redacted)
+		case "url":
+			field.SetString(redactURL(field.String()))
+		}
+	}
+}
+
+// keywordPassword matches the passwords on a keyword/value connection string, such as
+// "host=db user=app password=secret", either quoted or not.
+var keywordPassword = regexp.MustCompile(`(?i)(^|\s)(\w*password\s*=\s*)('(?:[^'\\]|\\.)*'|\S*)`)
+
+// redactURL hides the password and the query values on the given URL, since they may hold passwords or tokens.
+// Keyword/value connection strings get their passwords hidden instead. Values that can't be parsed are hidden as a
+// whole.
+func redactURL(value string) string {
+	if !strings.Contains(value, "://") && strings.Contains(value, "=") && !strings.Contains(value, "?") {
+		return keywordPassword.ReplaceAllString(value, "${1}${2}"+redacted)
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return redacted
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			query[key] = []string{redacted}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// sortedKeys retrieves the keys of the given map in order, so the problems are always reported the same way.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -1,6 +1,9 @@
 package entity
 
-import "go/ast"
+import (
+	"context"
+	"go/ast"
+)
 
 // Miner interface is used to define a custom miner.
 type Miner interface {
@@ -14,6 +17,27 @@ type Miner interface {
 	Results() interface{}
 }
 
+// HistoryMiner interface is used to define a custom miner which also learns from the version
+// history of the source code.
+type HistoryMiner interface {
+	Miner
+	// MineHistory applies the mining logic on the version history stored at the given location, leaving out the
+	// files rejected by skips. It stops as soon as the context is cancelled.
+	MineHistory(ctx context.Context, location string, skips func(filename string) bool) error
+}
+
+// LanguageMiner interface is used to define a custom miner which adapts its word lists to the
+// natural language of each package.
+type LanguageMiner interface {
+	Miner
+	// SetLanguages specifies the language detected for each package, using the full package name as key.
+	SetLanguages(languages map[string]Language)
+}
+
+// LanguageDetector defines the contract for the functions capable of detecting the dominant
+// natural language on a set of texts.
+type LanguageDetector func(texts []string) Language
+
 // MinerAbstractFactory is an interface for creating mining algorithm factories.
 type MinerAbstractFactory interface {
 	// Get returns a MinerFactory for the selectd mining algorithm.
@@ -46,6 +70,14 @@ type Splitter interface {
 	Split(token string) []Split
 }
 
+// LanguageSplitter interface is used to define a custom splitter which adapts its word lists to the
+// natural language of each identifier.
+type LanguageSplitter interface {
+	Splitter
+	// SplitInLanguage returns the split identifier, using the word lists for the given language.
+	SplitInLanguage(token string, lang Language) []Split
+}
+
 // SplitterAbstractFactory is an interface for creating splitting algorithm factories.
 type SplitterAbstractFactory interface {
 	// Get returns a SplitterFactory for the selectd splitting algorithm.
@@ -68,6 +100,21 @@ type Expander interface {
 	Expand(ident Identifier) []Expansion
 }
 
+// RetargetableExpander is an Expander that isn't bound to a splitter, so it can be applied on the splits of any
+// splitter on the pipeline.
+type RetargetableExpander interface {
+	Expander
+	// On returns a copy of the expander applied on the splits of the given splitter.
+	On(splitter string) Expander
+}
+
+// DictionaryExpanderName is the name of the Expanders built by a DictionaryExpanderFactory.
+const DictionaryExpanderName = "dictionary"
+
+// DictionaryExpanderFactory defines the contract for the factory functions capable of
+// building Expanders backed up by a managed Dictionary.
+type DictionaryExpanderFactory func(dict Dictionary) Expander
+
 // ExpanderAbstractFactory is an interface for creating expandion algorithm factories.
 type ExpanderAbstractFactory interface {
 	// Get returns a ExpanderFactory for the selectd expansion algorithm.
@@ -79,3 +126,26 @@ type ExpanderFactory interface {
 	// Make returns an expansion algorithm instance built from miners.
 	Make(miningResults map[string]Miner) (Expander, error)
 }
+
+// Rule interface is used to define a custom naming-convention rule.
+type Rule interface {
+	// Name returns the name of the custom rule.
+	Name() string
+	// Severity returns the severity assigned to the findings reported by the rule.
+	Severity() Severity
+	// Check verifies the identifier against the rule and returns the violations found.
+	Check(ident Identifier) []Finding
+}
+
+// RuleAbstractFactory is an interface for creating naming-convention rule factories.
+type RuleAbstractFactory interface {
+	// Get returns a RuleFactory for the selected rule.
+	Get(name string) (RuleFactory, error)
+}
+
+// RuleFactory is an interface for creating naming-convention rule instances.
+type RuleFactory interface {
+	// Make returns a rule instance reporting with the given severity. If no severity is
+	// provided, the rule's default one is used.
+	Make(severity Severity) (Rule, error)
+}
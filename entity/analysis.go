@@ -17,11 +17,49 @@ import (
 type AnalysisConfig struct {
 	Miners                    []string
 	MinerAlgorithmFactory     MinerAbstractFactory
+	LanguageDetector          LanguageDetector
 	ExtractorFactory          ExtractorFactory
 	SplittingAlgorithmFactory SplitterAbstractFactory
 	Splitters                 []string
 	ExpansionAlgorithmFactory ExpanderAbstractFactory
 	Expanders                 []string
+	DictionaryExpanderFactory DictionaryExpanderFactory
+	RuleFactory               RuleAbstractFactory
+	Rules                     []string
+	RuleSeverities            map[string]Severity
+	// Stages sets the concurrency for the "read", "parse", "split", "expand" and "normalize" stages.
+	// Missing stages are processed by a single worker.
+	Stages map[string]StageConfig
+	// Guards limits the files read from the source code.
+	Guards FileGuards
+	// IncludeTests reads the _test.go files too. Their identifiers are reported on a separate scope.
+	IncludeTests bool
+}
+
+// Pipeline names the miners, splitters, expanders and rules applied on an analysis, and whether the test
+// files were analyzed.
+type Pipeline struct {
+	Miners       []string
+	Splitters    []string
+	Expanders    []string
+	Rules        []string
+	IncludeTests bool
+}
+
+// Pipeline stages that can be processed concurrently.
+const (
+	StageRead      = "read"
+	StageParse     = "parse"
+	StageSplit     = "split"
+	StageExpand    = "expand"
+	StageNormalize = "normalize"
+)
+
+// StageConfig defines how many workers process the elements of a pipeline stage, and how many
+// processed elements can be kept in memory ahead of the next stage.
+type StageConfig struct {
+	Workers int
+	Buffer  int
 }
 
 // File represents a source code file, including its raw form and also its Abstract Syntax Tree representation.
@@ -31,6 +69,8 @@ type File struct {
 	AST     *ast.File
 	FileSet *token.FileSet
 	Error   error
+	// Skipped indicates the file was left out of the analysis by the file guards or the project filter.
+	Skipped bool
 }
 
 // Identifier represents an identifier extracted from source code, indicating its origin, type,
@@ -42,13 +82,19 @@ type Identifier struct {
 	Package       string
 	File          string
 	Position      token.Pos
+	Line          int
+	Column        int
 	Name          string
 	Type          token.Token
+	Receiver      string
+	ReceiverName  string
 	Node          *ast.Node
 	Splits        map[string][]Split
 	Expansions    map[string][]Expansion
 	Error         error
 	Normalization Normalization
+	Findings      []Finding
+	Language      Language
 }
 
 // FullPackageName returns the package name, including its directory structure.
@@ -66,6 +112,11 @@ func (i Identifier) FullPackageName() string {
 	return fmt.Sprintf("%s/%s", dir, i.Package)
 }
 
+// IsTest determines if the identifier was extracted from a test file.
+func (i Identifier) IsTest() bool {
+	return strings.HasSuffix(i.File, "_test.go")
+}
+
 // Exported determines if the identifier is exported on its package.
 func (i Identifier) Exported() bool {
 	if len(i.Name) == 0 {
@@ -75,7 +126,9 @@ func (i Identifier) Exported() bool {
 	return unicode.IsUpper(rune(i.Name[0])) && unicode.IsLetter(rune(i.Name[0]))
 }
 
-// Normalize applies a normalization function to select the best split/expansion approach.
+// Normalize applies a normalization function to select the best split/expansion approach. The expansions taken
+// from a managed dictionary are preferred whenever they expand any split, since they were set by hand, even if
+// another algorithm gets a closer word.
 func (i *Identifier) Normalize() {
 	normalization := Normalization{
 		Word:      "undefined",
@@ -83,6 +136,7 @@ func (i *Identifier) Normalize() {
 		Score:     0.0,
 	}
 
+	var managed *Normalization
 	for algorithm, expansions := range i.Expansions {
 		sort.Sort(bySoftwordOrder(expansions))
 
@@ -101,18 +155,36 @@ func (i *Identifier) Normalize() {
 
 		lengths := float64(len(i.Name) + len(word))
 		score := (lengths - float64(levenshtein.ComputeDistance(i.Name, word))) / lengths
+		candidate := Normalization{
+			Word:      word,
+			Algorithm: fmt.Sprintf("%s+%s", expansions[0].SplittingAlgorithm, algorithm),
+			Score:     score,
+		}
+		if algorithm == DictionaryExpanderName && expandsAny(expansions) {
+			managed = &candidate
+		}
 		if score >= normalization.Score {
-			normalization = Normalization{
-				Word:      word,
-				Algorithm: fmt.Sprintf("%s+%s", expansions[0].SplittingAlgorithm, algorithm),
-				Score:     score,
-			}
+			normalization = candidate
 		}
 	}
 
+	if managed != nil {
+		normalization = *managed
+	}
 	i.Normalization = normalization
 }
 
+// expandsAny determines if any of the expansions replaces the split it comes from.
+func expandsAny(expansions []Expansion) bool {
+	for _, expansion := range expansions {
+		if len(expansion.Values) > 0 && !strings.EqualFold(expansion.Values[0], expansion.From) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Split represents a hardword or softword in which the identifier was divided.
 type Split struct {
 	Order int
@@ -151,12 +223,27 @@ type AnalysisResults struct {
 	PipelineMiners          []string
 	PipelineSplitters       []string
 	PipelineExpanders       []string
+	PipelineRules           []string
+	IncludeTests            bool
 	FilesTotal              int
 	FilesValid              int
 	FilesError              int
 	FilesErrorSamples       []string
+	FilesSkipped            int
 	IdentifiersTotal        int
 	IdentifiersValid        int
 	IdentifiersError        int
 	IdentifiersErrorSamples []string
 }
+
+// Pipeline retrieves the miners, splitters, expanders and rules applied on the analysis, and whether the test files
+// were analyzed.
+func (a AnalysisResults) Pipeline() Pipeline {
+	return Pipeline{
+		Miners:       a.PipelineMiners,
+		Splitters:    a.PipelineSplitters,
+		Expanders:    a.PipelineExpanders,
+		Rules:        a.PipelineRules,
+		IncludeTests: a.IncludeTests,
+	}
+}
@@ -40,6 +40,22 @@ func TestExported_OnIdentifier(t *testing.T) {
 	}
 }
 
+func TestIsTest_OnIdentifier(t *testing.T) {
+	cases := []struct {
+		ident    entity.Identifier
+		expected bool
+	}{
+		{ident: entity.Identifier{File: "main.go"}, expected: false},
+		{ident: entity.Identifier{File: "main_test.go"}, expected: true},
+		{ident: entity.Identifier{File: "utils/crypto_test.go"}, expected: true},
+		{ident: entity.Identifier{File: "testing/helper.go"}, expected: false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.ident.IsTest())
+	}
+}
+
 func TestNormalize_OnIdentifier(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -111,6 +127,25 @@ func TestNormalize_OnIdentifier(t *testing.T) {
 				Score:     0.6842105263157895,
 			},
 		},
+		{
+			name: "dictionary_expansion_over_closer_word",
+			ident: entity.Identifier{
+				Name: "txn",
+				Expansions: map[string][]entity.Expansion{
+					"noexp": {
+						{Order: 1, SplittingAlgorithm: "conserv", From: "txn", Values: []string{"txn"}},
+					},
+					"dictionary": {
+						{Order: 1, SplittingAlgorithm: "conserv", From: "txn", Values: []string{"transaction"}},
+					},
+				},
+			},
+			expected: entity.Normalization{
+				Word:      "transaction",
+				Algorithm: "conserv+dictionary",
+				Score:     0.35714285714285715,
+			},
+		},
 	}
 
 	for _, c := range cases {
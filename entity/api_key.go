@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// ScopeRead allows retrieving projects, analyses and their results.
+	ScopeRead = "read"
+	// ScopeAnalyze allows importing and analyzing projects, besides every action allowed by ScopeRead.
+	ScopeAnalyze = "analyze"
+	// ScopeAdmin allows every action, including removing elements and managing the API keys.
+	ScopeAdmin = "admin"
+)
+
+// scopeLevels ranks the scopes, so a scope includes the ones below it.
+var scopeLevels = map[string]int{
+	ScopeRead:    1,
+	ScopeAnalyze: 2,
+	ScopeAdmin:   3,
+}
+
+// APIKey represents a credential granted to a client of the API, as a member of a workspace. Only the hash of
+// the key is kept.
+type APIKey struct {
+	ID          uuid.UUID
+	WorkspaceID uuid.UUID
+	Name        string
+	// Prefix holds the first characters of the key, so it can be recognized without revealing it.
+	Prefix    string
+	Hash      string
+	Scopes    []string
+	CreatedAt time.Time
+}
+
+// Allows checks if any scope granted to the key includes the given scope.
+func (k APIKey) Allows(scope string) bool {
+	required, ok := scopeLevels[scope]
+	if !ok {
+		return false
+	}
+
+	for _, granted := range k.Scopes {
+		if scopeLevels[granted] >= required {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidScope checks if the given value is a supported scope.
+func ValidScope(scope string) bool {
+	_, ok := scopeLevels[scope]
+	return ok
+}
+
+// HashAPIKey returns the hash stored for the given key.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllows_OnAPIKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string
+		scope    string
+		expected bool
+	}{
+		{"no_scopes", []string{}, entity.ScopeRead, false},
+		{"same_scope", []string{entity.ScopeAnalyze}, entity.ScopeAnalyze, true},
+		{"lower_scope", []string{entity.ScopeAnalyze}, entity.ScopeRead, true},
+		{"higher_scope", []string{entity.ScopeRead}, entity.ScopeAnalyze, false},
+		{"admin_scope", []string{entity.ScopeAdmin}, entity.ScopeAnalyze, true},
+		{"any_scope", []string{entity.ScopeRead, entity.ScopeAdmin}, entity.ScopeAdmin, true},
+		{"unknown_granted_scope", []string{"owner"}, entity.ScopeRead, false},
+		{"unknown_required_scope", []string{entity.ScopeAdmin}, "owner", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := entity.APIKey{Scopes: tt.scopes}
+
+			assert.Equal(t, tt.expected, key.Allows(tt.scope))
+		})
+	}
+}
+
+func TestValidScope(t *testing.T) {
+	assert.True(t, entity.ValidScope(entity.ScopeRead))
+	assert.True(t, entity.ValidScope(entity.ScopeAnalyze))
+	assert.True(t, entity.ValidScope(entity.ScopeAdmin))
+	assert.False(t, entity.ValidScope("owner"))
+}
+
+func TestHashAPIKey(t *testing.T) {
+	assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", entity.HashAPIKey("secret"))
+	assert.NotEqual(t, entity.HashAPIKey("secret"), entity.HashAPIKey("Secret"))
+}
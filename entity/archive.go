@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchiveSchemaVersion is the version of the archive format written by the current release. Archives written
+// with a previous version are upgraded when they are read.
+const ArchiveSchemaVersion = 1
+
+// ArchiveHeader describes the content of an archive, which holds every element related to an analysis.
+type ArchiveHeader struct {
+	SchemaVersion int
+	CreatedAt     time.Time
+	AnalysisID    uuid.UUID
+	ProjectRef    string
+}
+
+// ArchiveRecord holds one of the elements stored on an archive. Only one of its fields is set.
+type ArchiveRecord struct {
+	Project    *Project
+	Analysis   *AnalysisResults
+	Identifier *Identifier
+	Insight    *Insight
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DictionaryScopeOrganization identifies a dictionary applied to every project.
+	DictionaryScopeOrganization = "organization"
+	// DictionaryScopeProject identifies a dictionary applied to a single project, overriding
+	// the organization-wide entries.
+	DictionaryScopeProject = "project"
+)
+
+// Dictionary represents a managed set of abbreviations and their expansions, defined
+// for the whole organization or for a given project.
+type Dictionary struct {
+	ID         uuid.UUID
+	Scope      string
+	ProjectRef string
+	Entries    map[string][]string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Lookup retrieves the expansions defined for the given abbreviation, ignoring its case.
+func (d Dictionary) Lookup(abbreviation string) ([]string, bool) {
+	expansions, ok := d.Entries[strings.ToLower(abbreviation)]
+	if !ok || len(expansions) == 0 {
+		return nil, false
+	}
+
+	return expansions, true
+}
+
+// Override returns a new Dictionary where the entries from the given dictionary replace
+// the ones defined on the current dictionary.
+func (d Dictionary) Override(overrides Dictionary) Dictionary {
+	merged := Dictionary{
+		ID:         overrides.ID,
+		Scope:      overrides.Scope,
+		ProjectRef: overrides.ProjectRef,
+		Entries:    make(map[string][]string, len(d.Entries)+len(overrides.Entries)),
+		CreatedAt:  overrides.CreatedAt,
+		UpdatedAt:  overrides.UpdatedAt,
+	}
+	for abbreviation, expansions := range d.Entries {
+		merged.Entries[strings.ToLower(abbreviation)] = expansions
+	}
+	for abbreviation, expansions := range overrides.Entries {
+		merged.Entries[strings.ToLower(abbreviation)] = expansions
+	}
+
+	return merged
+}
@@ -0,0 +1,63 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup_OnDictionary(t *testing.T) {
+	dict := entity.Dictionary{
+		Entries: map[string][]string{
+			"txn": {"transaction"},
+			"mw":  {},
+		},
+	}
+
+	cases := []struct {
+		abbreviation string
+		expected     []string
+		found        bool
+	}{
+		{abbreviation: "txn", expected: []string{"transaction"}, found: true},
+		{abbreviation: "TXN", expected: []string{"transaction"}, found: true},
+		{abbreviation: "mw", expected: nil, found: false},
+		{abbreviation: "svc", expected: nil, found: false},
+	}
+
+	for _, c := range cases {
+		got, ok := dict.Lookup(c.abbreviation)
+		assert.Equal(t, c.found, ok)
+		assert.Equal(t, c.expected, got)
+	}
+}
+
+func TestOverride_OnDictionary_ShouldPreferOverridingEntries(t *testing.T) {
+	organization := entity.Dictionary{
+		Scope: entity.DictionaryScopeOrganization,
+		Entries: map[string][]string{
+			"txn": {"transaction"},
+			"cfg": {"config"},
+		},
+	}
+	project := entity.Dictionary{
+		Scope:      entity.DictionaryScopeProject,
+		ProjectRef: "eroatta/src-reader",
+		Entries: map[string][]string{
+			"CFG": {"configuration"},
+			"svc": {"service"},
+		},
+	}
+
+	got := organization.Override(project)
+
+	assert.Equal(t, entity.DictionaryScopeProject, got.Scope)
+	assert.Equal(t, "eroatta/src-reader", got.ProjectRef)
+	assert.Equal(t, map[string][]string{
+		"txn": {"transaction"},
+		"cfg": {"configuration"},
+		"svc": {"service"},
+	}, got.Entries)
+	assert.Equal(t, 2, len(organization.Entries))
+}
@@ -0,0 +1,11 @@
+package entity
+
+// Supported formats to export the identifiers extracted by an analysis.
+const (
+	// ExportFormatCSV exports one identifier per row, as comma-separated values.
+	ExportFormatCSV = "csv"
+	// ExportFormatJSONL exports one identifier per line, as a JSON object.
+	ExportFormatJSONL = "jsonl"
+	// ExportFormatParquet exports the identifiers as an Apache Parquet file.
+	ExportFormatParquet = "parquet"
+)
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// FileGuards limits the source code files read on an analysis, so repositories with huge, generated or vendored
+// files don't exhaust the memory or skew the results. A zero value on any limit means the limit isn't applied.
+type FileGuards struct {
+	// MaxFileSize is the size, in bytes, of the largest file read.
+	MaxFileSize int64
+	// MaxFiles is the number of files read.
+	MaxFiles int
+	// MaxTotalBytes is the size, in bytes, of every file read.
+	MaxTotalBytes int64
+	// SkipDirs holds the names of the directories never read, wherever they are.
+	SkipDirs []string
+	// SkipGenerated skips the files with a "// Code generated ... DO NOT EDIT." header.
+	SkipGenerated bool
+}
+
+// SkipsDir checks if the given file is placed on any of the skipped directories.
+func (g FileGuards) SkipsDir(filename string) bool {
+	dirs := strings.Split(path.Dir(filename), "/")
+	for _, dir := range dirs {
+		for _, skipped := range g.SkipDirs {
+			if dir == skipped {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FileFilter selects the files of a project to analyze, using glob patterns matched against the path of each file.
+// Besides the "*" and "?" wildcards, which don't match the "/" separator, "**" matches any number of directories.
+type FileFilter struct {
+	// Include holds the patterns a file must match to be analyzed. Every file is included if it's empty.
+	Include []string
+	// Exclude holds the patterns of the files not analyzed, even if they are included.
+	Exclude []string
+}
+
+// IsEmpty checks if the filter selects every file.
+func (f FileFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Allows checks if the given file is selected by the filter.
+func (f FileFilter) Allows(filename string) bool {
+	for _, pattern := range f.Exclude {
+		if MatchGlob(pattern, filename) {
+			return false
+		}
+	}
+
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, pattern := range f.Include {
+		if MatchGlob(pattern, filename) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchGlob checks if the given file path matches the glob pattern.
+// Invalid patterns don't match any file.
+func MatchGlob(pattern string, filename string) bool {
+	expr, err := globRegexp(pattern)
+	if err != nil {
+		return false
+	}
+
+	return expr.MatchString(filename)
+}
+
+// ValidGlob checks if the glob pattern can be matched, which requires every character class to be closed.
+func ValidGlob(pattern string) bool {
+	_, err := globRegexp(pattern)
+	return err == nil
+}
+
+// globRegexp translates a glob pattern into its regular expression.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed character class on %s", pattern)
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
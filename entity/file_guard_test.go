@@ -0,0 +1,63 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlob_ShouldMatchFilePaths(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		filename string
+		expected bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "internal/cmd/main.go", true},
+		{"internal/**", "internal/cmd/main.go", true},
+		{"internal/**", "cmd/main.go", false},
+		{"**/mock_*.go", "usecase/mock_repository.go", true},
+		{"v?.go", "v1.go", true},
+		{"v[0-9].go", "v1.go", true},
+		{"v[!0-9].go", "v1.go", false},
+		{"v[0-9.go", "v1.go", false},
+		{"main+1.go", "main+1.go", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.filename, func(t *testing.T) {
+			assert.Equal(t, tt.expected, entity.MatchGlob(tt.pattern, tt.filename))
+		})
+	}
+}
+
+func TestValidGlob_ShouldRejectUnclosedClasses(t *testing.T) {
+	assert.True(t, entity.ValidGlob("**/[a-z]*.go"))
+	assert.False(t, entity.ValidGlob("[a-z*.go"))
+}
+
+func TestAllows_OnFileFilter_ShouldApplyIncludeAndExclude(t *testing.T) {
+	filter := entity.FileFilter{
+		Include: []string{"cmd/**", "internal/**"},
+		Exclude: []string{"**/*_gen.go"},
+	}
+
+	assert.True(t, filter.Allows("cmd/main.go"))
+	assert.True(t, filter.Allows("internal/reader/reader.go"))
+	assert.False(t, filter.Allows("internal/reader/reader_gen.go"))
+	assert.False(t, filter.Allows("main.go"))
+	assert.True(t, entity.FileFilter{}.Allows("main.go"))
+}
+
+func TestSkipsDir_OnFileGuards_ShouldMatchDirectoriesAnywhere(t *testing.T) {
+	guards := entity.FileGuards{SkipDirs: []string{"vendor", "testdata"}}
+
+	assert.True(t, guards.SkipsDir("vendor/github.com/pkg/errors/errors.go"))
+	assert.True(t, guards.SkipsDir("parser/testdata/sample.go"))
+	assert.False(t, guards.SkipsDir("vendored.go"))
+	assert.False(t, guards.SkipsDir("internal/vendors/vendors.go"))
+}
@@ -0,0 +1,31 @@
+package entity
+
+// Severity represents how relevant a naming-convention violation is.
+type Severity string
+
+const (
+	// SeverityInfo indicates a stylistic suggestion.
+	SeverityInfo Severity = "info"
+	// SeverityWarning indicates a violation that should be reviewed.
+	SeverityWarning Severity = "warning"
+	// SeverityError indicates a violation that must be fixed.
+	SeverityError Severity = "error"
+)
+
+// Valid determines if the severity is one of the supported values.
+func (s Severity) Valid() bool {
+	switch s {
+	case SeverityInfo, SeverityWarning, SeverityError:
+		return true
+	default:
+		return false
+	}
+}
+
+// Finding represents a naming-convention violation reported by a Rule on an identifier.
+type Finding struct {
+	Rule       string
+	Severity   Severity
+	Message    string
+	Suggestion string
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"go/token"
+	"regexp"
+)
+
+// Supported sort orders for an IdentifierQuery.
+const (
+	// IdentifierSortDefault sorts the identifiers as they were stored.
+	IdentifierSortDefault = ""
+	// IdentifierSortScore sorts the identifiers by their normalization score, from the lowest to the highest.
+	IdentifierSortScore = "score"
+	// IdentifierSortScoreDesc sorts the identifiers by their normalization score, from the highest to the lowest.
+	IdentifierSortScoreDesc = "-score"
+)
+
+// IdentifierQuery defines the criteria to retrieve a page of identifiers. Empty criteria match every identifier.
+type IdentifierQuery struct {
+	Package  string
+	File     string
+	Type     token.Token
+	Exported *bool
+	MinScore *float64
+	MaxScore *float64
+	// Splitter matches the identifiers split by the given algorithm.
+	Splitter string
+	// Expander matches the identifiers expanded by the given algorithm.
+	Expander string
+	Name     *regexp.Regexp
+	Sort     string
+	// Cursor references the last identifier of the previous page.
+	Cursor string
+	Limit  int
+}
+
+// Match determines if the identifier matches the query criteria, without considering sorting and pagination.
+func (q IdentifierQuery) Match(ident Identifier) bool {
+	if q.Package != "" && ident.Package != q.Package {
+		return false
+	}
+
+	if q.File != "" && ident.File != q.File {
+		return false
+	}
+
+	if q.Type != token.ILLEGAL && ident.Type != q.Type {
+		return false
+	}
+
+	if q.Exported != nil && ident.Exported() != *q.Exported {
+		return false
+	}
+
+	if q.MinScore != nil && ident.Normalization.Score < *q.MinScore {
+		return false
+	}
+
+	if q.MaxScore != nil && ident.Normalization.Score > *q.MaxScore {
+		return false
+	}
+
+	if _, ok := ident.Splits[q.Splitter]; q.Splitter != "" && !ok {
+		return false
+	}
+
+	if _, ok := ident.Expansions[q.Expander]; q.Expander != "" && !ok {
+		return false
+	}
+
+	if q.Name != nil && !q.Name.MatchString(ident.Name) {
+		return false
+	}
+
+	return true
+}
+
+// IdentifierPage represents a page of identifiers. If there are more identifiers, the NextCursor can be used
+// to retrieve the following page.
+type IdentifierPage struct {
+	Identifiers []Identifier
+	NextCursor  string
+}
@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"go/token"
+	"regexp"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_OnIdentifierQuery(t *testing.T) {
+	ident := entity.Identifier{
+		Package:       "reader",
+		File:          "reader/file.go",
+		Name:          "ReadCfg",
+		Type:          token.FUNC,
+		Splits:        map[string][]entity.Split{"conserv": {{Order: 1, Value: "Read"}, {Order: 2, Value: "Cfg"}}},
+		Expansions:    map[string][]entity.Expansion{"basic": {}},
+		Normalization: entity.Normalization{Score: 0.75},
+	}
+	yes, no := true, false
+	low, high := 0.5, 0.7
+
+	cases := []struct {
+		name     string
+		query    entity.IdentifierQuery
+		expected bool
+	}{
+		{name: "empty_query", query: entity.IdentifierQuery{}, expected: true},
+		{name: "package", query: entity.IdentifierQuery{Package: "reader"}, expected: true},
+		{name: "other_package", query: entity.IdentifierQuery{Package: "writer"}, expected: false},
+		{name: "other_file", query: entity.IdentifierQuery{File: "reader/dir.go"}, expected: false},
+		{name: "type", query: entity.IdentifierQuery{Type: token.FUNC}, expected: true},
+		{name: "other_type", query: entity.IdentifierQuery{Type: token.STRUCT}, expected: false},
+		{name: "exported", query: entity.IdentifierQuery{Exported: &yes}, expected: true},
+		{name: "not_exported", query: entity.IdentifierQuery{Exported: &no}, expected: false},
+		{name: "min_score", query: entity.IdentifierQuery{MinScore: &low}, expected: true},
+		{name: "max_score", query: entity.IdentifierQuery{MaxScore: &high}, expected: false},
+		{name: "splitter", query: entity.IdentifierQuery{Splitter: "conserv"}, expected: true},
+		{name: "other_splitter", query: entity.IdentifierQuery{Splitter: "greedy"}, expected: false},
+		{name: "expander", query: entity.IdentifierQuery{Expander: "basic"}, expected: true},
+		{name: "other_expander", query: entity.IdentifierQuery{Expander: "amap"}, expected: false},
+		{name: "name", query: entity.IdentifierQuery{Name: regexp.MustCompile("Cfg$")}, expected: true},
+		{name: "other_name", query: entity.IdentifierQuery{Name: regexp.MustCompile("^Write")}, expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.query.Match(ident))
+		})
+	}
+}
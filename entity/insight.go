@@ -2,18 +2,22 @@ package entity
 
 import "github.com/google/uuid"
 
-// Insight represents information extracted and summarized from an Analysis, for a package.
+// Insight represents information extracted and summarized from an Analysis, for a package. The identifiers
+// from test files are summarized on a separate Insight for the package.
 type Insight struct {
 	ID               string
 	ProjectRef       string
 	AnalysisID       uuid.UUID
 	Package          string
+	Language         Language
 	TotalIdentifiers int
 	TotalExported    int
 	TotalSplits      map[string]int
 	TotalExpansions  map[string]int
 	TotalWeight      float64
 	Files            map[string]struct{}
+	// Test indicates the Insight summarizes the identifiers from test files.
+	Test bool
 }
 
 // AvgSplits returns the average number of splits for a particular algorithm.
@@ -31,9 +35,10 @@ func (i Insight) Rate() float64 {
 	return i.TotalWeight / float64(i.TotalIdentifiers)
 }
 
-// Include includes an identifier into the analysis for the current package Insight.
+// Include includes an identifier into the analysis for the current package Insight, if it belongs to the
+// same scope.
 func (i *Insight) Include(ident Identifier) {
-	if i.Package != ident.FullPackageName() {
+	if i.Package != ident.FullPackageName() || i.Test != ident.IsTest() {
 		return
 	}
 
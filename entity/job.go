@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// JobStatusRunning indicates that an analysis job is held by its owner, or waiting to be recovered if its owner
+	// stopped refreshing it.
+	JobStatusRunning = "running"
+	// JobStatusFailed indicates that the analysis of a job couldn't be completed, and it won't be recovered.
+	JobStatusFailed = "failed"
+)
+
+// AnalysisContextKey is the key holding the ID given to the analysis started with a context.
+const AnalysisContextKey = "analysis"
+
+// ReplacedAnalysisContextKey is the key holding the ID of the previous analysis replaced by the analysis started
+// with a context.
+const ReplacedAnalysisContextKey = "replaced_analysis"
+
+// SourceCodeContextKey is the key holding the source code analyzed by the analysis started with a context, in place
+// of the one stored on the project.
+const SourceCodeContextKey = "source_code"
+
+// AnalysisJob records an analysis while it's running, so the analyses interrupted by a shutdown or a crash can be
+// found and recovered by any server instance. Failed analyses remain recorded, along with the reason.
+type AnalysisJob struct {
+	ID         uuid.UUID
+	ProjectID  uuid.UUID
+	AnalysisID uuid.UUID
+	// Pipeline holds the miners, splitters, expanders and rules requested for the analysis, or nil if the analysis
+	// applies the configured ones.
+	Pipeline *Pipeline
+	// Commit holds the pushed revision the project is analyzed again at, or an empty string if the analysis reads
+	// the source code stored on the project.
+	Commit  string
+	Attempt int
+	Status  string
+	Error   string
+	// Owner identifies the server instance running the analysis, which holds the job as long as it keeps
+	// refreshing it.
+	Owner         string
+	DateStarted   time.Time
+	DateRefreshed time.Time
+}
+
+// WithAnalysisID returns a copy of the context, where the analysis started with it is given the ID.
+func WithAnalysisID(ctx context.Context, analysisID uuid.UUID) context.Context {
+	return context.WithValue(ctx, AnalysisContextKey, analysisID)
+}
+
+// AnalysisIDFrom retrieves the ID for the analysis set on the context, or a new ID if none was set.
+func AnalysisIDFrom(ctx context.Context) uuid.UUID {
+	if analysisID, ok := ctx.Value(AnalysisContextKey).(uuid.UUID); ok {
+		return analysisID
+	}
+
+	return uuid.New()
+}
+
+// WithReplacedAnalysis returns a copy of the context, where the analysis started with it is allowed to coexist with
+// the given previous analysis of the project, which is removed once the new one is completed.
+func WithReplacedAnalysis(ctx context.Context, analysisID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ReplacedAnalysisContextKey, analysisID)
+}
+
+// ReplacedAnalysisFrom retrieves the ID of the previous analysis replaced by the one started with the context, or
+// uuid.Nil if none was set.
+func ReplacedAnalysisFrom(ctx context.Context) uuid.UUID {
+	if analysisID, ok := ctx.Value(ReplacedAnalysisContextKey).(uuid.UUID); ok {
+		return analysisID
+	}
+
+	return uuid.Nil
+}
+
+// WithSourceCode returns a copy of the context, where the analysis started with it reads the given source code
+// instead of the one stored on the project, which is only updated once the analysis is completed.
+func WithSourceCode(ctx context.Context, sourceCode SourceCode) context.Context {
+	return context.WithValue(ctx, SourceCodeContextKey, sourceCode)
+}
+
+// SourceCodeFrom retrieves the source code to be analyzed by the analysis started with the context, and whether
+// it was set.
+func SourceCodeFrom(ctx context.Context) (SourceCode, bool) {
+	sourceCode, ok := ctx.Value(SourceCodeContextKey).(SourceCode)
+	return sourceCode, ok
+}
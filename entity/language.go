@@ -0,0 +1,13 @@
+package entity
+
+// Language represents a natural language, identified by its ISO 639-1 code.
+type Language string
+
+const (
+	// LanguageEnglish identifies the English language, used by default.
+	LanguageEnglish Language = "en"
+	// LanguageSpanish identifies the Spanish language.
+	LanguageSpanish Language = "es"
+	// LanguagePortuguese identifies the Portuguese language.
+	LanguagePortuguese Language = "pt"
+)
@@ -15,6 +15,8 @@ type Project struct {
 	CreatedAt  time.Time
 	Metadata   Metadata
 	SourceCode SourceCode
+	// Filter selects the files analyzed from the source code.
+	Filter FileFilter
 }
 
 // Metadata holds the remote project information.
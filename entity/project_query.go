@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Supported sort orders for a ProjectQuery.
+const (
+	// ProjectSortDefault sorts the projects by their creation date, from the newest to the oldest.
+	ProjectSortDefault = ""
+	// ProjectSortCreated sorts the projects by their creation date, from the oldest to the newest.
+	ProjectSortCreated = "created_at"
+	// ProjectSortCreatedDesc sorts the projects by their creation date, from the newest to the oldest.
+	ProjectSortCreatedDesc = "-created_at"
+	// ProjectSortStars sorts the projects by their stargazers, from the lowest to the highest.
+	ProjectSortStars = "stars"
+	// ProjectSortStarsDesc sorts the projects by their stargazers, from the highest to the lowest.
+	ProjectSortStarsDesc = "-stars"
+	// ProjectSortAccuracy sorts the projects by the accuracy of their latest analysis, from the lowest to the
+	// highest. Projects without insights come last.
+	ProjectSortAccuracy = "accuracy"
+	// ProjectSortAccuracyDesc sorts the projects by the accuracy of their latest analysis, from the highest to
+	// the lowest. Projects without insights come last.
+	ProjectSortAccuracyDesc = "-accuracy"
+)
+
+// ProjectQuery defines the criteria to retrieve a page of projects. Empty criteria match every project.
+type ProjectQuery struct {
+	Owner   string
+	License string
+	Fork    *bool
+	Status  string
+	// Text matches the projects whose reference or description contain it, ignoring case.
+	Text   string
+	Sort   string
+	Offset int
+	Limit  int
+}
+
+// Match determines if the project matches the query criteria, without considering sorting and pagination.
+func (q ProjectQuery) Match(project Project) bool {
+	if q.Owner != "" && project.Metadata.Owner != q.Owner {
+		return false
+	}
+
+	if q.License != "" && project.Metadata.License != q.License {
+		return false
+	}
+
+	if q.Fork != nil && project.Metadata.IsFork != *q.Fork {
+		return false
+	}
+
+	if q.Status != "" && project.Status != q.Status {
+		return false
+	}
+
+	if text := strings.ToLower(q.Text); text != "" &&
+		!strings.Contains(strings.ToLower(project.Reference), text) &&
+		!strings.Contains(strings.ToLower(project.Metadata.Description), text) {
+		return false
+	}
+
+	return true
+}
+
+// ProjectPage represents a page of projects, along with the number of projects matching the query.
+type ProjectPage struct {
+	Projects []Project
+	// Accuracy holds the accuracy of the latest analysis for each project on the page with insights, by project ID.
+	Accuracy map[uuid.UUID]float64
+	Total    int
+}
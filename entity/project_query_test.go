@@ -0,0 +1,47 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch_OnProjectQuery(t *testing.T) {
+	project := entity.Project{
+		Status:    "done",
+		Reference: "eroatta/src-reader",
+		Metadata: entity.Metadata{
+			Owner:       "eroatta",
+			Description: "Source code identifiers splitting and expansion",
+			License:     "mit",
+			IsFork:      false,
+		},
+	}
+	yes, no := true, false
+
+	cases := []struct {
+		name     string
+		query    entity.ProjectQuery
+		expected bool
+	}{
+		{name: "empty_query", query: entity.ProjectQuery{}, expected: true},
+		{name: "owner", query: entity.ProjectQuery{Owner: "eroatta"}, expected: true},
+		{name: "other_owner", query: entity.ProjectQuery{Owner: "golang"}, expected: false},
+		{name: "license", query: entity.ProjectQuery{License: "mit"}, expected: true},
+		{name: "other_license", query: entity.ProjectQuery{License: "apache-2.0"}, expected: false},
+		{name: "not_fork", query: entity.ProjectQuery{Fork: &no}, expected: true},
+		{name: "fork", query: entity.ProjectQuery{Fork: &yes}, expected: false},
+		{name: "status", query: entity.ProjectQuery{Status: "done"}, expected: true},
+		{name: "other_status", query: entity.ProjectQuery{Status: "in_progress"}, expected: false},
+		{name: "text_on_reference", query: entity.ProjectQuery{Text: "SRC-READER"}, expected: true},
+		{name: "text_on_description", query: entity.ProjectQuery{Text: "expansion"}, expected: true},
+		{name: "other_text", query: entity.ProjectQuery{Text: "parser"}, expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.query.Match(project))
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package entity
+
+// AnalysisLimits holds the quotas applied before analyzing a project, since each analysis keeps every parsed file
+// and mining result in memory. A zero value on any limit means the limit isn't applied.
+type AnalysisLimits struct {
+	// MaxConcurrent is the number of analyses running at the same time, on every workspace.
+	MaxConcurrent int
+	// MaxConcurrentPerWorkspace is the number of analyses running at the same time on a single workspace.
+	MaxConcurrentPerWorkspace int
+	// MaxRepositorySize is the size of the repository, in kilobytes, as reported by its metadata.
+	MaxRepositorySize int
+	// MaxFiles is the number of source code files on the repository.
+	MaxFiles int
+}
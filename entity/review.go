@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+)
+
+// Default values for a ReviewRequest.
+const (
+	DefaultReviewMaxScore    = 0.5
+	DefaultReviewMaxComments = 20
+)
+
+// ReviewRequest defines the pull request to review using the results of an analysis.
+type ReviewRequest struct {
+	PullRequest int
+	Commit      string
+	// Files limits the review to the files changed by the pull request. Every file is reviewed if empty.
+	Files []string
+	// MaxScore sets the normalization score below which an identifier is commented.
+	MaxScore float64
+	// MaxComments sets how many comments are posted at most, starting from the lowest scores.
+	MaxComments int
+}
+
+// Review represents the comments posted on a pull request.
+type Review struct {
+	ProjectRef  string
+	PullRequest int
+	Commit      string
+	Comments    []ReviewComment
+}
+
+// ReviewComment represents a suggestion to rename an identifier, posted on the line declaring it.
+type ReviewComment struct {
+	Path       string
+	Line       int
+	Identifier string
+	Suggestion string
+	Score      float64
+	// Original holds the content of the commented line.
+	Original string
+}
+
+// Body returns the message for the comment.
+func (c ReviewComment) Body() string {
+	return fmt.Sprintf("`%s` may be hard to read (score %.2f). Consider renaming it to `%s`.",
+		c.Identifier, c.Score, c.Suggestion)
+}
+
+// SuggestedLine returns the content of the commented line, renaming the identifier.
+func (c ReviewComment) SuggestedLine() string {
+	word := regexp.MustCompile(`\b` + regexp.QuoteMeta(c.Identifier) + `\b`)
+	replaced := false
+	return word.ReplaceAllStringFunc(c.Original, func(match string) string {
+		if replaced {
+			return match
+		}
+		replaced = true
+		return c.Suggestion
+	})
+}
+
+// Fingerprint identifies the comment across reviews, regardless of the line it's posted on, so the same
+// suggestion isn't posted twice.
+func (c ReviewComment) Fingerprint() string {
+	sum := sha1.Sum([]byte(c.Path + "\x00" + c.Identifier + "\x00" + c.Suggestion))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,49 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBody_OnReviewComment(t *testing.T) {
+	comment := entity.ReviewComment{Identifier: "readCfg", Suggestion: "readConfig", Score: 0.25}
+
+	assert.Equal(t, "`readCfg` may be hard to read (score 0.25). Consider renaming it to `readConfig`.", comment.Body())
+}
+
+func TestSuggestedLine_OnReviewComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		suggestion string
+		original   string
+		expected   string
+	}{
+		{"declaration", "readCfg", "readConfig", "func readCfg(cfg string) error {", "func readConfig(cfg string) error {"},
+		{"whole_words_only", "cfg", "config", "var cfgFile, cfg = 1, 2", "var cfgFile, config = 1, 2"},
+		{"first_occurrence", "cfg", "config", "var cfg = cfg2(cfg)", "var config = cfg2(cfg)"},
+		{"missing", "cfg", "config", "var another = 1", "var another = 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment := entity.ReviewComment{Identifier: tt.identifier, Suggestion: tt.suggestion, Original: tt.original}
+
+			assert.Equal(t, tt.expected, comment.SuggestedLine())
+		})
+	}
+}
+
+func TestFingerprint_OnReviewComment(t *testing.T) {
+	comment := entity.ReviewComment{Path: "main.go", Line: 10, Identifier: "cfg", Suggestion: "config"}
+	moved := comment
+	moved.Line = 20
+	another := comment
+	another.Suggestion = "configuration"
+
+	assert.Len(t, comment.Fingerprint(), 16)
+	assert.Equal(t, comment.Fingerprint(), moved.Fingerprint())
+	assert.NotEqual(t, comment.Fingerprint(), another.Fingerprint())
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"go/token"
+
+	"github.com/google/uuid"
+)
+
+// SearchQuery defines the criteria to search identifiers across every analyzed project.
+type SearchQuery struct {
+	// Text holds the words to look for. An identifier must match every word to be retrieved.
+	Text string
+	// ProjectRef restricts the search to the given project, if provided.
+	ProjectRef string
+	// Prefix matches the indexed words starting with each searched word.
+	Prefix bool
+	// Fuzzy matches the indexed words within a small edit distance from each searched word.
+	Fuzzy bool
+	Limit int
+}
+
+// SearchResult represents an identifier matching a SearchQuery, along with its relevance.
+type SearchResult struct {
+	AnalysisID    uuid.UUID
+	ProjectRef    string
+	IdentifierID  string
+	Name          string
+	Type          token.Token
+	Package       string
+	File          string
+	Position      token.Pos
+	Normalization string
+	Score         float64
+}
+
+// SearchDocument represents an identifier indexed for searching, along with the weight of each term extracted
+// from it.
+type SearchDocument struct {
+	// ID is given by the index storing the document.
+	ID     string
+	Result SearchResult
+	Terms  map[string]float64
+}
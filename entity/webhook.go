@@ -0,0 +1,34 @@
+package entity
+
+import "strings"
+
+// Git hosting providers able to notify pushes.
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+	ProviderGitea  = "gitea"
+)
+
+// PushEvent represents a push notified by a Git hosting provider, indicating the pushed repository,
+// the updated reference and the commit it points to.
+type PushEvent struct {
+	Provider   string
+	Repository string
+	CloneURL   string
+	Ref        string
+	Commit     string
+}
+
+// Branch returns the name of the pushed branch, or an empty string if the pushed reference isn't a branch.
+func (e PushEvent) Branch() string {
+	if !strings.HasPrefix(e.Ref, "refs/heads/") {
+		return ""
+	}
+
+	return strings.TrimPrefix(e.Ref, "refs/heads/")
+}
+
+// Deleted determines if the push removed the reference, in which case there's nothing to analyze.
+func (e PushEvent) Deleted() bool {
+	return strings.Trim(e.Commit, "0") == ""
+}
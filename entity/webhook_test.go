@@ -0,0 +1,33 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranch_OnPushEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{"branch", "refs/heads/master", "master"},
+		{"nested_branch", "refs/heads/feature/webhooks", "feature/webhooks"},
+		{"tag", "refs/tags/v1.0.0", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, entity.PushEvent{Ref: tt.ref}.Branch())
+		})
+	}
+}
+
+func TestDeleted_OnPushEvent(t *testing.T) {
+	assert.True(t, entity.PushEvent{Commit: "0000000000000000000000000000000000000000"}.Deleted())
+	assert.True(t, entity.PushEvent{}.Deleted())
+	assert.False(t, entity.PushEvent{Commit: "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"}.Deleted())
+}
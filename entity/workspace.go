@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceContextKey is the key holding the ID of the current workspace on a context. It's a plain string, so
+// it can be set on the context of the HTTP handlers.
+const WorkspaceContextKey = "workspace"
+
+// DefaultWorkspaceID identifies the workspace holding the elements created without a workspace, including the
+// ones created before workspaces were introduced.
+var DefaultWorkspaceID = uuid.Nil
+
+// Workspace represents a tenant, isolating its projects, analyses, identifiers, insights, dictionaries and
+// API keys from the ones on any other workspace.
+type Workspace struct {
+	ID   uuid.UUID
+	Name string
+	// WebhookSecret signs the push notifications sent for the projects on the workspace, so a notification is only
+	// handled on the workspace whose secret signed it.
+	WebhookSecret string
+	CreatedAt     time.Time
+}
+
+// WithWorkspace returns a copy of the context, where every element is created on and retrieved from the given
+// workspace.
+func WithWorkspace(ctx context.Context, workspaceID uuid.UUID) context.Context {
+	return context.WithValue(ctx, WorkspaceContextKey, workspaceID)
+}
+
+// WorkspaceFrom retrieves the ID of the workspace set on the context, or the default workspace if none was set.
+func WorkspaceFrom(ctx context.Context) uuid.UUID {
+	if workspaceID, ok := ctx.Value(WorkspaceContextKey).(uuid.UUID); ok {
+		return workspaceID
+	}
+
+	return DefaultWorkspaceID
+}
@@ -0,0 +1,22 @@
+package entity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceFrom_WhenNoWorkspace_ShouldReturnDefaultWorkspace(t *testing.T) {
+	assert.Equal(t, entity.DefaultWorkspaceID, entity.WorkspaceFrom(context.Background()))
+}
+
+func TestWorkspaceFrom_ShouldReturnWorkspace(t *testing.T) {
+	workspaceID := uuid.New()
+	ctx := entity.WithWorkspace(context.Background(), workspaceID)
+
+	assert.Equal(t, workspaceID, entity.WorkspaceFrom(ctx))
+	assert.Equal(t, workspaceID, entity.WorkspaceFrom(context.WithValue(ctx, "another", 1)))
+}
@@ -1,64 +1,142 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
 
+	"github.com/eroatta/src-reader/config"
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
 	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/expander"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/extractor"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/splitter"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/export"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/notifier"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/github"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/memory"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/mongodb"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/search"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/sqldb"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/telemetry"
+	"github.com/eroatta/src-reader/repository"
 	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 func main() {
-	// create MongoDB client
-	dbHost := os.Getenv("MONGODB_HOST")
-	dbUsername := os.Getenv("MONGODB_USER")
-	dbPassword := os.Getenv("MONGODB_PASSWORD")
-	dbName := os.Getenv("MONGODB_DATABASE")
-	clt, err := mongodb.NewMongoClient(fmt.Sprintf("mongodb://%s:%s@%s:27017/%s", dbUsername, dbPassword, dbHost, dbName))
-	if err != nil {
-		log.WithError(err).Fatal("Unable to start MongoDB client")
+	// run the requested command instead of the server
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			os.Exit(runExport(os.Args[2:]))
+		case "archive":
+			os.Exit(runArchive(os.Args[2:]))
+		case "restore":
+			os.Exit(runRestore(os.Args[2:]))
+		case "webhook":
+			os.Exit(runWebhook(os.Args[2:]))
+		}
 	}
 
-	database := "reader"
+	// read the configuration file on CONFIG_FILE, if any, overridden by the environment
+	cfg := loadConfig()
+	configureLogs(cfg.Log)
+	loadWords(cfg.Pipeline.WordsDir)
+	tracerProvider := newTracerProvider(cfg.Tracing)
+	analysisConfig := newAnalysisConfig(cfg)
 
-	// create repositories based on MongoDB
-	projectRepository := mongodb.NewMongoDBProjecRepository(clt, database)
-	analysisRepository := mongodb.NewMongoDBAnalysisRepository(clt, database)
-	identifierRepository := mongodb.NewMongoDBIdentifierRepository(clt, database)
-	insightRepository := mongodb.NewMongoDBInsightRepository(clt, database)
+	// create repositories based on the configured storage
+	repos := newRepositories(cfg.Storage)
 
 	// create repositories based on Github
-	githubToken := os.Getenv("GITHUB_TOKEN")
-	sourceCodeFolder := "/tmp/repositories/github.com"
-	remoteProjectRepository := github.NewRESTMetadataRepository(&http.Client{}, "https://api.github.com", githubToken)
-	sourceCodeRepository := github.NewGogitSourceCodeRepository(sourceCodeFolder, github.PlainClonerFunc)
+	remoteProjectRepository := github.NewRESTMetadataRepository(&http.Client{}, cfg.Source.GitHubAPIURL,
+		cfg.Source.GitHubToken)
+	sourceCodeRepository := github.NewGogitSourceCodeRepository(cfg.Source.CloneDir, github.PlainClonerFunc)
 
 	// create supported use cases
-	importProjectUsecase := usecase.NewCreateProjectUsecase(projectRepository, remoteProjectRepository, sourceCodeRepository)
-	analyzeProjectUsecase := usecase.NewAnalyzeProjectUsecase(projectRepository, sourceCodeRepository,
-		identifierRepository, analysisRepository, defaultAnalysisConfig)
-	gainInsightsUsecase := usecase.NewGainInsightsUsecase(identifierRepository, insightRepository)
-	getInsightsUsecase := usecase.NewGetInsightsUsecase(insightRepository)
-	deleteInsightsUsecase := usecase.NewDeleteInsightsUsecase(insightRepository)
-	deleteAnalysisUsecase := usecase.NewDeleteAnalysisUsecase(deleteInsightsUsecase, identifierRepository, analysisRepository)
-	deleteProjectUsecase := usecase.NewDeleteProjectUsecase(deleteAnalysisUsecase, analysisRepository,
-		sourceCodeRepository, projectRepository)
-	originalFileUsecase := usecase.NewOriginalFileUsecase(projectRepository, sourceCodeRepository)
-	rewrittenFileUsecase := usecase.NewRewrittenFileUsecase(projectRepository, sourceCodeRepository, identifierRepository)
-
-	// create REST API server and register use cases
+	importProjectUsecase := usecase.NewCreateProjectUsecase(repos.project, remoteProjectRepository, sourceCodeRepository,
+		cfg.Limits.MaxRepositorySizeKB)
+	getProjectUsecase := usecase.NewGetProjectUsecase(repos.project)
+	listProjectsUsecase := usecase.NewListProjectsUsecase(repos.project)
+	indexAnalysisUsecase := usecase.NewIndexAnalysisUsecase(repos.identifier, repos.search)
+	// every analysis is recorded while it runs, held by this instance, so the interrupted ones are recovered by any
+	// instance
+	owner := newOwner()
+	untrackedAnalyzeProjectUsecase := usecase.NewAnalyzeProjectUsecase(repos.project, sourceCodeRepository,
+		repos.identifier, repos.analysis, repos.dictionary, indexAnalysisUsecase, analysisConfig)
+	analyzeProjectUsecase := usecase.NewTrackedAnalyzeProjectUsecase(untrackedAnalyzeProjectUsecase, repos.job, owner)
+	gainInsightsUsecase := usecase.NewGainInsightsUsecase(repos.identifier, repos.insight)
+	getInsightsUsecase := usecase.NewGetInsightsUsecase(repos.insight)
+	deleteInsightsUsecase := usecase.NewDeleteInsightsUsecase(repos.insight)
+	deleteAnalysisUsecase := usecase.NewDeleteAnalysisUsecase(deleteInsightsUsecase, repos.identifier,
+		repos.search, repos.analysis)
+	deleteProjectUsecase := usecase.NewDeleteProjectUsecase(deleteAnalysisUsecase, repos.analysis,
+		sourceCodeRepository, repos.project)
+	originalFileUsecase := usecase.NewOriginalFileUsecase(repos.project, sourceCodeRepository)
+	rewrittenFileUsecase := usecase.NewRewrittenFileUsecase(repos.project, sourceCodeRepository, repos.identifier)
+	getFindingsUsecase := usecase.NewGetFindingsUsecase(repos.identifier)
+	queryIdentifiersUsecase := usecase.NewQueryIdentifiersUsecase(repos.identifier, repos.analysis)
+	searchIdentifiersUsecase := usecase.NewSearchIdentifiersUsecase(repos.search)
+	exportAnalysisUsecase := usecase.NewExportAnalysisUsecase(repos.identifier, repos.analysis,
+		export.NewIdentifierWriterFactory())
+	archiveAnalysisUsecase := usecase.NewArchiveAnalysisUsecase(repos.project, repos.analysis,
+		repos.identifier, repos.insight, export.NewJSONLArchiveFormat())
+	restoreAnalysisUsecase := usecase.NewRestoreAnalysisUsecase(repos.project, repos.analysis,
+		repos.identifier, repos.insight, indexAnalysisUsecase, export.NewJSONLArchiveFormat())
+	// analyses requested through the API are subject to quotas, while pushes are analyzed one at a time
+	limitedAnalyzeProjectUsecase := usecase.NewLimitedAnalyzeProjectUsecase(analyzeProjectUsecase, repos.project,
+		entity.AnalysisLimits{
+			MaxConcurrent:             cfg.Limits.MaxConcurrentAnalyses,
+			MaxConcurrentPerWorkspace: cfg.Limits.MaxConcurrentAnalysesPerWorkspace,
+			MaxRepositorySize:         cfg.Limits.MaxRepositorySizeKB,
+			MaxFiles:                  cfg.Limits.MaxRepositoryFiles,
+		})
+	reanalyzeProjectUsecase := usecase.NewReanalyzeProjectUsecase(repos.project, sourceCodeRepository,
+		repos.analysis, deleteAnalysisUsecase, analyzeProjectUsecase, gainInsightsUsecase)
+	handlePushUsecase := usecase.NewHandlePushUsecase(repos.project, reanalyzeProjectUsecase, repos.job, 100)
+	notifyReviewUsecase := usecase.NewNotifyReviewUsecase(repos.project, repos.analysis,
+		repos.identifier, sourceCodeRepository, newNotifier(cfg.Notifier, cfg.Source))
+	createDictionaryUsecase := usecase.NewCreateDictionaryUsecase(repos.dictionary)
+	listDictionariesUsecase := usecase.NewListDictionariesUsecase(repos.dictionary)
+	getDictionaryUsecase := usecase.NewGetDictionaryUsecase(repos.dictionary)
+	updateDictionaryUsecase := usecase.NewUpdateDictionaryUsecase(repos.dictionary)
+	deleteDictionaryUsecase := usecase.NewDeleteDictionaryUsecase(repos.dictionary)
+	createAPIKeyUsecase := usecase.NewCreateAPIKeyUsecase(repos.apiKey)
+	listAPIKeysUsecase := usecase.NewListAPIKeysUsecase(repos.apiKey)
+	deleteAPIKeyUsecase := usecase.NewDeleteAPIKeyUsecase(repos.apiKey)
+	createWorkspaceUsecase := usecase.NewCreateWorkspaceUsecase(repos.workspace, createAPIKeyUsecase)
+	listWorkspacesUsecase := usecase.NewListWorkspacesUsecase(repos.workspace)
+	listJobsUsecase := usecase.NewListJobsUsecase(repos.job)
+
+	// create REST API server and register use cases, requiring an API key on every route registered afterwards
+	if cfg.Auth.AdminAPIKey == "" {
+		log.Warn("ADMIN_API_KEY is not set, only the stored API keys will be accepted")
+	}
 	router := rest.NewServer()
+	metrics.Register()
+	rest.RegisterAuthenticateUsecase(router, usecase.NewAuthenticateUsecase(repos.apiKey, cfg.Auth.AdminAPIKey))
+	rest.RegisterRateLimit(router, cfg.Limits.RateLimitPerMinute, time.Minute)
 	rest.RegisterCreateProjectUsecase(router, importProjectUsecase)
-	rest.RegisterAnalyzeProjectUsecase(router, analyzeProjectUsecase)
+	rest.RegisterGetProjectUsecase(router, getProjectUsecase)
+	rest.RegisterListProjectsUsecase(router, listProjectsUsecase)
+	rest.RegisterAnalyzeProjectUsecase(router, limitedAnalyzeProjectUsecase)
 	rest.RegisterDeleteProjectUsecase(router, deleteProjectUsecase)
 	rest.RegisterDeleteAnalysisUsecase(router, deleteAnalysisUsecase)
 	rest.RegisterGainInsightsUsecase(router, gainInsightsUsecase)
@@ -66,30 +144,367 @@ func main() {
 	rest.RegisterDeleteInsightsUsecase(router, deleteInsightsUsecase)
 	rest.RegisterOriginalFileUsecase(router, originalFileUsecase)
 	rest.RegisterRewrittenFileUsecase(router, rewrittenFileUsecase)
+	rest.RegisterGetFindingsUsecase(router, getFindingsUsecase)
+	rest.RegisterQueryIdentifiersUsecase(router, queryIdentifiersUsecase)
+	rest.RegisterSearchIdentifiersUsecase(router, searchIdentifiersUsecase)
+	rest.RegisterExportAnalysisUsecase(router, exportAnalysisUsecase)
+	rest.RegisterArchiveAnalysisUsecase(router, archiveAnalysisUsecase)
+	rest.RegisterRestoreAnalysisUsecase(router, restoreAnalysisUsecase)
+	rest.RegisterNotifyReviewUsecase(router, notifyReviewUsecase)
+	rest.RegisterCreateDictionaryUsecase(router, createDictionaryUsecase)
+	rest.RegisterListDictionariesUsecase(router, listDictionariesUsecase)
+	rest.RegisterGetDictionaryUsecase(router, getDictionaryUsecase)
+	rest.RegisterUpdateDictionaryUsecase(router, updateDictionaryUsecase)
+	rest.RegisterDeleteDictionaryUsecase(router, deleteDictionaryUsecase)
+	rest.RegisterCreateAPIKeyUsecase(router, createAPIKeyUsecase)
+	rest.RegisterListAPIKeysUsecase(router, listAPIKeysUsecase)
+	rest.RegisterDeleteAPIKeyUsecase(router, deleteAPIKeyUsecase)
+	rest.RegisterCreateWorkspaceUsecase(router, createWorkspaceUsecase)
+	rest.RegisterListWorkspacesUsecase(router, listWorkspacesUsecase)
+	rest.RegisterListJobsUsecase(router, listJobsUsecase)
+	rest.RegisterGetConfig(router, cfg)
+
+	// analyze the projects again when new commits are pushed to their repositories, verifying each notification with
+	// the secret of its workspace
+	if cfg.Auth.WebhookSecret == "" {
+		log.Warn("WEBHOOK_SECRET is not set, every push notification for the default workspace will be rejected")
+	}
+	rest.RegisterHandlePushUsecase(router, handlePushUsecase,
+		usecase.NewGetWebhookSecretUsecase(repos.workspace, cfg.Auth.WebhookSecret))
+	workCtx, stopWork := context.WithCancel(context.Background())
+	workDone := make(chan struct{})
+	go func() {
+		handlePushUsecase.Work(workCtx)
+		close(workDone)
+	}()
+
+	// periodically resume or fail the analyses whose owner stopped refreshing them, and complete or discard the
+	// analyses interrupted while storing their identifiers
+	cleanupAnalysesUsecase := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecase, repos.identifier, repos.analysis,
+		repos.job, repos.workspace)
+	recoverAnalysesUsecase := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecase, untrackedAnalyzeProjectUsecase,
+		reanalyzeProjectUsecase, repos.job, repos.workspace, owner)
+	go recoverAnalyses(workCtx, recoverAnalysesUsecase, usecase.JobLease)
+
+	// start the server, until a termination signal is received
+	server := &http.Server{Addr: cfg.Server.Address, Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Fatalf("Unable to listen on %s", cfg.Server.Address)
+		}
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	log.Infof("Shutting down on %v", <-signals)
+	shutdown(server, stopWork, workDone, tracerProvider, time.Duration(cfg.Server.DrainTimeoutSeconds)*time.Second)
+}
+
+// shutdown stops accepting requests and waits up to the drain timeout for the requests and the push analysis in
+// progress, while the pending push analyses are recorded as jobs and the recovery of analyses stops. Analyses still
+// running after the timeout are no longer refreshed, so they're recovered by any instance once their lease expires.
+// The buffered spans are exported last.
+func shutdown(server *http.Server, stopWork context.CancelFunc, workDone <-chan struct{},
+	tracerProvider *sdktrace.TracerProvider, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stopWork()
+	if err := server.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("Requests still in progress after the drain timeout")
+	}
+
+	select {
+	case <-workDone:
+		log.Info("Server stopped")
+	case <-ctx.Done():
+		log.Warn("Push analysis still in progress after the drain timeout, it will be recovered once its lease expires")
+	}
+
+	// export the spans still buffered
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+	if err := tracerProvider.Shutdown(flushCtx); err != nil {
+		log.WithError(err).Warn("Unable to export the remaining spans")
+	}
+}
+
+// repositories holds the repositories created for the configured storage.
+type repositories struct {
+	project    repository.ProjectRepository
+	analysis   repository.AnalysisRepository
+	identifier repository.IdentifierRepository
+	insight    repository.InsightRepository
+	dictionary repository.DictionaryRepository
+	apiKey     repository.APIKeyRepository
+	workspace  repository.WorkspaceRepository
+	job        repository.JobRepository
+	search     repository.SearchRepository
+}
+
+// newRepositories creates the repositories for the configured storage. Supported backends are "mongodb", which is
+// the default, "sqlite" and "postgres", which store every element on a SQL database, and "memory", which keeps every
+// element in memory and doesn't require any database.
+func newRepositories(storage config.Storage) repositories {
+	switch storage.Backend {
+	case "memory":
+		log.Warn("Using in memory storage, every element will be lost on shutdown")
+		insightRepository := memory.NewInMemoryInsightRepository()
+		return repositories{
+			project:    memory.NewInMemoryProjectRepositoryWithInsights(insightRepository),
+			analysis:   memory.NewInMemoryAnalysisRepository(),
+			identifier: memory.NewInMemoryIdentifierRepository(),
+			insight:    insightRepository,
+			dictionary: memory.NewInMemoryDictionaryRepository(),
+			apiKey:     memory.NewInMemoryAPIKeyRepository(),
+			workspace:  memory.NewInMemoryWorkspaceRepository(),
+			job:        memory.NewInMemoryJobRepository(),
+			search:     search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository()),
+		}
+	case "sqlite":
+		return newSQLRepositories(sqldb.DriverSQLite,
+			fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL", storage.SQLitePath))
+	case "postgres":
+		return newSQLRepositories(sqldb.DriverPostgres, storage.PostgresURL)
+	}
+
+	// create MongoDB client
+	clt, err := mongodb.NewMongoClient(storage.Mongo.ConnectionURI())
+	if err != nil {
+		log.WithError(err).Fatal("Unable to start MongoDB client")
+	}
+
+	database := storage.Mongo.Database
+
+	// create repositories based on MongoDB
+	return repositories{
+		project:    mongodb.NewMongoDBProjecRepository(clt, database),
+		analysis:   mongodb.NewMongoDBAnalysisRepository(clt, database),
+		identifier: mongodb.NewMongoDBIdentifierRepository(clt, database),
+		insight:    mongodb.NewMongoDBInsightRepository(clt, database),
+		dictionary: mongodb.NewMongoDBDictionaryRepository(clt, database),
+		apiKey:     mongodb.NewMongoDBAPIKeyRepository(clt, database),
+		workspace:  mongodb.NewMongoDBWorkspaceRepository(clt, database),
+		job:        mongodb.NewMongoDBJobRepository(clt, database),
+		search:     search.NewInvertedIndexRepository(mongodb.NewMongoDBSearchIndexRepository(clt, database)),
+	}
+}
+
+// newSQLRepositories creates the repositories based on the SQL database referenced by the data source name,
+// applying any pending schema migration.
+func newSQLRepositories(driver string, dsn string) repositories {
+	db, err := sqldb.NewSQLClient(driver, dsn)
+	if err != nil {
+		log.WithError(err).Fatalf("Unable to start %s client", driver)
+	}
+
+	return repositories{
+		project:    sqldb.NewSQLProjectRepository(db),
+		analysis:   sqldb.NewSQLAnalysisRepository(db),
+		identifier: sqldb.NewSQLIdentifierRepository(db),
+		insight:    sqldb.NewSQLInsightRepository(db),
+		dictionary: sqldb.NewSQLDictionaryRepository(db),
+		apiKey:     sqldb.NewSQLAPIKeyRepository(db),
+		workspace:  sqldb.NewSQLWorkspaceRepository(db),
+		job:        sqldb.NewSQLJobRepository(db),
+		search:     search.NewInvertedIndexRepository(sqldb.NewSQLSearchIndexRepository(db)),
+	}
+}
+
+// newNotifier creates the destination for the review comments. Supported destinations are "file", which is the
+// default and appends every review to a local file, "github", which posts them on the pull request, and "webhook",
+// which sends them to the configured URL, recording them on a local file. Every destination accepts up to 30 comments
+// per minute, deferring the rest.
+func newNotifier(cfg config.Notifier, source config.Source) repository.Notifier {
+	var n repository.Notifier
+	switch cfg.Destination {
+	case "github":
+		n = notifier.NewGitHubReviewNotifier(&http.Client{}, source.GitHubAPIURL, source.GitHubToken)
+	case "webhook":
+		n = notifier.NewWebhookNotifier(&http.Client{}, cfg.WebhookURL, cfg.SentFilePath)
+	default:
+		n = notifier.NewFileNotifier(cfg.FilePath)
+	}
+
+	return notifier.NewRateLimitedNotifier(n, 30, time.Minute)
+}
+
+// loadConfig reads the configuration file referenced by CONFIG_FILE, if any, applying the overrides found on the
+// environment. The process exits if the configuration is invalid.
+func loadConfig() config.Config {
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"), os.LookupEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return cfg
+}
+
+// loadWords extends the word lists of the supported languages with the ones on the given directory, if any.
+func loadWords(dir string) {
+	if dir == "" {
+		return
+	}
+
+	if err := language.LoadWords(dir); err != nil {
+		log.WithError(err).Fatalf("Unable to load the word lists from %s", dir)
+	}
+}
 
-	// start the server
-	router.Run()
+// newTracerProvider creates the tracer provider exporting the spans to the configured destination, and sets it as
+// the global one. Without an exporter, no span is sampled.
+func newTracerProvider(cfg config.Tracing) *sdktrace.TracerProvider {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "src-reader"))),
+	}
+	switch cfg.Exporter {
+	case "otlp":
+		exporter, err := telemetry.NewOTLPExporter(context.Background(), cfg.OTLPProtocol, cfg.OTLPEndpoint)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to create the trace exporter")
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case "stdout":
+		exporter, err := telemetry.NewStdoutExporter()
+		if err != nil {
+			log.WithError(err).Fatal("Unable to create the trace exporter")
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case "file":
+		exporter, err := telemetry.NewFileExporter(cfg.FilePath)
+		if err != nil {
+			log.WithError(err).Fatalf("Unable to open the trace file %s", cfg.FilePath)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		options = append(options, sdktrace.WithSampler(sdktrace.NeverSample()))
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider
 }
 
-var defaultAnalysisConfig = &entity.AnalysisConfig{
-	Miners: []string{
-		"wordcount",
-		"scoped-declarations",
-		"comments",
-		"declarations",
-		"global-frequency-table",
-	},
-	MinerAlgorithmFactory: miner.NewMinerFactory(),
-	ExtractorFactory:      extractor.New,
-	Splitters: []string{
-		"conserv",
-		"greedy",
-		"samurai",
-	},
-	SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
-	Expanders: []string{
-		"noexp",
-		"basic",
-		"amap"},
-	ExpansionAlgorithmFactory: expander.NewExpanderFactory(),
+// configureLogs sets the level and format of the logs. Both are validated along with the configuration.
+func configureLogs(cfg config.Log) {
+	level, _ := log.ParseLevel(cfg.Level)
+	log.SetLevel(level)
+	if cfg.Format == "json" {
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+}
+
+// newOwner identifies this server instance as the owner of the analyses it runs, by its host name and a random
+// suffix, so instances sharing a host are told apart.
+func newOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "src-reader"
+	}
+
+	return fmt.Sprintf("%s-%s", hostname, uuid.New().String()[:8])
+}
+
+// recoverAnalyses periodically resumes or fails the analyses not refreshed by their owner within the lease, which
+// were interrupted by a shutdown or a crash of any instance. Their staged identifiers, along with the ones of the
+// analyses interrupted while storing them, are committed or discarded first. It stops once the context is done.
+func recoverAnalyses(ctx context.Context, uc usecase.RecoverAnalysesUsecase, lease time.Duration) {
+	for {
+		resumed, failed, err := uc.Process(ctx, time.Now().Add(-lease))
+		if err != nil {
+			log.WithError(err).Warn("unable to recover interrupted analyses")
+		} else if resumed+failed > 0 {
+			log.Infof("interrupted analyses recovered: %d resumed, %d failed", resumed, failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(lease):
+		}
+	}
+}
+
+// newStageConfig creates the concurrency settings of a pipeline stage, running one worker per CPU unless the number
+// of workers is configured.
+func newStageConfig(stage config.Stage) entity.StageConfig {
+	workers := stage.Workers
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+
+	return entity.StageConfig{Workers: workers, Buffer: stage.Buffer}
+}
+
+// newRuleSeverities maps the configured severity of each rule. Rules without a severity keep their default one.
+func newRuleSeverities(severities map[string]string) map[string]entity.Severity {
+	ruleSeverities := make(map[string]entity.Severity, len(severities))
+	for rule, severity := range severities {
+		ruleSeverities[rule] = entity.Severity(severity)
+	}
+
+	return ruleSeverities
+}
+
+// newAnalysisConfig creates the configuration applied on every analysis, using the configured pipeline and guards.
+// The process exits if the pipeline names an unknown algorithm or rule.
+func newAnalysisConfig(cfg config.Config) *entity.AnalysisConfig {
+	analysisConfig := &entity.AnalysisConfig{
+		Miners:                    cfg.Pipeline.Miners,
+		MinerAlgorithmFactory:     miner.NewMinerFactory(),
+		LanguageDetector:          language.Detect,
+		ExtractorFactory:          extractor.New,
+		Splitters:                 cfg.Pipeline.Splitters,
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 cfg.Pipeline.Expanders,
+		ExpansionAlgorithmFactory: expander.NewExpanderFactory(),
+		DictionaryExpanderFactory: expander.NewDictionaryExpander,
+		Rules:                     cfg.Pipeline.Rules,
+		RuleFactory:               linter.NewRuleFactory(),
+		RuleSeverities:            newRuleSeverities(cfg.Pipeline.RuleSeverities),
+		Stages: map[string]entity.StageConfig{
+			entity.StageRead:      newStageConfig(cfg.Pipeline.Stages.Read),
+			entity.StageParse:     newStageConfig(cfg.Pipeline.Stages.Parse),
+			entity.StageSplit:     newStageConfig(cfg.Pipeline.Stages.Split),
+			entity.StageExpand:    newStageConfig(cfg.Pipeline.Stages.Expand),
+			entity.StageNormalize: newStageConfig(cfg.Pipeline.Stages.Normalize),
+		},
+		// skip huge, generated and vendored files while reading the source code
+		Guards: entity.FileGuards{
+			MaxFileSize:   int64(cfg.Limits.MaxFileSizeKB) * 1024,
+			MaxFiles:      cfg.Limits.MaxAnalyzedFiles,
+			MaxTotalBytes: int64(cfg.Limits.MaxAnalyzedSizeMB) * 1024 * 1024,
+			SkipDirs:      []string{"vendor", "testdata"},
+			SkipGenerated: true,
+		},
+		IncludeTests: cfg.Pipeline.IncludeTests,
+	}
+
+	problems := make([]string, 0)
+	for _, name := range analysisConfig.Miners {
+		if _, err := analysisConfig.MinerAlgorithmFactory.Get(name); err != nil {
+			problems = append(problems, fmt.Sprintf("pipeline.miners: unknown miner %q", name))
+		}
+	}
+	for _, name := range analysisConfig.Splitters {
+		if _, err := analysisConfig.SplittingAlgorithmFactory.Get(name); err != nil {
+			problems = append(problems, fmt.Sprintf("pipeline.splitters: unknown splitter %q", name))
+		}
+	}
+	for _, name := range analysisConfig.Expanders {
+		if _, err := analysisConfig.ExpansionAlgorithmFactory.Get(name); err != nil {
+			problems = append(problems, fmt.Sprintf("pipeline.expanders: unknown expander %q", name))
+		}
+	}
+	for _, name := range analysisConfig.Rules {
+		if _, err := analysisConfig.RuleFactory.Get(name); err != nil {
+			problems = append(problems, fmt.Sprintf("pipeline.rules: unknown rule %q", name))
+		}
+	}
+	if len(problems) > 0 {
+		log.Fatal(config.ValidationError{Problems: problems})
+	}
+
+	return analysisConfig
 }
@@ -0,0 +1,115 @@
+// Package metrics holds the Prometheus collectors measuring the analyses, from cloning the repositories to
+// processing each identifier. They are served on /metrics, next to the golden signals, once registered.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// Results of an analysis or a clone, used to label their durations.
+const (
+	ResultSuccess  = "success"
+	ResultError    = "error"
+	ResultCanceled = "canceled"
+)
+
+var (
+	// AnalysisDuration observes the time spent on each analysis, by result.
+	AnalysisDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "analysis_duration_seconds",
+			Help:    "A histogram of the time spent on each analysis, by result",
+			Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800},
+		},
+		[]string{"result"},
+	)
+
+	// StageDuration observes the time spent on each stage of the pipeline, from its start until its last element
+	// is sent.
+	StageDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "analysis_stage_duration_seconds",
+			Help:    "A histogram of the time spent on each stage of the analysis pipeline",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		},
+		[]string{"stage"},
+	)
+
+	// AnalyzedFiles counts the files found by the analyses, either parsed, failed or skipped.
+	AnalyzedFiles = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "analyzed_files",
+			Help: "A counter for the files found by the analyses, by status",
+		},
+		[]string{"status"},
+	)
+
+	// AnalyzedIdentifiers counts the identifiers processed by the analyses, either valid or with errors.
+	AnalyzedIdentifiers = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "analyzed_identifiers",
+			Help: "A counter for the identifiers processed by the analyses, by status",
+		},
+		[]string{"status"},
+	)
+
+	// SplitLatency observes the time each splitter takes on an identifier.
+	SplitLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "split_latency_seconds",
+			Help:    "A histogram of the time each splitter takes to split an identifier",
+			Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+		},
+		[]string{"splitter"},
+	)
+
+	// ExpansionLatency observes the time each expander takes on an identifier.
+	ExpansionLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "expansion_latency_seconds",
+			Help:    "A histogram of the time each expander takes to expand an identifier",
+			Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+		},
+		[]string{"expander"},
+	)
+
+	// CloneDuration observes the time spent cloning each repository, by result.
+	CloneDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "clone_duration_seconds",
+			Help:    "A histogram of the time spent cloning each repository, by result",
+			Buckets: []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		},
+		[]string{"result"},
+	)
+
+	// CloneSize observes the size on disk of each cloned repository, including its history.
+	CloneSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "clone_size_bytes",
+			Help:    "A histogram of the size on disk of each cloned repository",
+			Buckets: prometheus.ExponentialBuckets(1<<20, 4, 8),
+		},
+	)
+
+	// PushQueueDepth holds the number of re-analyses waiting to be run after a push.
+	PushQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "push_queue_depth",
+			Help: "A gauge for the re-analyses triggered by pushes and waiting to be run",
+		},
+	)
+)
+
+// Register registers every collector on the default registry, so they are served on /metrics.
+func Register() {
+	collectors := []prometheus.Collector{AnalysisDuration, StageDuration, AnalyzedFiles, AnalyzedIdentifiers,
+		SplitLatency, ExpansionLatency, CloneDuration, CloneSize, PushQueueDepth}
+	for _, coll := range collectors {
+		err := prometheus.Register(coll)
+		if err != nil {
+			log.WithError(err).Warn("unable to setup metric collector")
+		}
+	}
+}
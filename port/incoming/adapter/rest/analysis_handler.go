@@ -1,10 +1,12 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -12,19 +14,23 @@ import (
 )
 
 type createAnalysisCommand struct {
-	ProjectID string `json:"project_id" validate:"uuid"`
+	ProjectID    string `json:"project_id" validate:"uuid"`
+	IncludeTests bool   `json:"include_tests"`
 }
 
 type analysisResponse struct {
-	ID          string          `json:"id"`
-	CreatedAt   time.Time       `json:"created_at"`
-	ProjectRef  string          `json:"project_ref"`
-	ProjectID   string          `json:"project_id"`
-	Miners      []string        `json:"miners"`
-	Splitters   []string        `json:"splitters"`
-	Expanders   []string        `json:"expanders"`
-	Files       summaryResponse `json:"files_summary"`
-	Identifiers summaryResponse `json:"identifiers_summary"`
+	ID         string    `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	ProjectRef string    `json:"project_ref"`
+	ProjectID  string    `json:"project_id"`
+	Miners     []string  `json:"miners"`
+	Splitters  []string  `json:"splitters"`
+	Expanders  []string  `json:"expanders"`
+	Rules      []string  `json:"rules,omitempty"`
+	// IncludeTests is only reported for the analyses that read the test files.
+	IncludeTests bool            `json:"include_tests,omitempty"`
+	Files        summaryResponse `json:"files_summary"`
+	Identifiers  summaryResponse `json:"identifiers_summary"`
 }
 
 type summaryResponse struct {
@@ -32,6 +38,8 @@ type summaryResponse struct {
 	Valid        int      `json:"valid"`
 	Failed       int      `json:"failed"`
 	ErrorSamples []string `json:"error_samples"`
+	// Skipped is only reported for the files left out by the file guards or the project filter.
+	Skipped int `json:"skipped,omitempty"`
 }
 
 // RegisterAnalyzeProjectUsecase defines the proper URI and HTTP method to execute
@@ -59,10 +67,28 @@ func createAnalysis(ctx *gin.Context, uc usecase.AnalyzeProjectUsecase) {
 		return
 	}
 
-	analysis, err := uc.Process(ctx, uuid.MustParse(cmd.ProjectID))
+	analysesInProgress.Inc()
+	var analysis entity.AnalysisResults
+	var err error
+	if cmd.IncludeTests {
+		// the configured pipeline is kept, reading the test files too
+		analysis, err = uc.ProcessWithPipeline(ctx, uuid.MustParse(cmd.ProjectID), entity.Pipeline{IncludeTests: true})
+	} else {
+		analysis, err = uc.Process(ctx, uuid.MustParse(cmd.ProjectID))
+	}
+	analysesInProgress.Dec()
 	switch err {
 	case nil:
 		// do nothing
+	case usecase.ErrTooManyAnalyses:
+		rejections.WithLabelValues(rejectedByConcurrency, principal(ctx)).Inc()
+		setTooManyRequestsResponse(ctx, errors.New("too many analyses in progress, try again later"))
+		return
+	case usecase.ErrProjectTooLarge:
+		rejections.WithLabelValues(rejectedBySize, principal(ctx)).Inc()
+		setPayloadTooLargeResponse(ctx, fmt.Errorf("project with ID: %s exceeds the accepted size or number of files",
+			cmd.ProjectID))
+		return
 	case usecase.ErrProjectNotFound:
 		setBadRequestResponse(ctx, fmt.Errorf("project with ID: %s can't be found", cmd.ProjectID))
 		return
@@ -74,19 +100,26 @@ func createAnalysis(ctx *gin.Context, uc usecase.AnalyzeProjectUsecase) {
 		return
 	}
 
-	response := analysisResponse{
-		ID:         analysis.ID.String(),
-		CreatedAt:  analysis.DateCreated,
-		ProjectRef: analysis.ProjectName,
-		ProjectID:  analysis.ProjectID.String(),
-		Miners:     analysis.PipelineMiners,
-		Splitters:  analysis.PipelineSplitters,
-		Expanders:  analysis.PipelineExpanders,
+	ctx.JSON(http.StatusCreated, newAnalysisResponse(analysis))
+}
+
+func newAnalysisResponse(analysis entity.AnalysisResults) analysisResponse {
+	return analysisResponse{
+		ID:           analysis.ID.String(),
+		CreatedAt:    analysis.DateCreated,
+		ProjectRef:   analysis.ProjectName,
+		ProjectID:    analysis.ProjectID.String(),
+		Miners:       analysis.PipelineMiners,
+		Splitters:    analysis.PipelineSplitters,
+		Expanders:    analysis.PipelineExpanders,
+		Rules:        analysis.PipelineRules,
+		IncludeTests: analysis.IncludeTests,
 		Files: summaryResponse{
 			Total:        analysis.FilesTotal,
 			Valid:        analysis.FilesValid,
 			Failed:       analysis.FilesError,
 			ErrorSamples: analysis.FilesErrorSamples,
+			Skipped:      analysis.FilesSkipped,
 		},
 		Identifiers: summaryResponse{
 			Total:        analysis.IdentifiersTotal,
@@ -95,7 +128,6 @@ func createAnalysis(ctx *gin.Context, uc usecase.AnalyzeProjectUsecase) {
 			ErrorSamples: analysis.IdentifiersErrorSamples,
 		},
 	}
-	ctx.JSON(http.StatusCreated, response)
 }
 
 // RegisterDeleteAnalysisUsecase defines the proper URI and HTTP method to execute the
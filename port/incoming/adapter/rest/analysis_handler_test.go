@@ -172,6 +172,58 @@ func TestPOST_OnAnalysisCreationHandler_WithAlreadyExistingAnalysis_ShouldReturn
 		w.Body.String())
 }
 
+func TestPOST_OnAnalysisCreationHandler_WithTooManyAnalyses_ShouldReturnHTTP429(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterAnalyzeProjectUsecase(router, mockAnalyzeUsecase{
+		a:   entity.AnalysisResults{},
+		err: usecase.ErrTooManyAnalyses,
+	})
+
+	w := httptest.NewRecorder()
+	body := `{
+		"project_id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	}`
+	req, _ := http.NewRequest("POST", "/analysis", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "too_many_requests",
+			"message": "rate limit or quota exceeded",
+			"details": [
+				"too many analyses in progress, try again later"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnAnalysisCreationHandler_WithProjectTooLarge_ShouldReturnHTTP413(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterAnalyzeProjectUsecase(router, mockAnalyzeUsecase{
+		a:   entity.AnalysisResults{},
+		err: usecase.ErrProjectTooLarge,
+	})
+
+	w := httptest.NewRecorder()
+	body := `{
+		"project_id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	}`
+	req, _ := http.NewRequest("POST", "/analysis", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "payload_too_large",
+			"message": "the requested element exceeds the accepted size",
+			"details": [
+				"project with ID: 6ba7b810-9dad-11d1-80b4-00c04fd430c8 exceeds the accepted size or number of files"
+			]
+		}`,
+		w.Body.String())
+}
+
 func TestPOST_OnAnalysisCreationHandler_WithInternalError_ShouldReturnHTTP500(t *testing.T) {
 	router := rest.NewServer()
 	rest.RegisterAnalyzeProjectUsecase(router, mockAnalyzeUsecase{
@@ -268,6 +320,64 @@ func TestPOST_OnAnalysisCreationHandler_WithSuccess_ShouldReturnHTTP201(t *testi
 		w.Body.String())
 }
 
+func TestPOST_OnAnalysisCreationHandler_WithIncludedTests_ShouldReturnHTTP201(t *testing.T) {
+	now := time.Date(2020, time.May, 5, 22, 0, 0, 0, time.UTC)
+	var pipeline entity.Pipeline
+	router := rest.NewServer()
+	rest.RegisterAnalyzeProjectUsecase(router, mockAnalyzeUsecase{
+		a: entity.AnalysisResults{
+			ID:                uuid.MustParse("f17e675d-7823-4510-a04b-86e8c1f239ea"),
+			ProjectName:       "src-d/go-siva",
+			ProjectID:         uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+			DateCreated:       now,
+			PipelineMiners:    []string{"miner_1"},
+			PipelineSplitters: []string{"splitter_1"},
+			PipelineExpanders: []string{"expander_1"},
+			IncludeTests:      true,
+			FilesTotal:        2,
+			FilesValid:        2,
+			IdentifiersTotal:  12,
+			IdentifiersValid:  12,
+		},
+		pipeline: &pipeline,
+	})
+
+	w := httptest.NewRecorder()
+	body := `{
+		"project_id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"include_tests": true
+	}`
+	req, _ := http.NewRequest("POST", "/analysis", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, entity.Pipeline{IncludeTests: true}, pipeline)
+	assert.JSONEq(t, `
+		{
+			"id": "f17e675d-7823-4510-a04b-86e8c1f239ea",
+			"created_at": "2020-05-05T22:00:00Z",
+			"project_ref": "src-d/go-siva",
+			"project_id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			"miners": ["miner_1"],
+			"splitters": ["splitter_1"],
+			"expanders": ["expander_1"],
+			"include_tests": true,
+			"files_summary": {
+				"total": 2,
+				"valid": 2,
+				"failed": 0,
+				"error_samples": null
+			},
+			"identifiers_summary": {
+				"total": 12,
+				"valid": 12,
+				"failed": 0,
+				"error_samples": null
+			}
+		}`,
+		w.Body.String())
+}
+
 func TestDELETE_OnAnalysisDeleteHandler_WhenInvalidAnalysisID_ShouldReturn404(t *testing.T) {
 	router := rest.NewServer()
 	rest.RegisterDeleteAnalysisUsecase(router, nil)
@@ -336,12 +446,22 @@ func TestDELETE_OnAnalysisDeleteHandler_WhenAlreadyDeletedAnalysis_ShouldReturn2
 type mockAnalyzeUsecase struct {
 	a   entity.AnalysisResults
 	err error
+	// pipeline, if provided, records the pipeline given to the analysis.
+	pipeline *entity.Pipeline
 }
 
 func (m mockAnalyzeUsecase) Process(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
 	return m.a, m.err
 }
 
+func (m mockAnalyzeUsecase) ProcessWithPipeline(ctx context.Context, projectID uuid.UUID,
+	pipeline entity.Pipeline) (entity.AnalysisResults, error) {
+	if m.pipeline != nil {
+		*m.pipeline = pipeline
+	}
+	return m.a, m.err
+}
+
 type mockDeleteAnalysisUsecase struct {
 	err error
 }
@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type postCreateAPIKeyCommand struct {
+	Name   string   `json:"name" validate:"required,max=64"`
+	Scopes []string `json:"scopes" validate:"required,min=1"`
+}
+
+type apiKeyResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Prefix    string    `json:"prefix"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	// Key is only included when the key is created.
+	Key string `json:"key,omitempty"`
+}
+
+type apiKeysResponse struct {
+	Total int              `json:"total"`
+	Keys  []apiKeyResponse `json:"keys"`
+}
+
+// RegisterCreateAPIKeyUsecase defines the proper URI and HTTP method to execute the CreateAPIKeyUsecase.
+func RegisterCreateAPIKeyUsecase(r *gin.Engine, uc usecase.CreateAPIKeyUsecase) *gin.Engine {
+	r.POST("/admin/keys", func(c *gin.Context) {
+		createAPIKey(c, uc)
+	})
+
+	return r
+}
+
+func createAPIKey(ctx *gin.Context, uc usecase.CreateAPIKeyUsecase) {
+	var cmd postCreateAPIKeyCommand
+	if err := ctx.ShouldBindJSON(&cmd); err != nil {
+		log.WithError(err).Debug("failed to bind JSON body")
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	if err := requestValidator.Struct(cmd); err != nil {
+		log.WithError(err).Debug("failed while validating the command")
+		setBadRequestOnValidationResponse(ctx, err)
+		return
+	}
+
+	key, plain, err := uc.Process(ctx, cmd.Name, cmd.Scopes)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrInvalidAPIKeyScope:
+		setBadRequestResponse(ctx, fmt.Errorf("scopes must be any of: %s, %s, %s",
+			entity.ScopeRead, entity.ScopeAnalyze, entity.ScopeAdmin))
+		return
+	case usecase.ErrPreviousAPIKeyFound:
+		setBadRequestResponse(ctx, fmt.Errorf("previous API key named %s exists", cmd.Name))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing createAPIKeyUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error creating API key"))
+		return
+	}
+
+	response := toAPIKeyResponse(key)
+	response.Key = plain
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// RegisterListAPIKeysUsecase defines the proper URI and HTTP method to execute the ListAPIKeysUsecase.
+func RegisterListAPIKeysUsecase(r *gin.Engine, uc usecase.ListAPIKeysUsecase) *gin.Engine {
+	r.GET("/admin/keys", func(c *gin.Context) {
+		listAPIKeys(c, uc)
+	})
+
+	return r
+}
+
+func listAPIKeys(ctx *gin.Context, uc usecase.ListAPIKeysUsecase) {
+	keys, err := uc.Process(ctx)
+	if err != nil {
+		log.WithError(err).Error("unexpected error executing listAPIKeysUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error accessing API keys"))
+		return
+	}
+
+	response := apiKeysResponse{
+		Total: len(keys),
+		Keys:  make([]apiKeyResponse, len(keys)),
+	}
+	for i, key := range keys {
+		response.Keys[i] = toAPIKeyResponse(key)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RegisterDeleteAPIKeyUsecase defines the proper URI and HTTP method to execute the DeleteAPIKeyUsecase.
+func RegisterDeleteAPIKeyUsecase(r *gin.Engine, uc usecase.DeleteAPIKeyUsecase) *gin.Engine {
+	r.DELETE("/admin/keys/:id", func(c *gin.Context) {
+		deleteAPIKey(c, uc)
+	})
+
+	return r
+}
+
+func deleteAPIKey(ctx *gin.Context, uc usecase.DeleteAPIKeyUsecase) {
+	ID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("API key with ID: %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	err = uc.Process(ctx, ID)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrAPIKeyNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("API key with ID: %v can't be found", ID))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing deleteAPIKeyUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error deleting API key with ID: %v", ID))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func toAPIKeyResponse(key entity.APIKey) apiKeyResponse {
+	return apiKeyResponse{
+		ID:        key.ID.String(),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPOST_OnCreateAPIKeyHandler_WhenInvalidBody_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateAPIKeyUsecase(router, &mockCreateAPIKeyUsecase{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/keys", strings.NewReader(`{"name": "", "scopes": ["read"]}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": ["invalid field 'name' with value null or empty"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnCreateAPIKeyHandler_WhenUsecaseRejectsKey_ShouldReturn400(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"invalid_scope", usecase.ErrInvalidAPIKeyScope, "scopes must be any of: read, analyze, admin"},
+		{"previous_key", usecase.ErrPreviousAPIKeyFound, "previous API key named ci exists"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := rest.NewServer()
+			rest.RegisterCreateAPIKeyUsecase(router, &mockCreateAPIKeyUsecase{err: c.err})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/admin/keys", strings.NewReader(`{"name": "ci", "scopes": ["owner"]}`))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `
+				{
+					"name": "validation_error",
+					"message": "missing or invalid data",
+					"details": ["`+c.expected+`"]
+				}`,
+				w.Body.String())
+		})
+	}
+}
+
+func TestPOST_OnCreateAPIKeyHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateAPIKeyUsecase(router, &mockCreateAPIKeyUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/keys", strings.NewReader(`{"name": "ci", "scopes": ["read"]}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestPOST_OnCreateAPIKeyHandler_ShouldReturn201WithKey(t *testing.T) {
+	uc := &mockCreateAPIKeyUsecase{
+		key: entity.APIKey{
+			ID:        uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+			Name:      "ci",
+			Prefix:    "srk_0a1b2c3d",
+			Hash:      "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+			Scopes:    []string{"analyze"},
+			CreatedAt: time.Date(2020, time.May, 2, 10, 0, 0, 0, time.UTC),
+		},
+		plain: "srk_0a1b2c3d4e5f",
+	}
+	router := rest.NewServer()
+	rest.RegisterCreateAPIKeyUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/keys", strings.NewReader(`{"name": "ci", "scopes": ["analyze"]}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `
+		{
+			"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+			"name": "ci",
+			"prefix": "srk_0a1b2c3d",
+			"scopes": ["analyze"],
+			"created_at": "2020-05-02T10:00:00Z",
+			"key": "srk_0a1b2c3d4e5f"
+		}`,
+		w.Body.String())
+	assert.Equal(t, "ci", uc.name)
+	assert.Equal(t, []string{"analyze"}, uc.scopes)
+}
+
+func TestGET_OnListAPIKeysHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListAPIKeysUsecase(router, &mockListAPIKeysUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/keys", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGET_OnListAPIKeysHandler_ShouldReturn200WithoutKeys(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListAPIKeysUsecase(router, &mockListAPIKeysUsecase{
+		keys: []entity.APIKey{
+			{
+				ID:        uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+				Name:      "ci",
+				Prefix:    "srk_0a1b2c3d",
+				Hash:      "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+				Scopes:    []string{"read"},
+				CreatedAt: time.Date(2020, time.May, 2, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/keys", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `
+		{
+			"total": 1,
+			"keys": [
+				{
+					"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+					"name": "ci",
+					"prefix": "srk_0a1b2c3d",
+					"scopes": ["read"],
+					"created_at": "2020-05-02T10:00:00Z"
+				}
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestDELETE_OnDeleteAPIKeyHandler_WhenMissingKey_ShouldReturn404(t *testing.T) {
+	cases := []struct {
+		name string
+		id   string
+	}{
+		{"invalid_id", "invalid-id"},
+		{"missing_key", "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := rest.NewServer()
+			rest.RegisterDeleteAPIKeyUsecase(router, &mockDeleteAPIKeyUsecase{err: usecase.ErrAPIKeyNotFound})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", "/admin/keys/"+c.id, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+		})
+	}
+}
+
+func TestDELETE_OnDeleteAPIKeyHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterDeleteAPIKeyUsecase(router, &mockDeleteAPIKeyUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/admin/keys/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestDELETE_OnDeleteAPIKeyHandler_ShouldReturn204(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterDeleteAPIKeyUsecase(router, &mockDeleteAPIKeyUsecase{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/admin/keys/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+type mockCreateAPIKeyUsecase struct {
+	key    entity.APIKey
+	plain  string
+	err    error
+	name   string
+	scopes []string
+}
+
+func (m *mockCreateAPIKeyUsecase) Process(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error) {
+	m.name = name
+	m.scopes = scopes
+	return m.key, m.plain, m.err
+}
+
+type mockListAPIKeysUsecase struct {
+	keys []entity.APIKey
+	err  error
+}
+
+func (m *mockListAPIKeysUsecase) Process(ctx context.Context) ([]entity.APIKey, error) {
+	return m.keys, m.err
+}
+
+type mockDeleteAPIKeyUsecase struct {
+	err error
+}
+
+func (m *mockDeleteAPIKeyUsecase) Process(ctx context.Context, ID uuid.UUID) error {
+	return m.err
+}
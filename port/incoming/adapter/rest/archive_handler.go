@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// RegisterArchiveAnalysisUsecase defines the proper URI and HTTP method to execute the
+// ArchiveAnalysisUsecase.
+func RegisterArchiveAnalysisUsecase(r *gin.Engine, uc usecase.ArchiveAnalysisUsecase) *gin.Engine {
+	r.GET("/analysis/:id/archive", func(c *gin.Context) {
+		archiveAnalysis(c, uc)
+	})
+
+	return r
+}
+
+func archiveAnalysis(ctx *gin.Context, uc usecase.ArchiveAnalysisUsecase) {
+	analysisID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("analysis %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	// the archive is streamed as the identifiers are retrieved, so the headers are set before processing
+	ctx.Header("Content-Type", "application/x-ndjson")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"analysis-%s.archive.jsonl\"", analysisID))
+
+	err = uc.Process(ctx, analysisID, ctx.Writer)
+	if err == nil {
+		return
+	}
+
+	if ctx.Writer.Written() {
+		// the response can't be replaced once streaming started, so the client gets an incomplete archive
+		log.WithError(err).Errorf("archive for analysis ID: %v interrupted", analysisID)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Writer.Header().Del("Content-Type")
+	ctx.Writer.Header().Del("Content-Disposition")
+	switch err {
+	case usecase.ErrAnalysisNotFound, usecase.ErrProjectNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("analysis %v can't be found", analysisID))
+	default:
+		log.WithError(err).Error("unexpected error executing archiveAnalysisUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error archiving analysis with ID: %v", analysisID))
+	}
+}
+
+// RegisterRestoreAnalysisUsecase defines the proper URI and HTTP method to execute the
+// RestoreAnalysisUsecase.
+func RegisterRestoreAnalysisUsecase(r *gin.Engine, uc usecase.RestoreAnalysisUsecase) *gin.Engine {
+	r.POST("/analyses/import", func(c *gin.Context) {
+		restoreAnalysis(c, uc)
+	})
+
+	return r
+}
+
+func restoreAnalysis(ctx *gin.Context, uc usecase.RestoreAnalysisUsecase) {
+	analysis, err := uc.Process(ctx, ctx.Request.Body)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrInvalidArchive:
+		setBadRequestResponse(ctx, errors.New("invalid archive"))
+		return
+	case usecase.ErrUnsupportedArchiveVersion:
+		setBadRequestResponse(ctx, errors.New("unsupported archive schema version"))
+		return
+	case usecase.ErrPreviousAnalysisFound:
+		setBadRequestResponse(ctx, errors.New("a previous analysis exists for the archived project"))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing restoreAnalysisUsecase")
+		setInternalErrorResponse(ctx, errors.New("error restoring analysis"))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newAnalysisResponse(analysis))
+}
@@ -0,0 +1,210 @@
+package rest_test
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGET_OnArchiveHandler_WhenInvalidAnalysisID_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterArchiveAnalysisUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/invalid-id/archive", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGET_OnArchiveHandler_WhenNoAnalysis_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterArchiveAnalysisUsecase(router, &mockArchiveAnalysisUsecase{
+		err: usecase.ErrAnalysisNotFound,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/archive", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.JSONEq(t, `
+		{
+			"name": "not_found",
+			"message": "resource not found",
+			"details": ["analysis ed2cd46a-4afd-4d49-a6ea-1c8d12d40134 can't be found"]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnArchiveHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterArchiveAnalysisUsecase(router, &mockArchiveAnalysisUsecase{
+		err: usecase.ErrUnexpected,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/archive", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGET_OnArchiveHandler_ShouldStreamArchive(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterArchiveAnalysisUsecase(router, &mockArchiveAnalysisUsecase{
+		output: "{\"schema_version\":1}\n",
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/archive", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="analysis-ed2cd46a-4afd-4d49-a6ea-1c8d12d40134.archive.jsonl"`,
+		w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "{\"schema_version\":1}\n", w.Body.String())
+}
+
+func TestPOST_OnRestoreHandler_WhenInvalidArchive_ShouldReturn400(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"invalid_archive", usecase.ErrInvalidArchive, "invalid archive"},
+		{"unsupported_version", usecase.ErrUnsupportedArchiveVersion, "unsupported archive schema version"},
+		{"previous_analysis", usecase.ErrPreviousAnalysisFound, "a previous analysis exists for the archived project"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := rest.NewServer()
+			rest.RegisterRestoreAnalysisUsecase(router, &mockRestoreAnalysisUsecase{err: c.err})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/analyses/import", strings.NewReader("{}"))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `
+				{
+					"name": "validation_error",
+					"message": "missing or invalid data",
+					"details": ["`+c.expected+`"]
+				}`,
+				w.Body.String())
+		})
+	}
+}
+
+func TestPOST_OnRestoreHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterRestoreAnalysisUsecase(router, &mockRestoreAnalysisUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/analyses/import", strings.NewReader("{}"))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestPOST_OnRestoreHandler_ShouldReturnRestoredAnalysis(t *testing.T) {
+	uc := &mockRestoreAnalysisUsecase{
+		analysis: entity.AnalysisResults{
+			ID:                      uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+			DateCreated:             time.Date(2020, time.May, 10, 12, 0, 0, 0, time.UTC),
+			ProjectID:               uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"),
+			ProjectName:             "eroatta/test",
+			PipelineMiners:          []string{"wordcount"},
+			PipelineSplitters:       []string{"conserv"},
+			PipelineExpanders:       []string{"basic"},
+			FilesTotal:              1,
+			FilesValid:              1,
+			FilesErrorSamples:       []string{},
+			IdentifiersTotal:        2,
+			IdentifiersValid:        2,
+			IdentifiersErrorSamples: []string{},
+		},
+	}
+	router := rest.NewServer()
+	rest.RegisterRestoreAnalysisUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/analyses/import", strings.NewReader("{\"schema_version\":1}\n"))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "{\"schema_version\":1}\n", uc.body)
+	assert.JSONEq(t, `
+		{
+			"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+			"created_at": "2020-05-10T12:00:00Z",
+			"project_ref": "eroatta/test",
+			"project_id": "f9b76fde-c342-4328-8650-85da8f21e2be",
+			"miners": ["wordcount"],
+			"splitters": ["conserv"],
+			"expanders": ["basic"],
+			"files_summary": {"total": 1, "valid": 1, "failed": 0, "error_samples": []},
+			"identifiers_summary": {"total": 2, "valid": 2, "failed": 0, "error_samples": []}
+		}`,
+		w.Body.String())
+}
+
+type mockArchiveAnalysisUsecase struct {
+	output string
+	err    error
+}
+
+func (m *mockArchiveAnalysisUsecase) Process(ctx context.Context, analysisID uuid.UUID, w io.Writer) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	_, err := io.WriteString(w, m.output)
+	return err
+}
+
+type mockRestoreAnalysisUsecase struct {
+	analysis entity.AnalysisResults
+	body     string
+	err      error
+}
+
+func (m *mockRestoreAnalysisUsecase) Process(ctx context.Context, r io.Reader) (entity.AnalysisResults, error) {
+	b, _ := ioutil.ReadAll(r)
+	m.body = string(b)
+	return m.analysis, m.err
+}
+
+func TestPOST_OnRestoreHandler_WithReviewHandler_ShouldRouteBoth(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterRestoreAnalysisUsecase(router, &mockRestoreAnalysisUsecase{err: usecase.ErrUnexpected})
+	rest.RegisterNotifyReviewUsecase(router, &mockNotifyReviewUsecase{err: usecase.ErrAnalysisNotFound})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/analyses/import", strings.NewReader("{}"))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/review",
+		strings.NewReader(`{"pull_request": 7, "commit": "bc9968d"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
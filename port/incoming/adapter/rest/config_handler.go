@@ -0,0 +1,30 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eroatta/src-reader/config"
+	"github.com/eroatta/src-reader/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterGetConfig sets the endpoint showing the effective configuration of the server, with its secrets
+// redacted. As the configuration is shared by every workspace, it's only shown on the default workspace.
+func RegisterGetConfig(r *gin.Engine, cfg config.Config) *gin.Engine {
+	redacted := cfg.Redacted()
+	r.GET("/admin/config", func(c *gin.Context) {
+		getConfig(c, redacted)
+	})
+
+	return r
+}
+
+func getConfig(ctx *gin.Context, cfg config.Config) {
+	if entity.WorkspaceFrom(ctx) != entity.DefaultWorkspaceID {
+		setForbiddenResponse(ctx, fmt.Errorf("the configuration can only be accessed from the default workspace"))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cfg)
+}
@@ -0,0 +1,234 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type postCreateDictionaryCommand struct {
+	Scope      string              `json:"scope" validate:"oneof=organization project"`
+	ProjectRef string              `json:"project_ref" validate:"omitempty,reference"`
+	Entries    map[string][]string `json:"entries" validate:"required"`
+}
+
+type putUpdateDictionaryCommand struct {
+	Entries map[string][]string `json:"entries" validate:"required"`
+}
+
+type dictionaryResponse struct {
+	ID         string              `json:"id"`
+	Scope      string              `json:"scope"`
+	ProjectRef string              `json:"project_ref,omitempty"`
+	Entries    map[string][]string `json:"entries"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+type dictionariesResponse struct {
+	Total        int                  `json:"total"`
+	Dictionaries []dictionaryResponse `json:"dictionaries"`
+}
+
+// RegisterCreateDictionaryUsecase defines the proper URI and HTTP method to execute the CreateDictionaryUsecase.
+func RegisterCreateDictionaryUsecase(r *gin.Engine, uc usecase.CreateDictionaryUsecase) *gin.Engine {
+	r.POST("/dictionaries", func(c *gin.Context) {
+		createDictionary(c, uc)
+	})
+
+	return r
+}
+
+func createDictionary(ctx *gin.Context, uc usecase.CreateDictionaryUsecase) {
+	var cmd postCreateDictionaryCommand
+
+	if err := ctx.ShouldBindJSON(&cmd); err != nil {
+		log.WithError(err).Debug("failed to bind JSON body")
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	if err := requestValidator.Struct(cmd); err != nil {
+		log.WithError(err).Debug("failed while validating the command")
+		setBadRequestOnValidationResponse(ctx, err)
+		return
+	}
+
+	dict, err := uc.Process(ctx, entity.Dictionary{
+		Scope:      cmd.Scope,
+		ProjectRef: cmd.ProjectRef,
+		Entries:    cmd.Entries,
+	})
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrInvalidDictionaryScope:
+		setBadRequestResponse(ctx, fmt.Errorf("a project_ref is required for %s dictionaries", entity.DictionaryScopeProject))
+		return
+	case usecase.ErrPreviousDictionaryFound:
+		setBadRequestResponse(ctx, fmt.Errorf("previous %s dictionary exists", cmd.Scope))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing createDictionaryUsecase")
+		setInternalErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toDictionaryResponse(dict))
+}
+
+// RegisterListDictionariesUsecase defines the proper URI and HTTP method to execute the ListDictionariesUsecase.
+func RegisterListDictionariesUsecase(r *gin.Engine, uc usecase.ListDictionariesUsecase) *gin.Engine {
+	r.GET("/dictionaries", func(c *gin.Context) {
+		listDictionaries(c, uc)
+	})
+
+	return r
+}
+
+func listDictionaries(ctx *gin.Context, uc usecase.ListDictionariesUsecase) {
+	dicts, err := uc.Process(ctx)
+	if err != nil {
+		log.WithError(err).Error("unexpected error executing listDictionariesUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error accessing dictionaries"))
+		return
+	}
+
+	sort.Slice(dicts, func(i, j int) bool {
+		if dicts[i].Scope != dicts[j].Scope {
+			return dicts[i].Scope == entity.DictionaryScopeOrganization
+		}
+		return dicts[i].ProjectRef < dicts[j].ProjectRef
+	})
+
+	response := dictionariesResponse{
+		Total:        len(dicts),
+		Dictionaries: make([]dictionaryResponse, len(dicts)),
+	}
+	for i, dict := range dicts {
+		response.Dictionaries[i] = toDictionaryResponse(dict)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RegisterGetDictionaryUsecase defines the proper URI and HTTP method to execute the GetDictionaryUsecase.
+func RegisterGetDictionaryUsecase(r *gin.Engine, uc usecase.GetDictionaryUsecase) *gin.Engine {
+	r.GET("/dictionaries/:id", func(c *gin.Context) {
+		getDictionary(c, uc)
+	})
+
+	return r
+}
+
+func getDictionary(ctx *gin.Context, uc usecase.GetDictionaryUsecase) {
+	ID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("dictionary with ID: %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	dict, err := uc.Process(ctx, ID)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrDictionaryNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("dictionary with ID: %s can't be found", ID.String()))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing getDictionaryUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error accessing dictionary with ID: %s", ID.String()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toDictionaryResponse(dict))
+}
+
+// RegisterUpdateDictionaryUsecase defines the proper URI and HTTP method to execute the UpdateDictionaryUsecase.
+func RegisterUpdateDictionaryUsecase(r *gin.Engine, uc usecase.UpdateDictionaryUsecase) *gin.Engine {
+	r.PUT("/dictionaries/:id", func(c *gin.Context) {
+		updateDictionary(c, uc)
+	})
+
+	return r
+}
+
+func updateDictionary(ctx *gin.Context, uc usecase.UpdateDictionaryUsecase) {
+	ID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("dictionary with ID: %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	var cmd putUpdateDictionaryCommand
+	if err := ctx.ShouldBindJSON(&cmd); err != nil {
+		log.WithError(err).Debug("failed to bind JSON body")
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	if err := requestValidator.Struct(cmd); err != nil {
+		log.WithError(err).Debug("failed while validating the command")
+		setBadRequestOnValidationResponse(ctx, err)
+		return
+	}
+
+	dict, err := uc.Process(ctx, ID, cmd.Entries)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrDictionaryNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("dictionary with ID: %s can't be found", ID.String()))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing updateDictionaryUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error updating dictionary with ID: %s", ID.String()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toDictionaryResponse(dict))
+}
+
+// RegisterDeleteDictionaryUsecase defines the proper URI and HTTP method to execute the DeleteDictionaryUsecase.
+func RegisterDeleteDictionaryUsecase(r *gin.Engine, uc usecase.DeleteDictionaryUsecase) *gin.Engine {
+	r.DELETE("/dictionaries/:id", func(c *gin.Context) {
+		deleteDictionary(c, uc)
+	})
+
+	return r
+}
+
+func deleteDictionary(ctx *gin.Context, uc usecase.DeleteDictionaryUsecase) {
+	ID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("dictionary with ID: %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	err = uc.Process(ctx, ID)
+	if err != nil && err != usecase.ErrDictionaryNotFound {
+		log.WithError(err).Error("unexpected error executing deleteDictionaryUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error deleting dictionary with ID: %v", ID))
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func toDictionaryResponse(dict entity.Dictionary) dictionaryResponse {
+	return dictionaryResponse{
+		ID:         dict.ID.String(),
+		Scope:      dict.Scope,
+		ProjectRef: dict.ProjectRef,
+		Entries:    dict.Entries,
+		CreatedAt:  dict.CreatedAt,
+		UpdatedAt:  dict.UpdatedAt,
+	}
+}
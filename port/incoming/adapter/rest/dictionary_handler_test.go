@@ -0,0 +1,350 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPOST_OnDictionaryCreationHandler_WithInvalidScope_ShouldReturnHTTP400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateDictionaryUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	body := `{
+		"scope": "team",
+		"entries": {"txn": ["transaction"]}
+	}`
+	req, _ := http.NewRequest("POST", "/dictionaries", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": [
+				"invalid field 'scope' with value team"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnDictionaryCreationHandler_WithoutProjectReference_ShouldReturnHTTP400(t *testing.T) {
+	createDictionaryUsecaseMock := mockCreateDictionaryUsecase{
+		err: usecase.ErrInvalidDictionaryScope,
+	}
+
+	router := rest.NewServer()
+	rest.RegisterCreateDictionaryUsecase(router, createDictionaryUsecaseMock)
+
+	w := httptest.NewRecorder()
+	body := `{
+		"scope": "project",
+		"entries": {"txn": ["transaction"]}
+	}`
+	req, _ := http.NewRequest("POST", "/dictionaries", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": [
+				"a project_ref is required for project dictionaries"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnDictionaryCreationHandler_WithPreviousDictionary_ShouldReturnHTTP400(t *testing.T) {
+	createDictionaryUsecaseMock := mockCreateDictionaryUsecase{
+		err: usecase.ErrPreviousDictionaryFound,
+	}
+
+	router := rest.NewServer()
+	rest.RegisterCreateDictionaryUsecase(router, createDictionaryUsecaseMock)
+
+	w := httptest.NewRecorder()
+	body := `{
+		"scope": "organization",
+		"entries": {"txn": ["transaction"]}
+	}`
+	req, _ := http.NewRequest("POST", "/dictionaries", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": [
+				"previous organization dictionary exists"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnDictionaryCreationHandler_WithSuccessfulCreation_ShouldReturnHTTP201(t *testing.T) {
+	createDictionaryUsecaseMock := mockCreateDictionaryUsecase{
+		dict: newDictionary(),
+	}
+
+	router := rest.NewServer()
+	rest.RegisterCreateDictionaryUsecase(router, createDictionaryUsecaseMock)
+
+	w := httptest.NewRecorder()
+	body := `{
+		"scope": "project",
+		"project_ref": "eroatta/src-reader",
+		"entries": {"txn": ["transaction"], "svc": ["service"]}
+	}`
+	req, _ := http.NewRequest("POST", "/dictionaries", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `
+		{
+			"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+			"scope": "project",
+			"project_ref": "eroatta/src-reader",
+			"entries": {
+				"txn": ["transaction"],
+				"svc": ["service"]
+			},
+			"created_at": "2020-03-01T10:00:00Z",
+			"updated_at": "2020-03-01T10:00:00Z"
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnDictionariesListHandler_WhenErrorExecutingUsecase_ShouldReturnHTTP500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListDictionariesUsecase(router, mockListDictionariesUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dictionaries", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGET_OnDictionariesListHandler_ShouldReturnHTTP200(t *testing.T) {
+	organization := entity.Dictionary{
+		ID:      uuid.MustParse("ab1cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+		Scope:   entity.DictionaryScopeOrganization,
+		Entries: map[string][]string{"cfg": {"config"}},
+	}
+	router := rest.NewServer()
+	rest.RegisterListDictionariesUsecase(router, mockListDictionariesUsecase{
+		dicts: []entity.Dictionary{newDictionary(), organization},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dictionaries", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `
+		{
+			"total": 2,
+			"dictionaries": [
+				{
+					"id": "ab1cd46a-4afd-4d49-a6ea-1c8d12d40134",
+					"scope": "organization",
+					"entries": {"cfg": ["config"]},
+					"created_at": "0001-01-01T00:00:00Z",
+					"updated_at": "0001-01-01T00:00:00Z"
+				},
+				{
+					"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+					"scope": "project",
+					"project_ref": "eroatta/src-reader",
+					"entries": {
+						"txn": ["transaction"],
+						"svc": ["service"]
+					},
+					"created_at": "2020-03-01T10:00:00Z",
+					"updated_at": "2020-03-01T10:00:00Z"
+				}
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnDictionaryGetHandler_WhenInvalidDictionaryID_ShouldReturnHTTP404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetDictionaryUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dictionaries/invalid-id", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGET_OnDictionaryGetHandler_WhenNonExistingDictionary_ShouldReturnHTTP404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetDictionaryUsecase(router, mockGetDictionaryUsecase{err: usecase.ErrDictionaryNotFound})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dictionaries/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "not_found",
+			"message": "resource not found",
+			"details": [
+				"dictionary with ID: ed2cd46a-4afd-4d49-a6ea-1c8d12d40134 can't be found"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnDictionaryGetHandler_WhenExistingDictionary_ShouldReturnHTTP200(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetDictionaryUsecase(router, mockGetDictionaryUsecase{dict: newDictionary()})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/dictionaries/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPUT_OnDictionaryUpdateHandler_WithEmptyBody_ShouldReturnHTTP400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterUpdateDictionaryUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/dictionaries/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", strings.NewReader(`{}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": [
+				"invalid field 'entries' with value map[]"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPUT_OnDictionaryUpdateHandler_WhenNonExistingDictionary_ShouldReturnHTTP404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterUpdateDictionaryUsecase(router, mockUpdateDictionaryUsecase{err: usecase.ErrDictionaryNotFound})
+
+	w := httptest.NewRecorder()
+	body := `{"entries": {"txn": ["transaction"]}}`
+	req, _ := http.NewRequest("PUT", "/dictionaries/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPUT_OnDictionaryUpdateHandler_WithSuccessfulUpdate_ShouldReturnHTTP200(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterUpdateDictionaryUsecase(router, mockUpdateDictionaryUsecase{dict: newDictionary()})
+
+	w := httptest.NewRecorder()
+	body := `{"entries": {"txn": ["transaction"], "svc": ["service"]}}`
+	req, _ := http.NewRequest("PUT", "/dictionaries/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDELETE_OnDictionaryDeleteHandler_WhenErrorExecutingUsecase_ShouldReturnHTTP500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterDeleteDictionaryUsecase(router, mockDeleteDictionaryUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/dictionaries/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestDELETE_OnDictionaryDeleteHandler_WhenDeletedDictionary_ShouldReturnHTTP204(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterDeleteDictionaryUsecase(router, mockDeleteDictionaryUsecase{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/dictionaries/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func newDictionary() entity.Dictionary {
+	date := time.Date(2020, time.March, 1, 10, 0, 0, 0, time.UTC)
+	return entity.Dictionary{
+		ID:         uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+		Scope:      entity.DictionaryScopeProject,
+		ProjectRef: "eroatta/src-reader",
+		Entries: map[string][]string{
+			"txn": {"transaction"},
+			"svc": {"service"},
+		},
+		CreatedAt: date,
+		UpdatedAt: date,
+	}
+}
+
+type mockCreateDictionaryUsecase struct {
+	dict entity.Dictionary
+	err  error
+}
+
+func (m mockCreateDictionaryUsecase) Process(ctx context.Context, dict entity.Dictionary) (entity.Dictionary, error) {
+	return m.dict, m.err
+}
+
+type mockListDictionariesUsecase struct {
+	dicts []entity.Dictionary
+	err   error
+}
+
+func (m mockListDictionariesUsecase) Process(ctx context.Context) ([]entity.Dictionary, error) {
+	return m.dicts, m.err
+}
+
+type mockGetDictionaryUsecase struct {
+	dict entity.Dictionary
+	err  error
+}
+
+func (m mockGetDictionaryUsecase) Process(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error) {
+	return m.dict, m.err
+}
+
+type mockUpdateDictionaryUsecase struct {
+	dict entity.Dictionary
+	err  error
+}
+
+func (m mockUpdateDictionaryUsecase) Process(ctx context.Context, ID uuid.UUID, entries map[string][]string) (entity.Dictionary, error) {
+	return m.dict, m.err
+}
+
+type mockDeleteDictionaryUsecase struct {
+	err error
+}
+
+func (m mockDeleteDictionaryUsecase) Process(ctx context.Context, ID uuid.UUID) error {
+	return m.err
+}
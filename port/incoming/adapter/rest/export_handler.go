@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"fmt"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// exportContentTypes holds the content type for each supported export format.
+var exportContentTypes = map[string]string{
+	entity.ExportFormatCSV:     "text/csv; charset=utf-8",
+	entity.ExportFormatJSONL:   "application/x-ndjson",
+	entity.ExportFormatParquet: "application/vnd.apache.parquet",
+}
+
+// RegisterExportAnalysisUsecase defines the proper URI and HTTP method to execute the
+// ExportAnalysisUsecase.
+func RegisterExportAnalysisUsecase(r *gin.Engine, uc usecase.ExportAnalysisUsecase) *gin.Engine {
+	r.GET("/analysis/:id/export", func(c *gin.Context) {
+		exportAnalysis(c, uc)
+	})
+
+	return r
+}
+
+func exportAnalysis(ctx *gin.Context, uc usecase.ExportAnalysisUsecase) {
+	analysisID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("analysis %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	format := ctx.DefaultQuery("format", entity.ExportFormatCSV)
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		setBadRequestResponse(ctx, fmt.Errorf("invalid format '%s'", format))
+		return
+	}
+
+	// the identifiers are streamed as they are retrieved, so the headers are set before processing
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"analysis-%s.%s\"", analysisID, format))
+
+	err = uc.Process(ctx, analysisID, format, ctx.Writer)
+	if err == nil {
+		return
+	}
+
+	if ctx.Writer.Written() {
+		// the response can't be replaced once streaming started, so the client gets an incomplete export
+		log.WithError(err).Errorf("export for analysis ID: %v interrupted", analysisID)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Writer.Header().Del("Content-Type")
+	ctx.Writer.Header().Del("Content-Disposition")
+	switch err {
+	case usecase.ErrAnalysisNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("analysis %v can't be found", analysisID))
+	case usecase.ErrUnsupportedFormat:
+		setBadRequestResponse(ctx, fmt.Errorf("invalid format '%s'", format))
+	default:
+		log.WithError(err).Error("unexpected error executing exportAnalysisUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error exporting analysis with ID: %v", analysisID))
+	}
+}
@@ -0,0 +1,113 @@
+package rest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGET_OnExportHandler_WhenInvalidAnalysisID_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterExportAnalysisUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/invalid-id/export", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGET_OnExportHandler_WhenInvalidFormat_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterExportAnalysisUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/export?format=xlsx", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": ["invalid format 'xlsx'"]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnExportHandler_WhenNoAnalysis_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterExportAnalysisUsecase(router, &mockExportAnalysisUsecase{
+		err: usecase.ErrAnalysisNotFound,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/export", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.JSONEq(t, `
+		{
+			"name": "not_found",
+			"message": "resource not found",
+			"details": ["analysis ed2cd46a-4afd-4d49-a6ea-1c8d12d40134 can't be found"]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnExportHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterExportAnalysisUsecase(router, &mockExportAnalysisUsecase{
+		err: usecase.ErrUnexpected,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/export", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGET_OnExportHandler_ShouldStreamIdentifiers(t *testing.T) {
+	uc := &mockExportAnalysisUsecase{
+		output: "{\"name\":\"main\"}\n",
+	}
+	router := rest.NewServer()
+	rest.RegisterExportAnalysisUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/export?format=jsonl", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="analysis-ed2cd46a-4afd-4d49-a6ea-1c8d12d40134.jsonl"`,
+		w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "jsonl", uc.format)
+	assert.Equal(t, "{\"name\":\"main\"}\n", w.Body.String())
+}
+
+type mockExportAnalysisUsecase struct {
+	output string
+	format string
+	err    error
+}
+
+func (m *mockExportAnalysisUsecase) Process(ctx context.Context, analysisID uuid.UUID, format string, w io.Writer) error {
+	m.format = format
+	if m.err != nil {
+		return m.err
+	}
+
+	_, err := io.WriteString(w, m.output)
+	return err
+}
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type findingsResponse struct {
+	AnalysisID string            `json:"analysis_id"`
+	Total      int               `json:"total"`
+	Severities map[string]int    `json:"severities"`
+	Findings   []findingResponse `json:"findings"`
+}
+
+type findingResponse struct {
+	IdentifierID string `json:"identifier_id"`
+	Name         string `json:"name"`
+	Package      string `json:"package"`
+	File         string `json:"file"`
+	Position     int    `json:"position"`
+	Rule         string `json:"rule"`
+	Severity     string `json:"severity"`
+	Message      string `json:"message"`
+	Suggestion   string `json:"suggestion,omitempty"`
+}
+
+// RegisterGetFindingsUsecase defines the proper URI and HTTP method to execute the
+// GetFindingsUsecase.
+func RegisterGetFindingsUsecase(r *gin.Engine, uc usecase.GetFindingsUsecase) *gin.Engine {
+	r.GET("/analysis/:id/findings", func(c *gin.Context) {
+		getFindings(c, uc)
+	})
+
+	return r
+}
+
+func getFindings(ctx *gin.Context, uc usecase.GetFindingsUsecase) {
+	analysisID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("findings for analysis ID: %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	severity := entity.Severity(ctx.Query("severity"))
+	if severity != "" && !severity.Valid() {
+		setBadRequestResponse(ctx, fmt.Errorf("invalid severity '%s'", severity))
+		return
+	}
+
+	identifiers, err := uc.Process(ctx, analysisID, ctx.Query("rule"), severity)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrIdentifiersNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("findings for analysis ID: %v can't be found", analysisID))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing getFindingsUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error accessing findings for analysis ID: %v", analysisID))
+		return
+	}
+
+	response := findingsResponse{
+		AnalysisID: analysisID.String(),
+		Severities: make(map[string]int),
+		Findings:   make([]findingResponse, 0),
+	}
+	for _, ident := range identifiers {
+		for _, finding := range ident.Findings {
+			response.Total++
+			response.Severities[string(finding.Severity)]++
+			response.Findings = append(response.Findings, findingResponse{
+				IdentifierID: ident.ID,
+				Name:         ident.Name,
+				Package:      ident.FullPackageName(),
+				File:         ident.File,
+				Position:     int(ident.Position),
+				Rule:         finding.Rule,
+				Severity:     string(finding.Severity),
+				Message:      finding.Message,
+				Suggestion:   finding.Suggestion,
+			})
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,140 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGET_OnFindingsHandler_WhenInvalidAnalysisID_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetFindingsUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/invalid-id/findings", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGET_OnFindingsHandler_WhenInvalidSeverity_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetFindingsUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/findings?severity=critical", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": [
+				"invalid severity 'critical'"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnFindingsHandler_WhenNoIdentifiers_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetFindingsUsecase(router, mockGetFindingsUsecase{
+		err: usecase.ErrIdentifiersNotFound,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/findings", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGET_OnFindingsHandler_WhenErrorExecutingUsecase_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetFindingsUsecase(router, mockGetFindingsUsecase{
+		err: usecase.ErrUnexpected,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/findings", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "internal_error",
+			"message": "internal server error",
+			"details": [
+				"error accessing findings for analysis ID: ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnFindingsHandler_WhenExistingFindings_ShouldReturn200(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetFindingsUsecase(router, mockGetFindingsUsecase{
+		identifiers: []entity.Identifier{
+			{
+				ID:       "filename:http/server.go+++pkg:http+++declType:struct+++name:HTTPServer",
+				Name:     "HTTPServer",
+				Package:  "http",
+				File:     "http/server.go",
+				Position: 120,
+				Findings: []entity.Finding{
+					{
+						Rule:       "package-stutter",
+						Severity:   entity.SeverityWarning,
+						Message:    "name http.HTTPServer stutters; consider calling it Server",
+						Suggestion: "Server",
+					},
+				},
+			},
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/findings", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `
+		{
+			"analysis_id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+			"total": 1,
+			"severities": {
+				"warning": 1
+			},
+			"findings": [
+				{
+					"identifier_id": "filename:http/server.go+++pkg:http+++declType:struct+++name:HTTPServer",
+					"name": "HTTPServer",
+					"package": "http",
+					"file": "http/server.go",
+					"position": 120,
+					"rule": "package-stutter",
+					"severity": "warning",
+					"message": "name http.HTTPServer stutters; consider calling it Server",
+					"suggestion": "Server"
+				}
+			]
+		}`,
+		w.Body.String())
+}
+
+type mockGetFindingsUsecase struct {
+	identifiers []entity.Identifier
+	err         error
+}
+
+func (m mockGetFindingsUsecase) Process(ctx context.Context, analysisID uuid.UUID, rule string, severity entity.Severity) ([]entity.Identifier, error) {
+	return m.identifiers, m.err
+}
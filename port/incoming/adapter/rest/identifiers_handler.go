@@ -0,0 +1,223 @@
+package rest
+
+import (
+	"fmt"
+	"go/token"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxIdentifiersPageSize is the maximum number of identifiers that can be requested on a single page.
+const maxIdentifiersPageSize = 500
+
+// identifierTypes holds the declaration types that can be used to filter identifiers.
+var identifierTypes = map[string]token.Token{
+	"func":      token.FUNC,
+	"var":       token.VAR,
+	"const":     token.CONST,
+	"struct":    token.STRUCT,
+	"interface": token.INTERFACE,
+}
+
+type identifiersResponse struct {
+	AnalysisID  string               `json:"analysis_id"`
+	Identifiers []identifierResponse `json:"identifiers"`
+	NextCursor  string               `json:"next_cursor,omitempty"`
+}
+
+type identifierResponse struct {
+	ID            string                         `json:"id"`
+	Name          string                         `json:"name"`
+	Package       string                         `json:"package"`
+	File          string                         `json:"file"`
+	Position      int                            `json:"position"`
+	Type          string                         `json:"type"`
+	Exported      bool                           `json:"exported"`
+	Test          bool                           `json:"test"`
+	Splits        map[string][]string            `json:"splits"`
+	Expansions    map[string][]expansionResponse `json:"expansions"`
+	Normalization normalizationResponse          `json:"normalization"`
+}
+
+type expansionResponse struct {
+	From   string   `json:"from"`
+	Values []string `json:"values"`
+}
+
+type normalizationResponse struct {
+	Word      string  `json:"word"`
+	Algorithm string  `json:"algorithm"`
+	Score     float64 `json:"score"`
+}
+
+// RegisterQueryIdentifiersUsecase defines the proper URI and HTTP method to execute the
+// QueryIdentifiersUsecase.
+func RegisterQueryIdentifiersUsecase(r *gin.Engine, uc usecase.QueryIdentifiersUsecase) *gin.Engine {
+	r.GET("/analysis/:id/identifiers", func(c *gin.Context) {
+		queryIdentifiers(c, uc)
+	})
+
+	return r
+}
+
+func queryIdentifiers(ctx *gin.Context, uc usecase.QueryIdentifiersUsecase) {
+	analysisID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("identifiers for analysis ID: %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	query, err := newIdentifierQuery(ctx)
+	if err != nil {
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	page, err := uc.Process(ctx, analysisID, query)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrAnalysisNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("identifiers for analysis ID: %v can't be found", analysisID))
+		return
+	case usecase.ErrInvalidCursor:
+		setBadRequestResponse(ctx, fmt.Errorf("invalid cursor '%s'", query.Cursor))
+		return
+	case usecase.ErrInvalidAlgorithm:
+		setBadRequestResponse(ctx, fmt.Errorf("invalid splitter '%s' or expander '%s' for analysis ID: %v",
+			query.Splitter, query.Expander, analysisID))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing queryIdentifiersUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error accessing identifiers for analysis ID: %v", analysisID))
+		return
+	}
+
+	response := identifiersResponse{
+		AnalysisID:  analysisID.String(),
+		Identifiers: make([]identifierResponse, len(page.Identifiers)),
+		NextCursor:  page.NextCursor,
+	}
+	for i, ident := range page.Identifiers {
+		response.Identifiers[i] = newIdentifierResponse(ident)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// newIdentifierQuery builds an entity.IdentifierQuery from the query parameters on the request.
+func newIdentifierQuery(ctx *gin.Context) (entity.IdentifierQuery, error) {
+	query := entity.IdentifierQuery{
+		Package:  ctx.Query("package"),
+		File:     ctx.Query("file"),
+		Splitter: ctx.Query("splitter"),
+		Expander: ctx.Query("expander"),
+		Cursor:   ctx.Query("cursor"),
+	}
+
+	if value := ctx.Query("type"); value != "" {
+		tok, ok := identifierTypes[value]
+		if !ok {
+			return query, fmt.Errorf("invalid type '%s'", value)
+		}
+		query.Type = tok
+	}
+
+	if value := ctx.Query("exported"); value != "" {
+		exported, err := strconv.ParseBool(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid exported '%s'", value)
+		}
+		query.Exported = &exported
+	}
+
+	for param, score := range map[string]**float64{"min_score": &query.MinScore, "max_score": &query.MaxScore} {
+		if value := ctx.Query(param); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s '%s'", param, value)
+			}
+			*score = &parsed
+		}
+	}
+
+	if value := ctx.Query("name"); value != "" {
+		name, err := regexp.Compile(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid name '%s'", value)
+		}
+		query.Name = name
+	}
+
+	switch value := ctx.Query("sort"); value {
+	case entity.IdentifierSortDefault, entity.IdentifierSortScore, entity.IdentifierSortScoreDesc:
+		query.Sort = value
+	default:
+		return query, fmt.Errorf("invalid sort '%s'", value)
+	}
+
+	if value := ctx.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxIdentifiersPageSize {
+			return query, fmt.Errorf("invalid limit '%s'", value)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+func newIdentifierResponse(ident entity.Identifier) identifierResponse {
+	response := identifierResponse{
+		ID:         ident.ID,
+		Name:       ident.Name,
+		Package:    ident.Package,
+		File:       ident.File,
+		Position:   int(ident.Position),
+		Type:       ident.Type.String(),
+		Exported:   ident.Exported(),
+		Test:       ident.IsTest(),
+		Splits:     make(map[string][]string, len(ident.Splits)),
+		Expansions: make(map[string][]expansionResponse, len(ident.Expansions)),
+		Normalization: normalizationResponse{
+			Word:      ident.Normalization.Word,
+			Algorithm: ident.Normalization.Algorithm,
+			Score:     ident.Normalization.Score,
+		},
+	}
+
+	for algorithm, splits := range ident.Splits {
+		sorted := append([]entity.Split{}, splits...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+
+		values := make([]string, len(sorted))
+		for i, split := range sorted {
+			values[i] = split.Value
+		}
+		response.Splits[algorithm] = values
+	}
+
+	for algorithm, expansions := range ident.Expansions {
+		sorted := append([]entity.Expansion{}, expansions...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+
+		values := make([]expansionResponse, len(sorted))
+		for i, expansion := range sorted {
+			values[i] = expansionResponse{From: expansion.From, Values: expansion.Values}
+			if values[i].Values == nil {
+				values[i].Values = make([]string, 0)
+			}
+		}
+		response.Expansions[algorithm] = values
+	}
+
+	return response
+}
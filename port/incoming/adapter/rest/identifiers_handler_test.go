@@ -0,0 +1,212 @@
+package rest_test
+
+import (
+	"context"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGET_OnIdentifiersHandler_WhenInvalidAnalysisID_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterQueryIdentifiersUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/invalid-id/identifiers", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGET_OnIdentifiersHandler_WhenInvalidParameters_ShouldReturn400(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "type", query: "type=method", expected: "invalid type 'method'"},
+		{name: "exported", query: "exported=maybe", expected: "invalid exported 'maybe'"},
+		{name: "min_score", query: "min_score=low", expected: "invalid min_score 'low'"},
+		{name: "name", query: "name=%5Bread", expected: "invalid name '[read'"},
+		{name: "sort", query: "sort=name", expected: "invalid sort 'name'"},
+		{name: "limit", query: "limit=1000", expected: "invalid limit '1000'"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := rest.NewServer()
+			rest.RegisterQueryIdentifiersUsecase(router, nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/identifiers?"+c.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `
+				{
+					"name": "validation_error",
+					"message": "missing or invalid data",
+					"details": ["`+c.expected+`"]
+				}`,
+				w.Body.String())
+		})
+	}
+}
+
+func TestGET_OnIdentifiersHandler_WhenInvalidCursor_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterQueryIdentifiersUsecase(router, &mockQueryIdentifiersUsecase{
+		err: usecase.ErrInvalidCursor,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/identifiers?cursor=abc", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGET_OnIdentifiersHandler_WhenInvalidAlgorithm_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterQueryIdentifiersUsecase(router, &mockQueryIdentifiersUsecase{
+		err: usecase.ErrInvalidAlgorithm,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/identifiers?splitter=$where", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGET_OnIdentifiersHandler_WhenNoAnalysis_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterQueryIdentifiersUsecase(router, &mockQueryIdentifiersUsecase{
+		err: usecase.ErrAnalysisNotFound,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/identifiers", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGET_OnIdentifiersHandler_WhenErrorExecutingUsecase_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterQueryIdentifiersUsecase(router, &mockQueryIdentifiersUsecase{
+		err: usecase.ErrUnexpected,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/identifiers", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "internal_error",
+			"message": "internal server error",
+			"details": [
+				"error accessing identifiers for analysis ID: ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnIdentifiersHandler_WhenExistingIdentifiers_ShouldReturn200(t *testing.T) {
+	uc := &mockQueryIdentifiersUsecase{
+		page: entity.IdentifierPage{
+			Identifiers: []entity.Identifier{
+				{
+					ID:       "filename:http/server.go+++pkg:http+++declType:func+++name:ServeCfg",
+					Name:     "ServeCfg",
+					Package:  "http",
+					File:     "http/server.go",
+					Position: 120,
+					Type:     token.FUNC,
+					Splits: map[string][]entity.Split{
+						"conserv": {{Order: 2, Value: "Cfg"}, {Order: 1, Value: "Serve"}},
+					},
+					Expansions: map[string][]entity.Expansion{
+						"basic": {
+							{Order: 1, From: "Serve", Values: []string{"serve"}},
+							{Order: 2, From: "Cfg", Values: []string{"config"}},
+						},
+					},
+					Normalization: entity.Normalization{Word: "serveConfig", Algorithm: "conserv+basic", Score: 0.8},
+				},
+			},
+			NextCursor: "eyJzIjowLjgsImsiOiIxIn0",
+		},
+	}
+	router := rest.NewServer()
+	rest.RegisterQueryIdentifiersUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/identifiers"+
+		"?package=http&type=func&exported=true&min_score=0.5&splitter=conserv&name=%5EServe&sort=-score&limit=1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `
+		{
+			"analysis_id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+			"identifiers": [
+				{
+					"id": "filename:http/server.go+++pkg:http+++declType:func+++name:ServeCfg",
+					"name": "ServeCfg",
+					"package": "http",
+					"file": "http/server.go",
+					"position": 120,
+					"type": "func",
+					"exported": true,
+					"test": false,
+					"splits": {
+						"conserv": ["Serve", "Cfg"]
+					},
+					"expansions": {
+						"basic": [
+							{"from": "Serve", "values": ["serve"]},
+							{"from": "Cfg", "values": ["config"]}
+						]
+					},
+					"normalization": {
+						"word": "serveConfig",
+						"algorithm": "conserv+basic",
+						"score": 0.8
+					}
+				}
+			],
+			"next_cursor": "eyJzIjowLjgsImsiOiIxIn0"
+		}`,
+		w.Body.String())
+
+	assert.Equal(t, "http", uc.query.Package)
+	assert.Equal(t, token.FUNC, uc.query.Type)
+	assert.True(t, *uc.query.Exported)
+	assert.Equal(t, 0.5, *uc.query.MinScore)
+	assert.Nil(t, uc.query.MaxScore)
+	assert.Equal(t, "conserv", uc.query.Splitter)
+	assert.Equal(t, "^Serve", uc.query.Name.String())
+	assert.Equal(t, entity.IdentifierSortScoreDesc, uc.query.Sort)
+	assert.Equal(t, 1, uc.query.Limit)
+}
+
+type mockQueryIdentifiersUsecase struct {
+	page  entity.IdentifierPage
+	err   error
+	query entity.IdentifierQuery
+}
+
+func (m *mockQueryIdentifiersUsecase) Process(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error) {
+	m.query = query
+	return m.page, m.err
+}
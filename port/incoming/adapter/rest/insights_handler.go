@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/usecase"
@@ -22,6 +23,13 @@ type insightsResponse struct {
 	Summary    insightsSummaryResponse `json:"identifiers"`
 	Overall    float64                 `json:"accuracy"`
 	Packages   []packageResponse       `json:"packages"`
+	Tests      *testsResponse          `json:"tests,omitempty"`
+}
+
+type testsResponse struct {
+	Summary  insightsSummaryResponse `json:"identifiers"`
+	Overall  float64                 `json:"accuracy"`
+	Packages []packageResponse       `json:"packages"`
 }
 
 type insightsSummaryResponse struct {
@@ -30,10 +38,11 @@ type insightsSummaryResponse struct {
 }
 
 type packageResponse struct {
-	Name    string                  `json:"name"`
-	Summary insightsSummaryResponse `json:"identifiers"`
-	Ratio   float64                 `json:"accuracy"`
-	Files   []string                `json:"files"`
+	Name     string                  `json:"name"`
+	Language string                  `json:"language,omitempty"`
+	Summary  insightsSummaryResponse `json:"identifiers"`
+	Ratio    float64                 `json:"accuracy"`
+	Files    []string                `json:"files"`
 }
 
 // RegisterGainInsightsUsecase sets the endpoint and the handler on the REST service to
@@ -62,6 +71,12 @@ func createInsights(ctx *gin.Context, uc usecase.GainInsightsUsecase) {
 		return
 	}
 
+	withTests, err := includeTests(ctx)
+	if err != nil {
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
 	insights, err := uc.Process(ctx, uuid.MustParse(cmd.AnalysisID))
 	switch err {
 	case nil:
@@ -77,22 +92,30 @@ func createInsights(ctx *gin.Context, uc usecase.GainInsightsUsecase) {
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, getInsightsResponse(cmd.AnalysisID, insights))
+	ctx.JSON(http.StatusCreated, getInsightsResponse(cmd.AnalysisID, insights, withTests))
 }
 
-func getInsightsResponse(analysisID string, insights []entity.Insight) insightsResponse {
+// getInsightsResponse summarizes the insights, reporting the ones from test files on their own section. The
+// identifiers from test files are left out of the headline summary and accuracy, unless includeTests is set.
+func getInsightsResponse(analysisID string, insights []entity.Insight, includeTests bool) insightsResponse {
 	response := insightsResponse{
 		AnalysisID: analysisID,
 		Summary:    insightsSummaryResponse{},
 		Packages:   make([]packageResponse, 0),
 	}
+	tests := testsResponse{
+		Packages: make([]packageResponse, 0),
+	}
 	weighted := 0.0
+	testsWeighted := 0.0
 
 	for _, insight := range insights {
 		response.ProjectRef = insight.ProjectRef
-		response.Summary.Total += insight.TotalIdentifiers
-		response.Summary.Exported += insight.TotalExported
-		weighted += insight.TotalWeight
+		if !insight.Test || includeTests {
+			response.Summary.Total += insight.TotalIdentifiers
+			response.Summary.Exported += insight.TotalExported
+			weighted += insight.TotalWeight
+		}
 
 		files := make([]string, 0)
 		for file := range insight.Files {
@@ -100,17 +123,35 @@ func getInsightsResponse(analysisID string, insights []entity.Insight) insightsR
 		}
 		sort.Strings(files)
 
-		response.Packages = append(response.Packages, packageResponse{
-			Name: insight.Package,
+		pkg := packageResponse{
+			Name:     insight.Package,
+			Language: string(insight.Language),
 			Summary: insightsSummaryResponse{
 				Total:    insight.TotalIdentifiers,
 				Exported: insight.TotalExported,
 			},
 			Ratio: insight.Rate(),
 			Files: files,
-		})
+		}
+		if !insight.Test {
+			response.Packages = append(response.Packages, pkg)
+			continue
+		}
+
+		tests.Summary.Total += insight.TotalIdentifiers
+		tests.Summary.Exported += insight.TotalExported
+		testsWeighted += insight.TotalWeight
+		tests.Packages = append(tests.Packages, pkg)
+	}
+	if response.Summary.Total > 0 {
+		response.Overall = weighted / float64(response.Summary.Total)
+	}
+	if len(tests.Packages) > 0 {
+		if tests.Summary.Total > 0 {
+			tests.Overall = testsWeighted / float64(tests.Summary.Total)
+		}
+		response.Tests = &tests
 	}
-	response.Overall = weighted / float64(response.Summary.Total)
 
 	return response
 }
@@ -132,6 +173,12 @@ func getInsights(ctx *gin.Context, uc usecase.GetInsightsUsecase) {
 		return
 	}
 
+	withTests, err := includeTests(ctx)
+	if err != nil {
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
 	insights, err := uc.Process(ctx, analysisID)
 	switch err {
 	case nil:
@@ -145,7 +192,23 @@ func getInsights(ctx *gin.Context, uc usecase.GetInsightsUsecase) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, getInsightsResponse(analysisID.String(), insights))
+	ctx.JSON(http.StatusOK, getInsightsResponse(analysisID.String(), insights, withTests))
+}
+
+// includeTests checks if the "include_tests" query parameter asks to count the identifiers from test files on
+// the headline accuracy.
+func includeTests(ctx *gin.Context) (bool, error) {
+	value := ctx.Query("include_tests")
+	if value == "" {
+		return false, nil
+	}
+
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid include_tests '%s'", value)
+	}
+
+	return include, nil
 }
 
 // RegisterDeleteInsightsUsecase defines the proper URI and HTTP method to execute the
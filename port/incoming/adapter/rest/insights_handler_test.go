@@ -3,6 +3,7 @@ package rest_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -395,6 +396,99 @@ func TestGET_OnInsightsGetHandler_WithExistingInsights_ShouldReturn200(t *testin
 		w.Body.String())
 }
 
+func TestGET_OnInsightsGetHandler_WithInsightsFromTestFiles_ShouldReportTestsApart(t *testing.T) {
+	retrieveInsightsMock := mockGetInsightsUsecase{
+		ins: []entity.Insight{
+			{
+				ProjectRef:       "eroatta/test",
+				Package:          "main",
+				TotalIdentifiers: 2,
+				TotalExported:    1,
+				TotalWeight:      1.6,
+				Files: map[string]struct{}{
+					"main.go": {},
+				},
+			},
+			{
+				ProjectRef:       "eroatta/test",
+				Package:          "main",
+				TotalIdentifiers: 2,
+				TotalExported:    2,
+				TotalWeight:      1.0,
+				Files: map[string]struct{}{
+					"main_test.go": {},
+				},
+				Test: true,
+			},
+		},
+	}
+
+	router := rest.NewServer()
+	rest.RegisterGetInsightsUsecase(router, retrieveInsightsMock)
+
+	tests := []struct {
+		name        string
+		query       string
+		identifiers string
+		accuracy    float64
+	}{
+		{"excluding_tests_by_default", "", `{"total": 2, "exported": 1}`, 0.8},
+		{"including_tests", "?include_tests=true", `{"total": 4, "exported": 3}`, 0.65},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/insights/a9f42bb8-92e6-4344-852b-2a9d8dd5b503"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, fmt.Sprintf(`
+				{
+					"analysis_id": "a9f42bb8-92e6-4344-852b-2a9d8dd5b503",
+					"project_ref": "eroatta/test",
+					"identifiers": %s,
+					"accuracy": %v,
+					"packages": [
+						{
+							"name": "main",
+							"accuracy": 0.8,
+							"identifiers": {"total": 2, "exported": 1},
+							"files": ["main.go"]
+						}
+					],
+					"tests": {
+						"identifiers": {"total": 2, "exported": 2},
+						"accuracy": 0.5,
+						"packages": [
+							{
+								"name": "main",
+								"accuracy": 0.5,
+								"identifiers": {"total": 2, "exported": 2},
+								"files": ["main_test.go"]
+							}
+						]
+					}
+				}`, tt.identifiers, tt.accuracy),
+				w.Body.String())
+		})
+	}
+}
+
+func TestGET_OnInsightsGetHandler_WithInvalidIncludeTests_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterGetInsightsUsecase(router, mockGetInsightsUsecase{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/insights/a9f42bb8-92e6-4344-852b-2a9d8dd5b503?include_tests=maybe", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"name": "validation_error", "message": "missing or invalid data",
+		"details": ["invalid include_tests 'maybe'"]}`,
+		w.Body.String())
+}
+
 func TestDELETE_OnInsightsDeleteHandler_WithInvalidAnalysisID_ShouldReturn404(t *testing.T) {
 	router := rest.NewServer()
 	rest.RegisterDeleteInsightsUsecase(router, nil)
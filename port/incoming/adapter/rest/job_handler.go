@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type jobResponse struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	AnalysisID  string    `json:"analysis_id"`
+	Attempt     int       `json:"attempt"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	Owner       string    `json:"owner"`
+	StartedAt   time.Time `json:"started_at"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+}
+
+type jobsResponse struct {
+	Total int           `json:"total"`
+	Jobs  []jobResponse `json:"jobs"`
+}
+
+// RegisterListJobsUsecase defines the proper URI and HTTP method to execute the ListJobsUsecase.
+func RegisterListJobsUsecase(r *gin.Engine, uc usecase.ListJobsUsecase) *gin.Engine {
+	r.GET("/jobs", func(c *gin.Context) {
+		listJobs(c, uc)
+	})
+
+	return r
+}
+
+func listJobs(ctx *gin.Context, uc usecase.ListJobsUsecase) {
+	jobs, err := uc.Process(ctx)
+	if err != nil {
+		log.WithError(err).Error("unexpected error executing listJobsUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error accessing analysis jobs"))
+		return
+	}
+
+	response := jobsResponse{
+		Total: len(jobs),
+		Jobs:  make([]jobResponse, len(jobs)),
+	}
+	for i, job := range jobs {
+		response.Jobs[i] = toJobResponse(job)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func toJobResponse(job entity.AnalysisJob) jobResponse {
+	return jobResponse{
+		ID:          job.ID.String(),
+		ProjectID:   job.ProjectID.String(),
+		AnalysisID:  job.AnalysisID.String(),
+		Attempt:     job.Attempt,
+		Status:      job.Status,
+		Error:       job.Error,
+		Owner:       job.Owner,
+		StartedAt:   job.DateStarted,
+		RefreshedAt: job.DateRefreshed,
+	}
+}
@@ -0,0 +1,85 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGET_OnListJobsHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListJobsUsecase(router, mockListJobsUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/jobs", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "internal_error",
+			"message": "internal server error",
+			"details": ["error accessing analysis jobs"]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnListJobsHandler_ShouldReturn200(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListJobsUsecase(router, mockListJobsUsecase{
+		jobs: []entity.AnalysisJob{
+			{
+				ID:            uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+				ProjectID:     uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"),
+				AnalysisID:    uuid.MustParse("f1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+				Attempt:       2,
+				Status:        entity.JobStatusFailed,
+				Error:         "analysis interrupted 2 times",
+				Owner:         "server-1-0a1b2c3d",
+				DateStarted:   time.Date(2020, time.May, 2, 10, 0, 0, 0, time.UTC),
+				DateRefreshed: time.Date(2020, time.May, 2, 10, 5, 0, 0, time.UTC),
+			},
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/jobs", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `
+		{
+			"total": 1,
+			"jobs": [
+				{
+					"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+					"project_id": "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+					"analysis_id": "f1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d",
+					"attempt": 2,
+					"status": "failed",
+					"error": "analysis interrupted 2 times",
+					"owner": "server-1-0a1b2c3d",
+					"started_at": "2020-05-02T10:00:00Z",
+					"refreshed_at": "2020-05-02T10:05:00Z"
+				}
+			]
+		}`,
+		w.Body.String())
+}
+
+type mockListJobsUsecase struct {
+	jobs []entity.AnalysisJob
+	err  error
+}
+
+func (m mockListJobsUsecase) Process(ctx context.Context) ([]entity.AnalysisJob, error) {
+	return m.jobs, m.err
+}
@@ -1,21 +1,42 @@
 package rest
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/tracing"
+	"github.com/eroatta/src-reader/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// principalKey is the key on the request context holding the name of the API key sending the request.
+const principalKey = "principal"
+
+// apiKeyIDKey is the key on the request context holding the ID of the API key sending the request.
+const apiKeyIDKey = "api_key_id"
+
+// anonymousPrincipal identifies the requests sent without an API key, to the public routes.
+const anonymousPrincipal = "anonymous"
+
 var (
 	requests = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "requests",
 			Help: "A counter for the requests Golden Signal",
 		},
-		[]string{"code", "method"},
+		[]string{"code", "method", "principal"},
 	)
 
 	latency = prometheus.NewHistogramVec(
@@ -26,10 +47,32 @@ var (
 		},
 		[]string{"code", "method"},
 	)
+
+	rejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rejected_requests",
+			Help: "A counter for the requests rejected by the rate limit or the analysis quotas",
+		},
+		[]string{"reason", "principal"},
+	)
+
+	analysesInProgress = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "analyses_in_progress",
+			Help: "A gauge for the analyses requested through the API and still in progress",
+		},
+	)
+)
+
+// Reasons to reject a request, used to label the rejected requests.
+const (
+	rejectedByRateLimit   = "rate_limit"
+	rejectedByConcurrency = "concurrency"
+	rejectedBySize        = "size"
 )
 
 func setMetricsCollectors(r *gin.Engine) {
-	collectors := []prometheus.Collector{requests, latency}
+	collectors := []prometheus.Collector{requests, latency, rejections, analysesInProgress}
 	for _, coll := range collectors {
 		err := prometheus.Register(coll)
 		if err != nil {
@@ -50,9 +93,201 @@ func goldenSignalsHandler(c *gin.Context) {
 	defer func(t time.Time, path string) {
 		code := strconv.Itoa(c.Writer.Status())
 		// register metrics
-		requests.WithLabelValues(code, path).Inc()
+		requests.WithLabelValues(code, path, principal(c)).Inc()
 		latency.WithLabelValues(code, path).Observe(time.Since(t).Seconds())
 	}(time.Now(), c.Request.URL.Path)
 
 	c.Next()
 }
+
+// tracingHandler records a span for each request, continuing the trace sent on the traceparent header, if any. The
+// span is kept on the request context, so the spans started by the use cases are nested under it.
+func tracingHandler(c *gin.Context) {
+	if c.Request.URL.Path == "/metrics" {
+		c.Next()
+		return
+	}
+
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+	ctx := propagation.TraceContext{}.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+	ctx, span := tracing.Start(ctx, c.Request.Method+" "+route, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("http.method", c.Request.Method), attribute.String("http.route", route)))
+	defer span.End()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Set(tracing.SpanContextKey, span)
+
+	c.Next()
+
+	span.SetAttributes(attribute.Int("http.status_code", c.Writer.Status()), attribute.String("principal", principal(c)))
+	if c.Writer.Status() >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(c.Writer.Status()))
+	}
+}
+
+// publicRoutes holds the routes available without an API key. Push notifications are authenticated by their
+// signature instead.
+var publicRoutes = map[string]bool{
+	"/ping":                    true,
+	"/metrics":                 true,
+	"/webhooks/git":            true,
+	"/webhooks/git/:workspace": true,
+}
+
+// RegisterAuthenticateUsecase requires an API key on every route registered afterwards, except the public ones.
+// The key must be sent as a bearer token and allow the scope required by the route: removing elements and
+// managing the API keys and workspaces require the admin scope, any other change requires the analyze scope
+// and retrieving elements requires the read scope. Every request is handled on the workspace the key belongs to.
+func RegisterAuthenticateUsecase(r *gin.Engine, uc usecase.AuthenticateUsecase) *gin.Engine {
+	r.Use(func(c *gin.Context) {
+		authenticate(c, uc)
+	})
+
+	return r
+}
+
+func authenticate(ctx *gin.Context, uc usecase.AuthenticateUsecase) {
+	route := ctx.FullPath()
+	if publicRoutes[route] {
+		ctx.Next()
+		return
+	}
+
+	token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	key, err := uc.Process(ctx, token)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrInvalidCredentials:
+		setUnauthorizedResponse(ctx, errors.New("a valid API key is required as bearer token"))
+		ctx.Abort()
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing authenticateUsecase")
+		setInternalErrorResponse(ctx, errors.New("error authenticating request"))
+		ctx.Abort()
+		return
+	}
+
+	ctx.Set(principalKey, key.Name)
+	ctx.Set(apiKeyIDKey, key.ID)
+	ctx.Set(entity.WorkspaceContextKey, key.WorkspaceID)
+	logger := log.WithField("principal", key.Name).WithField("workspace", key.WorkspaceID)
+
+	scope := requiredScope(ctx.Request.Method, route)
+	if !key.Allows(scope) {
+		logger.Warnf("%s %s denied, missing scope %s", ctx.Request.Method, ctx.Request.URL.Path, scope)
+		setForbiddenResponse(ctx, fmt.Errorf("the API key doesn't allow the %s scope", scope))
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+
+	logger.WithField("status", ctx.Writer.Status()).Infof("%s %s", ctx.Request.Method, ctx.Request.URL.Path)
+}
+
+// requiredScope retrieves the scope an API key must allow to access the given route.
+func requiredScope(method string, route string) string {
+	switch {
+	case strings.HasPrefix(route, "/admin/"), method == http.MethodDelete:
+		return entity.ScopeAdmin
+	case method == http.MethodGet, method == http.MethodHead:
+		return entity.ScopeRead
+	default:
+		return entity.ScopeAnalyze
+	}
+}
+
+// principal retrieves the name of the API key sending the request.
+func principal(ctx *gin.Context) string {
+	if name := ctx.GetString(principalKey); name != "" {
+		return name
+	}
+	return anonymousPrincipal
+}
+
+// RegisterRateLimit limits the requests sent with each API key to the given number on every time window, rejecting
+// the ones exceeding it. It must be registered after RegisterAuthenticateUsecase, and the public routes aren't limited.
+// A zero limit doesn't limit any request.
+func RegisterRateLimit(r *gin.Engine, limit int, window time.Duration) *gin.Engine {
+	if limit <= 0 {
+		return r
+	}
+
+	limiter := &rateLimiter{
+		limit:   limit,
+		window:  window,
+		now:     time.Now,
+		windows: make(map[string]*rateWindow),
+	}
+	r.Use(func(c *gin.Context) {
+		rateLimit(c, limiter)
+	})
+
+	return r
+}
+
+func rateLimit(ctx *gin.Context, limiter *rateLimiter) {
+	id, ok := ctx.Get(apiKeyIDKey)
+	if !ok {
+		ctx.Next()
+		return
+	}
+
+	name := principal(ctx)
+	retryAfter, allowed := limiter.allow(fmt.Sprintf("%v", id))
+	if !allowed {
+		rejections.WithLabelValues(rejectedByRateLimit, name).Inc()
+		ctx.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		setTooManyRequestsResponse(ctx, fmt.Errorf("the API key exceeded %d requests per %v", limiter.limit,
+			limiter.window))
+		ctx.Abort()
+		return
+	}
+
+	ctx.Next()
+}
+
+// rateLimiter counts the requests sent with each API key on fixed time windows.
+type rateLimiter struct {
+	limit   int
+	window  time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+}
+
+type rateWindow struct {
+	start    time.Time
+	requests int
+}
+
+// allow counts a new request for the given key, and checks if it's allowed on the current window. If it's not,
+// it also retrieves the time left until the window ends.
+func (l *rateLimiter) allow(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for k, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, k)
+		}
+	}
+
+	w, ok := l.windows[key]
+	if !ok {
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+	if w.requests >= l.limit {
+		return w.start.Add(l.window).Sub(now), false
+	}
+	w.requests++
+
+	return 0, true
+}
@@ -0,0 +1,264 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/tracing"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newAuthenticatedServer creates a server requiring the API keys known by the given usecase, with a route
+// answering 200 for each HTTP method.
+func newAuthenticatedServer(uc usecase.AuthenticateUsecase) *gin.Engine {
+	router := rest.NewServer()
+	rest.RegisterAuthenticateUsecase(router, uc)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/projects/:id", ok)
+	router.POST("/projects", ok)
+	router.DELETE("/projects/:id", ok)
+	router.GET("/admin/keys", ok)
+	router.POST("/webhooks/git", ok)
+
+	return router
+}
+
+func TestAuthenticate_WhenMissingOrInvalidKey_ShouldReturn401(t *testing.T) {
+	cases := []struct {
+		name          string
+		authorization string
+	}{
+		{"missing_header", ""},
+		{"unknown_key", "Bearer srk_unknown"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := newAuthenticatedServer(&mockAuthenticateUsecase{err: usecase.ErrInvalidCredentials})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/projects/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+			if c.authorization != "" {
+				req.Header.Set("Authorization", c.authorization)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.JSONEq(t, `
+				{
+					"name": "unauthorized",
+					"message": "missing or invalid credentials",
+					"details": ["a valid API key is required as bearer token"]
+				}`,
+				w.Body.String())
+		})
+	}
+}
+
+func TestAuthenticate_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := newAuthenticatedServer(&mockAuthenticateUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/projects/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	req.Header.Set("Authorization", "Bearer srk_0a1b2c3d")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestAuthenticate_ShouldEnforceScopePerRoute(t *testing.T) {
+	cases := []struct {
+		name     string
+		scope    string
+		method   string
+		path     string
+		expected int
+	}{
+		{"read_on_get", entity.ScopeRead, "GET", "/projects/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", http.StatusOK},
+		{"read_on_post", entity.ScopeRead, "POST", "/projects", http.StatusForbidden},
+		{"analyze_on_post", entity.ScopeAnalyze, "POST", "/projects", http.StatusOK},
+		{"analyze_on_delete", entity.ScopeAnalyze, "DELETE", "/projects/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", http.StatusForbidden},
+		{"analyze_on_admin", entity.ScopeAnalyze, "GET", "/admin/keys", http.StatusForbidden},
+		{"admin_on_delete", entity.ScopeAdmin, "DELETE", "/projects/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", http.StatusOK},
+		{"admin_on_admin", entity.ScopeAdmin, "GET", "/admin/keys", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			uc := &mockAuthenticateUsecase{key: entity.APIKey{Name: "ci", Scopes: []string{c.scope}}}
+			router := newAuthenticatedServer(uc)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(c.method, c.path, nil)
+			req.Header.Set("Authorization", "Bearer srk_0a1b2c3d")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, c.expected, w.Code)
+			assert.Equal(t, "srk_0a1b2c3d", uc.token)
+		})
+	}
+}
+
+func TestAuthenticate_OnPublicRoutes_ShouldNotRequireKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{"ping", "GET", "/ping"},
+		{"webhooks", "POST", "/webhooks/git"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			uc := &mockAuthenticateUsecase{err: usecase.ErrInvalidCredentials}
+			router := newAuthenticatedServer(uc)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(c.method, c.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, uc.token)
+		})
+	}
+}
+
+func TestAuthenticate_ShouldHandleRequestOnKeyWorkspace(t *testing.T) {
+	workspaceID := uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f")
+	uc := &mockAuthenticateUsecase{
+		key: entity.APIKey{Name: "ci", WorkspaceID: workspaceID, Scopes: []string{entity.ScopeRead}},
+	}
+	router := newAuthenticatedServer(uc)
+	router.GET("/workspace", func(c *gin.Context) {
+		c.String(http.StatusOK, entity.WorkspaceFrom(c).String())
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/workspace", nil)
+	req.Header.Set("Authorization", "Bearer srk_0a1b2c3d")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, workspaceID.String(), w.Body.String())
+}
+
+func TestRateLimit_ShouldLimitRequestsPerKey(t *testing.T) {
+	uc := &mockAuthenticateUsecase{key: entity.APIKey{Name: "ci", Scopes: []string{entity.ScopeRead}}}
+	router := rest.NewServer()
+	rest.RegisterAuthenticateUsecase(router, uc)
+	rest.RegisterRateLimit(router, 2, time.Minute)
+	router.GET("/projects/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	ci := uuid.MustParse("8b4b1a38-0c35-4a4c-9a1b-5f3e4d2c1b0a")
+	send := func(id uuid.UUID, name string) *httptest.ResponseRecorder {
+		uc.key.ID = id
+		uc.key.Name = name
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/projects/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+		req.Header.Set("Authorization", "Bearer srk_0a1b2c3d")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, send(ci, "ci").Code)
+	assert.Equal(t, http.StatusOK, send(ci, "ci").Code)
+	w := send(ci, "ci")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `
+		{
+			"name": "too_many_requests",
+			"message": "rate limit or quota exceeded",
+			"details": ["the API key exceeded 2 requests per 1m0s"]
+		}`,
+		w.Body.String())
+
+	// renaming the key doesn't reset its limit
+	assert.Equal(t, http.StatusTooManyRequests, send(ci, "ci-renamed").Code)
+
+	// every key has its own limit, even when sharing the name
+	assert.Equal(t, http.StatusOK, send(uuid.New(), "ci").Code)
+}
+
+func TestRateLimit_OnPublicRoutes_ShouldNotLimitRequests(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterAuthenticateUsecase(router, &mockAuthenticateUsecase{err: usecase.ErrInvalidCredentials})
+	rest.RegisterRateLimit(router, 1, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/ping", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
+
+// recordedSpans holds every span ended on the tests, since the global tracer provider can only be set once.
+var recordedSpans = func() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}()
+
+func TestTracing_ShouldRecordSpanPerRequestContinuingIncomingTrace(t *testing.T) {
+	uc := &mockAuthenticateUsecase{key: entity.APIKey{Name: "ci", Scopes: []string{entity.ScopeRead}}}
+	router := rest.NewServer()
+	rest.RegisterAuthenticateUsecase(router, uc)
+	router.GET("/projects/:id", func(c *gin.Context) {
+		_, span := tracing.Start(c, "GetProject")
+		span.End()
+		c.Status(http.StatusInternalServerError)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/projects/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134", nil)
+	req.Header.Set("Authorization", "Bearer srk_0a1b2c3d")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(w, req)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recordedSpans.Ended() {
+		if span.SpanContext().TraceID().String() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+			spans[span.Name()] = span
+		}
+	}
+	require.Len(t, spans, 2)
+
+	request := spans["GET /projects/:id"]
+	require.NotNil(t, request)
+	assert.Equal(t, "00f067aa0ba902b7", request.Parent().SpanID().String())
+	assert.Equal(t, trace.SpanKindServer, request.SpanKind())
+	assert.Equal(t, codes.Error, request.Status().Code)
+	assert.Contains(t, request.Attributes(), attribute.Int("http.status_code", 500))
+	assert.Contains(t, request.Attributes(), attribute.String("principal", "ci"))
+	assert.Equal(t, request.SpanContext().SpanID(), spans["GetProject"].Parent().SpanID())
+}
+
+type mockAuthenticateUsecase struct {
+	key   entity.APIKey
+	err   error
+	token string
+}
+
+func (m *mockAuthenticateUsecase) Process(ctx context.Context, key string) (entity.APIKey, error) {
+	m.token = key
+	return m.key, m.err
+}
@@ -1,9 +1,11 @@
 package rest
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/eroatta/src-reader/entity"
@@ -14,6 +16,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// maxProjectsPageSize is the maximum number of projects that can be requested on a single page.
+const maxProjectsPageSize = 100
+
 func init() {
 	regex := regexp.MustCompile(`^[a-zA-Z0-9-_]+/[a-zA-Z0-9-_]+$`)
 	err := requestValidator.RegisterValidation("reference", func(fl validator.FieldLevel) bool {
@@ -25,7 +30,9 @@ func init() {
 }
 
 type postCreateProjectCommand struct {
-	Reference string `json:"reference" validate:"reference"`
+	Reference string   `json:"reference" validate:"reference"`
+	Include   []string `json:"include" validate:"max=50,dive,required,max=256"`
+	Exclude   []string `json:"exclude" validate:"max=50,dive,required,max=256"`
 }
 
 type projectResponse struct {
@@ -34,6 +41,7 @@ type projectResponse struct {
 	Reference  string             `json:"reference"`
 	Metadata   metadataResponse   `json:"metadata"`
 	SourceCode sourcecodeResponse `json:"source_code"`
+	Filter     *filterResponse    `json:"filter,omitempty"`
 }
 
 type metadataResponse struct {
@@ -59,6 +67,24 @@ type sourcecodeResponse struct {
 	Files    []string `json:"files"`
 }
 
+type filterResponse struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+type projectsResponse struct {
+	Projects []projectItemResponse `json:"projects"`
+	Page     int                   `json:"page"`
+	PerPage  int                   `json:"per_page"`
+	Total    int                   `json:"total"`
+}
+
+type projectItemResponse struct {
+	projectResponse
+	CreatedAt time.Time `json:"created_at"`
+	Accuracy  *float64  `json:"accuracy,omitempty"`
+}
+
 // RegisterCreateProjectUsecase defines the proper URI and HTTP method to execute the CreateProjectUsecase.
 func RegisterCreateProjectUsecase(r *gin.Engine, uc usecase.CreateProjectUsecase) *gin.Engine {
 	r.POST("/projects", func(c *gin.Context) {
@@ -83,8 +109,18 @@ func createProject(ctx *gin.Context, uc usecase.CreateProjectUsecase) {
 		return
 	}
 
-	project, err := uc.Process(ctx, cmd.Reference)
-	if err != nil {
+	project, err := uc.Process(ctx, cmd.Reference, entity.FileFilter{Include: cmd.Include, Exclude: cmd.Exclude})
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrInvalidFileFilter:
+		setBadRequestResponse(ctx, errors.New("include and exclude must hold valid glob patterns"))
+		return
+	case usecase.ErrProjectTooLarge:
+		rejections.WithLabelValues(rejectedBySize, principal(ctx)).Inc()
+		setPayloadTooLargeResponse(ctx, fmt.Errorf("project %s exceeds the accepted size", cmd.Reference))
+		return
+	default:
 		log.WithError(err).Error("unexpected error executing createProjectUsecase")
 		setInternalErrorResponse(ctx, err)
 		return
@@ -125,6 +161,106 @@ func getProject(ctx *gin.Context, uc usecase.GetProjectUsecase) {
 	ctx.JSON(http.StatusOK, toProjectResponse(project))
 }
 
+// RegisterListProjectsUsecase defines the proper URI and HTTP method to execute the ListProjectsUsecase.
+func RegisterListProjectsUsecase(r *gin.Engine, uc usecase.ListProjectsUsecase) *gin.Engine {
+	r.GET("/projects", func(c *gin.Context) {
+		listProjects(c, uc)
+	})
+
+	return r
+}
+
+func listProjects(ctx *gin.Context, uc usecase.ListProjectsUsecase) {
+	pageNumber, perPage, err := pagination(ctx)
+	if err != nil {
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	query, err := newProjectQuery(ctx)
+	if err != nil {
+		setBadRequestResponse(ctx, err)
+		return
+	}
+	query.Offset = (pageNumber - 1) * perPage
+	query.Limit = perPage
+
+	page, err := uc.Process(ctx, query)
+	if err != nil {
+		log.WithError(err).Error("unexpected error executing listProjectsUsecase")
+		setInternalErrorResponse(ctx, errors.New("error accessing projects"))
+		return
+	}
+
+	response := projectsResponse{
+		Projects: make([]projectItemResponse, len(page.Projects)),
+		Page:     pageNumber,
+		PerPage:  perPage,
+		Total:    page.Total,
+	}
+	for i, project := range page.Projects {
+		response.Projects[i] = projectItemResponse{
+			projectResponse: toProjectResponse(project),
+			CreatedAt:       project.CreatedAt,
+		}
+		if accuracy, ok := page.Accuracy[project.ID]; ok {
+			response.Projects[i].Accuracy = &accuracy
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// pagination reads the requested page number and page size from the query parameters on the request.
+func pagination(ctx *gin.Context) (int, int, error) {
+	page, perPage := 1, usecase.DefaultProjectsPageSize
+	if value := ctx.Query("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf("invalid page '%s'", value)
+		}
+		page = parsed
+	}
+
+	if value := ctx.Query("per_page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxProjectsPageSize {
+			return 0, 0, fmt.Errorf("invalid per_page '%s'", value)
+		}
+		perPage = parsed
+	}
+
+	return page, perPage, nil
+}
+
+// newProjectQuery builds an entity.ProjectQuery from the query parameters on the request.
+func newProjectQuery(ctx *gin.Context) (entity.ProjectQuery, error) {
+	query := entity.ProjectQuery{
+		Owner:   ctx.Query("owner"),
+		License: ctx.Query("license"),
+		Status:  ctx.Query("status"),
+		Text:    ctx.Query("q"),
+	}
+
+	if value := ctx.Query("fork"); value != "" {
+		fork, err := strconv.ParseBool(value)
+		if err != nil {
+			return query, fmt.Errorf("invalid fork '%s'", value)
+		}
+		query.Fork = &fork
+	}
+
+	switch value := ctx.Query("sort"); value {
+	case entity.ProjectSortDefault, entity.ProjectSortCreated, entity.ProjectSortCreatedDesc,
+		entity.ProjectSortStars, entity.ProjectSortStarsDesc, entity.ProjectSortAccuracy, entity.ProjectSortAccuracyDesc:
+		query.Sort = value
+	default:
+		return query, fmt.Errorf("invalid sort '%s'", value)
+	}
+
+	return query, nil
+}
+
 // RegisterDeleteProjectUsecase defines the proper URI and HTTP method to execute the DeleteProjectUsecase.
 func RegisterDeleteProjectUsecase(r *gin.Engine, uc usecase.DeleteProjectUsecase) *gin.Engine {
 	r.DELETE("/projects/:id", func(c *gin.Context) {
@@ -152,7 +288,7 @@ func deleteProject(ctx *gin.Context, uc usecase.DeleteProjectUsecase) {
 }
 
 func toProjectResponse(project entity.Project) projectResponse {
-	return projectResponse{
+	response := projectResponse{
 		ID:        project.ID.String(),
 		Status:    project.Status,
 		Reference: project.Reference,
@@ -178,4 +314,12 @@ func toProjectResponse(project entity.Project) projectResponse {
 			Files:    project.SourceCode.Files,
 		},
 	}
+	if !project.Filter.IsEmpty() {
+		response.Filter = &filterResponse{
+			Include: project.Filter.Include,
+			Exclude: project.Filter.Exclude,
+		}
+	}
+
+	return response
 }
@@ -129,6 +129,87 @@ func TestPOST_OnProjectCreationHandler_WithInternalError_ShouldReturnHTTP500(t *
 		w.Body.String())
 }
 
+func TestPOST_OnProjectCreationHandler_WithInvalidFilter_ShouldReturnHTTP400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateProjectUsecase(router, mockCreateUsecase{
+		err: usecase.ErrInvalidFileFilter,
+	})
+
+	w := httptest.NewRecorder()
+	body := `{
+		"reference": "eroatta/src-reader",
+		"exclude": ["[a-z*.go"]
+	}`
+	req, _ := http.NewRequest("POST", "/projects", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": [
+				"include and exclude must hold valid glob patterns"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnProjectCreationHandler_WithTooLargeRepository_ShouldReturnHTTP413(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateProjectUsecase(router, mockCreateUsecase{
+		err: usecase.ErrProjectTooLarge,
+	})
+
+	w := httptest.NewRecorder()
+	body := `{
+		"reference": "eroatta/src-reader"
+	}`
+	req, _ := http.NewRequest("POST", "/projects", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "payload_too_large",
+			"message": "the requested element exceeds the accepted size",
+			"details": [
+				"project eroatta/src-reader exceeds the accepted size"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnProjectCreationHandler_WithFilter_ShouldReturnHTTP201WithFilter(t *testing.T) {
+	var filter entity.FileFilter
+	router := rest.NewServer()
+	rest.RegisterCreateProjectUsecase(router, mockCreateUsecase{
+		project: entity.Project{
+			ID:        uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"),
+			Status:    "done",
+			Reference: "src-d/go-siva",
+			Filter: entity.FileFilter{
+				Include: []string{"**/*.go"},
+				Exclude: []string{"cmd/**"},
+			},
+		},
+		filter: &filter,
+	})
+
+	w := httptest.NewRecorder()
+	body := `{
+		"reference": "src-d/go-siva",
+		"include": ["**/*.go"],
+		"exclude": ["cmd/**"]
+	}`
+	req, _ := http.NewRequest("POST", "/projects", strings.NewReader(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, entity.FileFilter{Include: []string{"**/*.go"}, Exclude: []string{"cmd/**"}}, filter)
+	assert.Contains(t, w.Body.String(), `"filter":{"include":["**/*.go"],"exclude":["cmd/**"]}`)
+}
+
 func TestPOST_OnProjectCreationHandler_WithSuccess_ShouldReturnHTTP201(t *testing.T) {
 	now := time.Date(2020, time.May, 5, 22, 0, 0, 0, time.UTC)
 	router := rest.NewServer()
@@ -409,12 +490,153 @@ func TestDELETE_OnProjectDeleteHandler_WhenAlreadyDeletedProject_ShouldReturn204
 	assert.Equal(t, http.StatusNoContent, w.Code)
 }
 
+func TestGET_OnProjectListHandler_WithInvalidParameters_ShouldReturnHTTP400(t *testing.T) {
+	cases := []struct {
+		name     string
+		params   string
+		expected string
+	}{
+		{"invalid_page", "page=0", "invalid page '0'"},
+		{"invalid_per_page", "per_page=101", "invalid per_page '101'"},
+		{"invalid_fork", "fork=maybe", "invalid fork 'maybe'"},
+		{"invalid_sort", "sort=name", "invalid sort 'name'"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := rest.NewServer()
+			rest.RegisterListProjectsUsecase(router, nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/projects?"+c.params, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `
+				{
+					"name": "validation_error",
+					"message": "missing or invalid data",
+					"details": ["`+c.expected+`"]
+				}`,
+				w.Body.String())
+		})
+	}
+}
+
+func TestGET_OnProjectListHandler_WithInternalError_ShouldReturnHTTP500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListProjectsUsecase(router, &mockListUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/projects", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGET_OnProjectListHandler_ShouldReturnHTTP200(t *testing.T) {
+	projectID := uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134")
+	otherID := uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be")
+	uc := &mockListUsecase{
+		page: entity.ProjectPage{
+			Projects: []entity.Project{
+				{
+					ID:         projectID,
+					Status:     "done",
+					Reference:  "eroatta/test",
+					CreatedAt:  time.Date(2020, time.May, 10, 12, 0, 0, 0, time.UTC),
+					Metadata:   entity.Metadata{Owner: "eroatta", License: "mit", Stargazers: 10},
+					SourceCode: entity.SourceCode{Files: []string{"main.go"}},
+				},
+				{
+					ID:         otherID,
+					Status:     "in_progress",
+					Reference:  "eroatta/other",
+					CreatedAt:  time.Date(2020, time.May, 9, 12, 0, 0, 0, time.UTC),
+					Metadata:   entity.Metadata{Owner: "eroatta", License: "mit"},
+					SourceCode: entity.SourceCode{Files: []string{}},
+				},
+			},
+			Accuracy: map[uuid.UUID]float64{projectID: 0.75},
+			Total:    7,
+		},
+	}
+	router := rest.NewServer()
+	rest.RegisterListProjectsUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET",
+		"/projects?owner=eroatta&license=mit&fork=false&status=done&q=test&sort=-accuracy&page=2&per_page=5", nil)
+	router.ServeHTTP(w, req)
+
+	fork := false
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, entity.ProjectQuery{Owner: "eroatta", License: "mit", Fork: &fork, Status: "done", Text: "test",
+		Sort: entity.ProjectSortAccuracyDesc, Offset: 5, Limit: 5}, uc.query)
+	assert.JSONEq(t, `
+		{
+			"projects": [
+				{
+					"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+					"status": "done",
+					"reference": "eroatta/test",
+					"created_at": "2020-05-10T12:00:00Z",
+					"accuracy": 0.75,
+					"metadata": {
+						"remote_id": "", "owner": "eroatta", "fullname": "", "description": "", "clone_url": "",
+						"branch": "", "license": "mit", "created_at": null, "is_fork": false, "size": 0,
+						"stargazers": 10, "watchers": 0, "forks": 0
+					},
+					"source_code": {"hash": "", "location": "", "files": ["main.go"]}
+				},
+				{
+					"id": "f9b76fde-c342-4328-8650-85da8f21e2be",
+					"status": "in_progress",
+					"reference": "eroatta/other",
+					"created_at": "2020-05-09T12:00:00Z",
+					"metadata": {
+						"remote_id": "", "owner": "eroatta", "fullname": "", "description": "", "clone_url": "",
+						"branch": "", "license": "mit", "created_at": null, "is_fork": false, "size": 0,
+						"stargazers": 0, "watchers": 0, "forks": 0
+					},
+					"source_code": {"hash": "", "location": "", "files": []}
+				}
+			],
+			"page": 2,
+			"per_page": 5,
+			"total": 7
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnProjectListHandler_WithoutParameters_ShouldUseDefaultPagination(t *testing.T) {
+	uc := &mockListUsecase{
+		page: entity.ProjectPage{Projects: []entity.Project{}},
+	}
+	router := rest.NewServer()
+	rest.RegisterListProjectsUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/projects", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, entity.ProjectQuery{Limit: usecase.DefaultProjectsPageSize}, uc.query)
+	assert.JSONEq(t, `{"projects": [], "page": 1, "per_page": 20, "total": 0}`, w.Body.String())
+}
+
 type mockCreateUsecase struct {
 	project entity.Project
 	err     error
+	// filter holds the received filter, if provided.
+	filter *entity.FileFilter
 }
 
-func (m mockCreateUsecase) Process(ctx context.Context, projectRef string) (entity.Project, error) {
+func (m mockCreateUsecase) Process(ctx context.Context, projectRef string,
+	filter entity.FileFilter) (entity.Project, error) {
+	if m.filter != nil {
+		*m.filter = filter
+	}
 	return m.project, m.err
 }
 
@@ -434,3 +656,14 @@ type mockDeleteUsecase struct {
 func (m mockDeleteUsecase) Process(ctx context.Context, ID uuid.UUID) error {
 	return m.err
 }
+
+type mockListUsecase struct {
+	page  entity.ProjectPage
+	query entity.ProjectQuery
+	err   error
+}
+
+func (m *mockListUsecase) Process(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error) {
+	m.query = query
+	return m.page, m.err
+}
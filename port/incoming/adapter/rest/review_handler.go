@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type postNotifyReviewCommand struct {
+	PullRequest int      `json:"pull_request" validate:"min=1"`
+	Commit      string   `json:"commit" validate:"required"`
+	Files       []string `json:"files"`
+	MaxScore    float64  `json:"max_score" validate:"min=0,max=1"`
+	MaxComments int      `json:"max_comments" validate:"min=0"`
+}
+
+type reviewResponse struct {
+	ProjectRef  string                  `json:"project_ref"`
+	PullRequest int                     `json:"pull_request"`
+	Commit      string                  `json:"commit"`
+	Comments    []reviewCommentResponse `json:"comments"`
+}
+
+type reviewCommentResponse struct {
+	Path       string  `json:"path"`
+	Line       int     `json:"line"`
+	Identifier string  `json:"identifier"`
+	Suggestion string  `json:"suggestion"`
+	Score      float64 `json:"score"`
+}
+
+// RegisterNotifyReviewUsecase defines the proper URI and HTTP method to execute the NotifyReviewUsecase.
+func RegisterNotifyReviewUsecase(r *gin.Engine, uc usecase.NotifyReviewUsecase) *gin.Engine {
+	r.POST("/analysis/:id/review", func(c *gin.Context) {
+		notifyReview(c, uc)
+	})
+
+	return r
+}
+
+func notifyReview(ctx *gin.Context, uc usecase.NotifyReviewUsecase) {
+	analysisID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		setNotFoundResponse(ctx, fmt.Errorf("analysis %s can't be found", ctx.Param("id")))
+		return
+	}
+
+	var cmd postNotifyReviewCommand
+	if err := ctx.ShouldBindJSON(&cmd); err != nil {
+		log.WithError(err).Debug("failed to bind JSON body")
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	if err := requestValidator.Struct(cmd); err != nil {
+		log.WithError(err).Debug("failed while validating the command")
+		setBadRequestOnValidationResponse(ctx, err)
+		return
+	}
+
+	review, err := uc.Process(ctx, analysisID, entity.ReviewRequest{
+		PullRequest: cmd.PullRequest,
+		Commit:      cmd.Commit,
+		Files:       cmd.Files,
+		MaxScore:    cmd.MaxScore,
+		MaxComments: cmd.MaxComments,
+	})
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrAnalysisNotFound, usecase.ErrProjectNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("analysis %v can't be found", analysisID))
+		return
+	case usecase.ErrIdentifiersNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("identifiers for analysis %v can't be found", analysisID))
+		return
+	case usecase.ErrNotificationRateLimited:
+		ctx.JSON(http.StatusTooManyRequests, errorResponse{
+			Name:    "rate_limited",
+			Message: "too many requests",
+			Details: []string{err.Error()},
+		})
+		return
+	case usecase.ErrUnableToNotify:
+		ctx.JSON(http.StatusBadGateway, errorResponse{
+			Name:    "bad_gateway",
+			Message: "unable to reach the notification destination",
+			Details: []string{err.Error()},
+		})
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing notifyReviewUsecase")
+		setInternalErrorResponse(ctx, errors.New("error posting review"))
+		return
+	}
+
+	response := reviewResponse{
+		ProjectRef:  review.ProjectRef,
+		PullRequest: review.PullRequest,
+		Commit:      review.Commit,
+		Comments:    make([]reviewCommentResponse, 0, len(review.Comments)),
+	}
+	for _, comment := range review.Comments {
+		response.Comments = append(response.Comments, reviewCommentResponse{
+			Path:       comment.Path,
+			Line:       comment.Line,
+			Identifier: comment.Identifier,
+			Suggestion: comment.Suggestion,
+			Score:      comment.Score,
+		})
+	}
+
+	ctx.JSON(http.StatusCreated, response)
+}
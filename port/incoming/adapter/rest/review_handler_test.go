@@ -0,0 +1,128 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPOST_OnReviewHandler_WhenInvalidAnalysisID_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterNotifyReviewUsecase(router, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/analysis/invalid-id/review", strings.NewReader(`{}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPOST_OnReviewHandler_WhenInvalidBody_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterNotifyReviewUsecase(router, &mockNotifyReviewUsecase{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/review",
+		strings.NewReader(`{"pull_request": 0, "commit": "bc9968d", "max_score": 2}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": [
+				"invalid field 'pull_request' with value 0",
+				"invalid field 'max_score' with value 2"
+			]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnReviewHandler_WhenUsecaseFails_ShouldReturnError(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"analysis_not_found", usecase.ErrAnalysisNotFound, http.StatusNotFound},
+		{"project_not_found", usecase.ErrProjectNotFound, http.StatusNotFound},
+		{"identifiers_not_found", usecase.ErrIdentifiersNotFound, http.StatusNotFound},
+		{"rate_limited", usecase.ErrNotificationRateLimited, http.StatusTooManyRequests},
+		{"unable_to_notify", usecase.ErrUnableToNotify, http.StatusBadGateway},
+		{"unexpected", usecase.ErrUnexpected, http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := rest.NewServer()
+			rest.RegisterNotifyReviewUsecase(router, &mockNotifyReviewUsecase{err: c.err})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/review",
+				strings.NewReader(`{"pull_request": 7, "commit": "bc9968d"}`))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, c.status, w.Code)
+		})
+	}
+}
+
+func TestPOST_OnReviewHandler_ShouldReturn201(t *testing.T) {
+	uc := &mockNotifyReviewUsecase{
+		review: entity.Review{
+			ProjectRef:  "eroatta/test",
+			PullRequest: 7,
+			Commit:      "bc9968d",
+			Comments: []entity.ReviewComment{
+				{Path: "main.go", Line: 5, Identifier: "cfg", Suggestion: "config", Score: 0.4, Original: "var cfg = 1"},
+			},
+		},
+	}
+	router := rest.NewServer()
+	rest.RegisterNotifyReviewUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/analysis/ed2cd46a-4afd-4d49-a6ea-1c8d12d40134/review",
+		strings.NewReader(`{"pull_request": 7, "commit": "bc9968d", "files": ["main.go"], "max_score": 0.6, "max_comments": 5}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `
+		{
+			"project_ref": "eroatta/test",
+			"pull_request": 7,
+			"commit": "bc9968d",
+			"comments": [
+				{"path": "main.go", "line": 5, "identifier": "cfg", "suggestion": "config", "score": 0.4}
+			]
+		}`,
+		w.Body.String())
+	assert.Equal(t, entity.ReviewRequest{
+		PullRequest: 7,
+		Commit:      "bc9968d",
+		Files:       []string{"main.go"},
+		MaxScore:    0.6,
+		MaxComments: 5,
+	}, uc.request)
+}
+
+type mockNotifyReviewUsecase struct {
+	review  entity.Review
+	err     error
+	request entity.ReviewRequest
+}
+
+func (m *mockNotifyReviewUsecase) Process(ctx context.Context, analysisID uuid.UUID,
+	request entity.ReviewRequest) (entity.Review, error) {
+	m.request = request
+	return m.review, m.err
+}
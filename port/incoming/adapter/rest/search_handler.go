@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxSearchResults is the maximum number of results that can be requested on a single search.
+const maxSearchResults = 100
+
+type searchResponse struct {
+	Query   string                 `json:"query"`
+	Results []searchResultResponse `json:"results"`
+}
+
+type searchResultResponse struct {
+	AnalysisID    string  `json:"analysis_id"`
+	Project       string  `json:"project"`
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Type          string  `json:"type"`
+	Package       string  `json:"package"`
+	File          string  `json:"file"`
+	Position      int     `json:"position"`
+	Normalization string  `json:"normalization"`
+	Score         float64 `json:"score"`
+}
+
+// RegisterSearchIdentifiersUsecase defines the proper URI and HTTP method to execute the
+// SearchIdentifiersUsecase.
+func RegisterSearchIdentifiersUsecase(r *gin.Engine, uc usecase.SearchIdentifiersUsecase) *gin.Engine {
+	r.GET("/search", func(c *gin.Context) {
+		searchIdentifiers(c, uc)
+	})
+
+	return r
+}
+
+func searchIdentifiers(ctx *gin.Context, uc usecase.SearchIdentifiersUsecase) {
+	query, err := newSearchQuery(ctx)
+	if err != nil {
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	results, err := uc.Process(ctx, query)
+	if err != nil {
+		log.WithError(err).Error("unexpected error executing searchIdentifiersUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error searching identifiers matching '%s'", query.Text))
+		return
+	}
+
+	response := searchResponse{
+		Query:   query.Text,
+		Results: make([]searchResultResponse, len(results)),
+	}
+	for i, result := range results {
+		response.Results[i] = searchResultResponse{
+			AnalysisID:    result.AnalysisID.String(),
+			Project:       result.ProjectRef,
+			ID:            result.IdentifierID,
+			Name:          result.Name,
+			Type:          result.Type.String(),
+			Package:       result.Package,
+			File:          result.File,
+			Position:      int(result.Position),
+			Normalization: result.Normalization,
+			Score:         result.Score,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// newSearchQuery builds an entity.SearchQuery from the query parameters on the request. Prefix and fuzzy
+// matching are enabled unless explicitly disabled.
+func newSearchQuery(ctx *gin.Context) (entity.SearchQuery, error) {
+	query := entity.SearchQuery{
+		Text:       strings.TrimSpace(ctx.Query("q")),
+		ProjectRef: ctx.Query("project"),
+		Prefix:     true,
+		Fuzzy:      true,
+	}
+	if query.Text == "" {
+		return query, fmt.Errorf("missing q")
+	}
+
+	for param, enabled := range map[string]*bool{"prefix": &query.Prefix, "fuzzy": &query.Fuzzy} {
+		if value := ctx.Query(param); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s '%s'", param, value)
+			}
+			*enabled = parsed
+		}
+	}
+
+	if value := ctx.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxSearchResults {
+			return query, fmt.Errorf("invalid limit '%s'", value)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
@@ -0,0 +1,141 @@
+package rest_test
+
+import (
+	"context"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGET_OnSearchHandler_WhenInvalidParameters_ShouldReturn400(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "missing_q", query: "q=+", expected: "missing q"},
+		{name: "prefix", query: "q=cfg&prefix=maybe", expected: "invalid prefix 'maybe'"},
+		{name: "fuzzy", query: "q=cfg&fuzzy=maybe", expected: "invalid fuzzy 'maybe'"},
+		{name: "limit", query: "q=cfg&limit=1000", expected: "invalid limit '1000'"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := rest.NewServer()
+			rest.RegisterSearchIdentifiersUsecase(router, nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/search?"+c.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `
+				{
+					"name": "validation_error",
+					"message": "missing or invalid data",
+					"details": ["`+c.expected+`"]
+				}`,
+				w.Body.String())
+		})
+	}
+}
+
+func TestGET_OnSearchHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterSearchIdentifiersUsecase(router, &mockSearchIdentifiersUsecase{
+		err: usecase.ErrUnexpected,
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=cfg", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "internal_error",
+			"message": "internal server error",
+			"details": ["error searching identifiers matching 'cfg'"]
+		}`,
+		w.Body.String())
+}
+
+func TestGET_OnSearchHandler_WhenNoResults_ShouldReturn200(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterSearchIdentifiersUsecase(router, &mockSearchIdentifiersUsecase{
+		results: []entity.SearchResult{},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=cfg", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"query": "cfg", "results": []}`, w.Body.String())
+}
+
+func TestGET_OnSearchHandler_ShouldReturn200(t *testing.T) {
+	uc := &mockSearchIdentifiersUsecase{
+		results: []entity.SearchResult{
+			{
+				AnalysisID:    uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+				ProjectRef:    "eroatta/reader",
+				IdentifierID:  "reader/file.go+++readCfg",
+				Name:          "readCfg",
+				Type:          token.FUNC,
+				Package:       "reader",
+				File:          "reader/file.go",
+				Position:      token.Pos(10),
+				Normalization: "readConfig",
+				Score:         3.5,
+			},
+		},
+	}
+	router := rest.NewServer()
+	rest.RegisterSearchIdentifiersUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=read+config&project=eroatta/reader&fuzzy=false&limit=5", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, entity.SearchQuery{Text: "read config", ProjectRef: "eroatta/reader", Prefix: true, Limit: 5},
+		uc.query)
+	assert.JSONEq(t, `
+		{
+			"query": "read config",
+			"results": [
+				{
+					"analysis_id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+					"project": "eroatta/reader",
+					"id": "reader/file.go+++readCfg",
+					"name": "readCfg",
+					"type": "func",
+					"package": "reader",
+					"file": "reader/file.go",
+					"position": 10,
+					"normalization": "readConfig",
+					"score": 3.5
+				}
+			]
+		}`,
+		w.Body.String())
+}
+
+type mockSearchIdentifiersUsecase struct {
+	results []entity.SearchResult
+	query   entity.SearchQuery
+	err     error
+}
+
+func (m *mockSearchIdentifiersUsecase) Process(ctx context.Context, query entity.SearchQuery) ([]entity.SearchResult, error) {
+	m.query = query
+	return m.results, m.err
+}
@@ -19,6 +19,7 @@ var requestValidator = validator.New()
 func NewServer() *gin.Engine {
 	r := gin.Default()
 
+	r.Use(tracingHandler)
 	setMetricsCollectors(r)
 
 	r.GET("/ping", pingHandler)
@@ -82,6 +83,46 @@ func setNotFoundResponse(ctx *gin.Context, err error) {
 	ctx.JSON(http.StatusNotFound, errResponse)
 }
 
+func setUnauthorizedResponse(ctx *gin.Context, err error) {
+	errResponse := errorResponse{
+		Name:    "unauthorized",
+		Message: "missing or invalid credentials",
+		Details: []string{err.Error()},
+	}
+
+	ctx.JSON(http.StatusUnauthorized, errResponse)
+}
+
+func setForbiddenResponse(ctx *gin.Context, err error) {
+	errResponse := errorResponse{
+		Name:    "forbidden",
+		Message: "insufficient permissions",
+		Details: []string{err.Error()},
+	}
+
+	ctx.JSON(http.StatusForbidden, errResponse)
+}
+
+func setPayloadTooLargeResponse(ctx *gin.Context, err error) {
+	errResponse := errorResponse{
+		Name:    "payload_too_large",
+		Message: "the requested element exceeds the accepted size",
+		Details: []string{err.Error()},
+	}
+
+	ctx.JSON(http.StatusRequestEntityTooLarge, errResponse)
+}
+
+func setTooManyRequestsResponse(ctx *gin.Context, err error) {
+	errResponse := errorResponse{
+		Name:    "too_many_requests",
+		Message: "rate limit or quota exceeded",
+		Details: []string{err.Error()},
+	}
+
+	ctx.JSON(http.StatusTooManyRequests, errResponse)
+}
+
 func setInternalErrorResponse(ctx *gin.Context, err error) {
 	errResponse := errorResponse{
 		Name:    "internal_error",
@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// Headers set by each provider to identify the event and sign its payload. Gitea also sets the GitHub headers,
+// so it must be detected first.
+const (
+	giteaEventHeader      = "X-Gitea-Event"
+	giteaSignatureHeader  = "X-Gitea-Signature"
+	githubEventHeader     = "X-GitHub-Event"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	gitlabEventHeader     = "X-Gitlab-Event"
+	gitlabTokenHeader     = "X-Gitlab-Token"
+)
+
+// maxWebhookBodySize is the largest notification accepted, read before its signature can be verified.
+const maxWebhookBodySize = 5 << 20
+
+// pushPayload holds the fields of a push notification used by GitHub, GitLab and Gitea. GitLab identifies
+// the repository on the project field.
+type pushPayload struct {
+	Ref        string `json:"ref"`
+	After      string `json:"after"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		CloneURL string `json:"clone_url"`
+	} `json:"repository"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		GitHTTPURL        string `json:"git_http_url"`
+	} `json:"project"`
+}
+
+type pushResponse struct {
+	Status     string `json:"status"`
+	Provider   string `json:"provider"`
+	ProjectID  string `json:"project_id,omitempty"`
+	ProjectRef string `json:"project_ref,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+}
+
+// RegisterHandlePushUsecase defines the proper URI and HTTP method to execute the HandlePushUsecase. Notifications
+// sent to /webhooks/git are handled on the default workspace, while the ones sent to /webhooks/git/:workspace are
+// handled on the given workspace. Every notification must be signed using the secret of its workspace, so it can't
+// reach the projects of any other workspace.
+func RegisterHandlePushUsecase(r *gin.Engine, uc usecase.HandlePushUsecase,
+	suc usecase.GetWebhookSecretUsecase) *gin.Engine {
+	r.POST("/webhooks/git", func(c *gin.Context) {
+		handlePush(c, uc, suc)
+	})
+	r.POST("/webhooks/git/:workspace", func(c *gin.Context) {
+		workspaceID, err := uuid.Parse(c.Param("workspace"))
+		if err != nil {
+			setBadRequestResponse(c, fmt.Errorf("invalid workspace %s", c.Param("workspace")))
+			return
+		}
+		c.Set(entity.WorkspaceContextKey, workspaceID)
+
+		handlePush(c, uc, suc)
+	})
+
+	return r
+}
+
+func handlePush(ctx *gin.Context, uc usecase.HandlePushUsecase, suc usecase.GetWebhookSecretUsecase) {
+	provider, event := webhookProvider(ctx.Request.Header)
+	if provider == "" {
+		setBadRequestResponse(ctx, errors.New("unsupported webhook provider"))
+		return
+	}
+
+	secret, err := suc.Process(ctx)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrWorkspaceNotFound:
+		// answered as an invalid signature, so the existing workspaces can't be told apart
+		secret = ""
+	default:
+		log.WithError(err).Error("unexpected error executing getWebhookSecretUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error verifying %s webhook", provider))
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxWebhookBodySize))
+	switch {
+	case err == nil:
+		// do nothing
+	case len(body) >= maxWebhookBodySize:
+		setPayloadTooLargeResponse(ctx, fmt.Errorf("%s webhook exceeds %d bytes", provider, maxWebhookBodySize))
+		return
+	default:
+		log.WithError(err).Debug("failed to read webhook body")
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	if !verifyWebhook(provider, ctx.Request.Header, body, secret) {
+		setUnauthorizedResponse(ctx, fmt.Errorf("invalid signature for %s webhook", provider))
+		return
+	}
+
+	// other events, such as the ping sent when the webhook is created, are acknowledged but ignored
+	if event != "push" && event != "Push Hook" {
+		ctx.JSON(http.StatusOK, pushResponse{Status: "ignored", Provider: provider})
+		return
+	}
+
+	push, err := newPushEvent(provider, body)
+	if err != nil {
+		log.WithError(err).Debug("failed to decode push payload")
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	project, err := uc.Process(ctx, push)
+	response := pushResponse{
+		Status:     "queued",
+		Provider:   provider,
+		ProjectID:  project.ID.String(),
+		ProjectRef: project.Reference,
+		Ref:        push.Ref,
+		Commit:     push.Commit,
+	}
+	switch err {
+	case nil:
+		ctx.JSON(http.StatusAccepted, response)
+	case usecase.ErrPushIgnored:
+		response.Status = "ignored"
+		ctx.JSON(http.StatusOK, response)
+	case usecase.ErrProjectNotFound:
+		setNotFoundResponse(ctx, fmt.Errorf("project for repository %s can't be found", push.Repository))
+	case usecase.ErrTooManyPendingAnalyses:
+		ctx.JSON(http.StatusServiceUnavailable, errorResponse{
+			Name:    "unavailable",
+			Message: "service unavailable",
+			Details: []string{err.Error()},
+		})
+	default:
+		log.WithError(err).Error("unexpected error executing handlePushUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error handling push for repository %s", push.Repository))
+	}
+}
+
+// webhookProvider detects the provider sending the notification, along with the notified event.
+func webhookProvider(header http.Header) (string, string) {
+	switch {
+	case header.Get(giteaEventHeader) != "":
+		return entity.ProviderGitea, header.Get(giteaEventHeader)
+	case header.Get(githubEventHeader) != "":
+		return entity.ProviderGitHub, header.Get(githubEventHeader)
+	case header.Get(gitlabEventHeader) != "":
+		return entity.ProviderGitLab, header.Get(gitlabEventHeader)
+	default:
+		return "", ""
+	}
+}
+
+// verifyWebhook checks the notification was sent by a provider knowing the secret. GitHub and Gitea sign the
+// payload using HMAC-SHA256, while GitLab sends the secret itself.
+func verifyWebhook(provider string, header http.Header, body []byte, secret string) bool {
+	if secret == "" {
+		return false
+	}
+
+	switch provider {
+	case entity.ProviderGitHub:
+		signature := header.Get(githubSignatureHeader)
+		return strings.HasPrefix(signature, "sha256=") &&
+			hmac.Equal([]byte(strings.TrimPrefix(signature, "sha256=")), []byte(sign(body, secret)))
+	case entity.ProviderGitea:
+		return hmac.Equal([]byte(header.Get(giteaSignatureHeader)), []byte(sign(body, secret)))
+	case entity.ProviderGitLab:
+		return subtle.ConstantTimeCompare([]byte(header.Get(gitlabTokenHeader)), []byte(secret)) == 1
+	default:
+		return false
+	}
+}
+
+// sign computes the hex-encoded HMAC-SHA256 for the payload.
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newPushEvent(provider string, body []byte) (entity.PushEvent, error) {
+	var payload pushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return entity.PushEvent{}, err
+	}
+
+	push := entity.PushEvent{
+		Provider:   provider,
+		Repository: payload.Repository.FullName,
+		CloneURL:   payload.Repository.CloneURL,
+		Ref:        payload.Ref,
+		Commit:     payload.After,
+	}
+	if provider == entity.ProviderGitLab {
+		push.Repository = payload.Project.PathWithNamespace
+		push.CloneURL = payload.Project.GitHTTPURL
+	}
+
+	if push.Repository == "" || push.Ref == "" || push.Commit == "" {
+		return entity.PushEvent{}, errors.New("missing repository, ref or commit on push payload")
+	}
+
+	return push, nil
+}
+
+// NewWebhookRequest creates a push notification for the given URL, including the headers sent by the provider,
+// and signed using the secret. It allows replaying stored payloads against a running server.
+func NewWebhookRequest(url string, provider string, body []byte, secret string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	switch provider {
+	case entity.ProviderGitHub:
+		req.Header.Set(githubEventHeader, "push")
+		req.Header.Set(githubSignatureHeader, "sha256="+sign(body, secret))
+	case entity.ProviderGitea:
+		req.Header.Set(giteaEventHeader, "push")
+		req.Header.Set(giteaSignatureHeader, sign(body, secret))
+	case entity.ProviderGitLab:
+		req.Header.Set(gitlabEventHeader, "Push Hook")
+		req.Header.Set(gitlabTokenHeader, secret)
+	default:
+		return nil, fmt.Errorf("unsupported webhook provider %s", provider)
+	}
+
+	return req, nil
+}
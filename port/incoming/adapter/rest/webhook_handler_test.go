@@ -0,0 +1,386 @@
+package rest_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookSecret = "s3cr3t"
+
+func fixture(t *testing.T, provider string) []byte {
+	body, err := ioutil.ReadFile("../../../../config/webhooks/" + provider + "_push.json")
+	require.NoError(t, err)
+	return body
+}
+
+func TestPOST_OnWebhookHandler_WhenUnsupportedProvider_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{}, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/webhooks/git", bytes.NewReader(fixture(t, entity.ProviderGitHub)))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": ["unsupported webhook provider"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnWebhookHandler_WhenInvalidSignature_ShouldReturn401(t *testing.T) {
+	for _, provider := range []string{entity.ProviderGitHub, entity.ProviderGitLab, entity.ProviderGitea} {
+		t.Run(provider, func(t *testing.T) {
+			uc := &mockHandlePushUsecase{}
+			router := rest.NewServer()
+			rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+			w := httptest.NewRecorder()
+			req, _ := rest.NewWebhookRequest("/webhooks/git", provider, fixture(t, provider), "another")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.JSONEq(t, `
+				{
+					"name": "unauthorized",
+					"message": "missing or invalid credentials",
+					"details": ["invalid signature for `+provider+` webhook"]
+				}`,
+				w.Body.String())
+			assert.Empty(t, uc.event)
+		})
+	}
+}
+
+func TestPOST_OnWebhookHandler_WhenNoSecret_ShouldReturn401(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{}, mockGetWebhookSecretUsecase{})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, fixture(t, entity.ProviderGitHub), "")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestPOST_OnWebhookHandler_WhenBodyTooLarge_ShouldReturn413(t *testing.T) {
+	uc := &mockHandlePushUsecase{}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	body := bytes.Repeat([]byte(" "), 5<<20+1)
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, body, webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Empty(t, uc.event)
+}
+
+func TestPOST_OnWebhookHandler_WhenSignedByGitHub_ShouldVerifySignature(t *testing.T) {
+	uc := &mockHandlePushUsecase{}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	body := fixture(t, entity.ProviderGitHub)
+	mac := hmac.New(sha256.New, []byte(webhookSecret))
+	mac.Write(body)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/webhooks/git", bytes.NewReader(body))
+	req.Header.Set("X-GitHub-Event", "push")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestPOST_OnWebhookHandler_WhenPingEvent_ShouldReturn200(t *testing.T) {
+	uc := &mockHandlePushUsecase{}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	body := []byte(`{"zen": "Keep it logically awesome."}`)
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, body, webhookSecret)
+	req.Header.Set("X-GitHub-Event", "ping")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "ignored", "provider": "github"}`, w.Body.String())
+	assert.Empty(t, uc.event)
+}
+
+func TestPOST_OnWebhookHandler_WhenInvalidPayload_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{}, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	body := []byte(`{"ref": "refs/heads/master"}`)
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, body, webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": ["missing repository, ref or commit on push payload"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnWebhookHandler_WhenNoProjectFound_ShouldReturn404(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{err: usecase.ErrProjectNotFound},
+		mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, fixture(t, entity.ProviderGitHub),
+		webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "not_found",
+			"message": "resource not found",
+			"details": ["project for repository eroatta/src-reader can't be found"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnWebhookHandler_WhenPushIgnored_ShouldReturn200(t *testing.T) {
+	uc := &mockHandlePushUsecase{
+		project: entity.Project{ID: uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"), Reference: "eroatta/src-reader"},
+		err:     usecase.ErrPushIgnored,
+	}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, fixture(t, entity.ProviderGitHub),
+		webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `
+		{
+			"status": "ignored",
+			"provider": "github",
+			"project_id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+			"project_ref": "eroatta/src-reader",
+			"ref": "refs/heads/master",
+			"commit": "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnWebhookHandler_WhenQueueIsFull_ShouldReturn503(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{err: usecase.ErrTooManyPendingAnalyses},
+		mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, fixture(t, entity.ProviderGitHub),
+		webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestPOST_OnWebhookHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{err: usecase.ErrUnexpected},
+		mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, fixture(t, entity.ProviderGitHub),
+		webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "internal_error",
+			"message": "internal server error",
+			"details": ["error handling push for repository eroatta/src-reader"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnWebhookHandler_WhenPushed_ShouldReturn202(t *testing.T) {
+	tests := []struct {
+		provider string
+		cloneURL string
+	}{
+		{entity.ProviderGitHub, "https://github.com/eroatta/src-reader.git"},
+		{entity.ProviderGitLab, "https://gitlab.com/eroatta/src-reader.git"},
+		{entity.ProviderGitea, "https://gitea.com/eroatta/src-reader.git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			uc := &mockHandlePushUsecase{
+				project: entity.Project{ID: uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"), Reference: "eroatta/src-reader"},
+			}
+			router := rest.NewServer()
+			rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+			w := httptest.NewRecorder()
+			req, _ := rest.NewWebhookRequest("/webhooks/git", tt.provider, fixture(t, tt.provider), webhookSecret)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusAccepted, w.Code)
+			assert.JSONEq(t, `
+				{
+					"status": "queued",
+					"provider": "`+tt.provider+`",
+					"project_id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+					"project_ref": "eroatta/src-reader",
+					"ref": "refs/heads/master",
+					"commit": "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"
+				}`,
+				w.Body.String())
+			assert.Equal(t, entity.PushEvent{
+				Provider:   tt.provider,
+				Repository: "eroatta/src-reader",
+				CloneURL:   tt.cloneURL,
+				Ref:        "refs/heads/master",
+				Commit:     "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+			}, uc.event)
+		})
+	}
+}
+
+func TestPOST_OnWebhookHandler_WhenInvalidWorkspace_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{}, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git/invalid", entity.ProviderGitHub,
+		fixture(t, entity.ProviderGitHub), webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"name": "validation_error", "message": "missing or invalid data", "details": ["invalid workspace invalid"]}`,
+		w.Body.String())
+}
+
+func TestPOST_OnWebhookHandler_WhenWorkspaceNotFound_ShouldReturn401(t *testing.T) {
+	uc := &mockHandlePushUsecase{}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{err: usecase.ErrWorkspaceNotFound})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git/0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+		entity.ProviderGitHub, fixture(t, entity.ProviderGitHub), "")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, uc.event)
+}
+
+func TestPOST_OnWebhookHandler_WhenErrorRetrievingSecret_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, &mockHandlePushUsecase{}, mockGetWebhookSecretUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git", entity.ProviderGitHub, fixture(t, entity.ProviderGitHub), webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestPOST_OnWebhookHandler_WhenSignedWithAnotherWorkspaceSecret_ShouldReturn401(t *testing.T) {
+	uc := &mockHandlePushUsecase{}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{
+		secret:     webhookSecret,
+		workspaces: map[uuid.UUID]string{uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"): "backend"},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git/0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+		entity.ProviderGitHub, fixture(t, entity.ProviderGitHub), webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, uc.event)
+}
+
+func TestPOST_OnWebhookHandler_WhenWorkspaceProvided_ShouldHandlePushOnWorkspace(t *testing.T) {
+	uc := &mockHandlePushUsecase{}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{
+		secret:     webhookSecret,
+		workspaces: map[uuid.UUID]string{uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"): "backend"},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git/0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+		entity.ProviderGitHub, fixture(t, entity.ProviderGitHub), "backend")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"), uc.workspace)
+}
+
+func TestPOST_OnWebhookHandler_WhenWorkspaceQueried_ShouldHandlePushOnDefaultWorkspace(t *testing.T) {
+	uc := &mockHandlePushUsecase{}
+	router := rest.NewServer()
+	rest.RegisterHandlePushUsecase(router, uc, mockGetWebhookSecretUsecase{secret: webhookSecret})
+
+	w := httptest.NewRecorder()
+	req, _ := rest.NewWebhookRequest("/webhooks/git?workspace=0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+		entity.ProviderGitHub, fixture(t, entity.ProviderGitHub), webhookSecret)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, entity.DefaultWorkspaceID, uc.workspace)
+}
+
+type mockHandlePushUsecase struct {
+	event     entity.PushEvent
+	workspace uuid.UUID
+	project   entity.Project
+	err       error
+}
+
+func (m *mockHandlePushUsecase) Process(ctx context.Context, event entity.PushEvent) (entity.Project, error) {
+	m.event = event
+	m.workspace = entity.WorkspaceFrom(ctx)
+	return m.project, m.err
+}
+
+func (m *mockHandlePushUsecase) Work(ctx context.Context) {}
+
+type mockGetWebhookSecretUsecase struct {
+	secret     string
+	workspaces map[uuid.UUID]string
+	err        error
+}
+
+func (m mockGetWebhookSecretUsecase) Process(ctx context.Context) (string, error) {
+	if workspaceID := entity.WorkspaceFrom(ctx); workspaceID != entity.DefaultWorkspaceID {
+		return m.workspaces[workspaceID], m.err
+	}
+	return m.secret, m.err
+}
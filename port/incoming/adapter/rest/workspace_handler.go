@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type postCreateWorkspaceCommand struct {
+	Name string `json:"name" validate:"required,max=64"`
+}
+
+type workspaceResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// Key and WebhookSecret are only included when the workspace is created.
+	Key           *apiKeyResponse `json:"key,omitempty"`
+	WebhookSecret string          `json:"webhook_secret,omitempty"`
+}
+
+type workspacesResponse struct {
+	Total      int                 `json:"total"`
+	Workspaces []workspaceResponse `json:"workspaces"`
+}
+
+// RegisterCreateWorkspaceUsecase defines the proper URI and HTTP method to execute the CreateWorkspaceUsecase.
+func RegisterCreateWorkspaceUsecase(r *gin.Engine, uc usecase.CreateWorkspaceUsecase) *gin.Engine {
+	r.POST("/admin/workspaces", func(c *gin.Context) {
+		createWorkspace(c, uc)
+	})
+
+	return r
+}
+
+func createWorkspace(ctx *gin.Context, uc usecase.CreateWorkspaceUsecase) {
+	var cmd postCreateWorkspaceCommand
+	if err := ctx.ShouldBindJSON(&cmd); err != nil {
+		log.WithError(err).Debug("failed to bind JSON body")
+		setBadRequestResponse(ctx, err)
+		return
+	}
+
+	if err := requestValidator.Struct(cmd); err != nil {
+		log.WithError(err).Debug("failed while validating the command")
+		setBadRequestOnValidationResponse(ctx, err)
+		return
+	}
+
+	workspace, key, plain, err := uc.Process(ctx, cmd.Name)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrWorkspaceNotAllowed:
+		setForbiddenResponse(ctx, fmt.Errorf("workspaces can only be managed from the default workspace"))
+		return
+	case usecase.ErrPreviousWorkspaceFound:
+		setBadRequestResponse(ctx, fmt.Errorf("previous workspace named %s exists", cmd.Name))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing createWorkspaceUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error creating workspace"))
+		return
+	}
+
+	keyResponse := toAPIKeyResponse(key)
+	keyResponse.Key = plain
+	response := toWorkspaceResponse(workspace)
+	response.Key = &keyResponse
+	response.WebhookSecret = workspace.WebhookSecret
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// RegisterListWorkspacesUsecase defines the proper URI and HTTP method to execute the ListWorkspacesUsecase.
+func RegisterListWorkspacesUsecase(r *gin.Engine, uc usecase.ListWorkspacesUsecase) *gin.Engine {
+	r.GET("/admin/workspaces", func(c *gin.Context) {
+		listWorkspaces(c, uc)
+	})
+
+	return r
+}
+
+func listWorkspaces(ctx *gin.Context, uc usecase.ListWorkspacesUsecase) {
+	workspaces, err := uc.Process(ctx)
+	switch err {
+	case nil:
+		// do nothing
+	case usecase.ErrWorkspaceNotAllowed:
+		setForbiddenResponse(ctx, fmt.Errorf("workspaces can only be managed from the default workspace"))
+		return
+	default:
+		log.WithError(err).Error("unexpected error executing listWorkspacesUsecase")
+		setInternalErrorResponse(ctx, fmt.Errorf("error accessing workspaces"))
+		return
+	}
+
+	response := workspacesResponse{
+		Total:      len(workspaces),
+		Workspaces: make([]workspaceResponse, len(workspaces)),
+	}
+	for i, workspace := range workspaces {
+		response.Workspaces[i] = toWorkspaceResponse(workspace)
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func toWorkspaceResponse(workspace entity.Workspace) workspaceResponse {
+	return workspaceResponse{
+		ID:        workspace.ID.String(),
+		Name:      workspace.Name,
+		CreatedAt: workspace.CreatedAt,
+	}
+}
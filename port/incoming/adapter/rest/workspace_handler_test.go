@@ -0,0 +1,202 @@
+package rest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/incoming/adapter/rest"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPOST_OnCreateWorkspaceHandler_WhenInvalidBody_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateWorkspaceUsecase(router, &mockCreateWorkspaceUsecase{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/workspaces", strings.NewReader(`{"name": ""}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": ["invalid field 'name' with value null or empty"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnCreateWorkspaceHandler_WhenPreviousWorkspace_ShouldReturn400(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateWorkspaceUsecase(router, &mockCreateWorkspaceUsecase{err: usecase.ErrPreviousWorkspaceFound})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/workspaces", strings.NewReader(`{"name": "acme"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "validation_error",
+			"message": "missing or invalid data",
+			"details": ["previous workspace named acme exists"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnCreateWorkspaceHandler_WhenNotAllowed_ShouldReturn403(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateWorkspaceUsecase(router, &mockCreateWorkspaceUsecase{err: usecase.ErrWorkspaceNotAllowed})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/workspaces", strings.NewReader(`{"name": "acme"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `
+		{
+			"name": "forbidden",
+			"message": "insufficient permissions",
+			"details": ["workspaces can only be managed from the default workspace"]
+		}`,
+		w.Body.String())
+}
+
+func TestPOST_OnCreateWorkspaceHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterCreateWorkspaceUsecase(router, &mockCreateWorkspaceUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/workspaces", strings.NewReader(`{"name": "acme"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestPOST_OnCreateWorkspaceHandler_ShouldReturn201WithKey(t *testing.T) {
+	uc := &mockCreateWorkspaceUsecase{
+		workspace: entity.Workspace{
+			ID:            uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"),
+			Name:          "acme",
+			WebhookSecret: "0a1b2c3d4e5f",
+			CreatedAt:     time.Date(2020, time.May, 2, 10, 0, 0, 0, time.UTC),
+		},
+		key: entity.APIKey{
+			ID:          uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+			Name:        "admin",
+			Prefix:      "srk_0a1b2c3d",
+			Scopes:      []string{"read", "analyze", "admin"},
+			WorkspaceID: uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"),
+			CreatedAt:   time.Date(2020, time.May, 2, 10, 0, 0, 0, time.UTC),
+		},
+		plain: "srk_0a1b2c3d4e5f",
+	}
+	router := rest.NewServer()
+	rest.RegisterCreateWorkspaceUsecase(router, uc)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/workspaces", strings.NewReader(`{"name": "acme"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `
+		{
+			"id": "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+			"name": "acme",
+			"created_at": "2020-05-02T10:00:00Z",
+			"key": {
+				"id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+				"name": "admin",
+				"prefix": "srk_0a1b2c3d",
+				"scopes": ["read", "analyze", "admin"],
+				"created_at": "2020-05-02T10:00:00Z",
+				"key": "srk_0a1b2c3d4e5f"
+			},
+			"webhook_secret": "0a1b2c3d4e5f"
+		}`,
+		w.Body.String())
+	assert.Equal(t, "acme", uc.name)
+}
+
+func TestGET_OnListWorkspacesHandler_WhenNotAllowed_ShouldReturn403(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListWorkspacesUsecase(router, &mockListWorkspacesUsecase{err: usecase.ErrWorkspaceNotAllowed})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/workspaces", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGET_OnListWorkspacesHandler_WhenUnexpectedError_ShouldReturn500(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListWorkspacesUsecase(router, &mockListWorkspacesUsecase{err: usecase.ErrUnexpected})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/workspaces", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestGET_OnListWorkspacesHandler_ShouldReturn200(t *testing.T) {
+	router := rest.NewServer()
+	rest.RegisterListWorkspacesUsecase(router, &mockListWorkspacesUsecase{
+		workspaces: []entity.Workspace{
+			{
+				ID:            uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"),
+				Name:          "acme",
+				WebhookSecret: "0a1b2c3d4e5f",
+				CreatedAt:     time.Date(2020, time.May, 2, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/workspaces", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `
+		{
+			"total": 1,
+			"workspaces": [
+				{
+					"id": "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+					"name": "acme",
+					"created_at": "2020-05-02T10:00:00Z"
+				}
+			]
+		}`,
+		w.Body.String())
+}
+
+type mockCreateWorkspaceUsecase struct {
+	workspace entity.Workspace
+	key       entity.APIKey
+	plain     string
+	err       error
+	name      string
+}
+
+func (m *mockCreateWorkspaceUsecase) Process(ctx context.Context, name string) (entity.Workspace, entity.APIKey, string, error) {
+	m.name = name
+	return m.workspace, m.key, m.plain, m.err
+}
+
+type mockListWorkspacesUsecase struct {
+	workspaces []entity.Workspace
+	err        error
+}
+
+func (m *mockListWorkspacesUsecase) Process(ctx context.Context) ([]entity.Workspace, error) {
+	return m.workspaces, m.err
+}
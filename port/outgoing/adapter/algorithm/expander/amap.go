@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
 	"github.com/eroatta/token/amap"
 )
@@ -30,9 +31,10 @@ func (f amapFactory) Make(miningResults map[string]entity.Miner) (entity.Expande
 	referenceText := commentsMiner.Results().([]string)
 
 	return &amapExpander{
-		expander:           expander{"amap"},
-		scopedDeclarations: scopedDeclarations,
-		referenceText:      referenceText,
+		expander:            expander{"amap"},
+		scopedDeclarations:  scopedDeclarations,
+		referenceText:       referenceText,
+		foldedReferenceText: fold(referenceText),
 	}, nil
 }
 
@@ -40,6 +42,9 @@ type amapExpander struct {
 	expander
 	scopedDeclarations map[string]miner.ScopedDecl
 	referenceText      []string
+	// foldedReferenceText holds the reference text without accented letters, used for the identifiers
+	// written in languages other than English.
+	foldedReferenceText []string
 }
 
 // Expand receives a entity.Identifier and processes the available splits that
@@ -47,6 +52,7 @@ type amapExpander struct {
 // On AMAP, we rely on the related scoped declaration information for the identifier.
 // If no decalaration information can be found, we avoid trying to expand the identifier
 // because results can be broad.
+// For identifiers written in languages other than English, the comments are matched without accents.
 func (a amapExpander) Expand(ident entity.Identifier) []entity.Expansion {
 	splits, ok := ident.Splits[a.ApplicableOn()]
 	if !ok {
@@ -67,9 +73,15 @@ func (a amapExpander) Expand(ident entity.Identifier) []entity.Expansion {
 		return expansions
 	}
 
+	// identifiers don't carry accented letters, so they are removed from the texts written in other languages
+	comments, packageComments, referenceText := scopedDecl.Comments, scopedDecl.PackageComments, a.referenceText
+	if ident.Language != "" && ident.Language != entity.LanguageEnglish {
+		comments, packageComments, referenceText = fold(comments), fold(packageComments), a.foldedReferenceText
+	}
+
 	// TODO change strings.Join
 	scope := amap.NewTokenScope(scopedDecl.VariableDecls, scopedDecl.Name,
-		strings.Join(scopedDecl.BodyText, " "), scopedDecl.Comments, scopedDecl.PackageComments)
+		strings.Join(scopedDecl.BodyText, " "), comments, packageComments)
 
 	expansions := make([]entity.Expansion, len(splits))
 	for i, split := range splits {
@@ -77,7 +89,7 @@ func (a amapExpander) Expand(ident entity.Identifier) []entity.Expansion {
 			Order:              split.Order,
 			SplittingAlgorithm: a.ApplicableOn(),
 			From:               split.Value,
-			Values:             amap.Expand(split.Value, scope, a.referenceText),
+			Values:             amap.Expand(split.Value, scope, referenceText),
 		}
 	}
 
@@ -87,3 +99,13 @@ func (a amapExpander) Expand(ident entity.Identifier) []entity.Expansion {
 func (a amapExpander) ApplicableOn() string {
 	return "samurai"
 }
+
+// fold removes the accented letters from each text.
+func fold(texts []string) []string {
+	folded := make([]string, len(texts))
+	for i, text := range texts {
+		folded[i] = language.Fold(text)
+	}
+
+	return folded
+}
@@ -131,3 +131,36 @@ func TestExpand_OnAMAP_ShouldReturnExpandedResults(t *testing.T) {
 	assert.Equal(t, 1, len(got))
 	assert.EqualValues(t, []entity.Expansion{{Order: 1, SplittingAlgorithm: "samurai", From: "sb", Values: []string{"string buffer"}}}, got)
 }
+
+func TestExpand_OnAMAPWhenIdentifierInSpanish_ShouldMatchCommentsWithoutAccents(t *testing.T) {
+	miningResults := map[string]entity.Miner{
+		"scoped-declarations": &miner.Scope{
+			Scopes: map[string]miner.ScopedDecl{
+				"filename:main.go+++pkg:main+++declType:var+++name:dir": {
+					ID:       "dir",
+					DeclType: token.VAR,
+					Comments: []string{"dirección de envío"},
+				},
+			},
+		},
+		"comments": miner.NewComments(),
+	}
+
+	factory := expander.NewAMAPFactory()
+	amap, _ := factory.Make(miningResults)
+
+	ident := entity.Identifier{
+		ID:   "filename:main.go+++pkg:main+++declType:var+++name:dir",
+		Name: "dir",
+		Splits: map[string][]entity.Split{
+			"samurai": {
+				{Order: 1, Value: "dir"},
+			},
+		},
+		Language: entity.LanguageSpanish,
+	}
+
+	got := amap.Expand(ident)
+
+	assert.EqualValues(t, []entity.Expansion{{Order: 1, SplittingAlgorithm: "samurai", From: "dir", Values: []string{"direccion"}}}, got)
+}
@@ -4,14 +4,23 @@ import (
 	"errors"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/agnivade/levenshtein"
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
 	"github.com/eroatta/token/basic"
 	"github.com/eroatta/token/expansion"
 )
 
+// languageExpansions holds the default expansions for the identifiers written in each language other than English,
+// built on first use.
+var (
+	languageExpansions     map[entity.Language]expansion.Set
+	languageExpansionsOnce sync.Once
+)
+
 // NewBasicFactory creates a new Basic expanders factory.
 func NewBasicFactory() entity.ExpanderFactory {
 	return basicFactory{}
@@ -43,6 +52,7 @@ type basicExpander struct {
 // If no declaration information can be found, we avoid trying to expand the identifier
 // because results can be broad.
 // If a declaration is found but several expansions are found, we handle a subset of them.
+// The default expansions are taken from the dictionary of the language of the identifier.
 func (b basicExpander) Expand(ident entity.Identifier) []entity.Expansion {
 	splits, ok := ident.Splits[b.ApplicableOn()]
 	if !ok {
@@ -79,9 +89,10 @@ func (b basicExpander) Expand(ident entity.Identifier) []entity.Expansion {
 		phrases[acron.String()] = strings.ReplaceAll(phrase, " ", "-")
 	}
 
+	defaults := defaultExpansions(ident.Language)
 	expanded := make([]entity.Expansion, len(splits))
 	for i, split := range splits {
-		expansions := basic.Expand(split.Value, words, phrases, basic.DefaultExpansions)
+		expansions := basic.Expand(split.Value, words, phrases, defaults)
 		if len(expansions) == 0 {
 			expansions = []string{split.Value}
 		}
@@ -105,6 +116,28 @@ func (b basicExpander) ApplicableOn() string {
 	return "greedy"
 }
 
+// defaultExpansions retrieves the default expansions for the given language, or the ones for English if the
+// language isn't supported.
+func defaultExpansions(lang entity.Language) expansion.Set {
+	languageExpansionsOnce.Do(func() {
+		languageExpansions = make(map[entity.Language]expansion.Set)
+		for _, supported := range language.Supported() {
+			if supported == entity.LanguageEnglish {
+				continue
+			}
+
+			languageExpansions[supported] = expansion.NewSetBuilder().AddList(language.Dictionary(supported)).Build()
+		}
+	})
+
+	expansions, ok := languageExpansions[lang]
+	if !ok {
+		return basic.DefaultExpansions
+	}
+
+	return expansions
+}
+
 // handleMultipleExpansinos measures the distance between two strings according the
 // Levenshtein algorithm, and select the closest three expansions.
 func handleMultipleExpansions(token string, expansions []string) []string {
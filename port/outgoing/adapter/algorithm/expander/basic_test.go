@@ -193,3 +193,38 @@ func TestExpand_OnBasicWhenMultipleResults_ShouldReturnClosestThreePerWord(t *te
 	assert.Equal(t, 1, len(got))
 	assert.EqualValues(t, []entity.Expansion{{Order: 1, SplittingAlgorithm: "greedy", From: "contrl", Values: []string{"control", "control", "contrail"}}}, got)
 }
+
+func TestExpand_OnBasicWhenIdentifierInSpanish_ShouldReturnExpandedResultsFromSpanishDictionary(t *testing.T) {
+	miningResults := map[string]entity.Miner{
+		"declarations": &miner.Declaration{
+			Decls: map[string]miner.Decl{
+				"filename:main.go+++pkg:main+++declType:var+++name:direcc": {
+					ID:       "direcc",
+					DeclType: token.VAR,
+					Words:    map[string]struct{}{},
+					Phrases:  map[string]struct{}{},
+				},
+			},
+		},
+	}
+
+	factory := expander.NewBasicFactory()
+	basic, _ := factory.Make(miningResults)
+
+	ident := entity.Identifier{
+		ID:   "filename:main.go+++pkg:main+++declType:var+++name:direcc",
+		Name: "direcc",
+		Splits: map[string][]entity.Split{
+			"greedy": {
+				{Order: 1, Value: "direcc"},
+			},
+		},
+		Language: entity.LanguageSpanish,
+	}
+
+	got := basic.Expand(ident)
+
+	assert.EqualValues(t, []entity.Expansion{
+		{Order: 1, SplittingAlgorithm: "greedy", From: "direcc", Values: []string{"direccion"}},
+	}, got)
+}
@@ -0,0 +1,75 @@
+package expander
+
+import (
+	"strings"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
+)
+
+// NewDictionaryExpander creates a new expander backed up by a managed abbreviation dictionary, applied on the
+// conserv splits unless it's retargeted. It satisfies the entity.DictionaryExpanderFactory definition.
+func NewDictionaryExpander(dict entity.Dictionary) entity.Expander {
+	folded := make(map[string][]string, len(dict.Entries))
+	for abbreviation, expansions := range dict.Entries {
+		folded[language.Fold(strings.ToLower(abbreviation))] = expansions
+	}
+
+	return dictionaryExpander{
+		expander:   expander{entity.DictionaryExpanderName},
+		dictionary: dict,
+		folded:     folded,
+		splitter:   "conserv",
+	}
+}
+
+type dictionaryExpander struct {
+	expander
+	dictionary entity.Dictionary
+	// folded holds the entries keyed by their abbreviations without accents, used for the identifiers written in
+	// languages other than English.
+	folded   map[string][]string
+	splitter string
+}
+
+// Expand receives a entity.Identifier and processes the available splits that
+// can be expanded with the current algorithm.
+// On Dictionary, every split found on the dictionary is replaced by its managed expansions,
+// while the remaining splits are kept as they are. Since identifiers don't carry accented letters, the
+// abbreviations of identifiers written in languages other than English are also matched without accents.
+func (e dictionaryExpander) Expand(ident entity.Identifier) []entity.Expansion {
+	splits, ok := ident.Splits[e.ApplicableOn()]
+	if !ok {
+		return []entity.Expansion{}
+	}
+
+	expansions := make([]entity.Expansion, len(splits))
+	for i, split := range splits {
+		values, found := e.dictionary.Lookup(split.Value)
+		if !found && ident.Language != "" && ident.Language != entity.LanguageEnglish {
+			values = e.folded[strings.ToLower(split.Value)]
+			found = len(values) > 0
+		}
+		if !found {
+			values = []string{split.Value}
+		}
+
+		expansions[i] = entity.Expansion{
+			Order:              split.Order,
+			SplittingAlgorithm: e.ApplicableOn(),
+			From:               split.Value,
+			Values:             values,
+		}
+	}
+	return expansions
+}
+
+func (e dictionaryExpander) ApplicableOn() string {
+	return e.splitter
+}
+
+// On returns a copy of the expander applied on the splits of the given splitter.
+func (e dictionaryExpander) On(splitter string) entity.Expander {
+	e.splitter = splitter
+	return e
+}
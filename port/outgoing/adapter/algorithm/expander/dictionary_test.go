@@ -0,0 +1,111 @@
+package expander_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/expander"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDictionaryExpander_ShouldReturnExpander(t *testing.T) {
+	dict := expander.NewDictionaryExpander(entity.Dictionary{})
+
+	assert.NotNil(t, dict)
+	assert.Equal(t, "dictionary", dict.Name())
+	assert.Equal(t, "conserv", dict.ApplicableOn())
+}
+
+func TestExpand_OnDictionaryWhenNoSplitsApplicable_ShouldReturnEmptyResults(t *testing.T) {
+	dict := expander.NewDictionaryExpander(entity.Dictionary{})
+
+	ident := entity.Identifier{
+		Name: "txn",
+		Splits: map[string][]entity.Split{
+			"gentest": {
+				{Order: 1, Value: "txn"},
+			},
+		},
+	}
+
+	got := dict.Expand(ident)
+
+	assert.Equal(t, 0, len(got))
+}
+
+func TestExpand_OnDictionary_ShouldReturnDictionaryExpansions(t *testing.T) {
+	dict := expander.NewDictionaryExpander(entity.Dictionary{
+		Entries: map[string][]string{
+			"txn": {"transaction"},
+			"svc": {"service"},
+		},
+	})
+
+	ident := entity.Identifier{
+		Name: "txnSvcName",
+		Splits: map[string][]entity.Split{
+			"conserv": {
+				{Order: 1, Value: "txn"},
+				{Order: 2, Value: "svc"},
+				{Order: 3, Value: "name"},
+			},
+		},
+	}
+
+	got := dict.Expand(ident)
+
+	assert.EqualValues(t, []entity.Expansion{
+		{Order: 1, SplittingAlgorithm: "conserv", From: "txn", Values: []string{"transaction"}},
+		{Order: 2, SplittingAlgorithm: "conserv", From: "svc", Values: []string{"service"}},
+		{Order: 3, SplittingAlgorithm: "conserv", From: "name", Values: []string{"name"}},
+	}, got)
+}
+
+func TestExpand_OnDictionaryWhenIdentifierInSpanish_ShouldMatchAbbreviationsWithoutAccents(t *testing.T) {
+	dict := expander.NewDictionaryExpander(entity.Dictionary{
+		Entries: map[string][]string{
+			"núm": {"número"},
+		},
+	})
+
+	ident := entity.Identifier{
+		Name: "numCuenta",
+		Splits: map[string][]entity.Split{
+			"conserv": {
+				{Order: 1, Value: "num"},
+				{Order: 2, Value: "cuenta"},
+			},
+		},
+	}
+
+	assert.EqualValues(t, []entity.Expansion{
+		{Order: 1, SplittingAlgorithm: "conserv", From: "num", Values: []string{"num"}},
+		{Order: 2, SplittingAlgorithm: "conserv", From: "cuenta", Values: []string{"cuenta"}},
+	}, dict.Expand(ident))
+
+	ident.Language = entity.LanguageSpanish
+
+	assert.EqualValues(t, []entity.Expansion{
+		{Order: 1, SplittingAlgorithm: "conserv", From: "num", Values: []string{"número"}},
+		{Order: 2, SplittingAlgorithm: "conserv", From: "cuenta", Values: []string{"cuenta"}},
+	}, dict.Expand(ident))
+}
+
+func TestOn_OnDictionary_ShouldExpandTheSplitsOfTheGivenSplitter(t *testing.T) {
+	dict := expander.NewDictionaryExpander(entity.Dictionary{
+		Entries: map[string][]string{"txn": {"transaction"}},
+	}).(entity.RetargetableExpander).On("greedy")
+
+	ident := entity.Identifier{
+		Name: "txnName",
+		Splits: map[string][]entity.Split{
+			"greedy": {{Order: 1, Value: "txn"}, {Order: 2, Value: "name"}},
+		},
+	}
+
+	assert.Equal(t, "greedy", dict.ApplicableOn())
+	assert.EqualValues(t, []entity.Expansion{
+		{Order: 1, SplittingAlgorithm: "greedy", From: "txn", Values: []string{"transaction"}},
+		{Order: 2, SplittingAlgorithm: "greedy", From: "name", Values: []string{"name"}},
+	}, dict.Expand(ident))
+}
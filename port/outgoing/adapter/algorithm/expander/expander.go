@@ -12,12 +12,14 @@ import (
 // 	* "noexp"
 //	* "basic"
 //	* "amap"
+//	* "learned"
 func NewExpanderFactory() entity.ExpanderAbstractFactory {
 	return &expanderFactory{
 		factories: map[string]entity.ExpanderFactory{
-			"noexp": NewNoExpansionFactory(),
-			"basic": NewBasicFactory(),
-			"amap":  NewAMAPFactory(),
+			"noexp":   NewNoExpansionFactory(),
+			"basic":   NewBasicFactory(),
+			"amap":    NewAMAPFactory(),
+			"learned": NewLearnedFactory(),
 		},
 	}
 }
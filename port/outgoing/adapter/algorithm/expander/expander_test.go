@@ -30,3 +30,11 @@ func TestGet_OnExpanderFactory_WithAMAP_ShouldReturnAMAPFactory(t *testing.T) {
 	assert.Implements(t, (*entity.ExpanderFactory)(nil), got)
 	assert.NoError(t, err)
 }
+
+func TestGet_OnExpanderFactory_WithLearned_ShouldReturnLearnedFactory(t *testing.T) {
+	af := expander.NewExpanderFactory()
+	got, err := af.Get("learned")
+
+	assert.Implements(t, (*entity.ExpanderFactory)(nil), got)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,84 @@
+package expander
+
+import (
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
+)
+
+// DefaultMinConfidence defines the minimum confidence required to apply a learned expansion.
+const DefaultMinConfidence = 0.5
+
+// NewLearnedFactory creates a new Learned expanders factory.
+func NewLearnedFactory() entity.ExpanderFactory {
+	return learnedFactory{}
+}
+
+type learnedFactory struct{}
+
+func (f learnedFactory) Make(miningResults map[string]entity.Miner) (entity.Expander, error) {
+	abbreviationsMiner, ok := miningResults["abbreviations"]
+	if !ok {
+		return nil, errors.New("unable to retrieve input from abbreviations miner")
+	}
+	learned := abbreviationsMiner.Results().(map[string][]miner.LearnedExpansion)
+
+	return learnedExpander{
+		expander:      expander{"learned"},
+		learned:       learned,
+		minConfidence: DefaultMinConfidence,
+		splitter:      "conserv",
+	}, nil
+}
+
+type learnedExpander struct {
+	expander
+	learned       map[string][]miner.LearnedExpansion
+	minConfidence float64
+	splitter      string
+}
+
+// Expand receives a entity.Identifier and processes the available splits that
+// can be expanded with the current algorithm.
+// On Learned, we rely on the abbreviations learned from the version history and from
+// the sibling identifiers. Only the expansions reaching the minimum confidence are applied,
+// sorted by their confidence.
+func (e learnedExpander) Expand(ident entity.Identifier) []entity.Expansion {
+	splits, ok := ident.Splits[e.ApplicableOn()]
+	if !ok {
+		return []entity.Expansion{}
+	}
+
+	expansions := make([]entity.Expansion, len(splits))
+	for i, split := range splits {
+		values := make([]string, 0)
+		for _, learned := range e.learned[split.Value] {
+			if learned.Confidence >= e.minConfidence {
+				values = append(values, learned.Expansion)
+			}
+		}
+
+		if len(values) == 0 {
+			values = append(values, split.Value)
+		}
+
+		expansions[i] = entity.Expansion{
+			Order:              split.Order,
+			SplittingAlgorithm: e.ApplicableOn(),
+			From:               split.Value,
+			Values:             values,
+		}
+	}
+	return expansions
+}
+
+func (e learnedExpander) ApplicableOn() string {
+	return e.splitter
+}
+
+// On returns a copy of the expander applied on the splits of the given splitter.
+func (e learnedExpander) On(splitter string) entity.Expander {
+	e.splitter = splitter
+	return e
+}
@@ -0,0 +1,137 @@
+package expander_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/expander"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
+	"github.com/eroatta/token/lists"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLearnedFactory_ShouldReturnExpanderFactory(t *testing.T) {
+	factory := expander.NewLearnedFactory()
+
+	assert.NotNil(t, factory)
+}
+
+func TestMake_OnLearnedFactory_WhenMissingAbbreviationsMiner_ShouldReturnError(t *testing.T) {
+	factory := expander.NewLearnedFactory()
+	learned, err := factory.Make(map[string]entity.Miner{})
+
+	assert.Nil(t, learned)
+	assert.EqualError(t, err, "unable to retrieve input from abbreviations miner")
+}
+
+func TestMake_OnLearnedFactory_ShouldReturnExpander(t *testing.T) {
+	miningResults := map[string]entity.Miner{
+		"abbreviations": miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits),
+	}
+
+	factory := expander.NewLearnedFactory()
+	learned, err := factory.Make(miningResults)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "learned", learned.Name())
+	assert.Equal(t, "conserv", learned.ApplicableOn())
+}
+
+func TestExpand_OnLearnedWhenNoSplitsApplicable_ShouldReturnEmptyResults(t *testing.T) {
+	miningResults := map[string]entity.Miner{
+		"abbreviations": miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits),
+	}
+
+	factory := expander.NewLearnedFactory()
+	learned, _ := factory.Make(miningResults)
+
+	ident := entity.Identifier{
+		Name: "cfg",
+		Splits: map[string][]entity.Split{
+			"gentest": {
+				{Order: 1, Value: "cfg"},
+			},
+		},
+	}
+
+	got := learned.Expand(ident)
+
+	assert.Equal(t, 0, len(got))
+}
+
+func TestExpand_OnLearned_ShouldApplyConfidentExpansions(t *testing.T) {
+	src := `
+		package main
+
+		var cfg, defaultConfig string
+		var str, stream, newString string
+	`
+	fs := token.NewFileSet()
+	node, _ := parser.ParseFile(fs, "", []byte(src), parser.AllErrors)
+
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	ast.Walk(abbreviations, node)
+	miningResults := map[string]entity.Miner{
+		"abbreviations": abbreviations,
+	}
+
+	factory := expander.NewLearnedFactory()
+	learned, _ := factory.Make(miningResults)
+
+	ident := entity.Identifier{
+		Name: "cfgStr",
+		Splits: map[string][]entity.Split{
+			"conserv": {
+				{Order: 1, Value: "cfg"},
+				{Order: 2, Value: "str"},
+			},
+		},
+	}
+
+	got := learned.Expand(ident)
+
+	assert.EqualValues(t, []entity.Expansion{
+		{Order: 1, SplittingAlgorithm: "conserv", From: "cfg", Values: []string{"config"}},
+		{Order: 2, SplittingAlgorithm: "conserv", From: "str", Values: []string{"str"}},
+	}, got)
+}
+
+func TestOn_OnLearned_ShouldExpandTheSplitsOfTheGivenSplitter(t *testing.T) {
+	src := `
+		package main
+
+		var cfg, defaultConfig string
+	`
+	fs := token.NewFileSet()
+	node, _ := parser.ParseFile(fs, "", []byte(src), parser.AllErrors)
+
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	ast.Walk(abbreviations, node)
+	miningResults := map[string]entity.Miner{
+		"abbreviations": abbreviations,
+	}
+
+	factory := expander.NewLearnedFactory()
+	learned, _ := factory.Make(miningResults)
+	retargeted := learned.(entity.RetargetableExpander).On("samurai")
+
+	ident := entity.Identifier{
+		Name: "cfgName",
+		Splits: map[string][]entity.Split{
+			"samurai": {
+				{Order: 1, Value: "cfg"},
+				{Order: 2, Value: "name"},
+			},
+		},
+	}
+
+	assert.Equal(t, "conserv", learned.ApplicableOn())
+	assert.Equal(t, "samurai", retargeted.ApplicableOn())
+	assert.EqualValues(t, []entity.Expansion{
+		{Order: 1, SplittingAlgorithm: "samurai", From: "cfg", Values: []string{"config"}},
+		{Order: 2, SplittingAlgorithm: "samurai", From: "name", Values: []string{"name"}},
+	}, retargeted.Expand(ident))
+}
@@ -46,8 +46,13 @@ func (e *Extractor) Visit(node ast.Node) ast.Visitor {
 		name := elem.Name.String()
 
 		recv := ""
+		recvName := ""
 		if elem.Recv != nil && elem.Recv.NumFields() > 0 {
 			for _, r := range elem.Recv.List {
+				if len(r.Names) > 0 {
+					recvName = r.Names[0].Name
+				}
+
 				switch exp := r.Type.(type) {
 				case *ast.Ident:
 					recv = exp.Name
@@ -62,7 +67,10 @@ func (e *Extractor) Visit(node ast.Node) ast.Visitor {
 
 		id := entity.NewIDBuilder().WithFilename(e.filename).
 			WithPackage(e.packageName).WithReceiver(recv).WithName(name).WithType(token.FUNC).Build()
-		e.identifiers = append(e.identifiers, newIdentifier(id, e.packageName, e.filename, elem.Pos(), name, token.FUNC))
+		ident := newIdentifier(id, e.packageName, e.filename, elem.Pos(), name, token.FUNC)
+		ident.Receiver = recv
+		ident.ReceiverName = recvName
+		e.identifiers = append(e.identifiers, ident)
 
 		// set current location at the beginning of each function
 		e.currentLoc = id
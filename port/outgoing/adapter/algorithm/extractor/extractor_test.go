@@ -85,14 +85,16 @@ func TestVisit_OnExtractorWithFuncDeclUsingSameFuncName_ShouldReturnFoundIdentif
 			Expansions: make(map[string][]entity.Expansion),
 		},
 		{
-			ID:         "filename:testfile.go+++pkg:main+++declType:func+++name:car.name",
-			Package:    "main",
-			File:       "testfile.go",
-			Position:   42,
-			Name:       "name",
-			Type:       token.FUNC,
-			Splits:     make(map[string][]entity.Split),
-			Expansions: make(map[string][]entity.Expansion),
+			ID:           "filename:testfile.go+++pkg:main+++declType:func+++name:car.name",
+			Package:      "main",
+			File:         "testfile.go",
+			Position:     42,
+			Name:         "name",
+			Type:         token.FUNC,
+			Receiver:     "car",
+			ReceiverName: "c",
+			Splits:       make(map[string][]entity.Split),
+			Expansions:   make(map[string][]entity.Expansion),
 		},
 		{
 			ID:         "filename:testfile.go+++pkg:main+++declType:struct+++name:boat",
@@ -105,14 +107,16 @@ func TestVisit_OnExtractorWithFuncDeclUsingSameFuncName_ShouldReturnFoundIdentif
 			Expansions: make(map[string][]entity.Expansion),
 		},
 		{
-			ID:         "filename:testfile.go+++pkg:main+++declType:func+++name:boat.name",
-			Package:    "main",
-			File:       "testfile.go",
-			Position:   110,
-			Name:       "name",
-			Type:       token.FUNC,
-			Splits:     make(map[string][]entity.Split),
-			Expansions: make(map[string][]entity.Expansion),
+			ID:           "filename:testfile.go+++pkg:main+++declType:func+++name:boat.name",
+			Package:      "main",
+			File:         "testfile.go",
+			Position:     110,
+			Name:         "name",
+			Type:         token.FUNC,
+			Receiver:     "boat",
+			ReceiverName: "b",
+			Splits:       make(map[string][]entity.Split),
+			Expansions:   make(map[string][]entity.Expansion),
 		},
 	}
 
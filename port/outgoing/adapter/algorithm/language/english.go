@@ -0,0 +1,11 @@
+package language
+
+var englishStop = []string{
+	"a", "about", "after", "all", "also", "an", "and", "any", "are", "as", "at", "be", "been", "before",
+	"but", "by", "can", "could", "did", "do", "does", "each", "for", "from", "had", "has", "have", "he",
+	"her", "his", "how", "if", "in", "into", "is", "it", "its", "may", "more", "most", "must", "no", "not",
+	"of", "on", "one", "only", "or", "other", "our", "should", "so", "some", "such", "than", "that",
+	"the", "their", "them", "then", "there", "these", "they", "this", "those", "to", "under", "until",
+	"up", "was", "we", "were", "what", "when", "where", "which", "while", "who", "will", "with", "would",
+	"you", "your",
+}
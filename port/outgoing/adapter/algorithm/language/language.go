@@ -0,0 +1,231 @@
+// Package language provides the word lists and the text processing functions for each supported
+// natural language, and also a way to detect the dominant language on a set of texts.
+package language
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/eroatta/nounphrases"
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/token/lists"
+)
+
+// MinEvidence defines the minimum number of stop words required to detect a language.
+const MinEvidence = 3
+
+var (
+	letters   = regexp.MustCompile("[a-z]+")
+	sentences = regexp.MustCompile(`[.,;:!?()\[\]{}"]+`)
+
+	folding = strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+		"é", "e", "è", "e", "ê", "e", "ë", "e",
+		"í", "i", "ì", "i", "î", "i", "ï", "i",
+		"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+		"ú", "u", "ù", "u", "û", "u", "ü", "u",
+		"ñ", "n", "ç", "c",
+		"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+		"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+		"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+		"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+		"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+		"Ñ", "N", "Ç", "C",
+	)
+
+	stop = map[entity.Language]lists.List{
+		entity.LanguageEnglish:    lists.NewBuilder().Add(englishStop...).Build(),
+		entity.LanguageSpanish:    lists.NewBuilder().Add(spanishStop...).Build(),
+		entity.LanguagePortuguese: lists.NewBuilder().Add(portugueseStop...).Build(),
+	}
+
+	dictionaries = map[entity.Language]lists.List{
+		entity.LanguageEnglish: lists.Dictionary,
+		entity.LanguageSpanish: dictionary{
+			words: lists.NewBuilder().Add(spanishWords...).Build(),
+			stop:  stop[entity.LanguageSpanish],
+		},
+		entity.LanguagePortuguese: dictionary{
+			words: lists.NewBuilder().Add(portugueseWords...).Build(),
+			stop:  stop[entity.LanguagePortuguese],
+		},
+	}
+)
+
+// Supported returns the list of supported languages.
+func Supported() []entity.Language {
+	return []entity.Language{entity.LanguageEnglish, entity.LanguageSpanish, entity.LanguagePortuguese}
+}
+
+// Detect determines the dominant language on the given texts, based on the number of stop words
+// found for each supported language. If there is not enough evidence, English is assumed.
+// It satisfies the entity.LanguageDetector definition.
+func Detect(texts []string) entity.Language {
+	hits := make(map[entity.Language]int)
+	for _, text := range texts {
+		for _, word := range words(text) {
+			for _, lang := range Supported() {
+				if stop[lang].Contains(word) {
+					hits[lang]++
+				}
+			}
+		}
+	}
+
+	detected := entity.LanguageEnglish
+	for _, lang := range Supported() {
+		if hits[lang] >= MinEvidence && hits[lang] > hits[detected] {
+			detected = lang
+		}
+	}
+
+	return detected
+}
+
+// Dictionary returns the list of known words for the given language. Since identifiers usually mix
+// English words with the ones from the team's language, the English dictionary is always included,
+// but the stop words for the language are excluded.
+func Dictionary(lang entity.Language) lists.List {
+	dict, ok := dictionaries[lang]
+	if !ok {
+		return lists.Dictionary
+	}
+
+	return dict
+}
+
+// LoadWords extends the seed word lists of the supported languages, other than English, with the words on the
+// <language>.txt files found on the given directory, such as es.txt for Spanish. Each file holds one word per
+// line; blank lines and lines starting with # are ignored, and missing files leave the seed list as it is. The
+// lists are cached by the splitters and expanders, so the words must be loaded before any analysis starts.
+func LoadWords(dir string) error {
+	for _, lang := range Supported() {
+		if lang == entity.LanguageEnglish {
+			continue
+		}
+
+		f, err := os.Open(filepath.Join(dir, string(lang)+".txt"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		loaded, err := readWords(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("reading words for %s: %w", lang, err)
+		}
+
+		dict := dictionaries[lang].(dictionary)
+		dict.words = lists.NewBuilder().Add(dict.words.Elements()...).Add(loaded...).Build()
+		dictionaries[lang] = dict
+	}
+
+	return nil
+}
+
+// readWords reads one word per line, skipping blank lines and comments.
+func readWords(r io.Reader) ([]string, error) {
+	loaded := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		loaded = append(loaded, Fold(strings.ToLower(word)))
+	}
+
+	return loaded, scanner.Err()
+}
+
+// Stop returns the list of stop words for the given language.
+func Stop(lang entity.Language) lists.List {
+	list, ok := stop[lang]
+	if !ok {
+		return stop[entity.LanguageEnglish]
+	}
+
+	return list
+}
+
+// Phrases extracts the phrases found on the given text. For English, noun phrases are extracted using
+// a part-of-speech tagger. For the remaining languages, phrases are the sequences of two to four
+// consecutive words, delimited by stop words and punctuation marks.
+func Phrases(lang entity.Language, text string) ([]string, error) {
+	if _, ok := dictionaries[lang]; !ok || lang == entity.LanguageEnglish {
+		return nounphrases.Find(text)
+	}
+
+	phrases := make([]string, 0)
+	for _, sentence := range sentences.Split(Fold(strings.ToLower(text)), -1) {
+		run := make([]string, 0)
+		for _, word := range append(strings.Fields(sentence), "") {
+			if word != "" && letters.FindString(word) == word && !stop[lang].Contains(word) {
+				run = append(run, word)
+				continue
+			}
+
+			if len(run) >= 2 && len(run) <= 4 {
+				phrases = append(phrases, strings.Join(run, " "))
+			}
+			run = run[:0]
+		}
+	}
+
+	return phrases, nil
+}
+
+// Fold replaces the accented letters by their unaccented form, as used on identifiers.
+func Fold(text string) string {
+	return folding.Replace(text)
+}
+
+func words(text string) []string {
+	return letters.FindAllString(Fold(strings.ToLower(text)), -1)
+}
+
+// dictionary is a lists.List backed up by the English dictionary and the words for another
+// language, excluding the stop words for that language.
+type dictionary struct {
+	words lists.List
+	stop  lists.List
+}
+
+func (d dictionary) Contains(element string) bool {
+	element = strings.ToLower(element)
+	if d.stop.Contains(element) {
+		return false
+	}
+
+	return d.words.Contains(element) || lists.Dictionary.Contains(element)
+}
+
+func (d dictionary) Size() int {
+	return len(d.Elements())
+}
+
+func (d dictionary) Elements() []string {
+	unique := make(map[string]struct{}, d.words.Size()+lists.Dictionary.Size())
+	for _, list := range []lists.List{d.words, lists.Dictionary} {
+		for _, element := range list.Elements() {
+			if !d.stop.Contains(element) {
+				unique[element] = struct{}{}
+			}
+		}
+	}
+
+	elements := make([]string, 0, len(unique))
+	for element := range unique {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
@@ -0,0 +1,97 @@
+package language_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect_ShouldReturnDominantLanguage(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  entity.Language
+	}{
+		{"no_texts", []string{}, entity.LanguageEnglish},
+		{"not_enough_evidence", []string{"// la fecha"}, entity.LanguageEnglish},
+		{"english", []string{"// Process reads the file and returns the list of identifiers."}, entity.LanguageEnglish},
+		{"spanish", []string{"// Procesar lee el archivo y devuelve la lista de los identificadores."}, entity.LanguageSpanish},
+		{"spanish_accents", []string{"// obtiene la configuración", "// la conexión está en uso con el servidor"}, entity.LanguageSpanish},
+		{"portuguese", []string{"// Processar lê o arquivo e retorna a lista dos identificadores.", "// não há dados no banco"}, entity.LanguagePortuguese},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, language.Detect(tt.texts))
+		})
+	}
+}
+
+func TestDictionary_ShouldIncludeEnglishAndLanguageWords(t *testing.T) {
+	tests := []struct {
+		name string
+		lang entity.Language
+		word string
+		want bool
+	}{
+		{"english_word_on_english", entity.LanguageEnglish, "user", true},
+		{"spanish_word_on_english", entity.LanguageEnglish, "usuario", false},
+		{"english_word_on_spanish", entity.LanguageSpanish, "user", true},
+		{"spanish_word_on_spanish", entity.LanguageSpanish, "Usuario", true},
+		{"spanish_stop_word_on_spanish", entity.LanguageSpanish, "el", false},
+		{"portuguese_word_on_portuguese", entity.LanguagePortuguese, "senha", true},
+		{"portuguese_stop_word_on_portuguese", entity.LanguagePortuguese, "nao", false},
+		{"unknown_language", entity.Language("fr"), "user", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, language.Dictionary(tt.lang).Contains(tt.word))
+		})
+	}
+}
+
+func TestLoadWords_ShouldExtendTheSeedLists(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "es.txt"),
+		[]byte("# palabras del dominio\nVencimiento\n\nfacturación\n"), 0644))
+
+	require.NoError(t, language.LoadWords(dir))
+
+	dict := language.Dictionary(entity.LanguageSpanish)
+	assert.True(t, dict.Contains("vencimiento"))
+	assert.True(t, dict.Contains("facturacion"))
+	assert.True(t, dict.Contains("usuario"))
+	assert.False(t, dict.Contains("# palabras del dominio"))
+	assert.False(t, language.Dictionary(entity.LanguagePortuguese).Contains("vencimiento"))
+}
+
+func TestStop_ShouldReturnLanguageStopWords(t *testing.T) {
+	assert.True(t, language.Stop(entity.LanguageEnglish).Contains("the"))
+	assert.True(t, language.Stop(entity.LanguageSpanish).Contains("los"))
+	assert.True(t, language.Stop(entity.LanguagePortuguese).Contains("dos"))
+	assert.True(t, language.Stop(entity.Language("fr")).Contains("the"))
+}
+
+func TestPhrases_OnSpanish_ShouldReturnSequencesBetweenStopWords(t *testing.T) {
+	got, err := language.Phrases(entity.LanguageSpanish, "obtiene la fecha vencimiento de la factura electrónica, para el cliente")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"fecha vencimiento", "factura electronica"}, got)
+}
+
+func TestPhrases_OnEnglish_ShouldReturnNounPhrases(t *testing.T) {
+	got, err := language.Phrases(entity.LanguageEnglish, "the abstract syntax tree")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, got)
+}
+
+func TestFold_ShouldRemoveAccents(t *testing.T) {
+	assert.Equal(t, "configuracion conexao nino", language.Fold("configuración conexão niño"))
+}
@@ -0,0 +1,31 @@
+package language
+
+var portugueseStop = []string{
+	"a", "ao", "aos", "aquela", "aquele", "as", "ate", "com", "como", "da", "das", "de", "dela", "dele",
+	"depois", "do", "dos", "e", "ela", "elas", "ele", "eles", "em", "entre", "era", "essa", "esse", "esta",
+	"estao", "este", "eu", "foi", "ha", "isso", "isto", "ja", "lhe", "mais", "mas", "me", "mesmo", "muito",
+	"na", "nao", "nas", "nem", "no", "nos", "num", "numa", "o", "os", "ou", "para", "pela", "pelas",
+	"pelo", "pelos", "por", "porque", "quando", "que", "quem", "se", "sem", "ser", "seu", "seus", "so",
+	"sobre", "sua", "suas", "tambem", "tem", "um", "uma", "umas", "uns", "voce",
+}
+
+// portugueseWords is the seed list of Portuguese words, picked the same way as spanishWords; pt.txt on the
+// directory given to LoadWords extends it.
+var portugueseWords = []string{
+	"abrir", "acesso", "acao", "ativo", "atual", "atualizar", "adicionar", "ajuda", "ajuste", "anterior",
+	"arquivo", "arquivos", "banco", "base", "buscar", "busca", "cadastro", "caixa", "calcular", "calculo",
+	"campo", "campos", "carregar", "carga", "chave", "cliente", "clientes", "cobranca", "codigo",
+	"coluna", "compra", "conexao", "configuracao", "consulta", "contador", "contar", "conta", "contas",
+	"criar", "dado", "dados", "data", "dia", "dias", "divida", "documento", "elemento", "elementos",
+	"empresa", "endereco", "entrada", "enviar", "envio", "erro", "erros", "escrever", "estado", "evento",
+	"excluir", "fatura", "fila", "fim", "final", "funcao", "gerar", "grupo", "hora", "horas",
+	"identificador", "imprimir", "indice", "inicio", "iniciar", "ler", "linha", "linhas", "lista",
+	"listar", "mensagem", "mensagens", "mes", "metodo", "minuto", "modo", "mostrar", "nome", "nomes",
+	"novo", "nova", "numero", "objeto", "obter", "ordem", "pagina", "pagamento", "pagamentos",
+	"parametro", "pasta", "pedido", "pedidos", "permissao", "pessoa", "preco", "primeiro", "processo",
+	"processar", "produto", "produtos", "fornecedor", "porta", "quantidade", "receber", "registro",
+	"registros", "remover", "resposta", "resultado", "resultados", "rota", "saida", "saldo", "salvar",
+	"segundo", "senha", "servico", "servidor", "sessao", "seguinte", "solicitacao", "tabela", "tarefa",
+	"tarefas", "texto", "tempo", "tipo", "total", "ultimo", "usuario", "usuarios", "validar", "valido",
+	"valor", "valores", "vazio", "venda", "vendas", "velho",
+}
@@ -0,0 +1,35 @@
+package language
+
+var spanishStop = []string{
+	"a", "al", "algo", "algun", "alguna", "algunas", "alguno", "algunos", "ante", "antes", "aqui", "asi",
+	"aun", "cada", "como", "con", "contra", "cual", "cuando", "de", "del", "desde", "donde", "durante",
+	"e", "el", "ella", "ellas", "ellos", "en", "entre", "era", "es", "esa", "esas", "ese", "eso", "esos",
+	"esta", "estan", "estas", "este", "esto", "estos", "fue", "ha", "hace", "hacia", "han", "hasta", "hay",
+	"la", "las", "le", "les", "lo", "los", "mas", "me", "mi", "mientras", "muy", "ni", "no", "nos",
+	"o", "otra", "otro", "para", "pero", "poco", "por", "porque", "que", "se", "segun", "ser", "si",
+	"sin", "sobre", "son", "su", "sus", "tambien", "tanto", "te", "tiene", "todo", "todos", "tu", "u",
+	"un", "una", "unas", "uno", "unos", "y", "ya",
+}
+
+// spanishWords is the seed list of Spanish words, picked among the ones usually found on identifiers, such as the
+// names of business entities and actions. It's not meant to be a full dictionary: deployments analyzing Spanish
+// code extend it with a word list loaded by LoadWords.
+var spanishWords = []string{
+	"abrir", "acceso", "accion", "activo", "actual", "actualizar", "agregar", "ajuste", "alta", "anterior",
+	"archivo", "archivos", "arreglo", "ayuda", "baja", "base", "borrar", "buscar", "busqueda", "cadena",
+	"caja", "calcular", "calculo", "cambio", "campo", "campos", "cantidad", "carga", "cargar", "carpeta",
+	"cerrar", "clave", "cliente", "clientes", "cobro", "codigo", "cola", "columna", "compra", "conexion",
+	"configuracion", "consulta", "contar", "contador", "contrasena", "correo", "crear", "cuenta", "cuentas",
+	"datos", "dato", "deuda", "dia", "dias", "direccion", "documento", "elemento", "elementos", "eliminar",
+	"empresa", "entrada", "enviar", "envio", "error", "errores", "escribir", "estado", "evento", "factura",
+	"fecha", "fila", "filas", "fin", "final", "funcion", "generar", "guardar", "grupo", "hora", "horas",
+	"identificador", "imprimir", "indice", "inicio", "iniciar", "leer", "linea", "lineas", "lista",
+	"listar", "llave", "lleno", "mensaje", "mensajes", "mes", "metodo", "minuto", "modo", "monto",
+	"mostrar", "nombre", "nombres", "nuevo", "nueva", "numero", "objeto", "obtener", "orden", "pagina",
+	"pago", "pagos", "parametro", "pedido", "pedidos", "permiso", "persona", "precio", "primero", "proceso",
+	"procesar", "producto", "productos", "proveedor", "puerto", "recibir", "registro", "registros",
+	"respuesta", "resultado", "resultados", "rol", "ruta", "saldo", "salida", "segundo", "servicio",
+	"servidor", "sesion", "siguiente", "solicitud", "tabla", "tarea", "tareas", "texto", "tiempo", "tipo",
+	"total", "ultimo", "usuario", "usuarios", "validar", "valido", "valor", "valores", "vacio", "venta",
+	"ventas", "viejo",
+}
@@ -0,0 +1,54 @@
+package linter
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// NewGetterPrefixFactory creates a new getter prefix rule factory.
+func NewGetterPrefixFactory() entity.RuleFactory {
+	return getterPrefixFactory{}
+}
+
+type getterPrefixFactory struct{}
+
+func (f getterPrefixFactory) Make(severity entity.Severity) (entity.Rule, error) {
+	base, err := newRule("getter-prefix", severity, entity.SeverityInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return getterPrefixRule{base}, nil
+}
+
+type getterPrefixRule struct {
+	rule
+}
+
+// Check reports methods named as "GetX", given that Go getters should be named after
+// the field they return.
+func (r getterPrefixRule) Check(ident entity.Identifier) []entity.Finding {
+	if ident.Type != token.FUNC || ident.Receiver == "" {
+		return []entity.Finding{}
+	}
+
+	if !strings.HasPrefix(ident.Name, "Get") || len(ident.Name) == len("Get") {
+		return []entity.Finding{}
+	}
+
+	rest := ident.Name[len("Get"):]
+	if !unicode.IsUpper(rune(rest[0])) {
+		return []entity.Finding{}
+	}
+
+	return []entity.Finding{
+		{
+			Message:    fmt.Sprintf("getter %s.%s should be named %s", ident.Receiver, ident.Name, rest),
+			Suggestion: rest,
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package linter_test
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_OnGetterPrefix_ShouldReportGettersStartingWithGet(t *testing.T) {
+	cases := []struct {
+		name     string
+		ident    entity.Identifier
+		findings int
+	}{
+		{"function", entity.Identifier{Name: "GetOwner", Type: token.FUNC}, 0},
+		{"method_without_prefix", entity.Identifier{Name: "Owner", Type: token.FUNC, Receiver: "project"}, 0},
+		{"method_named_get", entity.Identifier{Name: "Get", Type: token.FUNC, Receiver: "project"}, 0},
+		{"method_with_word_prefix", entity.Identifier{Name: "Getaway", Type: token.FUNC, Receiver: "project"}, 0},
+		{"getter", entity.Identifier{Name: "GetOwner", Type: token.FUNC, Receiver: "project"}, 1},
+	}
+
+	rule, _ := linter.NewGetterPrefixFactory().Make("")
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			findings := rule.Check(c.ident)
+
+			assert.Equal(t, c.findings, len(findings))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// commonInitialisms is the list of initialisms that should keep a consistent case, as
+// defined on the Go code review comments.
+var commonInitialisms = map[string]struct{}{
+	"ACL": {}, "API": {}, "ASCII": {}, "CPU": {}, "CSS": {}, "DNS": {}, "EOF": {}, "GUID": {},
+	"HTML": {}, "HTTP": {}, "HTTPS": {}, "ID": {}, "IP": {}, "JSON": {}, "LHS": {}, "QPS": {},
+	"RAM": {}, "RHS": {}, "RPC": {}, "SLA": {}, "SMTP": {}, "SQL": {}, "SSH": {}, "TCP": {},
+	"TLS": {}, "TTL": {}, "UDP": {}, "UI": {}, "UID": {}, "UUID": {}, "URI": {}, "URL": {},
+	"UTF8": {}, "VM": {}, "XML": {}, "XMPP": {}, "XSRF": {}, "XSS": {},
+}
+
+// NewInitialismsFactory creates a new initialisms rule factory.
+func NewInitialismsFactory() entity.RuleFactory {
+	return initialismsFactory{}
+}
+
+type initialismsFactory struct{}
+
+func (f initialismsFactory) Make(severity entity.Severity) (entity.Rule, error) {
+	base, err := newRule("initialisms", severity, entity.SeverityWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	return initialismsRule{base}, nil
+}
+
+type initialismsRule struct {
+	rule
+}
+
+// Check reports initialisms that don't have a consistent case, such as "Url" or "HttpId".
+// A lower case initialism is only accepted at the beginning of the name.
+func (r initialismsRule) Check(ident entity.Identifier) []entity.Finding {
+	parts := words(ident.Name)
+
+	var invalid []string
+	for i, word := range parts {
+		upper := strings.ToUpper(word)
+		if _, ok := commonInitialisms[upper]; !ok || word == upper {
+			continue
+		}
+
+		if i == 0 && word == strings.ToLower(word) {
+			continue
+		}
+
+		invalid = append(invalid, word)
+		parts[i] = upper
+	}
+
+	if len(invalid) == 0 {
+		return []entity.Finding{}
+	}
+
+	return []entity.Finding{
+		{
+			Message:    fmt.Sprintf("initialisms %s should have a consistent case", strings.Join(invalid, ", ")),
+			Suggestion: strings.Join(parts, ""),
+		},
+	}
+}
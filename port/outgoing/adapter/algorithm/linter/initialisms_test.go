@@ -0,0 +1,47 @@
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake_OnInitialismsFactory_ShouldReturnRuleWithDefaultSeverity(t *testing.T) {
+	rule, err := linter.NewInitialismsFactory().Make("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "initialisms", rule.Name())
+	assert.Equal(t, entity.SeverityWarning, rule.Severity())
+}
+
+func TestCheck_OnInitialisms_ShouldReportInconsistentInitialisms(t *testing.T) {
+	cases := []struct {
+		name       string
+		identifier string
+		findings   int
+		suggestion string
+	}{
+		{"no_initialisms", "parseFile", 0, ""},
+		{"upper_initialism", "ServeHTTP", 0, ""},
+		{"upper_initialism_before_word", "HTTPServer", 0, ""},
+		{"lower_initialism_on_start", "urlPath", 0, ""},
+		{"capitalized_initialism", "Url", 1, "URL"},
+		{"two_capitalized_initialisms", "HttpId", 1, "HTTPID"},
+		{"capitalized_initialism_after_word", "userId", 1, "userID"},
+		{"snake_case_initialism", "base_Url", 1, "baseURL"},
+	}
+
+	rule, _ := linter.NewInitialismsFactory().Make("")
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			findings := rule.Check(entity.Identifier{Name: c.identifier})
+
+			assert.Equal(t, c.findings, len(findings))
+			if c.findings > 0 {
+				assert.Equal(t, c.suggestion, findings[0].Suggestion)
+			}
+		})
+	}
+}
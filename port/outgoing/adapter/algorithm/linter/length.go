@@ -0,0 +1,57 @@
+package linter
+
+import (
+	"fmt"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// DefaultMaxLength is the maximum length accepted for exported names.
+const DefaultMaxLength = 30
+
+// NewNameLengthFactory creates a new name length rule factory, using DefaultMaxLength.
+func NewNameLengthFactory() entity.RuleFactory {
+	return nameLengthFactory{maxLength: DefaultMaxLength}
+}
+
+type nameLengthFactory struct {
+	maxLength int
+}
+
+func (f nameLengthFactory) Make(severity entity.Severity) (entity.Rule, error) {
+	base, err := newRule("name-length", severity, entity.SeverityInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return nameLengthRule{
+		rule:      base,
+		maxLength: f.maxLength,
+	}, nil
+}
+
+type nameLengthRule struct {
+	rule
+	maxLength int
+}
+
+// Check reports exported identifiers named with a single letter, or with a name longer
+// than the maximum length.
+func (r nameLengthRule) Check(ident entity.Identifier) []entity.Finding {
+	if !ident.Exported() {
+		return []entity.Finding{}
+	}
+
+	switch length := len([]rune(ident.Name)); {
+	case length == 1:
+		return []entity.Finding{
+			{Message: fmt.Sprintf("exported name %s is a single letter", ident.Name)},
+		}
+	case length > r.maxLength:
+		return []entity.Finding{
+			{Message: fmt.Sprintf("exported name %s is longer than %d characters", ident.Name, r.maxLength)},
+		}
+	default:
+		return []entity.Finding{}
+	}
+}
@@ -0,0 +1,31 @@
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_OnNameLength_ShouldReportSingleLetterAndLongExportedNames(t *testing.T) {
+	cases := []struct {
+		name       string
+		identifier string
+		findings   int
+	}{
+		{"unexported_single_letter", "x", 0},
+		{"exported", "Reader", 0},
+		{"exported_single_letter", "X", 1},
+		{"exported_long_name", "DefaultRepositoryConnectionTimeoutInSeconds", 1},
+	}
+
+	rule, _ := linter.NewNameLengthFactory().Make("")
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			findings := rule.Check(entity.Identifier{Name: c.identifier})
+
+			assert.Equal(t, c.findings, len(findings))
+		})
+	}
+}
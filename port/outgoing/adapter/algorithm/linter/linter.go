@@ -0,0 +1,109 @@
+package linter
+
+import (
+	"errors"
+	"unicode"
+
+	"github.com/eroatta/src-reader/entity"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewRuleFactory creates a new entity.RuleAbstractFactory, including the available rule factories.
+// It supports:
+//	* "initialisms"
+//	* "package-stutter"
+//	* "snake-case"
+//	* "getter-prefix"
+//	* "name-length"
+//	* "receiver-consistency"
+func NewRuleFactory() entity.RuleAbstractFactory {
+	return &ruleFactory{
+		factories: map[string]entity.RuleFactory{
+			"initialisms":          NewInitialismsFactory(),
+			"package-stutter":      NewPackageStutterFactory(),
+			"snake-case":           NewSnakeCaseFactory(),
+			"getter-prefix":        NewGetterPrefixFactory(),
+			"name-length":          NewNameLengthFactory(),
+			"receiver-consistency": NewReceiverConsistencyFactory(),
+		},
+	}
+}
+
+type ruleFactory struct {
+	factories map[string]entity.RuleFactory
+}
+
+// Get retrieves an entity.RuleFactory matching the rule name.
+func (f ruleFactory) Get(name string) (entity.RuleFactory, error) {
+	factory, ok := f.factories[name]
+	if !ok {
+		log.WithField("name", name).Error("no factory declared for the given name")
+		return nil, errors.New("no factory defined")
+	}
+
+	return factory, nil
+}
+
+type rule struct {
+	name     string
+	severity entity.Severity
+}
+
+// Name returns the name of the rule.
+func (r rule) Name() string {
+	return r.name
+}
+
+// Severity returns the severity used on the findings reported by the rule.
+func (r rule) Severity() entity.Severity {
+	return r.severity
+}
+
+// newRule creates the base rule, falling back to the default severity when none is provided.
+func newRule(name string, severity entity.Severity, defaultSeverity entity.Severity) (rule, error) {
+	if severity == "" {
+		severity = defaultSeverity
+	}
+
+	if !severity.Valid() {
+		return rule{}, errors.New("invalid severity")
+	}
+
+	return rule{name: name, severity: severity}, nil
+}
+
+// words splits a name into its words, preserving their case. It splits on underscores,
+// lower to upper case changes and on the last upper case letter of an upper case sequence
+// followed by a lower case letter (i.e. "HTTPServer" is split into "HTTP" and "Server").
+func words(name string) []string {
+	runes := []rune(name)
+	words := make([]string, 0)
+
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+
+		if i == start || !unicode.IsUpper(runes[i]) {
+			continue
+		}
+
+		prev := runes[i-1]
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
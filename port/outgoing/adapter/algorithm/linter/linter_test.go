@@ -0,0 +1,49 @@
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRuleFactory_ShouldReturnRuleAbstractFactory(t *testing.T) {
+	af := linter.NewRuleFactory()
+
+	assert.NotNil(t, af)
+	assert.Implements(t, (*entity.RuleAbstractFactory)(nil), af)
+}
+
+func TestGet_OnRuleFactory_WithNotExistingRule_ShouldReturnError(t *testing.T) {
+	af := linter.NewRuleFactory()
+	got, err := af.Get("non-existing")
+
+	assert.Nil(t, got)
+	assert.Error(t, err)
+}
+
+func TestGet_OnRuleFactory_WithInitialisms_ShouldReturnInitialismsFactory(t *testing.T) {
+	af := linter.NewRuleFactory()
+	got, err := af.Get("initialisms")
+
+	assert.Implements(t, (*entity.RuleFactory)(nil), got)
+	assert.NoError(t, err)
+}
+
+func TestMake_OnRuleFactory_WithInvalidSeverity_ShouldReturnError(t *testing.T) {
+	factory := linter.NewSnakeCaseFactory()
+	rule, err := factory.Make("critical")
+
+	assert.Nil(t, rule)
+	assert.Error(t, err)
+}
+
+func TestMake_OnRuleFactory_WithSeverity_ShouldOverrideDefaultSeverity(t *testing.T) {
+	factory := linter.NewSnakeCaseFactory()
+	rule, err := factory.Make(entity.SeverityError)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "snake-case", rule.Name())
+	assert.Equal(t, entity.SeverityError, rule.Severity())
+}
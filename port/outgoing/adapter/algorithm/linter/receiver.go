@@ -0,0 +1,72 @@
+package linter
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// NewReceiverConsistencyFactory creates a new receiver consistency rule factory.
+func NewReceiverConsistencyFactory() entity.RuleFactory {
+	return receiverConsistencyFactory{}
+}
+
+type receiverConsistencyFactory struct{}
+
+func (f receiverConsistencyFactory) Make(severity entity.Severity) (entity.Rule, error) {
+	base, err := newRule("receiver-consistency", severity, entity.SeverityWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	return &receiverConsistencyRule{
+		rule:  base,
+		names: make(map[string]string),
+	}, nil
+}
+
+// receiverConsistencyRule keeps track of the first receiver name found for each receiver type,
+// so it's meant to be used on a single analysis.
+type receiverConsistencyRule struct {
+	rule
+	sync.Mutex
+	names map[string]string
+}
+
+// Check reports methods whose receiver is named "this" or "self", or whose receiver
+// name differs from the name used on previously checked methods for the same type.
+func (r *receiverConsistencyRule) Check(ident entity.Identifier) []entity.Finding {
+	if ident.Receiver == "" || ident.ReceiverName == "" || ident.ReceiverName == "_" {
+		return []entity.Finding{}
+	}
+
+	if ident.ReceiverName == "this" || ident.ReceiverName == "self" {
+		return []entity.Finding{
+			{Message: fmt.Sprintf("receiver name for %s.%s should be a reflection of its identity; don't use generic names such as %s",
+				ident.Receiver, ident.Name, ident.ReceiverName)},
+		}
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	key := fmt.Sprintf("%s.%s", ident.FullPackageName(), ident.Receiver)
+	expected, ok := r.names[key]
+	if !ok {
+		r.names[key] = ident.ReceiverName
+		return []entity.Finding{}
+	}
+
+	if expected == ident.ReceiverName {
+		return []entity.Finding{}
+	}
+
+	return []entity.Finding{
+		{
+			Message: fmt.Sprintf("receiver name %s for %s.%s should be consistent with previous receiver name %s",
+				ident.ReceiverName, ident.Receiver, ident.Name, expected),
+			Suggestion: expected,
+		},
+	}
+}
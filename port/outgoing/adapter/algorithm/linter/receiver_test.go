@@ -0,0 +1,37 @@
+package linter_test
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_OnReceiverConsistency_WithGenericName_ShouldReportFinding(t *testing.T) {
+	rule, _ := linter.NewReceiverConsistencyFactory().Make("")
+
+	findings := rule.Check(entity.Identifier{Name: "Read", Type: token.FUNC, Receiver: "reader", ReceiverName: "self"})
+
+	assert.Equal(t, 1, len(findings))
+}
+
+func TestCheck_OnReceiverConsistency_WithInconsistentNames_ShouldReportFinding(t *testing.T) {
+	rule, _ := linter.NewReceiverConsistencyFactory().Make("")
+
+	first := rule.Check(entity.Identifier{Package: "io", File: "io/reader.go", Name: "Read", Type: token.FUNC,
+		Receiver: "reader", ReceiverName: "r"})
+	second := rule.Check(entity.Identifier{Package: "io", File: "io/reader.go", Name: "Close", Type: token.FUNC,
+		Receiver: "reader", ReceiverName: "r"})
+	third := rule.Check(entity.Identifier{Package: "io", File: "io/reader.go", Name: "Reset", Type: token.FUNC,
+		Receiver: "reader", ReceiverName: "rd"})
+	other := rule.Check(entity.Identifier{Package: "io", File: "io/writer.go", Name: "Write", Type: token.FUNC,
+		Receiver: "writer", ReceiverName: "w"})
+
+	assert.Empty(t, first)
+	assert.Empty(t, second)
+	assert.Equal(t, 1, len(third))
+	assert.Equal(t, "r", third[0].Suggestion)
+	assert.Empty(t, other)
+}
@@ -0,0 +1,69 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// NewSnakeCaseFactory creates a new snake case rule factory.
+func NewSnakeCaseFactory() entity.RuleFactory {
+	return snakeCaseFactory{}
+}
+
+type snakeCaseFactory struct{}
+
+func (f snakeCaseFactory) Make(severity entity.Severity) (entity.Rule, error) {
+	base, err := newRule("snake-case", severity, entity.SeverityWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	return snakeCaseRule{base}, nil
+}
+
+type snakeCaseRule struct {
+	rule
+}
+
+// Check reports identifiers using underscores to separate words, such as "max_length" or
+// "MAX_LENGTH", and suggests its MixedCaps form.
+func (r snakeCaseRule) Check(ident entity.Identifier) []entity.Finding {
+	name := strings.Trim(ident.Name, "_")
+	if !strings.Contains(name, "_") {
+		return []entity.Finding{}
+	}
+
+	var suggestion strings.Builder
+	for i, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+
+		if isUpper(word) {
+			word = strings.ToLower(word)
+		}
+
+		if i == 0 && !ident.Exported() {
+			suggestion.WriteString(word)
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		suggestion.WriteString(string(runes))
+	}
+
+	return []entity.Finding{
+		{
+			Message:    fmt.Sprintf("name %s uses underscores; use MixedCaps or mixedCaps instead", ident.Name),
+			Suggestion: suggestion.String(),
+		},
+	}
+}
+
+func isUpper(word string) bool {
+	return word == strings.ToUpper(word) && word != strings.ToLower(word)
+}
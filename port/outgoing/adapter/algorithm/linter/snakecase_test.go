@@ -0,0 +1,37 @@
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_OnSnakeCase_ShouldReportNamesWithUnderscores(t *testing.T) {
+	cases := []struct {
+		name       string
+		identifier string
+		findings   int
+		suggestion string
+	}{
+		{"mixed_caps", "maxLength", 0, ""},
+		{"blank", "_", 0, ""},
+		{"leading_underscore", "_internal", 0, ""},
+		{"snake_case", "max_length", 1, "maxLength"},
+		{"exported_snake_case", "Max_length", 1, "MaxLength"},
+		{"upper_snake_case", "MAX_LENGTH", 1, "MaxLength"},
+	}
+
+	rule, _ := linter.NewSnakeCaseFactory().Make("")
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			findings := rule.Check(entity.Identifier{Name: c.identifier})
+
+			assert.Equal(t, c.findings, len(findings))
+			if c.findings > 0 {
+				assert.Equal(t, c.suggestion, findings[0].Suggestion)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package linter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// NewPackageStutterFactory creates a new package stutter rule factory.
+func NewPackageStutterFactory() entity.RuleFactory {
+	return packageStutterFactory{}
+}
+
+type packageStutterFactory struct{}
+
+func (f packageStutterFactory) Make(severity entity.Severity) (entity.Rule, error) {
+	base, err := newRule("package-stutter", severity, entity.SeverityWarning)
+	if err != nil {
+		return nil, err
+	}
+
+	return packageStutterRule{base}, nil
+}
+
+type packageStutterRule struct {
+	rule
+}
+
+// Check reports exported identifiers that repeat the package name, such as "http.HTTPServer".
+// Methods are skipped, given that they are referenced through their receivers.
+func (r packageStutterRule) Check(ident entity.Identifier) []entity.Finding {
+	if !ident.Exported() || ident.Receiver != "" || ident.Package == "" {
+		return []entity.Finding{}
+	}
+
+	pkg := strings.ToLower(ident.Package)
+	if len(ident.Name) <= len(pkg) || !strings.HasPrefix(strings.ToLower(ident.Name), pkg) {
+		return []entity.Finding{}
+	}
+
+	rest := ident.Name[len(pkg):]
+	if !unicode.IsUpper(rune(rest[0])) {
+		return []entity.Finding{}
+	}
+
+	return []entity.Finding{
+		{
+			Message:    fmt.Sprintf("name %s.%s stutters; consider calling it %s", ident.Package, ident.Name, rest),
+			Suggestion: rest,
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package linter_test
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_OnPackageStutter_ShouldReportStutteringNames(t *testing.T) {
+	cases := []struct {
+		name       string
+		ident      entity.Identifier
+		findings   int
+		suggestion string
+	}{
+		{"unexported", entity.Identifier{Package: "http", Name: "httpServer"}, 0, ""},
+		{"no_stutter", entity.Identifier{Package: "http", Name: "Server"}, 0, ""},
+		{"prefix_without_boundary", entity.Identifier{Package: "http", Name: "Httpserver"}, 0, ""},
+		{"method", entity.Identifier{Package: "http", Name: "HTTPServer", Type: token.FUNC, Receiver: "mux"}, 0, ""},
+		{"upper_stutter", entity.Identifier{Package: "http", Name: "HTTPServer"}, 1, "Server"},
+		{"capitalized_stutter", entity.Identifier{Package: "user", Name: "UserRepository"}, 1, "Repository"},
+	}
+
+	rule, _ := linter.NewPackageStutterFactory().Make("")
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			findings := rule.Check(c.ident)
+
+			assert.Equal(t, c.findings, len(findings))
+			if c.findings > 0 {
+				assert.Equal(t, c.suggestion, findings[0].Suggestion)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package miner
+
+import (
+	"context"
+	"go/ast"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/token/conserv"
+	"github.com/eroatta/token/lists"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/diff"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+)
+
+const (
+	// SourceHistory identifies the expansions learned from renames on the version history.
+	SourceHistory = "history"
+	// SourceSiblings identifies the expansions learned from sibling identifiers on the same package.
+	SourceSiblings = "siblings"
+
+	// DefaultMaxCommits defines the number of commits walked while learning from the version history.
+	DefaultMaxCommits = 500
+
+	historyConfidence  = 0.8
+	siblingsConfidence = 0.6
+)
+
+var tokenizer = regexp.MustCompile("[A-Za-z_][A-Za-z0-9_]*")
+
+// NewAbbreviationsFactory creates a new learned abbreviations miner factory.
+func NewAbbreviationsFactory() entity.MinerFactory {
+	return abbreviationsFactory{}
+}
+
+type abbreviationsFactory struct{}
+
+func (f abbreviationsFactory) Make() (entity.Miner, error) {
+	return NewAbbreviations(lists.Dictionary, DefaultMaxCommits), nil
+}
+
+// NewAbbreviations initializes a new learned abbreviations miner. Words contained on the
+// dictionary are never considered abbreviations.
+func NewAbbreviations(dict lists.List, maxCommits int) *Abbreviations {
+	return &Abbreviations{
+		miner:      miner{"abbreviations"},
+		dict:       dict,
+		maxCommits: maxCommits,
+		words:      make(map[string]map[string]struct{}),
+		renames:    make(map[string]map[string]int),
+	}
+}
+
+// Abbreviations represents the learned abbreviations miner, which aligns abbreviated words with
+// their full-word siblings on the same package, and also with the renames found on the version history.
+type Abbreviations struct {
+	miner
+	dict        lists.List
+	maxCommits  int
+	packageName string
+	words       map[string]map[string]struct{}
+	renames     map[string]map[string]int
+}
+
+// LearnedExpansion represents a full word learned for an abbreviation, with its source and confidence.
+type LearnedExpansion struct {
+	Expansion  string
+	Source     string
+	Confidence float64
+}
+
+// SetCurrentFile specifies the current file being mined.
+func (m *Abbreviations) SetCurrentFile(filename string) {
+	// do nothing
+}
+
+// Visit implements the ast.Visitor interface and handles the logic for the data extraction.
+func (m *Abbreviations) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		return nil
+	}
+
+	switch elem := node.(type) {
+	case *ast.File:
+		m.packageName = elem.Name.String()
+
+	case *ast.Ident:
+		if elem.Name == "_" {
+			return m
+		}
+
+		words, ok := m.words[m.packageName]
+		if !ok {
+			words = make(map[string]struct{})
+			m.words[m.packageName] = words
+		}
+
+		for _, word := range splitWords(elem.Name) {
+			words[word] = struct{}{}
+		}
+	}
+
+	return m
+}
+
+// MineHistory walks the commits reachable from HEAD on the Git repository stored at the given location,
+// looking for identifiers renamed from an abbreviation to its full word, or the other way around. Only the
+// changes on the Go files not rejected by skips are diffed. The walk stops as soon as the context is cancelled.
+func (m *Abbreviations) MineHistory(ctx context.Context, location string, skips func(filename string) bool) error {
+	repo, err := git.PlainOpen(location)
+	if err != nil {
+		return err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+
+	commits, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return err
+	}
+
+	walked := 0
+	return commits.ForEach(func(commit *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if walked >= m.maxCommits {
+			return storer.ErrStop
+		}
+		walked++
+
+		// merge and root commits are skipped, since they don't represent a single change
+		if commit.NumParents() != 1 {
+			return nil
+		}
+
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return err
+		}
+
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return err
+		}
+
+		tree, err := commit.Tree()
+		if err != nil {
+			return err
+		}
+
+		changes, err := object.DiffTreeContext(ctx, parentTree, tree)
+		if err != nil {
+			return err
+		}
+
+		// the changes are only diffed once the files are known to be mined, so huge or vendored files are never
+		// loaded
+		for _, change := range changes {
+			filename := change.To.Name
+			if filename == "" || !strings.HasSuffix(filename, ".go") || (skips != nil && skips(filename)) {
+				continue
+			}
+
+			patch, err := change.PatchContext(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, filePatch := range patch.FilePatches() {
+				if !filePatch.IsBinary() {
+					m.mineChunks(filePatch.Chunks())
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// mineChunks pairs the lines deleted by a chunk with the lines added by the following one, and
+// registers the renamed identifiers. Each deleted line is paired with the added line that only differs on
+// renamed identifiers, if any, so the lines inserted or removed along with the renames are ignored.
+func (m *Abbreviations) mineChunks(chunks []diff.Chunk) {
+	for i := 1; i < len(chunks); i++ {
+		if chunks[i-1].Type() != diff.Delete || chunks[i].Type() != diff.Add {
+			continue
+		}
+
+		added := make([][]string, 0)
+		for _, line := range strings.Split(chunks[i].Content(), "\n") {
+			added = append(added, tokenizer.FindAllString(line, -1))
+		}
+
+		paired := make([]bool, len(added))
+		for _, line := range strings.Split(chunks[i-1].Content(), "\n") {
+			before := tokenizer.FindAllString(line, -1)
+			best := -1
+			var bestRenames [][2]string
+			for j, after := range added {
+				if paired[j] {
+					continue
+				}
+
+				renames, ok := m.renamesBetween(before, after)
+				if ok && len(renames) > 0 && (best == -1 || len(renames) < len(bestRenames)) {
+					best, bestRenames = j, renames
+				}
+			}
+			if best == -1 {
+				continue
+			}
+
+			paired[best] = true
+			for _, rename := range bestRenames {
+				if _, ok := m.renames[rename[0]]; !ok {
+					m.renames[rename[0]] = make(map[string]int)
+				}
+				m.renames[rename[0]][rename[1]]++
+			}
+		}
+	}
+}
+
+// renamesBetween compares the tokens of a deleted line with the ones of an added line, retrieving the
+// abbreviation and the expansion for each renamed identifier. Lines differing on anything else can't be paired.
+func (m *Abbreviations) renamesBetween(before []string, after []string) ([][2]string, bool) {
+	if len(before) != len(after) {
+		return nil, false
+	}
+
+	renames := make([][2]string, 0)
+	for k := range before {
+		if before[k] == after[k] {
+			continue
+		}
+
+		abbreviation, expansion, ok := m.renamedWord(before[k], after[k])
+		if !ok {
+			return nil, false
+		}
+		renames = append(renames, [2]string{abbreviation, expansion})
+	}
+
+	return renames, true
+}
+
+// renamedWord checks if both identifiers only differ on a single word, being one of them an abbreviation
+// of the other.
+func (m *Abbreviations) renamedWord(before string, after string) (string, string, bool) {
+	beforeWords := splitWords(before)
+	afterWords := splitWords(after)
+	if len(beforeWords) != len(afterWords) {
+		return "", "", false
+	}
+
+	diffs := 0
+	var from, to string
+	for i := range beforeWords {
+		if beforeWords[i] != afterWords[i] {
+			diffs++
+			from, to = beforeWords[i], afterWords[i]
+		}
+	}
+
+	switch {
+	case diffs != 1:
+		return "", "", false
+	case m.isAbbreviation(from, to):
+		return from, to, true
+	case m.isAbbreviation(to, from):
+		return to, from, true
+	}
+
+	return "", "", false
+}
+
+// isAbbreviation determines if the short word can be an abbreviation for the long word: it can't be a known
+// word, it must start with the same letter and its letters must appear in the same order on the long word.
+func (m *Abbreviations) isAbbreviation(short string, long string) bool {
+	if len(short) < 2 || len(long) < 4 || len(short) >= len(long) || short[0] != long[0] {
+		return false
+	}
+
+	if m.dict.Contains(short) {
+		return false
+	}
+
+	i := 0
+	for j := 0; i < len(short) && j < len(long); j++ {
+		if short[i] == long[j] {
+			i++
+		}
+	}
+
+	return i == len(short)
+}
+
+// Results returns the learned expansions for each abbreviation, sorted by confidence.
+func (m *Abbreviations) Results() interface{} {
+	confidences := make(map[string]map[string]float64)
+	sources := make(map[string]map[string]string)
+	register := func(abbreviation string, expansion string, source string, confidence float64) {
+		if _, ok := confidences[abbreviation]; !ok {
+			confidences[abbreviation] = make(map[string]float64)
+			sources[abbreviation] = make(map[string]string)
+		}
+
+		previous, ok := confidences[abbreviation][expansion]
+		switch {
+		case !ok:
+			confidences[abbreviation][expansion] = confidence
+			sources[abbreviation][expansion] = source
+		case sources[abbreviation][expansion] == source:
+			confidences[abbreviation][expansion] = max(previous, confidence)
+		default:
+			// independent evidence from both sources, where the history is the strongest one
+			confidences[abbreviation][expansion] = 1 - (1-previous)*(1-confidence)
+			sources[abbreviation][expansion] = SourceHistory
+		}
+	}
+
+	for abbreviation, expansions := range m.renames {
+		for expansion, count := range expansions {
+			confidence := 1.0
+			for i := 0; i < count; i++ {
+				confidence *= 1 - historyConfidence
+			}
+			register(abbreviation, expansion, SourceHistory, 1-confidence)
+		}
+	}
+
+	for _, words := range m.words {
+		for short := range words {
+			candidates := make([]string, 0)
+			for long := range words {
+				if m.isAbbreviation(short, long) {
+					candidates = append(candidates, long)
+				}
+			}
+
+			for _, candidate := range candidates {
+				register(short, candidate, SourceSiblings, siblingsConfidence/float64(len(candidates)))
+			}
+		}
+	}
+
+	learned := make(map[string][]LearnedExpansion, len(confidences))
+	for abbreviation, expansions := range confidences {
+		for expansion, confidence := range expansions {
+			learned[abbreviation] = append(learned[abbreviation], LearnedExpansion{
+				Expansion:  expansion,
+				Source:     sources[abbreviation][expansion],
+				Confidence: confidence,
+			})
+		}
+
+		sort.Slice(learned[abbreviation], func(i, j int) bool {
+			a, b := learned[abbreviation][i], learned[abbreviation][j]
+			if a.Confidence != b.Confidence {
+				return a.Confidence > b.Confidence
+			}
+			return a.Expansion < b.Expansion
+		})
+	}
+
+	return learned
+}
+
+func splitWords(name string) []string {
+	words := make([]string, 0)
+	for _, word := range strings.Split(conserv.Split(name), " ") {
+		word = strings.ToLower(strings.Trim(word, "_"))
+		if word == "" {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return words
+}
+
+func max(a float64, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,262 @@
+package miner_test
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
+	"github.com/eroatta/token/lists"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestNewAbbreviationsFactory_ShouldReturnAbbreviationsMinerFactory(t *testing.T) {
+	factory := miner.NewAbbreviationsFactory()
+
+	assert.NotNil(t, factory)
+}
+
+func TestMake_OnAbbreviationsFactory_ShouldReturnMiner(t *testing.T) {
+	factory := miner.NewAbbreviationsFactory()
+	miner, err := factory.Make()
+
+	assert.Equal(t, "abbreviations", miner.Name())
+	assert.NoError(t, err)
+}
+
+func TestVisit_OnAbbreviationsWithNilNode_ShouldReturnNil(t *testing.T) {
+	miner := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+
+	assert.Nil(t, miner.Visit(nil))
+}
+
+func TestResults_OnAbbreviations_ShouldAlignSiblingIdentifiers(t *testing.T) {
+	src := `
+		package main
+
+		var cfg string
+		var defaultConfig string
+
+		func readUsr(usrName string, userID int) {}
+	`
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	ast.Walk(abbreviations, parse(t, src))
+
+	got := abbreviations.Results().(map[string][]miner.LearnedExpansion)
+
+	assert.Equal(t, []miner.LearnedExpansion{
+		{Expansion: "config", Source: miner.SourceSiblings, Confidence: 0.6},
+	}, got["cfg"])
+	assert.Equal(t, []miner.LearnedExpansion{
+		{Expansion: "user", Source: miner.SourceSiblings, Confidence: 0.6},
+	}, got["usr"])
+	assert.NotContains(t, got, "user")
+	assert.NotContains(t, got, "read")
+}
+
+func TestResults_OnAbbreviationsWithSeveralCandidates_ShouldSplitConfidence(t *testing.T) {
+	src := `
+		package main
+
+		var str, string, stream int
+	`
+	abbreviations := miner.NewAbbreviations(lists.NewBuilder().Build(), miner.DefaultMaxCommits)
+	ast.Walk(abbreviations, parse(t, src))
+
+	got := abbreviations.Results().(map[string][]miner.LearnedExpansion)
+
+	assert.Equal(t, []miner.LearnedExpansion{
+		{Expansion: "stream", Source: miner.SourceSiblings, Confidence: 0.3},
+		{Expansion: "string", Source: miner.SourceSiblings, Confidence: 0.3},
+	}, got["str"])
+}
+
+func TestMineHistory_OnAbbreviationsWithInvalidLocation_ShouldReturnError(t *testing.T) {
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+
+	err := abbreviations.MineHistory(context.TODO(), "/tmp/non-existing-repository", nil)
+
+	assert.Error(t, err)
+}
+
+func TestMineHistory_OnAbbreviations_ShouldLearnFromRenames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "abbreviations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit(t, repo, dir, "main.go", "package main\n\nvar usrName string\n\nfunc svcCtx() {}\n")
+	commit(t, repo, dir, "main.go", "package main\n\nvar userName string\n\nfunc svcContext() {}\n")
+	commit(t, repo, dir, "README.md", "var cfgName\n")
+	commit(t, repo, dir, "README.md", "var configName\n")
+
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	err = abbreviations.MineHistory(context.TODO(), dir, nil)
+
+	assert.NoError(t, err)
+
+	got := abbreviations.Results().(map[string][]miner.LearnedExpansion)
+	assert.Equal(t, 2, len(got))
+	assert.Equal(t, "user", got["usr"][0].Expansion)
+	assert.Equal(t, miner.SourceHistory, got["usr"][0].Source)
+	assert.InDelta(t, 0.8, got["usr"][0].Confidence, 0.0001)
+	assert.Equal(t, "context", got["ctx"][0].Expansion)
+	assert.NotContains(t, got, "cfg")
+}
+
+func TestMineHistory_OnAbbreviationsWithUnevenChunks_ShouldPairTheRenamedLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "abbreviations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit(t, repo, dir, "main.go", "package main\n\nvar usrName string\n\nfunc main() {}\n")
+	commit(t, repo, dir, "main.go", "package main\n\nvar retries int\nvar userName string\n\nfunc main() {}\n")
+
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	err = abbreviations.MineHistory(context.TODO(), dir, nil)
+
+	assert.NoError(t, err)
+
+	got := abbreviations.Results().(map[string][]miner.LearnedExpansion)
+	assert.Equal(t, 1, len(got))
+	assert.Equal(t, "user", got["usr"][0].Expansion)
+}
+
+func TestMineHistory_OnAbbreviationsWithSiblings_ShouldCombineConfidence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "abbreviations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit(t, repo, dir, "main.go", "package main\n\nvar usrName string\n")
+	commit(t, repo, dir, "main.go", "package main\n\nvar userName string\n")
+
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	ast.Walk(abbreviations, parse(t, "package main\n\nvar usr, user string\n"))
+	err = abbreviations.MineHistory(context.TODO(), dir, nil)
+
+	assert.NoError(t, err)
+
+	got := abbreviations.Results().(map[string][]miner.LearnedExpansion)
+	assert.Equal(t, 1, len(got["usr"]))
+	assert.Equal(t, miner.SourceHistory, got["usr"][0].Source)
+	assert.InDelta(t, 0.92, got["usr"][0].Confidence, 0.0001)
+}
+
+func TestMineHistory_OnAbbreviationsWithSkippedFiles_ShouldIgnoreTheirChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "abbreviations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit(t, repo, dir, "main.go", "package main\n\nvar usrName string\n")
+	commit(t, repo, dir, "main.go", "package main\n\nvar userName string\n")
+	commit(t, repo, dir, "vendor/lib/lib.go", "package lib\n\nfunc svcCtx() {}\n")
+	commit(t, repo, dir, "vendor/lib/lib.go", "package lib\n\nfunc svcContext() {}\n")
+
+	skipped := make([]string, 0)
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	err = abbreviations.MineHistory(context.TODO(), dir, func(filename string) bool {
+		skipped = append(skipped, filename)
+		return filename == "vendor/lib/lib.go"
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, skipped, "vendor/lib/lib.go")
+
+	got := abbreviations.Results().(map[string][]miner.LearnedExpansion)
+	assert.Equal(t, "user", got["usr"][0].Expansion)
+	assert.NotContains(t, got, "ctx")
+}
+
+func TestMineHistory_OnAbbreviationsWithCanceledContext_ShouldReturnError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "abbreviations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit(t, repo, dir, "main.go", "package main\n\nvar usrName string\n")
+	commit(t, repo, dir, "main.go", "package main\n\nvar userName string\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	abbreviations := miner.NewAbbreviations(lists.Dictionary, miner.DefaultMaxCommits)
+	err = abbreviations.MineHistory(ctx, dir, nil)
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, abbreviations.Results())
+}
+
+func parse(t *testing.T, src string) *ast.File {
+	fs := token.NewFileSet()
+	node, err := parser.ParseFile(fs, "", []byte(src), parser.AllErrors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return node
+}
+
+func commit(t *testing.T, repo *git.Repository, dir string, filename string, content string) {
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, filename)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, filename), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := wt.Add(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = wt.Commit("update "+filename, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
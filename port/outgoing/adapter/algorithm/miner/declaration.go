@@ -6,8 +6,8 @@ import (
 	"go/token"
 	"strings"
 
-	"github.com/eroatta/nounphrases"
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
 	"github.com/eroatta/token/conserv"
 	"github.com/eroatta/token/lists"
 	log "github.com/sirupsen/logrus"
@@ -24,12 +24,15 @@ func (f declarationsFactory) Make() (entity.Miner, error) {
 	return NewDeclaration(lists.Dictionary), nil
 }
 
-// NewDeclaration initializes a new declarations miner.
+// NewDeclaration initializes a new declarations miner. The given dictionary is used for the packages
+// written in English, or with no detected language.
 func NewDeclaration(dict lists.List) *Declaration {
 	return &Declaration{
-		miner: miner{"declarations"},
-		Dict:  dict,
-		Decls: make(map[string]Decl),
+		miner:       miner{"declarations"},
+		Dict:        dict,
+		defaultDict: dict,
+		Languages:   make(map[string]entity.Language),
+		Decls:       make(map[string]Decl),
 	}
 }
 
@@ -39,6 +42,9 @@ type Declaration struct {
 	miner
 	Filename    string
 	Dict        lists.List
+	defaultDict lists.List
+	Languages   map[string]entity.Language
+	Language    entity.Language
 	PackageName string
 	Comments    []*ast.CommentGroup
 	Included    []ast.Decl
@@ -58,6 +64,11 @@ func (m *Declaration) SetCurrentFile(filename string) {
 	m.Filename = filename
 }
 
+// SetLanguages specifies the language detected for each package, so the matching word lists are used.
+func (m *Declaration) SetLanguages(languages map[string]entity.Language) {
+	m.Languages = languages
+}
+
 // Visit implements the ast.Visitor interface and handles the logic for the data extraction.
 func (m *Declaration) Visit(node ast.Node) ast.Visitor {
 	// TODO: (stemming + stopping)
@@ -68,6 +79,11 @@ func (m *Declaration) Visit(node ast.Node) ast.Visitor {
 	switch elem := node.(type) {
 	case *ast.File:
 		m.PackageName = elem.Name.String()
+		m.Language = m.Languages[entity.Identifier{Package: m.PackageName, File: m.Filename}.FullPackageName()]
+		m.Dict = m.defaultDict
+		if m.Language != "" && m.Language != entity.LanguageEnglish {
+			m.Dict = language.Dictionary(m.Language)
+		}
 		m.Included = elem.Decls
 		m.Comments = append(m.Comments, elem.Comments...)
 
@@ -87,7 +103,7 @@ func (m *Declaration) Visit(node ast.Node) ast.Visitor {
 		genDeclComments := newDecl("common", elem.Tok)
 		if elem.Doc != nil {
 			for _, comment := range elem.Doc.List {
-				genDeclComments = extractWordAndPhrasesFromComment(genDeclComments, comment.Text, m.Dict)
+				genDeclComments = extractWordAndPhrasesFromComment(genDeclComments, comment.Text, m.Dict, m.Language)
 			}
 		}
 
@@ -96,7 +112,7 @@ func (m *Declaration) Visit(node ast.Node) ast.Visitor {
 				valDeclComments := newDecl("val", elem.Tok)
 				if valSpec.Doc != nil {
 					for _, comment := range valSpec.Doc.List {
-						valDeclComments = extractWordAndPhrasesFromComment(valDeclComments, comment.Text, m.Dict)
+						valDeclComments = extractWordAndPhrasesFromComment(valDeclComments, comment.Text, m.Dict, m.Language)
 					}
 				}
 
@@ -106,7 +122,7 @@ func (m *Declaration) Visit(node ast.Node) ast.Visitor {
 					}
 
 					declText := newDecl(declID(m.Filename, m.PackageName, elem.Tok, name.String(), ""), elem.Tok)
-					declText = extractDeclFromValue(declText, valSpec, name.Name, j, m.Dict)
+					declText = extractDeclFromValue(declText, valSpec, name.Name, j, m.Dict, m.Language)
 					declText = merge(merge(declText, genDeclComments), valDeclComments)
 
 					m.Decls[declText.ID] = declText
@@ -125,20 +141,20 @@ func (m *Declaration) Visit(node ast.Node) ast.Visitor {
 
 				if typeSpec.Doc != nil {
 					for _, comment := range typeSpec.Doc.List {
-						declText = extractWordAndPhrasesFromComment(declText, comment.Text, m.Dict)
+						declText = extractWordAndPhrasesFromComment(declText, comment.Text, m.Dict, m.Language)
 					}
 				}
 
 				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
 					declText.ID = declID(m.Filename, m.PackageName, token.STRUCT, name, "")
 					declText.DeclType = token.STRUCT
-					declText = extractDeclFromStruct(declText, structType, m.Dict)
+					declText = extractDeclFromStruct(declText, structType, m.Dict, m.Language)
 				}
 
 				if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
 					declText.ID = declID(m.Filename, m.PackageName, token.INTERFACE, name, "")
 					declText.DeclType = token.INTERFACE
-					declText = extractDeclFromInterface(declText, interfaceType, m.Dict)
+					declText = extractDeclFromInterface(declText, interfaceType, m.Dict, m.Language)
 				}
 
 				declText = merge(declText, genDeclComments)
@@ -194,7 +210,7 @@ func extractDeclFromFunction(elem *ast.FuncDecl, m *Declaration) Decl {
 
 	if elem.Doc != nil {
 		for _, comment := range elem.Doc.List {
-			functionText = extractWordAndPhrasesFromComment(functionText, comment.Text, m.Dict)
+			functionText = extractWordAndPhrasesFromComment(functionText, comment.Text, m.Dict, m.Language)
 		}
 	}
 
@@ -202,7 +218,7 @@ func extractDeclFromFunction(elem *ast.FuncDecl, m *Declaration) Decl {
 	for _, group := range m.Comments {
 		for _, comment := range group.List {
 			if comment.Slash > start && comment.Slash < end {
-				functionText = extractWordAndPhrasesFromComment(functionText, comment.Text, m.Dict)
+				functionText = extractWordAndPhrasesFromComment(functionText, comment.Text, m.Dict, m.Language)
 			}
 		}
 	}
@@ -210,7 +226,7 @@ func extractDeclFromFunction(elem *ast.FuncDecl, m *Declaration) Decl {
 	return functionText
 }
 
-func extractDeclFromValue(declText Decl, valSpec *ast.ValueSpec, name string, index int, list lists.List) Decl {
+func extractDeclFromValue(declText Decl, valSpec *ast.ValueSpec, name string, index int, list lists.List, lang entity.Language) Decl {
 	for _, part := range strings.Split(conserv.Split(name), " ") {
 		if list.Contains(part) {
 			declText.Words[part] = struct{}{}
@@ -221,13 +237,13 @@ func extractDeclFromValue(declText Decl, valSpec *ast.ValueSpec, name string, in
 		if val, ok := valSpec.Values[index].(*ast.BasicLit); ok && val.Kind == token.STRING {
 			valStr := strings.Replace(val.Value, "\"", "", -1)
 			for _, word := range strings.Split(valStr, " ") {
-				word = strings.ToLower(cleaner.ReplaceAllString(word, ""))
+				word = strings.ToLower(cleaner.ReplaceAllString(language.Fold(word), ""))
 				if list.Contains(word) {
 					declText.Words[word] = struct{}{}
 				}
 			}
 
-			phrases, _ := nounphrases.Find(cleanComment(valStr))
+			phrases, _ := language.Phrases(lang, cleanComment(valStr))
 			for _, phr := range phrases {
 				declText.Phrases[phr] = struct{}{}
 			}
@@ -237,7 +253,7 @@ func extractDeclFromValue(declText Decl, valSpec *ast.ValueSpec, name string, in
 	return declText
 }
 
-func extractDeclFromStruct(declText Decl, structType *ast.StructType, list lists.List) Decl {
+func extractDeclFromStruct(declText Decl, structType *ast.StructType, list lists.List, lang entity.Language) Decl {
 	if structType.Fields != nil && structType.Fields.List != nil {
 		for _, field := range structType.Fields.List {
 			for _, fname := range field.Names {
@@ -250,7 +266,7 @@ func extractDeclFromStruct(declText Decl, structType *ast.StructType, list lists
 
 			if field.Doc != nil {
 				for _, comment := range field.Doc.List {
-					declText = extractWordAndPhrasesFromComment(declText, comment.Text, list)
+					declText = extractWordAndPhrasesFromComment(declText, comment.Text, list, lang)
 				}
 			}
 		}
@@ -259,7 +275,7 @@ func extractDeclFromStruct(declText Decl, structType *ast.StructType, list lists
 	return declText
 }
 
-func extractDeclFromInterface(declText Decl, interfaceType *ast.InterfaceType, list lists.List) Decl {
+func extractDeclFromInterface(declText Decl, interfaceType *ast.InterfaceType, list lists.List, lang entity.Language) Decl {
 	if interfaceType.Methods != nil && interfaceType.Methods.List != nil {
 		for _, method := range interfaceType.Methods.List {
 			for _, mname := range method.Names {
@@ -272,7 +288,7 @@ func extractDeclFromInterface(declText Decl, interfaceType *ast.InterfaceType, l
 
 			if method.Doc != nil {
 				for _, comment := range method.Doc.List {
-					declText = extractWordAndPhrasesFromComment(declText, comment.Text, list)
+					declText = extractWordAndPhrasesFromComment(declText, comment.Text, list, lang)
 				}
 			}
 		}
@@ -302,16 +318,16 @@ func cleanComment(text string) string {
 	return strings.TrimSpace(cleanComment)
 }
 
-func extractWordAndPhrasesFromComment(functionText Decl, comment string, list lists.List) Decl {
+func extractWordAndPhrasesFromComment(functionText Decl, comment string, list lists.List, lang entity.Language) Decl {
 	cleanComment := cleanComment(comment)
 	for _, word := range strings.Split(cleanComment, " ") {
-		word = cleaner.ReplaceAllString(word, "")
+		word = cleaner.ReplaceAllString(language.Fold(word), "")
 		if list.Contains(word) {
 			functionText.Words[word] = struct{}{}
 		}
 	}
 
-	phrases, err := nounphrases.Find(cleanComment)
+	phrases, err := language.Phrases(lang, cleanComment)
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("unable to retrieve phrases from comment \"%s\"", cleanComment))
 	}
@@ -6,6 +6,7 @@ import (
 	"go/token"
 	"testing"
 
+	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
 	"github.com/stretchr/testify/assert"
 )
@@ -813,3 +814,39 @@ func TestVisit_OnDeclarationWithInterfaceDecl_ShouldReturnWordsAndPhrases(t *tes
 		})
 	}
 }
+
+func TestVisit_OnDeclarationWithSpanishPackage_ShouldUseSpanishWordLists(t *testing.T) {
+	src := `
+		package main
+
+		// guardar configuración del usuario
+		func guardar() {
+
+		}
+	`
+
+	fs := token.NewFileSet()
+	node, _ := parser.ParseFile(fs, "testfile.go", []byte(src), parser.ParseComments)
+
+	factory := miner.NewDeclarationsFactory()
+	m, _ := factory.Make()
+	m.(entity.LanguageMiner).SetLanguages(map[string]entity.Language{"main": entity.LanguageSpanish})
+	m.SetCurrentFile("testfile.go")
+	ast.Walk(m, node)
+
+	decls := m.Results().(map[string]miner.Decl)
+	assert.Equal(t, map[string]miner.Decl{
+		"filename:testfile.go+++pkg:main+++declType:func+++name:guardar": {
+			ID:       "filename:testfile.go+++pkg:main+++declType:func+++name:guardar",
+			DeclType: token.FUNC,
+			Words: map[string]struct{}{
+				"guardar":       {},
+				"configuracion": {},
+				"usuario":       {},
+			},
+			Phrases: map[string]struct{}{
+				"guardar configuracion": {},
+			},
+		},
+	}, decls)
+}
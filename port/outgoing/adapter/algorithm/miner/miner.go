@@ -9,7 +9,8 @@ import (
 
 // NewMinerFactory creates a new entity.MinerAbstractFactory, including the available miner factories.
 // It supports:
-// 	* "comments"
+// 	* "abbreviations"
+//	* "comments"
 //	* "declarations"
 //	* "global-frequency-table"
 //	* "scoped-declarations"
@@ -17,6 +18,7 @@ import (
 func NewMinerFactory() entity.MinerAbstractFactory {
 	return &minerFactory{
 		factories: map[string]entity.MinerFactory{
+			"abbreviations":          NewAbbreviationsFactory(),
 			"comments":               NewCommentsFactory(),
 			"declarations":           NewDeclarationsFactory(),
 			"global-frequency-table": NewGlobalFreqTableFactory(),
@@ -23,6 +23,14 @@ func TestGet_OnMinerFactory_WithNotExistingAlgorithm_ShouldReturnError(t *testin
 	assert.Error(t, err)
 }
 
+func TestGet_OnMinerFactory_WithAbbreviations_ShouldReturnAbbreviationsFactory(t *testing.T) {
+	af := miner.NewMinerFactory()
+	got, err := af.Get("abbreviations")
+
+	assert.Implements(t, (*entity.MinerFactory)(nil), got)
+	assert.NoError(t, err)
+}
+
 func TestGet_OnMinerFactory_WithComments_ShouldReturnCommentsFactory(t *testing.T) {
 	af := miner.NewMinerFactory()
 	got, err := af.Get("comments")
@@ -2,9 +2,19 @@ package splitter
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
 	"github.com/eroatta/token/greedy"
+	"github.com/eroatta/token/lists"
+)
+
+// languageLists holds the word lists used to split the identifiers written in each language other than English,
+// built on first use.
+var (
+	languageLists     map[entity.Language]lists.List
+	languageListsOnce sync.Once
 )
 
 // NewGreedyFactory creates a new Greedy splitter factory.
@@ -26,10 +36,42 @@ type greedySplitter struct {
 
 // Split splits a token using the Greedy splitter.
 func (g greedySplitter) Split(token string) []entity.Split {
+	return g.SplitInLanguage(token, entity.LanguageEnglish)
+}
+
+// SplitInLanguage splits a token using the Greedy splitter. Besides the default list, the words and the stop words
+// of the given language are matched.
+func (g greedySplitter) SplitInLanguage(token string, lang entity.Language) []entity.Split {
 	splits := []entity.Split{}
-	for i, split := range strings.Split(greedy.Split(token, greedy.DefaultList), " ") {
+	for i, split := range strings.Split(greedy.Split(token, greedyList(lang)), " ") {
 		splits = append(splits, entity.Split{Order: i + 1, Value: split})
 	}
 
 	return splits
 }
+
+// greedyList retrieves the word list for the given language, or the default list for English and the unsupported
+// languages.
+func greedyList(lang entity.Language) lists.List {
+	languageListsOnce.Do(func() {
+		languageLists = make(map[entity.Language]lists.List)
+		for _, supported := range language.Supported() {
+			if supported == entity.LanguageEnglish {
+				continue
+			}
+
+			languageLists[supported] = lists.NewBuilder().
+				Add(greedy.DefaultList.Elements()...).
+				Add(language.Dictionary(supported).Elements()...).
+				Add(language.Stop(supported).Elements()...).
+				Build()
+		}
+	})
+
+	list, ok := languageLists[lang]
+	if !ok {
+		return greedy.DefaultList
+	}
+
+	return list
+}
@@ -24,3 +24,16 @@ func TestSplit_OnGreedy_ShouldReturnSplit(t *testing.T) {
 	assert.Equal(t, "greedy", splitter.Name())
 	assert.Equal(t, []entity.Split{{Order: 1, Value: "car"}}, got)
 }
+
+func TestSplitInLanguage_OnGreedy_ShouldUseTheWordsOfTheLanguage(t *testing.T) {
+	factory := splitter.NewGreedyFactory()
+	greedy, _ := factory.Make(nil)
+	languageSplitter, ok := greedy.(entity.LanguageSplitter)
+	assert.True(t, ok)
+
+	got := languageSplitter.SplitInLanguage("cerrarcuenta", entity.LanguageSpanish)
+
+	assert.Equal(t, []entity.Split{{Order: 1, Value: "cerrar"}, {Order: 2, Value: "cuenta"}}, got)
+	assert.Equal(t, languageSplitter.Split("cerrarcuenta"),
+		languageSplitter.SplitInLanguage("cerrarcuenta", entity.LanguageEnglish))
+}
@@ -0,0 +1,633 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"go/token"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// Types of the elements stored on an archive.
+const (
+	archiveTypeProject    = "project"
+	archiveTypeAnalysis   = "analysis"
+	archiveTypeIdentifier = "identifier"
+	archiveTypeInsight    = "insight"
+)
+
+// archiveUpgrades holds, for each previous schema version, the function that upgrades its elements to the following
+// version. An archive can be read only if there is an upgrade for every version between its own and the current one.
+var archiveUpgrades = map[int]func(line archiveLine) (archiveLine, error){}
+
+// NewJSONLArchiveFormat creates a repository.ArchiveFormat that stores every element as a JSON object, one per line.
+// The first line holds the archive header, including its schema version.
+func NewJSONLArchiveFormat() repository.ArchiveFormat {
+	return jsonlArchiveFormat{}
+}
+
+type jsonlArchiveFormat struct{}
+
+// NewWriter creates a repository.ArchiveWriter that writes JSON lines on the given destination.
+func (f jsonlArchiveFormat) NewWriter(w io.Writer, header entity.ArchiveHeader) (repository.ArchiveWriter, error) {
+	buffer := bufio.NewWriter(w)
+	writer := &jsonlArchiveWriter{
+		buffer:  buffer,
+		encoder: json.NewEncoder(buffer),
+	}
+
+	version := header.SchemaVersion
+	if err := writer.encoder.Encode(archiveHeader{
+		SchemaVersion: &version,
+		CreatedAt:     header.CreatedAt,
+		AnalysisID:    header.AnalysisID,
+		Project:       header.ProjectRef,
+	}); err != nil {
+		log.WithError(err).Error("unable to write archive header")
+		return nil, repository.ErrArchiveUnexpected
+	}
+
+	return writer, nil
+}
+
+// NewReader creates a repository.ArchiveReader that reads JSON lines from the given source.
+func (f jsonlArchiveFormat) NewReader(r io.Reader) (repository.ArchiveReader, error) {
+	decoder := json.NewDecoder(bufio.NewReader(r))
+
+	var header archiveHeader
+	if err := decoder.Decode(&header); err != nil || header.SchemaVersion == nil {
+		log.WithError(err).Warn("unable to read archive header")
+		return nil, repository.ErrArchiveInvalid
+	}
+
+	version := *header.SchemaVersion
+	if version > entity.ArchiveSchemaVersion {
+		log.Warnf("archive schema version %d is newer than the supported version %d", version, entity.ArchiveSchemaVersion)
+		return nil, repository.ErrArchiveUnsupportedVersion
+	}
+	for v := version; v < entity.ArchiveSchemaVersion; v++ {
+		if _, ok := archiveUpgrades[v]; !ok {
+			log.Warnf("archive schema version %d can't be upgraded", version)
+			return nil, repository.ErrArchiveUnsupportedVersion
+		}
+	}
+
+	return &jsonlArchiveReader{
+		decoder: decoder,
+		header: entity.ArchiveHeader{
+			SchemaVersion: version,
+			CreatedAt:     header.CreatedAt,
+			AnalysisID:    header.AnalysisID,
+			ProjectRef:    header.Project,
+		},
+	}, nil
+}
+
+type jsonlArchiveWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// Write writes a new line for the element.
+func (w *jsonlArchiveWriter) Write(record entity.ArchiveRecord) error {
+	var line struct {
+		Type string      `json:"type"`
+		Data interface{} `json:"data"`
+	}
+	switch {
+	case record.Project != nil:
+		line.Type, line.Data = archiveTypeProject, newProjectRecord(*record.Project)
+	case record.Analysis != nil:
+		line.Type, line.Data = archiveTypeAnalysis, newAnalysisRecord(*record.Analysis)
+	case record.Identifier != nil:
+		line.Type, line.Data = archiveTypeIdentifier, newIdentifierArchiveRecord(*record.Identifier)
+	case record.Insight != nil:
+		line.Type, line.Data = archiveTypeInsight, newInsightRecord(*record.Insight)
+	default:
+		return repository.ErrArchiveInvalid
+	}
+
+	if err := w.encoder.Encode(line); err != nil {
+		log.WithError(err).Errorf("unable to write archive element of type %s", line.Type)
+		return repository.ErrArchiveUnexpected
+	}
+
+	return nil
+}
+
+// Close flushes the written lines.
+func (w *jsonlArchiveWriter) Close() error {
+	if err := w.buffer.Flush(); err != nil {
+		log.WithError(err).Error("unable to flush archive")
+		return repository.ErrArchiveUnexpected
+	}
+
+	return nil
+}
+
+type jsonlArchiveReader struct {
+	decoder *json.Decoder
+	header  entity.ArchiveHeader
+}
+
+// Header returns the archive header.
+func (r *jsonlArchiveReader) Header() entity.ArchiveHeader {
+	return r.header
+}
+
+// Read reads the next line, upgrading it to the current schema version.
+func (r *jsonlArchiveReader) Read() (entity.ArchiveRecord, error) {
+	var line archiveLine
+	if err := r.decoder.Decode(&line); err == io.EOF {
+		return entity.ArchiveRecord{}, io.EOF
+	} else if err != nil {
+		log.WithError(err).Warn("unable to read archive element")
+		return entity.ArchiveRecord{}, repository.ErrArchiveInvalid
+	}
+
+	for v := r.header.SchemaVersion; v < entity.ArchiveSchemaVersion; v++ {
+		upgraded, err := archiveUpgrades[v](line)
+		if err != nil {
+			log.WithError(err).Warnf("unable to upgrade archive element from schema version %d", v)
+			return entity.ArchiveRecord{}, repository.ErrArchiveInvalid
+		}
+		line = upgraded
+	}
+
+	record, err := line.decode()
+	if err != nil {
+		log.WithError(err).Warnf("unable to decode archive element of type %s", line.Type)
+		return entity.ArchiveRecord{}, repository.ErrArchiveInvalid
+	}
+
+	return record, nil
+}
+
+type archiveHeader struct {
+	SchemaVersion *int      `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	AnalysisID    uuid.UUID `json:"analysis_id"`
+	Project       string    `json:"project"`
+}
+
+// archiveLine holds an element of any type, before being decoded.
+type archiveLine struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (l archiveLine) decode() (entity.ArchiveRecord, error) {
+	switch l.Type {
+	case archiveTypeProject:
+		var r projectRecord
+		if err := json.Unmarshal(l.Data, &r); err != nil {
+			return entity.ArchiveRecord{}, err
+		}
+		project := r.toEntity()
+		return entity.ArchiveRecord{Project: &project}, nil
+	case archiveTypeAnalysis:
+		var r analysisRecord
+		if err := json.Unmarshal(l.Data, &r); err != nil {
+			return entity.ArchiveRecord{}, err
+		}
+		analysis := r.toEntity()
+		return entity.ArchiveRecord{Analysis: &analysis}, nil
+	case archiveTypeIdentifier:
+		var r identifierArchiveRecord
+		if err := json.Unmarshal(l.Data, &r); err != nil {
+			return entity.ArchiveRecord{}, err
+		}
+		ident := r.toEntity()
+		return entity.ArchiveRecord{Identifier: &ident}, nil
+	case archiveTypeInsight:
+		var r insightRecord
+		if err := json.Unmarshal(l.Data, &r); err != nil {
+			return entity.ArchiveRecord{}, err
+		}
+		insight := r.toEntity()
+		return entity.ArchiveRecord{Insight: &insight}, nil
+	default:
+		return entity.ArchiveRecord{}, errors.New("unknown element type")
+	}
+}
+
+type projectRecord struct {
+	ID         uuid.UUID        `json:"id"`
+	Status     string           `json:"status"`
+	Reference  string           `json:"reference"`
+	CreatedAt  time.Time        `json:"created_at"`
+	Metadata   metadataRecord   `json:"metadata"`
+	SourceCode sourceCodeRecord `json:"source_code"`
+	Filter     *filterRecord    `json:"filter,omitempty"`
+}
+
+type metadataRecord struct {
+	RemoteID      string     `json:"remote_id"`
+	Owner         string     `json:"owner"`
+	Fullname      string     `json:"fullname"`
+	Description   string     `json:"description"`
+	CloneURL      string     `json:"clone_url"`
+	DefaultBranch string     `json:"default_branch"`
+	License       string     `json:"license"`
+	CreatedAt     *time.Time `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	IsFork        bool       `json:"is_fork"`
+	Size          int32      `json:"size"`
+	Stargazers    int32      `json:"stargazers"`
+	Watchers      int32      `json:"watchers"`
+	Forks         int32      `json:"forks"`
+}
+
+type sourceCodeRecord struct {
+	Hash     string   `json:"hash"`
+	Location string   `json:"location"`
+	Files    []string `json:"files"`
+}
+
+type filterRecord struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+func newProjectRecord(p entity.Project) projectRecord {
+	record := projectRecord{
+		ID:        p.ID,
+		Status:    p.Status,
+		Reference: p.Reference,
+		CreatedAt: p.CreatedAt,
+		Metadata: metadataRecord{
+			RemoteID:      p.Metadata.RemoteID,
+			Owner:         p.Metadata.Owner,
+			Fullname:      p.Metadata.Fullname,
+			Description:   p.Metadata.Description,
+			CloneURL:      p.Metadata.CloneURL,
+			DefaultBranch: p.Metadata.DefaultBranch,
+			License:       p.Metadata.License,
+			CreatedAt:     p.Metadata.CreatedAt,
+			UpdatedAt:     p.Metadata.UpdatedAt,
+			IsFork:        p.Metadata.IsFork,
+			Size:          p.Metadata.Size,
+			Stargazers:    p.Metadata.Stargazers,
+			Watchers:      p.Metadata.Watchers,
+			Forks:         p.Metadata.Forks,
+		},
+		SourceCode: sourceCodeRecord{
+			Hash:     p.SourceCode.Hash,
+			Location: p.SourceCode.Location,
+			Files:    p.SourceCode.Files,
+		},
+	}
+	if !p.Filter.IsEmpty() {
+		record.Filter = &filterRecord{Include: p.Filter.Include, Exclude: p.Filter.Exclude}
+	}
+
+	return record
+}
+
+func (r projectRecord) toEntity() entity.Project {
+	project := entity.Project{
+		ID:        r.ID,
+		Status:    r.Status,
+		Reference: r.Reference,
+		CreatedAt: r.CreatedAt,
+		Metadata: entity.Metadata{
+			RemoteID:      r.Metadata.RemoteID,
+			Owner:         r.Metadata.Owner,
+			Fullname:      r.Metadata.Fullname,
+			Description:   r.Metadata.Description,
+			CloneURL:      r.Metadata.CloneURL,
+			DefaultBranch: r.Metadata.DefaultBranch,
+			License:       r.Metadata.License,
+			CreatedAt:     r.Metadata.CreatedAt,
+			UpdatedAt:     r.Metadata.UpdatedAt,
+			IsFork:        r.Metadata.IsFork,
+			Size:          r.Metadata.Size,
+			Stargazers:    r.Metadata.Stargazers,
+			Watchers:      r.Metadata.Watchers,
+			Forks:         r.Metadata.Forks,
+		},
+		SourceCode: entity.SourceCode{
+			Hash:     r.SourceCode.Hash,
+			Location: r.SourceCode.Location,
+			Files:    r.SourceCode.Files,
+		},
+	}
+	if r.Filter != nil {
+		project.Filter = entity.FileFilter{Include: r.Filter.Include, Exclude: r.Filter.Exclude}
+	}
+
+	return project
+}
+
+type analysisRecord struct {
+	ID                      uuid.UUID `json:"id"`
+	CreatedAt               time.Time `json:"created_at"`
+	ProjectID               uuid.UUID `json:"project_id"`
+	ProjectName             string    `json:"project_name"`
+	Miners                  []string  `json:"miners"`
+	Splitters               []string  `json:"splitters"`
+	Expanders               []string  `json:"expanders"`
+	Rules                   []string  `json:"rules"`
+	FilesTotal              int       `json:"files_total"`
+	FilesValid              int       `json:"files_valid"`
+	FilesError              int       `json:"files_error"`
+	FilesErrorSamples       []string  `json:"files_error_samples"`
+	FilesSkipped            int       `json:"files_skipped,omitempty"`
+	IncludeTests            bool      `json:"include_tests,omitempty"`
+	IdentifiersTotal        int       `json:"identifiers_total"`
+	IdentifiersValid        int       `json:"identifiers_valid"`
+	IdentifiersError        int       `json:"identifiers_error"`
+	IdentifiersErrorSamples []string  `json:"identifiers_error_samples"`
+}
+
+func newAnalysisRecord(a entity.AnalysisResults) analysisRecord {
+	return analysisRecord{
+		ID:                      a.ID,
+		CreatedAt:               a.DateCreated,
+		ProjectID:               a.ProjectID,
+		ProjectName:             a.ProjectName,
+		Miners:                  a.PipelineMiners,
+		Splitters:               a.PipelineSplitters,
+		Expanders:               a.PipelineExpanders,
+		Rules:                   a.PipelineRules,
+		FilesTotal:              a.FilesTotal,
+		FilesValid:              a.FilesValid,
+		FilesError:              a.FilesError,
+		FilesErrorSamples:       a.FilesErrorSamples,
+		FilesSkipped:            a.FilesSkipped,
+		IncludeTests:            a.IncludeTests,
+		IdentifiersTotal:        a.IdentifiersTotal,
+		IdentifiersValid:        a.IdentifiersValid,
+		IdentifiersError:        a.IdentifiersError,
+		IdentifiersErrorSamples: a.IdentifiersErrorSamples,
+	}
+}
+
+func (r analysisRecord) toEntity() entity.AnalysisResults {
+	return entity.AnalysisResults{
+		ID:                      r.ID,
+		DateCreated:             r.CreatedAt,
+		ProjectID:               r.ProjectID,
+		ProjectName:             r.ProjectName,
+		PipelineMiners:          r.Miners,
+		PipelineSplitters:       r.Splitters,
+		PipelineExpanders:       r.Expanders,
+		PipelineRules:           r.Rules,
+		FilesTotal:              r.FilesTotal,
+		FilesValid:              r.FilesValid,
+		FilesError:              r.FilesError,
+		FilesErrorSamples:       r.FilesErrorSamples,
+		FilesSkipped:            r.FilesSkipped,
+		IncludeTests:            r.IncludeTests,
+		IdentifiersTotal:        r.IdentifiersTotal,
+		IdentifiersValid:        r.IdentifiersValid,
+		IdentifiersError:        r.IdentifiersError,
+		IdentifiersErrorSamples: r.IdentifiersErrorSamples,
+	}
+}
+
+// identifierArchiveRecord holds every attribute of an identifier, so it can be restored as it was stored.
+type identifierArchiveRecord struct {
+	ID            string                              `json:"id"`
+	Project       string                              `json:"project"`
+	AnalysisID    uuid.UUID                           `json:"analysis_id"`
+	Package       string                              `json:"package"`
+	File          string                              `json:"file"`
+	Position      int                                 `json:"position"`
+	Line          int                                 `json:"line"`
+	Column        int                                 `json:"column"`
+	Name          string                              `json:"name"`
+	Type          string                              `json:"type"`
+	Receiver      string                              `json:"receiver"`
+	ReceiverName  string                              `json:"receiver_name"`
+	Splits        map[string][]splitArchiveRecord     `json:"splits"`
+	Expansions    map[string][]expansionArchiveRecord `json:"expansions"`
+	Error         string                              `json:"error"`
+	Normalization normalizationArchiveRecord          `json:"normalization"`
+	Findings      []findingArchiveRecord              `json:"findings"`
+	Language      string                              `json:"language"`
+}
+
+type splitArchiveRecord struct {
+	Order int    `json:"order"`
+	Value string `json:"value"`
+}
+
+type expansionArchiveRecord struct {
+	Order              int      `json:"order"`
+	From               string   `json:"from"`
+	Values             []string `json:"values"`
+	SplittingAlgorithm string   `json:"splitting_algorithm"`
+}
+
+type normalizationArchiveRecord struct {
+	Word      string  `json:"word"`
+	Algorithm string  `json:"algorithm"`
+	Score     float64 `json:"score"`
+}
+
+type findingArchiveRecord struct {
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion"`
+}
+
+func newIdentifierArchiveRecord(ident entity.Identifier) identifierArchiveRecord {
+	r := identifierArchiveRecord{
+		ID:           ident.ID,
+		Project:      ident.ProjectRef,
+		AnalysisID:   ident.AnalysisID,
+		Package:      ident.Package,
+		File:         ident.File,
+		Position:     int(ident.Position),
+		Line:         ident.Line,
+		Column:       ident.Column,
+		Name:         ident.Name,
+		Type:         ident.Type.String(),
+		Receiver:     ident.Receiver,
+		ReceiverName: ident.ReceiverName,
+		Splits:       make(map[string][]splitArchiveRecord, len(ident.Splits)),
+		Expansions:   make(map[string][]expansionArchiveRecord, len(ident.Expansions)),
+		Normalization: normalizationArchiveRecord{
+			Word:      ident.Normalization.Word,
+			Algorithm: ident.Normalization.Algorithm,
+			Score:     ident.Normalization.Score,
+		},
+		Findings: make([]findingArchiveRecord, len(ident.Findings)),
+		Language: string(ident.Language),
+	}
+	if ident.Error != nil {
+		r.Error = ident.Error.Error()
+	}
+
+	for algorithm, splits := range ident.Splits {
+		records := make([]splitArchiveRecord, len(splits))
+		for i, split := range splits {
+			records[i] = splitArchiveRecord{Order: split.Order, Value: split.Value}
+		}
+		r.Splits[algorithm] = records
+	}
+
+	for algorithm, expansions := range ident.Expansions {
+		records := make([]expansionArchiveRecord, len(expansions))
+		for i, expansion := range expansions {
+			records[i] = expansionArchiveRecord{
+				Order:              expansion.Order,
+				From:               expansion.From,
+				Values:             expansion.Values,
+				SplittingAlgorithm: expansion.SplittingAlgorithm,
+			}
+		}
+		r.Expansions[algorithm] = records
+	}
+
+	for i, finding := range ident.Findings {
+		r.Findings[i] = findingArchiveRecord{
+			Rule:       finding.Rule,
+			Severity:   string(finding.Severity),
+			Message:    finding.Message,
+			Suggestion: finding.Suggestion,
+		}
+	}
+
+	return r
+}
+
+func (r identifierArchiveRecord) toEntity() entity.Identifier {
+	ident := entity.Identifier{
+		ID:           r.ID,
+		ProjectRef:   r.Project,
+		AnalysisID:   r.AnalysisID,
+		Package:      r.Package,
+		File:         r.File,
+		Position:     token.Pos(r.Position),
+		Line:         r.Line,
+		Column:       r.Column,
+		Name:         r.Name,
+		Receiver:     r.Receiver,
+		ReceiverName: r.ReceiverName,
+		Splits:       make(map[string][]entity.Split, len(r.Splits)),
+		Expansions:   make(map[string][]entity.Expansion, len(r.Expansions)),
+		Normalization: entity.Normalization{
+			Word:      r.Normalization.Word,
+			Algorithm: r.Normalization.Algorithm,
+			Score:     r.Normalization.Score,
+		},
+		Language: entity.Language(r.Language),
+	}
+	if tok := token.Lookup(r.Type); tok.IsKeyword() {
+		ident.Type = tok
+	}
+	if r.Error != "" {
+		ident.Error = errors.New(r.Error)
+	}
+
+	for algorithm, records := range r.Splits {
+		splits := make([]entity.Split, len(records))
+		for i, split := range records {
+			splits[i] = entity.Split{Order: split.Order, Value: split.Value}
+		}
+		ident.Splits[algorithm] = splits
+	}
+
+	for algorithm, records := range r.Expansions {
+		expansions := make([]entity.Expansion, len(records))
+		for i, expansion := range records {
+			expansions[i] = entity.Expansion{
+				Order:              expansion.Order,
+				From:               expansion.From,
+				Values:             expansion.Values,
+				SplittingAlgorithm: expansion.SplittingAlgorithm,
+			}
+		}
+		ident.Expansions[algorithm] = expansions
+	}
+
+	if len(r.Findings) > 0 {
+		ident.Findings = make([]entity.Finding, len(r.Findings))
+		for i, finding := range r.Findings {
+			ident.Findings[i] = entity.Finding{
+				Rule:       finding.Rule,
+				Severity:   entity.Severity(finding.Severity),
+				Message:    finding.Message,
+				Suggestion: finding.Suggestion,
+			}
+		}
+	}
+
+	return ident
+}
+
+type insightRecord struct {
+	ID               string         `json:"id"`
+	Project          string         `json:"project"`
+	AnalysisID       uuid.UUID      `json:"analysis_id"`
+	Package          string         `json:"package"`
+	Language         string         `json:"language"`
+	TotalIdentifiers int            `json:"total_identifiers"`
+	TotalExported    int            `json:"total_exported"`
+	TotalSplits      map[string]int `json:"total_splits"`
+	TotalExpansions  map[string]int `json:"total_expansions"`
+	TotalWeight      float64        `json:"total_weight"`
+	Files            []string       `json:"files"`
+	Test             bool           `json:"test,omitempty"`
+}
+
+func newInsightRecord(i entity.Insight) insightRecord {
+	files := make([]string, 0, len(i.Files))
+	for file := range i.Files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	return insightRecord{
+		ID:               i.ID,
+		Project:          i.ProjectRef,
+		AnalysisID:       i.AnalysisID,
+		Package:          i.Package,
+		Language:         string(i.Language),
+		TotalIdentifiers: i.TotalIdentifiers,
+		TotalExported:    i.TotalExported,
+		TotalSplits:      i.TotalSplits,
+		TotalExpansions:  i.TotalExpansions,
+		TotalWeight:      i.TotalWeight,
+		Files:            files,
+		Test:             i.Test,
+	}
+}
+
+func (r insightRecord) toEntity() entity.Insight {
+	insight := entity.Insight{
+		ID:               r.ID,
+		ProjectRef:       r.Project,
+		AnalysisID:       r.AnalysisID,
+		Package:          r.Package,
+		Language:         entity.Language(r.Language),
+		TotalIdentifiers: r.TotalIdentifiers,
+		TotalExported:    r.TotalExported,
+		TotalSplits:      r.TotalSplits,
+		TotalExpansions:  r.TotalExpansions,
+		TotalWeight:      r.TotalWeight,
+		Files:            make(map[string]struct{}, len(r.Files)),
+		Test:             r.Test,
+	}
+	if insight.TotalSplits == nil {
+		insight.TotalSplits = make(map[string]int)
+	}
+	if insight.TotalExpansions == nil {
+		insight.TotalExpansions = make(map[string]int)
+	}
+	for _, file := range r.Files {
+		insight.Files[file] = struct{}{}
+	}
+
+	return insight
+}
@@ -0,0 +1,182 @@
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"go/token"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/export"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func archivedRecords() []entity.ArchiveRecord {
+	projectID := uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be")
+	analysisID := uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134")
+	created := time.Date(2020, time.May, 10, 12, 0, 0, 0, time.UTC)
+
+	project := entity.Project{
+		ID:        projectID,
+		Status:    "done",
+		Reference: "eroatta/test",
+		CreatedAt: created,
+		Metadata: entity.Metadata{
+			RemoteID:   "1",
+			Owner:      "eroatta",
+			Fullname:   "eroatta/test",
+			CloneURL:   "https://github.com/eroatta/test.git",
+			License:    "mit",
+			CreatedAt:  &created,
+			Stargazers: 10,
+		},
+		SourceCode: entity.SourceCode{Hash: "abc", Location: "/tmp/test", Files: []string{"main.go"}},
+		Filter:     entity.FileFilter{Exclude: []string{"tools/**"}},
+	}
+	analysis := entity.AnalysisResults{
+		ID:                      analysisID,
+		DateCreated:             created,
+		ProjectID:               projectID,
+		ProjectName:             "eroatta/test",
+		PipelineMiners:          []string{"wordcount"},
+		PipelineSplitters:       []string{"conserv"},
+		PipelineExpanders:       []string{"basic"},
+		FilesTotal:              1,
+		FilesValid:              1,
+		FilesSkipped:            1,
+		IncludeTests:            true,
+		IdentifiersTotal:        2,
+		IdentifiersValid:        1,
+		IdentifiersError:        1,
+		IdentifiersErrorSamples: []string{"x"},
+	}
+	ident := exportedIdentifiers[0]
+	ident.Splits = map[string][]entity.Split{"conserv": {{Order: 1, Value: "Parse"}, {Order: 2, Value: "Cfg"}}}
+	ident.Findings = []entity.Finding{{Rule: "abbreviation", Severity: entity.SeverityWarning, Message: "Cfg"}}
+	failed := exportedIdentifiers[1]
+	failed.Splits = map[string][]entity.Split{}
+	failed.Expansions = map[string][]entity.Expansion{}
+	insight := entity.Insight{
+		ID:               "eroatta/test+++main",
+		ProjectRef:       "eroatta/test",
+		AnalysisID:       analysisID,
+		Package:          "main",
+		TotalIdentifiers: 2,
+		TotalExported:    1,
+		TotalSplits:      map[string]int{"conserv": 2},
+		TotalExpansions:  map[string]int{"basic": 1},
+		TotalWeight:      0.75,
+		Files:            map[string]struct{}{"main.go": {}},
+	}
+
+	return []entity.ArchiveRecord{
+		{Project: &project}, {Analysis: &analysis}, {Identifier: &ident}, {Identifier: &failed}, {Insight: &insight},
+	}
+}
+
+func TestArchive_OnJSONLArchiveFormat_ShouldRestoreEveryElement(t *testing.T) {
+	header := entity.ArchiveHeader{
+		SchemaVersion: entity.ArchiveSchemaVersion,
+		CreatedAt:     time.Date(2020, time.May, 11, 0, 0, 0, 0, time.UTC),
+		AnalysisID:    uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+		ProjectRef:    "eroatta/test",
+	}
+	format := export.NewJSONLArchiveFormat()
+
+	var b bytes.Buffer
+	w, err := format.NewWriter(&b, header)
+	require.NoError(t, err)
+	for _, record := range archivedRecords() {
+		require.NoError(t, w.Write(record))
+	}
+	require.NoError(t, w.Close())
+	assert.Equal(t, 6, strings.Count(b.String(), "\n"))
+
+	r, err := format.NewReader(&b)
+	require.NoError(t, err)
+	assert.Equal(t, header, r.Header())
+
+	read := make([]entity.ArchiveRecord, 0)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		read = append(read, record)
+	}
+	assert.Equal(t, archivedRecords(), read)
+}
+
+func TestWrite_OnJSONLArchiveWriter_WhenEmptyRecord_ShouldReturnError(t *testing.T) {
+	w, err := export.NewJSONLArchiveFormat().NewWriter(&bytes.Buffer{}, entity.ArchiveHeader{SchemaVersion: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, repository.ErrArchiveInvalid, w.Write(entity.ArchiveRecord{}))
+}
+
+func TestNewReader_OnJSONLArchiveFormat_WhenInvalidHeader_ShouldReturnError(t *testing.T) {
+	cases := []struct {
+		name     string
+		archive  string
+		expected error
+	}{
+		{"empty_archive", "", repository.ErrArchiveInvalid},
+		{"not_json", "schema_version=1\n", repository.ErrArchiveInvalid},
+		{"missing_version", `{"project":"eroatta/test"}` + "\n", repository.ErrArchiveInvalid},
+		{"newer_version", `{"schema_version":99}` + "\n", repository.ErrArchiveUnsupportedVersion},
+		{"no_upgrade_path", `{"schema_version":0}` + "\n", repository.ErrArchiveUnsupportedVersion},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := export.NewJSONLArchiveFormat().NewReader(strings.NewReader(c.archive))
+
+			assert.Nil(t, r)
+			assert.Equal(t, c.expected, err)
+		})
+	}
+}
+
+func TestRead_OnJSONLArchiveReader_WhenInvalidElement_ShouldReturnError(t *testing.T) {
+	cases := []struct {
+		name    string
+		element string
+	}{
+		{"not_json", "identifier"},
+		{"unknown_type", `{"type":"commit","data":{}}`},
+		{"invalid_data", `{"type":"identifier","data":{"position":"first"}}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			archive := `{"schema_version":1}` + "\n" + c.element + "\n"
+			r, err := export.NewJSONLArchiveFormat().NewReader(strings.NewReader(archive))
+			require.NoError(t, err)
+
+			record, err := r.Read()
+
+			assert.Equal(t, entity.ArchiveRecord{}, record)
+			assert.Equal(t, repository.ErrArchiveInvalid, err)
+		})
+	}
+}
+
+func TestRead_OnJSONLArchiveReader_ShouldDecodeIdentifierError(t *testing.T) {
+	archive := `{"schema_version":1}` + "\n" +
+		`{"type":"identifier","data":{"name":"x","type":"var","error":"unable to split"}}` + "\n"
+	r, err := export.NewJSONLArchiveFormat().NewReader(strings.NewReader(archive))
+	require.NoError(t, err)
+
+	record, err := r.Read()
+
+	require.NoError(t, err)
+	assert.Equal(t, token.VAR, record.Identifier.Type)
+	assert.Equal(t, errors.New("unable to split"), record.Identifier.Error)
+}
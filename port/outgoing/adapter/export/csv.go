@@ -0,0 +1,110 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// csvHeader holds the columns written for each identifier. Splits and expansions are written as JSON objects,
+// keyed by algorithm.
+var csvHeader = []string{
+	"analysis_id",
+	"project",
+	"id",
+	"package",
+	"file",
+	"line",
+	"column",
+	"name",
+	"type",
+	"exported",
+	"test",
+	"splits",
+	"expansions",
+	"normalization_word",
+	"normalization_algorithm",
+	"normalization_score",
+	"error",
+}
+
+// CSVIdentifierWriter represents a writer capable of storing identifiers as comma-separated values.
+type CSVIdentifierWriter struct {
+	output        *csv.Writer
+	headerWritten bool
+}
+
+// NewCSVIdentifierWriter creates a new CSVIdentifierWriter to write identifiers as comma-separated values
+// on the given destination.
+func NewCSVIdentifierWriter(w io.Writer) (repository.IdentifierWriter, error) {
+	return &CSVIdentifierWriter{
+		output: csv.NewWriter(w),
+	}, nil
+}
+
+// Write creates a new row for an identifier. The header is written before the first row.
+func (w *CSVIdentifierWriter) Write(ident entity.Identifier) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	r := newRecord(ident)
+	row := []string{
+		r.AnalysisID,
+		r.Project,
+		r.ID,
+		r.Package,
+		r.File,
+		strconv.FormatInt(r.Line, 10),
+		strconv.FormatInt(r.Column, 10),
+		r.Name,
+		r.Type,
+		strconv.FormatBool(r.Exported),
+		strconv.FormatBool(r.Test),
+		r.splitsJSON(),
+		r.expansionsJSON(),
+		r.NormalizationWord,
+		r.NormalizationAlgorithm,
+		strconv.FormatFloat(r.NormalizationScore, 'f', -1, 64),
+		r.Error,
+	}
+	if err := w.output.Write(row); err != nil {
+		log.WithError(err).Errorf("unable to write identifier with ID %s", ident.ID)
+		return repository.ErrExportUnexpected
+	}
+
+	return nil
+}
+
+// Close flushes the written rows. If no identifier was written, only the header is written.
+func (w *CSVIdentifierWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.output.Flush()
+	if err := w.output.Error(); err != nil {
+		log.WithError(err).Error("unable to flush identifiers")
+		return repository.ErrExportUnexpected
+	}
+
+	return nil
+}
+
+func (w *CSVIdentifierWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+
+	w.headerWritten = true
+	if err := w.output.Write(csvHeader); err != nil {
+		log.WithError(err).Error("unable to write header")
+		return repository.ErrExportUnexpected
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// NewIdentifierWriterFactory creates a new repository.IdentifierWriterFactory, including the available formats.
+// It supports:
+//   - "csv"
+//   - "jsonl"
+//   - "parquet"
+func NewIdentifierWriterFactory() repository.IdentifierWriterFactory {
+	return identifierWriterFactory{
+		constructors: map[string]func(w io.Writer) (repository.IdentifierWriter, error){
+			entity.ExportFormatCSV:     NewCSVIdentifierWriter,
+			entity.ExportFormatJSONL:   NewJSONLIdentifierWriter,
+			entity.ExportFormatParquet: NewParquetIdentifierWriter,
+		},
+	}
+}
+
+type identifierWriterFactory struct {
+	constructors map[string]func(w io.Writer) (repository.IdentifierWriter, error)
+}
+
+// Make creates a repository.IdentifierWriter for the given format, writing on the given destination.
+func (f identifierWriterFactory) Make(format string, w io.Writer) (repository.IdentifierWriter, error) {
+	constructor, ok := f.constructors[format]
+	if !ok {
+		log.WithField("format", format).Error("no writer declared for the given format")
+		return nil, repository.ErrExportUnsupportedFormat
+	}
+
+	return constructor(w)
+}
+
+// record is the exported representation of an identifier, shared by every format.
+type record struct {
+	AnalysisID             string                       `json:"analysis_id"`
+	Project                string                       `json:"project"`
+	ID                     string                       `json:"id"`
+	Package                string                       `json:"package"`
+	File                   string                       `json:"file"`
+	Line                   int64                        `json:"line"`
+	Column                 int64                        `json:"column"`
+	Name                   string                       `json:"name"`
+	Type                   string                       `json:"type"`
+	Exported               bool                         `json:"exported"`
+	Test                   bool                         `json:"test"`
+	Splits                 map[string][]string          `json:"splits"`
+	Expansions             map[string][]expansionRecord `json:"expansions"`
+	NormalizationWord      string                       `json:"normalization_word"`
+	NormalizationAlgorithm string                       `json:"normalization_algorithm"`
+	NormalizationScore     float64                      `json:"normalization_score"`
+	Error                  string                       `json:"error"`
+}
+
+type expansionRecord struct {
+	From   string   `json:"from"`
+	Values []string `json:"values"`
+}
+
+func newRecord(ident entity.Identifier) record {
+	r := record{
+		AnalysisID:             ident.AnalysisID.String(),
+		Project:                ident.ProjectRef,
+		ID:                     ident.ID,
+		Package:                ident.Package,
+		File:                   ident.File,
+		Line:                   int64(ident.Line),
+		Column:                 int64(ident.Column),
+		Name:                   ident.Name,
+		Type:                   ident.Type.String(),
+		Exported:               ident.Exported(),
+		Test:                   ident.IsTest(),
+		Splits:                 make(map[string][]string, len(ident.Splits)),
+		Expansions:             make(map[string][]expansionRecord, len(ident.Expansions)),
+		NormalizationWord:      ident.Normalization.Word,
+		NormalizationAlgorithm: ident.Normalization.Algorithm,
+		NormalizationScore:     ident.Normalization.Score,
+	}
+	if ident.Error != nil {
+		r.Error = ident.Error.Error()
+	}
+
+	for algorithm, splits := range ident.Splits {
+		sorted := append([]entity.Split{}, splits...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+
+		values := make([]string, len(sorted))
+		for i, split := range sorted {
+			values[i] = split.Value
+		}
+		r.Splits[algorithm] = values
+	}
+
+	for algorithm, expansions := range ident.Expansions {
+		sorted := append([]entity.Expansion{}, expansions...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Order < sorted[j].Order })
+
+		values := make([]expansionRecord, len(sorted))
+		for i, expansion := range sorted {
+			values[i] = expansionRecord{From: expansion.From, Values: expansion.Values}
+			if values[i].Values == nil {
+				values[i].Values = make([]string, 0)
+			}
+		}
+		r.Expansions[algorithm] = values
+	}
+
+	return r
+}
+
+// splitsJSON returns the splits encoded as a JSON object, for the formats that only support flat values.
+func (r record) splitsJSON() string {
+	b, _ := json.Marshal(r.Splits)
+	return string(b)
+}
+
+// expansionsJSON returns the expansions encoded as a JSON object, for the formats that only support flat values.
+func (r record) expansionsJSON() string {
+	b, _ := json.Marshal(r.Expansions)
+	return string(b)
+}
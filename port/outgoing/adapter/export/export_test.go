@@ -0,0 +1,178 @@
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/export"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+var exportedIdentifiers = []entity.Identifier{
+	{
+		ID:         "main.go+++parseCfg",
+		AnalysisID: uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+		ProjectRef: "eroatta/test",
+		Package:    "main",
+		File:       "main.go",
+		Position:   token.Pos(42),
+		Line:       5,
+		Column:     6,
+		Name:       "ParseCfg",
+		Type:       token.FUNC,
+		Splits: map[string][]entity.Split{
+			"conserv": {{Order: 2, Value: "Cfg"}, {Order: 1, Value: "Parse"}},
+		},
+		Expansions: map[string][]entity.Expansion{
+			"basic": {{Order: 1, From: "Parse", Values: []string{"parse"}}, {Order: 2, From: "Cfg"}},
+		},
+		Normalization: entity.Normalization{Word: "parseConfig", Algorithm: "conserv+basic", Score: 0.75},
+	},
+	{
+		ID:         "main.go+++x",
+		AnalysisID: uuid.MustParse("ed2cd46a-4afd-4d49-a6ea-1c8d12d40134"),
+		ProjectRef: "eroatta/test",
+		Package:    "main",
+		File:       "main.go",
+		Position:   token.Pos(7),
+		Line:       1,
+		Column:     5,
+		Name:       "x",
+		Type:       token.VAR,
+		Error:      errors.New("unable to split"),
+	},
+}
+
+func write(t *testing.T, format string, idents []entity.Identifier) []byte {
+	var b bytes.Buffer
+	w, err := export.NewIdentifierWriterFactory().Make(format, &b)
+	require.NoError(t, err)
+
+	for _, ident := range idents {
+		require.NoError(t, w.Write(ident))
+	}
+	require.NoError(t, w.Close())
+
+	return b.Bytes()
+}
+
+func TestMake_OnIdentifierWriterFactory_WhenUnsupportedFormat_ShouldReturnError(t *testing.T) {
+	w, err := export.NewIdentifierWriterFactory().Make("xlsx", &bytes.Buffer{})
+
+	assert.Nil(t, w)
+	assert.Equal(t, repository.ErrExportUnsupportedFormat, err)
+}
+
+func TestWrite_OnCSVIdentifierWriter_ShouldWriteHeaderAndRows(t *testing.T) {
+	output := write(t, entity.ExportFormatCSV, exportedIdentifiers)
+
+	expected := strings.Join([]string{
+		"analysis_id,project,id,package,file,line,column,name,type,exported,test,splits,expansions," +
+			"normalization_word,normalization_algorithm,normalization_score,error",
+		`ed2cd46a-4afd-4d49-a6ea-1c8d12d40134,eroatta/test,main.go+++parseCfg,main,main.go,5,6,ParseCfg,func,true,false,` +
+			`"{""conserv"":[""Parse"",""Cfg""]}",` +
+			`"{""basic"":[{""from"":""Parse"",""values"":[""parse""]},{""from"":""Cfg"",""values"":[]}]}",` +
+			`parseConfig,conserv+basic,0.75,`,
+		`ed2cd46a-4afd-4d49-a6ea-1c8d12d40134,eroatta/test,main.go+++x,main,main.go,1,5,x,var,false,false,{},{},,,0,` +
+			`unable to split`,
+		"",
+	}, "\n")
+	assert.Equal(t, expected, string(output))
+}
+
+func TestClose_OnCSVIdentifierWriter_WhenNoIdentifiers_ShouldWriteHeader(t *testing.T) {
+	output := write(t, entity.ExportFormatCSV, []entity.Identifier{})
+
+	assert.True(t, strings.HasPrefix(string(output), "analysis_id,project,id,"))
+	assert.Equal(t, 1, strings.Count(string(output), "\n"))
+}
+
+func TestWrite_OnJSONLIdentifierWriter_ShouldWriteOneLinePerIdentifier(t *testing.T) {
+	output := write(t, entity.ExportFormatJSONL, exportedIdentifiers)
+
+	lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+	require.Equal(t, 2, len(lines))
+	assert.JSONEq(t, `
+		{
+			"analysis_id": "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+			"project": "eroatta/test",
+			"id": "main.go+++parseCfg",
+			"package": "main",
+			"file": "main.go",
+			"line": 5,
+			"column": 6,
+			"name": "ParseCfg",
+			"type": "func",
+			"exported": true,
+			"test": false,
+			"splits": {"conserv": ["Parse", "Cfg"]},
+			"expansions": {"basic": [{"from": "Parse", "values": ["parse"]}, {"from": "Cfg", "values": []}]},
+			"normalization_word": "parseConfig",
+			"normalization_algorithm": "conserv+basic",
+			"normalization_score": 0.75,
+			"error": ""
+		}`, lines[0])
+	assert.Contains(t, lines[1], `"error":"unable to split"`)
+}
+
+type parquetRow struct {
+	AnalysisID             string  `parquet:"name=analysis_id, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Project                string  `parquet:"name=project, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ID                     string  `parquet:"name=id, type=UTF8"`
+	Package                string  `parquet:"name=package, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	File                   string  `parquet:"name=file, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Line                   int64   `parquet:"name=line, type=INT64"`
+	Column                 int64   `parquet:"name=column, type=INT64"`
+	Name                   string  `parquet:"name=name, type=UTF8"`
+	Type                   string  `parquet:"name=type, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Exported               bool    `parquet:"name=exported, type=BOOLEAN"`
+	Test                   bool    `parquet:"name=test, type=BOOLEAN"`
+	Splits                 string  `parquet:"name=splits, type=UTF8"`
+	Expansions             string  `parquet:"name=expansions, type=UTF8"`
+	NormalizationWord      string  `parquet:"name=normalization_word, type=UTF8"`
+	NormalizationAlgorithm string  `parquet:"name=normalization_algorithm, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	NormalizationScore     float64 `parquet:"name=normalization_score, type=DOUBLE"`
+	Error                  string  `parquet:"name=error, type=UTF8"`
+}
+
+func TestWrite_OnParquetIdentifierWriter_ShouldWriteReadableFile(t *testing.T) {
+	output := write(t, entity.ExportFormatParquet, exportedIdentifiers)
+
+	file, err := buffer.NewBufferFile(output)
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(file, new(parquetRow), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	require.Equal(t, int64(2), pr.GetNumRows())
+	rows := make([]parquetRow, 2)
+	require.NoError(t, pr.Read(&rows))
+
+	assert.Equal(t, parquetRow{
+		AnalysisID:             "ed2cd46a-4afd-4d49-a6ea-1c8d12d40134",
+		Project:                "eroatta/test",
+		ID:                     "main.go+++parseCfg",
+		Package:                "main",
+		File:                   "main.go",
+		Line:                   5,
+		Column:                 6,
+		Name:                   "ParseCfg",
+		Type:                   "func",
+		Exported:               true,
+		Splits:                 `{"conserv":["Parse","Cfg"]}`,
+		Expansions:             `{"basic":[{"from":"Parse","values":["parse"]},{"from":"Cfg","values":[]}]}`,
+		NormalizationWord:      "parseConfig",
+		NormalizationAlgorithm: "conserv+basic",
+		NormalizationScore:     0.75,
+	}, rows[0])
+	assert.Equal(t, "unable to split", rows[1].Error)
+}
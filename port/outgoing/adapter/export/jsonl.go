@@ -0,0 +1,47 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// JSONLIdentifierWriter represents a writer capable of storing identifiers as JSON objects, one per line.
+type JSONLIdentifierWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewJSONLIdentifierWriter creates a new JSONLIdentifierWriter to write identifiers as JSON lines on the
+// given destination.
+func NewJSONLIdentifierWriter(w io.Writer) (repository.IdentifierWriter, error) {
+	buffer := bufio.NewWriter(w)
+	return &JSONLIdentifierWriter{
+		buffer:  buffer,
+		encoder: json.NewEncoder(buffer),
+	}, nil
+}
+
+// Write writes a new line for an identifier.
+func (w *JSONLIdentifierWriter) Write(ident entity.Identifier) error {
+	if err := w.encoder.Encode(newRecord(ident)); err != nil {
+		log.WithError(err).Errorf("unable to write identifier with ID %s", ident.ID)
+		return repository.ErrExportUnexpected
+	}
+
+	return nil
+}
+
+// Close flushes the written lines.
+func (w *JSONLIdentifierWriter) Close() error {
+	if err := w.buffer.Flush(); err != nil {
+		log.WithError(err).Error("unable to flush identifiers")
+		return repository.ErrExportUnexpected
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"io"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetRecord is the Parquet schema for an identifier. Splits and expansions are written as JSON objects,
+// keyed by algorithm.
+type parquetRecord struct {
+	AnalysisID             string  `parquet:"name=analysis_id, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Project                string  `parquet:"name=project, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	ID                     string  `parquet:"name=id, type=UTF8"`
+	Package                string  `parquet:"name=package, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	File                   string  `parquet:"name=file, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Line                   int64   `parquet:"name=line, type=INT64"`
+	Column                 int64   `parquet:"name=column, type=INT64"`
+	Name                   string  `parquet:"name=name, type=UTF8"`
+	Type                   string  `parquet:"name=type, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	Exported               bool    `parquet:"name=exported, type=BOOLEAN"`
+	Test                   bool    `parquet:"name=test, type=BOOLEAN"`
+	Splits                 string  `parquet:"name=splits, type=UTF8"`
+	Expansions             string  `parquet:"name=expansions, type=UTF8"`
+	NormalizationWord      string  `parquet:"name=normalization_word, type=UTF8"`
+	NormalizationAlgorithm string  `parquet:"name=normalization_algorithm, type=UTF8, encoding=PLAIN_DICTIONARY"`
+	NormalizationScore     float64 `parquet:"name=normalization_score, type=DOUBLE"`
+	Error                  string  `parquet:"name=error, type=UTF8"`
+}
+
+// ParquetIdentifierWriter represents a writer capable of storing identifiers as an Apache Parquet file.
+type ParquetIdentifierWriter struct {
+	output *writer.ParquetWriter
+}
+
+// NewParquetIdentifierWriter creates a new ParquetIdentifierWriter to write identifiers as an Apache Parquet file
+// on the given destination.
+func NewParquetIdentifierWriter(w io.Writer) (repository.IdentifierWriter, error) {
+	output, err := writer.NewParquetWriterFromWriter(w, new(parquetRecord), 1)
+	if err != nil {
+		log.WithError(err).Error("unable to create Parquet writer")
+		return nil, repository.ErrExportUnexpected
+	}
+
+	return &ParquetIdentifierWriter{
+		output: output,
+	}, nil
+}
+
+// Write adds an identifier to the current row group. Row groups are written as they are completed.
+func (w *ParquetIdentifierWriter) Write(ident entity.Identifier) error {
+	r := newRecord(ident)
+	row := parquetRecord{
+		AnalysisID:             r.AnalysisID,
+		Project:                r.Project,
+		ID:                     r.ID,
+		Package:                r.Package,
+		File:                   r.File,
+		Line:                   r.Line,
+		Column:                 r.Column,
+		Name:                   r.Name,
+		Type:                   r.Type,
+		Exported:               r.Exported,
+		Test:                   r.Test,
+		Splits:                 r.splitsJSON(),
+		Expansions:             r.expansionsJSON(),
+		NormalizationWord:      r.NormalizationWord,
+		NormalizationAlgorithm: r.NormalizationAlgorithm,
+		NormalizationScore:     r.NormalizationScore,
+		Error:                  r.Error,
+	}
+	if err := w.output.Write(row); err != nil {
+		log.WithError(err).Errorf("unable to write identifier with ID %s", ident.ID)
+		return repository.ErrExportUnexpected
+	}
+
+	return nil
+}
+
+// Close writes the last row group and the file footer.
+func (w *ParquetIdentifierWriter) Close() error {
+	if err := w.output.WriteStop(); err != nil {
+		log.WithError(err).Error("unable to complete Parquet file")
+		return repository.ErrExportUnexpected
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// FileNotifier represents a repository.Notifier that appends each comment as a JSON line to a local file,
+// which is useful for testing the reviews without posting them.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier creates a new FileNotifier, writing to the given file.
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{
+		path: path,
+	}
+}
+
+type fileLineDTO struct {
+	ProjectRef  string `json:"project_ref"`
+	PullRequest int    `json:"pull_request"`
+	Commit      string `json:"commit"`
+	commentDTO
+}
+
+// Sent retrieves the fingerprints of the comments written to the file for the pull request.
+func (n *FileNotifier) Sent(ctx context.Context, review entity.Review) (map[string]bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	sent := make(map[string]bool)
+	f, err := os.Open(n.path)
+	switch {
+	case os.IsNotExist(err):
+		return sent, nil
+	case err != nil:
+		log.WithError(err).Errorf("error opening review file %s", n.path)
+		return nil, repository.ErrNotifierUnexpected
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line fileLineDTO
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			log.WithError(err).Errorf("error decoding review file %s", n.path)
+			return nil, repository.ErrNotifierUnexpected
+		}
+
+		if line.ProjectRef == review.ProjectRef && line.PullRequest == review.PullRequest {
+			sent[line.Fingerprint] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.WithError(err).Errorf("error reading review file %s", n.path)
+		return nil, repository.ErrNotifierUnexpected
+	}
+
+	return sent, nil
+}
+
+// Notify appends the comments to the file.
+func (n *FileNotifier) Notify(ctx context.Context, review entity.Review) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.WithError(err).Errorf("error opening review file %s", n.path)
+		return repository.ErrNotifierUnexpected
+	}
+
+	encoder := json.NewEncoder(f)
+	for _, comment := range review.Comments {
+		err = encoder.Encode(fileLineDTO{
+			ProjectRef:  review.ProjectRef,
+			PullRequest: review.PullRequest,
+			Commit:      review.Commit,
+			commentDTO:  newCommentDTO(comment),
+		})
+		if err != nil {
+			break
+		}
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.WithError(err).Errorf("error writing review file %s", n.path)
+		return repository.ErrNotifierUnexpected
+	}
+
+	return nil
+}
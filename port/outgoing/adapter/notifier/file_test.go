@@ -0,0 +1,75 @@
+package notifier_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/port/outgoing/adapter/notifier"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSent_OnFileNotifier_WithNoFile_ShouldReturnEmpty(t *testing.T) {
+	n := notifier.NewFileNotifier(filepath.Join(t.TempDir(), "review.jsonl"))
+
+	sent, err := n.Sent(context.TODO(), review())
+
+	assert.NoError(t, err)
+	assert.Empty(t, sent)
+}
+
+func TestSent_OnFileNotifier_WithInvalidFile_ShouldReturnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.jsonl")
+	require.NoError(t, ioutil.WriteFile(path, []byte("not json\n"), 0644))
+	n := notifier.NewFileNotifier(path)
+
+	sent, err := n.Sent(context.TODO(), review())
+
+	assert.EqualError(t, err, repository.ErrNotifierUnexpected.Error())
+	assert.Nil(t, sent)
+}
+
+func TestNotify_OnFileNotifier_ShouldAppendComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.jsonl")
+	n := notifier.NewFileNotifier(path)
+
+	require.NoError(t, n.Notify(context.TODO(), review()))
+	another := review()
+	another.PullRequest = 8
+	require.NoError(t, n.Notify(context.TODO(), another))
+
+	raw, _ := ioutil.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	assert.Equal(t, 2, len(lines))
+	fingerprint := review().Comments[0].Fingerprint()
+	assert.JSONEq(t, `
+		{
+			"project_ref": "eroatta/test",
+			"pull_request": 7,
+			"commit": "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+			"path": "main.go",
+			"line": 3,
+			"identifier": "cfg",
+			"suggestion": "config",
+			"score": 0.25,
+			"body": "`+"`cfg` may be hard to read (score 0.25). Consider renaming it to `config`."+`",
+			"suggested_line": "var config = 1",
+			"fingerprint": "`+fingerprint+`"
+		}`, lines[0])
+
+	sent, err := n.Sent(context.TODO(), review())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{fingerprint: true}, sent)
+}
+
+func TestNotify_OnFileNotifier_WithInvalidPath_ShouldReturnError(t *testing.T) {
+	n := notifier.NewFileNotifier(t.TempDir())
+
+	err := n.Notify(context.TODO(), review())
+
+	assert.EqualError(t, err, repository.ErrNotifierUnexpected.Error())
+}
@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// commentsPerPage is the number of existing review comments retrieved on each request.
+const commentsPerPage = 100
+
+// fingerprintMarker finds the fingerprint hidden on the comments posted by the GitHubReviewNotifier.
+var fingerprintMarker = regexp.MustCompile(`<!-- src-reader:([0-9a-f]+) -->`)
+
+// GitHubReviewNotifier represents a repository.Notifier that posts the comments as a pull request review,
+// through the GitHub REST API v3 or any compatible API. Each comment suggests the renamed line, so it can be
+// applied from the pull request.
+type GitHubReviewNotifier struct {
+	httpClient  *http.Client
+	baseURL     string
+	accessToken string
+}
+
+// NewGitHubReviewNotifier creates a new GitHubReviewNotifier, authenticated by the given access token.
+func NewGitHubReviewNotifier(httpClient *http.Client, baseURL string, accessToken string) *GitHubReviewNotifier {
+	return &GitHubReviewNotifier{
+		httpClient:  httpClient,
+		baseURL:     baseURL,
+		accessToken: accessToken,
+	}
+}
+
+type reviewRequest struct {
+	CommitID string                 `json:"commit_id"`
+	Event    string                 `json:"event"`
+	Body     string                 `json:"body"`
+	Comments []reviewCommentRequest `json:"comments"`
+}
+
+type reviewCommentRequest struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side"`
+	Body string `json:"body"`
+}
+
+type reviewCommentResponse struct {
+	Body string `json:"body"`
+}
+
+// Sent retrieves the fingerprints hidden on the existing comments for the pull request.
+func (n GitHubReviewNotifier) Sent(ctx context.Context, review entity.Review) (map[string]bool, error) {
+	sent := make(map[string]bool)
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/repos/%s/pulls/%d/comments?per_page=%d&page=%d",
+			n.baseURL, review.ProjectRef, review.PullRequest, commentsPerPage, page)
+		body, err := n.do(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var comments []reviewCommentResponse
+		if err := json.Unmarshal(body, &comments); err != nil {
+			log.WithError(err).Error("an error occurred while parsing review comments")
+			return nil, repository.ErrNotifierUnexpected
+		}
+
+		for _, comment := range comments {
+			if match := fingerprintMarker.FindStringSubmatch(comment.Body); match != nil {
+				sent[match[1]] = true
+			}
+		}
+
+		if len(comments) < commentsPerPage {
+			return sent, nil
+		}
+	}
+}
+
+// Notify posts a review on the pull request, including every comment.
+func (n GitHubReviewNotifier) Notify(ctx context.Context, review entity.Review) error {
+	request := reviewRequest{
+		CommitID: review.Commit,
+		Event:    "COMMENT",
+		Body:     fmt.Sprintf("src-reader found %d identifiers that could be easier to read.", len(review.Comments)),
+		Comments: make([]reviewCommentRequest, 0, len(review.Comments)),
+	}
+	for _, comment := range review.Comments {
+		request.Comments = append(request.Comments, reviewCommentRequest{
+			Path: comment.Path,
+			Line: comment.Line,
+			Side: "RIGHT",
+			Body: fmt.Sprintf("%s\n\n```suggestion\n%s\n```\n<!-- src-reader:%s -->",
+				comment.Body(), comment.SuggestedLine(), comment.Fingerprint()),
+		})
+	}
+
+	payload, _ := json.Marshal(request)
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d/reviews", n.baseURL, review.ProjectRef, review.PullRequest)
+	_, err := n.do(ctx, http.MethodPost, url, payload)
+	return err
+}
+
+// do sends an authenticated request to the API and returns the response body. The API reports exceeded rate
+// limits using either 403 or 429 status codes.
+func (n GitHubReviewNotifier) do(ctx context.Context, method string, url string, payload []byte) ([]byte, error) {
+	request, _ := http.NewRequest(method, url, bytes.NewReader(payload))
+	request = request.WithContext(ctx)
+	request.Header.Add("Authorization", fmt.Sprintf("token %s", n.accessToken))
+	request.Header.Add("Accept", "application/vnd.github.v3+json")
+	if payload != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		log.WithError(err).Errorf("an error occurred while trying to %s the resource: %s", method, url)
+		return nil, repository.ErrNotifierUnexpected
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		log.WithError(err).Error("an error occurred while reading response body")
+		return nil, repository.ErrNotifierUnexpected
+	}
+
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests,
+		response.StatusCode == http.StatusForbidden && response.Header.Get("X-RateLimit-Remaining") == "0":
+		log.WithField("status_code", response.StatusCode).Warnf("rate limit exceeded for the resource: %s", url)
+		return nil, repository.ErrNotifierRateLimited
+	case response.StatusCode >= http.StatusBadRequest:
+		log.WithField("status_code", response.StatusCode).WithField("response_message", string(body)).
+			Errorf("an error occurred while trying to %s the resource: %s", method, url)
+		return nil, repository.ErrNotifierUnexpected
+	}
+
+	return body, nil
+}
@@ -0,0 +1,131 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/notifier"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func review() entity.Review {
+	return entity.Review{
+		ProjectRef:  "eroatta/test",
+		PullRequest: 7,
+		Commit:      "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		Comments: []entity.ReviewComment{
+			{Path: "main.go", Line: 3, Identifier: "cfg", Suggestion: "config", Score: 0.25, Original: "var cfg = 1"},
+		},
+	}
+}
+
+func TestSent_OnGitHubReviewNotifier_ShouldReturnFingerprintsOnEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token valid-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/repos/eroatta/test/pulls/7/comments", r.URL.Path)
+
+		comments := make([]map[string]string, 0)
+		switch r.URL.Query().Get("page") {
+		case "1":
+			for i := 0; i < 99; i++ {
+				comments = append(comments, map[string]string{"body": "LGTM"})
+			}
+			comments = append(comments, map[string]string{"body": "rename it\n<!-- src-reader:0123456789abcdef -->"})
+		case "2":
+			comments = append(comments, map[string]string{"body": "rename it\n<!-- src-reader:fedcba9876543210 -->"})
+		}
+		json.NewEncoder(w).Encode(comments)
+	}))
+	defer server.Close()
+
+	n := notifier.NewGitHubReviewNotifier(server.Client(), server.URL, "valid-token")
+
+	sent, err := n.Sent(context.TODO(), review())
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"0123456789abcdef": true, "fedcba9876543210": true}, sent)
+}
+
+func TestSent_OnGitHubReviewNotifier_WhenInvalidToken_ShouldReturnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintln(w, `{"message": "Bad credentials"}`)
+	}))
+	defer server.Close()
+
+	n := notifier.NewGitHubReviewNotifier(server.Client(), server.URL, "invalid-token")
+
+	sent, err := n.Sent(context.TODO(), review())
+
+	assert.EqualError(t, err, repository.ErrNotifierUnexpected.Error())
+	assert.Nil(t, sent)
+}
+
+func TestNotify_OnGitHubReviewNotifier_WhenRateLimitExceeded_ShouldReturnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprintln(w, `{"message": "API rate limit exceeded"}`)
+	}))
+	defer server.Close()
+
+	n := notifier.NewGitHubReviewNotifier(server.Client(), server.URL, "valid-token")
+
+	err := n.Notify(context.TODO(), review())
+
+	assert.EqualError(t, err, repository.ErrNotifierRateLimited.Error())
+}
+
+func TestNotify_OnGitHubReviewNotifier_ShouldPostReviewWithSuggestions(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/eroatta/test/pulls/7/reviews", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, `{"id": 80}`)
+	}))
+	defer server.Close()
+
+	n := notifier.NewGitHubReviewNotifier(server.Client(), server.URL, "valid-token")
+
+	err := n.Notify(context.TODO(), review())
+
+	require.NoError(t, err)
+	var posted struct {
+		CommitID string `json:"commit_id"`
+		Event    string `json:"event"`
+		Comments []struct {
+			Path string `json:"path"`
+			Line int    `json:"line"`
+			Side string `json:"side"`
+			Body string `json:"body"`
+		} `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &posted))
+	assert.Equal(t, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52", posted.CommitID)
+	assert.Equal(t, "COMMENT", posted.Event)
+	require.Equal(t, 1, len(posted.Comments))
+	assert.Equal(t, "main.go", posted.Comments[0].Path)
+	assert.Equal(t, 3, posted.Comments[0].Line)
+	assert.Equal(t, "RIGHT", posted.Comments[0].Side)
+	assert.Equal(t, strings.Join([]string{
+		"`cfg` may be hard to read (score 0.25). Consider renaming it to `config`.",
+		"",
+		"```suggestion",
+		"var config = 1",
+		"```",
+		"<!-- src-reader:" + review().Comments[0].Fingerprint() + " -->",
+	}, "\n"), posted.Comments[0].Body)
+}
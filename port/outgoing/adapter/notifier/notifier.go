@@ -0,0 +1,30 @@
+// Package notifier provides the destinations for the review comments on a pull request.
+package notifier
+
+import "github.com/eroatta/src-reader/entity"
+
+// commentDTO is the representation of a review comment sent by the WebhookNotifier and written by
+// the FileNotifier.
+type commentDTO struct {
+	Path          string  `json:"path"`
+	Line          int     `json:"line"`
+	Identifier    string  `json:"identifier"`
+	Suggestion    string  `json:"suggestion"`
+	Score         float64 `json:"score"`
+	Body          string  `json:"body"`
+	SuggestedLine string  `json:"suggested_line"`
+	Fingerprint   string  `json:"fingerprint"`
+}
+
+func newCommentDTO(comment entity.ReviewComment) commentDTO {
+	return commentDTO{
+		Path:          comment.Path,
+		Line:          comment.Line,
+		Identifier:    comment.Identifier,
+		Suggestion:    comment.Suggestion,
+		Score:         comment.Score,
+		Body:          comment.Body(),
+		SuggestedLine: comment.SuggestedLine(),
+		Fingerprint:   comment.Fingerprint(),
+	}
+}
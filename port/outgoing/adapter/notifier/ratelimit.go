@@ -0,0 +1,100 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+)
+
+// RateLimitedNotifier represents a repository.Notifier that limits how many comments are posted by another
+// notifier on a time window, so repeated reviews don't flood the destination.
+type RateLimitedNotifier struct {
+	next   repository.Notifier
+	limit  int
+	window time.Duration
+	now    func() time.Time
+	mu     sync.Mutex
+	// posted holds the time and number of comments for each review posted, or being posted, on the current window.
+	posted []*postedReview
+}
+
+type postedReview struct {
+	at       time.Time
+	comments int
+}
+
+// NewRateLimitedNotifier creates a new RateLimitedNotifier, allowing up to the given number of comments
+// on each time window.
+func NewRateLimitedNotifier(next repository.Notifier, limit int, window time.Duration) *RateLimitedNotifier {
+	return &RateLimitedNotifier{
+		next:   next,
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		posted: make([]*postedReview, 0),
+	}
+}
+
+// Sent retrieves the fingerprints of the comments already posted by the underlying notifier.
+func (n *RateLimitedNotifier) Sent(ctx context.Context, review entity.Review) (map[string]bool, error) {
+	return n.next.Sent(ctx, review)
+}
+
+// Notify posts the review using the underlying notifier, along with as many comments as the current window allows.
+// The remaining comments are deferred, reporting them with a *repository.DeferredCommentsError, and
+// repository.ErrNotifierRateLimited is returned if no comment is allowed. The allowed comments are reserved before
+// posting them, so concurrent reviews don't wait for each other.
+func (n *RateLimitedNotifier) Notify(ctx context.Context, review entity.Review) error {
+	reserved := n.reserve(len(review.Comments))
+	if reserved == nil {
+		return repository.ErrNotifierRateLimited
+	}
+
+	allowed := review
+	allowed.Comments = review.Comments[:reserved.comments]
+	if err := n.next.Notify(ctx, allowed); err != nil {
+		n.mu.Lock()
+		reserved.comments = 0
+		n.mu.Unlock()
+		return err
+	}
+
+	if deferred := len(review.Comments) - reserved.comments; deferred > 0 {
+		return &repository.DeferredCommentsError{Posted: reserved.comments, Deferred: deferred}
+	}
+
+	return nil
+}
+
+// reserve counts up to the given number of comments on the current window, returning nil if no comment is allowed.
+func (n *RateLimitedNotifier) reserve(comments int) *postedReview {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.now()
+	current := n.posted[:0]
+	total := 0
+	for _, posted := range n.posted {
+		if now.Sub(posted.at) < n.window {
+			current = append(current, posted)
+			total += posted.comments
+		}
+	}
+	n.posted = current
+
+	available := n.limit - total
+	if available <= 0 {
+		return nil
+	}
+	if comments < available {
+		available = comments
+	}
+
+	reserved := &postedReview{at: now, comments: available}
+	n.posted = append(n.posted, reserved)
+
+	return reserved
+}
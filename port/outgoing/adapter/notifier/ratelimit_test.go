@@ -0,0 +1,101 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify_OnRateLimitedNotifier_ShouldLimitCommentsOnWindow(t *testing.T) {
+	next := &notifierMock{}
+	n := NewRateLimitedNotifier(next, 3, time.Minute)
+	now := time.Date(2020, time.June, 14, 18, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+
+	twoComments := entity.Review{Comments: make([]entity.ReviewComment, 2)}
+	assert.NoError(t, n.Notify(context.TODO(), twoComments))
+	assert.Equal(t, &repository.DeferredCommentsError{Posted: 1, Deferred: 1}, n.Notify(context.TODO(), twoComments))
+	assert.EqualError(t, n.Notify(context.TODO(), twoComments), repository.ErrNotifierRateLimited.Error())
+	assert.Equal(t, []int{2, 1}, next.comments)
+
+	// the first reviews leave the window
+	now = now.Add(time.Minute)
+	assert.NoError(t, n.Notify(context.TODO(), twoComments))
+	assert.Equal(t, []int{2, 1, 2}, next.comments)
+}
+
+func TestNotify_OnRateLimitedNotifier_ShouldNotHoldTheLockWhilePosting(t *testing.T) {
+	posting, release := make(chan struct{}), make(chan struct{})
+	next := &notifierMock{posting: posting, release: release}
+	n := NewRateLimitedNotifier(next, 3, time.Minute)
+
+	done := make(chan error)
+	go func() {
+		done <- n.Notify(context.TODO(), entity.Review{Comments: make([]entity.ReviewComment, 2)})
+	}()
+	<-posting
+
+	// the comments of the review being posted are already counted
+	next.posting, next.release = nil, nil
+	err := n.Notify(context.TODO(), entity.Review{Comments: make([]entity.ReviewComment, 2)})
+	assert.Equal(t, &repository.DeferredCommentsError{Posted: 1, Deferred: 1}, err)
+
+	close(release)
+	assert.NoError(t, <-done)
+}
+
+func TestNotify_OnRateLimitedNotifier_WhenFailingToNotify_ShouldNotCountComments(t *testing.T) {
+	next := &notifierMock{err: repository.ErrNotifierUnexpected}
+	n := NewRateLimitedNotifier(next, 2, time.Minute)
+
+	twoComments := entity.Review{Comments: make([]entity.ReviewComment, 2)}
+	assert.EqualError(t, n.Notify(context.TODO(), twoComments), repository.ErrNotifierUnexpected.Error())
+
+	next.err = nil
+	assert.NoError(t, n.Notify(context.TODO(), twoComments))
+}
+
+func TestSent_OnRateLimitedNotifier_ShouldUseUnderlyingNotifier(t *testing.T) {
+	n := NewRateLimitedNotifier(&notifierMock{sent: map[string]bool{"0123456789abcdef": true}}, 1, time.Minute)
+
+	sent, err := n.Sent(context.TODO(), entity.Review{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"0123456789abcdef": true}, sent)
+}
+
+type notifierMock struct {
+	sent     map[string]bool
+	notified int
+	comments []int
+	err      error
+	// posting, if set, is signaled when a review is being posted, which waits until release is closed.
+	posting chan struct{}
+	release chan struct{}
+}
+
+func (m *notifierMock) Sent(ctx context.Context, review entity.Review) (map[string]bool, error) {
+	if m.sent == nil {
+		return nil, errors.New("shouldn't be called")
+	}
+	return m.sent, nil
+}
+
+func (m *notifierMock) Notify(ctx context.Context, review entity.Review) error {
+	if m.posting != nil {
+		posting, release := m.posting, m.release
+		posting <- struct{}{}
+		<-release
+	}
+	if m.err != nil {
+		return m.err
+	}
+	m.notified++
+	m.comments = append(m.comments, len(review.Comments))
+	return nil
+}
@@ -0,0 +1,80 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// WebhookNotifier represents a repository.Notifier that posts each review as a JSON document to a generic
+// webhook. Since the webhook can't be queried, the posted comments are recorded on a local file, so they are
+// remembered across restarts.
+type WebhookNotifier struct {
+	httpClient *http.Client
+	url        string
+	// sent records the posted comments.
+	sent *FileNotifier
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier, posting to the given URL and recording the posted comments
+// on the given file.
+func NewWebhookNotifier(httpClient *http.Client, url string, sentPath string) *WebhookNotifier {
+	return &WebhookNotifier{
+		httpClient: httpClient,
+		url:        url,
+		sent:       NewFileNotifier(sentPath),
+	}
+}
+
+type reviewDTO struct {
+	ProjectRef  string       `json:"project_ref"`
+	PullRequest int          `json:"pull_request"`
+	Commit      string       `json:"commit"`
+	Comments    []commentDTO `json:"comments"`
+}
+
+// Sent retrieves the fingerprints of the comments posted for the pull request, as recorded on the file.
+func (n *WebhookNotifier) Sent(ctx context.Context, review entity.Review) (map[string]bool, error) {
+	return n.sent.Sent(ctx, review)
+}
+
+// Notify posts the review to the webhook, recording its comments once they are accepted.
+func (n *WebhookNotifier) Notify(ctx context.Context, review entity.Review) error {
+	dto := reviewDTO{
+		ProjectRef:  review.ProjectRef,
+		PullRequest: review.PullRequest,
+		Commit:      review.Commit,
+		Comments:    make([]commentDTO, 0, len(review.Comments)),
+	}
+	for _, comment := range review.Comments {
+		dto.Comments = append(dto.Comments, newCommentDTO(comment))
+	}
+	payload, _ := json.Marshal(dto)
+
+	request, _ := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(payload))
+	request = request.WithContext(ctx)
+	request.Header.Add("Content-Type", "application/json")
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		log.WithError(err).Errorf("an error occurred while trying to POST the review to %s", n.url)
+		return repository.ErrNotifierUnexpected
+	}
+	response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests:
+		log.Warnf("rate limit exceeded for the webhook %s", n.url)
+		return repository.ErrNotifierRateLimited
+	case response.StatusCode >= http.StatusBadRequest:
+		log.WithField("status_code", response.StatusCode).Errorf("an error occurred while trying to POST the review to %s", n.url)
+		return repository.ErrNotifierUnexpected
+	}
+
+	return n.sent.Notify(ctx, review)
+}
@@ -0,0 +1,114 @@
+package notifier_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/notifier"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotify_OnWebhookNotifier_WhenRateLimitExceeded_ShouldReturnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	n := notifier.NewWebhookNotifier(server.Client(), server.URL, sentPath(t))
+
+	err := n.Notify(context.TODO(), review())
+
+	assert.EqualError(t, err, repository.ErrNotifierRateLimited.Error())
+	sent, _ := n.Sent(context.TODO(), review())
+	assert.Empty(t, sent)
+}
+
+func TestNotify_OnWebhookNotifier_WhenFailingRequest_ShouldReturnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	n := notifier.NewWebhookNotifier(server.Client(), server.URL, sentPath(t))
+
+	err := n.Notify(context.TODO(), review())
+
+	assert.EqualError(t, err, repository.ErrNotifierUnexpected.Error())
+}
+
+func TestNotify_OnWebhookNotifier_ShouldPostReviewAndRememberComments(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	n := notifier.NewWebhookNotifier(server.Client(), server.URL, sentPath(t))
+
+	err := n.Notify(context.TODO(), review())
+
+	require.NoError(t, err)
+	fingerprint := review().Comments[0].Fingerprint()
+	assert.JSONEq(t, `
+		{
+			"project_ref": "eroatta/test",
+			"pull_request": 7,
+			"commit": "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+			"comments": [
+				{
+					"path": "main.go",
+					"line": 3,
+					"identifier": "cfg",
+					"suggestion": "config",
+					"score": 0.25,
+					"body": "`+"`cfg` may be hard to read (score 0.25). Consider renaming it to `config`."+`",
+					"suggested_line": "var config = 1",
+					"fingerprint": "`+fingerprint+`"
+				}
+			]
+		}`, body)
+
+	sent, err := n.Sent(context.TODO(), review())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{fingerprint: true}, sent)
+
+	another := review()
+	another.PullRequest = 8
+	sent, err = n.Sent(context.TODO(), another)
+	assert.NoError(t, err)
+	assert.Empty(t, sent)
+}
+
+func TestSent_OnWebhookNotifier_WithNoReviews_ShouldReturnEmpty(t *testing.T) {
+	n := notifier.NewWebhookNotifier(http.DefaultClient, "http://localhost", sentPath(t))
+
+	sent, err := n.Sent(context.TODO(), entity.Review{ProjectRef: "eroatta/test", PullRequest: 7})
+
+	assert.NoError(t, err)
+	assert.Empty(t, sent)
+}
+
+func TestSent_OnWebhookNotifier_ShouldRememberCommentsAcrossInstances(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	path := sentPath(t)
+
+	require.NoError(t, notifier.NewWebhookNotifier(server.Client(), server.URL, path).Notify(context.TODO(), review()))
+
+	sent, err := notifier.NewWebhookNotifier(server.Client(), server.URL, path).Sent(context.TODO(), review())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{review().Comments[0].Fingerprint(): true}, sent)
+}
+
+func sentPath(t *testing.T) string {
+	return filepath.Join(t.TempDir(), "review-sent.jsonl")
+}
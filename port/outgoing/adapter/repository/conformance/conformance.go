@@ -0,0 +1,1234 @@
+// Package conformance provides the test suites shared by every repository adapter, so the behaviour
+// is the same whatever the storage backend is.
+//
+// Each suite receives a function that creates a new and empty repository for every test case.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"go/token"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ProjectRepository runs the conformance suite for a repository.ProjectRepository.
+func ProjectRepository(t *testing.T, newRepository func(t *testing.T) repository.ProjectRepository) {
+	ctx := context.Background()
+	project := entity.Project{
+		ID:        uuid.New(),
+		Status:    "done",
+		Reference: "eroatta/test",
+		Metadata:  entity.Metadata{Fullname: "eroatta/test"},
+		SourceCode: entity.SourceCode{
+			Hash:     "asdf1234asdf",
+			Location: "/tmp/repositories/eroatta/test",
+			Files:    []string{"main.go"},
+		},
+		Filter: entity.FileFilter{Exclude: []string{"tools/**"}},
+	}
+
+	t.Run("get_missing_project", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.Get(ctx, uuid.New())
+		assert.Equal(t, repository.ErrProjectNoResults, err)
+
+		_, err = r.GetByReference(ctx, "eroatta/missing")
+		assert.Equal(t, repository.ErrProjectNoResults, err)
+	})
+
+	t.Run("add_and_get_project", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, project))
+
+		found, err := r.Get(ctx, project.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, project.ID, found.ID)
+		assert.Equal(t, project.Reference, found.Reference)
+		assert.Equal(t, project.SourceCode, found.SourceCode)
+
+		found, err = r.GetByReference(ctx, project.Reference)
+		assert.NoError(t, err)
+		assert.Equal(t, project.ID, found.ID)
+	})
+
+	t.Run("update_project", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, project))
+
+		updated := project
+		updated.SourceCode = entity.SourceCode{
+			Hash:     "qwer5678qwer",
+			Location: "/tmp/repositories/eroatta/test",
+			Files:    []string{"main.go", "main_test.go"},
+		}
+		assert.NoError(t, r.Update(ctx, updated))
+
+		found, _ := r.Get(ctx, project.ID)
+		assert.Equal(t, updated.SourceCode, found.SourceCode)
+
+		missing := project
+		missing.ID = uuid.New()
+		assert.Equal(t, repository.ErrProjectNoResults, r.Update(ctx, missing))
+	})
+
+	t.Run("delete_project", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, project))
+
+		assert.NoError(t, r.Delete(ctx, project.ID))
+		assert.Equal(t, repository.ErrProjectNoResults, r.Delete(ctx, project.ID))
+
+		_, err := r.Get(ctx, project.ID)
+		assert.Equal(t, repository.ErrProjectNoResults, err)
+	})
+
+	t.Run("workspaces_are_isolated", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.WithWorkspace(ctx, uuid.New())
+		require.NoError(t, r.Add(other, project))
+
+		_, err := r.Get(ctx, project.ID)
+		assert.Equal(t, repository.ErrProjectNoResults, err)
+		_, err = r.GetByReference(ctx, project.Reference)
+		assert.Equal(t, repository.ErrProjectNoResults, err)
+		page, err := r.Query(ctx, entity.ProjectQuery{})
+		assert.NoError(t, err)
+		assert.Empty(t, page.Projects)
+		assert.Equal(t, repository.ErrProjectNoResults, r.Update(ctx, project))
+		assert.Equal(t, repository.ErrProjectNoResults, r.Delete(ctx, project.ID))
+
+		found, err := r.Get(other, project.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, project.ID, found.ID)
+	})
+}
+
+// ProjectQuery runs the conformance suite for the queries on a repository.ProjectRepository. The accuracy of each
+// project is computed from the insights stored on the given repository.InsightRepository, which shares the storage
+// with the repository.ProjectRepository.
+func ProjectQuery(t *testing.T,
+	newRepositories func(t *testing.T) (repository.ProjectRepository, repository.InsightRepository)) {
+	ctx := context.Background()
+	created := time.Date(2020, time.May, 10, 12, 0, 0, 0, time.UTC)
+	newProject := func(ref string, owner string, days int, stars int32, fork bool) entity.Project {
+		return entity.Project{
+			ID:        uuid.New(),
+			Status:    "done",
+			Reference: ref,
+			CreatedAt: created.AddDate(0, 0, days),
+			Metadata: entity.Metadata{
+				Owner:       owner,
+				Fullname:    ref,
+				Description: "Project " + ref,
+				License:     "mit",
+				IsFork:      fork,
+				Stargazers:  stars,
+			},
+			SourceCode: entity.SourceCode{Files: []string{"main.go"}},
+		}
+	}
+	projects := []entity.Project{
+		newProject("eroatta/reader", "eroatta", 0, 30, false),
+		newProject("eroatta/writer", "eroatta", 2, 10, false),
+		newProject("golang/tools", "golang", 1, 20, true),
+	}
+	projects[1].Metadata.License = "apache-2.0"
+	projects[2].Metadata.Description = "Go tools for the split_100% project"
+	insights := []entity.Insight{
+		{ProjectRef: "eroatta/reader", AnalysisID: uuid.New(), Package: "main", TotalIdentifiers: 2, TotalWeight: 1.0,
+			TotalSplits: map[string]int{}, TotalExpansions: map[string]int{}, Files: map[string]struct{}{}},
+		{ProjectRef: "eroatta/reader", AnalysisID: uuid.New(), Package: "util", TotalIdentifiers: 2, TotalWeight: 2.0,
+			TotalSplits: map[string]int{}, TotalExpansions: map[string]int{}, Files: map[string]struct{}{}},
+		{ProjectRef: "golang/tools", AnalysisID: uuid.New(), Package: "main", TotalIdentifiers: 4, TotalWeight: 3.6,
+			TotalSplits: map[string]int{}, TotalExpansions: map[string]int{}, Files: map[string]struct{}{}},
+		// insights from test files are left out of the accuracy
+		{ProjectRef: "golang/tools", AnalysisID: uuid.New(), Package: "main", TotalIdentifiers: 4, TotalWeight: 0.4,
+			TotalSplits: map[string]int{}, TotalExpansions: map[string]int{}, Files: map[string]struct{}{}, Test: true},
+	}
+	yes, no := true, false
+
+	references := func(page entity.ProjectPage) []string {
+		refs := make([]string, 0)
+		for _, project := range page.Projects {
+			refs = append(refs, project.Reference)
+		}
+		return refs
+	}
+
+	cases := []struct {
+		name     string
+		query    entity.ProjectQuery
+		expected []string
+		total    int
+	}{
+		{name: "no_criteria", query: entity.ProjectQuery{},
+			expected: []string{"eroatta/writer", "golang/tools", "eroatta/reader"}, total: 3},
+		{name: "owner", query: entity.ProjectQuery{Owner: "eroatta"},
+			expected: []string{"eroatta/writer", "eroatta/reader"}, total: 2},
+		{name: "license", query: entity.ProjectQuery{License: "apache-2.0"}, expected: []string{"eroatta/writer"}, total: 1},
+		{name: "fork", query: entity.ProjectQuery{Fork: &yes}, expected: []string{"golang/tools"}, total: 1},
+		{name: "not_fork", query: entity.ProjectQuery{Fork: &no, Sort: entity.ProjectSortCreated},
+			expected: []string{"eroatta/reader", "eroatta/writer"}, total: 2},
+		{name: "status", query: entity.ProjectQuery{Status: "in_progress"}, expected: []string{}, total: 0},
+		{name: "text_on_reference", query: entity.ProjectQuery{Text: "WRITER"}, expected: []string{"eroatta/writer"}, total: 1},
+		{name: "text_on_description", query: entity.ProjectQuery{Text: "split_100%"},
+			expected: []string{"golang/tools"}, total: 1},
+		{name: "text_with_wildcards", query: entity.ProjectQuery{Text: "a%r"}, expected: []string{}, total: 0},
+		{name: "sort_by_stars", query: entity.ProjectQuery{Sort: entity.ProjectSortStars},
+			expected: []string{"eroatta/writer", "golang/tools", "eroatta/reader"}, total: 3},
+		{name: "sort_by_stars_desc", query: entity.ProjectQuery{Sort: entity.ProjectSortStarsDesc},
+			expected: []string{"eroatta/reader", "golang/tools", "eroatta/writer"}, total: 3},
+		{name: "sort_by_accuracy", query: entity.ProjectQuery{Sort: entity.ProjectSortAccuracy},
+			expected: []string{"eroatta/reader", "golang/tools", "eroatta/writer"}, total: 3},
+		{name: "sort_by_accuracy_desc", query: entity.ProjectQuery{Sort: entity.ProjectSortAccuracyDesc},
+			expected: []string{"golang/tools", "eroatta/reader", "eroatta/writer"}, total: 3},
+		{name: "first_page", query: entity.ProjectQuery{Sort: entity.ProjectSortCreated, Limit: 2},
+			expected: []string{"eroatta/reader", "golang/tools"}, total: 3},
+		{name: "last_page", query: entity.ProjectQuery{Sort: entity.ProjectSortCreated, Offset: 2, Limit: 2},
+			expected: []string{"eroatta/writer"}, total: 3},
+		{name: "after_last_page", query: entity.ProjectQuery{Offset: 3, Limit: 2}, expected: []string{}, total: 3},
+	}
+
+	pr, ir := newRepositories(t)
+	for _, project := range projects {
+		require.NoError(t, pr.Add(ctx, project))
+	}
+	require.NoError(t, ir.AddAll(ctx, insights))
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			page, err := pr.Query(ctx, c.query)
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, references(page))
+			assert.Equal(t, c.total, page.Total)
+		})
+	}
+
+	t.Run("accuracy", func(t *testing.T) {
+		page, err := pr.Query(ctx, entity.ProjectQuery{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(page.Accuracy))
+		assert.InDelta(t, 0.75, page.Accuracy[projects[0].ID], 0.001)
+		assert.InDelta(t, 0.9, page.Accuracy[projects[2].ID], 0.001)
+		_, ok := page.Accuracy[projects[1].ID]
+		assert.False(t, ok)
+	})
+}
+
+// AnalysisRepository runs the conformance suite for a repository.AnalysisRepository.
+func AnalysisRepository(t *testing.T, newRepository func(t *testing.T) repository.AnalysisRepository) {
+	ctx := context.Background()
+	analysis := entity.AnalysisResults{
+		ID:                uuid.New(),
+		ProjectID:         uuid.New(),
+		ProjectName:       "eroatta/test",
+		DateCreated:       time.Now(),
+		PipelineMiners:    []string{},
+		PipelineSplitters: []string{"conserv"},
+		PipelineExpanders: []string{"noexp"},
+		IncludeTests:      true,
+		FilesTotal:        1,
+		FilesValid:        1,
+		FilesSkipped:      1,
+		IdentifiersTotal:  2,
+		IdentifiersValid:  2,
+	}
+
+	t.Run("get_missing_analysis", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.Get(ctx, uuid.New())
+		assert.Equal(t, repository.ErrAnalysisNoResults, err)
+
+		_, err = r.GetByProjectID(ctx, uuid.New())
+		assert.Equal(t, repository.ErrAnalysisNoResults, err)
+	})
+
+	t.Run("add_and_get_analysis", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, analysis))
+
+		found, err := r.Get(ctx, analysis.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, analysis.ID, found.ID)
+		assert.Equal(t, analysis.ProjectName, found.ProjectName)
+		assert.Equal(t, analysis.IdentifiersTotal, found.IdentifiersTotal)
+		assert.Equal(t, analysis.FilesSkipped, found.FilesSkipped)
+		assert.True(t, found.IncludeTests)
+
+		found, err = r.GetByProjectID(ctx, analysis.ProjectID)
+		assert.NoError(t, err)
+		assert.Equal(t, analysis.ID, found.ID)
+	})
+
+	t.Run("delete_analysis", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, analysis))
+
+		assert.NoError(t, r.Delete(ctx, analysis.ID))
+		assert.Equal(t, repository.ErrAnalysisNoResults, r.Delete(ctx, analysis.ID))
+	})
+
+	t.Run("workspaces_are_isolated", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.WithWorkspace(ctx, uuid.New())
+		require.NoError(t, r.Add(other, analysis))
+
+		_, err := r.Get(ctx, analysis.ID)
+		assert.Equal(t, repository.ErrAnalysisNoResults, err)
+		_, err = r.GetByProjectID(ctx, analysis.ProjectID)
+		assert.Equal(t, repository.ErrAnalysisNoResults, err)
+		assert.Equal(t, repository.ErrAnalysisNoResults, r.Delete(ctx, analysis.ID))
+
+		_, err = r.Get(other, analysis.ID)
+		assert.NoError(t, err)
+	})
+}
+
+// IdentifierRepository runs the conformance suite for a repository.IdentifierRepository.
+func IdentifierRepository(t *testing.T, newRepository func(t *testing.T) repository.IdentifierRepository) {
+	ctx := context.Background()
+	analysis := entity.AnalysisResults{ID: uuid.New(), ProjectName: "eroatta/test"}
+	idents := []entity.Identifier{
+		newIdentifier("main.go", "main"),
+		newIdentifier("main.go", "parseFile"),
+		newIdentifier("util.go", "maxLen"),
+	}
+
+	names := func(idents []entity.Identifier) []string {
+		found := make([]string, 0)
+		for _, ident := range idents {
+			found = append(found, ident.Name)
+		}
+		return found
+	}
+
+	t.Run("find_missing_identifiers", func(t *testing.T) {
+		r := newRepository(t)
+
+		found, err := r.FindAllByAnalysisID(ctx, uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+
+		assert.Equal(t, repository.ErrIdentifierNoResults, r.DeleteAllByAnalysisID(ctx, uuid.New()))
+	})
+
+	t.Run("staged_identifiers_are_not_visible", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, analysis, idents))
+		require.NoError(t, r.Add(ctx, analysis, newIdentifier("util.go", "minLen")))
+
+		found, err := r.FindAllByAnalysisID(ctx, analysis.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+
+		found, err = r.FindAllByProjectAndFile(ctx, analysis.ProjectName, "main.go")
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+
+		staged, err := r.FindStagedAnalysisIDs(ctx, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{analysis.ID}, staged)
+
+		staged, err = r.FindStagedAnalysisIDs(ctx, time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.Empty(t, staged)
+	})
+
+	t.Run("in_progress_analyses_are_not_idle", func(t *testing.T) {
+		r := newRepository(t)
+		idle := entity.AnalysisResults{ID: uuid.New(), ProjectName: "eroatta/idle"}
+		require.NoError(t, r.AddAll(ctx, analysis, idents))
+		require.NoError(t, r.Stage(ctx, analysis.ID))
+		require.NoError(t, r.Stage(ctx, idle.ID))
+
+		staged, err := r.FindStagedAnalysisIDs(ctx, time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.Empty(t, staged)
+
+		staged, err = r.FindStagedAnalysisIDs(ctx, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{analysis.ID, idle.ID}, staged)
+
+		require.NoError(t, r.Stage(ctx, analysis.ID))
+		require.NoError(t, r.Commit(ctx, analysis.ID))
+		assert.Equal(t, repository.ErrIdentifierNoResults, r.DeleteAllByAnalysisID(ctx, idle.ID))
+
+		staged, err = r.FindStagedAnalysisIDs(ctx, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Empty(t, staged)
+	})
+
+	t.Run("line_and_column_are_kept", func(t *testing.T) {
+		r := newRepository(t)
+		ident := newIdentifier("main.go", "main")
+		ident.Line, ident.Column = 12, 6
+		require.NoError(t, r.AddAll(ctx, analysis, []entity.Identifier{ident}))
+		require.NoError(t, r.Commit(ctx, analysis.ID))
+
+		found, err := r.FindAllByAnalysisID(ctx, analysis.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(found))
+		assert.Equal(t, 12, found[0].Line)
+		assert.Equal(t, 6, found[0].Column)
+	})
+
+	t.Run("committed_identifiers_are_visible", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, analysis, idents))
+		require.NoError(t, r.Commit(ctx, analysis.ID))
+
+		found, err := r.FindAllByAnalysisID(ctx, analysis.ID)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"main", "parseFile", "maxLen"}, names(found))
+
+		found, err = r.FindAllByProjectAndFile(ctx, analysis.ProjectName, "main.go")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"main", "parseFile"}, names(found))
+		for _, ident := range found {
+			assert.Equal(t, analysis.ID, ident.AnalysisID)
+			assert.Equal(t, analysis.ProjectName, ident.ProjectRef)
+			assert.Equal(t, ident.Name, ident.Splits["conserv"][0].Value)
+		}
+
+		staged, err := r.FindStagedAnalysisIDs(ctx, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Empty(t, staged)
+	})
+
+	t.Run("iterate_identifiers", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, analysis, idents))
+		require.NoError(t, r.Commit(ctx, analysis.ID))
+
+		it, err := r.IterateByAnalysisID(ctx, analysis.ID)
+		require.NoError(t, err)
+
+		found := make([]entity.Identifier, 0)
+		for it.Next(ctx) {
+			found = append(found, it.Identifier())
+		}
+		assert.NoError(t, it.Err())
+		assert.NoError(t, it.Close(ctx))
+		assert.ElementsMatch(t, []string{"main", "parseFile", "maxLen"}, names(found))
+	})
+
+	t.Run("query_identifiers", func(t *testing.T) {
+		r := newRepository(t)
+		scored := make([]entity.Identifier, 0)
+		for i, name := range []string{"Reader", "readFile", "cfg", "maxLen", "Writer"} {
+			ident := newIdentifier("io.go", name)
+			ident.Type = token.FUNC
+			ident.Normalization.Score = float64(i%3) / 2
+			scored = append(scored, ident)
+		}
+		scored[2].Type = token.VAR
+		scored[3].Splits["greedy"] = []entity.Split{{Order: 1, Value: "max"}, {Order: 2, Value: "len"}}
+		require.NoError(t, r.AddAll(ctx, analysis, scored))
+		require.NoError(t, r.AddAll(ctx, entity.AnalysisResults{ID: uuid.New()}, scored))
+		require.NoError(t, r.Commit(ctx, analysis.ID))
+
+		exported, minScore := true, 0.5
+		page, err := r.QueryByAnalysisID(ctx, analysis.ID, entity.IdentifierQuery{Exported: &exported})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Reader", "Writer"}, names(page.Identifiers))
+		assert.Empty(t, page.NextCursor)
+
+		page, _ = r.QueryByAnalysisID(ctx, analysis.ID, entity.IdentifierQuery{Type: token.FUNC, MinScore: &minScore})
+		assert.ElementsMatch(t, []string{"readFile", "Writer"}, names(page.Identifiers))
+
+		page, _ = r.QueryByAnalysisID(ctx, analysis.ID, entity.IdentifierQuery{Splitter: "greedy"})
+		assert.Equal(t, []string{"maxLen"}, names(page.Identifiers))
+
+		page, _ = r.QueryByAnalysisID(ctx, analysis.ID, entity.IdentifierQuery{Name: regexp.MustCompile("^[Rr]ead")})
+		assert.ElementsMatch(t, []string{"Reader", "readFile"}, names(page.Identifiers))
+
+		sorted := make([]entity.Identifier, 0)
+		query := entity.IdentifierQuery{Sort: entity.IdentifierSortScoreDesc, Limit: 2}
+		for pages := 0; pages < 5; pages++ {
+			page, err := r.QueryByAnalysisID(ctx, analysis.ID, query)
+			require.NoError(t, err)
+			sorted = append(sorted, page.Identifiers...)
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		require.Equal(t, 5, len(sorted))
+		assert.Equal(t, "cfg", sorted[0].Name)
+		assert.ElementsMatch(t, []string{"readFile", "Writer"}, names(sorted[1:3]))
+		assert.ElementsMatch(t, []string{"Reader", "maxLen"}, names(sorted[3:]))
+
+		_, err = r.QueryByAnalysisID(ctx, analysis.ID, entity.IdentifierQuery{Cursor: "invalid"})
+		assert.Equal(t, repository.ErrIdentifierInvalidCursor, err)
+	})
+
+	t.Run("delete_staged_and_committed_identifiers", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.AnalysisResults{ID: uuid.New(), ProjectName: "eroatta/other"}
+		require.NoError(t, r.AddAll(ctx, analysis, idents))
+		require.NoError(t, r.AddAll(ctx, other, idents))
+		require.NoError(t, r.Commit(ctx, other.ID))
+
+		assert.NoError(t, r.DeleteAllByAnalysisID(ctx, analysis.ID))
+		assert.NoError(t, r.DeleteAllByAnalysisID(ctx, other.ID))
+
+		staged, _ := r.FindStagedAnalysisIDs(ctx, time.Now().Add(time.Minute))
+		assert.Empty(t, staged)
+
+		found, _ := r.FindAllByAnalysisID(ctx, other.ID)
+		assert.Empty(t, found)
+	})
+
+	t.Run("workspaces_are_isolated", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.WithWorkspace(ctx, uuid.New())
+		require.NoError(t, r.AddAll(other, analysis, idents))
+
+		staged, err := r.FindStagedAnalysisIDs(ctx, time.Now().Add(time.Minute))
+		assert.NoError(t, err)
+		assert.Empty(t, staged)
+		require.NoError(t, r.Commit(ctx, analysis.ID))
+		assert.Equal(t, repository.ErrIdentifierNoResults, r.DeleteAllByAnalysisID(ctx, analysis.ID))
+
+		require.NoError(t, r.Commit(other, analysis.ID))
+		found, err := r.FindAllByAnalysisID(ctx, analysis.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+		found, err = r.FindAllByProjectAndFile(ctx, analysis.ProjectName, "main.go")
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+		page, err := r.QueryByAnalysisID(ctx, analysis.ID, entity.IdentifierQuery{})
+		assert.NoError(t, err)
+		assert.Empty(t, page.Identifiers)
+
+		found, err = r.FindAllByAnalysisID(other, analysis.ID)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"main", "parseFile", "maxLen"}, names(found))
+	})
+}
+
+// InsightRepository runs the conformance suite for a repository.InsightRepository.
+func InsightRepository(t *testing.T, newRepository func(t *testing.T) repository.InsightRepository) {
+	ctx := context.Background()
+	analysisID := uuid.New()
+	insights := []entity.Insight{
+		{
+			ProjectRef:       "eroatta/test",
+			AnalysisID:       analysisID,
+			Package:          "main",
+			TotalIdentifiers: 2,
+			TotalExported:    1,
+			TotalSplits:      map[string]int{"conserv": 3},
+			TotalExpansions:  map[string]int{"noexp": 3},
+			TotalWeight:      1.7,
+			Files:            map[string]struct{}{"main.go": {}},
+		},
+		{
+			ProjectRef:       "eroatta/test",
+			AnalysisID:       analysisID,
+			Package:          "util",
+			TotalIdentifiers: 1,
+			TotalSplits:      map[string]int{"conserv": 1},
+			TotalExpansions:  map[string]int{"noexp": 1},
+			TotalWeight:      0.7,
+			Files:            map[string]struct{}{"util/util.go": {}},
+		},
+		{
+			ProjectRef:       "eroatta/test",
+			AnalysisID:       analysisID,
+			Package:          "main",
+			TotalIdentifiers: 1,
+			TotalSplits:      map[string]int{"conserv": 2},
+			TotalExpansions:  map[string]int{"noexp": 2},
+			TotalWeight:      0.5,
+			Files:            map[string]struct{}{"main_test.go": {}},
+			Test:             true,
+		},
+	}
+
+	t.Run("get_missing_insights", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.GetByAnalysisID(ctx, uuid.New())
+		assert.Equal(t, repository.ErrInsightNoResults, err)
+
+		assert.Equal(t, repository.ErrInsightNoResults, r.DeleteAllByAnalysisID(ctx, uuid.New()))
+	})
+
+	t.Run("add_and_get_insights", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, insights))
+
+		found, err := r.GetByAnalysisID(ctx, analysisID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(found))
+
+		byPackage := make(map[string]entity.Insight)
+		tests := make([]entity.Insight, 0)
+		for _, insight := range found {
+			assert.NotEmpty(t, insight.ID)
+			if insight.Test {
+				tests = append(tests, insight)
+				continue
+			}
+			byPackage[insight.Package] = insight
+		}
+		assert.Equal(t, 2, byPackage["main"].TotalIdentifiers)
+		assert.Equal(t, map[string]int{"conserv": 3}, byPackage["main"].TotalSplits)
+		assert.Equal(t, map[string]struct{}{"util/util.go": {}}, byPackage["util"].Files)
+		assert.InDelta(t, 0.85, byPackage["main"].Rate(), 0.001)
+		require.Equal(t, 1, len(tests))
+		assert.Equal(t, map[string]struct{}{"main_test.go": {}}, tests[0].Files)
+	})
+
+	t.Run("delete_insights", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, insights))
+
+		assert.NoError(t, r.DeleteAllByAnalysisID(ctx, analysisID))
+
+		_, err := r.GetByAnalysisID(ctx, analysisID)
+		assert.Equal(t, repository.ErrInsightNoResults, err)
+	})
+
+	t.Run("workspaces_are_isolated", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.WithWorkspace(ctx, uuid.New())
+		require.NoError(t, r.AddAll(other, insights))
+
+		_, err := r.GetByAnalysisID(ctx, analysisID)
+		assert.Equal(t, repository.ErrInsightNoResults, err)
+		assert.Equal(t, repository.ErrInsightNoResults, r.DeleteAllByAnalysisID(ctx, analysisID))
+
+		found, err := r.GetByAnalysisID(other, analysisID)
+		assert.NoError(t, err)
+		assert.Equal(t, len(insights), len(found))
+	})
+}
+
+// DictionaryRepository runs the conformance suite for a repository.DictionaryRepository.
+func DictionaryRepository(t *testing.T, newRepository func(t *testing.T) repository.DictionaryRepository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	organization := entity.Dictionary{
+		ID:        uuid.New(),
+		Scope:     entity.DictionaryScopeOrganization,
+		Entries:   map[string][]string{"ctx": {"context"}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	project := entity.Dictionary{
+		ID:         uuid.New(),
+		Scope:      entity.DictionaryScopeProject,
+		ProjectRef: "eroatta/test",
+		Entries:    map[string][]string{"cfg": {"config", "configuration"}},
+		CreatedAt:  now.Add(time.Second),
+		UpdatedAt:  now.Add(time.Second),
+	}
+
+	t.Run("get_missing_dictionary", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.Get(ctx, uuid.New())
+		assert.Equal(t, repository.ErrDictionaryNoResults, err)
+
+		_, err = r.GetByScope(ctx, entity.DictionaryScopeOrganization, "")
+		assert.Equal(t, repository.ErrDictionaryNoResults, err)
+
+		found, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("add_and_get_dictionaries", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, organization))
+		require.NoError(t, r.Add(ctx, project))
+
+		found, err := r.Get(ctx, project.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, project.Entries, found.Entries)
+
+		found, err = r.GetByScope(ctx, entity.DictionaryScopeOrganization, "ignored")
+		assert.NoError(t, err)
+		assert.Equal(t, organization.ID, found.ID)
+
+		found, err = r.GetByScope(ctx, entity.DictionaryScopeProject, "eroatta/test")
+		assert.NoError(t, err)
+		assert.Equal(t, project.ID, found.ID)
+
+		_, err = r.GetByScope(ctx, entity.DictionaryScopeProject, "eroatta/other")
+		assert.Equal(t, repository.ErrDictionaryNoResults, err)
+
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(all))
+	})
+
+	t.Run("update_dictionary", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, project))
+
+		updated := project
+		updated.Entries = map[string][]string{"req": {"request"}}
+		assert.NoError(t, r.Update(ctx, updated))
+
+		found, _ := r.Get(ctx, project.ID)
+		assert.Equal(t, updated.Entries, found.Entries)
+
+		missing := organization
+		missing.ID = uuid.New()
+		assert.Equal(t, repository.ErrDictionaryNoResults, r.Update(ctx, missing))
+	})
+
+	t.Run("delete_dictionary", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, project))
+
+		assert.NoError(t, r.Delete(ctx, project.ID))
+		assert.Equal(t, repository.ErrDictionaryNoResults, r.Delete(ctx, project.ID))
+	})
+
+	t.Run("workspaces_are_isolated", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.WithWorkspace(ctx, uuid.New())
+		require.NoError(t, r.Add(other, organization))
+
+		_, err := r.Get(ctx, organization.ID)
+		assert.Equal(t, repository.ErrDictionaryNoResults, err)
+		_, err = r.GetByScope(ctx, entity.DictionaryScopeOrganization, "")
+		assert.Equal(t, repository.ErrDictionaryNoResults, err)
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+		assert.Equal(t, repository.ErrDictionaryNoResults, r.Update(ctx, organization))
+		assert.Equal(t, repository.ErrDictionaryNoResults, r.Delete(ctx, organization.ID))
+
+		_, err = r.GetByScope(other, entity.DictionaryScopeOrganization, "")
+		assert.NoError(t, err)
+	})
+}
+
+// APIKeyRepository runs the conformance suite for a repository.APIKeyRepository.
+func APIKeyRepository(t *testing.T, newRepository func(t *testing.T) repository.APIKeyRepository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	reader := entity.APIKey{
+		ID:        uuid.New(),
+		Name:      "dashboard",
+		Prefix:    "srk_0a1b2c3d",
+		Hash:      entity.HashAPIKey("srk_0a1b2c3d"),
+		Scopes:    []string{entity.ScopeRead},
+		CreatedAt: now,
+	}
+	admin := entity.APIKey{
+		ID:        uuid.New(),
+		Name:      "ops",
+		Prefix:    "srk_4e5f6a7b",
+		Hash:      entity.HashAPIKey("srk_4e5f6a7b"),
+		Scopes:    []string{entity.ScopeAnalyze, entity.ScopeAdmin},
+		CreatedAt: now.Add(time.Second),
+	}
+
+	t.Run("get_missing_api_key", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.GetByHash(ctx, reader.Hash)
+		assert.Equal(t, repository.ErrAPIKeyNoResults, err)
+
+		found, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("add_and_get_api_keys", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, admin))
+		require.NoError(t, r.Add(ctx, reader))
+
+		found, err := r.GetByHash(ctx, admin.Hash)
+		assert.NoError(t, err)
+		assert.Equal(t, admin.ID, found.ID)
+		assert.Equal(t, admin.Name, found.Name)
+		assert.Equal(t, admin.Prefix, found.Prefix)
+		assert.Equal(t, admin.Scopes, found.Scopes)
+		assert.True(t, admin.CreatedAt.Equal(found.CreatedAt))
+
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, len(all)) {
+			assert.Equal(t, reader.ID, all[0].ID)
+			assert.Equal(t, admin.ID, all[1].ID)
+		}
+	})
+
+	t.Run("delete_api_key", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, reader))
+
+		assert.NoError(t, r.Delete(ctx, reader.ID))
+		assert.Equal(t, repository.ErrAPIKeyNoResults, r.Delete(ctx, reader.ID))
+
+		_, err := r.GetByHash(ctx, reader.Hash)
+		assert.Equal(t, repository.ErrAPIKeyNoResults, err)
+	})
+
+	t.Run("keys_are_listed_by_workspace", func(t *testing.T) {
+		r := newRepository(t)
+		member := reader
+		member.WorkspaceID = uuid.New()
+		require.NoError(t, r.Add(ctx, member))
+
+		found, err := r.GetByHash(ctx, member.Hash)
+		assert.NoError(t, err)
+		assert.Equal(t, member.WorkspaceID, found.WorkspaceID)
+
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+		assert.Equal(t, repository.ErrAPIKeyNoResults, r.Delete(ctx, member.ID))
+
+		all, err = r.FindAll(entity.WithWorkspace(ctx, member.WorkspaceID))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(all))
+	})
+}
+
+// WorkspaceRepository runs the conformance suite for a repository.WorkspaceRepository.
+func WorkspaceRepository(t *testing.T, newRepository func(t *testing.T) repository.WorkspaceRepository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	first := entity.Workspace{ID: uuid.New(), Name: "backend", WebhookSecret: "s3cr3t", CreatedAt: now}
+	second := entity.Workspace{ID: uuid.New(), Name: "frontend", CreatedAt: now.Add(time.Second)}
+
+	t.Run("get_missing_workspace", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.Get(ctx, uuid.New())
+		assert.Equal(t, repository.ErrWorkspaceNoResults, err)
+
+		found, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("add_and_get_workspaces", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, second))
+		require.NoError(t, r.Add(ctx, first))
+
+		found, err := r.Get(ctx, first.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, first.Name, found.Name)
+		assert.Equal(t, first.WebhookSecret, found.WebhookSecret)
+		assert.True(t, first.CreatedAt.Equal(found.CreatedAt))
+
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, len(all)) {
+			assert.Equal(t, first.ID, all[0].ID)
+			assert.Equal(t, second.ID, all[1].ID)
+		}
+	})
+}
+
+// JobRepository runs the conformance suite for a repository.JobRepository.
+func JobRepository(t *testing.T, newRepository func(t *testing.T) repository.JobRepository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Millisecond)
+	configured := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), AnalysisID: uuid.New(), Attempt: 1,
+		Status: entity.JobStatusRunning, Owner: "src-reader-1", DateStarted: now, DateRefreshed: now}
+	requested := entity.AnalysisJob{
+		ID:            uuid.New(),
+		ProjectID:     uuid.New(),
+		AnalysisID:    uuid.New(),
+		Pipeline:      &entity.Pipeline{Splitters: []string{"conserv"}, Rules: []string{}, IncludeTests: true},
+		Commit:        "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		Attempt:       2,
+		Status:        entity.JobStatusRunning,
+		Owner:         "src-reader-2",
+		DateStarted:   now.Add(time.Second),
+		DateRefreshed: now.Add(time.Second),
+	}
+
+	t.Run("find_missing_jobs", func(t *testing.T) {
+		r := newRepository(t)
+
+		found, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, found)
+		assert.Equal(t, repository.ErrJobNoResults, r.Delete(ctx, configured.ID))
+	})
+
+	t.Run("add_and_find_jobs", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, requested))
+		require.NoError(t, r.Add(ctx, configured))
+
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, len(all)) {
+			assert.Equal(t, configured.ID, all[0].ID)
+			assert.Equal(t, configured.ProjectID, all[0].ProjectID)
+			assert.Equal(t, configured.AnalysisID, all[0].AnalysisID)
+			assert.Nil(t, all[0].Pipeline)
+			assert.Empty(t, all[0].Commit)
+			assert.Equal(t, 1, all[0].Attempt)
+			assert.Equal(t, entity.JobStatusRunning, all[0].Status)
+			assert.Equal(t, "src-reader-1", all[0].Owner)
+			assert.True(t, configured.DateStarted.Equal(all[0].DateStarted))
+			assert.True(t, configured.DateRefreshed.Equal(all[0].DateRefreshed))
+
+			assert.Equal(t, requested.ID, all[1].ID)
+			if assert.NotNil(t, all[1].Pipeline) {
+				assert.Nil(t, all[1].Pipeline.Miners)
+				assert.Equal(t, []string{"conserv"}, all[1].Pipeline.Splitters)
+				assert.Equal(t, []string{}, all[1].Pipeline.Rules)
+				assert.True(t, all[1].Pipeline.IncludeTests)
+			}
+			assert.Equal(t, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52", all[1].Commit)
+			assert.Equal(t, 2, all[1].Attempt)
+		}
+	})
+
+	t.Run("refresh_owned_jobs", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, configured))
+
+		assert.NoError(t, r.Refresh(ctx, configured))
+		all, _ := r.FindAll(ctx)
+		if assert.Equal(t, 1, len(all)) {
+			assert.True(t, all[0].DateRefreshed.After(configured.DateRefreshed))
+		}
+
+		taken := configured
+		taken.Owner = "src-reader-2"
+		assert.Equal(t, repository.ErrJobNoResults, r.Refresh(ctx, taken))
+		assert.Equal(t, repository.ErrJobNoResults, r.Refresh(ctx, requested))
+	})
+
+	t.Run("claim_jobs_not_refreshed", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, configured))
+
+		claimed := configured
+		claimed.AnalysisID = uuid.New()
+		claimed.Attempt = 2
+		claimed.Owner = "src-reader-2"
+		claimed.DateRefreshed = now.Add(time.Minute)
+		assert.Equal(t, repository.ErrJobNoResults, r.Claim(ctx, claimed, now))
+		assert.NoError(t, r.Claim(ctx, claimed, now.Add(time.Second)))
+		// the job was already claimed for the second attempt
+		assert.Equal(t, repository.ErrJobNoResults, r.Claim(ctx, claimed, now.Add(time.Hour)))
+
+		all, _ := r.FindAll(ctx)
+		if assert.Equal(t, 1, len(all)) {
+			assert.Equal(t, claimed.AnalysisID, all[0].AnalysisID)
+			assert.Equal(t, 2, all[0].Attempt)
+			assert.Equal(t, "src-reader-2", all[0].Owner)
+			assert.True(t, claimed.DateRefreshed.Equal(all[0].DateRefreshed))
+		}
+		assert.Equal(t, repository.ErrJobNoResults, r.Refresh(ctx, configured))
+		assert.NoError(t, r.Refresh(ctx, claimed))
+	})
+
+	t.Run("fail_running_jobs", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, configured))
+
+		assert.NoError(t, r.Fail(ctx, configured.ID, "unexpected error"))
+		assert.Equal(t, repository.ErrJobNoResults, r.Fail(ctx, configured.ID, "unexpected error"))
+		assert.Equal(t, repository.ErrJobNoResults, r.Refresh(ctx, configured))
+
+		all, _ := r.FindAll(ctx)
+		if assert.Equal(t, 1, len(all)) {
+			assert.Equal(t, entity.JobStatusFailed, all[0].Status)
+			assert.Equal(t, "unexpected error", all[0].Error)
+		}
+	})
+
+	t.Run("delete_job", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.Add(ctx, configured))
+
+		assert.NoError(t, r.Delete(ctx, configured.ID))
+		assert.Equal(t, repository.ErrJobNoResults, r.Delete(ctx, configured.ID))
+
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+	})
+
+	t.Run("jobs_are_listed_by_workspace", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.WithWorkspace(ctx, uuid.New())
+		require.NoError(t, r.Add(other, configured))
+
+		all, err := r.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, all)
+		assert.Equal(t, repository.ErrJobNoResults, r.Delete(ctx, configured.ID))
+		assert.Equal(t, repository.ErrJobNoResults, r.Refresh(ctx, configured))
+		assert.Equal(t, repository.ErrJobNoResults, r.Fail(ctx, configured.ID, "unexpected error"))
+
+		all, err = r.FindAll(other)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(all))
+	})
+}
+
+// SearchIndexRepository runs the conformance suite for a repository.SearchIndexRepository.
+func SearchIndexRepository(t *testing.T, newRepository func(t *testing.T) repository.SearchIndexRepository) {
+	ctx := context.Background()
+	first, second := uuid.New(), uuid.New()
+	reader := entity.SearchDocument{
+		Result: entity.SearchResult{AnalysisID: first, ProjectRef: "eroatta/reader", IdentifierID: "main.go+++readCfg",
+			Name: "readCfg", Type: token.FUNC, Package: "main", File: "main.go", Position: token.Pos(10),
+			Normalization: "readConfig"},
+		Terms: map[string]float64{"readcfg": 3.0, "read": 2.0, "cfg": 2.0, "config": 1.5, "configuration": 1.0},
+	}
+	writer := entity.SearchDocument{
+		Result: entity.SearchResult{AnalysisID: second, ProjectRef: "eroatta/writer",
+			IdentifierID: "main.go+++writeCfg", Name: "writeCfg", Type: token.FUNC, Package: "main", File: "main.go",
+			Position: token.Pos(20)},
+		Terms: map[string]float64{"writecfg": 3.0, "write": 2.0, "cfg": 2.0, "config_": 1.0},
+	}
+
+	// documentOf retrieves the ID of the document holding the identifier with the given name.
+	documentOf := func(t *testing.T, r repository.SearchIndexRepository, ctx context.Context, name string) string {
+		postings, err := r.FindPostings(ctx, []string{"cfg"}, "")
+		require.NoError(t, err)
+		docs := make([]string, 0)
+		for id := range postings["cfg"] {
+			docs = append(docs, id)
+		}
+		results, err := r.FindDocuments(ctx, docs)
+		require.NoError(t, err)
+		for id, result := range results {
+			if result.Name == name {
+				return id
+			}
+		}
+		return ""
+	}
+
+	t.Run("find_on_empty_index", func(t *testing.T) {
+		r := newRepository(t)
+
+		terms, err := r.FindTerms(ctx, "c", 1, 0, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, terms)
+
+		postings, err := r.FindPostings(ctx, []string{"cfg"}, "")
+		assert.NoError(t, err)
+		assert.Empty(t, postings)
+		assert.Equal(t, repository.ErrSearchNoResults, r.DeleteAllByAnalysisID(ctx, first))
+	})
+
+	t.Run("add_and_find_documents", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+
+		postings, err := r.FindPostings(ctx, []string{"cfg", "read", "missing"}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(postings))
+		assert.Equal(t, 2, len(postings["cfg"]))
+		if assert.Equal(t, 1, len(postings["read"])) {
+			for id, weight := range postings["read"] {
+				assert.Equal(t, 2.0, weight)
+				assert.Equal(t, 2.0, postings["cfg"][id])
+
+				results, err := r.FindDocuments(ctx, []string{id, "missing"})
+				assert.NoError(t, err)
+				assert.Equal(t, map[string]entity.SearchResult{id: reader.Result}, results)
+			}
+		}
+	})
+
+	t.Run("find_terms_by_prefix_and_length", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+
+		terms, err := r.FindTerms(ctx, "c", 1, 0, 0)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"cfg", "config", "config_", "configuration"}, terms)
+
+		terms, err = r.FindTerms(ctx, "c", 4, 7, 0)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"config", "config_"}, terms)
+
+		// wildcards on the prefix are matched literally
+		terms, err = r.FindTerms(ctx, "config_", 1, 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"config_"}, terms)
+
+		terms, err = r.FindTerms(ctx, "C", 1, 0, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, terms)
+	})
+
+	t.Run("replace_documents_by_analysis", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+
+		replacement := reader
+		replacement.Result.Name = "loadCfg"
+		replacement.Terms = map[string]float64{"loadcfg": 3.0, "load": 2.0, "cfg": 2.0}
+		require.NoError(t, r.ReplaceAll(ctx, first, batches([]entity.SearchDocument{replacement})))
+
+		assert.NotEmpty(t, documentOf(t, r, ctx, "loadCfg"))
+		assert.NotEmpty(t, documentOf(t, r, ctx, "writeCfg"))
+		assert.Empty(t, documentOf(t, r, ctx, "readCfg"))
+
+		terms, err := r.FindTerms(ctx, "read", 1, 0, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, terms)
+	})
+
+	t.Run("replace_documents_in_batches", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+
+		loader := reader
+		loader.Result.Name = "loadCfg"
+		loader.Terms = map[string]float64{"loadcfg": 3.0, "load": 2.0, "cfg": 2.0}
+		require.NoError(t, r.ReplaceAll(ctx, first,
+			batches([]entity.SearchDocument{reader}, []entity.SearchDocument{loader})))
+
+		assert.NotEmpty(t, documentOf(t, r, ctx, "readCfg"))
+		assert.NotEmpty(t, documentOf(t, r, ctx, "loadCfg"))
+		assert.NotEmpty(t, documentOf(t, r, ctx, "writeCfg"))
+	})
+
+	t.Run("failed_replacement_keeps_previous_documents", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+
+		replacement := reader
+		replacement.Result.Name = "loadCfg"
+		replacement.Terms = map[string]float64{"loadcfg": 3.0, "load": 2.0, "cfg": 2.0}
+		it := batches([]entity.SearchDocument{replacement})
+		it.err = errors.New("unexpected error")
+		assert.Error(t, r.ReplaceAll(ctx, first, it))
+
+		assert.NotEmpty(t, documentOf(t, r, ctx, "readCfg"))
+		assert.Empty(t, documentOf(t, r, ctx, "loadCfg"))
+	})
+
+	t.Run("find_terms_up_to_a_limit", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+
+		terms, err := r.FindTerms(ctx, "c", 1, 0, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"cfg", "config"}, terms)
+	})
+
+	t.Run("find_postings_by_project", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+
+		postings, err := r.FindPostings(ctx, []string{"cfg", "write"}, "eroatta/reader")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(postings))
+		if assert.Equal(t, 1, len(postings["cfg"])) {
+			for id := range postings["cfg"] {
+				results, err := r.FindDocuments(ctx, []string{id})
+				assert.NoError(t, err)
+				assert.Equal(t, "readCfg", results[id].Name)
+			}
+		}
+	})
+
+	t.Run("delete_documents_by_analysis", func(t *testing.T) {
+		r := newRepository(t)
+		require.NoError(t, r.AddAll(ctx, []entity.SearchDocument{reader, writer}))
+		id := documentOf(t, r, ctx, "readCfg")
+
+		assert.NoError(t, r.DeleteAllByAnalysisID(ctx, first))
+		assert.Equal(t, repository.ErrSearchNoResults, r.DeleteAllByAnalysisID(ctx, first))
+
+		results, err := r.FindDocuments(ctx, []string{id})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+
+		postings, err := r.FindPostings(ctx, []string{"cfg", "read"}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(postings))
+		assert.Equal(t, 1, len(postings["cfg"]))
+	})
+
+	t.Run("documents_are_scoped_by_workspace", func(t *testing.T) {
+		r := newRepository(t)
+		other := entity.WithWorkspace(ctx, uuid.New())
+		require.NoError(t, r.AddAll(other, []entity.SearchDocument{reader}))
+		id := documentOf(t, r, other, "readCfg")
+
+		terms, err := r.FindTerms(ctx, "c", 1, 0, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, terms)
+
+		results, err := r.FindDocuments(ctx, []string{id})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, repository.ErrSearchNoResults, r.DeleteAllByAnalysisID(ctx, first))
+
+		assert.NoError(t, r.DeleteAllByAnalysisID(other, first))
+	})
+}
+
+// documentBatches implements repository.SearchDocumentIterator over the given batches, stopping with err once
+// they are exhausted, if set.
+type documentBatches struct {
+	batches [][]entity.SearchDocument
+	current []entity.SearchDocument
+	err     error
+}
+
+func batches(docs ...[]entity.SearchDocument) *documentBatches {
+	return &documentBatches{batches: docs}
+}
+
+func (b *documentBatches) Next(ctx context.Context) bool {
+	if len(b.batches) == 0 {
+		return false
+	}
+
+	b.current, b.batches = b.batches[0], b.batches[1:]
+	return true
+}
+
+func (b *documentBatches) Documents() []entity.SearchDocument {
+	return b.current
+}
+
+func (b *documentBatches) Err() error {
+	if len(b.batches) > 0 {
+		return nil
+	}
+
+	return b.err
+}
+
+func newIdentifier(file string, name string) entity.Identifier {
+	return entity.Identifier{
+		ID:         "filename:" + file + "+++pkg:main+++declType:func+++name:" + name,
+		Package:    "main",
+		File:       file,
+		Name:       name,
+		Splits:     map[string][]entity.Split{"conserv": {{Order: 1, Value: name}}},
+		Expansions: map[string][]entity.Expansion{},
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
 	"github.com/eroatta/src-reader/repository"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/src-d/go-billy.v4"
 	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
 )
 
 // NewGogitSourceCodeRepository creates a new instance of SourceCodeRepository that clones source code
@@ -41,20 +45,25 @@ func PlainClonerFunc(ctx context.Context, path string, url string) (*git.Reposit
 }
 
 // Clone clones the source code, under a given name, using the provided clone URL, and stores the files on the
-// OS folder.
+// OS folder of the workspace set on the context, so the same repository imported on several workspaces is cloned
+// once for each of them. The time spent cloning and the size of the clone are observed.
 func (r GogitSourceCodeRepository) Clone(ctx context.Context, fullname string, cloneURL string) (entity.SourceCode, error) {
-	path := fmt.Sprintf("%s/%s", r.baseDir, fullname)
+	path := fmt.Sprintf("%s/%s/%s", r.baseDir, entity.WorkspaceFrom(ctx), fullname)
 	err := os.MkdirAll(path, os.ModePerm)
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("failed to create directory %s", path))
 		return entity.SourceCode{}, repository.ErrSourceCodeUnableCreateDestination
 	}
 
+	start := time.Now()
 	cloned, err := r.clonerFunc(ctx, path, cloneURL)
 	if err != nil {
+		metrics.CloneDuration.WithLabelValues(metrics.ResultError).Observe(time.Since(start).Seconds())
 		log.WithError(err).Error(fmt.Sprintf("failed to clone repository %s into %s", cloneURL, path))
 		return entity.SourceCode{}, repository.ErrSourceCodeUnableCloneRemoteRepository
 	}
+	metrics.CloneDuration.WithLabelValues(metrics.ResultSuccess).Observe(time.Since(start).Seconds())
+	metrics.CloneSize.Observe(float64(size(path)))
 
 	ref, err := cloned.Head()
 	if err != nil {
@@ -82,6 +91,65 @@ func (r GogitSourceCodeRepository) Clone(ctx context.Context, fullname string, c
 	}, nil
 }
 
+// size retrieves the size of the files under the given directory, including the Git metadata. Files that can't
+// be accessed are left out.
+func size(dir string) int64 {
+	var total int64
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+
+	return total
+}
+
+// Update fetches the latest changes from the origin remote for the source code stored on the OS location
+// folder, and checks out the given revision.
+func (r GogitSourceCodeRepository) Update(ctx context.Context, location string, hash string) (entity.SourceCode, error) {
+	if !strings.HasPrefix(location, r.baseDir) {
+		return entity.SourceCode{}, repository.ErrSourceCodeNotFound
+	}
+
+	cloned, err := git.PlainOpen(location)
+	if err != nil {
+		log.WithError(err).Error(fmt.Sprintf("failed to open repository on %s", location))
+		return entity.SourceCode{}, repository.ErrSourceCodeNotFound
+	}
+
+	err = cloned.FetchContext(ctx, &git.FetchOptions{RemoteName: git.DefaultRemoteName})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		log.WithError(err).Error(fmt.Sprintf("failed to fetch changes for repository on %s", location))
+		return entity.SourceCode{}, repository.ErrSourceCodeUnableUpdate
+	}
+
+	wt, err := cloned.Worktree()
+	if err != nil {
+		log.WithError(err).Error(fmt.Sprintf("failed to access worktree on repository %s", location))
+		return entity.SourceCode{}, repository.ErrSourceCodeUnableAccessMetadata
+	}
+
+	err = wt.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(hash), Force: true})
+	if err != nil {
+		log.WithError(err).Error(fmt.Sprintf("failed to checkout %s on repository %s", hash, location))
+		return entity.SourceCode{}, repository.ErrSourceCodeUnableUpdate
+	}
+
+	files, err := read(wt.Filesystem, "")
+	if err != nil {
+		log.WithError(err).Error(fmt.Sprintf("failed to get filenames on repository %s", location))
+		return entity.SourceCode{}, repository.ErrSourceCodeUnableAccessMetadata
+	}
+
+	return entity.SourceCode{
+		Hash:     hash,
+		Location: location,
+		Files:    files,
+	}, nil
+}
+
+// read lists the files under the given directory, leaving out the hidden files and the vendored dependencies.
 func read(fs billy.Filesystem, rootDir string) ([]string, error) {
 	files, err := fs.ReadDir(rootDir)
 	if err != nil {
@@ -90,7 +158,7 @@ func read(fs billy.Filesystem, rootDir string) ([]string, error) {
 
 	names := make([]string, 0)
 	for _, file := range files {
-		if strings.HasPrefix(file.Name(), ".") {
+		if strings.HasPrefix(file.Name(), ".") || (file.IsDir() && file.Name() == "vendor") {
 			continue
 		}
 
@@ -145,3 +213,19 @@ func (r GogitSourceCodeRepository) Read(ctx context.Context, location string, fi
 
 	return raw, nil
 }
+
+// Size retrieves the size of a file on the provided location, stored on the OS filesystem, without reading it.
+func (r GogitSourceCodeRepository) Size(ctx context.Context, location string, filename string) (int64, error) {
+	if !strings.HasPrefix(location, r.baseDir) {
+		return 0, repository.ErrSourceCodeUnableReadFile
+	}
+
+	path := fmt.Sprintf("%s/%s", location, filename)
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		log.WithError(err).Error(fmt.Sprintf("unable to access file %s", path))
+		return 0, repository.ErrSourceCodeUnableReadFile
+	}
+
+	return info.Size(), nil
+}
@@ -8,12 +8,16 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/src-d/go-billy.v4/memfs"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 	"gopkg.in/src-d/go-git.v4/storage/memory"
 )
 
@@ -31,14 +35,18 @@ func TestClone_OnGogitSourceCodeRepository_WhenUnableToCreateDestinationPath_Sho
 	}
 	defer os.RemoveAll(tmpDir)
 
-	tmpFile, err := ioutil.TempFile(tmpDir, "")
+	workspaceDir := fmt.Sprintf("%s/%s", tmpDir, entity.DefaultWorkspaceID)
+	if err := os.Mkdir(workspaceDir, os.ModePerm); err != nil {
+		assert.FailNow(t, "unexpected error creating workspace folder", err)
+	}
+	tmpFile, err := ioutil.TempFile(workspaceDir, "")
 	if err != nil {
 		assert.FailNow(t, "unexpected error creating temp folder", err)
 	}
 	defer os.Remove(tmpFile.Name())
 
 	sourceCodeRepository := NewGogitSourceCodeRepository(tmpDir, nil)
-	existingFilename := strings.ReplaceAll(tmpFile.Name(), fmt.Sprintf("%s/", tmpDir), "")
+	existingFilename := strings.ReplaceAll(tmpFile.Name(), fmt.Sprintf("%s/", workspaceDir), "")
 
 	sourceCode, err := sourceCodeRepository.Clone(context.TODO(), existingFilename, "clone_url")
 
@@ -119,7 +127,7 @@ func TestClone_OnGogitSourceCodeRepository_ShouldReturnSourceCode(t *testing.T)
 
 	// a cloned repository, including HEAD ref
 	fs := memfs.New()
-	files := []string{"main.go", "file.go", "file_test.go", "README.md", ".gitignore"}
+	files := []string{"main.go", "file.go", "file_test.go", "README.md", ".gitignore", "vendor/errors/errors.go"}
 	for _, name := range files {
 		_, err = fs.Create(name)
 		if err != nil {
@@ -138,14 +146,132 @@ func TestClone_OnGogitSourceCodeRepository_ShouldReturnSourceCode(t *testing.T)
 	}
 	sourceCodeRepository := NewGogitSourceCodeRepository(tmpDir, clonerFunc)
 
-	sourceCode, err := sourceCodeRepository.Clone(context.TODO(), "eroatta/testrepo", "clone_url")
+	workspaceID := uuid.New()
+	ctx := entity.WithWorkspace(context.TODO(), workspaceID)
+	sourceCode, err := sourceCodeRepository.Clone(ctx, "eroatta/testrepo", "clone_url")
 
 	assert.NoError(t, err)
 	assert.Equal(t, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52", sourceCode.Hash)
-	assert.Equal(t, fmt.Sprintf("%s/eroatta/testrepo", tmpDir), sourceCode.Location)
+	assert.Equal(t, fmt.Sprintf("%s/%s/eroatta/testrepo", tmpDir, workspaceID), sourceCode.Location)
 	assert.ElementsMatch(t, []string{"main.go", "file.go", "file_test.go", "README.md"}, sourceCode.Files)
 }
 
+func TestSize_ShouldSumFilesUnderDirectory(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "test-size")
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating temp folder", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	_ = os.MkdirAll(tmpDir+"/.git/objects", os.ModePerm)
+	_ = ioutil.WriteFile(tmpDir+"/main.go", []byte("package main"), 0644)
+	_ = ioutil.WriteFile(tmpDir+"/.git/objects/pack", make([]byte, 1024), 0644)
+
+	assert.Equal(t, int64(1036), size(tmpDir))
+	assert.Equal(t, int64(0), size(tmpDir+"/missing"))
+}
+
+func TestUpdate_OnGogitSourceCodeRepository_WithNonSharedBaseDir_ShouldReturnError(t *testing.T) {
+	sourceCodeRepository := NewGogitSourceCodeRepository("/tmp/mydir", nil)
+
+	sourceCode, err := sourceCodeRepository.Update(context.TODO(), "/tmp/another/dir", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, repository.ErrSourceCodeNotFound.Error())
+	assert.Empty(t, sourceCode)
+}
+
+func TestUpdate_OnGogitSourceCodeRepository_WithNoExistingRepository_ShouldReturnError(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "test-update-missing")
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating temp folder", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	sourceCodeRepository := NewGogitSourceCodeRepository(tmpDir, nil)
+
+	sourceCode, err := sourceCodeRepository.Update(context.TODO(), fmt.Sprintf("%s/eroatta/testrepo", tmpDir),
+		"bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, repository.ErrSourceCodeNotFound.Error())
+	assert.Empty(t, sourceCode)
+}
+
+func TestUpdate_OnGogitSourceCodeRepository_WithUnknownRevision_ShouldReturnError(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "test-update-unknown")
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating temp folder", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origin := fmt.Sprintf("%s/origin", tmpDir)
+	commit(t, origin, "main.go")
+	location := fmt.Sprintf("%s/eroatta/testrepo", tmpDir)
+	if _, err := git.PlainClone(location, false, &git.CloneOptions{URL: origin}); err != nil {
+		assert.FailNow(t, "unexpected error cloning repository", err)
+	}
+
+	sourceCodeRepository := NewGogitSourceCodeRepository(tmpDir, nil)
+
+	sourceCode, err := sourceCodeRepository.Update(context.TODO(), location, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, repository.ErrSourceCodeUnableUpdate.Error())
+	assert.Empty(t, sourceCode)
+}
+
+func TestUpdate_OnGogitSourceCodeRepository_ShouldReturnSourceCodeAtRevision(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "test-update-success")
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating temp folder", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	origin := fmt.Sprintf("%s/origin", tmpDir)
+	commit(t, origin, "main.go")
+	location := fmt.Sprintf("%s/eroatta/testrepo", tmpDir)
+	if _, err := git.PlainClone(location, false, &git.CloneOptions{URL: origin}); err != nil {
+		assert.FailNow(t, "unexpected error cloning repository", err)
+	}
+	hash := commit(t, origin, "file.go")
+
+	sourceCodeRepository := NewGogitSourceCodeRepository(tmpDir, nil)
+
+	sourceCode, err := sourceCodeRepository.Update(context.TODO(), location, hash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, hash, sourceCode.Hash)
+	assert.Equal(t, location, sourceCode.Location)
+	assert.ElementsMatch(t, []string{"main.go", "file.go"}, sourceCode.Files)
+}
+
+// commit adds a new file to the repository on the given path, initializing it if needed, and returns
+// the hash of the created commit.
+func commit(t *testing.T, path string, filename string) string {
+	rep, err := git.PlainOpen(path)
+	if err == git.ErrRepositoryNotExists {
+		rep, err = git.PlainInit(path, false)
+	}
+	if err != nil {
+		assert.FailNow(t, "unexpected error opening repository", err)
+	}
+
+	if err := ioutil.WriteFile(fmt.Sprintf("%s/%s", path, filename), []byte("package main"), 0666); err != nil {
+		assert.FailNow(t, "unexpected error creating file", err)
+	}
+
+	wt, _ := rep.Worktree()
+	if _, err := wt.Add(filename); err != nil {
+		assert.FailNow(t, "unexpected error adding file", err)
+	}
+	hash, err := wt.Commit(fmt.Sprintf("add %s", filename), &git.CommitOptions{
+		Author: &object.Signature{Name: "eroatta", Email: "eroatta@example.com", When: time.Now()},
+	})
+	if err != nil {
+		assert.FailNow(t, "unexpected error committing file", err)
+	}
+
+	return hash.String()
+}
+
 func TestRemove_OnGogitSourceCodeRepository_WithNonSharedBaseDir_ShouldReturnError(t *testing.T) {
 	sourceCodeRepository := NewGogitSourceCodeRepository("/tmp/mydir", nil)
 	err := sourceCodeRepository.Remove(context.TODO(), "/tmp/another/dir")
@@ -203,3 +329,38 @@ func TestRead_OnGogitSourceCodeRepository_WithExistingFile_ShouldReturnBytes(t *
 	assert.NoError(t, err)
 	assert.EqualValues(t, []byte("test"), rawFile)
 }
+
+func TestSize_OnGogitSourceCodeRepository_WithNonSharedBaseDir_ShouldReturnError(t *testing.T) {
+	sourceCodeRepository := NewGogitSourceCodeRepository("/tmp/mydir", nil)
+	size, err := sourceCodeRepository.Size(context.TODO(), "/tmp/another/dir", "file.go")
+
+	assert.EqualError(t, err, repository.ErrSourceCodeUnableReadFile.Error())
+	assert.Zero(t, size)
+}
+
+func TestSize_OnGogitSourceCodeRepository_WithNoExistingFile_ShouldReturnError(t *testing.T) {
+	sourceCodeRepository := NewGogitSourceCodeRepository("/tmp/mydir", nil)
+	size, err := sourceCodeRepository.Size(context.TODO(), "/tmp/mydir", "file.go")
+
+	assert.EqualError(t, err, repository.ErrSourceCodeUnableReadFile.Error())
+	assert.Zero(t, size)
+}
+
+func TestSize_OnGogitSourceCodeRepository_WithExistingFile_ShouldReturnSize(t *testing.T) {
+	tmpDir, err := ioutil.TempDir(os.TempDir(), "test-size")
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating temp folder", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = ioutil.WriteFile(fmt.Sprintf("%s/file.go", tmpDir), []byte("package main"), 0666)
+	if err != nil {
+		assert.FailNow(t, "unexpected error creating temp file", err)
+	}
+
+	sourceCodeRepository := NewGogitSourceCodeRepository(tmpDir, nil)
+	size, err := sourceCodeRepository.Size(context.TODO(), tmpDir, "file.go")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), size)
+}
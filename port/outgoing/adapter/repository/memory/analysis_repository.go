@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryAnalysisRepository represents a In Memory database, focused on handling analysis results as memory elements.
+type InMemoryAnalysisRepository struct {
+	mu       sync.RWMutex
+	analysis map[uuid.UUID]entity.AnalysisResults
+	// workspaces holds the workspace for each analysis.
+	workspaces map[uuid.UUID]uuid.UUID
+}
+
+// NewInMemoryAnalysisRepository creates a repository.AnalysisRepository backed up by memory storage.
+func NewInMemoryAnalysisRepository() *InMemoryAnalysisRepository {
+	return &InMemoryAnalysisRepository{
+		analysis:   make(map[uuid.UUID]entity.AnalysisResults),
+		workspaces: make(map[uuid.UUID]uuid.UUID),
+	}
+}
+
+// Add stores an AnalysisResults entity into the underlying in memory storage.
+func (r *InMemoryAnalysisRepository) Add(ctx context.Context, analysis entity.AnalysisResults) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.analysis[analysis.ID] = analysis
+	r.workspaces[analysis.ID] = entity.WorkspaceFrom(ctx)
+	return nil
+}
+
+// Get retrieves an existing analysis using its ID.
+func (r *InMemoryAnalysisRepository) Get(ctx context.Context, id uuid.UUID) (entity.AnalysisResults, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	analysis, ok := r.analysis[id]
+	if !ok || !r.visible(ctx, id) {
+		return entity.AnalysisResults{}, repository.ErrAnalysisNoResults
+	}
+
+	return analysis, nil
+}
+
+// GetByProjectID retrieves an existing analysis for the given Project.
+func (r *InMemoryAnalysisRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, analysis := range r.analysis {
+		if analysis.ProjectID == projectID && r.visible(ctx, analysis.ID) {
+			return analysis, nil
+		}
+	}
+
+	return entity.AnalysisResults{}, repository.ErrAnalysisNoResults
+}
+
+// Delete removes an existing analysis from the underlying in memory storage.
+func (r *InMemoryAnalysisRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.analysis[id]; !ok || !r.visible(ctx, id) {
+		return repository.ErrAnalysisNoResults
+	}
+	delete(r.analysis, id)
+	delete(r.workspaces, id)
+
+	return nil
+}
+
+// visible checks if the given analysis belongs to the workspace on the context.
+func (r *InMemoryAnalysisRepository) visible(ctx context.Context, ID uuid.UUID) bool {
+	return r.workspaces[ID] == entity.WorkspaceFrom(ctx)
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryAPIKeyRepository represents a In Memory database, focused on handling API keys as memory elements.
+type InMemoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[uuid.UUID]entity.APIKey
+}
+
+// NewInMemoryAPIKeyRepository creates a repository.APIKeyRepository backed up by memory storage.
+func NewInMemoryAPIKeyRepository() *InMemoryAPIKeyRepository {
+	return &InMemoryAPIKeyRepository{
+		keys: make(map[uuid.UUID]entity.APIKey),
+	}
+}
+
+// Add stores an APIKey entity into the underlying in memory storage.
+func (r *InMemoryAPIKeyRepository) Add(ctx context.Context, key entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys[key.ID] = key
+	return nil
+}
+
+// GetByHash finds the existing APIKey matching the given hash, on any workspace.
+func (r *InMemoryAPIKeyRepository) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+
+	return entity.APIKey{}, repository.ErrAPIKeyNoResults
+}
+
+// FindAll retrieves every existing APIKey on the workspace, sorted by creation time.
+func (r *InMemoryAPIKeyRepository) FindAll(ctx context.Context) ([]entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]entity.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		if key.WorkspaceID == entity.WorkspaceFrom(ctx) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
+
+// Delete removes an existing APIKey on the workspace from the underlying in memory storage.
+func (r *InMemoryAPIKeyRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key, ok := r.keys[ID]; !ok || key.WorkspaceID != entity.WorkspaceFrom(ctx) {
+		return repository.ErrAPIKeyNoResults
+	}
+	delete(r.keys, ID)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/conformance"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/memory"
+	"github.com/eroatta/src-reader/repository"
+)
+
+func TestConformance_OnInMemoryProjectRepository(t *testing.T) {
+	conformance.ProjectRepository(t, func(t *testing.T) repository.ProjectRepository {
+		return memory.NewInMemoryProjectRepository()
+	})
+}
+
+func TestConformance_OnInMemoryProjectRepository_Query(t *testing.T) {
+	conformance.ProjectQuery(t, func(t *testing.T) (repository.ProjectRepository, repository.InsightRepository) {
+		insights := memory.NewInMemoryInsightRepository()
+		return memory.NewInMemoryProjectRepositoryWithInsights(insights), insights
+	})
+}
+
+func TestConformance_OnInMemoryAnalysisRepository(t *testing.T) {
+	conformance.AnalysisRepository(t, func(t *testing.T) repository.AnalysisRepository {
+		return memory.NewInMemoryAnalysisRepository()
+	})
+}
+
+func TestConformance_OnInMemoryIdentifierRepository(t *testing.T) {
+	conformance.IdentifierRepository(t, func(t *testing.T) repository.IdentifierRepository {
+		return memory.NewInMemoryIdentifierRepository()
+	})
+}
+
+func TestConformance_OnInMemoryInsightRepository(t *testing.T) {
+	conformance.InsightRepository(t, func(t *testing.T) repository.InsightRepository {
+		return memory.NewInMemoryInsightRepository()
+	})
+}
+
+func TestConformance_OnInMemoryDictionaryRepository(t *testing.T) {
+	conformance.DictionaryRepository(t, func(t *testing.T) repository.DictionaryRepository {
+		return memory.NewInMemoryDictionaryRepository()
+	})
+}
+
+func TestConformance_OnInMemoryAPIKeyRepository(t *testing.T) {
+	conformance.APIKeyRepository(t, func(t *testing.T) repository.APIKeyRepository {
+		return memory.NewInMemoryAPIKeyRepository()
+	})
+}
+
+func TestConformance_OnInMemoryWorkspaceRepository(t *testing.T) {
+	conformance.WorkspaceRepository(t, func(t *testing.T) repository.WorkspaceRepository {
+		return memory.NewInMemoryWorkspaceRepository()
+	})
+}
+
+func TestConformance_OnInMemoryJobRepository(t *testing.T) {
+	conformance.JobRepository(t, func(t *testing.T) repository.JobRepository {
+		return memory.NewInMemoryJobRepository()
+	})
+}
+
+func TestConformance_OnInMemorySearchIndexRepository(t *testing.T) {
+	conformance.SearchIndexRepository(t, func(t *testing.T) repository.SearchIndexRepository {
+		return memory.NewInMemorySearchIndexRepository()
+	})
+}
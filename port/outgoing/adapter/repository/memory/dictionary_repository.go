@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryDictionaryRepository represents a In Memory database, focused on handling dictionaries as memory elements.
+type InMemoryDictionaryRepository struct {
+	mu           sync.RWMutex
+	dictionaries map[uuid.UUID]entity.Dictionary
+	// workspaces holds the workspace for each dictionary.
+	workspaces map[uuid.UUID]uuid.UUID
+}
+
+// NewInMemoryDictionaryRepository creates a repository.DictionaryRepository backed up by memory storage.
+func NewInMemoryDictionaryRepository() *InMemoryDictionaryRepository {
+	return &InMemoryDictionaryRepository{
+		dictionaries: make(map[uuid.UUID]entity.Dictionary),
+		workspaces:   make(map[uuid.UUID]uuid.UUID),
+	}
+}
+
+// Add stores a Dictionary entity into the underlying in memory storage.
+func (r *InMemoryDictionaryRepository) Add(ctx context.Context, dict entity.Dictionary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dictionaries[dict.ID] = dict
+	r.workspaces[dict.ID] = entity.WorkspaceFrom(ctx)
+	return nil
+}
+
+// Get finds an existing Dictionary by ID.
+func (r *InMemoryDictionaryRepository) Get(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dict, ok := r.dictionaries[ID]
+	if !ok || !r.visible(ctx, ID) {
+		return entity.Dictionary{}, repository.ErrDictionaryNoResults
+	}
+
+	return dict, nil
+}
+
+// GetByScope finds the existing Dictionary defined for the given scope and project reference.
+func (r *InMemoryDictionaryRepository) GetByScope(ctx context.Context, scope string, projectRef string) (entity.Dictionary, error) {
+	if scope == entity.DictionaryScopeOrganization {
+		projectRef = ""
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, dict := range r.dictionaries {
+		if dict.Scope == scope && dict.ProjectRef == projectRef && r.visible(ctx, dict.ID) {
+			return dict, nil
+		}
+	}
+
+	return entity.Dictionary{}, repository.ErrDictionaryNoResults
+}
+
+// FindAll retrieves every existing Dictionary, sorted by creation time.
+func (r *InMemoryDictionaryRepository) FindAll(ctx context.Context) ([]entity.Dictionary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dicts := make([]entity.Dictionary, 0, len(r.dictionaries))
+	for _, dict := range r.dictionaries {
+		if r.visible(ctx, dict.ID) {
+			dicts = append(dicts, dict)
+		}
+	}
+	sort.Slice(dicts, func(i, j int) bool {
+		return dicts[i].CreatedAt.Before(dicts[j].CreatedAt)
+	})
+
+	return dicts, nil
+}
+
+// Update replaces an existing Dictionary on the underlying in memory storage.
+func (r *InMemoryDictionaryRepository) Update(ctx context.Context, dict entity.Dictionary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dictionaries[dict.ID]; !ok || !r.visible(ctx, dict.ID) {
+		return repository.ErrDictionaryNoResults
+	}
+	r.dictionaries[dict.ID] = dict
+
+	return nil
+}
+
+// Delete removes an existing Dictionary from the underlying in memory storage.
+func (r *InMemoryDictionaryRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dictionaries[ID]; !ok || !r.visible(ctx, ID) {
+		return repository.ErrDictionaryNoResults
+	}
+	delete(r.dictionaries, ID)
+	delete(r.workspaces, ID)
+
+	return nil
+}
+
+// visible checks if the given dictionary belongs to the workspace on the context.
+func (r *InMemoryDictionaryRepository) visible(ctx context.Context, ID uuid.UUID) bool {
+	return r.workspaces[ID] == entity.WorkspaceFrom(ctx)
+}
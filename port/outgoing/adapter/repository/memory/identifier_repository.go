@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryIdentifierRepository represents a In Memory database, focused on handling identifiers as memory elements.
+// Identifiers are staged when added, and they are retrieved only after being committed.
+type InMemoryIdentifierRepository struct {
+	mu      sync.RWMutex
+	records []identifierRecord
+	marks   map[stagingKey]time.Time
+	seq     int64
+}
+
+// stagingKey identifies the in progress mark of an analysis on a workspace.
+type stagingKey struct {
+	workspace  uuid.UUID
+	analysisID uuid.UUID
+}
+
+// identifierRecord holds an identifier along with its staging status, and the sequence number used to sort it.
+type identifierRecord struct {
+	ident     entity.Identifier
+	workspace uuid.UUID
+	staged    bool
+	createdAt time.Time
+	seq       int64
+}
+
+// NewInMemoryIdentifierRepository creates a repository.IdentifierRepository backed up by memory storage.
+func NewInMemoryIdentifierRepository() *InMemoryIdentifierRepository {
+	return &InMemoryIdentifierRepository{
+		records: make([]identifierRecord, 0),
+		marks:   make(map[stagingKey]time.Time),
+	}
+}
+
+// Add stages an Identifier entity into the underlying in memory storage.
+func (r *InMemoryIdentifierRepository) Add(ctx context.Context, analysis entity.AnalysisResults, ident entity.Identifier) error {
+	return r.AddAll(ctx, analysis, []entity.Identifier{ident})
+}
+
+// AddAll stages a set of Identifier entities into the underlying in memory storage.
+func (r *InMemoryIdentifierRepository) AddAll(ctx context.Context, analysis entity.AnalysisResults, idents []entity.Identifier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	workspace := entity.WorkspaceFrom(ctx)
+	for _, ident := range idents {
+		ident.AnalysisID = analysis.ID
+		ident.ProjectRef = analysis.ProjectName
+		r.seq++
+		r.records = append(r.records, identifierRecord{ident: ident, workspace: workspace, staged: true,
+			createdAt: now, seq: r.seq})
+	}
+
+	return nil
+}
+
+// FindAllByAnalysisID retrieves all the committed identifiers related to a given analysis.
+func (r *InMemoryIdentifierRepository) FindAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Identifier, error) {
+	return r.find(ctx, func(ident entity.Identifier) bool {
+		return ident.AnalysisID == analysisID
+	}), nil
+}
+
+// IterateByAnalysisID retrieves an iterator over the committed identifiers related to a given analysis.
+func (r *InMemoryIdentifierRepository) IterateByAnalysisID(ctx context.Context, analysisID uuid.UUID) (repository.IdentifierIterator, error) {
+	idents, _ := r.FindAllByAnalysisID(ctx, analysisID)
+	return &identifierIterator{idents: idents, pos: -1}, nil
+}
+
+// QueryByAnalysisID retrieves a page of the committed identifiers related to a given analysis, matching and sorted
+// by the given query.
+func (r *InMemoryIdentifierRepository) QueryByAnalysisID(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error) {
+	less := func(a, b identifierRecord) bool {
+		scoreA, scoreB := a.ident.Normalization.Score, b.ident.Normalization.Score
+		switch {
+		case query.Sort == entity.IdentifierSortScore && scoreA != scoreB:
+			return scoreA < scoreB
+		case query.Sort == entity.IdentifierSortScoreDesc && scoreA != scoreB:
+			return scoreA > scoreB
+		}
+		return a.seq < b.seq
+	}
+
+	var after *identifierRecord
+	if query.Cursor != "" {
+		cursor, err := repository.DecodeIdentifierCursor(query.Cursor)
+		if err != nil {
+			return entity.IdentifierPage{}, err
+		}
+
+		seq, err := strconv.ParseInt(cursor.Key, 10, 64)
+		if err != nil {
+			return entity.IdentifierPage{}, repository.ErrIdentifierInvalidCursor
+		}
+		after = &identifierRecord{ident: entity.Identifier{Normalization: entity.Normalization{Score: cursor.Score}}, seq: seq}
+	}
+
+	workspace := entity.WorkspaceFrom(ctx)
+	r.mu.RLock()
+	records := make([]identifierRecord, 0)
+	for _, record := range r.records {
+		if record.staged || record.workspace != workspace || record.ident.AnalysisID != analysisID ||
+			!query.Match(record.ident) {
+			continue
+		}
+
+		if after != nil && !less(*after, record) {
+			continue
+		}
+		records = append(records, record)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return less(records[i], records[j])
+	})
+
+	page := entity.IdentifierPage{Identifiers: make([]entity.Identifier, 0)}
+	for i, record := range records {
+		if query.Limit > 0 && i == query.Limit {
+			last := records[i-1]
+			page.NextCursor = repository.IdentifierCursor{
+				Score: last.ident.Normalization.Score,
+				Key:   strconv.FormatInt(last.seq, 10),
+			}.Encode()
+			break
+		}
+		page.Identifiers = append(page.Identifiers, record.ident)
+	}
+
+	return page, nil
+}
+
+// FindAllByProjectAndFile retrieves all the committed identifiers for a file related to a given project.
+func (r *InMemoryIdentifierRepository) FindAllByProjectAndFile(ctx context.Context, projectRef string, filename string) ([]entity.Identifier, error) {
+	return r.find(ctx, func(ident entity.Identifier) bool {
+		return ident.ProjectRef == projectRef && ident.File == filename
+	}), nil
+}
+
+// DeleteAllByAnalysisID removes the identifiers related to a given analysis, either staged or committed.
+func (r *InMemoryIdentifierRepository) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workspace := entity.WorkspaceFrom(ctx)
+	delete(r.marks, stagingKey{workspace: workspace, analysisID: analysisID})
+	kept := make([]identifierRecord, 0, len(r.records))
+	for _, record := range r.records {
+		if record.workspace != workspace || record.ident.AnalysisID != analysisID {
+			kept = append(kept, record)
+		}
+	}
+
+	if len(kept) == len(r.records) {
+		return repository.ErrIdentifierNoResults
+	}
+	r.records = kept
+
+	return nil
+}
+
+// Commit makes the staged identifiers for a given analysis visible.
+func (r *InMemoryIdentifierRepository) Commit(ctx context.Context, analysisID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workspace := entity.WorkspaceFrom(ctx)
+	delete(r.marks, stagingKey{workspace: workspace, analysisID: analysisID})
+	for i := range r.records {
+		if r.records[i].workspace == workspace && r.records[i].ident.AnalysisID == analysisID {
+			r.records[i].staged = false
+		}
+	}
+
+	return nil
+}
+
+// Stage marks the given analysis as in progress, or refreshes its mark.
+func (r *InMemoryIdentifierRepository) Stage(ctx context.Context, analysisID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.marks[stagingKey{workspace: entity.WorkspaceFrom(ctx), analysisID: analysisID}] = time.Now()
+
+	return nil
+}
+
+// FindStagedAnalysisIDs retrieves the analysis IDs for the identifiers staged or marked as in progress before the
+// given time, leaving out the analyses whose mark was refreshed after it.
+func (r *InMemoryIdentifierRepository) FindStagedAnalysisIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workspace := entity.WorkspaceFrom(ctx)
+	ids := make([]uuid.UUID, 0)
+	found := make(map[uuid.UUID]bool)
+	for key, refreshedAt := range r.marks {
+		if key.workspace != workspace {
+			continue
+		}
+
+		// analyses refreshed after the given time are still in progress
+		found[key.analysisID] = refreshedAt.Before(before)
+		if found[key.analysisID] {
+			ids = append(ids, key.analysisID)
+		}
+	}
+
+	for _, record := range r.records {
+		if !record.staged || record.workspace != workspace || !record.createdAt.Before(before) {
+			continue
+		}
+		if _, ok := found[record.ident.AnalysisID]; ok {
+			continue
+		}
+
+		found[record.ident.AnalysisID] = true
+		ids = append(ids, record.ident.AnalysisID)
+	}
+
+	return ids, nil
+}
+
+// find retrieves the committed identifiers on the workspace that match the given filter.
+func (r *InMemoryIdentifierRepository) find(ctx context.Context, filter func(entity.Identifier) bool) []entity.Identifier {
+	workspace := entity.WorkspaceFrom(ctx)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	idents := make([]entity.Identifier, 0)
+	for _, record := range r.records {
+		if !record.staged && record.workspace == workspace && filter(record.ident) {
+			idents = append(idents, record.ident)
+		}
+	}
+
+	return idents
+}
+
+// identifierIterator implements repository.IdentifierIterator over a set of identifiers.
+type identifierIterator struct {
+	idents []entity.Identifier
+	pos    int
+}
+
+func (it *identifierIterator) Next(ctx context.Context) bool {
+	if ctx.Err() != nil || it.pos+1 >= len(it.idents) {
+		return false
+	}
+
+	it.pos++
+	return true
+}
+
+func (it *identifierIterator) Identifier() entity.Identifier {
+	return it.idents[it.pos]
+}
+
+func (it *identifierIterator) Err() error {
+	return nil
+}
+
+func (it *identifierIterator) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,79 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/memory"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAll_OnInMemoryIdentifierRepository_ShouldStageIdentifiers(t *testing.T) {
+	analysis := entity.AnalysisResults{ID: uuid.New(), ProjectName: "eroatta/test"}
+	r := memory.NewInMemoryIdentifierRepository()
+
+	err := r.AddAll(context.TODO(), analysis, []entity.Identifier{
+		{Name: "main", File: "main.go"},
+		{Name: "helper", File: "main.go"},
+	})
+	assert.NoError(t, err)
+
+	idents, err := r.FindAllByAnalysisID(context.TODO(), analysis.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, idents)
+
+	idents, err = r.FindAllByProjectAndFile(context.TODO(), "eroatta/test", "main.go")
+	assert.NoError(t, err)
+	assert.Empty(t, idents)
+
+	ids, err := r.FindStagedAnalysisIDs(context.TODO(), time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{analysis.ID}, ids)
+}
+
+func TestCommit_OnInMemoryIdentifierRepository_ShouldMakeIdentifiersVisible(t *testing.T) {
+	analysis := entity.AnalysisResults{ID: uuid.New(), ProjectName: "eroatta/test"}
+	r := memory.NewInMemoryIdentifierRepository()
+	_ = r.AddAll(context.TODO(), analysis, []entity.Identifier{
+		{Name: "main", File: "main.go"},
+		{Name: "helper", File: "main.go"},
+	})
+
+	err := r.Commit(context.TODO(), analysis.ID)
+	assert.NoError(t, err)
+
+	idents, _ := r.FindAllByProjectAndFile(context.TODO(), "eroatta/test", "main.go")
+	assert.Equal(t, 2, len(idents))
+	assert.Equal(t, analysis.ID, idents[0].AnalysisID)
+
+	it, err := r.IterateByAnalysisID(context.TODO(), analysis.ID)
+	assert.NoError(t, err)
+	names := make([]string, 0)
+	for it.Next(context.TODO()) {
+		names = append(names, it.Identifier().Name)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"main", "helper"}, names)
+
+	ids, _ := r.FindStagedAnalysisIDs(context.TODO(), time.Now().Add(time.Minute))
+	assert.Empty(t, ids)
+}
+
+func TestDeleteAllByAnalysisID_OnInMemoryIdentifierRepository_ShouldRemoveStagedIdentifiers(t *testing.T) {
+	analysis := entity.AnalysisResults{ID: uuid.New(), ProjectName: "eroatta/test"}
+	r := memory.NewInMemoryIdentifierRepository()
+	_ = r.Add(context.TODO(), analysis, entity.Identifier{Name: "main", File: "main.go"})
+
+	err := r.DeleteAllByAnalysisID(context.TODO(), analysis.ID)
+	assert.NoError(t, err)
+
+	ids, _ := r.FindStagedAnalysisIDs(context.TODO(), time.Now().Add(time.Minute))
+	assert.Empty(t, ids)
+
+	err = r.DeleteAllByAnalysisID(context.TODO(), analysis.ID)
+	assert.EqualError(t, err, repository.ErrIdentifierNoResults.Error())
+}
@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryInsightRepository represents a In Memory database, focused on handling insights as memory elements.
+type InMemoryInsightRepository struct {
+	mu       sync.RWMutex
+	insights map[uuid.UUID][]entity.Insight
+	// workspaces holds the workspace for the insights of each analysis.
+	workspaces map[uuid.UUID]uuid.UUID
+}
+
+// NewInMemoryInsightRepository creates a repository.InsightRepository backed up by memory storage.
+func NewInMemoryInsightRepository() *InMemoryInsightRepository {
+	return &InMemoryInsightRepository{
+		insights:   make(map[uuid.UUID][]entity.Insight),
+		workspaces: make(map[uuid.UUID]uuid.UUID),
+	}
+}
+
+// AddAll stores a set of Insight entities into the underlying in memory storage.
+func (r *InMemoryInsightRepository) AddAll(ctx context.Context, insights []entity.Insight) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, insight := range insights {
+		if insight.ID == "" {
+			insight.ID = uuid.New().String()
+		}
+		r.insights[insight.AnalysisID] = append(r.insights[insight.AnalysisID], insight)
+		r.workspaces[insight.AnalysisID] = entity.WorkspaceFrom(ctx)
+	}
+
+	return nil
+}
+
+// GetByAnalysisID retrieves the insights related to a given analysis.
+func (r *InMemoryInsightRepository) GetByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Insight, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	insights, ok := r.insights[analysisID]
+	if !ok || r.workspaces[analysisID] != entity.WorkspaceFrom(ctx) {
+		return []entity.Insight{}, repository.ErrInsightNoResults
+	}
+
+	return append([]entity.Insight{}, insights...), nil
+}
+
+// DeleteAllByAnalysisID removes the insights related to a given analysis.
+func (r *InMemoryInsightRepository) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.insights[analysisID]; !ok || r.workspaces[analysisID] != entity.WorkspaceFrom(ctx) {
+		return repository.ErrInsightNoResults
+	}
+	delete(r.insights, analysisID)
+	delete(r.workspaces, analysisID)
+
+	return nil
+}
+
+// accuracyByProject computes the accuracy for each project with insights on the given workspace, weighting the
+// accuracy of each package by its number of identifiers. The insights from test files are left out.
+func (r *InMemoryInsightRepository) accuracyByProject(workspaceID uuid.UUID) map[string]float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	weights := make(map[string]float64)
+	totals := make(map[string]int)
+	for analysisID, insights := range r.insights {
+		if r.workspaces[analysisID] != workspaceID {
+			continue
+		}
+		for _, insight := range insights {
+			if insight.Test {
+				continue
+			}
+			weights[insight.ProjectRef] += insight.TotalWeight
+			totals[insight.ProjectRef] += insight.TotalIdentifiers
+		}
+	}
+
+	accuracy := make(map[string]float64, len(totals))
+	for projectRef, total := range totals {
+		if total > 0 {
+			accuracy[projectRef] = weights[projectRef] / float64(total)
+		}
+	}
+
+	return accuracy
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryJobRepository represents a In Memory database, focused on handling analysis jobs as memory elements.
+type InMemoryJobRepository struct {
+	mu   sync.RWMutex
+	jobs map[uuid.UUID]entity.AnalysisJob
+	// workspaces holds the workspace for each job.
+	workspaces map[uuid.UUID]uuid.UUID
+}
+
+// NewInMemoryJobRepository creates a repository.JobRepository backed up by memory storage.
+func NewInMemoryJobRepository() *InMemoryJobRepository {
+	return &InMemoryJobRepository{
+		jobs:       make(map[uuid.UUID]entity.AnalysisJob),
+		workspaces: make(map[uuid.UUID]uuid.UUID),
+	}
+}
+
+// Add stores an AnalysisJob entity into the underlying in memory storage.
+func (r *InMemoryJobRepository) Add(ctx context.Context, job entity.AnalysisJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.ID] = job
+	r.workspaces[job.ID] = entity.WorkspaceFrom(ctx)
+	return nil
+}
+
+// FindAll retrieves every existing AnalysisJob on the workspace, sorted by start time.
+func (r *InMemoryJobRepository) FindAll(ctx context.Context) ([]entity.AnalysisJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	jobs := make([]entity.AnalysisJob, 0, len(r.jobs))
+	for id, job := range r.jobs {
+		if r.workspaces[id] == entity.WorkspaceFrom(ctx) {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].DateStarted.Before(jobs[j].DateStarted)
+	})
+
+	return jobs, nil
+}
+
+// Refresh extends the hold of the owner on a running AnalysisJob on the workspace.
+func (r *InMemoryJobRepository) Refresh(ctx context.Context, job entity.AnalysisJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.find(ctx, job.ID)
+	if !ok || current.Status != entity.JobStatusRunning || current.Owner != job.Owner || current.Attempt != job.Attempt {
+		return repository.ErrJobNoResults
+	}
+	current.DateRefreshed = time.Now()
+	r.jobs[job.ID] = current
+
+	return nil
+}
+
+// Claim records the given AnalysisJob as the next attempt of a running job on the workspace, not refreshed since
+// the given time.
+func (r *InMemoryJobRepository) Claim(ctx context.Context, job entity.AnalysisJob, refreshedBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.find(ctx, job.ID)
+	if !ok || current.Status != entity.JobStatusRunning || current.Attempt != job.Attempt-1 ||
+		!current.DateRefreshed.Before(refreshedBefore) {
+		return repository.ErrJobNoResults
+	}
+	current.AnalysisID = job.AnalysisID
+	current.Attempt = job.Attempt
+	current.Owner = job.Owner
+	current.DateRefreshed = job.DateRefreshed
+	r.jobs[job.ID] = current
+
+	return nil
+}
+
+// Fail records a running AnalysisJob on the workspace as failed.
+func (r *InMemoryJobRepository) Fail(ctx context.Context, ID uuid.UUID, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.find(ctx, ID)
+	if !ok || current.Status != entity.JobStatusRunning {
+		return repository.ErrJobNoResults
+	}
+	current.Status = entity.JobStatusFailed
+	current.Error = reason
+	current.DateRefreshed = time.Now()
+	r.jobs[ID] = current
+
+	return nil
+}
+
+// find retrieves an existing AnalysisJob on the workspace.
+func (r *InMemoryJobRepository) find(ctx context.Context, ID uuid.UUID) (entity.AnalysisJob, bool) {
+	if workspaceID, ok := r.workspaces[ID]; !ok || workspaceID != entity.WorkspaceFrom(ctx) {
+		return entity.AnalysisJob{}, false
+	}
+
+	return r.jobs[ID], true
+}
+
+// Delete removes an existing AnalysisJob on the workspace from the underlying in memory storage.
+func (r *InMemoryJobRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if workspaceID, ok := r.workspaces[ID]; !ok || workspaceID != entity.WorkspaceFrom(ctx) {
+		return repository.ErrJobNoResults
+	}
+	delete(r.jobs, ID)
+	delete(r.workspaces, ID)
+
+	return nil
+}
@@ -2,35 +2,182 @@ package memory
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
 )
 
 // InMemoryProjectRepository represents a In Memory database, focused on handling projects as memory elements.
 type InMemoryProjectRepository struct {
-	repos map[string]*entity.Project
+	mu       sync.RWMutex
+	projects map[uuid.UUID]entity.Project
+	// workspaces holds the workspace for each project.
+	workspaces map[uuid.UUID]uuid.UUID
+	// insights holds the insights used to compute the accuracy of each project, if any.
+	insights *InMemoryInsightRepository
 }
 
 // NewInMemoryProjectRepository created a repository.ProjectRepository backed up by memory storage.
 func NewInMemoryProjectRepository() *InMemoryProjectRepository {
 	return &InMemoryProjectRepository{
-		repos: make(map[string]*entity.Project),
+		projects:   make(map[uuid.UUID]entity.Project),
+		workspaces: make(map[uuid.UUID]uuid.UUID),
 	}
 }
 
+// NewInMemoryProjectRepositoryWithInsights creates a repository.ProjectRepository backed up by memory storage,
+// that computes the accuracy of each project using the insights held by the given repository.
+func NewInMemoryProjectRepositoryWithInsights(insights *InMemoryInsightRepository) *InMemoryProjectRepository {
+	r := NewInMemoryProjectRepository()
+	r.insights = insights
+	return r
+}
+
 // Add stores a Project entity into the underlying in memory storage.
-func (r InMemoryProjectRepository) Add(ctx context.Context, project entity.Project) error {
-	r.repos[project.Reference] = &project
+func (r *InMemoryProjectRepository) Add(ctx context.Context, project entity.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects[project.ID] = project
+	r.workspaces[project.ID] = entity.WorkspaceFrom(ctx)
 	return nil
 }
 
-// GetByReference finds an existing Project on the in memory storage, using the given reference as filter.
-func (r InMemoryProjectRepository) GetByReference(ctx context.Context, projectRef string) (entity.Project, error) {
-	project, ok := r.repos[projectRef]
-	if !ok {
+// Get finds an existing Project on the in memory storage, using its ID.
+func (r *InMemoryProjectRepository) Get(ctx context.Context, ID uuid.UUID) (entity.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, ok := r.projects[ID]
+	if !ok || !r.visible(ctx, ID) {
 		return entity.Project{}, repository.ErrProjectNoResults
 	}
 
-	return *project, nil
+	return project, nil
+}
+
+// GetByReference finds an existing Project on the in memory storage, using the given reference as filter.
+func (r *InMemoryProjectRepository) GetByReference(ctx context.Context, projectRef string) (entity.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, project := range r.projects {
+		if project.Reference == projectRef && r.visible(ctx, project.ID) {
+			return project, nil
+		}
+	}
+
+	return entity.Project{}, repository.ErrProjectNoResults
+}
+
+// Query retrieves a page of the projects matching and sorted by the given query. Projects with the same sorting
+// value are sorted by their reference.
+func (r *InMemoryProjectRepository) Query(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error) {
+	accuracy := make(map[string]float64)
+	if r.insights != nil {
+		accuracy = r.insights.accuracyByProject(entity.WorkspaceFrom(ctx))
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := make([]entity.Project, 0)
+	for _, project := range r.projects {
+		if query.Match(project) && r.visible(ctx, project.ID) {
+			projects = append(projects, project)
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		switch query.Sort {
+		case entity.ProjectSortCreated:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case entity.ProjectSortStars:
+			if a.Metadata.Stargazers != b.Metadata.Stargazers {
+				return a.Metadata.Stargazers < b.Metadata.Stargazers
+			}
+		case entity.ProjectSortStarsDesc:
+			if a.Metadata.Stargazers != b.Metadata.Stargazers {
+				return a.Metadata.Stargazers > b.Metadata.Stargazers
+			}
+		case entity.ProjectSortAccuracy, entity.ProjectSortAccuracyDesc:
+			x, okA := accuracy[a.Reference]
+			y, okB := accuracy[b.Reference]
+			switch {
+			case okA != okB:
+				return okA
+			case x != y && query.Sort == entity.ProjectSortAccuracy:
+				return x < y
+			case x != y:
+				return x > y
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		}
+
+		if a.Reference != b.Reference {
+			return a.Reference < b.Reference
+		}
+		return a.ID.String() < b.ID.String()
+	})
+
+	page := entity.ProjectPage{
+		Projects: make([]entity.Project, 0),
+		Accuracy: make(map[uuid.UUID]float64),
+		Total:    len(projects),
+	}
+	if query.Offset < len(projects) {
+		projects = projects[query.Offset:]
+		if query.Limit > 0 && len(projects) > query.Limit {
+			projects = projects[:query.Limit]
+		}
+		page.Projects = projects
+	}
+	for _, project := range page.Projects {
+		if value, ok := accuracy[project.Reference]; ok {
+			page.Accuracy[project.ID] = value
+		}
+	}
+
+	return page, nil
+}
+
+// Update replaces an existing Project on the underlying in memory storage.
+func (r *InMemoryProjectRepository) Update(ctx context.Context, project entity.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[project.ID]; !ok || !r.visible(ctx, project.ID) {
+		return repository.ErrProjectNoResults
+	}
+	r.projects[project.ID] = project
+
+	return nil
+}
+
+// Delete removes an existing Project from the in memory storage.
+func (r *InMemoryProjectRepository) Delete(ctx context.Context, ID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.projects[ID]; !ok || !r.visible(ctx, ID) {
+		return repository.ErrProjectNoResults
+	}
+	delete(r.projects, ID)
+	delete(r.workspaces, ID)
+
+	return nil
+}
+
+// visible checks if the given project belongs to the workspace on the context.
+func (r *InMemoryProjectRepository) visible(ctx context.Context, ID uuid.UUID) bool {
+	return r.workspaces[ID] == entity.WorkspaceFrom(ctx)
 }
@@ -0,0 +1,236 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemorySearchIndexRepository represents a In Memory database, focused on handling the documents and postings
+// of the search index as memory elements.
+type InMemorySearchIndexRepository struct {
+	mu       sync.RWMutex
+	seq      int64
+	docs     map[string]searchDocument
+	postings map[uuid.UUID]map[string]map[string]float64
+	analyses map[uuid.UUID][]string
+	// terms holds the indexed terms of each workspace, sorted to look for prefixes. It's rebuilt on the next lookup
+	// after any change.
+	terms  map[uuid.UUID][]string
+	sorted bool
+}
+
+// searchDocument holds a stored document, along with the workspace it belongs to.
+type searchDocument struct {
+	workspace uuid.UUID
+	doc       entity.SearchDocument
+}
+
+// NewInMemorySearchIndexRepository creates a repository.SearchIndexRepository backed up by memory storage.
+func NewInMemorySearchIndexRepository() *InMemorySearchIndexRepository {
+	return &InMemorySearchIndexRepository{
+		docs:     make(map[string]searchDocument),
+		postings: make(map[uuid.UUID]map[string]map[string]float64),
+		analyses: make(map[uuid.UUID][]string),
+		terms:    make(map[uuid.UUID][]string),
+	}
+}
+
+// AddAll stores a set of documents on the current workspace.
+func (r *InMemorySearchIndexRepository) AddAll(ctx context.Context, docs []entity.SearchDocument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workspace := entity.WorkspaceFrom(ctx)
+	for _, doc := range docs {
+		r.insert(workspace, doc)
+	}
+
+	return nil
+}
+
+// ReplaceAll stores every document provided by the iterator on the current workspace, replacing the documents
+// previously stored for the given analysis. The batches are gathered before taking the lock, so the previous
+// documents are kept if the iterator fails.
+func (r *InMemorySearchIndexRepository) ReplaceAll(ctx context.Context, analysisID uuid.UUID,
+	it repository.SearchDocumentIterator) error {
+	docs := make([]entity.SearchDocument, 0)
+	for it.Next(ctx) {
+		docs = append(docs, it.Documents()...)
+	}
+	if it.Err() != nil {
+		return repository.ErrSearchUnexpected
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	workspace := entity.WorkspaceFrom(ctx)
+	r.remove(workspace, analysisID)
+	for _, doc := range docs {
+		r.insert(workspace, doc)
+	}
+
+	return nil
+}
+
+// FindTerms retrieves up to limit distinct terms on the current workspace starting with the given prefix, whose
+// length is between the given bounds, the shortest ones first.
+func (r *InMemorySearchIndexRepository) FindTerms(ctx context.Context, prefix string, minLength int,
+	maxLength int, limit int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.sorted {
+		r.sortTerms()
+	}
+
+	terms := r.terms[entity.WorkspaceFrom(ctx)]
+	found := make([]string, 0)
+	for i := sort.SearchStrings(terms, prefix); i < len(terms) && strings.HasPrefix(terms[i], prefix); i++ {
+		length := utf8.RuneCountInString(terms[i])
+		if length >= minLength && (maxLength < 1 || length <= maxLength) {
+			found = append(found, terms[i])
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return utf8.RuneCountInString(found[i]) < utf8.RuneCountInString(found[j])
+	})
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+
+	return found, nil
+}
+
+// FindPostings retrieves the weight of each one of the given terms on the documents of the current workspace,
+// belonging to the given project if any.
+func (r *InMemorySearchIndexRepository) FindPostings(ctx context.Context, terms []string,
+	projectRef string) (map[string]map[string]float64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	postings := r.postings[entity.WorkspaceFrom(ctx)]
+	found := make(map[string]map[string]float64)
+	for _, term := range terms {
+		if len(postings[term]) == 0 {
+			continue
+		}
+
+		for id, weight := range postings[term] {
+			if projectRef != "" && r.docs[id].doc.Result.ProjectRef != projectRef {
+				continue
+			}
+			if _, ok := found[term]; !ok {
+				found[term] = make(map[string]float64)
+			}
+			found[term][id] = weight
+		}
+	}
+
+	return found, nil
+}
+
+// FindDocuments retrieves the search results held by the documents of the current workspace with the given IDs.
+func (r *InMemorySearchIndexRepository) FindDocuments(ctx context.Context,
+	ids []string) (map[string]entity.SearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workspace := entity.WorkspaceFrom(ctx)
+	found := make(map[string]entity.SearchResult, len(ids))
+	for _, id := range ids {
+		if stored, ok := r.docs[id]; ok && stored.workspace == workspace {
+			found[id] = stored.doc.Result
+		}
+	}
+
+	return found, nil
+}
+
+// DeleteAllByAnalysisID removes the documents related to a given analysis on the current workspace.
+func (r *InMemorySearchIndexRepository) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.remove(entity.WorkspaceFrom(ctx), analysisID) {
+		return repository.ErrSearchNoResults
+	}
+
+	return nil
+}
+
+// insert stores the document on a workspace, adding it to the postings of each one of its terms.
+func (r *InMemorySearchIndexRepository) insert(workspace uuid.UUID, doc entity.SearchDocument) {
+	r.seq++
+	doc.ID = strconv.FormatInt(r.seq, 10)
+	r.docs[doc.ID] = searchDocument{workspace: workspace, doc: doc}
+	r.analyses[doc.Result.AnalysisID] = append(r.analyses[doc.Result.AnalysisID], doc.ID)
+
+	if _, ok := r.postings[workspace]; !ok {
+		r.postings[workspace] = make(map[string]map[string]float64)
+	}
+	for term, weight := range doc.Terms {
+		if _, ok := r.postings[workspace][term]; !ok {
+			r.postings[workspace][term] = make(map[string]float64)
+			r.sorted = false
+		}
+		r.postings[workspace][term][doc.ID] = weight
+	}
+}
+
+// remove drops the documents related to a given analysis on a workspace, reporting whether any document was found.
+func (r *InMemorySearchIndexRepository) remove(workspace uuid.UUID, analysisID uuid.UUID) bool {
+	ids, kept := make([]string, 0), make([]string, 0)
+	for _, id := range r.analyses[analysisID] {
+		if r.docs[id].workspace == workspace {
+			ids = append(ids, id)
+		} else {
+			kept = append(kept, id)
+		}
+	}
+	if len(ids) == 0 {
+		return false
+	}
+
+	postings := r.postings[workspace]
+	for _, id := range ids {
+		for term := range r.docs[id].doc.Terms {
+			delete(postings[term], id)
+			if len(postings[term]) == 0 {
+				delete(postings, term)
+				r.sorted = false
+			}
+		}
+		delete(r.docs, id)
+	}
+	if len(kept) > 0 {
+		r.analyses[analysisID] = kept
+	} else {
+		delete(r.analyses, analysisID)
+	}
+
+	return true
+}
+
+// sortTerms rebuilds the sorted lists of indexed terms.
+func (r *InMemorySearchIndexRepository) sortTerms() {
+	r.terms = make(map[uuid.UUID][]string, len(r.postings))
+	for workspace, postings := range r.postings {
+		terms := make([]string, 0, len(postings))
+		for term := range postings {
+			terms = append(terms, term)
+		}
+		sort.Strings(terms)
+		r.terms[workspace] = terms
+	}
+	r.sorted = true
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+)
+
+// InMemoryWorkspaceRepository represents a In Memory database, focused on handling workspaces as memory elements.
+type InMemoryWorkspaceRepository struct {
+	mu         sync.RWMutex
+	workspaces map[uuid.UUID]entity.Workspace
+}
+
+// NewInMemoryWorkspaceRepository creates a repository.WorkspaceRepository backed up by memory storage.
+func NewInMemoryWorkspaceRepository() *InMemoryWorkspaceRepository {
+	return &InMemoryWorkspaceRepository{
+		workspaces: make(map[uuid.UUID]entity.Workspace),
+	}
+}
+
+// Add stores a Workspace entity into the underlying in memory storage.
+func (r *InMemoryWorkspaceRepository) Add(ctx context.Context, workspace entity.Workspace) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.workspaces[workspace.ID] = workspace
+	return nil
+}
+
+// Get finds an existing Workspace by ID.
+func (r *InMemoryWorkspaceRepository) Get(ctx context.Context, ID uuid.UUID) (entity.Workspace, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workspace, ok := r.workspaces[ID]
+	if !ok {
+		return entity.Workspace{}, repository.ErrWorkspaceNoResults
+	}
+
+	return workspace, nil
+}
+
+// FindAll retrieves every existing Workspace, sorted by creation time.
+func (r *InMemoryWorkspaceRepository) FindAll(ctx context.Context) ([]entity.Workspace, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workspaces := make([]entity.Workspace, 0, len(r.workspaces))
+	for _, workspace := range r.workspaces {
+		workspaces = append(workspaces, workspace)
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].CreatedAt.Before(workspaces[j].CreatedAt)
+	})
+
+	return workspaces, nil
+}
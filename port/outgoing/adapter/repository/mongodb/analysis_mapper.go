@@ -20,11 +20,14 @@ func (am *analysisMapper) toDTO(ent entity.AnalysisResults) analysisDTO {
 		Miners:     ent.PipelineMiners,
 		Splitters:  ent.PipelineSplitters,
 		Expanders:  ent.PipelineExpanders,
+		Rules:      ent.PipelineRules,
+		Tests:      ent.IncludeTests,
 		Files: summarizerDTO{
 			Total:        int32(ent.FilesTotal),
 			Valid:        int32(ent.FilesValid),
 			Failed:       int32(ent.FilesError),
 			ErrorSamples: ent.FilesErrorSamples,
+			Skipped:      int32(ent.FilesSkipped),
 		},
 		Identifiers: summarizerDTO{
 			Total:        int32(ent.IdentifiersTotal),
@@ -45,10 +48,13 @@ func (am *analysisMapper) toEntity(dto analysisDTO) entity.AnalysisResults {
 		PipelineMiners:          dto.Miners,
 		PipelineSplitters:       dto.Splitters,
 		PipelineExpanders:       dto.Expanders,
+		PipelineRules:           dto.Rules,
+		IncludeTests:            dto.Tests,
 		FilesTotal:              int(dto.Files.Total),
 		FilesValid:              int(dto.Files.Valid),
 		FilesError:              int(dto.Files.Failed),
 		FilesErrorSamples:       dto.Files.ErrorSamples,
+		FilesSkipped:            int(dto.Files.Skipped),
 		IdentifiersTotal:        int(dto.Identifiers.Total),
 		IdentifiersValid:        int(dto.Identifiers.Valid),
 		IdentifiersError:        int(dto.Identifiers.Failed),
@@ -59,12 +65,15 @@ func (am *analysisMapper) toEntity(dto analysisDTO) entity.AnalysisResults {
 // analysisDTO is the database representation for an AnalysisResults.
 type analysisDTO struct {
 	ID          string        `bson:"_id"`
+	WorkspaceID string        `bson:"workspace_id"`
 	CreatedAt   time.Time     `bson:"created_at"`
 	ProjectID   string        `bson:"project_id"`
 	ProjectRef  string        `bson:"project_ref"`
 	Miners      []string      `bson:"miners"`
 	Splitters   []string      `bson:"splitters"`
 	Expanders   []string      `bson:"expanders"`
+	Rules       []string      `bson:"rules,omitempty"`
+	Tests       bool          `bson:"include_tests,omitempty"`
 	Files       summarizerDTO `bson:"files_summary"`
 	Identifiers summarizerDTO `bson:"identifiers_summary"`
 }
@@ -75,4 +84,6 @@ type summarizerDTO struct {
 	Valid        int32    `bson:"valid"`
 	Failed       int32    `bson:"failed"`
 	ErrorSamples []string `bson:"error_samples"`
+	// Skipped is only set for the files.
+	Skipped int32 `bson:"skipped,omitempty"`
 }
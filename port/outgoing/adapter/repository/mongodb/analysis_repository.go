@@ -32,7 +32,9 @@ func NewMongoDBAnalysisRepository(client *mongo.Client, dbname string) *Analysis
 
 // Add transforms and stores an AnalysisResults entity into a document on the underlying MongoDB collection.
 func (adb *AnalysisDB) Add(ctx context.Context, analysis entity.AnalysisResults) error {
-	_, err := adb.collection.InsertOne(ctx, adb.mapper.toDTO(analysis))
+	dto := adb.mapper.toDTO(analysis)
+	dto.WorkspaceID = workspaceOf(ctx)
+	_, err := adb.collection.InsertOne(ctx, dto)
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("error inserting record %v", analysis))
 		return repository.ErrAnalysisUnexpected
@@ -41,9 +43,31 @@ func (adb *AnalysisDB) Add(ctx context.Context, analysis entity.AnalysisResults)
 	return nil
 }
 
+// Get retrieves an existing analysis using its ID, from the underlying MongoDB collection.
+func (adb *AnalysisDB) Get(ctx context.Context, id uuid.UUID) (entity.AnalysisResults, error) {
+	results := adb.collection.FindOne(ctx, inWorkspace(ctx, bson.M{"_id": id.String()}))
+	switch results.Err() {
+	case nil:
+		// do nothing
+	case mongo.ErrNoDocuments:
+		return entity.AnalysisResults{}, repository.ErrAnalysisNoResults
+	default:
+		log.WithError(results.Err()).Errorf("error searching analysis with id: %v", id)
+		return entity.AnalysisResults{}, repository.ErrAnalysisUnexpected
+	}
+
+	var dto analysisDTO
+	if err := results.Decode(&dto); err != nil {
+		log.WithError(err).Errorf("error decoding results for analysis with id: %v", id)
+		return entity.AnalysisResults{}, repository.ErrAnalysisUnexpected
+	}
+
+	return adb.mapper.toEntity(dto), nil
+}
+
 // GetByProjectID retrieves an existing analysis for the given Project, from the underlying MongoDB collection.
 func (adb *AnalysisDB) GetByProjectID(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
-	results := adb.collection.FindOne(ctx, bson.M{"project_id": projectID.String()})
+	results := adb.collection.FindOne(ctx, inWorkspace(ctx, bson.M{"project_id": projectID.String()}))
 	switch results.Err() {
 	case nil:
 		// do nothing
@@ -65,7 +89,7 @@ func (adb *AnalysisDB) GetByProjectID(ctx context.Context, projectID uuid.UUID)
 
 // Delete removes an existing Analysis from the underlying MongoDB collection.
 func (adb *AnalysisDB) Delete(ctx context.Context, id uuid.UUID) error {
-	results, err := adb.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	results, err := adb.collection.DeleteOne(ctx, inWorkspace(ctx, bson.M{"_id": id.String()}))
 	if err != nil {
 		log.WithError(err).Errorf("error deleting analysis with id: %v", id)
 		return repository.ErrAnalysisUnexpected
@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+// apiKeyMapper maps an APIKey between its model and database representations.
+type apiKeyMapper struct{}
+
+// toDTO maps the entity for APIKey into a Data Transfer Object.
+func (km *apiKeyMapper) toDTO(ent entity.APIKey) apiKeyDTO {
+	return apiKeyDTO{
+		ID:          ent.ID.String(),
+		WorkspaceID: ent.WorkspaceID.String(),
+		Name:        ent.Name,
+		Prefix:      ent.Prefix,
+		Hash:        ent.Hash,
+		Scopes:      ent.Scopes,
+		CreatedAt:   ent.CreatedAt,
+	}
+}
+
+// toEntity maps the Data Transfer Object for APIKey into a domain entity. Keys stored without a workspace belong to
+// the default workspace.
+func (km *apiKeyMapper) toEntity(dto apiKeyDTO) entity.APIKey {
+	workspaceID := entity.DefaultWorkspaceID
+	if dto.WorkspaceID != "" {
+		workspaceID = uuid.MustParse(dto.WorkspaceID)
+	}
+
+	return entity.APIKey{
+		ID:          uuid.MustParse(dto.ID),
+		WorkspaceID: workspaceID,
+		Name:        dto.Name,
+		Prefix:      dto.Prefix,
+		Hash:        dto.Hash,
+		Scopes:      dto.Scopes,
+		CreatedAt:   dto.CreatedAt,
+	}
+}
+
+// apiKeyDTO is the database representation for an APIKey.
+type apiKeyDTO struct {
+	ID          string    `bson:"_id"`
+	WorkspaceID string    `bson:"workspace_id"`
+	Name        string    `bson:"name"`
+	Prefix      string    `bson:"prefix"`
+	Hash        string    `bson:"hash"`
+	Scopes      []string  `bson:"scopes"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
@@ -0,0 +1,57 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDTO_OnAPIKeyMapper_ShouldReturnAPIKeyDTO(t *testing.T) {
+	now := time.Now()
+	key := entity.APIKey{
+		ID:          uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"),
+		WorkspaceID: uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"),
+		Name:        "ci",
+		Prefix:      "srk_1a2b3c4d",
+		Hash:        "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		Scopes:      []string{entity.ScopeAnalyze},
+		CreatedAt:   now,
+	}
+
+	km := &apiKeyMapper{}
+	dto := km.toDTO(key)
+
+	assert.Equal(t, "f9b76fde-c342-4328-8650-85da8f21e2be", dto.ID)
+	assert.Equal(t, "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f", dto.WorkspaceID)
+	assert.Equal(t, "ci", dto.Name)
+	assert.Equal(t, "srk_1a2b3c4d", dto.Prefix)
+	assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", dto.Hash)
+	assert.Equal(t, []string{"analyze"}, dto.Scopes)
+	assert.Equal(t, now, dto.CreatedAt)
+}
+
+func TestToEntity_OnAPIKeyMapper_ShouldReturnAPIKeyEntity(t *testing.T) {
+	now := time.Now()
+	dto := apiKeyDTO{
+		ID:        "f9b76fde-c342-4328-8650-85da8f21e2be",
+		Name:      "ci",
+		Prefix:    "srk_1a2b3c4d",
+		Hash:      "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		Scopes:    []string{"read", "analyze"},
+		CreatedAt: now,
+	}
+
+	km := &apiKeyMapper{}
+	ent := km.toEntity(dto)
+
+	assert.Equal(t, uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"), ent.ID)
+	assert.Equal(t, entity.DefaultWorkspaceID, ent.WorkspaceID)
+	assert.Equal(t, "ci", ent.Name)
+	assert.Equal(t, "srk_1a2b3c4d", ent.Prefix)
+	assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", ent.Hash)
+	assert.Equal(t, []string{entity.ScopeRead, entity.ScopeAnalyze}, ent.Scopes)
+	assert.Equal(t, now, ent.CreatedAt)
+}
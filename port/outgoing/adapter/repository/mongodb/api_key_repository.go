@@ -0,0 +1,103 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const apiKeysCollection string = "api_keys"
+
+// APIKeyDB represents a MongoDB database, focused on the collection handling the API key documents.
+type APIKeyDB struct {
+	client     *mongo.Client
+	mapper     *apiKeyMapper
+	collection *mongo.Collection
+}
+
+// NewMongoDBAPIKeyRepository creates a repository.APIKeyRepository backed up by a MongoDB database.
+func NewMongoDBAPIKeyRepository(client *mongo.Client, dbname string) *APIKeyDB {
+	return &APIKeyDB{
+		client:     client,
+		mapper:     &apiKeyMapper{},
+		collection: client.Database(dbname).Collection(apiKeysCollection),
+	}
+}
+
+// Add transforms and stores an APIKey entity into a document on the underlying MongoDB collection, for the
+// workspace it belongs to.
+func (kdb *APIKeyDB) Add(ctx context.Context, key entity.APIKey) error {
+	_, err := kdb.collection.InsertOne(ctx, kdb.mapper.toDTO(key))
+	if err != nil {
+		log.WithError(err).Errorf("error inserting API key %v", key.ID)
+		return repository.ErrAPIKeyUnexpected
+	}
+
+	return nil
+}
+
+// GetByHash finds the existing APIKey matching the given hash, on any workspace.
+func (kdb *APIKeyDB) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	res := kdb.collection.FindOne(ctx, bson.M{"hash": hash})
+	switch res.Err() {
+	case nil:
+		// do nothing
+	case mongo.ErrNoDocuments:
+		return entity.APIKey{}, repository.ErrAPIKeyNoResults
+	default:
+		log.WithError(res.Err()).Error("error searching API key by hash")
+		return entity.APIKey{}, repository.ErrAPIKeyUnexpected
+	}
+
+	var dto apiKeyDTO
+	if err := res.Decode(&dto); err != nil {
+		log.WithError(err).Error("error decoding result for API key")
+		return entity.APIKey{}, repository.ErrAPIKeyUnexpected
+	}
+
+	return kdb.mapper.toEntity(dto), nil
+}
+
+// FindAll retrieves every existing APIKey for the current workspace on the underlying MongoDB collection, sorted by
+// creation time.
+func (kdb *APIKeyDB) FindAll(ctx context.Context) ([]entity.APIKey, error) {
+	cursor, err := kdb.collection.Find(ctx, inWorkspace(ctx, bson.M{}), options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		log.WithError(err).Error("error searching API keys")
+		return []entity.APIKey{}, repository.ErrAPIKeyUnexpected
+	}
+
+	var elements []apiKeyDTO
+	err = cursor.All(ctx, &elements)
+	if err != nil {
+		log.WithError(err).Error("error decoding found API key documents")
+		return []entity.APIKey{}, repository.ErrAPIKeyUnexpected
+	}
+
+	keys := make([]entity.APIKey, len(elements))
+	for i, element := range elements {
+		keys[i] = kdb.mapper.toEntity(element)
+	}
+
+	return keys, nil
+}
+
+// Delete removes an existing APIKey from the underlying MongoDB collection.
+func (kdb *APIKeyDB) Delete(ctx context.Context, ID uuid.UUID) error {
+	results, err := kdb.collection.DeleteOne(ctx, inWorkspace(ctx, bson.M{"_id": ID.String()}))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting API key with id: %v", ID)
+		return repository.ErrAPIKeyUnexpected
+	}
+
+	if results.DeletedCount == 0 {
+		return repository.ErrAPIKeyNoResults
+	}
+	return nil
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/tracing"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -20,9 +27,9 @@ var (
 	ErrMongoDBValidation = errors.New("MongoDB connection validation error")
 )
 
-// NewMongoClient creates a new MongoDB client.
+// NewMongoClient creates a new MongoDB client, tracing every command sent to the server.
 func NewMongoClient(url string) (*mongo.Client, error) {
-	clientOptions := options.Client().ApplyURI(url)
+	clientOptions := options.Client().ApplyURI(url).SetMonitor(newCommandMonitor())
 	client, err := mongo.Connect(context.TODO(), clientOptions)
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("error opening a connection to %s", url))
@@ -37,3 +44,51 @@ func NewMongoClient(url string) (*mongo.Client, error) {
 
 	return client, nil
 }
+
+// newCommandMonitor creates a monitor recording a span for each command, from the moment it's sent until its
+// reply is received.
+func newCommandMonitor() *event.CommandMonitor {
+	var spans sync.Map
+	finish := func(requestID int64, err error) {
+		if span, ok := spans.Load(requestID); ok {
+			spans.Delete(requestID)
+			tracing.End(span.(trace.Span), err)
+		}
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			_, span := tracing.Start(ctx, e.CommandName, trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("db.system", "mongodb"),
+					attribute.String("db.name", e.DatabaseName),
+					attribute.String("db.operation", e.CommandName),
+				))
+			spans.Store(e.RequestID, span)
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, nil)
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, errors.New(e.Failure))
+		},
+	}
+}
+
+// workspaceOf returns the workspace set on the context, as stored on the workspace_id field.
+func workspaceOf(ctx context.Context) string {
+	return entity.WorkspaceFrom(ctx).String()
+}
+
+// inWorkspace scopes the given filter to the workspace set on the context. Documents stored before workspaces were
+// introduced have no workspace_id field, and belong to the default workspace.
+func inWorkspace(ctx context.Context, filter bson.M) bson.M {
+	workspace := entity.WorkspaceFrom(ctx)
+	if workspace == entity.DefaultWorkspaceID {
+		filter["workspace_id"] = bson.M{"$in": bson.A{workspace.String(), nil}}
+	} else {
+		filter["workspace_id"] = workspace.String()
+	}
+
+	return filter
+}
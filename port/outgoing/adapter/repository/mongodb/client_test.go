@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCommandMonitor_ShouldTraceEachCommandUnderCallerSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	monitor := newCommandMonitor()
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GetProject")
+	monitor.Started(ctx, &event.CommandStartedEvent{CommandName: "find", DatabaseName: "reader", RequestID: 1})
+	monitor.Started(ctx, &event.CommandStartedEvent{CommandName: "insert", DatabaseName: "reader", RequestID: 2})
+	monitor.Failed(ctx, &event.CommandFailedEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "insert", RequestID: 2},
+		Failure:              "duplicate key",
+	})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{
+		CommandFinishedEvent: event.CommandFinishedEvent{CommandName: "find", RequestID: 1},
+	})
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	insert, find := spans[0], spans[1]
+	assert.Equal(t, "insert", insert.Name())
+	assert.Equal(t, codes.Error, insert.Status().Code)
+	assert.Equal(t, "duplicate key", insert.Status().Description)
+	assert.Equal(t, "find", find.Name())
+	assert.Equal(t, codes.Unset, find.Status().Code)
+	assert.Equal(t, parent.SpanContext().SpanID(), find.Parent().SpanID())
+	assert.Equal(t, trace.SpanKindClient, find.SpanKind())
+	assert.Contains(t, find.Attributes(), attribute.String("db.system", "mongodb"))
+	assert.Contains(t, find.Attributes(), attribute.String("db.name", "reader"))
+}
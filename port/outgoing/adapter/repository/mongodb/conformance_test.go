@@ -0,0 +1,97 @@
+package mongodb_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/conformance"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/mongodb"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// newDatabase connects to the MongoDB server referenced by MONGODB_TEST_URL, and returns a client and the name
+// of a new database that is dropped after the test. The test is skipped if no server is referenced.
+func newDatabase(t *testing.T) (*mongo.Client, string) {
+	url := os.Getenv("MONGODB_TEST_URL")
+	if url == "" {
+		t.Skip("MONGODB_TEST_URL not set")
+	}
+
+	client, err := mongodb.NewMongoClient(url)
+	if err != nil {
+		t.Fatalf("unable to connect to %s: %v", url, err)
+	}
+
+	dbname := "conformance_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	t.Cleanup(func() {
+		_ = client.Database(dbname).Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	})
+
+	return client, dbname
+}
+
+func TestConformance_OnMongoDBProjectRepository(t *testing.T) {
+	conformance.ProjectRepository(t, func(t *testing.T) repository.ProjectRepository {
+		return mongodb.NewMongoDBProjecRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBProjectRepository_Query(t *testing.T) {
+	conformance.ProjectQuery(t, func(t *testing.T) (repository.ProjectRepository, repository.InsightRepository) {
+		client, dbname := newDatabase(t)
+		return mongodb.NewMongoDBProjecRepository(client, dbname), mongodb.NewMongoDBInsightRepository(client, dbname)
+	})
+}
+
+func TestConformance_OnMongoDBAnalysisRepository(t *testing.T) {
+	conformance.AnalysisRepository(t, func(t *testing.T) repository.AnalysisRepository {
+		return mongodb.NewMongoDBAnalysisRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBIdentifierRepository(t *testing.T) {
+	conformance.IdentifierRepository(t, func(t *testing.T) repository.IdentifierRepository {
+		return mongodb.NewMongoDBIdentifierRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBInsightRepository(t *testing.T) {
+	conformance.InsightRepository(t, func(t *testing.T) repository.InsightRepository {
+		return mongodb.NewMongoDBInsightRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBDictionaryRepository(t *testing.T) {
+	conformance.DictionaryRepository(t, func(t *testing.T) repository.DictionaryRepository {
+		return mongodb.NewMongoDBDictionaryRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBAPIKeyRepository(t *testing.T) {
+	conformance.APIKeyRepository(t, func(t *testing.T) repository.APIKeyRepository {
+		return mongodb.NewMongoDBAPIKeyRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBWorkspaceRepository(t *testing.T) {
+	conformance.WorkspaceRepository(t, func(t *testing.T) repository.WorkspaceRepository {
+		return mongodb.NewMongoDBWorkspaceRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBJobRepository(t *testing.T) {
+	conformance.JobRepository(t, func(t *testing.T) repository.JobRepository {
+		return mongodb.NewMongoDBJobRepository(newDatabase(t))
+	})
+}
+
+func TestConformance_OnMongoDBSearchIndexRepository(t *testing.T) {
+	conformance.SearchIndexRepository(t, func(t *testing.T) repository.SearchIndexRepository {
+		return mongodb.NewMongoDBSearchIndexRepository(newDatabase(t))
+	})
+}
@@ -0,0 +1,65 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+// dictionaryMapper maps a Dictionary between its model and database representations.
+type dictionaryMapper struct{}
+
+// toDTO maps the entity for Dictionary into a Data Transfer Object.
+func (dm *dictionaryMapper) toDTO(ent entity.Dictionary) dictionaryDTO {
+	entries := make([]entryDTO, 0, len(ent.Entries))
+	for abbreviation, expansions := range ent.Entries {
+		entries = append(entries, entryDTO{
+			Abbreviation: abbreviation,
+			Expansions:   expansions,
+		})
+	}
+
+	return dictionaryDTO{
+		ID:         ent.ID.String(),
+		Scope:      ent.Scope,
+		ProjectRef: ent.ProjectRef,
+		Entries:    entries,
+		CreatedAt:  ent.CreatedAt,
+		UpdatedAt:  ent.UpdatedAt,
+	}
+}
+
+// toEntity maps the Data Transfer Object for Dictionary into a domain entity.
+func (dm *dictionaryMapper) toEntity(dto dictionaryDTO) entity.Dictionary {
+	entries := make(map[string][]string, len(dto.Entries))
+	for _, entry := range dto.Entries {
+		entries[entry.Abbreviation] = entry.Expansions
+	}
+
+	return entity.Dictionary{
+		ID:         uuid.MustParse(dto.ID),
+		Scope:      dto.Scope,
+		ProjectRef: dto.ProjectRef,
+		Entries:    entries,
+		CreatedAt:  dto.CreatedAt,
+		UpdatedAt:  dto.UpdatedAt,
+	}
+}
+
+// dictionaryDTO is the database representation for a Dictionary.
+type dictionaryDTO struct {
+	ID          string     `bson:"_id"`
+	WorkspaceID string     `bson:"workspace_id"`
+	Scope       string     `bson:"scope"`
+	ProjectRef  string     `bson:"project_ref"`
+	Entries     []entryDTO `bson:"entries"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	UpdatedAt   time.Time  `bson:"updated_at"`
+}
+
+// entryDTO is the database representation for a Dictionary entry.
+type entryDTO struct {
+	Abbreviation string   `bson:"abbreviation"`
+	Expansions   []string `bson:"expansions"`
+}
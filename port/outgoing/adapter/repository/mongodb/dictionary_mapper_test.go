@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDTO_OnDictionaryMapper_ShouldReturnDictionaryDTO(t *testing.T) {
+	now := time.Now()
+	dict := entity.Dictionary{
+		ID:         uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"),
+		Scope:      entity.DictionaryScopeProject,
+		ProjectRef: "eroatta/src-reader",
+		Entries: map[string][]string{
+			"txn": {"transaction"},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	dm := &dictionaryMapper{}
+	dto := dm.toDTO(dict)
+
+	assert.Equal(t, "f9b76fde-c342-4328-8650-85da8f21e2be", dto.ID)
+	assert.Equal(t, "project", dto.Scope)
+	assert.Equal(t, "eroatta/src-reader", dto.ProjectRef)
+	assert.EqualValues(t, []entryDTO{
+		{Abbreviation: "txn", Expansions: []string{"transaction"}},
+	}, dto.Entries)
+	assert.Equal(t, now, dto.CreatedAt)
+	assert.Equal(t, now, dto.UpdatedAt)
+}
+
+func TestToEntity_OnDictionaryMapper_ShouldReturnDictionaryEntity(t *testing.T) {
+	now := time.Now()
+	dto := dictionaryDTO{
+		ID:    "f9b76fde-c342-4328-8650-85da8f21e2be",
+		Scope: "organization",
+		Entries: []entryDTO{
+			{Abbreviation: "svc", Expansions: []string{"service"}},
+			{Abbreviation: "cfg", Expansions: []string{"config", "configuration"}},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	dm := &dictionaryMapper{}
+	ent := dm.toEntity(dto)
+
+	assert.Equal(t, uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"), ent.ID)
+	assert.Equal(t, entity.DictionaryScopeOrganization, ent.Scope)
+	assert.Empty(t, ent.ProjectRef)
+	assert.Equal(t, map[string][]string{
+		"svc": {"service"},
+		"cfg": {"config", "configuration"},
+	}, ent.Entries)
+	assert.Equal(t, now, ent.CreatedAt)
+}
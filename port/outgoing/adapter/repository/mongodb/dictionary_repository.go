@@ -0,0 +1,131 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const dictionariesCollection string = "dictionaries"
+
+// DictionaryDB represents a MongoDB database, focused on the collection handling the dictionary documents.
+type DictionaryDB struct {
+	client     *mongo.Client
+	mapper     *dictionaryMapper
+	collection *mongo.Collection
+}
+
+// NewMongoDBDictionaryRepository creates a repository.DictionaryRepository backed up by a MongoDB database.
+func NewMongoDBDictionaryRepository(client *mongo.Client, dbname string) *DictionaryDB {
+	return &DictionaryDB{
+		client:     client,
+		mapper:     &dictionaryMapper{},
+		collection: client.Database(dbname).Collection(dictionariesCollection),
+	}
+}
+
+// Add transforms and stores a Dictionary entity into a document on the underlying MongoDB collection.
+func (ddb *DictionaryDB) Add(ctx context.Context, dict entity.Dictionary) error {
+	dto := ddb.mapper.toDTO(dict)
+	dto.WorkspaceID = workspaceOf(ctx)
+	_, err := ddb.collection.InsertOne(ctx, dto)
+	if err != nil {
+		log.WithError(err).Errorf("error inserting dictionary %v", dict.ID)
+		return repository.ErrDictionaryUnexpected
+	}
+
+	return nil
+}
+
+// Get finds an existing Dictionary by ID.
+func (ddb *DictionaryDB) Get(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error) {
+	return ddb.find(ctx, bson.M{"_id": ID.String()})
+}
+
+// GetByScope finds the existing Dictionary defined for the given scope and project reference.
+func (ddb *DictionaryDB) GetByScope(ctx context.Context, scope string, projectRef string) (entity.Dictionary, error) {
+	if scope == entity.DictionaryScopeOrganization {
+		projectRef = ""
+	}
+
+	return ddb.find(ctx, bson.M{"scope": scope, "project_ref": projectRef})
+}
+
+func (ddb *DictionaryDB) find(ctx context.Context, filter bson.M) (entity.Dictionary, error) {
+	res := ddb.collection.FindOne(ctx, inWorkspace(ctx, filter))
+	switch res.Err() {
+	case nil:
+		// do nothing
+	case mongo.ErrNoDocuments:
+		return entity.Dictionary{}, repository.ErrDictionaryNoResults
+	default:
+		log.WithError(res.Err()).Errorf("error searching dictionary with filter: %v", filter)
+		return entity.Dictionary{}, repository.ErrDictionaryUnexpected
+	}
+
+	var dto dictionaryDTO
+	if err := res.Decode(&dto); err != nil {
+		log.WithError(err).Errorf("error decoding result for dictionary with filter: %v", filter)
+		return entity.Dictionary{}, repository.ErrDictionaryUnexpected
+	}
+
+	return ddb.mapper.toEntity(dto), nil
+}
+
+// FindAll retrieves every existing Dictionary on the underlying MongoDB collection.
+func (ddb *DictionaryDB) FindAll(ctx context.Context) ([]entity.Dictionary, error) {
+	cursor, err := ddb.collection.Find(ctx, inWorkspace(ctx, bson.M{}))
+	if err != nil {
+		log.WithError(err).Error("error searching dictionaries")
+		return []entity.Dictionary{}, repository.ErrDictionaryUnexpected
+	}
+
+	var elements []dictionaryDTO
+	err = cursor.All(ctx, &elements)
+	if err != nil {
+		log.WithError(err).Error("error decoding found dictionary documents")
+		return []entity.Dictionary{}, repository.ErrDictionaryUnexpected
+	}
+
+	dicts := make([]entity.Dictionary, len(elements))
+	for i, element := range elements {
+		dicts[i] = ddb.mapper.toEntity(element)
+	}
+
+	return dicts, nil
+}
+
+// Update replaces an existing Dictionary on the underlying MongoDB collection.
+func (ddb *DictionaryDB) Update(ctx context.Context, dict entity.Dictionary) error {
+	dto := ddb.mapper.toDTO(dict)
+	dto.WorkspaceID = workspaceOf(ctx)
+	results, err := ddb.collection.ReplaceOne(ctx, inWorkspace(ctx, bson.M{"_id": dict.ID.String()}), dto)
+	if err != nil {
+		log.WithError(err).Errorf("error updating dictionary with id: %v", dict.ID)
+		return repository.ErrDictionaryUnexpected
+	}
+
+	if results.MatchedCount == 0 {
+		return repository.ErrDictionaryNoResults
+	}
+	return nil
+}
+
+// Delete removes an existing Dictionary from the underlying MongoDB collection.
+func (ddb *DictionaryDB) Delete(ctx context.Context, ID uuid.UUID) error {
+	results, err := ddb.collection.DeleteOne(ctx, inWorkspace(ctx, bson.M{"_id": ID.String()}))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting dictionary with id: %v", ID)
+		return repository.ErrDictionaryUnexpected
+	}
+
+	if results.DeletedCount == 0 {
+		return repository.ErrDictionaryNoResults
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // identifierMapper maps an Identifier between its model and database representations.
@@ -60,10 +61,15 @@ func (im *identifierMapper) toDTO(ent entity.Identifier, analysisEnt entity.Anal
 		ID:              ent.ID,
 		Package:         ent.Package,
 		AbsolutePackage: ent.FullPackageName(),
+		Language:        string(ent.Language),
 		File:            ent.File,
 		Position:        ent.Position,
+		Line:            ent.Line,
+		Column:          ent.Column,
 		Name:            ent.Name,
 		Type:            im.fromTokenToString(ent.Type),
+		Receiver:        ent.Receiver,
+		ReceiverName:    ent.ReceiverName,
 		AnalysisID:      analysisEnt.ID.String(),
 		ProjectRef:      analysisEnt.ProjectName,
 		CreatedAt:       time.Now(),
@@ -115,6 +121,17 @@ func (im *identifierMapper) toDTO(ent entity.Identifier, analysisEnt entity.Anal
 	dto.Expansions = expansions
 	dto.JoinedExpansions = joinedExpansions
 
+	findings := make([]findingDTO, len(ent.Findings))
+	for i, findingEnt := range ent.Findings {
+		findings[i] = findingDTO{
+			Rule:       findingEnt.Rule,
+			Severity:   string(findingEnt.Severity),
+			Message:    findingEnt.Message,
+			Suggestion: findingEnt.Suggestion,
+		}
+	}
+	dto.Findings = findings
+
 	return dto
 }
 
@@ -146,41 +163,64 @@ func (im *identifierMapper) toEntity(dto identifierDTO) entity.Identifier {
 		expansions[alg] = items
 	}
 
+	findings := make([]entity.Finding, len(dto.Findings))
+	for i, findingDto := range dto.Findings {
+		findings[i] = entity.Finding{
+			Rule:       findingDto.Rule,
+			Severity:   entity.Severity(findingDto.Severity),
+			Message:    findingDto.Message,
+			Suggestion: findingDto.Suggestion,
+		}
+	}
+
 	var err error
 	if dto.Error != "" {
 		err = errors.New(dto.Error)
 	}
 
 	return entity.Identifier{
-		ID:         dto.ID,
-		ProjectRef: dto.ProjectRef,
-		AnalysisID: uuid.MustParse(dto.AnalysisID),
-		Package:    dto.Package,
-		File:       dto.File,
-		Position:   dto.Position,
-		Name:       dto.Name,
-		Type:       im.fromStringToToken(dto.Type),
-		Node:       nil,
-		Splits:     splits,
-		Expansions: expansions,
-		Error:      err,
+		ID:           dto.ID,
+		ProjectRef:   dto.ProjectRef,
+		AnalysisID:   uuid.MustParse(dto.AnalysisID),
+		Package:      dto.Package,
+		Language:     entity.Language(dto.Language),
+		File:         dto.File,
+		Position:     dto.Position,
+		Line:         dto.Line,
+		Column:       dto.Column,
+		Name:         dto.Name,
+		Type:         im.fromStringToToken(dto.Type),
+		Receiver:     dto.Receiver,
+		ReceiverName: dto.ReceiverName,
+		Node:         nil,
+		Splits:       splits,
+		Expansions:   expansions,
+		Error:        err,
 		Normalization: entity.Normalization{
 			Word:      dto.Normalization.Word,
 			Algorithm: dto.Normalization.Algorithm,
 			Score:     dto.Normalization.Score,
 		},
+		Findings: findings,
 	}
 }
 
 // identifierDTO is the database representation for an Identifier.
 type identifierDTO struct {
+	ObjectID         primitive.ObjectID        `bson:"_id,omitempty"`
 	ID               string                    `bson:"identifier_id"`
+	WorkspaceID      string                    `bson:"workspace_id"`
 	Package          string                    `bson:"package"`
 	AbsolutePackage  string                    `bson:"absolute_package"`
+	Language         string                    `bson:"language,omitempty"`
 	File             string                    `bson:"file"`
 	Position         token.Pos                 `bson:"position"`
+	Line             int                       `bson:"line"`
+	Column           int                       `bson:"column"`
 	Name             string                    `bson:"name"`
 	Type             string                    `bson:"type"`
+	Receiver         string                    `bson:"receiver,omitempty"`
+	ReceiverName     string                    `bson:"receiver_name,omitempty"`
 	Splits           map[string][]splitDTO     `bson:"splits"`
 	JoinedSplits     map[string]string         `bson:"joined_splits"`
 	Expansions       map[string][]expansionDTO `bson:"expansions"`
@@ -190,7 +230,9 @@ type identifierDTO struct {
 	ProjectRef       string                    `bson:"project_ref"`
 	CreatedAt        time.Time                 `bson:"created_at"`
 	Exported         bool                      `bson:"is_exported"`
+	Staged           bool                      `bson:"staged,omitempty"`
 	Normalization    normalizationDTO          `bson:"normalization"`
+	Findings         []findingDTO              `bson:"findings"`
 }
 
 // splitDTO is the database representation for an Identifier's Split results.
@@ -213,3 +255,11 @@ type normalizationDTO struct {
 	Algorithm string  `bson:"algorithm"`
 	Score     float64 `bson:"score"`
 }
+
+// findingDTO is the database representation for an Identifier's naming-convention Finding.
+type findingDTO struct {
+	Rule       string `bson:"rule"`
+	Severity   string `bson:"severity"`
+	Message    string `bson:"message"`
+	Suggestion string `bson:"suggestion,omitempty"`
+}
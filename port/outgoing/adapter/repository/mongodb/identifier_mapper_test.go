@@ -41,6 +41,8 @@ func TestToDTO_OnIdentifierMapper_ShouldReturnIdentifierDTO(t *testing.T) {
 		Package:  "impl",
 		File:     "cmd/siva/impl/list.go",
 		Position: token.Pos(194),
+		Line:     9,
+		Column:   6,
 		Name:     "defaultOutput",
 		Type:     token.VAR,
 		Splits: map[string][]entity.Split{
@@ -74,6 +76,8 @@ func TestToDTO_OnIdentifierMapper_ShouldReturnIdentifierDTO(t *testing.T) {
 	assert.Equal(t, "cmd/siva/impl", dto.AbsolutePackage)
 	assert.Equal(t, "cmd/siva/impl/list.go", dto.File)
 	assert.Equal(t, token.Pos(194), dto.Position)
+	assert.Equal(t, 9, dto.Line)
+	assert.Equal(t, 6, dto.Column)
 	assert.Equal(t, "defaultOutput", dto.Name)
 	assert.Equal(t, "var", dto.Type)
 	assert.Equal(t, 1, len(dto.Splits))
@@ -104,6 +108,8 @@ func TestToEntity_OnIdentifierMapper_ShouldReturnIdentifierEntity(t *testing.T)
 		AbsolutePackage: "cmd/siva/impl",
 		File:            "cmd/siva/impl/list.go",
 		Position:        194,
+		Line:            9,
+		Column:          6,
 		Name:            "defaultOutput",
 		Type:            "var",
 		Splits: map[string][]splitDTO{
@@ -143,6 +149,8 @@ func TestToEntity_OnIdentifierMapper_ShouldReturnIdentifierEntity(t *testing.T)
 	assert.Equal(t, "cmd/siva/impl", ent.FullPackageName())
 	assert.Equal(t, "cmd/siva/impl/list.go", ent.File)
 	assert.Equal(t, token.Pos(194), ent.Position)
+	assert.Equal(t, 9, ent.Line)
+	assert.Equal(t, 6, ent.Column)
 	assert.Equal(t, "defaultOutput", ent.Name)
 	assert.Equal(t, token.VAR, ent.Type)
 	assert.Equal(t, 1, len(ent.Splits))
@@ -162,3 +170,55 @@ func TestToEntity_OnIdentifierMapper_ShouldReturnIdentifierEntity(t *testing.T)
 	assert.Equal(t, "conserv+no_exp", ent.Normalization.Algorithm)
 	assert.Equal(t, 0.99, ent.Normalization.Score)
 }
+
+func TestToDTO_OnIdentifierMapperWithFindings_ShouldReturnIdentifierDTOWithFindings(t *testing.T) {
+	identifier := entity.Identifier{
+		ID:           "filename:main.go+++pkg:main+++declType:func+++name:repo.GetUrl",
+		Package:      "main",
+		File:         "main.go",
+		Name:         "GetUrl",
+		Type:         token.FUNC,
+		Receiver:     "repo",
+		ReceiverName: "r",
+		Findings: []entity.Finding{
+			{Rule: "initialisms", Severity: entity.SeverityWarning, Message: "initialisms Url should have a consistent case", Suggestion: "GetURL"},
+			{Rule: "getter-prefix", Severity: entity.SeverityInfo, Message: "getter repo.GetUrl should be named Url", Suggestion: "Url"},
+		},
+	}
+
+	im := &identifierMapper{}
+	dto := im.toDTO(identifier, entity.AnalysisResults{ID: uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be")})
+
+	assert.Equal(t, "repo", dto.Receiver)
+	assert.Equal(t, "r", dto.ReceiverName)
+	assert.EqualValues(t, []findingDTO{
+		{Rule: "initialisms", Severity: "warning", Message: "initialisms Url should have a consistent case", Suggestion: "GetURL"},
+		{Rule: "getter-prefix", Severity: "info", Message: "getter repo.GetUrl should be named Url", Suggestion: "Url"},
+	}, dto.Findings)
+
+	ent := im.toEntity(dto)
+
+	assert.Equal(t, "repo", ent.Receiver)
+	assert.Equal(t, "r", ent.ReceiverName)
+	assert.EqualValues(t, identifier.Findings, ent.Findings)
+}
+
+func TestToDTO_OnIdentifierMapperWithLanguage_ShouldReturnIdentifierDTOWithLanguage(t *testing.T) {
+	identifier := entity.Identifier{
+		ID:       "filename:datos/datos.go+++pkg:datos+++declType:func+++name:guardar",
+		Package:  "datos",
+		File:     "datos/datos.go",
+		Name:     "guardar",
+		Type:     token.FUNC,
+		Language: entity.LanguageSpanish,
+	}
+
+	im := &identifierMapper{}
+	dto := im.toDTO(identifier, entity.AnalysisResults{ID: uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be")})
+
+	assert.Equal(t, "es", dto.Language)
+
+	ent := im.toEntity(dto)
+
+	assert.Equal(t, entity.LanguageSpanish, ent.Language)
+}
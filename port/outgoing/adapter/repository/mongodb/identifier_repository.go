@@ -3,22 +3,37 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"go/token"
+	"regexp"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/repository"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const identifiersCollection string = "identifiers"
 
+const stagedAnalysesCollection string = "staged_analyses"
+
+// DefaultBatchSize is the default number of identifiers stored on each InsertMany operation.
+const DefaultBatchSize = 1000
+
 // IdentifierDB represents a MongoDB database, focused on the collection handling the identifiers documents.
 type IdentifierDB struct {
 	client     *mongo.Client
 	mapper     *identifierMapper
 	collection *mongo.Collection
+	staged     *mongo.Collection
+	// BatchSize sets the maximum number of documents inserted on each InsertMany operation.
+	BatchSize int
+	// Ordered sets if batches should stop after the first failed insertion.
+	Ordered bool
 }
 
 // NewMongoDBIdentifierRepository creates a repository.IdentifierRepository backed up by a MongoDB database.
@@ -27,12 +42,25 @@ func NewMongoDBIdentifierRepository(client *mongo.Client, dbname string) *Identi
 		client:     client,
 		mapper:     &identifierMapper{},
 		collection: client.Database(dbname).Collection(identifiersCollection),
+		staged:     client.Database(dbname).Collection(stagedAnalysesCollection),
+		BatchSize:  DefaultBatchSize,
+		Ordered:    true,
 	}
 }
 
-// Add transforms and stores an Identifier entity into a document on the underlying MongoDB collection.
+// notStaged filters the documents for identifiers that were committed. Documents stored before staging was
+// introduced don't include the field, and they are considered committed.
+var notStaged = bson.M{"$ne": true}
+
+// algorithmName matches the names of the splitting and expansion algorithms, which key the splits and expansions
+// of each document.
+var algorithmName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// Add transforms and stores an Identifier entity into a staged document on the underlying MongoDB collection.
 func (idb *IdentifierDB) Add(ctx context.Context, analysis entity.AnalysisResults, ident entity.Identifier) error {
 	dto := idb.mapper.toDTO(ident, analysis)
+	dto.WorkspaceID = workspaceOf(ctx)
+	dto.Staged = true
 	_, err := idb.collection.InsertOne(ctx, dto)
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("error inserting record %v", ident))
@@ -42,9 +70,89 @@ func (idb *IdentifierDB) Add(ctx context.Context, analysis entity.AnalysisResult
 	return nil
 }
 
+// AddAll transforms and stores a set of Identifier entities as staged documents, using InsertMany operations of up
+// to BatchSize documents. Failed insertions are reported with a *repository.BatchError.
+func (idb *IdentifierDB) AddAll(ctx context.Context, analysis entity.AnalysisResults, idents []entity.Identifier) error {
+	size := idb.BatchSize
+	if size < 1 {
+		size = DefaultBatchSize
+	}
+
+	batchErr := &repository.BatchError{Ordered: idb.Ordered, Failed: make([]int, 0)}
+	for start := 0; start < len(idents); start += size {
+		end := start + size
+		if end > len(idents) {
+			end = len(idents)
+		}
+
+		docs := make([]interface{}, 0, end-start)
+		for _, ident := range idents[start:end] {
+			dto := idb.mapper.toDTO(ident, analysis)
+			dto.WorkspaceID = workspaceOf(ctx)
+			dto.Staged = true
+			docs = append(docs, dto)
+		}
+
+		_, err := idb.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(idb.Ordered))
+		if err == nil {
+			batchErr.Inserted += len(docs)
+			continue
+		}
+
+		failed, stopped := batchFailures(err, start, end, idb.Ordered)
+		log.WithError(err).Errorf("error inserting %d of %d identifiers for analysis ID %v", len(failed), len(docs),
+			analysis.ID)
+		batchErr.Inserted += len(docs) - len(failed)
+		batchErr.Failed = append(batchErr.Failed, failed...)
+		if stopped {
+			for i := end; i < len(idents); i++ {
+				batchErr.Failed = append(batchErr.Failed, i)
+			}
+			return batchErr
+		}
+	}
+
+	if len(batchErr.Failed) > 0 {
+		return batchErr
+	}
+
+	return nil
+}
+
+// batchFailures retrieves the indexes of the identifiers that failed on the batch going from start to end, and
+// whether the following batches must be skipped. Only the documents reported by a write error failed, unless the
+// batch is ordered, in which case the documents after the last write error weren't inserted either. If the error
+// isn't a write error, or the write concern wasn't satisfied, the insertion can't be confirmed for any document on
+// the batch, so every document is considered failed.
+func batchFailures(err error, start int, end int, ordered bool) ([]int, bool) {
+	failed := make([]int, 0, end-start)
+	bwe, ok := err.(mongo.BulkWriteException)
+	if !ok || len(bwe.WriteErrors) == 0 || bwe.WriteConcernError != nil {
+		for i := start; i < end; i++ {
+			failed = append(failed, i)
+		}
+		return failed, ordered || !ok
+	}
+
+	last := 0
+	for _, we := range bwe.WriteErrors {
+		failed = append(failed, start+we.Index)
+		if we.Index > last {
+			last = we.Index
+		}
+	}
+	if ordered {
+		for i := start + last + 1; i < end; i++ {
+			failed = append(failed, i)
+		}
+	}
+
+	return failed, ordered
+}
+
 // FindAllByAnalysisID retrieves all the identifiers related to a given analysis, from the underlying MongoDB collection.
 func (idb *IdentifierDB) FindAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Identifier, error) {
-	cursor, err := idb.collection.Find(ctx, bson.M{"analysis_id": analysisID.String()})
+	cursor, err := idb.collection.Find(ctx, inWorkspace(ctx, bson.M{"analysis_id": analysisID.String(), "staged": notStaged}))
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("error looking documents for analysis ID %v", analysisID))
 		return []entity.Identifier{}, repository.ErrIdentifierUnexpected
@@ -65,9 +173,196 @@ func (idb *IdentifierDB) FindAllByAnalysisID(ctx context.Context, analysisID uui
 	return identifiers, nil
 }
 
+// IterateByAnalysisID retrieves an iterator over the identifiers related to a given analysis. Documents are decoded
+// one at a time, as the iterator advances over the underlying MongoDB cursor.
+func (idb *IdentifierDB) IterateByAnalysisID(ctx context.Context, analysisID uuid.UUID) (repository.IdentifierIterator, error) {
+	cursor, err := idb.collection.Find(ctx, inWorkspace(ctx, bson.M{"analysis_id": analysisID.String(), "staged": notStaged}))
+	if err != nil {
+		log.WithError(err).Error(fmt.Sprintf("error looking documents for analysis ID %v", analysisID))
+		return nil, repository.ErrIdentifierUnexpected
+	}
+
+	return &identifierCursor{cursor: cursor, mapper: idb.mapper}, nil
+}
+
+// identifierCursor implements repository.IdentifierIterator over a MongoDB cursor.
+type identifierCursor struct {
+	cursor  *mongo.Cursor
+	mapper  *identifierMapper
+	current entity.Identifier
+	err     error
+}
+
+func (c *identifierCursor) Next(ctx context.Context) bool {
+	if c.err != nil || !c.cursor.Next(ctx) {
+		return false
+	}
+
+	var dto identifierDTO
+	if err := c.cursor.Decode(&dto); err != nil {
+		log.WithError(err).Error("error decoding identifier document")
+		c.err = repository.ErrIdentifierUnexpected
+		return false
+	}
+	c.current = c.mapper.toEntity(dto)
+
+	return true
+}
+
+func (c *identifierCursor) Identifier() entity.Identifier {
+	return c.current
+}
+
+func (c *identifierCursor) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+
+	if err := c.cursor.Err(); err != nil {
+		log.WithError(err).Error("error iterating identifier documents")
+		return repository.ErrIdentifierUnexpected
+	}
+
+	return nil
+}
+
+func (c *identifierCursor) Close(ctx context.Context) error {
+	return c.cursor.Close(ctx)
+}
+
+// QueryByAnalysisID retrieves a page of the committed identifiers related to a given analysis, matching and sorted
+// by the given query. Identifiers with the same score are sorted by their document ID.
+func (idb *IdentifierDB) QueryByAnalysisID(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error) {
+	filter := inWorkspace(ctx, bson.M{"analysis_id": analysisID.String(), "staged": notStaged})
+	if query.Package != "" {
+		filter["package"] = query.Package
+	}
+	if query.File != "" {
+		filter["file"] = query.File
+	}
+	if query.Type != token.ILLEGAL {
+		filter["type"] = idb.mapper.fromTokenToString(query.Type)
+	}
+	if query.Exported != nil {
+		filter["is_exported"] = *query.Exported
+	}
+	score := bson.M{}
+	if query.MinScore != nil {
+		score["$gte"] = *query.MinScore
+	}
+	if query.MaxScore != nil {
+		score["$lte"] = *query.MaxScore
+	}
+	if len(score) > 0 {
+		filter["normalization.score"] = score
+	}
+	for field, algorithm := range map[string]string{"splits": query.Splitter, "expansions": query.Expander} {
+		if algorithm == "" {
+			continue
+		}
+		// the name becomes part of a field path, so only plain algorithm names are accepted
+		if !algorithmName.MatchString(algorithm) {
+			return entity.IdentifierPage{Identifiers: []entity.Identifier{}}, nil
+		}
+		filter[field+"."+algorithm] = bson.M{"$exists": true}
+	}
+	if query.Name != nil {
+		// the pattern is matched by the server, keeping its anchors so an anchored pattern can use the index; it's
+		// matched again while reading the documents, since the server regular expressions aren't RE2
+		filter["name"] = primitive.Regex{Pattern: query.Name.String()}
+	}
+
+	sort := bson.D{{Key: "_id", Value: 1}}
+	scoreOperator := "$gt"
+	switch query.Sort {
+	case entity.IdentifierSortScore:
+		sort = bson.D{{Key: "normalization.score", Value: 1}, {Key: "_id", Value: 1}}
+	case entity.IdentifierSortScoreDesc:
+		sort = bson.D{{Key: "normalization.score", Value: -1}, {Key: "_id", Value: 1}}
+		scoreOperator = "$lt"
+	}
+
+	if query.Cursor != "" {
+		cursor, err := repository.DecodeIdentifierCursor(query.Cursor)
+		if err != nil {
+			return entity.IdentifierPage{}, err
+		}
+
+		lastID, err := primitive.ObjectIDFromHex(cursor.Key)
+		if err != nil {
+			return entity.IdentifierPage{}, repository.ErrIdentifierInvalidCursor
+		}
+
+		after := bson.M{"_id": bson.M{"$gt": lastID}}
+		if query.Sort != entity.IdentifierSortDefault {
+			after = bson.M{"$or": bson.A{
+				bson.M{"normalization.score": bson.M{scoreOperator: cursor.Score}},
+				bson.M{"normalization.score": cursor.Score, "_id": bson.M{"$gt": lastID}},
+			}}
+		}
+		filter = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	// the documents are read until the page is complete, so a name rejected while reading doesn't shorten the page
+	opts := options.Find().SetSort(sort)
+	if query.Limit > 0 {
+		opts.SetBatchSize(int32(query.Limit) + 1)
+		if query.Name == nil {
+			opts.SetLimit(int64(query.Limit) + 1)
+		}
+	}
+
+	cursor, err := idb.collection.Find(ctx, filter, opts)
+	if err != nil {
+		log.WithError(err).Errorf("error looking documents for analysis ID %v with filter %v", analysisID, filter)
+		return entity.IdentifierPage{}, repository.ErrIdentifierUnexpected
+	}
+
+	elements := make([]identifierDTO, 0)
+	for cursor.Next(ctx) {
+		var dto identifierDTO
+		if err = cursor.Decode(&dto); err != nil {
+			break
+		}
+
+		if query.Name != nil && !query.Name.MatchString(dto.Name) {
+			continue
+		}
+		elements = append(elements, dto)
+
+		if query.Limit > 0 && len(elements) > query.Limit {
+			break
+		}
+	}
+	if err == nil {
+		err = cursor.Err()
+	}
+	cursor.Close(ctx)
+	if err != nil {
+		log.WithError(err).Errorf("error decoding found documents for analysis ID %v", analysisID)
+		return entity.IdentifierPage{}, repository.ErrIdentifierUnexpected
+	}
+
+	page := entity.IdentifierPage{Identifiers: make([]entity.Identifier, 0, len(elements))}
+	for i, element := range elements {
+		if query.Limit > 0 && i == query.Limit {
+			last := elements[i-1]
+			page.NextCursor = repository.IdentifierCursor{
+				Score: last.Normalization.Score,
+				Key:   last.ObjectID.Hex(),
+			}.Encode()
+			break
+		}
+		page.Identifiers = append(page.Identifiers, idb.mapper.toEntity(element))
+	}
+
+	return page, nil
+}
+
 // FindAllByProjectAndFile retrieves all the identifiers for a file related to a given project, from the underlying MongoDB collection.
 func (idb *IdentifierDB) FindAllByProjectAndFile(ctx context.Context, projectRef string, filename string) ([]entity.Identifier, error) {
-	cursor, err := idb.collection.Find(ctx, bson.M{"project_ref": projectRef, "file": filename})
+	cursor, err := idb.collection.Find(ctx,
+		inWorkspace(ctx, bson.M{"project_ref": projectRef, "file": filename, "staged": notStaged}))
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("error looking documents for %s on file %s", projectRef, filename))
 		return []entity.Identifier{}, repository.ErrIdentifierUnexpected
@@ -88,9 +383,15 @@ func (idb *IdentifierDB) FindAllByProjectAndFile(ctx context.Context, projectRef
 	return identifiers, nil
 }
 
-// DeleteAllByAnalysisID removes a set of existing identifires from the underlying MongoDB collection.
+// DeleteAllByAnalysisID removes a set of existing identifires from the underlying MongoDB collection, along with the
+// in progress mark of their analysis.
 func (idb *IdentifierDB) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
-	results, err := idb.collection.DeleteMany(ctx, bson.M{"analysis_id": analysisID.String()})
+	if err := idb.unstage(ctx, analysisID); err != nil {
+		log.WithError(err).Errorf("error deleting staged analysis_id: %v", analysisID)
+		return repository.ErrIdentifierUnexpected
+	}
+
+	results, err := idb.collection.DeleteMany(ctx, inWorkspace(ctx, bson.M{"analysis_id": analysisID.String()}))
 	if err != nil {
 		log.WithError(err).Errorf("error deleting identifiers with analysis_id: %v", analysisID)
 		return repository.ErrIdentifierUnexpected
@@ -102,3 +403,81 @@ func (idb *IdentifierDB) DeleteAllByAnalysisID(ctx context.Context, analysisID u
 
 	return nil
 }
+
+// Commit makes the staged identifiers for a given analysis visible, on the underlying MongoDB collection.
+func (idb *IdentifierDB) Commit(ctx context.Context, analysisID uuid.UUID) error {
+	_, err := idb.collection.UpdateMany(ctx,
+		inWorkspace(ctx, bson.M{"analysis_id": analysisID.String(), "staged": true}),
+		bson.M{"$unset": bson.M{"staged": ""}})
+	if err != nil {
+		log.WithError(err).Errorf("error committing identifiers with analysis_id: %v", analysisID)
+		return repository.ErrIdentifierUnexpected
+	}
+
+	if err := idb.unstage(ctx, analysisID); err != nil {
+		log.WithError(err).Errorf("error deleting staged analysis_id: %v", analysisID)
+		return repository.ErrIdentifierUnexpected
+	}
+
+	return nil
+}
+
+// Stage marks the given analysis as in progress on the current workspace, or refreshes its mark.
+func (idb *IdentifierDB) Stage(ctx context.Context, analysisID uuid.UUID) error {
+	_, err := idb.staged.UpdateOne(ctx,
+		bson.M{"workspace_id": workspaceOf(ctx), "analysis_id": analysisID.String()},
+		bson.M{"$set": bson.M{"refreshed_at": time.Now()}},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.WithError(err).Errorf("error staging analysis_id: %v", analysisID)
+		return repository.ErrIdentifierUnexpected
+	}
+
+	return nil
+}
+
+// unstage removes the in progress mark of the given analysis on the current workspace.
+func (idb *IdentifierDB) unstage(ctx context.Context, analysisID uuid.UUID) error {
+	_, err := idb.staged.DeleteOne(ctx, bson.M{"workspace_id": workspaceOf(ctx), "analysis_id": analysisID.String()})
+	return err
+}
+
+// FindStagedAnalysisIDs retrieves the analysis IDs for the identifiers staged or marked as in progress before the
+// given time on the current workspace, leaving out the analyses whose mark was refreshed after it.
+func (idb *IdentifierDB) FindStagedAnalysisIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	active, err := idb.staged.Distinct(ctx, "analysis_id",
+		bson.M{"workspace_id": workspaceOf(ctx), "refreshed_at": bson.M{"$gte": before}})
+	if err != nil {
+		log.WithError(err).Errorf("error looking for analyses staged after %v", before)
+		return []uuid.UUID{}, repository.ErrIdentifierUnexpected
+	}
+
+	idle, err := idb.staged.Distinct(ctx, "analysis_id",
+		bson.M{"workspace_id": workspaceOf(ctx), "refreshed_at": bson.M{"$lt": before}})
+	if err != nil {
+		log.WithError(err).Errorf("error looking for analyses staged before %v", before)
+		return []uuid.UUID{}, repository.ErrIdentifierUnexpected
+	}
+
+	values, err := idb.collection.Distinct(ctx, "analysis_id", inWorkspace(ctx, bson.M{
+		"staged":      true,
+		"created_at":  bson.M{"$lt": before},
+		"analysis_id": bson.M{"$nin": append(active, idle...)},
+	}))
+	if err != nil {
+		log.WithError(err).Errorf("error looking for staged identifiers before %v", before)
+		return []uuid.UUID{}, repository.ErrIdentifierUnexpected
+	}
+
+	ids := make([]uuid.UUID, 0, len(idle)+len(values))
+	for _, value := range append(idle, values...) {
+		id, err := uuid.Parse(fmt.Sprintf("%v", value))
+		if err != nil {
+			log.WithError(err).Warnf("invalid analysis_id %v on staged identifiers", value)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
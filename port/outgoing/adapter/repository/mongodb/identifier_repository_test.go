@@ -0,0 +1,72 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestBatchFailures_OnUnorderedBatch_ShouldReturnWriteErrors(t *testing.T) {
+	err := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 1}},
+		{WriteError: mongo.WriteError{Index: 3}},
+	}}
+
+	failed, stopped := batchFailures(err, 10, 15, false)
+
+	assert.Equal(t, []int{11, 13}, failed)
+	assert.False(t, stopped)
+}
+
+func TestBatchFailures_OnOrderedBatch_ShouldReturnEveryDocumentFromWriteError(t *testing.T) {
+	err := mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: mongo.WriteError{Index: 2}}}}
+
+	failed, stopped := batchFailures(err, 10, 15, true)
+
+	assert.Equal(t, []int{12, 13, 14}, failed)
+	assert.True(t, stopped)
+}
+
+func TestBatchFailures_WhenWriteConcernError_ShouldReturnEveryDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     mongo.BulkWriteException
+		ordered bool
+	}{
+		{"unordered_without_write_errors", mongo.BulkWriteException{WriteConcernError: &mongo.WriteConcernError{}}, false},
+		{"ordered_without_write_errors", mongo.BulkWriteException{WriteConcernError: &mongo.WriteConcernError{}}, true},
+		{"unordered_with_write_errors", mongo.BulkWriteException{
+			WriteConcernError: &mongo.WriteConcernError{},
+			WriteErrors:       []mongo.BulkWriteError{{WriteError: mongo.WriteError{Index: 1}}},
+		}, false},
+		{"unordered_without_errors", mongo.BulkWriteException{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failed, stopped := batchFailures(tt.err, 10, 13, tt.ordered)
+
+			assert.Equal(t, []int{10, 11, 12}, failed)
+			assert.Equal(t, tt.ordered, stopped)
+		})
+	}
+}
+
+func TestBatchFailures_WhenUnexpectedError_ShouldReturnEveryDocumentAndStop(t *testing.T) {
+	failed, stopped := batchFailures(errors.New("connection reset"), 10, 12, false)
+
+	assert.Equal(t, []int{10, 11}, failed)
+	assert.True(t, stopped)
+}
+
+func TestAlgorithmName_ShouldOnlyMatchPlainNames(t *testing.T) {
+	for _, name := range []string{"conserv", "greedy", "noexp", "dictionary"} {
+		assert.True(t, algorithmName.MatchString(name), name)
+	}
+
+	for _, name := range []string{"greedy.value", "$where", "conserv\x00", "Samurai", ""} {
+		assert.False(t, algorithmName.MatchString(name), name)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
 )
 
 // insightMapper maps an entity.Insight between its model and database representations.
@@ -32,6 +33,7 @@ func (im *insightMapper) toDTO(ent entity.Insight) insightDTO {
 		AnalysisID:       ent.AnalysisID.String(),
 		CreatedAt:        time.Now(),
 		Package:          ent.Package,
+		Language:         string(ent.Language),
 		Accuracy:         ent.Rate(),
 		TotalIdentifiers: ent.TotalIdentifiers,
 		TotalExported:    ent.TotalExported,
@@ -41,19 +43,52 @@ func (im *insightMapper) toDTO(ent entity.Insight) insightDTO {
 		AvgExpansions:    avgExpansions,
 		TotalWeight:      ent.TotalWeight,
 		Files:            files,
+		Test:             ent.Test,
 	}
 }
 
+// toEntity maps the Data Transfer Object for entity.Insight into its entity representation.
 func (im *insightMapper) toEntity(dto insightDTO) entity.Insight {
-	return entity.Insight{}
+	files := make(map[string]struct{}, len(dto.Files))
+	for _, file := range dto.Files {
+		files[file] = struct{}{}
+	}
+
+	totalSplits := dto.TotalSplits
+	if totalSplits == nil {
+		totalSplits = make(map[string]int)
+	}
+
+	totalExpansions := dto.TotalExpansions
+	if totalExpansions == nil {
+		totalExpansions = make(map[string]int)
+	}
+
+	analysisID, _ := uuid.Parse(dto.AnalysisID)
+	return entity.Insight{
+		ID:               dto.ID,
+		ProjectRef:       dto.ProjectRef,
+		AnalysisID:       analysisID,
+		Package:          dto.Package,
+		Language:         entity.Language(dto.Language),
+		TotalIdentifiers: dto.TotalIdentifiers,
+		TotalExported:    dto.TotalExported,
+		TotalSplits:      totalSplits,
+		TotalExpansions:  totalExpansions,
+		TotalWeight:      dto.TotalWeight,
+		Files:            files,
+		Test:             dto.Test,
+	}
 }
 
 type insightDTO struct {
 	ID               string             `bson:"_id,omitempty"`
+	WorkspaceID      string             `bson:"workspace_id"`
 	CreatedAt        time.Time          `bson:"created_at"`
 	ProjectRef       string             `bson:"project_ref"`
 	AnalysisID       string             `bson:"analysis_id"`
 	Package          string             `bson:"package"`
+	Language         string             `bson:"language,omitempty"`
 	Accuracy         float64            `bson:"accuracy"`
 	TotalIdentifiers int                `bson:"total_identifiers"`
 	TotalExported    int                `bson:"total_exported"`
@@ -63,4 +98,5 @@ type insightDTO struct {
 	AvgExpansions    map[string]float64 `bson:"avg_expansions"`
 	TotalWeight      float64            `bson:"total_weight"`
 	Files            []string           `bson:"files"`
+	Test             bool               `bson:"test,omitempty"`
 }
@@ -25,6 +25,7 @@ func TestToDTO_OnInsightMapper_ShouldReturnInsightDTO(t *testing.T) {
 			"main.go":   {},
 			"helper.go": {},
 		},
+		Test: true,
 	}
 
 	im := &insightMapper{}
@@ -51,4 +52,39 @@ func TestToDTO_OnInsightMapper_ShouldReturnInsightDTO(t *testing.T) {
 	}, dto.AvgExpansions)
 	assert.Equal(t, 2.267, dto.TotalWeight)
 	assert.ElementsMatch(t, []string{"main.go", "helper.go"}, dto.Files)
+	assert.True(t, dto.Test)
+}
+
+func TestToEntity_OnInsightMapper_ShouldReturnInsightEntity(t *testing.T) {
+	dto := insightDTO{
+		ID:               "5f0b4f6b9d2b4e1c8c1a2b3c",
+		ProjectRef:       "eroatta/test",
+		AnalysisID:       "f9b76fde-c342-4328-8650-85da8f21e2be",
+		Package:          "main",
+		Language:         "es",
+		Accuracy:         0.5,
+		TotalIdentifiers: 3,
+		TotalExported:    1,
+		TotalSplits:      map[string]int{"conserv": 5},
+		TotalExpansions:  map[string]int{"noexp": 5},
+		TotalWeight:      1.5,
+		Files:            []string{"main.go", "test.go"},
+	}
+
+	im := &insightMapper{}
+	ent := im.toEntity(dto)
+
+	assert.Equal(t, "5f0b4f6b9d2b4e1c8c1a2b3c", ent.ID)
+	assert.Equal(t, "eroatta/test", ent.ProjectRef)
+	assert.Equal(t, "f9b76fde-c342-4328-8650-85da8f21e2be", ent.AnalysisID.String())
+	assert.Equal(t, "main", ent.Package)
+	assert.Equal(t, entity.LanguageSpanish, ent.Language)
+	assert.Equal(t, 3, ent.TotalIdentifiers)
+	assert.Equal(t, 1, ent.TotalExported)
+	assert.Equal(t, map[string]int{"conserv": 5}, ent.TotalSplits)
+	assert.Equal(t, map[string]int{"noexp": 5}, ent.TotalExpansions)
+	assert.Equal(t, 1.5, ent.TotalWeight)
+	assert.Equal(t, 0.5, ent.Rate())
+	assert.Equal(t, map[string]struct{}{"main.go": {}, "test.go": {}}, ent.Files)
+	assert.False(t, ent.Test)
 }
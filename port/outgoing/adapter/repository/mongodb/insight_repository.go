@@ -34,7 +34,9 @@ func NewMongoDBInsightRepository(client *mongo.Client, dbname string) *InsightDB
 func (idb *InsightDB) AddAll(ctx context.Context, insights []entity.Insight) error {
 	dtos := make([]interface{}, 0)
 	for _, insight := range insights {
-		dtos = append(dtos, idb.mapper.toDTO(insight))
+		dto := idb.mapper.toDTO(insight)
+		dto.WorkspaceID = workspaceOf(ctx)
+		dtos = append(dtos, dto)
 	}
 
 	results, err := idb.collection.InsertMany(ctx, dtos)
@@ -50,7 +52,7 @@ func (idb *InsightDB) AddAll(ctx context.Context, insights []entity.Insight) err
 // GetByAnalysisID finds a set of existing insights on the underlying MongoDB collection, and returns
 // them as entity.Insight.
 func (idb *InsightDB) GetByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Insight, error) {
-	cursor, err := idb.collection.Find(ctx, bson.M{"analysis_id": analysisID.String()})
+	cursor, err := idb.collection.Find(ctx, inWorkspace(ctx, bson.M{"analysis_id": analysisID.String()}))
 	switch err {
 	case nil:
 		// do nothing
@@ -68,6 +70,10 @@ func (idb *InsightDB) GetByAnalysisID(ctx context.Context, analysisID uuid.UUID)
 		return []entity.Insight{}, repository.ErrInsightUnexpected
 	}
 
+	if len(elements) == 0 {
+		return []entity.Insight{}, repository.ErrInsightNoResults
+	}
+
 	insights := make([]entity.Insight, len(elements))
 	for i, element := range elements {
 		insights[i] = idb.mapper.toEntity(element)
@@ -78,7 +84,7 @@ func (idb *InsightDB) GetByAnalysisID(ctx context.Context, analysisID uuid.UUID)
 
 // DeleteAllByAnalysisID removes a set of existing insights from the underlying MongoDB collection.
 func (idb *InsightDB) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
-	results, err := idb.collection.DeleteMany(ctx, bson.M{"analysis_id": analysisID.String()})
+	results, err := idb.collection.DeleteMany(ctx, inWorkspace(ctx, bson.M{"analysis_id": analysisID.String()}))
 	if err != nil {
 		log.WithError(err).Errorf("error deleting insights with analysis_id: %v", analysisID)
 		return repository.ErrInsightUnexpected
@@ -0,0 +1,98 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+// jobMapper maps an AnalysisJob between its model and database representations.
+type jobMapper struct{}
+
+// toDTO maps the entity for AnalysisJob into a Data Transfer Object.
+func (jm *jobMapper) toDTO(ent entity.AnalysisJob) jobDTO {
+	dto := jobDTO{
+		ID:            ent.ID.String(),
+		ProjectID:     ent.ProjectID.String(),
+		AnalysisID:    ent.AnalysisID.String(),
+		Commit:        ent.Commit,
+		Attempt:       ent.Attempt,
+		Status:        ent.Status,
+		Error:         ent.Error,
+		Owner:         ent.Owner,
+		DateStarted:   ent.DateStarted,
+		DateRefreshed: ent.DateRefreshed,
+	}
+	if ent.Pipeline != nil {
+		dto.Pipeline = &pipelineDTO{
+			Miners:       ent.Pipeline.Miners,
+			Splitters:    ent.Pipeline.Splitters,
+			Expanders:    ent.Pipeline.Expanders,
+			Rules:        ent.Pipeline.Rules,
+			IncludeTests: ent.Pipeline.IncludeTests,
+		}
+	}
+
+	return dto
+}
+
+// toEntity maps the Data Transfer Object for AnalysisJob into a domain entity.
+func (jm *jobMapper) toEntity(dto jobDTO) entity.AnalysisJob {
+	job := entity.AnalysisJob{
+		ID:            uuid.MustParse(dto.ID),
+		ProjectID:     uuid.MustParse(dto.ProjectID),
+		Commit:        dto.Commit,
+		Attempt:       dto.Attempt,
+		Status:        dto.Status,
+		Error:         dto.Error,
+		Owner:         dto.Owner,
+		DateStarted:   dto.DateStarted,
+		DateRefreshed: dto.DateRefreshed,
+	}
+	// jobs recorded before they were owned have no analysis, and they're running since they started
+	if dto.AnalysisID != "" {
+		job.AnalysisID = uuid.MustParse(dto.AnalysisID)
+	}
+	if job.Status == "" {
+		job.Status = entity.JobStatusRunning
+		job.DateRefreshed = dto.DateStarted
+	}
+	if dto.Pipeline != nil {
+		job.Pipeline = &entity.Pipeline{
+			Miners:       dto.Pipeline.Miners,
+			Splitters:    dto.Pipeline.Splitters,
+			Expanders:    dto.Pipeline.Expanders,
+			Rules:        dto.Pipeline.Rules,
+			IncludeTests: dto.Pipeline.IncludeTests,
+		}
+	}
+
+	return job
+}
+
+// jobDTO is the database representation for an AnalysisJob.
+type jobDTO struct {
+	ID            string       `bson:"_id"`
+	WorkspaceID   string       `bson:"workspace_id"`
+	ProjectID     string       `bson:"project_id"`
+	AnalysisID    string       `bson:"analysis_id,omitempty"`
+	Pipeline      *pipelineDTO `bson:"pipeline,omitempty"`
+	Commit        string       `bson:"commit,omitempty"`
+	Attempt       int          `bson:"attempt"`
+	Status        string       `bson:"status,omitempty"`
+	Error         string       `bson:"error,omitempty"`
+	Owner         string       `bson:"owner,omitempty"`
+	DateStarted   time.Time    `bson:"started_at"`
+	DateRefreshed time.Time    `bson:"refreshed_at"`
+}
+
+// pipelineDTO is the database representation for the pipeline requested for an analysis job. Lists are never
+// omitted, as a missing list keeps the configured one while an empty list applies none.
+type pipelineDTO struct {
+	Miners       []string `bson:"miners"`
+	Splitters    []string `bson:"splitters"`
+	Expanders    []string `bson:"expanders"`
+	Rules        []string `bson:"rules"`
+	IncludeTests bool     `bson:"include_tests"`
+}
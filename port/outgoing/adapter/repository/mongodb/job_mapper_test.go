@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDTO_OnJobMapper_ShouldReturnJobDTO(t *testing.T) {
+	now := time.Now()
+	job := entity.AnalysisJob{
+		ID:            uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"),
+		ProjectID:     uuid.MustParse("4d3e2f1a-0b9c-4d8e-8f7a-6b5c4d3e2f1a"),
+		AnalysisID:    uuid.MustParse("9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+		Pipeline:      &entity.Pipeline{Splitters: []string{"conserv"}, Rules: []string{}, IncludeTests: true},
+		Commit:        "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		Attempt:       1,
+		Status:        entity.JobStatusFailed,
+		Error:         "unexpected error",
+		Owner:         "src-reader-1",
+		DateStarted:   now,
+		DateRefreshed: now.Add(time.Minute),
+	}
+
+	jm := &jobMapper{}
+	dto := jm.toDTO(job)
+
+	assert.Equal(t, "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f", dto.ID)
+	assert.Equal(t, "4d3e2f1a-0b9c-4d8e-8f7a-6b5c4d3e2f1a", dto.ProjectID)
+	assert.Equal(t, "9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", dto.AnalysisID)
+	assert.Equal(t, &pipelineDTO{Splitters: []string{"conserv"}, Rules: []string{}, IncludeTests: true}, dto.Pipeline)
+	assert.Equal(t, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52", dto.Commit)
+	assert.Equal(t, 1, dto.Attempt)
+	assert.Equal(t, "failed", dto.Status)
+	assert.Equal(t, "unexpected error", dto.Error)
+	assert.Equal(t, "src-reader-1", dto.Owner)
+	assert.Equal(t, now, dto.DateStarted)
+	assert.Equal(t, now.Add(time.Minute), dto.DateRefreshed)
+
+	job.Pipeline = nil
+	assert.Nil(t, jm.toDTO(job).Pipeline)
+}
+
+func TestToEntity_OnJobMapper_ShouldReturnJobEntity(t *testing.T) {
+	now := time.Now()
+	dto := jobDTO{
+		ID:          "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+		ProjectID:   "4d3e2f1a-0b9c-4d8e-8f7a-6b5c4d3e2f1a",
+		Pipeline:    &pipelineDTO{Miners: []string{"wordcount"}},
+		Attempt:     2,
+		DateStarted: now,
+	}
+
+	jm := &jobMapper{}
+	ent := jm.toEntity(dto)
+
+	assert.Equal(t, uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"), ent.ID)
+	assert.Equal(t, uuid.MustParse("4d3e2f1a-0b9c-4d8e-8f7a-6b5c4d3e2f1a"), ent.ProjectID)
+	assert.Equal(t, &entity.Pipeline{Miners: []string{"wordcount"}}, ent.Pipeline)
+	assert.Empty(t, ent.Commit)
+	assert.Equal(t, 2, ent.Attempt)
+	assert.Equal(t, now, ent.DateStarted)
+	// documents stored before jobs were owned are running since they started
+	assert.Equal(t, uuid.Nil, ent.AnalysisID)
+	assert.Equal(t, entity.JobStatusRunning, ent.Status)
+	assert.Equal(t, now, ent.DateRefreshed)
+
+	dto.Pipeline = nil
+	assert.Nil(t, jm.toEntity(dto).Pipeline)
+
+	dto.AnalysisID = "9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+	dto.Commit = "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"
+	dto.Status = entity.JobStatusFailed
+	dto.Error = "unexpected error"
+	dto.Owner = "src-reader-1"
+	dto.DateRefreshed = now.Add(time.Minute)
+	ent = jm.toEntity(dto)
+	assert.Equal(t, uuid.MustParse("9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"), ent.AnalysisID)
+	assert.Equal(t, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52", ent.Commit)
+	assert.Equal(t, entity.JobStatusFailed, ent.Status)
+	assert.Equal(t, "unexpected error", ent.Error)
+	assert.Equal(t, "src-reader-1", ent.Owner)
+	assert.Equal(t, now.Add(time.Minute), ent.DateRefreshed)
+}
@@ -0,0 +1,141 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const jobsCollection string = "analysis_jobs"
+
+// JobDB represents a MongoDB database, focused on the collection handling the analysis job documents.
+type JobDB struct {
+	client     *mongo.Client
+	mapper     *jobMapper
+	collection *mongo.Collection
+}
+
+// NewMongoDBJobRepository creates a repository.JobRepository backed up by a MongoDB database.
+func NewMongoDBJobRepository(client *mongo.Client, dbname string) *JobDB {
+	return &JobDB{
+		client:     client,
+		mapper:     &jobMapper{},
+		collection: client.Database(dbname).Collection(jobsCollection),
+	}
+}
+
+// Add transforms and stores an AnalysisJob entity into a document on the underlying MongoDB collection, for the
+// current workspace.
+func (jdb *JobDB) Add(ctx context.Context, job entity.AnalysisJob) error {
+	dto := jdb.mapper.toDTO(job)
+	dto.WorkspaceID = workspaceOf(ctx)
+	_, err := jdb.collection.InsertOne(ctx, dto)
+	if err != nil {
+		log.WithError(err).Errorf("error inserting analysis job %v", job.ID)
+		return repository.ErrJobUnexpected
+	}
+
+	return nil
+}
+
+// FindAll retrieves every existing AnalysisJob for the current workspace on the underlying MongoDB collection,
+// sorted by start time.
+func (jdb *JobDB) FindAll(ctx context.Context) ([]entity.AnalysisJob, error) {
+	cursor, err := jdb.collection.Find(ctx, inWorkspace(ctx, bson.M{}), options.Find().SetSort(bson.M{"started_at": 1}))
+	if err != nil {
+		log.WithError(err).Error("error searching analysis jobs")
+		return []entity.AnalysisJob{}, repository.ErrJobUnexpected
+	}
+
+	var elements []jobDTO
+	err = cursor.All(ctx, &elements)
+	if err != nil {
+		log.WithError(err).Error("error decoding found analysis job documents")
+		return []entity.AnalysisJob{}, repository.ErrJobUnexpected
+	}
+
+	jobs := make([]entity.AnalysisJob, len(elements))
+	for i, element := range elements {
+		jobs[i] = jdb.mapper.toEntity(element)
+	}
+
+	return jobs, nil
+}
+
+// Refresh extends the hold of the owner on a running AnalysisJob on the underlying MongoDB collection.
+func (jdb *JobDB) Refresh(ctx context.Context, job entity.AnalysisJob) error {
+	results, err := jdb.collection.UpdateOne(ctx,
+		inWorkspace(ctx, bson.M{"_id": job.ID.String(), "status": running, "owner": job.Owner, "attempt": job.Attempt}),
+		bson.M{"$set": bson.M{"refreshed_at": time.Now()}})
+
+	return jdb.updated(results, err, job.ID)
+}
+
+// Claim records the given AnalysisJob as the next attempt of a running job on the underlying MongoDB collection, as
+// long as it wasn't refreshed since the given time.
+func (jdb *JobDB) Claim(ctx context.Context, job entity.AnalysisJob, refreshedBefore time.Time) error {
+	results, err := jdb.collection.UpdateOne(ctx,
+		inWorkspace(ctx, bson.M{
+			"_id":          job.ID.String(),
+			"status":       running,
+			"attempt":      job.Attempt - 1,
+			"refreshed_at": bson.M{"$lt": refreshedBefore},
+		}),
+		bson.M{"$set": bson.M{
+			"analysis_id":  job.AnalysisID.String(),
+			"attempt":      job.Attempt,
+			"status":       entity.JobStatusRunning,
+			"owner":        job.Owner,
+			"refreshed_at": job.DateRefreshed,
+		}})
+
+	return jdb.updated(results, err, job.ID)
+}
+
+// Fail records a running AnalysisJob as failed on the underlying MongoDB collection.
+func (jdb *JobDB) Fail(ctx context.Context, ID uuid.UUID, reason string) error {
+	results, err := jdb.collection.UpdateOne(ctx,
+		inWorkspace(ctx, bson.M{"_id": ID.String(), "status": running}),
+		bson.M{"$set": bson.M{"status": entity.JobStatusFailed, "error": reason, "refreshed_at": time.Now()}})
+
+	return jdb.updated(results, err, ID)
+}
+
+// running filters the documents for running jobs. Documents stored before jobs had a status don't include the
+// field, and they are considered running.
+var running = bson.M{"$in": bson.A{entity.JobStatusRunning, nil}}
+
+// updated checks the results of updating an AnalysisJob.
+func (jdb *JobDB) updated(results *mongo.UpdateResult, err error, ID uuid.UUID) error {
+	if err != nil {
+		log.WithError(err).Errorf("error updating analysis job with id: %v", ID)
+		return repository.ErrJobUnexpected
+	}
+
+	if results.MatchedCount == 0 {
+		return repository.ErrJobNoResults
+	}
+
+	return nil
+}
+
+// Delete removes an existing AnalysisJob from the underlying MongoDB collection.
+func (jdb *JobDB) Delete(ctx context.Context, ID uuid.UUID) error {
+	results, err := jdb.collection.DeleteOne(ctx, inWorkspace(ctx, bson.M{"_id": ID.String()}))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting analysis job with id: %v", ID)
+		return repository.ErrJobUnexpected
+	}
+
+	if results.DeletedCount == 0 {
+		return repository.ErrJobNoResults
+	}
+	return nil
+}
@@ -39,6 +39,10 @@ func (pm *projectMapper) toDTO(ent entity.Project) projectDTO {
 			Files:      ent.SourceCode.Files,
 			FilesCount: int32(len(ent.SourceCode.Files)),
 		},
+		Filter: fileFilterDTO{
+			Include: ent.Filter.Include,
+			Exclude: ent.Filter.Exclude,
+		},
 	}
 }
 
@@ -70,17 +74,23 @@ func (pm *projectMapper) toEntity(dto projectDTO) entity.Project {
 			Location: dto.SourceCode.Location,
 			Files:    dto.SourceCode.Files,
 		},
+		Filter: entity.FileFilter{
+			Include: dto.Filter.Include,
+			Exclude: dto.Filter.Exclude,
+		},
 	}
 }
 
 // projectDTO is the database representation for a Project.
 type projectDTO struct {
-	ID         string        `bson:"_id"`
-	Status     string        `bson:"status"`
-	ProjecRef  string        `bson:"project_ref"`
-	CreatedAt  time.Time     `bson:"created_at"`
-	Metadata   metadataDTO   `bson:"metadata"`
-	SourceCode sourceCodeDTO `bson:"source_code"`
+	ID          string        `bson:"_id"`
+	WorkspaceID string        `bson:"workspace_id"`
+	Status      string        `bson:"status"`
+	ProjecRef   string        `bson:"project_ref"`
+	CreatedAt   time.Time     `bson:"created_at"`
+	Metadata    metadataDTO   `bson:"metadata"`
+	SourceCode  sourceCodeDTO `bson:"source_code"`
+	Filter      fileFilterDTO `bson:"filter"`
 }
 
 // metadataDTO is the database representation for a Project's Metadata.
@@ -108,3 +118,9 @@ type sourceCodeDTO struct {
 	Files      []string `bson:"files"`
 	FilesCount int32    `bson:"files_count"`
 }
+
+// fileFilterDTO is the database representation for a Project's FileFilter.
+type fileFilterDTO struct {
+	Include []string `bson:"include,omitempty"`
+	Exclude []string `bson:"exclude,omitempty"`
+}
@@ -3,12 +3,14 @@ package mongodb
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/repository"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -32,7 +34,9 @@ func NewMongoDBProjecRepository(client *mongo.Client, dbname string) *ProjectDB
 
 // Add transforms and stores a Project entity into a document on the underlying MongoDB collection.
 func (pdb *ProjectDB) Add(ctx context.Context, project entity.Project) error {
-	_, err := pdb.collection.InsertOne(ctx, pdb.mapper.toDTO(project))
+	dto := pdb.mapper.toDTO(project)
+	dto.WorkspaceID = workspaceOf(ctx)
+	_, err := pdb.collection.InsertOne(ctx, dto)
 	if err != nil {
 		log.WithError(err).Error(fmt.Sprintf("error inserting record %v", project))
 		return repository.ErrProjectUnexpected
@@ -43,7 +47,7 @@ func (pdb *ProjectDB) Add(ctx context.Context, project entity.Project) error {
 
 // Get finds an existing Project by ID.
 func (pdb *ProjectDB) Get(ctx context.Context, ID uuid.UUID) (entity.Project, error) {
-	return pdb.find(ctx, bson.M{"_id": ID.String})
+	return pdb.find(ctx, bson.M{"_id": ID.String()})
 }
 
 // GetByReference finds an existing Project using the given reference as filter.
@@ -51,8 +55,8 @@ func (pdb *ProjectDB) GetByReference(ctx context.Context, projectRef string) (en
 	return pdb.find(ctx, bson.M{"project_ref": projectRef})
 }
 
-func (pdb *ProjectDB) find(ctx context.Context, filter interface{}) (entity.Project, error) {
-	res := pdb.collection.FindOne(ctx, filter)
+func (pdb *ProjectDB) find(ctx context.Context, filter bson.M) (entity.Project, error) {
+	res := pdb.collection.FindOne(ctx, inWorkspace(ctx, filter))
 	switch res.Err() {
 	case nil:
 		// do nothing
@@ -72,9 +76,126 @@ func (pdb *ProjectDB) find(ctx context.Context, filter interface{}) (entity.Proj
 	return pdb.mapper.toEntity(dto), nil
 }
 
+// Query retrieves a page of the projects matching and sorted by the given query. The accuracy of each project is
+// computed from its insights, leaving out the ones from test files. Projects with the same sorting value are
+// sorted by their reference.
+func (pdb *ProjectDB) Query(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error) {
+	filter := inWorkspace(ctx, bson.M{})
+	if query.Owner != "" {
+		filter["metadata.owner"] = query.Owner
+	}
+	if query.License != "" {
+		filter["metadata.license"] = query.License
+	}
+	if query.Fork != nil {
+		filter["metadata.is_fork"] = *query.Fork
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.Text != "" {
+		text := primitive.Regex{Pattern: regexp.QuoteMeta(query.Text), Options: "i"}
+		filter["$or"] = bson.A{bson.M{"project_ref": text}, bson.M{"metadata.description": text}}
+	}
+
+	total, err := pdb.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		log.WithError(err).Errorf("error counting projects with filter: %v", filter)
+		return entity.ProjectPage{}, repository.ErrProjectUnexpected
+	}
+
+	sort := bson.D{{Key: "created_at", Value: -1}}
+	switch query.Sort {
+	case entity.ProjectSortCreated:
+		sort = bson.D{{Key: "created_at", Value: 1}}
+	case entity.ProjectSortStars:
+		sort = bson.D{{Key: "metadata.stargazers", Value: 1}}
+	case entity.ProjectSortStarsDesc:
+		sort = bson.D{{Key: "metadata.stargazers", Value: -1}}
+	case entity.ProjectSortAccuracy:
+		sort = bson.D{{Key: "has_accuracy", Value: -1}, {Key: "accuracy", Value: 1}}
+	case entity.ProjectSortAccuracyDesc:
+		sort = bson.D{{Key: "has_accuracy", Value: -1}, {Key: "accuracy", Value: -1}}
+	}
+	sort = append(sort, bson.E{Key: "project_ref", Value: 1}, bson.E{Key: "_id", Value: 1})
+
+	// the accuracy is weighted by the number of identifiers on each package, leaving out the test files
+	identifiers := bson.M{"$sum": "$insights.total_identifiers"}
+	hasAccuracy := bson.M{"$gt": bson.A{identifiers, 0}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$lookup", Value: bson.M{"from": insightCollection, "let": bson.M{"ref": "$project_ref"},
+			"pipeline": mongo.Pipeline{{{Key: "$match", Value: inWorkspace(ctx, bson.M{
+				"$expr": bson.M{"$eq": bson.A{"$project_ref", "$$ref"}}, "test": bson.M{"$ne": true}})}}},
+			"as": "insights"}}},
+		{{Key: "$addFields", Value: bson.M{
+			"has_accuracy": hasAccuracy,
+			"accuracy": bson.M{"$cond": bson.A{hasAccuracy,
+				bson.M{"$divide": bson.A{bson.M{"$sum": "$insights.total_weight"}, identifiers}}, nil}},
+		}}},
+		{{Key: "$project", Value: bson.M{"insights": 0}}},
+		{{Key: "$sort", Value: sort}},
+		{{Key: "$skip", Value: query.Offset}},
+	}
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+	}
+
+	cursor, err := pdb.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.WithError(err).Errorf("error looking for projects with filter: %v", filter)
+		return entity.ProjectPage{}, repository.ErrProjectUnexpected
+	}
+	defer cursor.Close(ctx)
+
+	page := entity.ProjectPage{
+		Projects: make([]entity.Project, 0),
+		Accuracy: make(map[uuid.UUID]float64),
+		Total:    int(total),
+	}
+	for cursor.Next(ctx) {
+		var dto struct {
+			projectDTO `bson:",inline"`
+			Accuracy   *float64 `bson:"accuracy"`
+		}
+		if err := cursor.Decode(&dto); err != nil {
+			log.WithError(err).Errorf("error decoding projects with filter: %v", filter)
+			return entity.ProjectPage{}, repository.ErrProjectUnexpected
+		}
+
+		project := pdb.mapper.toEntity(dto.projectDTO)
+		page.Projects = append(page.Projects, project)
+		if dto.Accuracy != nil {
+			page.Accuracy[project.ID] = *dto.Accuracy
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		log.WithError(err).Errorf("error iterating projects with filter: %v", filter)
+		return entity.ProjectPage{}, repository.ErrProjectUnexpected
+	}
+
+	return page, nil
+}
+
+// Update replaces the document for an existing Project on the underlying MongoDB collection.
+func (pdb *ProjectDB) Update(ctx context.Context, project entity.Project) error {
+	dto := pdb.mapper.toDTO(project)
+	dto.WorkspaceID = workspaceOf(ctx)
+	results, err := pdb.collection.ReplaceOne(ctx, inWorkspace(ctx, bson.M{"_id": project.ID.String()}), dto)
+	if err != nil {
+		log.WithError(err).Errorf("error updating project with id: %v", project.ID)
+		return repository.ErrProjectUnexpected
+	}
+
+	if results.MatchedCount == 0 {
+		return repository.ErrProjectNoResults
+	}
+	return nil
+}
+
 // Delete removes an existing Project from the underlying MongoDB collection.
 func (pdb *ProjectDB) Delete(ctx context.Context, projectID uuid.UUID) error {
-	results, err := pdb.collection.DeleteOne(ctx, bson.M{"_id": projectID.String()})
+	results, err := pdb.collection.DeleteOne(ctx, inWorkspace(ctx, bson.M{"_id": projectID.String()}))
 	if err != nil {
 		log.WithError(err).Errorf("error deleting project with id: %v", projectID)
 		return repository.ErrProjectUnexpected
@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"go/token"
+	"unicode/utf8"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+// searchIndexMapper maps an entity.SearchDocument between its model and database representations.
+type searchIndexMapper struct{}
+
+// toDTOs maps the entity for entity.SearchDocument into the Data Transfer Objects for the document and its
+// postings, identified by the given ID.
+func (sm *searchIndexMapper) toDTOs(id string, ent entity.SearchDocument) (searchDocumentDTO, []searchPostingDTO) {
+	result := ent.Result
+	doc := searchDocumentDTO{
+		ID:            id,
+		AnalysisID:    result.AnalysisID.String(),
+		ProjectRef:    result.ProjectRef,
+		IdentifierID:  result.IdentifierID,
+		Name:          result.Name,
+		Type:          int(result.Type),
+		Package:       result.Package,
+		File:          result.File,
+		Position:      int(result.Position),
+		Normalization: result.Normalization,
+	}
+
+	postings := make([]searchPostingDTO, 0, len(ent.Terms))
+	for term, weight := range ent.Terms {
+		postings = append(postings, searchPostingDTO{
+			Term:       term,
+			TermLength: utf8.RuneCountInString(term),
+			DocumentID: id,
+			AnalysisID: doc.AnalysisID,
+			ProjectRef: doc.ProjectRef,
+			Weight:     weight,
+		})
+	}
+
+	return doc, postings
+}
+
+// toEntity maps the Data Transfer Object for a search document into the entity.SearchResult it holds.
+func (sm *searchIndexMapper) toEntity(dto searchDocumentDTO) entity.SearchResult {
+	analysisID, _ := uuid.Parse(dto.AnalysisID)
+	return entity.SearchResult{
+		AnalysisID:    analysisID,
+		ProjectRef:    dto.ProjectRef,
+		IdentifierID:  dto.IdentifierID,
+		Name:          dto.Name,
+		Type:          token.Token(dto.Type),
+		Package:       dto.Package,
+		File:          dto.File,
+		Position:      token.Pos(dto.Position),
+		Normalization: dto.Normalization,
+	}
+}
+
+type searchDocumentDTO struct {
+	ID            string `bson:"_id"`
+	WorkspaceID   string `bson:"workspace_id"`
+	Generation    string `bson:"generation"`
+	AnalysisID    string `bson:"analysis_id"`
+	ProjectRef    string `bson:"project_ref"`
+	IdentifierID  string `bson:"identifier_id"`
+	Name          string `bson:"name"`
+	Type          int    `bson:"type"`
+	Package       string `bson:"package"`
+	File          string `bson:"file"`
+	Position      int    `bson:"position"`
+	Normalization string `bson:"normalization"`
+}
+
+type searchPostingDTO struct {
+	WorkspaceID string  `bson:"workspace_id"`
+	Generation  string  `bson:"generation"`
+	Term        string  `bson:"term"`
+	TermLength  int     `bson:"term_length"`
+	DocumentID  string  `bson:"document_id"`
+	AnalysisID  string  `bson:"analysis_id"`
+	ProjectRef  string  `bson:"project_ref"`
+	Weight      float64 `bson:"weight"`
+}
@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDTOs_OnSearchIndexMapper_ShouldReturnDocumentAndPostings(t *testing.T) {
+	analysisID := uuid.New()
+	ent := entity.SearchDocument{
+		Result: entity.SearchResult{
+			AnalysisID:    analysisID,
+			ProjectRef:    "eroatta/test",
+			IdentifierID:  "main.go+++readCfg",
+			Name:          "readCfg",
+			Type:          token.FUNC,
+			Package:       "main",
+			File:          "main.go",
+			Position:      token.Pos(10),
+			Normalization: "readConfig",
+		},
+		Terms: map[string]float64{"readcfg": 3.0, "configuración": 1.0},
+	}
+
+	sm := &searchIndexMapper{}
+	doc, postings := sm.toDTOs("1", ent)
+
+	assert.Equal(t, searchDocumentDTO{ID: "1", AnalysisID: analysisID.String(), ProjectRef: "eroatta/test",
+		IdentifierID: "main.go+++readCfg", Name: "readCfg", Type: int(token.FUNC), Package: "main", File: "main.go",
+		Position: 10, Normalization: "readConfig"}, doc)
+	assert.ElementsMatch(t, []searchPostingDTO{
+		{Term: "readcfg", TermLength: 7, DocumentID: "1", AnalysisID: analysisID.String(),
+			ProjectRef: "eroatta/test", Weight: 3.0},
+		{Term: "configuración", TermLength: 13, DocumentID: "1", AnalysisID: analysisID.String(),
+			ProjectRef: "eroatta/test", Weight: 1.0},
+	}, postings)
+}
+
+func TestToEntity_OnSearchIndexMapper_ShouldReturnSearchResult(t *testing.T) {
+	analysisID := uuid.New()
+	dto := searchDocumentDTO{
+		ID:            "1",
+		WorkspaceID:   uuid.New().String(),
+		Generation:    uuid.New().String(),
+		AnalysisID:    analysisID.String(),
+		ProjectRef:    "eroatta/test",
+		IdentifierID:  "main.go+++readCfg",
+		Name:          "readCfg",
+		Type:          int(token.FUNC),
+		Package:       "main",
+		File:          "main.go",
+		Position:      10,
+		Normalization: "readConfig",
+	}
+
+	sm := &searchIndexMapper{}
+	result := sm.toEntity(dto)
+
+	assert.Equal(t, entity.SearchResult{AnalysisID: analysisID, ProjectRef: "eroatta/test",
+		IdentifierID: "main.go+++readCfg", Name: "readCfg", Type: token.FUNC, Package: "main", File: "main.go",
+		Position: token.Pos(10), Normalization: "readConfig"}, result)
+}
@@ -0,0 +1,248 @@
+package mongodb
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const searchDocumentsCollection string = "search_documents"
+
+const searchPostingsCollection string = "search_postings"
+
+// SearchIndexDB represents a MongoDB database, focused on the collections handling the documents and postings of
+// the search index.
+type SearchIndexDB struct {
+	client    *mongo.Client
+	mapper    *searchIndexMapper
+	documents *mongo.Collection
+	postings  *mongo.Collection
+}
+
+// NewMongoDBSearchIndexRepository creates a repository.SearchIndexRepository backed up by a MongoDB database.
+func NewMongoDBSearchIndexRepository(client *mongo.Client, dbname string) *SearchIndexDB {
+	return &SearchIndexDB{
+		client:    client,
+		mapper:    &searchIndexMapper{},
+		documents: client.Database(dbname).Collection(searchDocumentsCollection),
+		postings:  client.Database(dbname).Collection(searchPostingsCollection),
+	}
+}
+
+// AddAll transforms and stores a set of documents and their postings on the underlying MongoDB collections.
+func (sdb *SearchIndexDB) AddAll(ctx context.Context, docs []entity.SearchDocument) error {
+	if err := sdb.insert(ctx, uuid.New().String(), docs); err != nil {
+		log.WithError(err).Error("error inserting search documents")
+		return repository.ErrSearchUnexpected
+	}
+
+	return nil
+}
+
+// ReplaceAll stores every document provided by the iterator, replacing the documents previously stored for the
+// given analysis. Each batch is inserted as soon as it's provided, tagged with a generation of its own, so the
+// previous documents are only removed once every new document was inserted.
+func (sdb *SearchIndexDB) ReplaceAll(ctx context.Context, analysisID uuid.UUID,
+	it repository.SearchDocumentIterator) error {
+	generation := uuid.New().String()
+	var err error
+	for err == nil && it.Next(ctx) {
+		err = sdb.insert(ctx, generation, it.Documents())
+	}
+	if err == nil {
+		err = it.Err()
+	}
+	if err != nil {
+		log.WithError(err).Errorf("error inserting search documents for analysis_id: %v", analysisID)
+		if _, err := sdb.delete(ctx, bson.M{"analysis_id": analysisID.String(), "generation": generation}); err != nil {
+			log.WithError(err).Errorf("error discarding search documents for analysis_id: %v", analysisID)
+		}
+		return repository.ErrSearchUnexpected
+	}
+
+	_, err = sdb.delete(ctx, bson.M{"analysis_id": analysisID.String(), "generation": bson.M{"$ne": generation}})
+	if err != nil {
+		log.WithError(err).Errorf("error deleting previous search documents for analysis_id: %v", analysisID)
+		return repository.ErrSearchUnexpected
+	}
+
+	return nil
+}
+
+// FindTerms retrieves up to limit distinct terms on the underlying postings collection starting with the given
+// prefix, whose length is between the given bounds, the shortest ones first.
+func (sdb *SearchIndexDB) FindTerms(ctx context.Context, prefix string, minLength int,
+	maxLength int, limit int) ([]string, error) {
+	length := bson.M{"$gte": minLength}
+	if maxLength > 0 {
+		length["$lte"] = maxLength
+	}
+	filter := inWorkspace(ctx, bson.M{
+		"term":        primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)},
+		"term_length": length,
+	})
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$term", "term_length": bson.M{"$first": "$term_length"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "term_length", Value: 1}, {Key: "_id", Value: 1}}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	cursor, err := sdb.postings.Aggregate(ctx, pipeline)
+	if err != nil {
+		log.WithError(err).Errorf("error searching terms with prefix %s", prefix)
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	var elements []struct {
+		Term string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &elements); err != nil {
+		log.WithError(err).Errorf("error decoding terms with prefix %s", prefix)
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	terms := make([]string, 0, len(elements))
+	for _, element := range elements {
+		terms = append(terms, element.Term)
+	}
+
+	return terms, nil
+}
+
+// FindPostings retrieves the weight of each one of the given terms on the documents containing it, from the
+// underlying postings collection, which keep the project of their documents.
+func (sdb *SearchIndexDB) FindPostings(ctx context.Context, terms []string,
+	projectRef string) (map[string]map[string]float64, error) {
+	filter := bson.M{"term": bson.M{"$in": terms}}
+	if projectRef != "" {
+		filter["project_ref"] = projectRef
+	}
+
+	cursor, err := sdb.postings.Find(ctx, inWorkspace(ctx, filter))
+	if err != nil {
+		log.WithError(err).Errorf("error searching postings for %d terms", len(terms))
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	var elements []searchPostingDTO
+	if err := cursor.All(ctx, &elements); err != nil {
+		log.WithError(err).Errorf("error decoding postings for %d terms", len(terms))
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	postings := make(map[string]map[string]float64)
+	for _, element := range elements {
+		if _, ok := postings[element.Term]; !ok {
+			postings[element.Term] = make(map[string]float64)
+		}
+		postings[element.Term][element.DocumentID] = element.Weight
+	}
+
+	return postings, nil
+}
+
+// FindDocuments retrieves the search results held by the documents with the given IDs, from the underlying
+// documents collection.
+func (sdb *SearchIndexDB) FindDocuments(ctx context.Context, ids []string) (map[string]entity.SearchResult, error) {
+	cursor, err := sdb.documents.Find(ctx, inWorkspace(ctx, bson.M{"_id": bson.M{"$in": ids}}))
+	if err != nil {
+		log.WithError(err).Errorf("error searching %d search documents", len(ids))
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	var elements []searchDocumentDTO
+	if err := cursor.All(ctx, &elements); err != nil {
+		log.WithError(err).Errorf("error decoding %d search documents", len(ids))
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	results := make(map[string]entity.SearchResult, len(elements))
+	for _, element := range elements {
+		results[element.ID] = sdb.mapper.toEntity(element)
+	}
+
+	return results, nil
+}
+
+// DeleteAllByAnalysisID removes the documents related to a given analysis, along with their postings, from the
+// underlying MongoDB collections.
+func (sdb *SearchIndexDB) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	count, err := sdb.delete(ctx, bson.M{"analysis_id": analysisID.String()})
+	if err != nil {
+		log.WithError(err).Errorf("error deleting search documents with analysis_id: %v", analysisID)
+		return repository.ErrSearchUnexpected
+	}
+
+	if count == 0 {
+		return repository.ErrSearchNoResults
+	}
+
+	return nil
+}
+
+// insert stores the documents and their postings under the given generation.
+func (sdb *SearchIndexDB) insert(ctx context.Context, generation string, docs []entity.SearchDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	documents, postings := make([]interface{}, 0, len(docs)), make([]interface{}, 0)
+	for _, doc := range docs {
+		dto, terms := sdb.mapper.toDTOs(uuid.New().String(), doc)
+		dto.WorkspaceID = workspaceOf(ctx)
+		dto.Generation = generation
+		documents = append(documents, dto)
+		for _, term := range terms {
+			term.WorkspaceID = dto.WorkspaceID
+			term.Generation = generation
+			postings = append(postings, term)
+		}
+	}
+
+	if _, err := sdb.documents.InsertMany(ctx, documents); err != nil {
+		return err
+	}
+	if len(postings) > 0 {
+		if _, err := sdb.postings.InsertMany(ctx, postings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// delete removes the documents and postings matching the given filter on the current workspace, returning the
+// number of removed documents. Postings are removed first, so no posting outlives its document.
+func (sdb *SearchIndexDB) delete(ctx context.Context, filter bson.M) (int64, error) {
+	if _, err := sdb.postings.DeleteMany(ctx, inWorkspace(ctx, copyFilter(filter))); err != nil {
+		return 0, err
+	}
+
+	results, err := sdb.documents.DeleteMany(ctx, inWorkspace(ctx, copyFilter(filter)))
+	if err != nil {
+		return 0, err
+	}
+
+	return results.DeletedCount, nil
+}
+
+// copyFilter returns a shallow copy of the given filter, since inWorkspace modifies the filter it receives.
+func copyFilter(filter bson.M) bson.M {
+	copied := make(bson.M, len(filter)+1)
+	for key, value := range filter {
+		copied[key] = value
+	}
+
+	return copied
+}
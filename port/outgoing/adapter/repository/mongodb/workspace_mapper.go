@@ -0,0 +1,39 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+// workspaceMapper maps a Workspace between its model and database representations.
+type workspaceMapper struct{}
+
+// toDTO maps the entity for Workspace into a Data Transfer Object.
+func (wm *workspaceMapper) toDTO(ent entity.Workspace) workspaceDTO {
+	return workspaceDTO{
+		ID:            ent.ID.String(),
+		Name:          ent.Name,
+		WebhookSecret: ent.WebhookSecret,
+		CreatedAt:     ent.CreatedAt,
+	}
+}
+
+// toEntity maps the Data Transfer Object for Workspace into a domain entity.
+func (wm *workspaceMapper) toEntity(dto workspaceDTO) entity.Workspace {
+	return entity.Workspace{
+		ID:            uuid.MustParse(dto.ID),
+		Name:          dto.Name,
+		WebhookSecret: dto.WebhookSecret,
+		CreatedAt:     dto.CreatedAt,
+	}
+}
+
+// workspaceDTO is the database representation for a Workspace.
+type workspaceDTO struct {
+	ID            string    `bson:"_id"`
+	Name          string    `bson:"name"`
+	WebhookSecret string    `bson:"webhook_secret,omitempty"`
+	CreatedAt     time.Time `bson:"created_at"`
+}
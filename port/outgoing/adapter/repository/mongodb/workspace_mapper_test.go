@@ -0,0 +1,46 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDTO_OnWorkspaceMapper_ShouldReturnWorkspaceDTO(t *testing.T) {
+	now := time.Now()
+	workspace := entity.Workspace{
+		ID:            uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"),
+		Name:          "backend",
+		WebhookSecret: "s3cr3t",
+		CreatedAt:     now,
+	}
+
+	wm := &workspaceMapper{}
+	dto := wm.toDTO(workspace)
+
+	assert.Equal(t, "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f", dto.ID)
+	assert.Equal(t, "backend", dto.Name)
+	assert.Equal(t, "s3cr3t", dto.WebhookSecret)
+	assert.Equal(t, now, dto.CreatedAt)
+}
+
+func TestToEntity_OnWorkspaceMapper_ShouldReturnWorkspaceEntity(t *testing.T) {
+	now := time.Now()
+	dto := workspaceDTO{
+		ID:            "0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f",
+		Name:          "backend",
+		WebhookSecret: "s3cr3t",
+		CreatedAt:     now,
+	}
+
+	wm := &workspaceMapper{}
+	ent := wm.toEntity(dto)
+
+	assert.Equal(t, uuid.MustParse("0c2f4d6e-8a1b-4c3d-9e5f-7a6b5c4d3e2f"), ent.ID)
+	assert.Equal(t, "backend", ent.Name)
+	assert.Equal(t, "s3cr3t", ent.WebhookSecret)
+	assert.Equal(t, now, ent.CreatedAt)
+}
@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const workspacesCollection string = "workspaces"
+
+// WorkspaceDB represents a MongoDB database, focused on the collection handling the workspace documents.
+type WorkspaceDB struct {
+	client     *mongo.Client
+	mapper     *workspaceMapper
+	collection *mongo.Collection
+}
+
+// NewMongoDBWorkspaceRepository creates a repository.WorkspaceRepository backed up by a MongoDB database.
+func NewMongoDBWorkspaceRepository(client *mongo.Client, dbname string) *WorkspaceDB {
+	return &WorkspaceDB{
+		client:     client,
+		mapper:     &workspaceMapper{},
+		collection: client.Database(dbname).Collection(workspacesCollection),
+	}
+}
+
+// Add transforms and stores a Workspace entity into a document on the underlying MongoDB collection.
+func (wdb *WorkspaceDB) Add(ctx context.Context, workspace entity.Workspace) error {
+	_, err := wdb.collection.InsertOne(ctx, wdb.mapper.toDTO(workspace))
+	if err != nil {
+		log.WithError(err).Errorf("error inserting workspace %v", workspace.ID)
+		return repository.ErrWorkspaceUnexpected
+	}
+
+	return nil
+}
+
+// Get finds an existing Workspace by ID.
+func (wdb *WorkspaceDB) Get(ctx context.Context, ID uuid.UUID) (entity.Workspace, error) {
+	res := wdb.collection.FindOne(ctx, bson.M{"_id": ID.String()})
+	switch res.Err() {
+	case nil:
+		// do nothing
+	case mongo.ErrNoDocuments:
+		return entity.Workspace{}, repository.ErrWorkspaceNoResults
+	default:
+		log.WithError(res.Err()).Errorf("error searching workspace with id: %v", ID)
+		return entity.Workspace{}, repository.ErrWorkspaceUnexpected
+	}
+
+	var dto workspaceDTO
+	if err := res.Decode(&dto); err != nil {
+		log.WithError(err).Errorf("error decoding result for workspace with id: %v", ID)
+		return entity.Workspace{}, repository.ErrWorkspaceUnexpected
+	}
+
+	return wdb.mapper.toEntity(dto), nil
+}
+
+// FindAll retrieves every existing Workspace on the underlying MongoDB collection, sorted by creation time.
+func (wdb *WorkspaceDB) FindAll(ctx context.Context) ([]entity.Workspace, error) {
+	cursor, err := wdb.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		log.WithError(err).Error("error searching workspaces")
+		return []entity.Workspace{}, repository.ErrWorkspaceUnexpected
+	}
+
+	var elements []workspaceDTO
+	err = cursor.All(ctx, &elements)
+	if err != nil {
+		log.WithError(err).Error("error decoding found workspace documents")
+		return []entity.Workspace{}, repository.ErrWorkspaceUnexpected
+	}
+
+	workspaces := make([]entity.Workspace, len(elements))
+	for i, element := range elements {
+		workspaces[i] = wdb.mapper.toEntity(element)
+	}
+
+	return workspaces, nil
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// Weights applied to the terms extracted from each part of an identifier: matching the whole name is more relevant
+// than matching one of the possible expansions.
+const (
+	nameWeight          = 3.0
+	splitWeight         = 2.0
+	normalizationWeight = 1.5
+	expansionWeight     = 1.0
+)
+
+// terms extracts the weighted terms to be indexed for the given identifier. If a term is extracted from several
+// parts of the identifier, the highest weight is kept.
+func terms(ident entity.Identifier) map[string]float64 {
+	weights := make(map[string]float64)
+	add := func(term string, weight float64) {
+		if term != "" && weights[term] < weight {
+			weights[term] = weight
+		}
+	}
+	addWords := func(text string, weight float64) {
+		for _, word := range words(text) {
+			add(word, weight)
+		}
+	}
+
+	add(strings.ToLower(ident.Name), nameWeight)
+	addWords(ident.Name, splitWeight)
+	for _, splits := range ident.Splits {
+		for _, split := range splits {
+			addWords(split.Value, splitWeight)
+		}
+	}
+	for _, expansions := range ident.Expansions {
+		for _, expansion := range expansions {
+			for _, value := range expansion.Values {
+				addWords(value, expansionWeight)
+			}
+		}
+	}
+	add(strings.ToLower(ident.Normalization.Word), normalizationWeight)
+	addWords(ident.Normalization.Word, normalizationWeight)
+
+	return weights
+}
+
+// words breaks the given text into lowercase words, splitting on every character that is not a letter or a digit
+// and on camel case boundaries, so "parseHTTPRequest" results in "parse", "http" and "request".
+func words(text string) []string {
+	runes := []rune(text)
+	result := make([]string, 0)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start != -1 {
+				result = append(result, strings.ToLower(string(runes[start:i])))
+				start = -1
+			}
+			continue
+		}
+
+		if start != -1 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextIsLower {
+				result = append(result, strings.ToLower(string(runes[start:i])))
+				start = -1
+			}
+		}
+
+		if start == -1 {
+			start = i
+		}
+	}
+	if start != -1 {
+		result = append(result, strings.ToLower(string(runes[start:])))
+	}
+
+	return result
+}
@@ -0,0 +1,263 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/agnivade/levenshtein"
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultLimit is the number of results retrieved on each search, unless a limit is provided.
+const DefaultLimit = 20
+
+// maxPrefixTerms is the maximum number of terms a searched word expands to when matching by prefix. The shortest
+// terms are kept, since they are the closest ones to the word.
+const maxPrefixTerms = 50
+
+// batchSize is the number of documents handed to the index storage on each batch while replacing an analysis.
+const batchSize = 500
+
+// Factors applied to the weight of the terms matched by prefix or by similarity, so exact matches come first.
+const (
+	prefixFactor = 0.75
+	fuzzyFactor  = 0.5
+)
+
+// InvertedIndexRepository represents an inverted index, mapping each term extracted from the identifiers to the
+// identifiers containing it. The index is held by a repository.SearchIndexRepository, so every server instance
+// sharing the storage backend searches the same index.
+type InvertedIndexRepository struct {
+	index repository.SearchIndexRepository
+}
+
+// NewInvertedIndexRepository creates a repository.SearchRepository backed up by the given index storage.
+func NewInvertedIndexRepository(index repository.SearchIndexRepository) *InvertedIndexRepository {
+	return &InvertedIndexRepository{
+		index: index,
+	}
+}
+
+// AddAll indexes a set of Identifier entities on the current workspace, using the terms extracted from their names,
+// splits, expansions and normalizations.
+func (r *InvertedIndexRepository) AddAll(ctx context.Context, idents []entity.Identifier) error {
+	docs := make([]entity.SearchDocument, 0, len(idents))
+	for _, ident := range idents {
+		docs = append(docs, newDocument(ident))
+	}
+
+	return r.index.AddAll(ctx, docs)
+}
+
+// ReplaceAll indexes every identifier provided by the iterator on the current workspace, replacing the identifiers
+// previously indexed for the given analysis. The identifiers are handed to the index storage in batches, so they
+// aren't held in memory at once, and the previous identifiers are kept if the iterator fails.
+func (r *InvertedIndexRepository) ReplaceAll(ctx context.Context, analysisID uuid.UUID,
+	it repository.IdentifierIterator) error {
+	batches := &documentBatches{identifiers: it, size: batchSize}
+	err := r.index.ReplaceAll(ctx, analysisID, batches)
+	if err := batches.identifiers.Err(); err != nil {
+		log.WithError(err).Errorf("error iterating identifiers to index for analysis ID: %v", analysisID)
+		return repository.ErrSearchUnexpected
+	}
+
+	return err
+}
+
+// Search retrieves the identifiers on the current workspace matching every word on the given query, sorted by
+// relevance. The relevance of an identifier is the sum of the weights of its terms matching each word.
+func (r *InvertedIndexRepository) Search(ctx context.Context, query entity.SearchQuery) ([]entity.SearchResult, error) {
+	searched := words(query.Text)
+	if len(searched) == 0 {
+		return []entity.SearchResult{}, repository.ErrSearchNoResults
+	}
+
+	var scores map[string]float64
+	for _, word := range searched {
+		matches, err := r.match(ctx, word, query)
+		if err != nil {
+			return []entity.SearchResult{}, err
+		}
+
+		if scores == nil {
+			scores = matches
+			continue
+		}
+
+		for id := range scores {
+			score, ok := matches[id]
+			if !ok {
+				delete(scores, id)
+				continue
+			}
+			scores[id] += score
+		}
+	}
+	if len(scores) == 0 {
+		return []entity.SearchResult{}, repository.ErrSearchNoResults
+	}
+
+	ids := make([]string, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	docs, err := r.index.FindDocuments(ctx, ids)
+	if err != nil {
+		return []entity.SearchResult{}, err
+	}
+
+	results := make([]entity.SearchResult, 0, len(docs))
+	for id, result := range docs {
+		result.Score = scores[id]
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return results, repository.ErrSearchNoResults
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch {
+		case a.Score != b.Score:
+			return a.Score > b.Score
+		case a.ProjectRef != b.ProjectRef:
+			return a.ProjectRef < b.ProjectRef
+		case a.File != b.File:
+			return a.File < b.File
+		default:
+			return a.Position < b.Position
+		}
+	})
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// DeleteAllByAnalysisID removes the indexed identifiers related to a given analysis on the current workspace.
+func (r *InvertedIndexRepository) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	return r.index.DeleteAllByAnalysisID(ctx, analysisID)
+}
+
+// newDocument builds the document indexing the given identifier.
+func newDocument(ident entity.Identifier) entity.SearchDocument {
+	return entity.SearchDocument{
+		Result: entity.SearchResult{
+			AnalysisID:    ident.AnalysisID,
+			ProjectRef:    ident.ProjectRef,
+			IdentifierID:  ident.ID,
+			Name:          ident.Name,
+			Type:          ident.Type,
+			Package:       ident.Package,
+			File:          ident.File,
+			Position:      ident.Position,
+			Normalization: ident.Normalization.Word,
+		},
+		Terms: terms(ident),
+	}
+}
+
+// documentBatches implements repository.SearchDocumentIterator, building the documents of the identifiers provided
+// by an iterator in batches of the given size.
+type documentBatches struct {
+	identifiers repository.IdentifierIterator
+	size        int
+	current     []entity.SearchDocument
+}
+
+func (b *documentBatches) Next(ctx context.Context) bool {
+	b.current = make([]entity.SearchDocument, 0, b.size)
+	for len(b.current) < b.size && b.identifiers.Next(ctx) {
+		b.current = append(b.current, newDocument(b.identifiers.Identifier()))
+	}
+
+	return len(b.current) > 0
+}
+
+func (b *documentBatches) Documents() []entity.SearchDocument {
+	return b.current
+}
+
+func (b *documentBatches) Err() error {
+	return b.identifiers.Err()
+}
+
+// match retrieves the documents containing the given word, along with the best weight for each one of them. Only
+// the documents of the searched project, if any, are retrieved.
+func (r *InvertedIndexRepository) match(ctx context.Context, word string,
+	query entity.SearchQuery) (map[string]float64, error) {
+	factors := map[string]float64{word: 1.0}
+	length := utf8.RuneCountInString(word)
+	if query.Prefix {
+		terms, err := r.index.FindTerms(ctx, word, length+1, 0, maxPrefixTerms)
+		if err != nil {
+			return nil, err
+		}
+		for _, term := range terms {
+			factors[term] = prefixFactor
+		}
+	}
+
+	if distance := maxDistance(word); query.Fuzzy && distance > 0 {
+		// only the terms sharing the first letter and a similar length are compared, so the cost doesn't grow
+		// with the whole index
+		first, _ := utf8.DecodeRuneInString(word)
+		terms, err := r.index.FindTerms(ctx, string(first), length-distance, length+distance, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, term := range terms {
+			if term == word {
+				continue
+			}
+
+			d := levenshtein.ComputeDistance(word, term)
+			if factor := fuzzyFactor / float64(d); d <= distance && factor > factors[term] {
+				factors[term] = factor
+			}
+		}
+	}
+
+	terms := make([]string, 0, len(factors))
+	for term := range factors {
+		terms = append(terms, term)
+	}
+	postings, err := r.index.FindPostings(ctx, terms, query.ProjectRef)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make(map[string]float64)
+	for term, docs := range postings {
+		for id, weight := range docs {
+			if score := weight * factors[term]; score > matches[id] {
+				matches[id] = score
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// maxDistance determines how many edits are tolerated for a word to match a term by similarity. Short words
+// are only matched exactly or by prefix, since any edit would make them match almost anything.
+func maxDistance(word string) int {
+	switch length := utf8.RuneCountInString(word); {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,281 @@
+package search_test
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/memory"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/search"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	readerAnalysis = uuid.New()
+	writerAnalysis = uuid.New()
+)
+
+func identifiers() []entity.Identifier {
+	return []entity.Identifier{
+		{
+			ID: "reader/file.go+++readCfgFile", AnalysisID: readerAnalysis, ProjectRef: "eroatta/reader",
+			Package: "reader", File: "reader/file.go", Position: token.Pos(10), Name: "readCfgFile", Type: token.FUNC,
+			Splits: map[string][]entity.Split{
+				"conserv": {{Order: 1, Value: "read"}, {Order: 2, Value: "Cfg"}, {Order: 3, Value: "File"}},
+			},
+			Expansions: map[string][]entity.Expansion{
+				"basic": {{Order: 2, From: "Cfg", Values: []string{"config", "configuration"}}},
+			},
+			Normalization: entity.Normalization{Word: "readConfigFile", Score: 0.9},
+		},
+		{
+			ID: "reader/file.go+++configuration", AnalysisID: readerAnalysis, ProjectRef: "eroatta/reader",
+			Package: "reader", File: "reader/file.go", Position: token.Pos(5), Name: "configuration", Type: token.VAR,
+		},
+		{
+			ID: "writer/file.go+++writeCfg", AnalysisID: writerAnalysis, ProjectRef: "eroatta/writer",
+			Package: "writer", File: "writer/file.go", Position: token.Pos(20), Name: "writeCfg", Type: token.FUNC,
+			Expansions: map[string][]entity.Expansion{
+				"basic": {{Order: 2, From: "Cfg", Values: []string{"config"}}},
+			},
+		},
+	}
+}
+
+func names(results []entity.SearchResult) []string {
+	found := make([]string, 0)
+	for _, result := range results {
+		found = append(found, result.Name)
+	}
+	return found
+}
+
+func TestSearch_OnInvertedIndexRepository(t *testing.T) {
+	r := search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository())
+	require.NoError(t, r.AddAll(context.TODO(), identifiers()))
+
+	cases := []struct {
+		name     string
+		query    entity.SearchQuery
+		expected []string
+	}{
+		{name: "name", query: entity.SearchQuery{Text: "readCfgFile"}, expected: []string{"readCfgFile"}},
+		{name: "split", query: entity.SearchQuery{Text: "cfg"}, expected: []string{"readCfgFile", "writeCfg"}},
+		{name: "expansion", query: entity.SearchQuery{Text: "config"},
+			expected: []string{"readCfgFile", "writeCfg"}},
+		{name: "every_word", query: entity.SearchQuery{Text: "config file"}, expected: []string{"readCfgFile"}},
+		{name: "prefix", query: entity.SearchQuery{Text: "conf", Prefix: true},
+			expected: []string{"configuration", "readCfgFile", "writeCfg"}},
+		{name: "no_prefix", query: entity.SearchQuery{Text: "conf"}, expected: []string{}},
+		{name: "no_fuzzy", query: entity.SearchQuery{Text: "confg"}, expected: []string{}},
+		{name: "fuzzy_typo", query: entity.SearchQuery{Text: "confg", Fuzzy: true},
+			expected: []string{"readCfgFile", "writeCfg"}},
+		{name: "fuzzy_first_letter", query: entity.SearchQuery{Text: "xonfig", Fuzzy: true}, expected: []string{}},
+		{name: "fuzzy_length", query: entity.SearchQuery{Text: "configurat", Fuzzy: true},
+			expected: []string{}},
+		{name: "fuzzy_short_word", query: entity.SearchQuery{Text: "cgf", Fuzzy: true}, expected: []string{}},
+		{name: "project", query: entity.SearchQuery{Text: "cfg", ProjectRef: "eroatta/writer"},
+			expected: []string{"writeCfg"}},
+		{name: "limit", query: entity.SearchQuery{Text: "cfg", Limit: 1}, expected: []string{"readCfgFile"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			results, err := r.Search(context.TODO(), c.query)
+
+			if len(c.expected) == 0 {
+				assert.Equal(t, repository.ErrSearchNoResults, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.expected, names(results))
+		})
+	}
+}
+
+func TestSearch_OnInvertedIndexRepository_ShouldSortByRelevance(t *testing.T) {
+	r := search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository())
+	require.NoError(t, r.AddAll(context.TODO(), identifiers()))
+
+	results, err := r.Search(context.TODO(), entity.SearchQuery{Text: "configuration"})
+
+	require.NoError(t, err)
+	require.Equal(t, 2, len(results))
+	assert.Equal(t, entity.SearchResult{AnalysisID: readerAnalysis, ProjectRef: "eroatta/reader",
+		IdentifierID: "reader/file.go+++configuration", Name: "configuration", Type: token.VAR, Package: "reader",
+		File: "reader/file.go", Position: token.Pos(5), Score: 3.0}, results[0])
+	assert.Equal(t, "readCfgFile", results[1].Name)
+	assert.Equal(t, "readConfigFile", results[1].Normalization)
+	assert.Equal(t, 1.0, results[1].Score)
+}
+
+func TestDeleteAllByAnalysisID_OnInvertedIndexRepository(t *testing.T) {
+	r := search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository())
+	require.NoError(t, r.AddAll(context.TODO(), identifiers()))
+
+	require.NoError(t, r.DeleteAllByAnalysisID(context.TODO(), readerAnalysis))
+
+	results, err := r.Search(context.TODO(), entity.SearchQuery{Text: "cfg", Prefix: true, Fuzzy: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"writeCfg"}, names(results))
+	assert.Equal(t, repository.ErrSearchNoResults, r.DeleteAllByAnalysisID(context.TODO(), readerAnalysis))
+}
+
+type sliceIterator struct {
+	idents []entity.Identifier
+	pos    int
+	err    error
+}
+
+func (s *sliceIterator) Next(ctx context.Context) bool {
+	if s.pos >= len(s.idents) || s.err != nil {
+		return false
+	}
+	s.pos++
+	return true
+}
+
+func (s *sliceIterator) Identifier() entity.Identifier {
+	return s.idents[s.pos-1]
+}
+
+func (s *sliceIterator) Err() error {
+	return s.err
+}
+
+func (s *sliceIterator) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestReplaceAll_OnInvertedIndexRepository_ShouldReplacePreviousIdentifiers(t *testing.T) {
+	r := search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository())
+	require.NoError(t, r.AddAll(context.TODO(), identifiers()))
+
+	replacement := []entity.Identifier{
+		{
+			ID: "reader/file.go+++loadCfg", AnalysisID: readerAnalysis, ProjectRef: "eroatta/reader",
+			Package: "reader", File: "reader/file.go", Position: token.Pos(30), Name: "loadCfg", Type: token.FUNC,
+		},
+	}
+	err := r.ReplaceAll(context.TODO(), readerAnalysis, &sliceIterator{idents: replacement})
+	require.NoError(t, err)
+
+	results, err := r.Search(context.TODO(), entity.SearchQuery{Text: "cfg"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"loadCfg", "writeCfg"}, names(results))
+
+	_, err = r.Search(context.TODO(), entity.SearchQuery{Text: "configuration"})
+	assert.Equal(t, repository.ErrSearchNoResults, err)
+}
+
+func TestReplaceAll_OnInvertedIndexRepository_WhenIteratorFails_ShouldKeepPreviousIdentifiers(t *testing.T) {
+	r := search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository())
+	require.NoError(t, r.AddAll(context.TODO(), identifiers()))
+
+	it := &sliceIterator{idents: identifiers(), err: repository.ErrIdentifierUnexpected}
+	err := r.ReplaceAll(context.TODO(), readerAnalysis, it)
+	assert.Equal(t, repository.ErrSearchUnexpected, err)
+
+	results, err := r.Search(context.TODO(), entity.SearchQuery{Text: "configuration"})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"configuration", "readCfgFile"}, names(results))
+}
+
+// batchRecorder records the size of each batch of documents handed to the index storage while replacing an
+// analysis.
+type batchRecorder struct {
+	*memory.InMemorySearchIndexRepository
+	sizes []int
+}
+
+func (b *batchRecorder) ReplaceAll(ctx context.Context, analysisID uuid.UUID,
+	it repository.SearchDocumentIterator) error {
+	return b.InMemorySearchIndexRepository.ReplaceAll(ctx, analysisID, &recordedBatches{it, b})
+}
+
+type recordedBatches struct {
+	repository.SearchDocumentIterator
+	recorder *batchRecorder
+}
+
+func (r *recordedBatches) Next(ctx context.Context) bool {
+	if !r.SearchDocumentIterator.Next(ctx) {
+		return false
+	}
+	r.recorder.sizes = append(r.recorder.sizes, len(r.Documents()))
+	return true
+}
+
+func TestReplaceAll_OnInvertedIndexRepository_ShouldIndexInBatches(t *testing.T) {
+	index := &batchRecorder{InMemorySearchIndexRepository: memory.NewInMemorySearchIndexRepository()}
+	r := search.NewInvertedIndexRepository(index)
+
+	idents := make([]entity.Identifier, 0)
+	for i := 0; i < 1200; i++ {
+		idents = append(idents, entity.Identifier{ID: fmt.Sprintf("main.go+++cfg%d", i), AnalysisID: readerAnalysis,
+			ProjectRef: "eroatta/reader", File: "main.go", Position: token.Pos(i), Name: fmt.Sprintf("cfg%d", i)})
+	}
+	err := r.ReplaceAll(context.TODO(), readerAnalysis, &sliceIterator{idents: idents})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{500, 500, 200}, index.sizes)
+	results, err := r.Search(context.TODO(), entity.SearchQuery{Text: "cfg1199"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cfg1199"}, names(results))
+}
+
+func TestSearch_OnInvertedIndexRepository_ShouldCapThePrefixExpansion(t *testing.T) {
+	r := search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository())
+
+	idents := make([]entity.Identifier, 0)
+	for i := 0; i < 60; i++ {
+		// the first ten names are the shortest ones
+		name := fmt.Sprintf("conf%c", 'a'+i)
+		if i >= 10 {
+			name = fmt.Sprintf("confword%02d", i)
+		}
+		idents = append(idents, entity.Identifier{ID: "main.go+++" + name, AnalysisID: readerAnalysis,
+			ProjectRef: "eroatta/reader", File: "main.go", Position: token.Pos(i), Name: name})
+	}
+	require.NoError(t, r.AddAll(context.TODO(), idents))
+
+	results, err := r.Search(context.TODO(), entity.SearchQuery{Text: "conf", Prefix: true, Limit: 100})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 50, len(results))
+	for _, name := range []string{"confa", "confj"} {
+		assert.Contains(t, names(results), name)
+	}
+}
+
+func TestSearch_OnInvertedIndexRepository_ShouldBeScopedByWorkspace(t *testing.T) {
+	r := search.NewInvertedIndexRepository(memory.NewInMemorySearchIndexRepository())
+	other := entity.WithWorkspace(context.TODO(), uuid.New())
+	require.NoError(t, r.AddAll(other, identifiers()))
+
+	_, err := r.Search(context.TODO(), entity.SearchQuery{Text: "cfg"})
+	assert.Equal(t, repository.ErrSearchNoResults, err)
+	assert.Equal(t, repository.ErrSearchNoResults, r.DeleteAllByAnalysisID(context.TODO(), readerAnalysis))
+
+	results, err := r.Search(other, entity.SearchQuery{Text: "cfg"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(results))
+}
+
+func TestSearch_OnInvertedIndexRepository_ShouldShareTheIndexStorage(t *testing.T) {
+	index := memory.NewInMemorySearchIndexRepository()
+	writer := search.NewInvertedIndexRepository(index)
+	require.NoError(t, writer.AddAll(context.TODO(), identifiers()))
+	require.NoError(t, writer.DeleteAllByAnalysisID(context.TODO(), writerAnalysis))
+
+	reader := search.NewInvertedIndexRepository(index)
+	results, err := reader.Search(context.TODO(), entity.SearchQuery{Text: "conf", Prefix: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"configuration", "readCfgFile"}, names(results))
+}
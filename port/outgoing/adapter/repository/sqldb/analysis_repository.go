@@ -0,0 +1,115 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const analysisColumns = `id, created_at, project_id, project_ref, miners, splitters, expanders, rules, include_tests,
+	files_total, files_valid, files_failed, files_error_samples, files_skipped,
+	identifiers_total, identifiers_valid, identifiers_failed, identifiers_error_samples`
+
+// AnalysisDB represents a SQL database, focused on the table handling the analysis results.
+type AnalysisDB struct {
+	db *DB
+}
+
+// NewSQLAnalysisRepository creates a repository.AnalysisRepository backed up by a SQL database.
+func NewSQLAnalysisRepository(db *DB) *AnalysisDB {
+	return &AnalysisDB{
+		db: db,
+	}
+}
+
+// Add stores an AnalysisResults entity into a row on the underlying analysis table.
+func (adb *AnalysisDB) Add(ctx context.Context, analysis entity.AnalysisResults) error {
+	_, err := adb.db.exec(ctx, "INSERT INTO analysis (workspace_id, "+analysisColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		workspaceOf(ctx), analysis.ID.String(), analysis.DateCreated.UTC(), analysis.ProjectID.String(), analysis.ProjectName,
+		toDocument(analysis.PipelineMiners), toDocument(analysis.PipelineSplitters),
+		toDocument(analysis.PipelineExpanders), toDocument(analysis.PipelineRules), analysis.IncludeTests,
+		analysis.FilesTotal, analysis.FilesValid, analysis.FilesError, toDocument(analysis.FilesErrorSamples),
+		analysis.FilesSkipped, analysis.IdentifiersTotal, analysis.IdentifiersValid, analysis.IdentifiersError,
+		toDocument(analysis.IdentifiersErrorSamples))
+	if err != nil {
+		log.WithError(err).Errorf("error inserting record %v", analysis)
+		return repository.ErrAnalysisUnexpected
+	}
+
+	return nil
+}
+
+// Get retrieves an existing analysis using its ID, from the underlying analysis table.
+func (adb *AnalysisDB) Get(ctx context.Context, id uuid.UUID) (entity.AnalysisResults, error) {
+	return adb.find(ctx, "id", id)
+}
+
+// GetByProjectID retrieves an existing analysis for the given Project, from the underlying analysis table.
+func (adb *AnalysisDB) GetByProjectID(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
+	return adb.find(ctx, "project_id", projectID)
+}
+
+func (adb *AnalysisDB) find(ctx context.Context, column string, value uuid.UUID) (entity.AnalysisResults, error) {
+	row := adb.db.queryRow(ctx, "SELECT "+analysisColumns+" FROM analysis WHERE workspace_id = ? AND "+column+" = ?",
+		workspaceOf(ctx), value.String())
+
+	var id, projectID, miners, splitters, expanders, rules, filesSamples, identifiersSamples string
+	var analysis entity.AnalysisResults
+	err := row.Scan(&id, &analysis.DateCreated, &projectID, &analysis.ProjectName,
+		&miners, &splitters, &expanders, &rules, &analysis.IncludeTests,
+		&analysis.FilesTotal, &analysis.FilesValid, &analysis.FilesError, &filesSamples, &analysis.FilesSkipped,
+		&analysis.IdentifiersTotal, &analysis.IdentifiersValid, &analysis.IdentifiersError, &identifiersSamples)
+	switch err {
+	case nil:
+		// do nothing
+	case sql.ErrNoRows:
+		return entity.AnalysisResults{}, repository.ErrAnalysisNoResults
+	default:
+		log.WithError(err).Errorf("error searching analysis with %s: %v", column, value)
+		return entity.AnalysisResults{}, repository.ErrAnalysisUnexpected
+	}
+
+	analysis.ID, err = uuid.Parse(id)
+	if err == nil {
+		analysis.ProjectID, err = uuid.Parse(projectID)
+	}
+	for doc, v := range map[*string]*[]string{
+		&miners:             &analysis.PipelineMiners,
+		&splitters:          &analysis.PipelineSplitters,
+		&expanders:          &analysis.PipelineExpanders,
+		&rules:              &analysis.PipelineRules,
+		&filesSamples:       &analysis.FilesErrorSamples,
+		&identifiersSamples: &analysis.IdentifiersErrorSamples,
+	} {
+		if err == nil {
+			err = fromDocument(*doc, v)
+		}
+	}
+	if err != nil {
+		log.WithError(err).Errorf("error decoding analysis with %s: %v", column, value)
+		return entity.AnalysisResults{}, repository.ErrAnalysisUnexpected
+	}
+
+	return analysis, nil
+}
+
+// Delete removes an existing Analysis from the underlying analysis table.
+func (adb *AnalysisDB) Delete(ctx context.Context, id uuid.UUID) error {
+	results, err := adb.db.exec(ctx, "DELETE FROM analysis WHERE id = ? AND workspace_id = ?", id.String(),
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting analysis with id: %v", id)
+		return repository.ErrAnalysisUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrAnalysisNoResults
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const apiKeyColumns = "id, workspace_id, name, prefix, hash, scopes, created_at"
+
+// APIKeyDB represents a SQL database, focused on the table handling the API keys.
+type APIKeyDB struct {
+	db *DB
+}
+
+// NewSQLAPIKeyRepository creates a repository.APIKeyRepository backed up by a SQL database.
+func NewSQLAPIKeyRepository(db *DB) *APIKeyDB {
+	return &APIKeyDB{
+		db: db,
+	}
+}
+
+// Add stores an APIKey entity into a row on the underlying api_keys table, for the workspace it belongs to.
+func (kdb *APIKeyDB) Add(ctx context.Context, key entity.APIKey) error {
+	_, err := kdb.db.exec(ctx, "INSERT INTO api_keys ("+apiKeyColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		key.ID.String(), key.WorkspaceID.String(), key.Name, key.Prefix, key.Hash, toDocument(key.Scopes), key.CreatedAt.UTC())
+	if err != nil {
+		log.WithError(err).Errorf("error inserting API key %v", key.ID)
+		return repository.ErrAPIKeyUnexpected
+	}
+
+	return nil
+}
+
+// GetByHash finds the existing APIKey matching the given hash, on any workspace.
+func (kdb *APIKeyDB) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	rows, err := kdb.db.query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE hash = ?", hash)
+	if err != nil {
+		log.WithError(err).Error("error searching API key by hash")
+		return entity.APIKey{}, repository.ErrAPIKeyUnexpected
+	}
+
+	keys, err := kdb.scan(rows)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	if len(keys) == 0 {
+		return entity.APIKey{}, repository.ErrAPIKeyNoResults
+	}
+
+	return keys[0], nil
+}
+
+// FindAll retrieves every existing APIKey for the current workspace on the underlying api_keys table.
+func (kdb *APIKeyDB) FindAll(ctx context.Context) ([]entity.APIKey, error) {
+	rows, err := kdb.db.query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE workspace_id = ? ORDER BY created_at",
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Error("error searching API keys")
+		return []entity.APIKey{}, repository.ErrAPIKeyUnexpected
+	}
+
+	return kdb.scan(rows)
+}
+
+// scan reads and closes a set of rows from the api_keys table.
+func (kdb *APIKeyDB) scan(rows *sql.Rows) ([]entity.APIKey, error) {
+	defer rows.Close()
+
+	keys := make([]entity.APIKey, 0)
+	for rows.Next() {
+		var id, workspaceID, scopes string
+		var key entity.APIKey
+		err := rows.Scan(&id, &workspaceID, &key.Name, &key.Prefix, &key.Hash, &scopes, &key.CreatedAt)
+		if err == nil {
+			key.ID, err = uuid.Parse(id)
+		}
+		if err == nil {
+			key.WorkspaceID, err = uuid.Parse(workspaceID)
+		}
+		if err == nil {
+			err = fromDocument(scopes, &key.Scopes)
+		}
+		if err != nil {
+			log.WithError(err).Error("error decoding API key rows")
+			return []entity.APIKey{}, repository.ErrAPIKeyUnexpected
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error iterating API key rows")
+		return []entity.APIKey{}, repository.ErrAPIKeyUnexpected
+	}
+
+	return keys, nil
+}
+
+// Delete removes an existing APIKey from the underlying api_keys table.
+func (kdb *APIKeyDB) Delete(ctx context.Context, ID uuid.UUID) error {
+	results, err := kdb.db.exec(ctx, "DELETE FROM api_keys WHERE id = ? AND workspace_id = ?", ID.String(),
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting API key with id: %v", ID)
+		return repository.ErrAPIKeyUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrAPIKeyNoResults
+	}
+
+	return nil
+}
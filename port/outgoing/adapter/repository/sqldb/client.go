@@ -0,0 +1,173 @@
+// Package sqldb provides repositories backed up by a SQL database. Both PostgreSQL, using the "postgres" driver,
+// and SQLite, using the "sqlite3" driver for embedded deployments, are supported.
+//
+// The drivers must be registered by the caller, usually with a blank import on the main package.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/eroatta/src-reader/tracing"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Supported database drivers.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+)
+
+var (
+	// ErrSQLConnection represents an error while attempting to create a connection to the given database.
+	ErrSQLConnection = errors.New("SQL database connection error")
+	// ErrSQLValidation represents an error while attempting to check the existing connection to the given database.
+	ErrSQLValidation = errors.New("SQL database connection validation error")
+	// ErrSQLMigration represents an error while attempting to update the schema of the given database.
+	ErrSQLMigration = errors.New("SQL database migration error")
+)
+
+// DB represents a SQL database, adapting the queries to the dialect of the underlying driver.
+type DB struct {
+	*sql.DB
+	driver string
+}
+
+// NewSQLClient opens a connection to the database referenced by the data source name, using one of the supported
+// drivers, and applies the pending schema migrations.
+func NewSQLClient(driver string, dsn string) (*DB, error) {
+	if driver != DriverPostgres && driver != DriverSQLite {
+		log.Errorf("unsupported SQL driver %s", driver)
+		return nil, ErrSQLConnection
+	}
+
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		log.WithError(err).Errorf("error opening a connection using %s", driver)
+		return nil, ErrSQLConnection
+	}
+
+	err = conn.Ping()
+	if err != nil {
+		log.WithError(err).Errorf("error validating a connection using %s", driver)
+		_ = conn.Close()
+		return nil, ErrSQLValidation
+	}
+
+	db := &DB{DB: conn, driver: driver}
+	if err := db.Migrate(context.TODO()); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// rebind replaces the "?" placeholders on the query with the ones expected by the underlying driver. Any "?"
+// inside a quoted string or identifier is kept as it is.
+func (db *DB) rebind(query string) string {
+	if db.driver != DriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			// an escaped quote closes and reopens the quoted text
+			if r == quote {
+				quote = 0
+			}
+			b.WriteRune(r)
+			continue
+		case r == '\'' || r == '"':
+			quote = r
+			b.WriteRune(r)
+			continue
+		case r != '?':
+			b.WriteRune(r)
+			continue
+		}
+
+		n++
+		b.WriteString("$")
+		b.WriteString(strconv.Itoa(n))
+	}
+
+	return b.String()
+}
+
+// exec executes a query that doesn't return rows, adapting its placeholders.
+func (db *DB) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query = db.rebind(query)
+	ctx, span := db.startSpan(ctx, query)
+	result, err := db.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+// query executes a query that returns rows, adapting its placeholders. The query is traced until the rows are
+// returned.
+func (db *DB) query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	query = db.rebind(query)
+	ctx, span := db.startSpan(ctx, query)
+	rows, err := db.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+
+	return rows, err
+}
+
+// queryRow executes a query that returns at most one row, adapting its placeholders.
+func (db *DB) queryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	query = db.rebind(query)
+	ctx, span := db.startSpan(ctx, query)
+	row := db.QueryRowContext(ctx, query, args...)
+	span.End()
+
+	return row
+}
+
+// withTx runs fn within a transaction, which is committed only if fn succeeds. The whole transaction is traced
+// on a single span.
+func (db *DB) withTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	ctx, span := tracing.Start(ctx, "TRANSACTION", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", db.system())))
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// startSpan starts the span of a query, named after its SQL operation.
+func (db *DB) startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := strings.ToUpper(strings.Fields(query + " SQL")[0])
+	return tracing.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system", db.system()),
+		attribute.String("db.statement", query),
+	))
+}
+
+// system retrieves the name of the database system, as expected on the spans.
+func (db *DB) system() string {
+	if db.driver == DriverPostgres {
+		return "postgresql"
+	}
+	return "sqlite"
+}
@@ -0,0 +1,37 @@
+package sqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebind_OnPostgres_ShouldNumberPlaceholdersOutsideQuotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"no_placeholders", "SELECT id FROM projects", "SELECT id FROM projects"},
+		{"placeholders", "SELECT id FROM projects WHERE id = ? AND status = ?",
+			"SELECT id FROM projects WHERE id = $1 AND status = $2"},
+		{"string_literal", "SELECT id FROM projects WHERE reference LIKE '%?%' AND id = ?",
+			"SELECT id FROM projects WHERE reference LIKE '%?%' AND id = $1"},
+		{"escaped_quote", "SELECT id FROM projects WHERE reference = 'it''s ?' AND id = ?",
+			"SELECT id FROM projects WHERE reference = 'it''s ?' AND id = $1"},
+		{"quoted_identifier", `SELECT "who?" FROM projects WHERE id = ?`, `SELECT "who?" FROM projects WHERE id = $1`},
+	}
+
+	db := &DB{driver: DriverPostgres}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, db.rebind(tt.query))
+		})
+	}
+}
+
+func TestRebind_OnSQLite_ShouldKeepQuery(t *testing.T) {
+	db := &DB{driver: DriverSQLite}
+
+	assert.Equal(t, "SELECT id FROM projects WHERE id = ?", db.rebind("SELECT id FROM projects WHERE id = ?"))
+}
@@ -0,0 +1,52 @@
+package sqldb_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/sqldb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestQueries_OnSQLClient_ShouldBeTracedUnderCallerSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	r := sqldb.NewSQLJobRepository(newSQLiteDatabase(t))
+	ctx, parent := otel.Tracer("test").Start(entity.WithWorkspace(context.Background(), uuid.Nil), "RecoverAnalyses")
+	require.NoError(t, r.Add(ctx, entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), DateStarted: time.Now()}))
+	_, err := r.FindAll(ctx)
+	require.NoError(t, err)
+	parent.End()
+
+	spans := make([]sdktrace.ReadOnlySpan, 0)
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() == parent.SpanContext().TraceID() && span.Name() != "RecoverAnalyses" {
+			spans = append(spans, span)
+		}
+	}
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "INSERT", spans[0].Name())
+	assert.Equal(t, "SELECT", spans[1].Name())
+	for _, span := range spans {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+		assert.Contains(t, span.Attributes(), attribute.String("db.system", "sqlite"))
+		for _, kv := range span.Attributes() {
+			if kv.Key == "db.statement" {
+				assert.True(t, strings.Contains(kv.Value.AsString(), "analysis_jobs"), kv.Value.AsString())
+			}
+		}
+	}
+}
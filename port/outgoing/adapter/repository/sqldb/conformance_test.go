@@ -0,0 +1,149 @@
+package sqldb_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/conformance"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/sqldb"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newSQLiteDatabase creates a new SQLite database on a temporary file, removed after the test.
+func newSQLiteDatabase(t *testing.T) *sqldb.DB {
+	dsn := "file:" + filepath.Join(t.TempDir(), "reader.db") + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := sqldb.NewSQLClient(sqldb.DriverSQLite, dsn)
+	if err != nil {
+		t.Fatalf("unable to create SQLite database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// newPostgresDatabase connects to the PostgreSQL server referenced by POSTGRES_TEST_URL, using a new schema that is
+// dropped after the test. The test is skipped if no server is referenced.
+func newPostgresDatabase(t *testing.T) *sqldb.DB {
+	url := os.Getenv("POSTGRES_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRES_TEST_URL not set")
+	}
+
+	schema := "conformance_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	admin, err := sql.Open(sqldb.DriverPostgres, url)
+	if err != nil {
+		t.Fatalf("unable to connect to %s: %v", url, err)
+	}
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("unable to create schema %s: %v", schema, err)
+	}
+
+	separator := "?"
+	if strings.Contains(url, "?") {
+		separator = "&"
+	}
+	db, err := sqldb.NewSQLClient(sqldb.DriverPostgres, url+separator+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("unable to connect to %s: %v", url, err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		_, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		_ = admin.Close()
+	})
+
+	return db
+}
+
+// drivers runs the given test against every supported database.
+func drivers(t *testing.T, test func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB)) {
+	t.Run("sqlite", func(t *testing.T) { test(t, newSQLiteDatabase) })
+	t.Run("postgres", func(t *testing.T) { test(t, newPostgresDatabase) })
+}
+
+func TestConformance_OnSQLProjectRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.ProjectRepository(t, func(t *testing.T) repository.ProjectRepository {
+			return sqldb.NewSQLProjectRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLProjectRepository_Query(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.ProjectQuery(t, func(t *testing.T) (repository.ProjectRepository, repository.InsightRepository) {
+			db := newDatabase(t)
+			return sqldb.NewSQLProjectRepository(db), sqldb.NewSQLInsightRepository(db)
+		})
+	})
+}
+
+func TestConformance_OnSQLAnalysisRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.AnalysisRepository(t, func(t *testing.T) repository.AnalysisRepository {
+			return sqldb.NewSQLAnalysisRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLIdentifierRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.IdentifierRepository(t, func(t *testing.T) repository.IdentifierRepository {
+			return sqldb.NewSQLIdentifierRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLInsightRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.InsightRepository(t, func(t *testing.T) repository.InsightRepository {
+			return sqldb.NewSQLInsightRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLDictionaryRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.DictionaryRepository(t, func(t *testing.T) repository.DictionaryRepository {
+			return sqldb.NewSQLDictionaryRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLAPIKeyRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.APIKeyRepository(t, func(t *testing.T) repository.APIKeyRepository {
+			return sqldb.NewSQLAPIKeyRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLWorkspaceRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.WorkspaceRepository(t, func(t *testing.T) repository.WorkspaceRepository {
+			return sqldb.NewSQLWorkspaceRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLJobRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.JobRepository(t, func(t *testing.T) repository.JobRepository {
+			return sqldb.NewSQLJobRepository(newDatabase(t))
+		})
+	})
+}
+
+func TestConformance_OnSQLSearchIndexRepository(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		conformance.SearchIndexRepository(t, func(t *testing.T) repository.SearchIndexRepository {
+			return sqldb.NewSQLSearchIndexRepository(newDatabase(t))
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const dictionaryColumns = "id, scope, project_ref, entries, created_at, updated_at"
+
+// DictionaryDB represents a SQL database, focused on the table handling the dictionaries.
+type DictionaryDB struct {
+	db *DB
+}
+
+// NewSQLDictionaryRepository creates a repository.DictionaryRepository backed up by a SQL database.
+func NewSQLDictionaryRepository(db *DB) *DictionaryDB {
+	return &DictionaryDB{
+		db: db,
+	}
+}
+
+// Add stores a Dictionary entity into a row on the underlying dictionaries table.
+func (ddb *DictionaryDB) Add(ctx context.Context, dict entity.Dictionary) error {
+	_, err := ddb.db.exec(ctx, "INSERT INTO dictionaries (workspace_id, "+dictionaryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, workspaceOf(ctx), dict.ID.String(), dict.Scope, dict.ProjectRef, toDocument(dict.Entries), dict.CreatedAt.UTC(),
+		dict.UpdatedAt.UTC())
+	if err != nil {
+		log.WithError(err).Errorf("error inserting dictionary %v", dict.ID)
+		return repository.ErrDictionaryUnexpected
+	}
+
+	return nil
+}
+
+// Get finds an existing Dictionary by ID.
+func (ddb *DictionaryDB) Get(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error) {
+	return ddb.find(ctx, "id = ?", ID.String())
+}
+
+// GetByScope finds the existing Dictionary defined for the given scope and project reference.
+func (ddb *DictionaryDB) GetByScope(ctx context.Context, scope string, projectRef string) (entity.Dictionary, error) {
+	if scope == entity.DictionaryScopeOrganization {
+		projectRef = ""
+	}
+
+	return ddb.find(ctx, "scope = ? AND project_ref = ?", scope, projectRef)
+}
+
+func (ddb *DictionaryDB) find(ctx context.Context, where string, args ...interface{}) (entity.Dictionary, error) {
+	rows, err := ddb.db.query(ctx, "SELECT "+dictionaryColumns+" FROM dictionaries WHERE workspace_id = ? AND "+
+		where+" ORDER BY created_at", append([]interface{}{workspaceOf(ctx)}, args...)...)
+	if err != nil {
+		log.WithError(err).Errorf("error searching dictionary with filter: %s %v", where, args)
+		return entity.Dictionary{}, repository.ErrDictionaryUnexpected
+	}
+
+	dicts, err := ddb.scan(rows)
+	if err != nil {
+		return entity.Dictionary{}, err
+	}
+
+	if len(dicts) == 0 {
+		return entity.Dictionary{}, repository.ErrDictionaryNoResults
+	}
+
+	return dicts[0], nil
+}
+
+// FindAll retrieves every existing Dictionary on the underlying dictionaries table.
+func (ddb *DictionaryDB) FindAll(ctx context.Context) ([]entity.Dictionary, error) {
+	rows, err := ddb.db.query(ctx, "SELECT "+dictionaryColumns+" FROM dictionaries WHERE workspace_id = ? ORDER BY created_at",
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Error("error searching dictionaries")
+		return []entity.Dictionary{}, repository.ErrDictionaryUnexpected
+	}
+
+	return ddb.scan(rows)
+}
+
+// scan reads and closes a set of rows from the dictionaries table.
+func (ddb *DictionaryDB) scan(rows *sql.Rows) ([]entity.Dictionary, error) {
+	defer rows.Close()
+
+	dicts := make([]entity.Dictionary, 0)
+	for rows.Next() {
+		var id, entries string
+		var dict entity.Dictionary
+		err := rows.Scan(&id, &dict.Scope, &dict.ProjectRef, &entries, &dict.CreatedAt, &dict.UpdatedAt)
+		if err == nil {
+			dict.ID, err = uuid.Parse(id)
+		}
+		if err == nil {
+			err = fromDocument(entries, &dict.Entries)
+		}
+		if err != nil {
+			log.WithError(err).Error("error decoding dictionary rows")
+			return []entity.Dictionary{}, repository.ErrDictionaryUnexpected
+		}
+		dicts = append(dicts, dict)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error iterating dictionary rows")
+		return []entity.Dictionary{}, repository.ErrDictionaryUnexpected
+	}
+
+	return dicts, nil
+}
+
+// Update replaces an existing Dictionary on the underlying dictionaries table.
+func (ddb *DictionaryDB) Update(ctx context.Context, dict entity.Dictionary) error {
+	results, err := ddb.db.exec(ctx, `UPDATE dictionaries SET scope = ?, project_ref = ?, entries = ?,
+		created_at = ?, updated_at = ? WHERE id = ? AND workspace_id = ?`,
+		dict.Scope, dict.ProjectRef, toDocument(dict.Entries), dict.CreatedAt.UTC(), dict.UpdatedAt.UTC(),
+		dict.ID.String(), workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Errorf("error updating dictionary with id: %v", dict.ID)
+		return repository.ErrDictionaryUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrDictionaryNoResults
+	}
+
+	return nil
+}
+
+// Delete removes an existing Dictionary from the underlying dictionaries table.
+func (ddb *DictionaryDB) Delete(ctx context.Context, ID uuid.UUID) error {
+	results, err := ddb.db.exec(ctx, "DELETE FROM dictionaries WHERE id = ? AND workspace_id = ?", ID.String(),
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting dictionary with id: %v", ID)
+		return repository.ErrDictionaryUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrDictionaryNoResults
+	}
+
+	return nil
+}
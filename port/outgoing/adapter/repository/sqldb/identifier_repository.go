@@ -0,0 +1,700 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"go/token"
+	"strings"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultBatchSize is the default number of identifiers stored on each transaction.
+const DefaultBatchSize = 500
+
+// identifiersOrder sorts the identifiers, and the rows related to them, by their location on the source code.
+const identifiersOrder = "i.file, i.position, i.row_id"
+
+// IdentifierDB represents a SQL database, focused on the tables handling the identifiers and their
+// splits, expansions and findings.
+type IdentifierDB struct {
+	db *DB
+	// BatchSize sets the maximum number of identifiers stored on each transaction.
+	BatchSize int
+}
+
+// NewSQLIdentifierRepository creates a repository.IdentifierRepository backed up by a SQL database.
+func NewSQLIdentifierRepository(db *DB) *IdentifierDB {
+	return &IdentifierDB{
+		db:        db,
+		BatchSize: DefaultBatchSize,
+	}
+}
+
+// Add stores a staged Identifier entity, along with its splits, expansions and findings.
+func (idb *IdentifierDB) Add(ctx context.Context, analysis entity.AnalysisResults, ident entity.Identifier) error {
+	if err := idb.AddAll(ctx, analysis, []entity.Identifier{ident}); err != nil {
+		return repository.ErrIdentifierUnexpected
+	}
+
+	return nil
+}
+
+// AddAll stores a set of staged Identifier entities, using a transaction for each batch of up to BatchSize
+// identifiers. If a batch fails, none of its identifiers is stored and the following batches are not processed,
+// so the failure is reported as an ordered *repository.BatchError.
+func (idb *IdentifierDB) AddAll(ctx context.Context, analysis entity.AnalysisResults, idents []entity.Identifier) error {
+	size := idb.BatchSize
+	if size < 1 {
+		size = DefaultBatchSize
+	}
+
+	inserted := 0
+	for start := 0; start < len(idents); start += size {
+		end := start + size
+		if end > len(idents) {
+			end = len(idents)
+		}
+
+		err := idb.db.withTx(ctx, func(tx *sql.Tx) error {
+			stmts, err := idb.prepare(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			createdAt := time.Now().UTC()
+			for _, ident := range idents[start:end] {
+				if err := stmts.insert(ctx, analysis, ident, createdAt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			log.WithError(err).Errorf("error inserting batch of %d identifiers for analysis ID %v", end-start, analysis.ID)
+			failed := make([]int, 0, len(idents)-start)
+			for i := start; i < len(idents); i++ {
+				failed = append(failed, i)
+			}
+			return &repository.BatchError{Ordered: true, Inserted: inserted, Failed: failed}
+		}
+		inserted += end - start
+	}
+
+	return nil
+}
+
+// identifierStatements holds the statements used to insert identifiers within a transaction.
+type identifierStatements struct {
+	identifier     *sql.Stmt
+	split          *sql.Stmt
+	expansion      *sql.Stmt
+	expansionValue *sql.Stmt
+	finding        *sql.Stmt
+}
+
+// prepare creates the statements used to insert identifiers, which are closed along with the transaction.
+func (idb *IdentifierDB) prepare(ctx context.Context, tx *sql.Tx) (*identifierStatements, error) {
+	queries := []string{
+		`INSERT INTO identifiers (row_id, workspace_id, identifier_id, analysis_id, project_ref, package,
+			absolute_package, language, file, position, line_number, column_number, name, type, receiver, receiver_name,
+			error_value, is_exported, normalization_word, normalization_algorithm, normalization_score, staged,
+			created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		"INSERT INTO identifier_splits (identifier_row, algorithm, split_order, value) VALUES (?, ?, ?, ?)",
+		`INSERT INTO identifier_expansions (identifier_row, algorithm, expansion_order, splitting_algorithm, from_value)
+			VALUES (?, ?, ?, ?, ?)`,
+		`INSERT INTO identifier_expansion_values (identifier_row, algorithm, expansion_order, position, value)
+			VALUES (?, ?, ?, ?, ?)`,
+		`INSERT INTO identifier_findings (identifier_row, position, rule, severity, message, suggestion)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+	}
+
+	stmts := make([]*sql.Stmt, len(queries))
+	for i, query := range queries {
+		stmt, err := tx.PrepareContext(ctx, idb.db.rebind(query))
+		if err != nil {
+			return nil, err
+		}
+		stmts[i] = stmt
+	}
+
+	return &identifierStatements{
+		identifier:     stmts[0],
+		split:          stmts[1],
+		expansion:      stmts[2],
+		expansionValue: stmts[3],
+		finding:        stmts[4],
+	}, nil
+}
+
+// insert stores a staged identifier and its related rows.
+func (s *identifierStatements) insert(ctx context.Context, analysis entity.AnalysisResults, ident entity.Identifier,
+	createdAt time.Time) error {
+	rowID := uuid.New().String()
+
+	var errorValue string
+	if ident.Error != nil {
+		errorValue = ident.Error.Error()
+	}
+
+	_, err := s.identifier.ExecContext(ctx, rowID, workspaceOf(ctx), ident.ID, analysis.ID.String(), analysis.ProjectName,
+		ident.Package, ident.FullPackageName(), string(ident.Language), ident.File, int64(ident.Position), ident.Line,
+		ident.Column, ident.Name, fromTokenToString(ident.Type), ident.Receiver, ident.ReceiverName, errorValue,
+		ident.Exported(),
+		ident.Normalization.Word, ident.Normalization.Algorithm, ident.Normalization.Score, true, createdAt)
+	if err != nil {
+		return err
+	}
+
+	for algorithm, splits := range ident.Splits {
+		for _, split := range splits {
+			if _, err := s.split.ExecContext(ctx, rowID, algorithm, split.Order, split.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	for algorithm, expansions := range ident.Expansions {
+		for _, expansion := range expansions {
+			_, err := s.expansion.ExecContext(ctx, rowID, algorithm, expansion.Order, expansion.SplittingAlgorithm,
+				expansion.From)
+			if err != nil {
+				return err
+			}
+
+			for i, value := range expansion.Values {
+				if _, err := s.expansionValue.ExecContext(ctx, rowID, algorithm, expansion.Order, i, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for i, finding := range ident.Findings {
+		_, err := s.finding.ExecContext(ctx, rowID, i, finding.Rule, string(finding.Severity), finding.Message,
+			finding.Suggestion)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindAllByAnalysisID retrieves all the committed identifiers related to a given analysis.
+func (idb *IdentifierDB) FindAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Identifier, error) {
+	return idb.findAll(ctx, "i.analysis_id = ? AND i.staged = ?", analysisID.String(), false)
+}
+
+// IterateByAnalysisID retrieves an iterator over the committed identifiers related to a given analysis. Rows are
+// decoded one identifier at a time, as the iterator advances.
+func (idb *IdentifierDB) IterateByAnalysisID(ctx context.Context, analysisID uuid.UUID) (repository.IdentifierIterator, error) {
+	cursor, err := idb.open(ctx, "i.analysis_id = ? AND i.staged = ?", analysisID.String(), false)
+	if err != nil {
+		log.WithError(err).Errorf("error looking identifiers for analysis ID %v", analysisID)
+		return nil, repository.ErrIdentifierUnexpected
+	}
+
+	return cursor, nil
+}
+
+// QueryByAnalysisID retrieves a page of the committed identifiers related to a given analysis, matching and sorted
+// by the given query. Identifiers with the same score are sorted by their row ID. The name is matched while reading
+// the rows, as regular expressions aren't supported by every driver.
+func (idb *IdentifierDB) QueryByAnalysisID(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error) {
+	where := "i.workspace_id = ? AND i.analysis_id = ? AND i.staged = ?"
+	args := []interface{}{workspaceOf(ctx), analysisID.String(), false}
+	if query.Package != "" {
+		where += " AND i.package = ?"
+		args = append(args, query.Package)
+	}
+	if query.File != "" {
+		where += " AND i.file = ?"
+		args = append(args, query.File)
+	}
+	if query.Type != token.ILLEGAL {
+		where += " AND i.type = ?"
+		args = append(args, fromTokenToString(query.Type))
+	}
+	if query.Exported != nil {
+		where += " AND i.is_exported = ?"
+		args = append(args, *query.Exported)
+	}
+	if query.MinScore != nil {
+		where += " AND i.normalization_score >= ?"
+		args = append(args, *query.MinScore)
+	}
+	if query.MaxScore != nil {
+		where += " AND i.normalization_score <= ?"
+		args = append(args, *query.MaxScore)
+	}
+	if query.Splitter != "" {
+		where += " AND EXISTS (SELECT 1 FROM identifier_splits s WHERE s.identifier_row = i.row_id AND s.algorithm = ?)"
+		args = append(args, query.Splitter)
+	}
+	if query.Expander != "" {
+		where += " AND EXISTS (SELECT 1 FROM identifier_expansions e WHERE e.identifier_row = i.row_id AND e.algorithm = ?)"
+		args = append(args, query.Expander)
+	}
+
+	order := "i.row_id"
+	scoreOperator := ">"
+	switch query.Sort {
+	case entity.IdentifierSortScore:
+		order = "i.normalization_score, i.row_id"
+	case entity.IdentifierSortScoreDesc:
+		order = "i.normalization_score DESC, i.row_id"
+		scoreOperator = "<"
+	}
+
+	if query.Cursor != "" {
+		cursor, err := repository.DecodeIdentifierCursor(query.Cursor)
+		if err != nil {
+			return entity.IdentifierPage{}, err
+		}
+
+		if query.Sort == entity.IdentifierSortDefault {
+			where += " AND i.row_id > ?"
+			args = append(args, cursor.Key)
+		} else {
+			where += " AND (i.normalization_score " + scoreOperator + " ? OR (i.normalization_score = ? AND i.row_id > ?))"
+			args = append(args, cursor.Score, cursor.Score, cursor.Key)
+		}
+	}
+
+	rows, err := idb.db.query(ctx, "SELECT i.row_id, i.name, i.normalization_score FROM identifiers i WHERE "+where+
+		" ORDER BY "+order, args...)
+	if err != nil {
+		log.WithError(err).Errorf("error looking identifiers for analysis ID %v", analysisID)
+		return entity.IdentifierPage{}, repository.ErrIdentifierUnexpected
+	}
+
+	rowIDs := make([]interface{}, 0)
+	var next repository.IdentifierCursor
+	for rows.Next() {
+		var rowID, name string
+		var score float64
+		if err = rows.Scan(&rowID, &name, &score); err != nil {
+			break
+		}
+
+		if query.Name != nil && !query.Name.MatchString(name) {
+			continue
+		}
+
+		if query.Limit > 0 && len(rowIDs) == query.Limit {
+			next.Key = rowIDs[len(rowIDs)-1].(string)
+			break
+		}
+		rowIDs = append(rowIDs, rowID)
+		next.Score = score
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err != nil {
+		log.WithError(err).Errorf("error reading identifiers for analysis ID %v", analysisID)
+		return entity.IdentifierPage{}, repository.ErrIdentifierUnexpected
+	}
+
+	page := entity.IdentifierPage{Identifiers: make([]entity.Identifier, 0, len(rowIDs))}
+	if len(rowIDs) == 0 {
+		return page, nil
+	}
+
+	found, err := idb.open(ctx, "i.row_id IN (?"+strings.Repeat(", ?", len(rowIDs)-1)+")", rowIDs...)
+	if err != nil {
+		log.WithError(err).Errorf("error looking identifiers for analysis ID %v", analysisID)
+		return entity.IdentifierPage{}, repository.ErrIdentifierUnexpected
+	}
+	defer found.Close(ctx)
+
+	byRowID := make(map[string]entity.Identifier, len(rowIDs))
+	for found.Next(ctx) {
+		byRowID[found.rowID] = found.Identifier()
+	}
+	if err := found.Err(); err != nil {
+		return entity.IdentifierPage{}, err
+	}
+
+	for _, rowID := range rowIDs {
+		page.Identifiers = append(page.Identifiers, byRowID[rowID.(string)])
+	}
+	if next.Key != "" {
+		page.NextCursor = next.Encode()
+	}
+
+	return page, nil
+}
+
+// FindAllByProjectAndFile retrieves all the committed identifiers for a file related to a given project.
+func (idb *IdentifierDB) FindAllByProjectAndFile(ctx context.Context, projectRef string, filename string) ([]entity.Identifier, error) {
+	return idb.findAll(ctx, "i.project_ref = ? AND i.file = ? AND i.staged = ?", projectRef, filename, false)
+}
+
+func (idb *IdentifierDB) findAll(ctx context.Context, where string, args ...interface{}) ([]entity.Identifier, error) {
+	cursor, err := idb.open(ctx, where, args...)
+	if err != nil {
+		log.WithError(err).Errorf("error looking identifiers with filter: %s %v", where, args)
+		return []entity.Identifier{}, repository.ErrIdentifierUnexpected
+	}
+	defer cursor.Close(ctx)
+
+	identifiers := make([]entity.Identifier, 0)
+	for cursor.Next(ctx) {
+		identifiers = append(identifiers, cursor.Identifier())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return []entity.Identifier{}, err
+	}
+
+	return identifiers, nil
+}
+
+// open queries the identifiers matching the given filter on the current workspace, along with their splits,
+// expansions and findings. Every query is sorted the same way, so the related rows can be merged as the
+// identifiers are read.
+func (idb *IdentifierDB) open(ctx context.Context, where string, args ...interface{}) (*identifierCursor, error) {
+	where = "i.workspace_id = ? AND " + where
+	args = append([]interface{}{workspaceOf(ctx)}, args...)
+	queries := []string{
+		`SELECT i.row_id, i.identifier_id, i.analysis_id, i.project_ref, i.package, i.language, i.file, i.position,
+			i.line_number, i.column_number, i.name, i.type, i.receiver, i.receiver_name, i.error_value, i.normalization_word,
+			i.normalization_algorithm, i.normalization_score
+			FROM identifiers i WHERE ` + where + " ORDER BY " + identifiersOrder,
+		`SELECT s.identifier_row, s.algorithm, s.split_order, s.value
+			FROM identifier_splits s JOIN identifiers i ON i.row_id = s.identifier_row
+			WHERE ` + where + " ORDER BY " + identifiersOrder + ", s.algorithm, s.split_order",
+		`SELECT e.identifier_row, e.algorithm, e.expansion_order, e.splitting_algorithm, e.from_value, v.value
+			FROM identifier_expansions e JOIN identifiers i ON i.row_id = e.identifier_row
+			LEFT JOIN identifier_expansion_values v ON v.identifier_row = e.identifier_row
+				AND v.algorithm = e.algorithm AND v.expansion_order = e.expansion_order
+			WHERE ` + where + " ORDER BY " + identifiersOrder + ", e.algorithm, e.expansion_order, v.position",
+		`SELECT f.identifier_row, f.rule, f.severity, f.message, f.suggestion
+			FROM identifier_findings f JOIN identifiers i ON i.row_id = f.identifier_row
+			WHERE ` + where + " ORDER BY " + identifiersOrder + ", f.position",
+	}
+	scanners := []func(rows *sql.Rows) (string, func(*entity.Identifier), error){
+		nil, scanSplit, scanExpansion, scanFinding,
+	}
+
+	cursor := &identifierCursor{related: make([]*relatedRows, 0, len(queries)-1)}
+	for i, query := range queries {
+		rows, err := idb.db.query(ctx, query, args...)
+		if err != nil {
+			cursor.Close(ctx)
+			return nil, err
+		}
+
+		if i == 0 {
+			cursor.identifiers = rows
+			continue
+		}
+		cursor.related = append(cursor.related, &relatedRows{rows: rows, scan: scanners[i]})
+	}
+
+	return cursor, nil
+}
+
+// scanSplit reads a row from the identifier_splits table.
+func scanSplit(rows *sql.Rows) (string, func(*entity.Identifier), error) {
+	var rowID, algorithm string
+	var split entity.Split
+	if err := rows.Scan(&rowID, &algorithm, &split.Order, &split.Value); err != nil {
+		return "", nil, err
+	}
+
+	return rowID, func(ident *entity.Identifier) {
+		ident.Splits[algorithm] = append(ident.Splits[algorithm], split)
+	}, nil
+}
+
+// scanExpansion reads a row from the identifier_expansions table, joined with one of its values. Consecutive
+// rows for the same expansion are merged.
+func scanExpansion(rows *sql.Rows) (string, func(*entity.Identifier), error) {
+	var rowID, algorithm string
+	var expansion entity.Expansion
+	var value sql.NullString
+	err := rows.Scan(&rowID, &algorithm, &expansion.Order, &expansion.SplittingAlgorithm, &expansion.From, &value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return rowID, func(ident *entity.Identifier) {
+		expansions := ident.Expansions[algorithm]
+		if n := len(expansions); n == 0 || expansions[n-1].Order != expansion.Order {
+			expansion.Values = make([]string, 0)
+			expansions = append(expansions, expansion)
+		}
+
+		if value.Valid {
+			last := &expansions[len(expansions)-1]
+			last.Values = append(last.Values, value.String)
+		}
+		ident.Expansions[algorithm] = expansions
+	}, nil
+}
+
+// scanFinding reads a row from the identifier_findings table.
+func scanFinding(rows *sql.Rows) (string, func(*entity.Identifier), error) {
+	var rowID, severity string
+	var finding entity.Finding
+	if err := rows.Scan(&rowID, &finding.Rule, &severity, &finding.Message, &finding.Suggestion); err != nil {
+		return "", nil, err
+	}
+	finding.Severity = entity.Severity(severity)
+
+	return rowID, func(ident *entity.Identifier) {
+		ident.Findings = append(ident.Findings, finding)
+	}, nil
+}
+
+// relatedRows merges the rows from a table related to the identifiers. Rows are read ahead, until one
+// related to a following identifier is found.
+type relatedRows struct {
+	rows  *sql.Rows
+	scan  func(rows *sql.Rows) (string, func(*entity.Identifier), error)
+	key   string
+	apply func(*entity.Identifier)
+	done  bool
+}
+
+// mergeInto applies every pending row related to the identifier with the given row ID.
+func (r *relatedRows) mergeInto(rowID string, ident *entity.Identifier) error {
+	for {
+		if r.apply == nil {
+			if r.done {
+				return nil
+			}
+
+			if !r.rows.Next() {
+				r.done = true
+				return r.rows.Err()
+			}
+
+			key, apply, err := r.scan(r.rows)
+			if err != nil {
+				return err
+			}
+			r.key, r.apply = key, apply
+		}
+
+		if r.key != rowID {
+			return nil
+		}
+
+		r.apply(ident)
+		r.apply = nil
+	}
+}
+
+// identifierCursor implements repository.IdentifierIterator over the rows of the identifiers table and the
+// tables related to it.
+type identifierCursor struct {
+	identifiers *sql.Rows
+	related     []*relatedRows
+	rowID       string
+	current     entity.Identifier
+	err         error
+}
+
+func (c *identifierCursor) Next(ctx context.Context) bool {
+	if c.err != nil || !c.identifiers.Next() {
+		return false
+	}
+
+	var rowID, analysisID, language, tok, errorValue string
+	var position int64
+	ident := entity.Identifier{
+		Splits:     make(map[string][]entity.Split),
+		Expansions: make(map[string][]entity.Expansion),
+		Findings:   make([]entity.Finding, 0),
+	}
+	err := c.identifiers.Scan(&rowID, &ident.ID, &analysisID, &ident.ProjectRef, &ident.Package, &language,
+		&ident.File, &position, &ident.Line, &ident.Column, &ident.Name, &tok, &ident.Receiver, &ident.ReceiverName, &errorValue,
+		&ident.Normalization.Word, &ident.Normalization.Algorithm, &ident.Normalization.Score)
+	if err == nil {
+		ident.AnalysisID, err = uuid.Parse(analysisID)
+	}
+	for _, related := range c.related {
+		if err == nil {
+			err = related.mergeInto(rowID, &ident)
+		}
+	}
+	if err != nil {
+		log.WithError(err).Error("error decoding identifier rows")
+		c.err = repository.ErrIdentifierUnexpected
+		return false
+	}
+
+	ident.Language = entity.Language(language)
+	ident.Position = token.Pos(position)
+	ident.Type = fromStringToToken(tok)
+	if errorValue != "" {
+		ident.Error = errors.New(errorValue)
+	}
+	c.rowID = rowID
+	c.current = ident
+
+	return true
+}
+
+func (c *identifierCursor) Identifier() entity.Identifier {
+	return c.current
+}
+
+func (c *identifierCursor) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+
+	if err := c.identifiers.Err(); err != nil {
+		log.WithError(err).Error("error iterating identifier rows")
+		return repository.ErrIdentifierUnexpected
+	}
+
+	return nil
+}
+
+func (c *identifierCursor) Close(ctx context.Context) error {
+	var err error
+	if c.identifiers != nil {
+		err = c.identifiers.Close()
+	}
+
+	for _, related := range c.related {
+		if closeErr := related.rows.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// DeleteAllByAnalysisID removes every identifier related to a given analysis, either staged or committed, along
+// with their splits, expansions and findings.
+func (idb *IdentifierDB) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	var deleted int64
+	err := idb.db.withTx(ctx, func(tx *sql.Tx) error {
+		for _, table := range []string{"identifier_splits", "identifier_expansion_values", "identifier_expansions",
+			"identifier_findings"} {
+			_, err := tx.ExecContext(ctx, idb.db.rebind("DELETE FROM "+table+
+				" WHERE identifier_row IN (SELECT row_id FROM identifiers WHERE workspace_id = ? AND analysis_id = ?)"),
+				workspaceOf(ctx), analysisID.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx,
+			idb.db.rebind("DELETE FROM staged_analyses WHERE workspace_id = ? AND analysis_id = ?"),
+			workspaceOf(ctx), analysisID.String())
+		if err != nil {
+			return err
+		}
+
+		results, err := tx.ExecContext(ctx,
+			idb.db.rebind("DELETE FROM identifiers WHERE workspace_id = ? AND analysis_id = ?"),
+			workspaceOf(ctx), analysisID.String())
+		if err != nil {
+			return err
+		}
+		deleted, err = results.RowsAffected()
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Errorf("error deleting identifiers with analysis_id: %v", analysisID)
+		return repository.ErrIdentifierUnexpected
+	}
+
+	if deleted == 0 {
+		return repository.ErrIdentifierNoResults
+	}
+
+	return nil
+}
+
+// Commit makes the staged identifiers for a given analysis visible, and removes its in progress mark.
+func (idb *IdentifierDB) Commit(ctx context.Context, analysisID uuid.UUID) error {
+	err := idb.db.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			idb.db.rebind("UPDATE identifiers SET staged = ? WHERE workspace_id = ? AND analysis_id = ? AND staged = ?"),
+			false, workspaceOf(ctx), analysisID.String(), true)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			idb.db.rebind("DELETE FROM staged_analyses WHERE workspace_id = ? AND analysis_id = ?"),
+			workspaceOf(ctx), analysisID.String())
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Errorf("error committing identifiers with analysis_id: %v", analysisID)
+		return repository.ErrIdentifierUnexpected
+	}
+
+	return nil
+}
+
+// Stage marks the given analysis as in progress on the current workspace, or refreshes its mark.
+func (idb *IdentifierDB) Stage(ctx context.Context, analysisID uuid.UUID) error {
+	_, err := idb.db.exec(ctx,
+		`INSERT INTO staged_analyses (workspace_id, analysis_id, refreshed_at) VALUES (?, ?, ?)
+		ON CONFLICT (workspace_id, analysis_id) DO UPDATE SET refreshed_at = excluded.refreshed_at`,
+		workspaceOf(ctx), analysisID.String(), time.Now().UTC())
+	if err != nil {
+		log.WithError(err).Errorf("error staging analysis_id: %v", analysisID)
+		return repository.ErrIdentifierUnexpected
+	}
+
+	return nil
+}
+
+// FindStagedAnalysisIDs retrieves the analysis IDs for the identifiers staged or marked as in progress before the
+// given time on the current workspace, leaving out the analyses whose mark was refreshed after it.
+func (idb *IdentifierDB) FindStagedAnalysisIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	rows, err := idb.db.query(ctx,
+		`SELECT DISTINCT i.analysis_id FROM identifiers i WHERE i.workspace_id = ? AND i.staged = ? AND i.created_at < ?
+			AND NOT EXISTS (SELECT 1 FROM staged_analyses s WHERE s.workspace_id = i.workspace_id
+				AND s.analysis_id = i.analysis_id AND s.refreshed_at >= ?)
+		UNION
+		SELECT analysis_id FROM staged_analyses WHERE workspace_id = ? AND refreshed_at < ?`,
+		workspaceOf(ctx), true, before.UTC(), before.UTC(), workspaceOf(ctx), before.UTC())
+	if err != nil {
+		log.WithError(err).Errorf("error looking for staged identifiers before %v", before)
+		return []uuid.UUID{}, repository.ErrIdentifierUnexpected
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			log.WithError(err).Errorf("error decoding staged identifiers before %v", before)
+			return []uuid.UUID{}, repository.ErrIdentifierUnexpected
+		}
+
+		id, err := uuid.Parse(value)
+		if err != nil {
+			log.WithError(err).Warnf("invalid analysis_id %v on staged identifiers", value)
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Errorf("error iterating staged identifiers before %v", before)
+		return []uuid.UUID{}, repository.ErrIdentifierUnexpected
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,101 @@
+package sqldb_test
+
+import (
+	"context"
+	"errors"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/sqldb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAll_OnSQLIdentifierRepository_ShouldStoreSplitsExpansionsAndFindings(t *testing.T) {
+	drivers(t, func(t *testing.T, newDatabase func(t *testing.T) *sqldb.DB) {
+		ctx := context.Background()
+		r := sqldb.NewSQLIdentifierRepository(newDatabase(t))
+		r.BatchSize = 1
+
+		analysis := entity.AnalysisResults{ID: uuid.New(), ProjectName: "eroatta/test"}
+		ident := entity.Identifier{
+			ID:           "filename:main.go+++pkg:main+++declType:func+++name:parseCfgFile",
+			Package:      "main",
+			File:         "main.go",
+			Position:     token.Pos(42),
+			Name:         "parseCfgFile",
+			Type:         token.FUNC,
+			Receiver:     "*Parser",
+			ReceiverName: "p",
+			Language:     entity.LanguageEnglish,
+			Splits: map[string][]entity.Split{
+				"conserv": {{Order: 1, Value: "parse"}, {Order: 2, Value: "Cfg"}, {Order: 3, Value: "File"}},
+				"greedy":  {{Order: 1, Value: "parse_cfg_file"}},
+			},
+			Expansions: map[string][]entity.Expansion{
+				"basic": {
+					{Order: 1, From: "parse", Values: []string{"parse"}, SplittingAlgorithm: "conserv"},
+					{Order: 2, From: "Cfg", Values: []string{"config", "configuration"}, SplittingAlgorithm: "conserv"},
+					{Order: 3, From: "File", Values: []string{}, SplittingAlgorithm: "conserv"},
+				},
+			},
+			Normalization: entity.Normalization{Word: "parseConfigFile", Algorithm: "conserv+basic", Score: 0.85},
+			Findings: []entity.Finding{
+				{Rule: "abbreviation", Severity: entity.SeverityWarning, Message: "Cfg is an abbreviation", Suggestion: "Config"},
+				{Rule: "length", Severity: entity.SeverityInfo, Message: "name is long"},
+			},
+			Error: errors.New("partially expanded"),
+		}
+		other := entity.Identifier{ID: "filename:main.go+++pkg:main+++declType:var+++name:x", Package: "main",
+			File: "main.go", Position: token.Pos(7), Name: "x", Type: token.VAR}
+
+		require.NoError(t, r.AddAll(ctx, analysis, []entity.Identifier{ident, other}))
+		require.NoError(t, r.Commit(ctx, analysis.ID))
+
+		found, err := r.FindAllByAnalysisID(ctx, analysis.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(found))
+
+		assert.Equal(t, "x", found[0].Name)
+		assert.Equal(t, token.VAR, found[0].Type)
+		assert.Empty(t, found[0].Splits)
+		assert.Empty(t, found[0].Findings)
+
+		ident.AnalysisID = analysis.ID
+		ident.ProjectRef = analysis.ProjectName
+		assert.Equal(t, ident, found[1])
+	})
+}
+
+func TestNewSQLClient_OnExistingSQLiteDatabase_ShouldKeepSchema(t *testing.T) {
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "reader.db")
+
+	db, err := sqldb.NewSQLClient(sqldb.DriverSQLite, dsn)
+	require.NoError(t, err)
+	project := entity.Project{ID: uuid.New(), Reference: "eroatta/test"}
+	require.NoError(t, sqldb.NewSQLProjectRepository(db).Add(ctx, project))
+	require.NoError(t, db.Close())
+
+	db, err = sqldb.NewSQLClient(sqldb.DriverSQLite, dsn)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var versions int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&versions))
+	assert.Equal(t, 12, versions)
+
+	found, err := sqldb.NewSQLProjectRepository(db).Get(ctx, project.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, project.Reference, found.Reference)
+}
+
+func TestNewSQLClient_OnUnsupportedDriver_ShouldReturnError(t *testing.T) {
+	db, err := sqldb.NewSQLClient("oracle", "")
+
+	assert.Nil(t, db)
+	assert.Equal(t, sqldb.ErrSQLConnection, err)
+}
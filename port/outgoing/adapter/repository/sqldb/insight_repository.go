@@ -0,0 +1,150 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// InsightDB represents a SQL database, focused on the table handling the insights.
+type InsightDB struct {
+	db *DB
+}
+
+// NewSQLInsightRepository creates a repository.InsightRepository backed up by a SQL database.
+func NewSQLInsightRepository(db *DB) *InsightDB {
+	return &InsightDB{
+		db: db,
+	}
+}
+
+// AddAll stores a set of entity.Insight entities into rows on the underlying insights table, within a
+// single transaction.
+func (idb *InsightDB) AddAll(ctx context.Context, insights []entity.Insight) error {
+	err := idb.db.withTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, idb.db.rebind(`INSERT INTO insights (workspace_id, id, created_at,
+			project_ref, analysis_id, package, language, accuracy, total_identifiers, total_exported, total_splits,
+			total_expansions, total_weight, files, is_test) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`))
+		if err != nil {
+			return err
+		}
+
+		workspaceID := workspaceOf(ctx)
+		createdAt := time.Now().UTC()
+		for _, insight := range insights {
+			files := make([]string, 0, len(insight.Files))
+			for file := range insight.Files {
+				files = append(files, file)
+			}
+
+			var accuracy float64
+			if insight.TotalIdentifiers > 0 {
+				accuracy = insight.Rate()
+			}
+
+			_, err := stmt.ExecContext(ctx, workspaceID, uuid.New().String(), createdAt, insight.ProjectRef,
+				insight.AnalysisID.String(), insight.Package, string(insight.Language), accuracy,
+				insight.TotalIdentifiers, insight.TotalExported, toDocument(insight.TotalSplits),
+				toDocument(insight.TotalExpansions), insight.TotalWeight, toDocument(files), insight.Test)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("error inserting records")
+		return repository.ErrInsightUnexpected
+	}
+
+	log.WithField("count", len(insights)).Debug("inserted insights")
+	return nil
+}
+
+// GetByAnalysisID finds a set of existing insights on the underlying insights table, and returns
+// them as entity.Insight.
+func (idb *InsightDB) GetByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Insight, error) {
+	rows, err := idb.db.query(ctx, `SELECT id, project_ref, analysis_id, package, language, total_identifiers,
+		total_exported, total_splits, total_expansions, total_weight, files, is_test
+		FROM insights WHERE workspace_id = ? AND analysis_id = ? ORDER BY package, is_test`, workspaceOf(ctx), analysisID.String())
+	if err != nil {
+		log.WithError(err).Errorf("error searching insights with analysis_id: %v", analysisID)
+		return []entity.Insight{}, repository.ErrInsightUnexpected
+	}
+	defer rows.Close()
+
+	insights := make([]entity.Insight, 0)
+	for rows.Next() {
+		var id, language, totalSplits, totalExpansions, files string
+		insight := entity.Insight{
+			TotalSplits:     make(map[string]int),
+			TotalExpansions: make(map[string]int),
+			Files:           make(map[string]struct{}),
+		}
+		var fileList []string
+		err := rows.Scan(&insight.ID, &insight.ProjectRef, &id, &insight.Package, &language,
+			&insight.TotalIdentifiers, &insight.TotalExported, &totalSplits, &totalExpansions,
+			&insight.TotalWeight, &files, &insight.Test)
+		if err == nil {
+			insight.AnalysisID, err = uuid.Parse(id)
+		}
+		if err == nil {
+			err = fromDocument(totalSplits, &insight.TotalSplits)
+		}
+		if err == nil {
+			err = fromDocument(totalExpansions, &insight.TotalExpansions)
+		}
+		if err == nil {
+			err = fromDocument(files, &fileList)
+		}
+		if err != nil {
+			log.WithError(err).Errorf("error decoding insights for analysis_id: %v", analysisID)
+			return []entity.Insight{}, repository.ErrInsightUnexpected
+		}
+
+		if insight.TotalSplits == nil {
+			insight.TotalSplits = make(map[string]int)
+		}
+		if insight.TotalExpansions == nil {
+			insight.TotalExpansions = make(map[string]int)
+		}
+		insight.Language = entity.Language(language)
+		for _, file := range fileList {
+			insight.Files[file] = struct{}{}
+		}
+		insights = append(insights, insight)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Errorf("error iterating insights for analysis_id: %v", analysisID)
+		return []entity.Insight{}, repository.ErrInsightUnexpected
+	}
+
+	if len(insights) == 0 {
+		return []entity.Insight{}, repository.ErrInsightNoResults
+	}
+
+	return insights, nil
+}
+
+// DeleteAllByAnalysisID removes a set of existing insights from the underlying insights table.
+func (idb *InsightDB) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	results, err := idb.db.exec(ctx, "DELETE FROM insights WHERE workspace_id = ? AND analysis_id = ?",
+		workspaceOf(ctx), analysisID.String())
+	if err != nil {
+		log.WithError(err).Errorf("error deleting insights with analysis_id: %v", analysisID)
+		return repository.ErrInsightUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrInsightNoResults
+	}
+
+	return nil
+}
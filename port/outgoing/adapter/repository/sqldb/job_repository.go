@@ -0,0 +1,152 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const jobColumns = "id, project_id, analysis_id, pipeline, commit_hash, attempt, status, error, owner, started_at, " +
+	"refreshed_at"
+
+// JobDB represents a SQL database, focused on the table handling the analysis jobs.
+type JobDB struct {
+	db *DB
+}
+
+// NewSQLJobRepository creates a repository.JobRepository backed up by a SQL database.
+func NewSQLJobRepository(db *DB) *JobDB {
+	return &JobDB{
+		db: db,
+	}
+}
+
+// Add stores an AnalysisJob entity into a row on the underlying analysis_jobs table, for the current workspace.
+func (jdb *JobDB) Add(ctx context.Context, job entity.AnalysisJob) error {
+	_, err := jdb.db.exec(ctx,
+		"INSERT INTO analysis_jobs (workspace_id, "+jobColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		workspaceOf(ctx), job.ID.String(), job.ProjectID.String(), job.AnalysisID.String(),
+		toDocument(toPipelineDocument(job.Pipeline)), job.Commit, job.Attempt, job.Status, job.Error, job.Owner,
+		job.DateStarted.UTC(), job.DateRefreshed.UTC())
+	if err != nil {
+		log.WithError(err).Errorf("error inserting analysis job %v", job.ID)
+		return repository.ErrJobUnexpected
+	}
+
+	return nil
+}
+
+// FindAll retrieves every existing AnalysisJob for the current workspace on the underlying analysis_jobs table.
+func (jdb *JobDB) FindAll(ctx context.Context) ([]entity.AnalysisJob, error) {
+	rows, err := jdb.db.query(ctx, "SELECT "+jobColumns+" FROM analysis_jobs WHERE workspace_id = ? ORDER BY started_at",
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Error("error searching analysis jobs")
+		return []entity.AnalysisJob{}, repository.ErrJobUnexpected
+	}
+
+	return jdb.scan(rows)
+}
+
+// scan reads and closes a set of rows from the analysis_jobs table.
+func (jdb *JobDB) scan(rows *sql.Rows) ([]entity.AnalysisJob, error) {
+	defer rows.Close()
+
+	jobs := make([]entity.AnalysisJob, 0)
+	for rows.Next() {
+		var id, projectID, analysisID, pipeline string
+		var job entity.AnalysisJob
+		err := rows.Scan(&id, &projectID, &analysisID, &pipeline, &job.Commit, &job.Attempt, &job.Status, &job.Error,
+			&job.Owner, &job.DateStarted, &job.DateRefreshed)
+		if err == nil {
+			job.ID, err = uuid.Parse(id)
+		}
+		if err == nil {
+			job.ProjectID, err = uuid.Parse(projectID)
+		}
+		if err == nil {
+			job.AnalysisID, err = uuid.Parse(analysisID)
+		}
+		var doc *pipelineDocument
+		if err == nil {
+			err = fromDocument(pipeline, &doc)
+		}
+		if err != nil {
+			log.WithError(err).Error("error decoding analysis job rows")
+			return []entity.AnalysisJob{}, repository.ErrJobUnexpected
+		}
+		job.Pipeline = doc.toEntity()
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error iterating analysis job rows")
+		return []entity.AnalysisJob{}, repository.ErrJobUnexpected
+	}
+
+	return jobs, nil
+}
+
+// Refresh extends the hold of the owner on a running AnalysisJob on the underlying analysis_jobs table.
+func (jdb *JobDB) Refresh(ctx context.Context, job entity.AnalysisJob) error {
+	results, err := jdb.db.exec(ctx, `UPDATE analysis_jobs SET refreshed_at = ?
+		WHERE id = ? AND workspace_id = ? AND status = ? AND owner = ? AND attempt = ?`,
+		time.Now().UTC(), job.ID.String(), workspaceOf(ctx), entity.JobStatusRunning, job.Owner, job.Attempt)
+
+	return jdb.updated(results, err, job.ID)
+}
+
+// Claim records the given AnalysisJob as the next attempt of a running job on the underlying analysis_jobs table,
+// as long as it wasn't refreshed since the given time.
+func (jdb *JobDB) Claim(ctx context.Context, job entity.AnalysisJob, refreshedBefore time.Time) error {
+	results, err := jdb.db.exec(ctx, `UPDATE analysis_jobs SET analysis_id = ?, attempt = ?, owner = ?, refreshed_at = ?
+		WHERE id = ? AND workspace_id = ? AND status = ? AND attempt = ? AND refreshed_at < ?`,
+		job.AnalysisID.String(), job.Attempt, job.Owner, job.DateRefreshed.UTC(), job.ID.String(), workspaceOf(ctx),
+		entity.JobStatusRunning, job.Attempt-1, refreshedBefore.UTC())
+
+	return jdb.updated(results, err, job.ID)
+}
+
+// Fail records a running AnalysisJob as failed on the underlying analysis_jobs table.
+func (jdb *JobDB) Fail(ctx context.Context, ID uuid.UUID, reason string) error {
+	results, err := jdb.db.exec(ctx, `UPDATE analysis_jobs SET status = ?, error = ?, refreshed_at = ?
+		WHERE id = ? AND workspace_id = ? AND status = ?`,
+		entity.JobStatusFailed, reason, time.Now().UTC(), ID.String(), workspaceOf(ctx), entity.JobStatusRunning)
+
+	return jdb.updated(results, err, ID)
+}
+
+// updated checks the results of updating an AnalysisJob.
+func (jdb *JobDB) updated(results sql.Result, err error, ID uuid.UUID) error {
+	if err != nil {
+		log.WithError(err).Errorf("error updating analysis job with id: %v", ID)
+		return repository.ErrJobUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrJobNoResults
+	}
+
+	return nil
+}
+
+// Delete removes an existing AnalysisJob from the underlying analysis_jobs table.
+func (jdb *JobDB) Delete(ctx context.Context, ID uuid.UUID) error {
+	results, err := jdb.db.exec(ctx, "DELETE FROM analysis_jobs WHERE id = ? AND workspace_id = ?", ID.String(),
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting analysis job with id: %v", ID)
+		return repository.ErrJobUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrJobNoResults
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"go/token"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// workspaceOf returns the workspace set on the context, as stored on the workspace_id column.
+func workspaceOf(ctx context.Context) string {
+	return entity.WorkspaceFrom(ctx).String()
+}
+
+// toDocument encodes a list or map as a JSON document, to be stored on a single column.
+func toDocument(v interface{}) string {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return "null"
+	}
+
+	return string(bytes)
+}
+
+// fromDocument decodes a JSON document stored on a single column into the given list or map.
+func fromDocument(doc string, v interface{}) error {
+	return json.Unmarshal([]byte(doc), v)
+}
+
+// filterDocument represents the include and exclude patterns of a project, stored on a single column.
+type filterDocument struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+func toFilterDocument(filter entity.FileFilter) filterDocument {
+	return filterDocument{Include: filter.Include, Exclude: filter.Exclude}
+}
+
+func (doc filterDocument) toEntity() entity.FileFilter {
+	return entity.FileFilter{Include: doc.Include, Exclude: doc.Exclude}
+}
+
+// pipelineDocument represents the pipeline requested for an analysis job, stored on a single column. Lists are
+// never omitted, as a missing list keeps the configured one while an empty list applies none.
+type pipelineDocument struct {
+	Miners       []string `json:"miners"`
+	Splitters    []string `json:"splitters"`
+	Expanders    []string `json:"expanders"`
+	Rules        []string `json:"rules"`
+	IncludeTests bool     `json:"include_tests"`
+}
+
+func toPipelineDocument(pipeline *entity.Pipeline) *pipelineDocument {
+	if pipeline == nil {
+		return nil
+	}
+
+	return &pipelineDocument{
+		Miners:       pipeline.Miners,
+		Splitters:    pipeline.Splitters,
+		Expanders:    pipeline.Expanders,
+		Rules:        pipeline.Rules,
+		IncludeTests: pipeline.IncludeTests,
+	}
+}
+
+func (doc *pipelineDocument) toEntity() *entity.Pipeline {
+	if doc == nil {
+		return nil
+	}
+
+	return &entity.Pipeline{
+		Miners:       doc.Miners,
+		Splitters:    doc.Splitters,
+		Expanders:    doc.Expanders,
+		Rules:        doc.Rules,
+		IncludeTests: doc.IncludeTests,
+	}
+}
+
+// fromTokenToString transforms a token.Token value into a human-readable string.
+func fromTokenToString(tok token.Token) string {
+	var tokenString string
+	switch tok {
+	case token.FUNC:
+		tokenString = "func"
+	case token.VAR:
+		tokenString = "var"
+	case token.CONST:
+		tokenString = "const"
+	case token.STRUCT:
+		tokenString = "struct"
+	case token.INTERFACE:
+		tokenString = "interface"
+	default:
+		tokenString = "unknown"
+	}
+
+	return tokenString
+}
+
+// fromStringToToken transforms a human-readable string into its token.Token value.
+func fromStringToToken(str string) token.Token {
+	var tok token.Token
+	switch str {
+	case "func":
+		tok = token.FUNC
+	case "var":
+		tok = token.VAR
+	case "const":
+		tok = token.CONST
+	case "struct":
+		tok = token.STRUCT
+	case "interface":
+		tok = token.INTERFACE
+	default:
+		tok = token.DEFAULT
+	}
+
+	return tok
+}
+
+// utc returns the given time on UTC, so every stored time can be compared. A nil time is kept as nil.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,345 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// migration represents a versioned change on the database schema. Statements must be valid on every
+// supported driver.
+type migration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+// migrations holds every schema change, ordered by version. Applied migrations must never be modified,
+// new changes are added as new versions.
+//
+// Identifiers keep their splits, expansions and findings on their own tables, so they can be filtered
+// by their values. Lists and maps that are only retrieved along their owner are stored as JSON documents.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create projects, analysis, identifiers, insights and dictionaries",
+		statements: []string{
+			`CREATE TABLE projects (
+				id TEXT PRIMARY KEY,
+				status TEXT NOT NULL,
+				project_ref TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				remote_id TEXT NOT NULL,
+				owner TEXT NOT NULL,
+				fullname TEXT NOT NULL,
+				description TEXT NOT NULL,
+				clone_url TEXT NOT NULL,
+				branch TEXT NOT NULL,
+				license TEXT NOT NULL,
+				remote_created_at TIMESTAMP,
+				remote_updated_at TIMESTAMP,
+				is_fork BOOLEAN NOT NULL,
+				size INTEGER NOT NULL,
+				stargazers INTEGER NOT NULL,
+				watchers INTEGER NOT NULL,
+				forks INTEGER NOT NULL,
+				source_hash TEXT NOT NULL,
+				source_location TEXT NOT NULL,
+				source_files TEXT NOT NULL
+			)`,
+			`CREATE INDEX projects_project_ref_idx ON projects (project_ref)`,
+			`CREATE TABLE analysis (
+				id TEXT PRIMARY KEY,
+				created_at TIMESTAMP NOT NULL,
+				project_id TEXT NOT NULL,
+				project_ref TEXT NOT NULL,
+				miners TEXT NOT NULL,
+				splitters TEXT NOT NULL,
+				expanders TEXT NOT NULL,
+				rules TEXT NOT NULL,
+				files_total INTEGER NOT NULL,
+				files_valid INTEGER NOT NULL,
+				files_failed INTEGER NOT NULL,
+				files_error_samples TEXT NOT NULL,
+				identifiers_total INTEGER NOT NULL,
+				identifiers_valid INTEGER NOT NULL,
+				identifiers_failed INTEGER NOT NULL,
+				identifiers_error_samples TEXT NOT NULL
+			)`,
+			`CREATE INDEX analysis_project_id_idx ON analysis (project_id)`,
+			`CREATE TABLE identifiers (
+				row_id TEXT PRIMARY KEY,
+				identifier_id TEXT NOT NULL,
+				analysis_id TEXT NOT NULL,
+				project_ref TEXT NOT NULL,
+				package TEXT NOT NULL,
+				absolute_package TEXT NOT NULL,
+				language TEXT NOT NULL,
+				file TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				name TEXT NOT NULL,
+				type TEXT NOT NULL,
+				receiver TEXT NOT NULL,
+				receiver_name TEXT NOT NULL,
+				error_value TEXT NOT NULL,
+				is_exported BOOLEAN NOT NULL,
+				normalization_word TEXT NOT NULL,
+				normalization_algorithm TEXT NOT NULL,
+				normalization_score DOUBLE PRECISION NOT NULL,
+				staged BOOLEAN NOT NULL,
+				created_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX identifiers_analysis_id_idx ON identifiers (analysis_id, staged)`,
+			`CREATE INDEX identifiers_project_ref_file_idx ON identifiers (project_ref, file)`,
+			`CREATE INDEX identifiers_staged_idx ON identifiers (staged, created_at)`,
+			`CREATE TABLE identifier_splits (
+				identifier_row TEXT NOT NULL REFERENCES identifiers (row_id),
+				algorithm TEXT NOT NULL,
+				split_order INTEGER NOT NULL,
+				value TEXT NOT NULL,
+				PRIMARY KEY (identifier_row, algorithm, split_order)
+			)`,
+			`CREATE INDEX identifier_splits_value_idx ON identifier_splits (algorithm, value)`,
+			`CREATE TABLE identifier_expansions (
+				identifier_row TEXT NOT NULL REFERENCES identifiers (row_id),
+				algorithm TEXT NOT NULL,
+				expansion_order INTEGER NOT NULL,
+				splitting_algorithm TEXT NOT NULL,
+				from_value TEXT NOT NULL,
+				PRIMARY KEY (identifier_row, algorithm, expansion_order)
+			)`,
+			`CREATE TABLE identifier_expansion_values (
+				identifier_row TEXT NOT NULL REFERENCES identifiers (row_id),
+				algorithm TEXT NOT NULL,
+				expansion_order INTEGER NOT NULL,
+				position INTEGER NOT NULL,
+				value TEXT NOT NULL,
+				PRIMARY KEY (identifier_row, algorithm, expansion_order, position)
+			)`,
+			`CREATE INDEX identifier_expansion_values_value_idx ON identifier_expansion_values (algorithm, value)`,
+			`CREATE TABLE identifier_findings (
+				identifier_row TEXT NOT NULL REFERENCES identifiers (row_id),
+				position INTEGER NOT NULL,
+				rule TEXT NOT NULL,
+				severity TEXT NOT NULL,
+				message TEXT NOT NULL,
+				suggestion TEXT NOT NULL,
+				PRIMARY KEY (identifier_row, position)
+			)`,
+			`CREATE INDEX identifier_findings_rule_idx ON identifier_findings (rule, severity)`,
+			`CREATE TABLE insights (
+				id TEXT PRIMARY KEY,
+				created_at TIMESTAMP NOT NULL,
+				project_ref TEXT NOT NULL,
+				analysis_id TEXT NOT NULL,
+				package TEXT NOT NULL,
+				language TEXT NOT NULL,
+				accuracy DOUBLE PRECISION NOT NULL,
+				total_identifiers INTEGER NOT NULL,
+				total_exported INTEGER NOT NULL,
+				total_splits TEXT NOT NULL,
+				total_expansions TEXT NOT NULL,
+				total_weight DOUBLE PRECISION NOT NULL,
+				files TEXT NOT NULL
+			)`,
+			`CREATE INDEX insights_analysis_id_idx ON insights (analysis_id)`,
+			`CREATE TABLE dictionaries (
+				id TEXT PRIMARY KEY,
+				scope TEXT NOT NULL,
+				project_ref TEXT NOT NULL,
+				entries TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX dictionaries_scope_idx ON dictionaries (scope, project_ref)`,
+		},
+	},
+	{
+		version:     2,
+		description: "create api_keys",
+		statements: []string{
+			`CREATE TABLE api_keys (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				prefix TEXT NOT NULL,
+				hash TEXT NOT NULL UNIQUE,
+				scopes TEXT NOT NULL,
+				created_at TIMESTAMP NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     3,
+		description: "create workspaces and scope every table by workspace",
+		statements: []string{
+			`CREATE TABLE workspaces (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL UNIQUE,
+				created_at TIMESTAMP NOT NULL
+			)`,
+			`ALTER TABLE projects ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'`,
+			`ALTER TABLE analysis ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'`,
+			`ALTER TABLE identifiers ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'`,
+			`ALTER TABLE insights ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'`,
+			`ALTER TABLE dictionaries ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'`,
+			`ALTER TABLE api_keys ADD COLUMN workspace_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'`,
+			`CREATE INDEX projects_workspace_id_idx ON projects (workspace_id, project_ref)`,
+			`CREATE INDEX identifiers_workspace_id_idx ON identifiers (workspace_id, analysis_id)`,
+		},
+	},
+	{
+		version:     4,
+		description: "add file filters to projects and skipped files to analysis",
+		statements: []string{
+			`ALTER TABLE projects ADD COLUMN file_filter TEXT NOT NULL DEFAULT '{}'`,
+			`ALTER TABLE analysis ADD COLUMN files_skipped INTEGER NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		version:     5,
+		description: "add the test scope to analysis and insights",
+		statements: []string{
+			`ALTER TABLE analysis ADD COLUMN include_tests BOOLEAN NOT NULL DEFAULT FALSE`,
+			`ALTER TABLE insights ADD COLUMN is_test BOOLEAN NOT NULL DEFAULT FALSE`,
+		},
+	},
+	{
+		version:     6,
+		description: "create analysis jobs",
+		statements: []string{
+			`CREATE TABLE analysis_jobs (
+				id TEXT PRIMARY KEY,
+				workspace_id TEXT NOT NULL,
+				project_id TEXT NOT NULL,
+				pipeline TEXT NOT NULL,
+				attempt INTEGER NOT NULL,
+				started_at TIMESTAMP NOT NULL
+			)`,
+		},
+	},
+	{
+		version:     7,
+		description: "create staged analyses",
+		statements: []string{
+			`CREATE TABLE staged_analyses (
+				workspace_id TEXT NOT NULL,
+				analysis_id TEXT NOT NULL,
+				refreshed_at TIMESTAMP NOT NULL,
+				PRIMARY KEY (workspace_id, analysis_id)
+			)`,
+		},
+	},
+	{
+		version:     8,
+		description: "create search documents and postings",
+		statements: []string{
+			`CREATE TABLE search_documents (
+				id TEXT PRIMARY KEY,
+				workspace_id TEXT NOT NULL,
+				analysis_id TEXT NOT NULL,
+				project_ref TEXT NOT NULL,
+				identifier_id TEXT NOT NULL,
+				name TEXT NOT NULL,
+				type INTEGER NOT NULL,
+				package TEXT NOT NULL,
+				file TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				normalization TEXT NOT NULL
+			)`,
+			`CREATE INDEX search_documents_analysis_id_idx ON search_documents (workspace_id, analysis_id)`,
+			`CREATE TABLE search_postings (
+				workspace_id TEXT NOT NULL,
+				term TEXT NOT NULL,
+				term_length INTEGER NOT NULL,
+				document_id TEXT NOT NULL,
+				analysis_id TEXT NOT NULL,
+				weight DOUBLE PRECISION NOT NULL,
+				PRIMARY KEY (workspace_id, term, document_id)
+			)`,
+			`CREATE INDEX search_postings_analysis_id_idx ON search_postings (workspace_id, analysis_id)`,
+		},
+	},
+	{
+		version:     9,
+		description: "add the line and column of the identifiers",
+		statements: []string{
+			`ALTER TABLE identifiers ADD COLUMN line_number INTEGER NOT NULL DEFAULT 0`,
+			`ALTER TABLE identifiers ADD COLUMN column_number INTEGER NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		version:     10,
+		description: "add the webhook secret to workspaces",
+		statements: []string{
+			`ALTER TABLE workspaces ADD COLUMN webhook_secret TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version:     11,
+		description: "add the owner, analysis and status to analysis jobs",
+		statements: []string{
+			`ALTER TABLE analysis_jobs ADD COLUMN analysis_id TEXT NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000'`,
+			`ALTER TABLE analysis_jobs ADD COLUMN status TEXT NOT NULL DEFAULT 'running'`,
+			`ALTER TABLE analysis_jobs ADD COLUMN error TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE analysis_jobs ADD COLUMN owner TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE analysis_jobs ADD COLUMN refreshed_at TIMESTAMP`,
+			`UPDATE analysis_jobs SET refreshed_at = started_at`,
+		},
+	},
+	{
+		version:     12,
+		description: "add the pushed commit to analysis jobs",
+		statements: []string{
+			`ALTER TABLE analysis_jobs ADD COLUMN commit_hash TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+}
+
+// Migrate applies the pending migrations on the current database, recording each applied version on the
+// schema_migrations table. Every migration is applied within its own transaction.
+func (db *DB) Migrate(ctx context.Context) error {
+	_, err := db.exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`)
+	if err != nil {
+		log.WithError(err).Error("error creating schema_migrations table")
+		return ErrSQLMigration
+	}
+
+	var current int
+	err = db.queryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current)
+	if err != nil {
+		log.WithError(err).Error("error looking for the current schema version")
+		return ErrSQLMigration
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		err := db.withTx(ctx, func(tx *sql.Tx) error {
+			for _, statement := range m.statements {
+				if _, err := tx.ExecContext(ctx, statement); err != nil {
+					return err
+				}
+			}
+
+			_, err := tx.ExecContext(ctx,
+				db.rebind("INSERT INTO schema_migrations (version, description, applied_at) VALUES (?, ?, ?)"),
+				m.version, m.description, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			log.WithError(err).Errorf("error applying migration %d: %s", m.version, m.description)
+			return ErrSQLMigration
+		}
+		log.Infof("Applied migration %d: %s", m.version, m.description)
+	}
+
+	return nil
+}
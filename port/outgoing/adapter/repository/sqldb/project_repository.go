@@ -0,0 +1,241 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strings"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const projectColumns = `id, status, project_ref, created_at, remote_id, owner, fullname, description, clone_url,
+	branch, license, remote_created_at, remote_updated_at, is_fork, size, stargazers, watchers, forks,
+	source_hash, source_location, source_files, file_filter`
+
+// ProjectDB represents a SQL database, focused on the table handling the projects.
+type ProjectDB struct {
+	db *DB
+}
+
+// NewSQLProjectRepository creates a repository.ProjectRepository backed up by a SQL database.
+func NewSQLProjectRepository(db *DB) *ProjectDB {
+	return &ProjectDB{
+		db: db,
+	}
+}
+
+// Add stores a Project entity into a row on the underlying projects table.
+func (pdb *ProjectDB) Add(ctx context.Context, project entity.Project) error {
+	_, err := pdb.db.exec(ctx, "INSERT INTO projects (workspace_id, "+projectColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		workspaceOf(ctx), project.ID.String(), project.Status, project.Reference, project.CreatedAt.UTC(),
+		project.Metadata.RemoteID, project.Metadata.Owner, project.Metadata.Fullname, project.Metadata.Description,
+		project.Metadata.CloneURL, project.Metadata.DefaultBranch, project.Metadata.License,
+		utc(project.Metadata.CreatedAt), utc(project.Metadata.UpdatedAt), project.Metadata.IsFork,
+		project.Metadata.Size, project.Metadata.Stargazers, project.Metadata.Watchers, project.Metadata.Forks,
+		project.SourceCode.Hash, project.SourceCode.Location, toDocument(project.SourceCode.Files),
+		toDocument(toFilterDocument(project.Filter)))
+	if err != nil {
+		log.WithError(err).Errorf("error inserting record %v", project)
+		return repository.ErrProjectUnexpected
+	}
+
+	return nil
+}
+
+// Get finds an existing Project by ID.
+func (pdb *ProjectDB) Get(ctx context.Context, ID uuid.UUID) (entity.Project, error) {
+	return pdb.find(ctx, "id", ID.String())
+}
+
+// GetByReference finds an existing Project using the given reference as filter.
+func (pdb *ProjectDB) GetByReference(ctx context.Context, projectRef string) (entity.Project, error) {
+	return pdb.find(ctx, "project_ref", projectRef)
+}
+
+func (pdb *ProjectDB) find(ctx context.Context, column string, value string) (entity.Project, error) {
+	row := pdb.db.queryRow(ctx, "SELECT "+projectColumns+" FROM projects WHERE workspace_id = ? AND "+column+" = ?",
+		workspaceOf(ctx), value)
+
+	project, err := scanProject(row)
+	switch err {
+	case nil:
+		// do nothing
+	case sql.ErrNoRows:
+		return entity.Project{}, repository.ErrProjectNoResults
+	default:
+		log.WithError(err).Errorf("error searching project with %s: %v", column, value)
+		return entity.Project{}, repository.ErrProjectUnexpected
+	}
+
+	return project, nil
+}
+
+// Query retrieves a page of the projects matching and sorted by the given query. The accuracy of each project is
+// computed from its insights, leaving out the ones from test files. Projects with the same sorting value are
+// sorted by their reference.
+func (pdb *ProjectDB) Query(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error) {
+	where := "workspace_id = ?"
+	args := []interface{}{workspaceOf(ctx)}
+	if query.Owner != "" {
+		where += " AND owner = ?"
+		args = append(args, query.Owner)
+	}
+	if query.License != "" {
+		where += " AND license = ?"
+		args = append(args, query.License)
+	}
+	if query.Fork != nil {
+		where += " AND is_fork = ?"
+		args = append(args, *query.Fork)
+	}
+	if query.Status != "" {
+		where += " AND status = ?"
+		args = append(args, query.Status)
+	}
+	if query.Text != "" {
+		where += ` AND (LOWER(project_ref) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\')`
+		pattern := "%" + likeEscaper.Replace(strings.ToLower(query.Text)) + "%"
+		args = append(args, pattern, pattern)
+	}
+
+	page := entity.ProjectPage{
+		Projects: make([]entity.Project, 0),
+		Accuracy: make(map[uuid.UUID]float64),
+	}
+	if err := pdb.db.queryRow(ctx, "SELECT COUNT(*) FROM projects WHERE "+where, args...).Scan(&page.Total); err != nil {
+		log.WithError(err).Error("error counting projects")
+		return entity.ProjectPage{}, repository.ErrProjectUnexpected
+	}
+
+	order := "created_at DESC"
+	switch query.Sort {
+	case entity.ProjectSortCreated:
+		order = "created_at"
+	case entity.ProjectSortStars:
+		order = "stargazers"
+	case entity.ProjectSortStarsDesc:
+		order = "stargazers DESC"
+	case entity.ProjectSortAccuracy:
+		order = "a.accuracy IS NULL, a.accuracy"
+	case entity.ProjectSortAccuracyDesc:
+		order = "a.accuracy IS NULL, a.accuracy DESC"
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+
+	rows, err := pdb.db.query(ctx, "SELECT "+projectColumns+`, a.accuracy FROM projects
+		LEFT JOIN (SELECT project_ref AS ref, SUM(total_weight) / SUM(total_identifiers) AS accuracy FROM insights
+			WHERE workspace_id = ? AND is_test = ? GROUP BY project_ref HAVING SUM(total_identifiers) > 0) a ON a.ref = project_ref
+		WHERE `+where+" ORDER BY "+order+", project_ref, id LIMIT ? OFFSET ?",
+		append(append([]interface{}{workspaceOf(ctx), false}, args...), limit, query.Offset)...)
+	if err != nil {
+		log.WithError(err).Error("error looking for projects")
+		return entity.ProjectPage{}, repository.ErrProjectUnexpected
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var accuracy sql.NullFloat64
+		project, err := scanProject(rows, &accuracy)
+		if err != nil {
+			log.WithError(err).Error("error decoding projects")
+			return entity.ProjectPage{}, repository.ErrProjectUnexpected
+		}
+
+		page.Projects = append(page.Projects, project)
+		if accuracy.Valid {
+			page.Accuracy[project.ID] = accuracy.Float64
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error iterating projects")
+		return entity.ProjectPage{}, repository.ErrProjectUnexpected
+	}
+
+	return page, nil
+}
+
+// likeEscaper escapes the wildcards on a text used as a LIKE pattern.
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanProject reads a project from a row holding the projectColumns, along with any given extra column.
+func scanProject(row rowScanner, extra ...interface{}) (entity.Project, error) {
+	var id, files, filter string
+	var project entity.Project
+	dest := []interface{}{&id, &project.Status, &project.Reference, &project.CreatedAt,
+		&project.Metadata.RemoteID, &project.Metadata.Owner, &project.Metadata.Fullname, &project.Metadata.Description,
+		&project.Metadata.CloneURL, &project.Metadata.DefaultBranch, &project.Metadata.License,
+		&project.Metadata.CreatedAt, &project.Metadata.UpdatedAt, &project.Metadata.IsFork,
+		&project.Metadata.Size, &project.Metadata.Stargazers, &project.Metadata.Watchers, &project.Metadata.Forks,
+		&project.SourceCode.Hash, &project.SourceCode.Location, &files, &filter}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return entity.Project{}, err
+	}
+
+	var err error
+	project.ID, err = uuid.Parse(id)
+	if err == nil {
+		err = fromDocument(files, &project.SourceCode.Files)
+	}
+	var doc filterDocument
+	if err == nil {
+		err = fromDocument(filter, &doc)
+	}
+	project.Filter = doc.toEntity()
+
+	return project, err
+}
+
+// Update replaces the row for an existing Project on the underlying projects table.
+func (pdb *ProjectDB) Update(ctx context.Context, project entity.Project) error {
+	results, err := pdb.db.exec(ctx, `UPDATE projects SET status = ?, project_ref = ?, created_at = ?, remote_id = ?,
+		owner = ?, fullname = ?, description = ?, clone_url = ?, branch = ?, license = ?, remote_created_at = ?,
+		remote_updated_at = ?, is_fork = ?, size = ?, stargazers = ?, watchers = ?, forks = ?, source_hash = ?,
+		source_location = ?, source_files = ?, file_filter = ? WHERE id = ? AND workspace_id = ?`,
+		project.Status, project.Reference, project.CreatedAt.UTC(),
+		project.Metadata.RemoteID, project.Metadata.Owner, project.Metadata.Fullname, project.Metadata.Description,
+		project.Metadata.CloneURL, project.Metadata.DefaultBranch, project.Metadata.License,
+		utc(project.Metadata.CreatedAt), utc(project.Metadata.UpdatedAt), project.Metadata.IsFork,
+		project.Metadata.Size, project.Metadata.Stargazers, project.Metadata.Watchers, project.Metadata.Forks,
+		project.SourceCode.Hash, project.SourceCode.Location, toDocument(project.SourceCode.Files),
+		toDocument(toFilterDocument(project.Filter)), project.ID.String(), workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Errorf("error updating project with id: %v", project.ID)
+		return repository.ErrProjectUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrProjectNoResults
+	}
+
+	return nil
+}
+
+// Delete removes an existing Project from the underlying projects table.
+func (pdb *ProjectDB) Delete(ctx context.Context, ID uuid.UUID) error {
+	results, err := pdb.db.exec(ctx, "DELETE FROM projects WHERE id = ? AND workspace_id = ?", ID.String(),
+		workspaceOf(ctx))
+	if err != nil {
+		log.WithError(err).Errorf("error deleting project with id: %v", ID)
+		return repository.ErrProjectUnexpected
+	}
+
+	if count, _ := results.RowsAffected(); count == 0 {
+		return repository.ErrProjectNoResults
+	}
+
+	return nil
+}
@@ -0,0 +1,298 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"go/token"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// searchLookupSize is the maximum number of terms or documents looked up on each query, so the number of
+// parameters stays within the limits of every driver.
+const searchLookupSize = 500
+
+// SearchIndexDB represents a SQL database, focused on the tables handling the documents and postings of the
+// search index.
+type SearchIndexDB struct {
+	db *DB
+}
+
+// NewSQLSearchIndexRepository creates a repository.SearchIndexRepository backed up by a SQL database.
+func NewSQLSearchIndexRepository(db *DB) *SearchIndexDB {
+	return &SearchIndexDB{
+		db: db,
+	}
+}
+
+// AddAll stores a set of documents into rows on the underlying search_documents and search_postings tables, within
+// a single transaction.
+func (sdb *SearchIndexDB) AddAll(ctx context.Context, docs []entity.SearchDocument) error {
+	err := sdb.db.withTx(ctx, func(tx *sql.Tx) error {
+		return sdb.insert(ctx, tx, docs)
+	})
+	if err != nil {
+		log.WithError(err).Error("error inserting search documents")
+		return repository.ErrSearchUnexpected
+	}
+
+	return nil
+}
+
+// ReplaceAll stores every document provided by the iterator, removing the documents previously stored for the
+// given analysis within the same transaction. Each batch is inserted as soon as it's provided.
+func (sdb *SearchIndexDB) ReplaceAll(ctx context.Context, analysisID uuid.UUID,
+	it repository.SearchDocumentIterator) error {
+	err := sdb.db.withTx(ctx, func(tx *sql.Tx) error {
+		if _, err := sdb.delete(ctx, tx, analysisID); err != nil {
+			return err
+		}
+
+		for it.Next(ctx) {
+			if err := sdb.insert(ctx, tx, it.Documents()); err != nil {
+				return err
+			}
+		}
+
+		return it.Err()
+	})
+	if err != nil {
+		log.WithError(err).Errorf("error replacing search documents for analysis_id: %v", analysisID)
+		return repository.ErrSearchUnexpected
+	}
+
+	return nil
+}
+
+// FindTerms retrieves up to limit distinct terms on the underlying search_postings table starting with the given
+// prefix, whose length is between the given bounds, the shortest ones first.
+func (sdb *SearchIndexDB) FindTerms(ctx context.Context, prefix string, minLength int,
+	maxLength int, limit int) ([]string, error) {
+	query := `SELECT DISTINCT term, term_length FROM search_postings WHERE workspace_id = ? AND term LIKE ? ESCAPE '\'
+		AND term_length >= ?`
+	args := []interface{}{workspaceOf(ctx), likeEscaper.Replace(prefix) + "%", minLength}
+	if maxLength > 0 {
+		query += " AND term_length <= ?"
+		args = append(args, maxLength)
+	}
+	query += " ORDER BY term_length, term"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := sdb.db.query(ctx, query, args...)
+	if err != nil {
+		log.WithError(err).Errorf("error searching terms with prefix %s", prefix)
+		return nil, repository.ErrSearchUnexpected
+	}
+	defer rows.Close()
+
+	terms := make([]string, 0)
+	for rows.Next() {
+		var term string
+		var length int
+		if err := rows.Scan(&term, &length); err != nil {
+			log.WithError(err).Errorf("error decoding terms with prefix %s", prefix)
+			return nil, repository.ErrSearchUnexpected
+		}
+		// LIKE ignores the case on some drivers
+		if strings.HasPrefix(term, prefix) {
+			terms = append(terms, term)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Errorf("error iterating terms with prefix %s", prefix)
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	return terms, nil
+}
+
+// FindPostings retrieves the weight of each one of the given terms on the documents containing it, from the
+// underlying search_postings table. The project, if any, is matched against the search_documents table.
+func (sdb *SearchIndexDB) FindPostings(ctx context.Context, terms []string,
+	projectRef string) (map[string]map[string]float64, error) {
+	postings := make(map[string]map[string]float64)
+	err := lookup(terms, func(batch []interface{}) error {
+		query := `SELECT p.term, p.document_id, p.weight FROM search_postings p`
+		args := []interface{}{workspaceOf(ctx)}
+		if projectRef != "" {
+			query += ` JOIN search_documents d ON d.id = p.document_id AND d.project_ref = ?`
+			args = append([]interface{}{projectRef}, args...)
+		}
+		query += ` WHERE p.workspace_id = ? AND p.term IN (?` + strings.Repeat(", ?", len(batch)-1) + `)`
+
+		rows, err := sdb.db.query(ctx, query, append(args, batch...)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var term, id string
+			var weight float64
+			if err := rows.Scan(&term, &id, &weight); err != nil {
+				return err
+			}
+			if _, ok := postings[term]; !ok {
+				postings[term] = make(map[string]float64)
+			}
+			postings[term][id] = weight
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		log.WithError(err).Errorf("error searching postings for %d terms", len(terms))
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	return postings, nil
+}
+
+// FindDocuments retrieves the search results held by the rows with the given IDs, from the underlying
+// search_documents table.
+func (sdb *SearchIndexDB) FindDocuments(ctx context.Context, ids []string) (map[string]entity.SearchResult, error) {
+	results := make(map[string]entity.SearchResult, len(ids))
+	err := lookup(ids, func(batch []interface{}) error {
+		rows, err := sdb.db.query(ctx, `SELECT id, analysis_id, project_ref, identifier_id, name, type, package, file,
+			position, normalization FROM search_documents WHERE workspace_id = ? AND id IN (?`+
+			strings.Repeat(", ?", len(batch)-1)+`)`, append([]interface{}{workspaceOf(ctx)}, batch...)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id, analysisID string
+			var declType, position int
+			var result entity.SearchResult
+			err := rows.Scan(&id, &analysisID, &result.ProjectRef, &result.IdentifierID, &result.Name, &declType,
+				&result.Package, &result.File, &position, &result.Normalization)
+			if err != nil {
+				return err
+			}
+			if result.AnalysisID, err = uuid.Parse(analysisID); err != nil {
+				return err
+			}
+			result.Type = token.Token(declType)
+			result.Position = token.Pos(position)
+			results[id] = result
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		log.WithError(err).Errorf("error searching %d search documents", len(ids))
+		return nil, repository.ErrSearchUnexpected
+	}
+
+	return results, nil
+}
+
+// DeleteAllByAnalysisID removes the documents related to a given analysis from the underlying search_documents and
+// search_postings tables.
+func (sdb *SearchIndexDB) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	var count int64
+	err := sdb.db.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		count, err = sdb.delete(ctx, tx, analysisID)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Errorf("error deleting search documents with analysis_id: %v", analysisID)
+		return repository.ErrSearchUnexpected
+	}
+
+	if count == 0 {
+		return repository.ErrSearchNoResults
+	}
+
+	return nil
+}
+
+// insert adds the documents and their postings within the given transaction.
+func (sdb *SearchIndexDB) insert(ctx context.Context, tx *sql.Tx, docs []entity.SearchDocument) error {
+	docStmt, err := tx.PrepareContext(ctx, sdb.db.rebind(`INSERT INTO search_documents (id, workspace_id,
+		analysis_id, project_ref, identifier_id, name, type, package, file, position, normalization)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`))
+	if err != nil {
+		return err
+	}
+	defer docStmt.Close()
+
+	postingStmt, err := tx.PrepareContext(ctx, sdb.db.rebind(`INSERT INTO search_postings (workspace_id, term,
+		term_length, document_id, analysis_id, weight) VALUES (?, ?, ?, ?, ?, ?)`))
+	if err != nil {
+		return err
+	}
+	defer postingStmt.Close()
+
+	workspaceID := workspaceOf(ctx)
+	for _, doc := range docs {
+		id := uuid.New().String()
+		result := doc.Result
+		_, err := docStmt.ExecContext(ctx, id, workspaceID, result.AnalysisID.String(), result.ProjectRef,
+			result.IdentifierID, result.Name, int(result.Type), result.Package, result.File, int(result.Position),
+			result.Normalization)
+		if err != nil {
+			return err
+		}
+
+		for term, weight := range doc.Terms {
+			_, err := postingStmt.ExecContext(ctx, workspaceID, term, utf8.RuneCountInString(term), id,
+				result.AnalysisID.String(), weight)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// delete removes the documents related to a given analysis and their postings within the given transaction,
+// returning the number of removed documents.
+func (sdb *SearchIndexDB) delete(ctx context.Context, tx *sql.Tx, analysisID uuid.UUID) (int64, error) {
+	workspaceID := workspaceOf(ctx)
+	_, err := tx.ExecContext(ctx, sdb.db.rebind("DELETE FROM search_postings WHERE workspace_id = ? AND analysis_id = ?"),
+		workspaceID, analysisID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	results, err := tx.ExecContext(ctx,
+		sdb.db.rebind("DELETE FROM search_documents WHERE workspace_id = ? AND analysis_id = ?"),
+		workspaceID, analysisID.String())
+	if err != nil {
+		return 0, err
+	}
+
+	return results.RowsAffected()
+}
+
+// lookup applies fn on consecutive batches of the given values, up to searchLookupSize values each.
+func lookup(values []string, fn func(batch []interface{}) error) error {
+	for start := 0; start < len(values); start += searchLookupSize {
+		end := start + searchLookupSize
+		if end > len(values) {
+			end = len(values)
+		}
+
+		batch := make([]interface{}, 0, end-start)
+		for _, value := range values[start:end] {
+			batch = append(batch, value)
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
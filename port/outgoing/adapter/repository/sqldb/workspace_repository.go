@@ -0,0 +1,95 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const workspaceColumns = "id, name, webhook_secret, created_at"
+
+// WorkspaceDB represents a SQL database, focused on the table handling the workspaces.
+type WorkspaceDB struct {
+	db *DB
+}
+
+// NewSQLWorkspaceRepository creates a repository.WorkspaceRepository backed up by a SQL database.
+func NewSQLWorkspaceRepository(db *DB) *WorkspaceDB {
+	return &WorkspaceDB{
+		db: db,
+	}
+}
+
+// Add stores a Workspace entity into a row on the underlying workspaces table.
+func (wdb *WorkspaceDB) Add(ctx context.Context, workspace entity.Workspace) error {
+	_, err := wdb.db.exec(ctx, "INSERT INTO workspaces ("+workspaceColumns+") VALUES (?, ?, ?, ?)",
+		workspace.ID.String(), workspace.Name, workspace.WebhookSecret, workspace.CreatedAt.UTC())
+	if err != nil {
+		log.WithError(err).Errorf("error inserting workspace %v", workspace.ID)
+		return repository.ErrWorkspaceUnexpected
+	}
+
+	return nil
+}
+
+// Get finds an existing Workspace by ID.
+func (wdb *WorkspaceDB) Get(ctx context.Context, ID uuid.UUID) (entity.Workspace, error) {
+	rows, err := wdb.db.query(ctx, "SELECT "+workspaceColumns+" FROM workspaces WHERE id = ?", ID.String())
+	if err != nil {
+		log.WithError(err).Errorf("error searching workspace with id: %v", ID)
+		return entity.Workspace{}, repository.ErrWorkspaceUnexpected
+	}
+
+	workspaces, err := wdb.scan(rows)
+	if err != nil {
+		return entity.Workspace{}, err
+	}
+
+	if len(workspaces) == 0 {
+		return entity.Workspace{}, repository.ErrWorkspaceNoResults
+	}
+
+	return workspaces[0], nil
+}
+
+// FindAll retrieves every existing Workspace on the underlying workspaces table.
+func (wdb *WorkspaceDB) FindAll(ctx context.Context) ([]entity.Workspace, error) {
+	rows, err := wdb.db.query(ctx, "SELECT "+workspaceColumns+" FROM workspaces ORDER BY created_at")
+	if err != nil {
+		log.WithError(err).Error("error searching workspaces")
+		return []entity.Workspace{}, repository.ErrWorkspaceUnexpected
+	}
+
+	return wdb.scan(rows)
+}
+
+// scan reads and closes a set of rows from the workspaces table.
+func (wdb *WorkspaceDB) scan(rows *sql.Rows) ([]entity.Workspace, error) {
+	defer rows.Close()
+
+	workspaces := make([]entity.Workspace, 0)
+	for rows.Next() {
+		var id string
+		var workspace entity.Workspace
+		err := rows.Scan(&id, &workspace.Name, &workspace.WebhookSecret, &workspace.CreatedAt)
+		if err == nil {
+			workspace.ID, err = uuid.Parse(id)
+		}
+		if err != nil {
+			log.WithError(err).Error("error decoding workspace rows")
+			return []entity.Workspace{}, repository.ErrWorkspaceUnexpected
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.WithError(err).Error("error iterating workspace rows")
+		return []entity.Workspace{}, repository.ErrWorkspaceUnexpected
+	}
+
+	return workspaces, nil
+}
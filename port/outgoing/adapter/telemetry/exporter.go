@@ -0,0 +1,50 @@
+// Package telemetry provides the exporters that send the recorded spans to an OpenTelemetry collector over OTLP, or
+// write them as JSON, one span per line, using the OpenTelemetry stdout exporter.
+package telemetry
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewStdoutExporter creates a sdktrace.SpanExporter writing the spans to the standard output.
+func NewStdoutExporter() (sdktrace.SpanExporter, error) {
+	return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+}
+
+// FileExporter represents a sdktrace.SpanExporter that appends the spans to a local file.
+type FileExporter struct {
+	*stdouttrace.Exporter
+	file *os.File
+}
+
+// NewFileExporter creates a new FileExporter, appending to the given file.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &FileExporter{
+		Exporter: exporter,
+		file:     file,
+	}, nil
+}
+
+// Shutdown stops exporting the spans and closes the file.
+func (e *FileExporter) Shutdown(ctx context.Context) error {
+	if err := e.Exporter.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	return e.file.Close()
+}
@@ -0,0 +1,136 @@
+package telemetry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/port/outgoing/adapter/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExportSpans_OnFileExporter_ShouldAppendOneLinePerSpan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+
+	e, err := telemetry.NewFileExporter(path)
+	require.NoError(t, err)
+
+	require.NoError(t, e.ExportSpans(context.TODO(), spans()))
+	require.NoError(t, e.ExportSpans(context.TODO(), nil))
+	require.NoError(t, e.ExportSpans(context.TODO(), spans()[:1]))
+	require.NoError(t, e.Shutdown(context.TODO()))
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	require.Len(t, lines, 3)
+
+	var span map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &span))
+	assert.Equal(t, "FindAll", span["Name"])
+	assert.Equal(t, "0102030405060708", span["Parent"].(map[string]interface{})["SpanID"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"Key": "ratio", "Value": map[string]interface{}{"Type": "FLOAT64", "Value": 0.5}},
+		map[string]interface{}{"Key": "files", "Value": map[string]interface{}{"Type": "STRINGSLICE", "Value": []interface{}{"main.go"}}},
+	}, span["Attributes"])
+}
+
+func TestShutdown_OnFileExporter_ShouldStopExportingSpans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+
+	e, err := telemetry.NewFileExporter(path)
+	require.NoError(t, err)
+	require.NoError(t, e.Shutdown(context.TODO()))
+
+	assert.NoError(t, e.ExportSpans(context.TODO(), spans()))
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+}
+
+func TestNewFileExporter_WhenInvalidPath_ShouldReturnError(t *testing.T) {
+	_, err := telemetry.NewFileExporter(filepath.Join(t.TempDir(), "missing", "traces.jsonl"))
+
+	assert.Error(t, err)
+}
+
+func TestExportSpans_OnOTLPExporter_ShouldPostSpansToCollector(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer collector.Close()
+
+	e, err := telemetry.NewOTLPExporter(context.TODO(), telemetry.ProtocolHTTP, collector.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, e.ExportSpans(context.TODO(), spans()))
+	require.NoError(t, e.Shutdown(context.TODO()))
+
+	r := <-requests
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "/v1/traces", r.URL.Path)
+	assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+}
+
+func TestNewOTLPExporter_WhenUnsupportedProtocol_ShouldReturnError(t *testing.T) {
+	_, err := telemetry.NewOTLPExporter(context.TODO(), "thrift", "http://localhost:4318")
+
+	assert.EqualError(t, err, `unsupported OTLP protocol "thrift"`)
+}
+
+func spans() []sdktrace.ReadOnlySpan {
+	traceID := trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	root := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8}})
+	child := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1}})
+	start := time.Unix(1600000000, 0)
+	res := resource.NewSchemaless(attribute.String("service.name", "src-reader"))
+	scope := instrumentation.Scope{Name: "github.com/eroatta/src-reader"}
+
+	return tracetest.SpanStubs{
+		{
+			Name:        "GET /projects",
+			SpanContext: root,
+			SpanKind:    trace.SpanKindServer,
+			StartTime:   start,
+			EndTime:     start.Add(time.Second),
+			Attributes:  []attribute.KeyValue{attribute.Int("http.status_code", 500), attribute.Bool("cached", false)},
+			Events: []sdktrace.Event{{
+				Name:       "exception",
+				Time:       start.Add(500 * time.Millisecond),
+				Attributes: []attribute.KeyValue{attribute.String("exception.message", errors.New("boom").Error())},
+			}},
+			Status:                 sdktrace.Status{Code: codes.Error, Description: "boom"},
+			Resource:               res,
+			InstrumentationLibrary: scope,
+		},
+		{
+			Name:                   "FindAll",
+			SpanContext:            child,
+			Parent:                 root,
+			SpanKind:               trace.SpanKindInternal,
+			StartTime:              start,
+			EndTime:                start.Add(time.Millisecond),
+			Attributes:             []attribute.KeyValue{attribute.Float64("ratio", 0.5), attribute.StringSlice("files", []string{"main.go"})},
+			Status:                 sdktrace.Status{Code: codes.Ok},
+			Resource:               res,
+			InstrumentationLibrary: scope,
+		},
+	}.Snapshots()
+}
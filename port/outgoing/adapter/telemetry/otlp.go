@@ -0,0 +1,30 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// ProtocolHTTP sends the spans as OTLP over HTTP, encoded as protobuf.
+	ProtocolHTTP = "http/protobuf"
+	// ProtocolGRPC sends the spans as OTLP over gRPC.
+	ProtocolGRPC = "grpc"
+)
+
+// NewOTLPExporter creates a sdktrace.SpanExporter sending the spans to the OpenTelemetry collector listening on the
+// given endpoint URL, using the given protocol. An http endpoint disables the transport security.
+func NewOTLPExporter(ctx context.Context, protocol string, endpoint string) (sdktrace.SpanExporter, error) {
+	switch protocol {
+	case ProtocolHTTP:
+		return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	case ProtocolGRPC:
+		return otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+	default:
+		return nil, fmt.Errorf("unsupported OTLP protocol %q", protocol)
+	}
+}
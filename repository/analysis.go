@@ -19,6 +19,8 @@ var (
 type AnalysisRepository interface {
 	// Add adds a new Analysis Results to the current repository.
 	Add(ctx context.Context, analysis entity.AnalysisResults) error
+	// Get retrieves an existing analysis, using its ID.
+	Get(ctx context.Context, id uuid.UUID) (entity.AnalysisResults, error)
 	// GetByProjectID retrieves an existing analysis for the given Project.
 	GetByProjectID(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error)
 	// Delete removes an Analysis from the current repository, using its ID.
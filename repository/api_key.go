@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAPIKeyNoResults indicates that no API keys were found matching the given criteria.
+	ErrAPIKeyNoResults = errors.New("no API keys found for the given criteria")
+	// ErrAPIKeyUnexpected indicates that the current action couldn't be completed because of an internal issue.
+	ErrAPIKeyUnexpected = errors.New("unexpected error performing the current action")
+)
+
+// APIKeyRepository represents a repository capable of operating with the API keys granted to the clients.
+type APIKeyRepository interface {
+	// Add adds a new APIKey to the current repository.
+	Add(ctx context.Context, key entity.APIKey) error
+	// GetByHash retrieves the APIKey matching the given hash.
+	GetByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	// FindAll retrieves every APIKey stored on the current repository.
+	FindAll(ctx context.Context) ([]entity.APIKey, error)
+	// Delete removes an existing APIKey from the current repository.
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"errors"
+	"io"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+var (
+	// ErrArchiveInvalid indicates that the archive is malformed or its elements can't be decoded.
+	ErrArchiveInvalid = errors.New("invalid archive")
+	// ErrArchiveUnsupportedVersion indicates that the archive schema version is newer than the supported one,
+	// or that it can't be upgraded.
+	ErrArchiveUnsupportedVersion = errors.New("unsupported archive schema version")
+	// ErrArchiveUnexpected indicates that an error occurred while trying to write or read an archive.
+	ErrArchiveUnexpected = errors.New("unexpected error performing the current operation on an archive")
+)
+
+// ArchiveWriter writes the elements related to an analysis as an archive.
+type ArchiveWriter interface {
+	// Write writes an element. The archive header is written before the first element.
+	Write(record entity.ArchiveRecord) error
+	// Close writes any buffered element. It doesn't close the underlying destination.
+	Close() error
+}
+
+// ArchiveReader reads the elements related to an analysis from an archive, upgraded to the current schema version.
+type ArchiveReader interface {
+	// Header returns the archive header, as it was written.
+	Header() entity.ArchiveHeader
+	// Read reads the next element. It returns io.EOF when there are no more elements.
+	Read() (entity.ArchiveRecord, error)
+}
+
+// ArchiveFormat creates writers and readers for a given archive format.
+type ArchiveFormat interface {
+	// NewWriter creates an ArchiveWriter for the given header, writing on the given destination.
+	NewWriter(w io.Writer, header entity.ArchiveHeader) (ArchiveWriter, error)
+	// NewReader creates an ArchiveReader from the given source, validating its header. If the archive schema
+	// version is newer than the supported one or can't be upgraded, ErrArchiveUnsupportedVersion is returned.
+	NewReader(r io.Reader) (ArchiveReader, error)
+}
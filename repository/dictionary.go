@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDictionaryNoResults indicates that no dictionaries were found matching the given criteria.
+	ErrDictionaryNoResults = errors.New("no dictionaries found for the given criteria")
+	// ErrDictionaryUnexpected indicates that the current action couldn't be completed because of an internal issue.
+	ErrDictionaryUnexpected = errors.New("unexpected error performing the current action")
+)
+
+// DictionaryRepository represents a repository capable of operating with abbreviation dictionaries.
+type DictionaryRepository interface {
+	// Add adds a new Dictionary to the current repository.
+	Add(ctx context.Context, dict entity.Dictionary) error
+	// Get retrieves a Dictionary by ID.
+	Get(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error)
+	// GetByScope retrieves the Dictionary defined for the given scope. The project reference is
+	// only considered for project-scoped dictionaries.
+	GetByScope(ctx context.Context, scope string, projectRef string) (entity.Dictionary, error)
+	// FindAll retrieves every Dictionary stored on the current repository.
+	FindAll(ctx context.Context) ([]entity.Dictionary, error)
+	// Update replaces an existing Dictionary.
+	Update(ctx context.Context, dict entity.Dictionary) error
+	// Delete removes an existing Dictionary from the current repository.
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"errors"
+	"io"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+var (
+	// ErrExportUnsupportedFormat indicates that the identifiers can't be exported using the given format.
+	ErrExportUnsupportedFormat = errors.New("unsupported format to export identifiers")
+	// ErrExportUnexpected indicates that an error occurred while trying to export identifiers.
+	ErrExportUnexpected = errors.New("unexpected error exporting identifiers")
+)
+
+// IdentifierWriter writes identifiers to an underlying destination, using a given format.
+type IdentifierWriter interface {
+	// Write writes an identifier, including its splits, expansions, normalization and location.
+	Write(ident entity.Identifier) error
+	// Close writes any buffered identifier and completes the output. It doesn't close the underlying destination.
+	Close() error
+}
+
+// IdentifierWriterFactory creates an IdentifierWriter for each supported format.
+type IdentifierWriterFactory interface {
+	// Make creates an IdentifierWriter for the given format, writing on the given destination. If the format
+	// is not supported, ErrExportUnsupportedFormat is returned.
+	Make(format string, w io.Writer) (IdentifierWriter, error)
+}
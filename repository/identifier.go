@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/google/uuid"
@@ -13,16 +17,97 @@ var (
 	ErrIdentifierNoResults = errors.New("no identifiers found for the given criteria")
 	// ErrIdentifierUnexpected indicates that an error occurred while trying to perform an operation on IdentifierRepository.
 	ErrIdentifierUnexpected = errors.New("unexpected error performing the current operation on IdentifierRepository")
+	// ErrIdentifierInvalidCursor indicates that the given cursor doesn't reference a position on a page of identifiers.
+	ErrIdentifierInvalidCursor = errors.New("invalid cursor for a page of identifiers")
 )
 
 // IdentifierRepository represents a repository able to store and retrieve identifiers.
+// Stored identifiers are staged, and they aren't retrieved until they are committed for their Analysis.
 type IdentifierRepository interface {
 	// Add associates an identifier with a given Analysis.
 	Add(ctx context.Context, analysis entity.AnalysisResults, ident entity.Identifier) error
+	// AddAll associates a set of identifiers with a given Analysis, storing them in batches.
+	// If any identifier can't be stored, a *BatchError is returned.
+	AddAll(ctx context.Context, analysis entity.AnalysisResults, idents []entity.Identifier) error
 	// FindAllByAnalysisID retrives a list of identifiers associated to the given analysis.
 	FindAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Identifier, error)
+	// IterateByAnalysisID retrieves an iterator over the identifiers associated to the given analysis,
+	// so they can be processed without holding them all in memory.
+	IterateByAnalysisID(ctx context.Context, analysisID uuid.UUID) (IdentifierIterator, error)
+	// QueryByAnalysisID retrieves a page of the identifiers associated to the given analysis, matching and
+	// sorted by the given query. An invalid cursor results in ErrIdentifierInvalidCursor.
+	QueryByAnalysisID(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error)
 	// FindAllByProjectAndFile retrieve a list of identifiers that match the given criteria.
 	FindAllByProjectAndFile(ctx context.Context, projectRef string, filename string) ([]entity.Identifier, error)
-	// DeleteAllByAnalysisID removes every identifiers related to a given Analysis ID.
+	// DeleteAllByAnalysisID removes every identifiers related to a given Analysis ID, either staged or committed.
 	DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error
+	// Commit makes every staged identifier related to a given Analysis ID visible.
+	Commit(ctx context.Context, analysisID uuid.UUID) error
+	// Stage marks the given Analysis ID as in progress, so its staged identifiers are kept while they are still being
+	// stored. Staging it again refreshes the mark, which is removed when the identifiers are committed or removed.
+	Stage(ctx context.Context, analysisID uuid.UUID) error
+	// FindStagedAnalysisIDs retrieves the IDs of the analysis idle since the given time: the ones with identifiers
+	// staged or marked as in progress before it, unless the mark was refreshed after it.
+	FindStagedAnalysisIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+}
+
+// IdentifierIterator iterates over a set of identifiers retrieved from an IdentifierRepository.
+type IdentifierIterator interface {
+	// Next advances to the next identifier. It returns false when there are no more identifiers or an error occurred.
+	Next(ctx context.Context) bool
+	// Identifier returns the current identifier.
+	Identifier() entity.Identifier
+	// Err returns the error that stopped the iteration, if any.
+	Err() error
+	// Close releases the resources held by the iterator.
+	Close(ctx context.Context) error
+}
+
+// BatchError reports the identifiers that couldn't be stored during a batch operation.
+// On ordered batches, the operation stops after the first failure, so every identifier after
+// it was not stored either.
+type BatchError struct {
+	// Ordered indicates if the batch was stopped after the first failure.
+	Ordered bool
+	// Inserted is the number of identifiers that were stored.
+	Inserted int
+	// Failed holds the indexes, on the given set of identifiers, of the identifiers that failed.
+	Failed []int
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	if e.Ordered && len(e.Failed) > 0 {
+		return fmt.Sprintf("ordered batch stopped at identifier %d, %d identifiers stored", e.Failed[0], e.Inserted)
+	}
+
+	return fmt.Sprintf("%d identifiers failed on unordered batch, %d identifiers stored", len(e.Failed), e.Inserted)
+}
+
+// IdentifierCursor represents the position of the last identifier on a page, using its normalization score and
+// the key used by the repository to sort identifiers with the same score.
+type IdentifierCursor struct {
+	Score float64 `json:"s"`
+	Key   string  `json:"k"`
+}
+
+// Encode returns the opaque representation of the cursor.
+func (c IdentifierCursor) Encode() string {
+	bytes, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// DecodeIdentifierCursor parses the opaque representation of a cursor.
+func DecodeIdentifierCursor(cursor string) (IdentifierCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return IdentifierCursor{}, ErrIdentifierInvalidCursor
+	}
+
+	var c IdentifierCursor
+	if err := json.Unmarshal(bytes, &c); err != nil || c.Key == "" {
+		return IdentifierCursor{}, ErrIdentifierInvalidCursor
+	}
+
+	return c, nil
 }
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrJobNoResults indicates that no analysis jobs were found matching the given criteria.
+	ErrJobNoResults = errors.New("no analysis jobs found for the given criteria")
+	// ErrJobUnexpected indicates that the current action couldn't be completed because of an internal issue.
+	ErrJobUnexpected = errors.New("unexpected error performing the current action")
+)
+
+// JobRepository represents a repository capable of recording the running and failed analyses.
+type JobRepository interface {
+	// Add records an AnalysisJob on the workspace.
+	Add(ctx context.Context, job entity.AnalysisJob) error
+	// FindAll retrieves every recorded AnalysisJob on the workspace, sorted by start time.
+	FindAll(ctx context.Context) ([]entity.AnalysisJob, error)
+	// Refresh extends the hold of the owner on a running AnalysisJob. If the job was taken over by another owner,
+	// or it's no longer running, ErrJobNoResults is returned.
+	Refresh(ctx context.Context, job entity.AnalysisJob) error
+	// Claim records the given AnalysisJob as the next attempt of a running job that wasn't refreshed since the given
+	// time, replacing its owner and analysis. If the job was refreshed or claimed since it was retrieved, so the
+	// recorded attempt isn't the previous one, ErrJobNoResults is returned.
+	Claim(ctx context.Context, job entity.AnalysisJob, refreshedBefore time.Time) error
+	// Fail records a running AnalysisJob on the workspace as failed, for the given reason.
+	Fail(ctx context.Context, ID uuid.UUID, reason string) error
+	// Delete removes a recorded AnalysisJob from the workspace, once its analysis is over.
+	Delete(ctx context.Context, ID uuid.UUID) error
+}
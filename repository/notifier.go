@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+var (
+	// ErrNotifierRateLimited indicates that the destination doesn't accept more notifications for now.
+	ErrNotifierRateLimited = errors.New("too many notifications sent to the destination")
+	// ErrNotifierUnexpected indicates that the current action couldn't be completed because of an internal issue.
+	ErrNotifierUnexpected = errors.New("unexpected error performing the current action")
+)
+
+// Notifier represents a destination for the review comments on a pull request.
+type Notifier interface {
+	// Sent retrieves the fingerprints of the comments already posted on the pull request for the given review.
+	Sent(ctx context.Context, review entity.Review) (map[string]bool, error)
+	// Notify posts the comments on the given review. A *DeferredCommentsError reports that only the first comments
+	// were posted.
+	Notify(ctx context.Context, review entity.Review) error
+}
+
+// DeferredCommentsError reports that only the first comments of a review were posted, since the destination doesn't
+// accept more notifications for now. The remaining comments aren't reported as sent, so they are left for a later
+// review.
+type DeferredCommentsError struct {
+	// Posted is the number of comments that were posted.
+	Posted int
+	// Deferred is the number of comments that were left out.
+	Deferred int
+}
+
+// Error implements the error interface.
+func (e *DeferredCommentsError) Error() string {
+	return fmt.Sprintf("%d comments posted, %d comments deferred", e.Posted, e.Deferred)
+}
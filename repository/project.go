@@ -23,6 +23,11 @@ type ProjectRepository interface {
 	Get(ctx context.Context, ID uuid.UUID) (entity.Project, error)
 	// GetByReference retrieves a Project using its reference name.
 	GetByReference(ctx context.Context, projectRef string) (entity.Project, error)
+	// Query retrieves a page of the projects matching and sorted by the given query, along with the accuracy
+	// of their latest analysis.
+	Query(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error)
+	// Update replaces an existing Project on the current repository.
+	Update(ctx context.Context, project entity.Project) error
 	// Delete removes an existing Project from the current repository.
 	Delete(ctx context.Context, ID uuid.UUID) error
 }
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSearchNoResults indicates that no identifiers were found matching the given search.
+	ErrSearchNoResults = errors.New("no identifiers found for the given search")
+	// ErrSearchUnexpected indicates that an error occurred while trying to perform an operation on SearchRepository.
+	ErrSearchUnexpected = errors.New("unexpected error performing the current operation on SearchRepository")
+)
+
+// SearchRepository represents an index able to search identifiers by their names, splits, expansions and
+// normalizations, across every analysis.
+type SearchRepository interface {
+	// AddAll indexes a set of identifiers, associated to the analysis referenced by each identifier.
+	AddAll(ctx context.Context, idents []entity.Identifier) error
+	// ReplaceAll indexes every identifier provided by the iterator, replacing the identifiers previously indexed for
+	// the given Analysis ID.
+	ReplaceAll(ctx context.Context, analysisID uuid.UUID, it IdentifierIterator) error
+	// Search retrieves the identifiers matching the given query, sorted by relevance.
+	Search(ctx context.Context, query entity.SearchQuery) ([]entity.SearchResult, error)
+	// DeleteAllByAnalysisID removes every indexed identifier related to a given Analysis ID.
+	DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error
+}
+
+// SearchIndexRepository represents the storage of the index searched by a SearchRepository, holding the documents
+// that index the identifiers and the postings that map each term to the documents containing it. Every element
+// belongs to the workspace set on the context.
+type SearchIndexRepository interface {
+	// AddAll stores a set of documents.
+	AddAll(ctx context.Context, docs []entity.SearchDocument) error
+	// ReplaceAll stores every document provided by the iterator, replacing the documents previously stored for the
+	// given Analysis ID. The previous documents are kept if the iterator fails.
+	ReplaceAll(ctx context.Context, analysisID uuid.UUID, it SearchDocumentIterator) error
+	// FindTerms retrieves up to limit distinct terms starting with the given prefix, whose length in characters is
+	// between minLength and maxLength, the shortest ones first. A maxLength or a limit lower than 1 leaves them
+	// unbounded.
+	FindTerms(ctx context.Context, prefix string, minLength int, maxLength int, limit int) ([]string, error)
+	// FindPostings retrieves, for each one of the given terms, the weight of the term on each document containing
+	// it, by document ID. A non-empty projectRef only retrieves the documents of the given project.
+	FindPostings(ctx context.Context, terms []string, projectRef string) (map[string]map[string]float64, error)
+	// FindDocuments retrieves the search results held by the documents with the given IDs, by document ID.
+	FindDocuments(ctx context.Context, ids []string) (map[string]entity.SearchResult, error)
+	// DeleteAllByAnalysisID removes every document related to a given Analysis ID.
+	DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error
+}
+
+// SearchDocumentIterator iterates over consecutive batches of documents to be stored on a SearchIndexRepository.
+type SearchDocumentIterator interface {
+	// Next advances to the next batch. It returns false when there are no more batches or an error occurred.
+	Next(ctx context.Context) bool
+	// Documents returns the current batch.
+	Documents() []entity.SearchDocument
+	// Err returns the error that stopped the iteration, if any.
+	Err() error
+}
@@ -18,6 +18,8 @@ var (
 	ErrSourceCodeUnableToRemove = errors.New("unable to remove source code")
 	// ErrSourceCodeUnableReadFile indicates that the requested file couldn't be accessed or read from the underlying storage.
 	ErrSourceCodeUnableReadFile = errors.New("unable to access or read file")
+	// ErrSourceCodeUnableUpdate indicates the source code couldn't be updated to the requested revision.
+	ErrSourceCodeUnableUpdate = errors.New("unable to update source code to the requested revision")
 	// ErrSourceCodeNotFound indicates the source code is not present on the underlying storage.
 	ErrSourceCodeNotFound = errors.New("unable to locate source code")
 )
@@ -26,8 +28,13 @@ var (
 type SourceCodeRepository interface {
 	// Clone clones the source code, under a given name, using the provided clone URL.
 	Clone(ctx context.Context, fullname string, cloneURL string) (entity.SourceCode, error)
+	// Update fetches the latest changes for the source code on the given location, and checks out the given
+	// revision.
+	Update(ctx context.Context, location string, hash string) (entity.SourceCode, error)
 	// Remove removes the source code on the given location.
 	Remove(ctx context.Context, location string) error
 	// Read reads the content of the given file, relative to the provided location.
 	Read(ctx context.Context, location string, filename string) ([]byte, error)
+	// Size retrieves the size, in bytes, of the given file, relative to the provided location, without reading it.
+	Size(ctx context.Context, location string, filename string) (int64, error)
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrWorkspaceNoResults indicates that no workspaces were found matching the given criteria.
+	ErrWorkspaceNoResults = errors.New("no workspaces found for the given criteria")
+	// ErrWorkspaceUnexpected indicates that the current action couldn't be completed because of an internal issue.
+	ErrWorkspaceUnexpected = errors.New("unexpected error performing the current action")
+)
+
+// WorkspaceRepository represents a repository capable of operating with workspaces.
+//
+// Every other repository is scoped by the workspace set on the context: elements are stored on that workspace, and
+// elements from any other workspace are never retrieved, updated nor removed.
+type WorkspaceRepository interface {
+	// Add adds a new Workspace to the current repository.
+	Add(ctx context.Context, workspace entity.Workspace) error
+	// Get retrieves a Workspace by ID.
+	Get(ctx context.Context, ID uuid.UUID) (entity.Workspace, error)
+	// FindAll retrieves every Workspace stored on the current repository.
+	FindAll(ctx context.Context) ([]entity.Workspace, error)
+}
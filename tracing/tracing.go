@@ -0,0 +1,40 @@
+// Package tracing records the spans of the requests and analyses, using the global OpenTelemetry tracer provider.
+// Spans are discarded until a tracer provider is set, so the instrumented code doesn't depend on how spans are
+// exported.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanContextKey is the key holding the current span on a context. It's a plain string, so it can be set on the
+// context of the HTTP handlers, which don't expose the context where the spans are usually stored.
+const SpanContextKey = "span"
+
+// tracer creates every span, delegating on the global tracer provider once it's set.
+var tracer = otel.Tracer("github.com/eroatta/src-reader")
+
+// Start creates a span as a child of the span on the context, if any, returning a copy of the context holding the
+// new span.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		if span, ok := ctx.Value(SpanContextKey).(trace.Span); ok {
+			ctx = trace.ContextWithSpan(ctx, span)
+		}
+	}
+
+	return tracer.Start(ctx, name, opts...)
+}
+
+// End ends the span, recording the error, if any.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,41 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eroatta/src-reader/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStart_ShouldCreateChildrenOfTheSpanOnTheContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	_, child := tracing.Start(ctx, "child")
+	tracing.End(child, nil)
+
+	// the HTTP handlers hold the span under a plain key
+	handlerCtx := context.WithValue(context.Background(), tracing.SpanContextKey, parent)
+	_, sibling := tracing.Start(handlerCtx, "sibling")
+	tracing.End(sibling, errors.New("unable to complete"))
+	tracing.End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Equal(t, 3, len(spans))
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, "sibling", spans[1].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "unable to complete", spans[1].Status().Description)
+	assert.Equal(t, 1, len(spans[1].Events()))
+	assert.False(t, spans[2].Parent().IsValid())
+}
@@ -4,15 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
 	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/tracing"
 	"github.com/eroatta/src-reader/usecase/step"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// identifiersBatchSize is the number of processed identifiers stored at once.
+const identifiersBatchSize = 500
+
 var (
 	// ErrProjectNotFound indicates that the requested project is not accessible.
 	ErrProjectNotFound = errors.New("unable to retrieve requested Project")
@@ -31,6 +39,8 @@ var (
 	ErrUnableToSaveIdentifiers = errors.New("unable to save extracted and processed indentifiers")
 	// ErrUnableToSaveAnalysis indicates that an error occurred while trying to store the results for an import process.
 	ErrUnableToSaveAnalysis = errors.New("unable to save analysis results after completed processing")
+	// ErrAnalysisCanceled indicates that the context was cancelled before the analysis could be completed.
+	ErrAnalysisCanceled = errors.New("analysis canceled before completion")
 	// ErrUnexpected indicates that an unexpected error ocurring while analyzing the project.
 	ErrUnexpected = errors.New("unexpected error")
 )
@@ -39,16 +49,22 @@ var (
 type AnalyzeProjectUsecase interface {
 	// Process performs the splitting and expansion process on the source code belonging to the Project.
 	Process(ctx context.Context, projecID uuid.UUID) (entity.AnalysisResults, error)
+	// ProcessWithPipeline performs the same process, but applying the given miners, splitters, expanders
+	// and rules instead of the configured ones, and reading the test files if the pipeline includes them.
+	ProcessWithPipeline(ctx context.Context, projectID uuid.UUID, pipeline entity.Pipeline) (entity.AnalysisResults, error)
 }
 
 // NewAnalyzeProjectUsecase initializes a new AnalyzeProjectUsecase handler.
 func NewAnalyzeProjectUsecase(pr repository.ProjectRepository, scr repository.SourceCodeRepository,
-	ir repository.IdentifierRepository, ar repository.AnalysisRepository, config *entity.AnalysisConfig) AnalyzeProjectUsecase {
+	ir repository.IdentifierRepository, ar repository.AnalysisRepository, dr repository.DictionaryRepository,
+	iuc IndexAnalysisUsecase, config *entity.AnalysisConfig) AnalyzeProjectUsecase {
 	return &analyzeProjectUsecase{
 		projectRepository:    pr,
 		sourceCodeRepository: scr,
 		identifierRepository: ir,
 		analysisRepository:   ar,
+		dictionaryRepository: dr,
+		indexAnalysisUsecase: iuc,
 		defaultConfig:        config,
 	}
 }
@@ -58,6 +74,8 @@ type analyzeProjectUsecase struct {
 	sourceCodeRepository repository.SourceCodeRepository
 	identifierRepository repository.IdentifierRepository
 	analysisRepository   repository.AnalysisRepository
+	dictionaryRepository repository.DictionaryRepository
+	indexAnalysisUsecase IndexAnalysisUsecase
 	defaultConfig        *entity.AnalysisConfig
 }
 
@@ -65,6 +83,66 @@ type analyzeProjectUsecase struct {
 // The process reads the source code, applies the given miners, splitters and expanders and then stores the results.
 // It's the default implementation for the use case.
 func (uc analyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
+	return uc.process(ctx, projectID, uc.defaultConfig)
+}
+
+// ProcessWithPipeline processes the given Project, replacing the miners, splitters, expanders and rules
+// provided by the AnalysisConfig with the ones on the pipeline. A nil list keeps the configured one, while an
+// empty list applies none.
+func (uc analyzeProjectUsecase) ProcessWithPipeline(ctx context.Context, projectID uuid.UUID,
+	pipeline entity.Pipeline) (entity.AnalysisResults, error) {
+	config := *uc.defaultConfig
+	if pipeline.Miners != nil {
+		config.Miners = pipeline.Miners
+	}
+	if pipeline.Splitters != nil {
+		config.Splitters = pipeline.Splitters
+	}
+	if pipeline.Expanders != nil {
+		config.Expanders = pipeline.Expanders
+	}
+	if pipeline.Rules != nil {
+		config.Rules = pipeline.Rules
+	}
+	config.IncludeTests = pipeline.IncludeTests
+
+	return uc.process(ctx, projectID, &config)
+}
+
+// process traces the analysis of the project, so each stage of the pipeline is recorded under a single span, and
+// observes its duration unless the project wasn't analyzed at all.
+func (uc analyzeProjectUsecase) process(ctx context.Context, projectID uuid.UUID,
+	config *entity.AnalysisConfig) (entity.AnalysisResults, error) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "AnalyzeProject", trace.WithAttributes(attribute.String("project.id", projectID.String())))
+	results, err := uc.analyze(ctx, projectID, config)
+	switch err {
+	case nil:
+		metrics.AnalysisDuration.WithLabelValues(metrics.ResultSuccess).Observe(time.Since(start).Seconds())
+	case ErrProjectNotFound, ErrPreviousAnalysisFound:
+		// do nothing
+	case ErrAnalysisCanceled:
+		metrics.AnalysisDuration.WithLabelValues(metrics.ResultCanceled).Observe(time.Since(start).Seconds())
+	default:
+		metrics.AnalysisDuration.WithLabelValues(metrics.ResultError).Observe(time.Since(start).Seconds())
+	}
+	if err == nil {
+		span.SetAttributes(
+			attribute.String("project", results.ProjectName),
+			attribute.Int("files", results.FilesTotal),
+			attribute.Int("files.error", results.FilesError),
+			attribute.Int("files.skipped", results.FilesSkipped),
+			attribute.Int("identifiers", results.IdentifiersTotal),
+			attribute.Int("identifiers.error", results.IdentifiersError),
+		)
+	}
+	tracing.End(span, err)
+
+	return results, err
+}
+
+func (uc analyzeProjectUsecase) analyze(ctx context.Context, projectID uuid.UUID,
+	config *entity.AnalysisConfig) (entity.AnalysisResults, error) {
 	project, err := uc.projectRepository.Get(ctx, projectID)
 	switch err {
 	case nil:
@@ -75,19 +153,24 @@ func (uc analyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID
 		log.WithError(err).Errorf("unable to retrieve project %s", projectID.String())
 		return entity.AnalysisResults{}, ErrUnexpected
 	}
+	if sourceCode, ok := entity.SourceCodeFrom(ctx); ok {
+		project.SourceCode = sourceCode
+	}
 
 	previousAnalysis, err := uc.analysisRepository.GetByProjectID(ctx, projectID)
 	switch err {
 	case repository.ErrAnalysisNoResults:
 		// do nothing
 	case nil:
-		return previousAnalysis, ErrPreviousAnalysisFound
+		if previousAnalysis.ID != entity.ReplacedAnalysisFrom(ctx) {
+			return previousAnalysis, ErrPreviousAnalysisFound
+		}
 	default:
 		log.WithError(err).Errorf("unable to check for previous analysis on project %s", projectID.String())
 		return entity.AnalysisResults{}, ErrUnexpected
 	}
 
-	analysisID, _ := uuid.NewUUID()
+	analysisID := entity.AnalysisIDFrom(ctx)
 	analysisResults := entity.AnalysisResults{
 		ID:                analysisID,
 		DateCreated:       time.Now(),
@@ -96,15 +179,35 @@ func (uc analyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID
 		PipelineMiners:    make([]string, 0),
 		PipelineSplitters: make([]string, 0),
 		PipelineExpanders: make([]string, 0),
+		PipelineRules:     make([]string, 0),
+		IncludeTests:      config.IncludeTests,
 	}
-	// read and parse files
-	filesc := step.Read(ctx, uc.sourceCodeRepository, project.SourceCode.Location, project.SourceCode.Files)
-	parsed := step.Parse(filesc)
+	// every stage stops as soon as the analysis is cancelled or returns early
+	pipelineCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// read and parse files, leaving out the ones skipped by the guards or the project filter
+	filesc := step.Read(pipelineCtx, uc.stage(entity.StageRead), uc.sourceCodeRepository,
+		project.SourceCode.Location, project.SourceCode.Files, project.Filter, config.Guards,
+		config.IncludeTests)
+	parsed := step.Parse(pipelineCtx, uc.stage(entity.StageParse), filesc)
 	files := step.Merge(parsed)
+	if ctx.Err() != nil {
+		log.WithError(ctx.Err()).Warnf("analysis canceled while reading files for project %s", project.Reference)
+		return entity.AnalysisResults{}, ErrAnalysisCanceled
+	}
 
 	valid := make([]entity.File, 0)
 	fileErrorSamples := make([]string, 0)
+	skippedFiles := make(map[string]bool)
+	skipped := 0
 	for _, file := range files {
+		if file.Skipped {
+			skippedFiles[file.Name] = true
+			skipped++
+			continue
+		}
+
 		if file.Error != nil {
 			if len(fileErrorSamples) < 10 {
 				fileErrorSamples = append(fileErrorSamples, file.Error.Error())
@@ -115,10 +218,14 @@ func (uc analyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID
 		}
 		valid = append(valid, file)
 	}
-	analysisResults.FilesTotal = len(files)
+	analysisResults.FilesTotal = len(files) - skipped
 	analysisResults.FilesValid = len(valid)
-	analysisResults.FilesError = len(files) - len(valid)
+	analysisResults.FilesError = len(files) - skipped - len(valid)
 	analysisResults.FilesErrorSamples = fileErrorSamples
+	analysisResults.FilesSkipped = skipped
+	metrics.AnalyzedFiles.WithLabelValues("parsed").Add(float64(analysisResults.FilesValid))
+	metrics.AnalyzedFiles.WithLabelValues("failed").Add(float64(analysisResults.FilesError))
+	metrics.AnalyzedFiles.WithLabelValues("skipped").Add(float64(analysisResults.FilesSkipped))
 
 	// if every file can't be parsed, then fail
 	if len(valid) == 0 {
@@ -128,17 +235,54 @@ func (uc analyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID
 	}
 
 	// apply the pre-process step (mine them)
-	miners := buildMiners(uc.defaultConfig)
+	miners := buildMiners(config)
 	for _, miner := range miners {
 		analysisResults.PipelineMiners = append(analysisResults.PipelineMiners, miner.Name())
 	}
 
-	miningResults := step.Mine(valid, miners...)
+	// some miners also learn from the version history of the source code, leaving out the files left out of the
+	// analysis, such as vendored, generated or huge files
+	skips := func(filename string) bool {
+		return (!config.IncludeTests && strings.HasSuffix(filename, "_test.go")) || skippedFiles[filename] ||
+			config.Guards.SkipsDir(filename) || !project.Filter.Allows(filename)
+	}
+	for _, miner := range miners {
+		historyMiner, ok := miner.(entity.HistoryMiner)
+		if !ok {
+			continue
+		}
+
+		historyCtx, span := tracing.Start(pipelineCtx, "MineHistory "+historyMiner.Name())
+		err := historyMiner.MineHistory(historyCtx, project.SourceCode.Location, skips)
+		tracing.End(span, err)
+		if err != nil {
+			log.WithError(err).Warnf("unable to mine the version history with %s for project %s",
+				historyMiner.Name(), project.Reference)
+		}
+	}
+	if ctx.Err() != nil {
+		log.WithError(ctx.Err()).Warnf("analysis canceled while mining the version history for project %s",
+			project.Reference)
+		return entity.AnalysisResults{}, ErrAnalysisCanceled
+	}
+
+	// detect the dominant language of each package, so language-aware miners use the matching word lists
+	languages := make(map[string]entity.Language)
+	if config.LanguageDetector != nil {
+		languages = step.DetectLanguages(valid, config.LanguageDetector)
+		for _, miner := range miners {
+			if languageMiner, ok := miner.(entity.LanguageMiner); ok {
+				languageMiner.SetLanguages(languages)
+			}
+		}
+	}
+
+	miningResults := step.Mine(pipelineCtx, valid, miners...)
 
 	// make the splitters from input and mining results
-	splitters := buildSplittersFromMiningResults(uc.defaultConfig, miningResults)
+	splitters := buildSplittersFromMiningResults(config, miningResults)
 	if len(splitters) == 0 {
-		log.WithField("desired", uc.defaultConfig.Splitters).Error("unable to create any splitter")
+		log.WithField("desired", config.Splitters).Error("unable to create any splitter")
 		return entity.AnalysisResults{}, ErrUnableToCreateProcessors
 	}
 	for _, splitter := range splitters {
@@ -146,23 +290,55 @@ func (uc analyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID
 	}
 
 	// make the expanders from input and mining results
-	expanders := buildExpandersFromMiningResults(uc.defaultConfig, miningResults)
+	expanders := buildExpandersFromMiningResults(config, miningResults)
 	if len(expanders) == 0 {
-		log.WithField("desired", uc.defaultConfig.Expanders).Error("unable to create any expander")
+		log.WithField("desired", config.Expanders).Error("unable to create any expander")
 		return entity.AnalysisResults{}, ErrUnableToCreateProcessors
 	}
+
+	// the managed dictionary, if any, is consulted ahead of the configured expanders
+	dictionary, err := uc.buildDictionary(ctx, project.Reference)
+	if err != nil {
+		return entity.AnalysisResults{}, err
+	}
+	if len(dictionary.Entries) > 0 && config.DictionaryExpanderFactory != nil {
+		expanders = append([]entity.Expander{config.DictionaryExpanderFactory(dictionary)}, expanders...)
+	}
+	expanders = applyOnSplitters(expanders, analysisResults.PipelineSplitters)
 	for _, expander := range expanders {
 		analysisResults.PipelineExpanders = append(analysisResults.PipelineExpanders, expander.Name())
 	}
 
+	// make the naming-convention rules, which are optional
+	rules := buildRules(config)
+	for _, rule := range rules {
+		analysisResults.PipelineRules = append(analysisResults.PipelineRules, rule.Name())
+	}
+
+	// the analysis is in progress until its identifiers are committed, so the cleanup process keeps them meanwhile
+	stopStaging, err := keepStaged(ctx, uc.identifierRepository, analysisID)
+	if err != nil {
+		return entity.AnalysisResults{}, ErrUnableToSaveIdentifiers
+	}
+	defer stopStaging()
+	rollback := func() {
+		stopStaging()
+		uc.rollback(ctx, analysisID)
+	}
+
 	// analyze each identifier
-	identc := step.Extract(valid, uc.defaultConfig.ExtractorFactory)
-	splittedc := step.Split(identc, splitters...)
-	expandedc := step.Expand(splittedc, expanders...)
-	normalizedc := step.Normalize(expandedc)
+	identc := step.Extract(pipelineCtx, valid, config.ExtractorFactory)
+	localizedc := step.Localize(pipelineCtx, identc, languages)
+	splittedc := step.Split(pipelineCtx, uc.stage(entity.StageSplit), localizedc, splitters...)
+	expandedc := step.Expand(pipelineCtx, uc.stage(entity.StageExpand), splittedc, expanders...)
+	normalizedc := step.Normalize(pipelineCtx, uc.stage(entity.StageNormalize), expandedc)
+	lintedc := step.Lint(pipelineCtx, normalizedc, rules...)
 
 	identErrorSamples := make([]string, 0)
-	for ident := range normalizedc {
+	batch := make([]entity.Identifier, 0, identifiersBatchSize)
+	validIdentifiers := metrics.AnalyzedIdentifiers.WithLabelValues("valid")
+	failedIdentifiers := metrics.AnalyzedIdentifiers.WithLabelValues("error")
+	for ident := range lintedc {
 		analysisResults.IdentifiersTotal++
 		if ident.Error != nil {
 			if len(identErrorSamples) < 10 {
@@ -176,27 +352,102 @@ func (uc analyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID
 				"position":   ident.Position,
 			}).Warn("an error occurred during the splitting the expansion for the identifier")
 			analysisResults.IdentifiersError++
+			failedIdentifiers.Inc()
+		} else {
+			validIdentifiers.Inc()
 		}
 
-		err := uc.identifierRepository.Add(ctx, analysisResults, ident)
-		if err != nil {
-			log.WithError(err).Error(fmt.Sprintf("unable to save identifier %s, on file %s for project %s",
-				ident.Name, ident.File, analysisResults.ProjectName))
-			return entity.AnalysisResults{}, ErrUnableToSaveIdentifiers
+		batch = append(batch, ident)
+		if len(batch) < identifiersBatchSize {
+			continue
 		}
+
+		if err := uc.saveIdentifiers(ctx, analysisResults, batch); err != nil {
+			rollback()
+			return entity.AnalysisResults{}, err
+		}
+		batch = batch[:0]
+	}
+	if ctx.Err() != nil {
+		log.WithError(ctx.Err()).Warnf("analysis canceled while processing identifiers for project %s", project.Reference)
+		rollback()
+		return entity.AnalysisResults{}, ErrAnalysisCanceled
+	}
+	if err := uc.saveIdentifiers(ctx, analysisResults, batch); err != nil {
+		rollback()
+		return entity.AnalysisResults{}, err
 	}
 	analysisResults.IdentifiersValid = analysisResults.IdentifiersTotal - analysisResults.IdentifiersError
 	analysisResults.IdentifiersErrorSamples = identErrorSamples
+	stopStaging()
 
+	// the analysis record is the commit marker: staged identifiers without one are discarded
 	err = uc.analysisRepository.Add(ctx, analysisResults)
 	if err != nil {
 		log.WithError(err).Errorf("unable to save analysis results for project %s", project.Reference)
+		rollback()
 		return entity.AnalysisResults{}, ErrUnableToSaveAnalysis
 	}
 
+	// if committing fails, the identifiers are committed later by the cleanup process
+	if err := uc.identifierRepository.Commit(ctx, analysisID); err != nil {
+		log.WithError(err).Warnf("unable to commit identifiers for analysis %v on project %s", analysisID, project.Reference)
+		return analysisResults, nil
+	}
+
+	// the search index can be rebuilt later, so the analysis is kept even if it can't be indexed
+	if err := uc.indexAnalysisUsecase.Process(ctx, analysisID); err != nil {
+		log.WithError(err).Warnf("unable to index identifiers for analysis %v on project %s", analysisID, project.Reference)
+	}
+
 	return analysisResults, nil
 }
 
+// rollback removes the staged identifiers for an analysis that couldn't be completed. It only keeps the workspace
+// from the analysis context, which could be already cancelled. Identifiers that can't be removed are discarded later
+// by the cleanup process.
+func (uc analyzeProjectUsecase) rollback(ctx context.Context, analysisID uuid.UUID) {
+	ctx = entity.WithWorkspace(context.Background(), entity.WorkspaceFrom(ctx))
+	err := uc.identifierRepository.DeleteAllByAnalysisID(ctx, analysisID)
+	switch err {
+	case nil, repository.ErrIdentifierNoResults:
+		// do nothing
+	default:
+		log.WithError(err).Warnf("unable to rollback identifiers for analysis %v", analysisID)
+	}
+}
+
+// saveIdentifiers stores a batch of processed identifiers.
+func (uc analyzeProjectUsecase) saveIdentifiers(ctx context.Context, analysisResults entity.AnalysisResults, batch []entity.Identifier) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := uc.identifierRepository.AddAll(ctx, analysisResults, batch)
+	if err != nil {
+		entry := log.WithError(err)
+		if batchErr, ok := err.(*repository.BatchError); ok && len(batchErr.Failed) > 0 {
+			first := batch[batchErr.Failed[0]]
+			entry = entry.WithFields(log.Fields{
+				"failed":   len(batchErr.Failed),
+				"inserted": batchErr.Inserted,
+			})
+			entry.Error(fmt.Sprintf("unable to save identifier %s, on file %s for project %s",
+				first.Name, first.File, analysisResults.ProjectName))
+		} else {
+			entry.Errorf("unable to save %d identifiers for project %s", len(batch), analysisResults.ProjectName)
+		}
+		return ErrUnableToSaveIdentifiers
+	}
+
+	return nil
+}
+
+// stage returns the concurrency settings for the given pipeline stage.
+func (uc analyzeProjectUsecase) stage(name string) entity.StageConfig {
+	return uc.defaultConfig.Stages[name]
+}
+
 // buildMiners initializes a set of miners, making them exclusives on current process.
 func buildMiners(config *entity.AnalysisConfig) []entity.Miner {
 	miners := make([]entity.Miner, 0)
@@ -262,3 +513,69 @@ func buildExpandersFromMiningResults(config *entity.AnalysisConfig, miningResult
 
 	return expanders
 }
+
+// applyOnSplitters moves the expanders whose splitter isn't on the pipeline, if they can be retargeted, to the first
+// splitter on the pipeline. The remaining ones are kept, although they won't expand any identifier.
+func applyOnSplitters(expanders []entity.Expander, splitters []string) []entity.Expander {
+	applied := make([]entity.Expander, len(expanders))
+	for i, expander := range expanders {
+		applied[i] = expander
+		if contains(splitters, expander.ApplicableOn()) {
+			continue
+		}
+
+		if retargetable, ok := expander.(entity.RetargetableExpander); ok {
+			applied[i] = retargetable.On(splitters[0])
+			continue
+		}
+		log.Warnf("expander %s won't expand any identifier, since the %s splitter isn't on the pipeline",
+			expander.Name(), expander.ApplicableOn())
+	}
+
+	return applied
+}
+
+// buildDictionary retrieves the organization-wide dictionary, overridden by the entries defined for the project.
+func (uc analyzeProjectUsecase) buildDictionary(ctx context.Context, projectRef string) (entity.Dictionary, error) {
+	dictionary := entity.Dictionary{Entries: map[string][]string{}}
+	if uc.dictionaryRepository == nil {
+		return dictionary, nil
+	}
+
+	for _, scope := range []string{entity.DictionaryScopeOrganization, entity.DictionaryScopeProject} {
+		dict, err := uc.dictionaryRepository.GetByScope(ctx, scope, projectRef)
+		switch err {
+		case nil:
+			dictionary = dictionary.Override(dict)
+		case repository.ErrDictionaryNoResults:
+			// do nothing
+		default:
+			log.WithError(err).Errorf("unable to retrieve %s dictionary for project %s", scope, projectRef)
+			return entity.Dictionary{}, ErrUnexpected
+		}
+	}
+
+	return dictionary, nil
+}
+
+// buildRules initializes the set of naming-convention rules, making them exclusives on current process.
+func buildRules(config *entity.AnalysisConfig) []entity.Rule {
+	rules := make([]entity.Rule, 0)
+	for _, name := range config.Rules {
+		factory, err := config.RuleFactory.Get(name)
+		if err != nil {
+			log.WithError(err).Error(fmt.Sprintf("unable to get rule factory for %s", name))
+			continue
+		}
+
+		rule, err := factory.Make(config.RuleSeverities[name])
+		if err != nil {
+			log.WithError(err).Error(fmt.Sprintf("unable to make rule for %s", name))
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
@@ -8,16 +8,24 @@ import (
 	"testing"
 	"time"
 
+	"github.com/eroatta/src-reader/config"
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/expander"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/extractor"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/language"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/linter"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/miner"
 	"github.com/eroatta/src-reader/port/outgoing/adapter/algorithm/splitter"
 	"github.com/eroatta/src-reader/repository"
 	"github.com/eroatta/src-reader/usecase"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewAnalyzeProjectUsecase_ShouldReturnNewInstance(t *testing.T) {
-	uc := usecase.NewAnalyzeProjectUsecase(nil, nil, nil, nil, nil)
+	uc := usecase.NewAnalyzeProjectUsecase(nil, nil, nil, nil, nil, nil, nil)
 
 	assert.Empty(t, uc)
 }
@@ -28,7 +36,7 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenNoProjectFound_ShouldReturnError(t
 		getErr:  repository.ErrProjectNoResults,
 	}
 
-	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, nil, nil, nil, &entity.AnalysisConfig{})
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, nil, nil, nil, nil, indexAnalysisUsecaseMock{}, &entity.AnalysisConfig{})
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
@@ -43,7 +51,7 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToRetrieveProject_ShouldRetu
 		getErr:  repository.ErrProjectUnexpected,
 	}
 
-	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, nil, nil, nil, &entity.AnalysisConfig{})
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, nil, nil, nil, nil, indexAnalysisUsecaseMock{}, &entity.AnalysisConfig{})
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
@@ -84,7 +92,7 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenExistingAnalysisForProject_ShouldRe
 		},
 	}
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, nil, nil,
-		analysisRepositoryMock, &entity.AnalysisConfig{})
+		analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, &entity.AnalysisConfig{})
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
@@ -117,7 +125,7 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToReadFiles_ShouldReturnErro
 	}
 
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
-		nil, analysisRepositoryMock, &entity.AnalysisConfig{})
+		nil, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, &entity.AnalysisConfig{})
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
@@ -152,7 +160,7 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToParseFiles_ShouldReturnErr
 	}
 
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
-		nil, analysisRepositoryMock, &entity.AnalysisConfig{})
+		nil, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, &entity.AnalysisConfig{})
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
@@ -191,7 +199,7 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToCreateSplitters_ShouldRetu
 		Splitters: []string{},
 	}
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
-		nil, analysisRepositoryMock, config)
+		nil, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
@@ -200,6 +208,48 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToCreateSplitters_ShouldRetu
 	assert.Empty(t, results)
 }
 
+func TestProcessWithPipeline_OnAnalyzeProjectUsecase_ShouldReplaceConfiguredPipeline(t *testing.T) {
+	project := entity.Project{
+		Reference: "eroatta/test",
+		SourceCode: entity.SourceCode{
+			Hash:     "asdf1234asdf",
+			Location: "/tmp/repositories/eroatta/test",
+			Files:    []string{"main.go"},
+		},
+	}
+	projectRepositoryMock := projectRepositoryMock{
+		project: project,
+	}
+
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+		},
+		err: nil,
+	}
+
+	analysisRepositoryMock := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{},
+		getErr:          repository.ErrAnalysisNoResults,
+	}
+
+	// the configured splitter would be created, but the pipeline doesn't include any
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		nil, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.ProcessWithPipeline(context.TODO(), projectID, entity.Pipeline{Splitters: []string{}})
+
+	assert.EqualError(t, err, usecase.ErrUnableToCreateProcessors.Error())
+	assert.Empty(t, results)
+	assert.Equal(t, []string{"conserv"}, config.Splitters)
+}
+
 func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToCreateExpanders_ShouldReturnError(t *testing.T) {
 	project := entity.Project{
 		Reference: "eroatta/test",
@@ -232,7 +282,7 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToCreateExpanders_ShouldRetu
 		Expanders:                 []string{},
 	}
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
-		nil, analysisRepositoryMock, config)
+		nil, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
@@ -261,8 +311,10 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToSaveIdentifiers_ShouldRetu
 		err: nil,
 	}
 
+	deletedIDs := make([]uuid.UUID, 0)
 	identifierRepositoryMock := identifierRepositoryMock{
-		err: repository.ErrIdentifierUnexpected,
+		err:     repository.ErrIdentifierUnexpected,
+		deleted: &deletedIDs,
 	}
 
 	analysisRepositoryMock := analysisRepositoryMock{
@@ -279,16 +331,17 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToSaveIdentifiers_ShouldRetu
 		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
 	}
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
-		identifierRepositoryMock, analysisRepositoryMock, config)
+		identifierRepositoryMock, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
 
 	assert.EqualError(t, err, usecase.ErrUnableToSaveIdentifiers.Error())
 	assert.Empty(t, results)
+	assert.Equal(t, 1, len(deletedIDs))
 }
 
-func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToSaveAnalysis_ShouldReturnError(t *testing.T) {
+func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToStageAnalysis_ShouldReturnError(t *testing.T) {
 	project := entity.Project{
 		Reference: "eroatta/test",
 		SourceCode: entity.SourceCode{
@@ -308,10 +361,63 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToSaveAnalysis_ShouldReturnE
 		err: nil,
 	}
 
+	batches := make([]int, 0)
 	identifierRepositoryMock := identifierRepositoryMock{
+		stageErr: repository.ErrIdentifierUnexpected,
+		batches:  &batches,
+	}
+
+	analysisRepositoryMock := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{},
+		getErr:          repository.ErrAnalysisNoResults,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.EqualError(t, err, usecase.ErrUnableToSaveIdentifiers.Error())
+	assert.Empty(t, results)
+	assert.Empty(t, batches)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToSaveAnalysis_ShouldReturnError(t *testing.T) {
+	project := entity.Project{
+		Reference: "eroatta/test",
+		SourceCode: entity.SourceCode{
+			Hash:     "asdf1234asdf",
+			Location: "/tmp/repositories/eroatta/test",
+			Files:    []string{"main.go"},
+		},
+	}
+	projectRepositoryMock := projectRepositoryMock{
+		project: project,
+	}
+
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+		},
 		err: nil,
 	}
 
+	deletedIDs, committedIDs := make([]uuid.UUID, 0), make([]uuid.UUID, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		err:       nil,
+		deleted:   &deletedIDs,
+		committed: &committedIDs,
+	}
+
 	analysisRepositoryMock := analysisRepositoryMock{
 		analysisResults: entity.AnalysisResults{},
 		getErr:          repository.ErrAnalysisNoResults,
@@ -327,13 +433,47 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToSaveAnalysis_ShouldReturnE
 		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
 	}
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
-		identifierRepositoryMock, analysisRepositoryMock, config)
+		identifierRepositoryMock, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
 
 	assert.EqualError(t, err, usecase.ErrUnableToSaveAnalysis.Error())
 	assert.Empty(t, results)
+	assert.Equal(t, 1, len(deletedIDs))
+	assert.Empty(t, committedIDs)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenReplacingPreviousAnalysis_ShouldAnalyzeProject(t *testing.T) {
+	previous := uuid.New()
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference:  "eroatta/test",
+			SourceCode: entity.SourceCode{Location: "/tmp/repositories/eroatta/test", Files: []string{"main.go"}},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{"main.go": []byte("package main")},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{ID: previous},
+	}
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	results, err := uc.Process(entity.WithReplacedAnalysis(context.TODO(), previous), uuid.New())
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, previous, results.ID)
+	assert.Equal(t, 1, results.IdentifiersTotal)
 }
 
 func TestProcess_OnAnalyzeProjectUsecase_WhenAnalyzingIdentifiers_ShouldReturnAnalysisResults(t *testing.T) {
@@ -360,8 +500,10 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenAnalyzingIdentifiers_ShouldReturnAn
 		err: nil,
 	}
 
+	committedIDs, indexedIDs := make([]uuid.UUID, 0), make([]uuid.UUID, 0)
 	identifierRepositoryMock := identifierRepositoryMock{
-		err: nil,
+		err:       nil,
+		committed: &committedIDs,
 	}
 
 	analysisRepositoryMock := analysisRepositoryMock{
@@ -379,13 +521,15 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenAnalyzingIdentifiers_ShouldReturnAn
 		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
 	}
 	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
-		identifierRepositoryMock, analysisRepositoryMock, config)
+		identifierRepositoryMock, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{indexed: &indexedIDs}, config)
 
 	projectID, _ := uuid.NewUUID()
 	results, err := uc.Process(context.TODO(), projectID)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, results.ID)
+	assert.Equal(t, []uuid.UUID{results.ID}, committedIDs)
+	assert.Equal(t, []uuid.UUID{results.ID}, indexedIDs)
 	assert.Equal(t, "eroatta/test", results.ProjectName)
 	assert.Equal(t, 1, results.FilesTotal)
 	assert.Equal(t, 1, results.FilesValid)
@@ -400,30 +544,758 @@ func TestProcess_OnAnalyzeProjectUsecase_WhenAnalyzingIdentifiers_ShouldReturnAn
 	assert.Empty(t, results.IdentifiersErrorSamples)
 }
 
-type sourceCodeFileReaderMock struct {
-	files map[string][]byte
-	err   error
+func TestProcess_OnAnalyzeProjectUsecase_WhenSkippingFiles_ShouldCountSkippedFiles(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			ID:        uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"),
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go", "vendor/errors/errors.go", "tools/tools.go"},
+			},
+			Filter: entity.FileFilter{Exclude: []string{"tools/**"}},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go":                 []byte("package main"),
+			"vendor/errors/errors.go": []byte("package errors"),
+			"tools/tools.go":          []byte("package tools"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+		Guards:                    entity.FileGuards{SkipDirs: []string{"vendor"}},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+	parsed := testutil.ToFloat64(metrics.AnalyzedFiles.WithLabelValues("parsed"))
+	skipped := testutil.ToFloat64(metrics.AnalyzedFiles.WithLabelValues("skipped"))
+
+	results, err := uc.Process(context.TODO(), uuid.New())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, results.FilesTotal)
+	assert.Equal(t, 1, results.FilesValid)
+	assert.Equal(t, 0, results.FilesError)
+	assert.Equal(t, 2, results.FilesSkipped)
+	assert.Equal(t, parsed+1, testutil.ToFloat64(metrics.AnalyzedFiles.WithLabelValues("parsed")))
+	assert.Equal(t, skipped+2, testutil.ToFloat64(metrics.AnalyzedFiles.WithLabelValues("skipped")))
 }
 
-func (m sourceCodeFileReaderMock) Clone(ctx context.Context, fullname string, cloneURL string) (entity.SourceCode, error) {
-	return entity.SourceCode{}, errors.New("shouldn't be called")
+func TestProcessWithPipeline_OnAnalyzeProjectUsecase_WhenIncludingTests_ShouldReadTestFiles(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go", "main_test.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go":      []byte("package main"),
+			"main_test.go": []byte("package main"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	results, err := uc.ProcessWithPipeline(context.TODO(), uuid.New(), entity.Pipeline{IncludeTests: true})
+
+	assert.NoError(t, err)
+	assert.True(t, results.IncludeTests)
+	assert.Equal(t, []string{"conserv"}, results.PipelineSplitters)
+	assert.Equal(t, 2, results.FilesTotal)
+	assert.Equal(t, 2, results.FilesValid)
+	assert.False(t, config.IncludeTests)
 }
 
-func (m sourceCodeFileReaderMock) Remove(ctx context.Context, location string) error {
-	return errors.New("shouldn't be called")
+func TestProcess_OnAnalyzeProjectUsecase_WhenFailingToIndexIdentifiers_ShouldReturnAnalysisResults(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	deletedIDs := make([]uuid.UUID, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		deleted: &deletedIDs,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{err: usecase.ErrUnexpected}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results.ID)
+	assert.Equal(t, 1, results.IdentifiersTotal)
+	assert.Empty(t, deletedIDs)
 }
 
-func (m sourceCodeFileReaderMock) Read(ctx context.Context, location string, filename string) ([]byte, error) {
-	if m.err != nil {
-		return []byte{}, m.err
+func TestProcess_OnAnalyzeProjectUsecase_WhenAnalyzingIdentifiersWithRules_ShouldReturnAnalysisResults(t *testing.T) {
+	project := entity.Project{
+		ID:        uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"),
+		Reference: "eroatta/test",
+		Metadata: entity.Metadata{
+			Fullname: "eroatta/test",
+		},
+		SourceCode: entity.SourceCode{
+			Hash:     "asdf1234asdf",
+			Location: "/tmp/repositories/eroatta/test",
+			Files:    []string{"main.go"},
+		},
+	}
+	projectRepositoryMock := projectRepositoryMock{
+		project: project,
 	}
 
-	b, ok := m.files[filename]
-	if !ok {
-		return []byte{}, errors.New("not found")
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+		},
+		err: nil,
 	}
 
-	return b, nil
+	identifierRepositoryMock := identifierRepositoryMock{
+		err: nil,
+	}
+
+	analysisRepositoryMock := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{},
+		getErr:          repository.ErrAnalysisNoResults,
+		addErr:          nil,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+		Rules:                     []string{"snake-case", "non-existing", "initialisms"},
+		RuleFactory:               linter.NewRuleFactory(),
+		RuleSeverities: map[string]entity.Severity{
+			"initialisms": "invalid",
+		},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"snake-case"}, results.PipelineRules)
+	assert.Equal(t, 1, results.IdentifiersTotal)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenErrorRetrievingDictionary_ShouldReturnError(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		getErr: repository.ErrDictionaryUnexpected,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+		DictionaryExpanderFactory: expander.NewDictionaryExpander,
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		nil, analysisRepositoryMock, dictionaryRepositoryMock, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+	assert.Empty(t, results)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenAnalyzingIdentifiersWithDictionary_ShouldReturnAnalysisResults(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			ID:        uuid.MustParse("f9b76fde-c342-4328-8650-85da8f21e2be"),
+			Reference: "eroatta/test",
+			Metadata: entity.Metadata{
+				Fullname: "eroatta/test",
+			},
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		dictionaries: map[string]entity.Dictionary{
+			entity.DictionaryScopeOrganization: {
+				Scope:   entity.DictionaryScopeOrganization,
+				Entries: map[string][]string{"main": {"principal"}},
+			},
+		},
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+		DictionaryExpanderFactory: expander.NewDictionaryExpander,
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, dictionaryRepositoryMock, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"dictionary", "mock"}, results.PipelineExpanders)
+	assert.Equal(t, 1, results.IdentifiersTotal)
+	assert.Equal(t, 1, results.IdentifiersValid)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenUsingDefaultPipelineWithDictionary_ShouldNormalizeWithDictionary(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/non-existing",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main\n\n// commit the pending transaction\nvar txn string"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		dictionaries: map[string]entity.Dictionary{
+			entity.DictionaryScopeOrganization: {
+				Scope:   entity.DictionaryScopeOrganization,
+				Entries: map[string][]string{"txn": {"transaction"}},
+			},
+		},
+	}
+	saved := make([]entity.Identifier, 0)
+
+	pipeline := config.Default().Pipeline
+	analysisConfig := &entity.AnalysisConfig{
+		Miners:                    pipeline.Miners,
+		MinerAlgorithmFactory:     miner.NewMinerFactory(),
+		LanguageDetector:          language.Detect,
+		ExtractorFactory:          extractor.New,
+		Splitters:                 pipeline.Splitters,
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 pipeline.Expanders,
+		ExpansionAlgorithmFactory: expander.NewExpanderFactory(),
+		DictionaryExpanderFactory: expander.NewDictionaryExpander,
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{saved: &saved}, analysisRepositoryMock, dictionaryRepositoryMock,
+		indexAnalysisUsecaseMock{}, analysisConfig)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, append([]string{"dictionary"}, pipeline.Expanders...), results.PipelineExpanders)
+	found := false
+	for _, ident := range saved {
+		if ident.Name != "txn" {
+			continue
+		}
+		found = true
+		assert.Equal(t, []string{"txn"}, ident.Expansions["noexp"][0].Values)
+		assert.Equal(t, "transaction", ident.Normalization.Word)
+		assert.Equal(t, "conserv+dictionary", ident.Normalization.Algorithm)
+	}
+	assert.True(t, found)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenDictionaryWithoutConservSplitter_ShouldExpandTheSplitsOfThePipeline(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/non-existing",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main\n\n// commit the pending transaction\nvar txn string"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		dictionaries: map[string]entity.Dictionary{
+			entity.DictionaryScopeOrganization: {
+				Scope:   entity.DictionaryScopeOrganization,
+				Entries: map[string][]string{"txn": {"transaction"}},
+			},
+		},
+	}
+	saved := make([]entity.Identifier, 0)
+
+	pipeline := config.Default().Pipeline
+	analysisConfig := &entity.AnalysisConfig{
+		Miners:                    pipeline.Miners,
+		MinerAlgorithmFactory:     miner.NewMinerFactory(),
+		LanguageDetector:          language.Detect,
+		ExtractorFactory:          extractor.New,
+		Splitters:                 []string{"samurai"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"amap"},
+		ExpansionAlgorithmFactory: expander.NewExpanderFactory(),
+		DictionaryExpanderFactory: expander.NewDictionaryExpander,
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{saved: &saved}, analysisRepositoryMock, dictionaryRepositoryMock,
+		indexAnalysisUsecaseMock{}, analysisConfig)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"dictionary", "amap"}, results.PipelineExpanders)
+	found := false
+	for _, ident := range saved {
+		if ident.Name != "txn" {
+			continue
+		}
+		found = true
+		assert.Equal(t, "transaction", ident.Normalization.Word)
+		assert.Equal(t, "samurai+dictionary", ident.Normalization.Algorithm)
+	}
+	assert.True(t, found)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenUnableToMineHistory_ShouldReturnAnalysisResults(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/non-existing",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main\n\nvar usr, user string"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{"abbreviations"},
+		MinerAlgorithmFactory:     miner.NewMinerFactory(),
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"learned"},
+		ExpansionAlgorithmFactory: expander.NewExpanderFactory(),
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"abbreviations"}, results.PipelineMiners)
+	assert.EqualValues(t, []string{"learned"}, results.PipelineExpanders)
+	assert.Equal(t, 1, results.IdentifiersValid)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenMiningHistory_ShouldSkipFilesLeftOutOfTheAnalysis(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go", "main_test.go", "gen.go", "vendor/lib/lib.go", "internal/db.go"},
+			},
+			Filter: entity.FileFilter{Exclude: []string{"internal/**"}},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+			"gen.go":  []byte("// Code generated by stringer. DO NOT EDIT.\n\npackage main"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	historyMiner := &historyMinerMock{}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{"history"},
+		MinerAlgorithmFactory:     minerAbstractFactoryMock{miner: historyMiner},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+		Guards:                    entity.FileGuards{SkipDirs: []string{"vendor"}, SkipGenerated: true},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	_, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/repositories/eroatta/test", historyMiner.location)
+	if assert.NotNil(t, historyMiner.skips) {
+		for _, filename := range []string{"main_test.go", "gen.go", "vendor/lib/lib.go", "internal/db.go"} {
+			assert.True(t, historyMiner.skips(filename), filename)
+		}
+		assert.False(t, historyMiner.skips("main.go"))
+		assert.False(t, historyMiner.skips("removed.go"))
+	}
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenDetectingLanguages_ShouldReturnAnalysisResults(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main\n\n// guardar los datos del usuario\nfunc guardar() {}"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	detected := make(map[string][]string)
+	config := &entity.AnalysisConfig{
+		Miners:                []string{"declarations"},
+		MinerAlgorithmFactory: miner.NewMinerFactory(),
+		LanguageDetector: func(texts []string) entity.Language {
+			detected["main"] = texts
+			return entity.LanguageSpanish
+		},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"declarations"}, results.PipelineMiners)
+	assert.EqualValues(t, map[string][]string{"main": {"guardar los datos del usuario\n"}}, detected)
+	assert.Equal(t, 1, results.IdentifiersValid)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenContextCanceled_ShouldReturnError(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    []string{"main.go"},
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go": []byte("package main"),
+		},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	config := &entity.AnalysisConfig{
+		Stages: map[string]entity.StageConfig{
+			entity.StageRead:  {Workers: 2},
+			entity.StageParse: {Workers: 2},
+		},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(ctx, projectID)
+
+	assert.EqualError(t, err, usecase.ErrAnalysisCanceled.Error())
+	assert.Equal(t, entity.AnalysisResults{}, results)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenUsingConcurrentStages_ShouldReturnAnalysisResults(t *testing.T) {
+	files := make([]string, 0)
+	raw := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("file%d.go", i)
+		files = append(files, name)
+		raw[name] = []byte("package main")
+	}
+
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    files,
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: raw,
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+		Stages: map[string]entity.StageConfig{
+			entity.StageRead:      {Workers: 4, Buffer: 4},
+			entity.StageParse:     {Workers: 4, Buffer: 4},
+			entity.StageSplit:     {Workers: 4, Buffer: 8},
+			entity.StageExpand:    {Workers: 4, Buffer: 8},
+			entity.StageNormalize: {Workers: 2},
+		},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock{}, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 20, results.FilesValid)
+	assert.Equal(t, 20, results.IdentifiersTotal)
+	assert.Equal(t, 20, results.IdentifiersValid)
+}
+
+func TestProcess_OnAnalyzeProjectUsecase_WhenManyIdentifiers_ShouldSaveThemInBatches(t *testing.T) {
+	files := make([]string, 0)
+	raw := make(map[string][]byte)
+	for i := 0; i < 501; i++ {
+		name := fmt.Sprintf("file%d.go", i)
+		files = append(files, name)
+		raw[name] = []byte("package main")
+	}
+
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{
+			Reference: "eroatta/test",
+			SourceCode: entity.SourceCode{
+				Location: "/tmp/repositories/eroatta/test",
+				Files:    files,
+			},
+		},
+	}
+	sourceCodeRepositoryMock := sourceCodeFileReaderMock{
+		files: raw,
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	batches := make([]int, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		batches: &batches,
+	}
+
+	config := &entity.AnalysisConfig{
+		Miners:                    []string{},
+		ExtractorFactory:          newExtractorMock,
+		Splitters:                 []string{"conserv"},
+		SplittingAlgorithmFactory: splitter.NewSplitterFactory(),
+		Expanders:                 []string{"mock"},
+		ExpansionAlgorithmFactory: expanderAbstractFactoryMock{},
+	}
+	uc := usecase.NewAnalyzeProjectUsecase(projectRepositoryMock, sourceCodeRepositoryMock,
+		identifierRepositoryMock, analysisRepositoryMock, nil, indexAnalysisUsecaseMock{}, config)
+
+	projectID, _ := uuid.NewUUID()
+	results, err := uc.Process(context.TODO(), projectID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 501, results.IdentifiersTotal)
+	assert.Equal(t, []int{500, 1}, batches)
+}
+
+type sourceCodeFileReaderMock struct {
+	files map[string][]byte
+	err   error
+}
+
+func (m sourceCodeFileReaderMock) Clone(ctx context.Context, fullname string, cloneURL string) (entity.SourceCode, error) {
+	return entity.SourceCode{}, errors.New("shouldn't be called")
+}
+
+func (m sourceCodeFileReaderMock) Update(ctx context.Context, location string, hash string) (entity.SourceCode, error) {
+	return entity.SourceCode{}, errors.New("shouldn't be called")
+}
+
+func (m sourceCodeFileReaderMock) Remove(ctx context.Context, location string) error {
+	return errors.New("shouldn't be called")
+}
+
+func (m sourceCodeFileReaderMock) Read(ctx context.Context, location string, filename string) ([]byte, error) {
+	if m.err != nil {
+		return []byte{}, m.err
+	}
+
+	b, ok := m.files[filename]
+	if !ok {
+		return []byte{}, errors.New("not found")
+	}
+
+	return b, nil
+}
+
+type minerAbstractFactoryMock struct {
+	miner entity.Miner
+}
+
+func (m minerAbstractFactoryMock) Get(name string) (entity.MinerFactory, error) {
+	return minerFactoryMock(m), nil
+}
+
+type minerFactoryMock struct {
+	miner entity.Miner
+}
+
+func (m minerFactoryMock) Make() (entity.Miner, error) {
+	return m.miner, nil
+}
+
+type historyMinerMock struct {
+	location string
+	skips    func(filename string) bool
+}
+
+func (m *historyMinerMock) Name() string {
+	return "history"
+}
+
+func (m *historyMinerMock) Visit(node ast.Node) ast.Visitor {
+	return nil
+}
+
+func (m *historyMinerMock) SetCurrentFile(filename string) {}
+
+func (m *historyMinerMock) Results() interface{} {
+	return nil
+}
+
+func (m *historyMinerMock) MineHistory(ctx context.Context, location string, skips func(filename string) bool) error {
+	m.location = location
+	m.skips = skips
+	return nil
+}
+
+func (m sourceCodeFileReaderMock) Size(ctx context.Context, location string, filename string) (int64, error) {
+	b, err := m.Read(ctx, location, filename)
+	return int64(len(b)), err
 }
 
 type expanderAbstractFactoryMock struct{}
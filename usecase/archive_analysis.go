@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// ArchiveAnalysisUsecase defines the contract for the use case that archives an analysis, along with its project,
+// identifiers and insights, so it can be restored later on any backend.
+type ArchiveAnalysisUsecase interface {
+	// Process writes the archive for the given analysis on the destination. Nothing is written if the analysis
+	// can't be found.
+	Process(ctx context.Context, analysisID uuid.UUID, w io.Writer) error
+}
+
+// NewArchiveAnalysisUsecase initializes a new ArchiveAnalysisUsecase instance.
+func NewArchiveAnalysisUsecase(pr repository.ProjectRepository, ar repository.AnalysisRepository,
+	ir repository.IdentifierRepository, insr repository.InsightRepository, af repository.ArchiveFormat) ArchiveAnalysisUsecase {
+	return archiveAnalysisUsecase{
+		projectRepository:    pr,
+		analysisRepository:   ar,
+		identifierRepository: ir,
+		insightRepository:    insr,
+		archiveFormat:        af,
+	}
+}
+
+type archiveAnalysisUsecase struct {
+	projectRepository    repository.ProjectRepository
+	analysisRepository   repository.AnalysisRepository
+	identifierRepository repository.IdentifierRepository
+	insightRepository    repository.InsightRepository
+	archiveFormat        repository.ArchiveFormat
+}
+
+func (uc archiveAnalysisUsecase) Process(ctx context.Context, analysisID uuid.UUID, w io.Writer) error {
+	analysis, err := uc.analysisRepository.Get(ctx, analysisID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrAnalysisNoResults:
+		return ErrAnalysisNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve analysis with ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	project, err := uc.projectRepository.Get(ctx, analysis.ProjectID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrProjectNoResults:
+		return ErrProjectNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve project with ID: %v", analysis.ProjectID)
+		return ErrUnexpected
+	}
+
+	insights, err := uc.insightRepository.GetByAnalysisID(ctx, analysisID)
+	switch err {
+	case nil, repository.ErrInsightNoResults:
+		// do nothing
+	default:
+		log.WithError(err).Errorf("unable to retrieve insights for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	it, err := uc.identifierRepository.IterateByAnalysisID(ctx, analysisID)
+	if err != nil {
+		log.WithError(err).Errorf("unable to retrieve identifiers for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+	defer it.Close(ctx)
+
+	writer, err := uc.archiveFormat.NewWriter(w, entity.ArchiveHeader{
+		SchemaVersion: entity.ArchiveSchemaVersion,
+		CreatedAt:     time.Now(),
+		AnalysisID:    analysisID,
+		ProjectRef:    project.Reference,
+	})
+	if err != nil {
+		log.WithError(err).Errorf("unable to create archive for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	records := []entity.ArchiveRecord{{Project: &project}, {Analysis: &analysis}}
+	for i := range insights {
+		records = append(records, entity.ArchiveRecord{Insight: &insights[i]})
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			log.WithError(err).Errorf("unable to archive analysis ID: %v", analysisID)
+			return ErrUnexpected
+		}
+	}
+
+	for it.Next(ctx) {
+		ident := it.Identifier()
+		if err := writer.Write(entity.ArchiveRecord{Identifier: &ident}); err != nil {
+			log.WithError(err).Errorf("unable to archive identifiers for analysis ID: %v", analysisID)
+			return ErrUnexpected
+		}
+	}
+	if err := it.Err(); err != nil {
+		log.WithError(err).Errorf("unable to iterate identifiers for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	if err := writer.Close(); err != nil {
+		log.WithError(err).Errorf("unable to complete archive for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	return nil
+}
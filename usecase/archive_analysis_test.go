@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewArchiveAnalysisUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewArchiveAnalysisUsecase(nil, nil, nil, nil, nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnArchiveAnalysisUsecase_WhenNoAnalysis_ShouldReturnError(t *testing.T) {
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	uc := usecase.NewArchiveAnalysisUsecase(projectRepositoryMock{}, analysisRepositoryMock, identifierRepositoryMock{},
+		insightsRepositoryMock{}, archiveFormatMock{})
+	err := uc.Process(context.TODO(), uuid.New(), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrAnalysisNotFound.Error())
+}
+
+func TestProcess_OnArchiveAnalysisUsecase_WhenNoProject_ShouldReturnError(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		getErr: repository.ErrProjectNoResults,
+	}
+
+	uc := usecase.NewArchiveAnalysisUsecase(projectRepositoryMock, analysisRepositoryMock{}, identifierRepositoryMock{},
+		insightsRepositoryMock{}, archiveFormatMock{})
+	err := uc.Process(context.TODO(), uuid.New(), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrProjectNotFound.Error())
+}
+
+func TestProcess_OnArchiveAnalysisUsecase_WhenErrorRetrievingInsights_ShouldReturnError(t *testing.T) {
+	insightsRepositoryMock := insightsRepositoryMock{
+		getErr: repository.ErrInsightUnexpected,
+	}
+
+	uc := usecase.NewArchiveAnalysisUsecase(projectRepositoryMock{}, analysisRepositoryMock{}, identifierRepositoryMock{},
+		insightsRepositoryMock, archiveFormatMock{})
+	err := uc.Process(context.TODO(), uuid.New(), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnArchiveAnalysisUsecase_WhenErrorWritingArchive_ShouldReturnError(t *testing.T) {
+	archiveFormatMock := archiveFormatMock{
+		writeErr: repository.ErrArchiveUnexpected,
+	}
+
+	uc := usecase.NewArchiveAnalysisUsecase(projectRepositoryMock{}, analysisRepositoryMock{}, identifierRepositoryMock{},
+		insightsRepositoryMock{}, archiveFormatMock)
+	err := uc.Process(context.TODO(), uuid.New(), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnArchiveAnalysisUsecase_ShouldWriteEveryElement(t *testing.T) {
+	analysisID := uuid.New()
+	projectRepositoryMock := projectRepositoryMock{
+		project: entity.Project{Reference: "eroatta/test"},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{ID: analysisID},
+	}
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{{Name: "main"}, {Name: "parseCfg"}},
+	}
+	insightsRepositoryMock := insightsRepositoryMock{
+		insights: []entity.Insight{{Package: "main"}},
+	}
+	written := make([]entity.ArchiveRecord, 0)
+	var header entity.ArchiveHeader
+	archiveFormatMock := archiveFormatMock{
+		written:       &written,
+		writtenHeader: &header,
+	}
+
+	uc := usecase.NewArchiveAnalysisUsecase(projectRepositoryMock, analysisRepositoryMock, identifierRepositoryMock,
+		insightsRepositoryMock, archiveFormatMock)
+	err := uc.Process(context.TODO(), analysisID, &bytes.Buffer{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ArchiveSchemaVersion, header.SchemaVersion)
+	assert.Equal(t, analysisID, header.AnalysisID)
+	assert.Equal(t, "eroatta/test", header.ProjectRef)
+	assert.Equal(t, []entity.ArchiveRecord{
+		{Project: &entity.Project{Reference: "eroatta/test"}},
+		{Analysis: &entity.AnalysisResults{ID: analysisID}},
+		{Insight: &entity.Insight{Package: "main"}},
+		{Identifier: &entity.Identifier{Name: "main"}},
+		{Identifier: &entity.Identifier{Name: "parseCfg"}},
+	}, written)
+}
+
+type archiveFormatMock struct {
+	header        entity.ArchiveHeader
+	records       []entity.ArchiveRecord
+	written       *[]entity.ArchiveRecord
+	writtenHeader *entity.ArchiveHeader
+	newErr        error
+	writeErr      error
+	readErr       error
+}
+
+func (f archiveFormatMock) NewWriter(w io.Writer, header entity.ArchiveHeader) (repository.ArchiveWriter, error) {
+	if f.newErr != nil {
+		return nil, f.newErr
+	}
+	if f.writtenHeader != nil {
+		*f.writtenHeader = header
+	}
+	return archiveWriterMock{written: f.written, err: f.writeErr}, nil
+}
+
+func (f archiveFormatMock) NewReader(r io.Reader) (repository.ArchiveReader, error) {
+	if f.newErr != nil {
+		return nil, f.newErr
+	}
+	return &archiveReaderMock{header: f.header, records: f.records, err: f.readErr}, nil
+}
+
+type archiveWriterMock struct {
+	written *[]entity.ArchiveRecord
+	err     error
+}
+
+func (m archiveWriterMock) Write(record entity.ArchiveRecord) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.written != nil {
+		*m.written = append(*m.written, record)
+	}
+	return nil
+}
+
+func (m archiveWriterMock) Close() error {
+	return nil
+}
+
+type archiveReaderMock struct {
+	header  entity.ArchiveHeader
+	records []entity.ArchiveRecord
+	pos     int
+	err     error
+}
+
+func (m *archiveReaderMock) Header() entity.ArchiveHeader {
+	return m.header
+}
+
+func (m *archiveReaderMock) Read() (entity.ArchiveRecord, error) {
+	if m.pos == len(m.records) {
+		if m.err != nil {
+			return entity.ArchiveRecord{}, m.err
+		}
+		return entity.ArchiveRecord{}, io.EOF
+	}
+
+	m.pos++
+	return m.records[m.pos-1], nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrInvalidCredentials indicates that the given API key is missing, unknown or revoked.
+var ErrInvalidCredentials = errors.New("missing or invalid API key")
+
+// AuthenticateUsecase defines the contract for the use case related to identify the client sending a request.
+type AuthenticateUsecase interface {
+	// Process retrieves the API key matching the given key.
+	Process(ctx context.Context, key string) (entity.APIKey, error)
+}
+
+// NewAuthenticateUsecase initializes a new AuthenticateUsecase instance. The root key is granted the admin scope
+// without being stored, so the first keys can be created. It's ignored if empty.
+func NewAuthenticateUsecase(kr repository.APIKeyRepository, rootKey string) AuthenticateUsecase {
+	return authenticateUsecase{
+		apiKeyRepository: kr,
+		rootKey:          rootKey,
+	}
+}
+
+type authenticateUsecase struct {
+	apiKeyRepository repository.APIKeyRepository
+	rootKey          string
+}
+
+func (uc authenticateUsecase) Process(ctx context.Context, key string) (entity.APIKey, error) {
+	if key == "" {
+		return entity.APIKey{}, ErrInvalidCredentials
+	}
+
+	if uc.rootKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(uc.rootKey)) == 1 {
+		return entity.APIKey{Name: RootAPIKeyName, Scopes: []string{entity.ScopeAdmin}}, nil
+	}
+
+	found, err := uc.apiKeyRepository.GetByHash(ctx, entity.HashAPIKey(key))
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrAPIKeyNoResults:
+		return entity.APIKey{}, ErrInvalidCredentials
+	default:
+		log.WithError(err).Error("unable to retrieve API key")
+		return entity.APIKey{}, ErrUnexpected
+	}
+
+	return found, nil
+}
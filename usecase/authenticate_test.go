@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthenticateUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewAuthenticateUsecase(nil, "")
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnAuthenticateUsecase_WhenInvalidKey_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		rootKey string
+		key     string
+	}{
+		{"missing_key", "", ""},
+		{"missing_key_and_root_key", "root-secret", ""},
+		{"unknown_key", "root-secret", "srk_unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := usecase.NewAuthenticateUsecase(apiKeyRepositoryMock{}, tt.rootKey)
+
+			key, err := uc.Process(context.TODO(), tt.key)
+
+			assert.Empty(t, key)
+			assert.EqualError(t, err, usecase.ErrInvalidCredentials.Error())
+		})
+	}
+}
+
+func TestProcess_OnAuthenticateUsecase_WhenErrorRetrievingKey_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewAuthenticateUsecase(apiKeyRepositoryMock{getErr: repository.ErrAPIKeyUnexpected}, "")
+
+	key, err := uc.Process(context.TODO(), "srk_0a1b2c3d")
+
+	assert.Empty(t, key)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnAuthenticateUsecase_WhenRootKey_ShouldReturnAdminKey(t *testing.T) {
+	uc := usecase.NewAuthenticateUsecase(apiKeyRepositoryMock{}, "root-secret")
+
+	key, err := uc.Process(context.TODO(), "root-secret")
+
+	assert.NoError(t, err)
+	assert.Equal(t, usecase.RootAPIKeyName, key.Name)
+	assert.True(t, key.Allows(entity.ScopeAdmin))
+}
+
+func TestProcess_OnAuthenticateUsecase_ShouldReturnStoredKey(t *testing.T) {
+	stored := entity.APIKey{Name: "ci", Hash: entity.HashAPIKey("srk_0a1b2c3d"), Scopes: []string{entity.ScopeRead}}
+	uc := usecase.NewAuthenticateUsecase(apiKeyRepositoryMock{keys: []entity.APIKey{stored}}, "root-secret")
+
+	key, err := uc.Process(context.TODO(), "srk_0a1b2c3d")
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, key)
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// stagingHeartbeat is how often an analysis storing its identifiers refreshes its in progress mark. The cleanup
+// process must only look for analyses idle for longer than it.
+const stagingHeartbeat = time.Minute
+
+// CleanupAnalysesUsecase defines the contract for the use case that completes or discards the analyses
+// interrupted while storing their identifiers.
+type CleanupAnalysesUsecase interface {
+	// Process looks for the identifiers staged before the given time on every workspace, skipping the analyses still
+	// in progress, either marked as staged or held by a job refreshed since then. If their analysis was stored, the
+	// identifiers are committed and indexed. Otherwise, the analysis is incomplete and the identifiers are removed.
+	// It returns the number of committed and discarded analyses.
+	Process(ctx context.Context, stagedBefore time.Time) (int, int, error)
+}
+
+// NewCleanupAnalysesUsecase initializes a new CleanupAnalysesUsecase instance.
+func NewCleanupAnalysesUsecase(iuc IndexAnalysisUsecase, ir repository.IdentifierRepository, ar repository.AnalysisRepository,
+	jr repository.JobRepository, wr repository.WorkspaceRepository) CleanupAnalysesUsecase {
+	return cleanupAnalysesUsecase{
+		indexAnalysisUsecase: iuc,
+		ir:                   ir,
+		ar:                   ar,
+		jr:                   jr,
+		wr:                   wr,
+	}
+}
+
+type cleanupAnalysesUsecase struct {
+	indexAnalysisUsecase IndexAnalysisUsecase
+	ir                   repository.IdentifierRepository
+	ar                   repository.AnalysisRepository
+	jr                   repository.JobRepository
+	wr                   repository.WorkspaceRepository
+}
+
+func (uc cleanupAnalysesUsecase) Process(ctx context.Context, stagedBefore time.Time) (int, int, error) {
+	workspaces, err := uc.wr.FindAll(ctx)
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve workspaces")
+		return 0, 0, ErrUnexpected
+	}
+
+	workspaceIDs := []uuid.UUID{entity.DefaultWorkspaceID}
+	for _, workspace := range workspaces {
+		workspaceIDs = append(workspaceIDs, workspace.ID)
+	}
+
+	var committed, discarded int
+	for _, workspaceID := range workspaceIDs {
+		c, d, err := uc.process(entity.WithWorkspace(ctx, workspaceID), stagedBefore)
+		committed, discarded = committed+c, discarded+d
+		if err != nil {
+			return committed, discarded, err
+		}
+	}
+
+	return committed, discarded, nil
+}
+
+// process completes or discards the interrupted analyses on the workspace set on the context.
+func (uc cleanupAnalysesUsecase) process(ctx context.Context, stagedBefore time.Time) (int, int, error) {
+	analysisIDs, err := uc.ir.FindStagedAnalysisIDs(ctx, stagedBefore)
+	if err != nil {
+		log.WithError(err).Errorf("unable to look for staged identifiers before %v", stagedBefore)
+		return 0, 0, ErrUnexpected
+	}
+
+	jobs, err := uc.jr.FindAll(ctx)
+	if err != nil {
+		log.WithError(err).Error("unable to look for running analyses")
+		return 0, 0, ErrUnexpected
+	}
+
+	running := make(map[uuid.UUID]bool)
+	for _, job := range jobs {
+		if job.Status == entity.JobStatusRunning && !job.DateRefreshed.Before(stagedBefore) {
+			running[job.AnalysisID] = true
+		}
+	}
+
+	var committed, discarded int
+	for _, analysisID := range analysisIDs {
+		if running[analysisID] {
+			continue
+		}
+
+		_, err := uc.ar.Get(ctx, analysisID)
+		switch err {
+		case nil:
+			if err := uc.ir.Commit(ctx, analysisID); err != nil {
+				log.WithError(err).Errorf("unable to commit identifiers for analysis ID: %v", analysisID)
+				return committed, discarded, ErrUnexpected
+			}
+			if err := uc.indexAnalysisUsecase.Process(ctx, analysisID); err != nil {
+				log.WithError(err).Warnf("unable to index identifiers for analysis ID: %v", analysisID)
+			}
+			committed++
+		case repository.ErrAnalysisNoResults:
+			err := uc.ir.DeleteAllByAnalysisID(ctx, analysisID)
+			if err != nil && err != repository.ErrIdentifierNoResults {
+				log.WithError(err).Errorf("unable to discard identifiers for analysis ID: %v", analysisID)
+				return committed, discarded, ErrUnexpected
+			}
+			discarded++
+		default:
+			log.WithError(err).Errorf("unable to retrieve analysis with ID: %v", analysisID)
+			return committed, discarded, ErrUnexpected
+		}
+	}
+
+	return committed, discarded, nil
+}
+
+// keepStaged marks the given analysis as in progress, and keeps refreshing the mark until the returned function is
+// called, so the cleanup process doesn't discard its identifiers while they are still being stored. The function
+// must be called before committing or removing the identifiers, since it waits until the mark is no longer refreshed.
+func keepStaged(ctx context.Context, ir repository.IdentifierRepository, analysisID uuid.UUID) (func(), error) {
+	if err := ir.Stage(ctx, analysisID); err != nil {
+		log.WithError(err).Errorf("unable to stage analysis ID: %v", analysisID)
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(stagingHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := ir.Stage(ctx, analysisID); err != nil {
+					log.WithError(err).Warnf("unable to refresh staged analysis ID: %v", analysisID)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}, nil
+}
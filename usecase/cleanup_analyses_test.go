@@ -0,0 +1,162 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCleanupAnalysesUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewCleanupAnalysesUsecase(nil, nil, nil, nil, nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_WhenErrorRetrievingWorkspaces_ShouldReturnError(t *testing.T) {
+	workspaceRepositoryMock := workspaceRepositoryMock{
+		getErr: repository.ErrWorkspaceUnexpected,
+	}
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock{}, identifierRepositoryMock{}, analysisRepositoryMock{},
+		newJobRepositoryMock(), workspaceRepositoryMock)
+	_, _, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_WhenErrorFindingStagedIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		err: repository.ErrIdentifierUnexpected,
+	}
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock{}, identifierRepositoryMock, analysisRepositoryMock{},
+		newJobRepositoryMock(), workspaceRepositoryMock{})
+	committed, discarded, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+	assert.Equal(t, 0, committed)
+	assert.Equal(t, 0, discarded)
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_WhenErrorRetrievingAnalysis_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		staged: []uuid.UUID{uuid.New()},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisUnexpected,
+	}
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock{}, identifierRepositoryMock, analysisRepositoryMock,
+		newJobRepositoryMock(), workspaceRepositoryMock{})
+	_, _, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_WhenErrorCommitting_ShouldReturnError(t *testing.T) {
+	analysisID := uuid.New()
+	identifierRepositoryMock := identifierRepositoryMock{
+		staged:    []uuid.UUID{analysisID},
+		commitErr: repository.ErrIdentifierUnexpected,
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		analyses: map[uuid.UUID]entity.AnalysisResults{analysisID: {ID: analysisID}},
+	}
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock{}, identifierRepositoryMock, analysisRepositoryMock,
+		newJobRepositoryMock(), workspaceRepositoryMock{})
+	_, _, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_ShouldCommitCompletedAndDiscardIncompleteAnalyses(t *testing.T) {
+	completed, incomplete := uuid.New(), uuid.New()
+	committedIDs, deletedIDs, indexedIDs := make([]uuid.UUID, 0), make([]uuid.UUID, 0), make([]uuid.UUID, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		staged:    []uuid.UUID{completed, incomplete},
+		committed: &committedIDs,
+		deleted:   &deletedIDs,
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		analyses: map[uuid.UUID]entity.AnalysisResults{completed: {ID: completed}},
+	}
+
+	indexAnalysisUsecaseMock := indexAnalysisUsecaseMock{
+		indexed: &indexedIDs,
+	}
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock, identifierRepositoryMock, analysisRepositoryMock,
+		newJobRepositoryMock(), workspaceRepositoryMock{})
+	committed, discarded, err := uc.Process(context.TODO(), time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, committed)
+	assert.Equal(t, 1, discarded)
+	assert.Equal(t, []uuid.UUID{completed}, committedIDs)
+	assert.Equal(t, []uuid.UUID{incomplete}, deletedIDs)
+	assert.Equal(t, []uuid.UUID{completed}, indexedIDs)
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_ShouldCleanupEveryWorkspace(t *testing.T) {
+	incomplete := uuid.New()
+	identifierRepositoryMock := identifierRepositoryMock{
+		staged: []uuid.UUID{incomplete},
+	}
+	analysisRepositoryMock := analysisRepositoryMock{
+		analyses: map[uuid.UUID]entity.AnalysisResults{},
+	}
+	workspaceRepositoryMock := workspaceRepositoryMock{
+		workspaces: []entity.Workspace{{ID: uuid.New(), Name: "backend"}},
+	}
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock{}, identifierRepositoryMock, analysisRepositoryMock,
+		newJobRepositoryMock(), workspaceRepositoryMock)
+	committed, discarded, err := uc.Process(context.TODO(), time.Now())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, committed)
+	assert.Equal(t, 2, discarded)
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_WhenErrorFindingJobs_ShouldReturnError(t *testing.T) {
+	jobRepositoryMock := newJobRepositoryMock()
+	jobRepositoryMock.findErr = repository.ErrJobUnexpected
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock{}, identifierRepositoryMock{}, analysisRepositoryMock{},
+		jobRepositoryMock, workspaceRepositoryMock{})
+	_, _, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCleanupAnalysesUsecase_ShouldSkipAnalysesHeldByRunningJobs(t *testing.T) {
+	stagedBefore := time.Now()
+	live, interrupted := uuid.New(), uuid.New()
+	deletedIDs := make([]uuid.UUID, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		staged:  []uuid.UUID{live, interrupted},
+		deleted: &deletedIDs,
+	}
+	jobRepositoryMock := newJobRepositoryMock(
+		entity.AnalysisJob{ID: uuid.New(), AnalysisID: live, Status: entity.JobStatusRunning,
+			DateRefreshed: stagedBefore.Add(time.Second)},
+		entity.AnalysisJob{ID: uuid.New(), AnalysisID: interrupted, Status: entity.JobStatusRunning,
+			DateRefreshed: stagedBefore.Add(-time.Hour)},
+	)
+
+	uc := usecase.NewCleanupAnalysesUsecase(indexAnalysisUsecaseMock{}, identifierRepositoryMock,
+		analysisRepositoryMock{analyses: map[uuid.UUID]entity.AnalysisResults{}}, jobRepositoryMock, workspaceRepositoryMock{})
+	committed, discarded, err := uc.Process(context.TODO(), stagedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, committed)
+	assert.Equal(t, 1, discarded)
+	assert.Equal(t, []uuid.UUID{interrupted}, deletedIDs)
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// RootAPIKeyName is the name given to the key configured when starting the server, which can't be
+	// used by a managed key.
+	RootAPIKeyName = "root"
+	// apiKeyPrefix identifies the keys generated by the server.
+	apiKeyPrefix = "srk_"
+	// apiKeyVisibleLength is the number of characters of the key kept as its prefix.
+	apiKeyVisibleLength = 12
+)
+
+var (
+	// ErrAPIKeyNotFound indicates that the requested API key is not accessible.
+	ErrAPIKeyNotFound = errors.New("unable to retrieve requested API key")
+	// ErrPreviousAPIKeyFound indicates there is an existing API key with the same name.
+	ErrPreviousAPIKeyFound = errors.New("existing previous API key with the same name")
+	// ErrInvalidAPIKeyScope indicates that a scope for the API key is not supported.
+	ErrInvalidAPIKeyScope = errors.New("invalid scope for API key")
+)
+
+// CreateAPIKeyUsecase defines the contract for the use case related to the creation of an API key.
+type CreateAPIKeyUsecase interface {
+	// Process generates and stores a new API key with the given name and scopes, as a member of the current
+	// workspace. Besides the stored APIKey, it returns the key itself, which can't be retrieved again.
+	Process(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error)
+}
+
+// NewCreateAPIKeyUsecase initializes a new CreateAPIKeyUsecase instance.
+func NewCreateAPIKeyUsecase(kr repository.APIKeyRepository) CreateAPIKeyUsecase {
+	return createAPIKeyUsecase{
+		apiKeyRepository: kr,
+	}
+}
+
+type createAPIKeyUsecase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func (uc createAPIKeyUsecase) Process(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error) {
+	if len(scopes) == 0 {
+		return entity.APIKey{}, "", ErrInvalidAPIKeyScope
+	}
+	for _, scope := range scopes {
+		if !entity.ValidScope(scope) {
+			return entity.APIKey{}, "", ErrInvalidAPIKeyScope
+		}
+	}
+
+	name = strings.TrimSpace(name)
+	if name == RootAPIKeyName {
+		return entity.APIKey{}, "", ErrPreviousAPIKeyFound
+	}
+
+	keys, err := uc.apiKeyRepository.FindAll(ctx)
+	switch err {
+	case nil, repository.ErrAPIKeyNoResults:
+		// do nothing
+	default:
+		log.WithError(err).Errorf("unable to check for previous API key %s", name)
+		return entity.APIKey{}, "", ErrUnexpected
+	}
+	for _, key := range keys {
+		if key.Name == name {
+			return entity.APIKey{}, "", ErrPreviousAPIKeyFound
+		}
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		log.WithError(err).Errorf("unable to generate API key %s", name)
+		return entity.APIKey{}, "", ErrUnexpected
+	}
+	plain := apiKeyPrefix + hex.EncodeToString(secret)
+
+	key := entity.APIKey{
+		WorkspaceID: entity.WorkspaceFrom(ctx),
+		Name:        name,
+		Prefix:      plain[:apiKeyVisibleLength],
+		Hash:        entity.HashAPIKey(plain),
+		Scopes:      scopes,
+		CreatedAt:   time.Now(),
+	}
+	key.ID, _ = uuid.NewUUID()
+
+	if err := uc.apiKeyRepository.Add(ctx, key); err != nil {
+		log.WithError(err).Errorf("unable to save API key %s", name)
+		return entity.APIKey{}, "", ErrUnexpected
+	}
+
+	return key, plain, nil
+}
@@ -0,0 +1,106 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCreateAPIKeyUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewCreateAPIKeyUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnCreateAPIKeyUsecase_WhenInvalidScopes_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+	}{
+		{"no_scopes", []string{}},
+		{"unknown_scope", []string{entity.ScopeRead, "owner"}},
+	}
+
+	uc := usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, plain, err := uc.Process(context.TODO(), "ci", tt.scopes)
+
+			assert.Empty(t, key)
+			assert.Empty(t, plain)
+			assert.EqualError(t, err, usecase.ErrInvalidAPIKeyScope.Error())
+		})
+	}
+}
+
+func TestProcess_OnCreateAPIKeyUsecase_WhenExistingName_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyName string
+	}{
+		{"stored_key", "ci"},
+		{"root_key", usecase.RootAPIKeyName},
+	}
+
+	uc := usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{
+		keys: []entity.APIKey{{Name: "ci"}},
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, plain, err := uc.Process(context.TODO(), tt.keyName, []string{entity.ScopeRead})
+
+			assert.Empty(t, key)
+			assert.Empty(t, plain)
+			assert.EqualError(t, err, usecase.ErrPreviousAPIKeyFound.Error())
+		})
+	}
+}
+
+func TestProcess_OnCreateAPIKeyUsecase_WhenErrorSavingKey_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{addErr: repository.ErrAPIKeyUnexpected})
+
+	key, plain, err := uc.Process(context.TODO(), "ci", []string{entity.ScopeRead})
+
+	assert.Empty(t, key)
+	assert.Empty(t, plain)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCreateAPIKeyUsecase_ShouldStoreHashedKey(t *testing.T) {
+	var added entity.APIKey
+	uc := usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{
+		getErr: repository.ErrAPIKeyNoResults,
+		added:  &added,
+	})
+
+	key, plain, err := uc.Process(context.TODO(), " ci ", []string{entity.ScopeAnalyze})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, "srk_"))
+	assert.Equal(t, 52, len(plain))
+	assert.NotEmpty(t, key.ID)
+	assert.Equal(t, "ci", key.Name)
+	assert.Equal(t, plain[:12], key.Prefix)
+	assert.Equal(t, entity.HashAPIKey(plain), key.Hash)
+	assert.Equal(t, []string{entity.ScopeAnalyze}, key.Scopes)
+	assert.False(t, key.CreatedAt.IsZero())
+	assert.Equal(t, key, added)
+}
+
+func TestProcess_OnCreateAPIKeyUsecase_ShouldAddKeyToCurrentWorkspace(t *testing.T) {
+	workspaceID := uuid.New()
+	uc := usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{})
+
+	key, _, err := uc.Process(entity.WithWorkspace(context.TODO(), workspaceID), "ci", []string{entity.ScopeRead})
+
+	assert.NoError(t, err)
+	assert.Equal(t, workspaceID, key.WorkspaceID)
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrDictionaryNotFound indicates that the requested dictionary is not accessible.
+	ErrDictionaryNotFound = errors.New("unable to retrieve requested Dictionary")
+	// ErrPreviousDictionaryFound indicates there is an existing dictionary for the same scope.
+	ErrPreviousDictionaryFound = errors.New("existing previous dictionary for scope")
+	// ErrInvalidDictionaryScope indicates that the scope or the project reference for the dictionary are not valid.
+	ErrInvalidDictionaryScope = errors.New("invalid scope for dictionary")
+)
+
+// CreateDictionaryUsecase defines the contract for the use case related to the creation of
+// an abbreviation dictionary.
+type CreateDictionaryUsecase interface {
+	// Process handles the process to store a new dictionary for the organization or for a project.
+	Process(ctx context.Context, dict entity.Dictionary) (entity.Dictionary, error)
+}
+
+// NewCreateDictionaryUsecase initializes a new CreateDictionaryUsecase instance.
+func NewCreateDictionaryUsecase(dr repository.DictionaryRepository) CreateDictionaryUsecase {
+	return createDictionaryUsecase{
+		dictionaryRepository: dr,
+	}
+}
+
+type createDictionaryUsecase struct {
+	dictionaryRepository repository.DictionaryRepository
+}
+
+func (uc createDictionaryUsecase) Process(ctx context.Context, dict entity.Dictionary) (entity.Dictionary, error) {
+	switch {
+	case dict.Scope == entity.DictionaryScopeOrganization:
+		dict.ProjectRef = ""
+	case dict.Scope == entity.DictionaryScopeProject && dict.ProjectRef != "":
+		// do nothing
+	default:
+		return entity.Dictionary{}, ErrInvalidDictionaryScope
+	}
+
+	_, err := uc.dictionaryRepository.GetByScope(ctx, dict.Scope, dict.ProjectRef)
+	switch err {
+	case repository.ErrDictionaryNoResults:
+		// do nothing
+	case nil:
+		return entity.Dictionary{}, ErrPreviousDictionaryFound
+	default:
+		log.WithError(err).Errorf("unable to check for previous %s dictionary %s", dict.Scope, dict.ProjectRef)
+		return entity.Dictionary{}, ErrUnexpected
+	}
+
+	dict.ID, _ = uuid.NewUUID()
+	dict.Entries = normalizeEntries(dict.Entries)
+	dict.CreatedAt = time.Now()
+	dict.UpdatedAt = dict.CreatedAt
+
+	if err := uc.dictionaryRepository.Add(ctx, dict); err != nil {
+		log.WithError(err).Errorf("unable to save %s dictionary %s", dict.Scope, dict.ProjectRef)
+		return entity.Dictionary{}, ErrUnexpected
+	}
+
+	return dict, nil
+}
+
+// normalizeEntries lowercases and trims every abbreviation and its expansions, discarding
+// the empty values.
+func normalizeEntries(entries map[string][]string) map[string][]string {
+	normalized := make(map[string][]string, len(entries))
+	for abbreviation, expansions := range entries {
+		abbreviation = strings.ToLower(strings.TrimSpace(abbreviation))
+		if abbreviation == "" {
+			continue
+		}
+
+		values := make([]string, 0, len(expansions))
+		for _, expansion := range expansions {
+			expansion = strings.ToLower(strings.TrimSpace(expansion))
+			if expansion == "" {
+				continue
+			}
+			values = append(values, expansion)
+		}
+
+		if len(values) > 0 {
+			normalized[abbreviation] = values
+		}
+	}
+
+	return normalized
+}
@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCreateDictionaryUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewCreateDictionaryUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnCreateDictionaryUsecase_WhenInvalidScope_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name string
+		dict entity.Dictionary
+	}{
+		{"unknown_scope", entity.Dictionary{Scope: "team"}},
+		{"project_without_reference", entity.Dictionary{Scope: entity.DictionaryScopeProject}},
+	}
+
+	uc := usecase.NewCreateDictionaryUsecase(dictionaryRepositoryMock{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dict, err := uc.Process(context.TODO(), tt.dict)
+
+			assert.Empty(t, dict)
+			assert.EqualError(t, err, usecase.ErrInvalidDictionaryScope.Error())
+		})
+	}
+}
+
+func TestProcess_OnCreateDictionaryUsecase_WhenExistingDictionary_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		dictionaries: map[string]entity.Dictionary{
+			entity.DictionaryScopeOrganization: {Scope: entity.DictionaryScopeOrganization},
+		},
+	}
+	uc := usecase.NewCreateDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), entity.Dictionary{Scope: entity.DictionaryScopeOrganization})
+
+	assert.Empty(t, dict)
+	assert.EqualError(t, err, usecase.ErrPreviousDictionaryFound.Error())
+}
+
+func TestProcess_OnCreateDictionaryUsecase_WhenErrorCheckingPreviousDictionary_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		getErr: repository.ErrDictionaryUnexpected,
+	}
+	uc := usecase.NewCreateDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), entity.Dictionary{Scope: entity.DictionaryScopeOrganization})
+
+	assert.Empty(t, dict)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCreateDictionaryUsecase_WhenErrorSavingDictionary_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		addErr: repository.ErrDictionaryUnexpected,
+	}
+	uc := usecase.NewCreateDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), entity.Dictionary{Scope: entity.DictionaryScopeOrganization})
+
+	assert.Empty(t, dict)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCreateDictionaryUsecase_ShouldReturnDictionary(t *testing.T) {
+	uc := usecase.NewCreateDictionaryUsecase(dictionaryRepositoryMock{})
+
+	dict, err := uc.Process(context.TODO(), entity.Dictionary{
+		Scope:      entity.DictionaryScopeProject,
+		ProjectRef: "eroatta/src-reader",
+		Entries: map[string][]string{
+			" TXN ": {"Transaction"},
+			"svc":   {"service", " "},
+			"mw":    {},
+			"":      {"empty"},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, dict.ID)
+	assert.Equal(t, entity.DictionaryScopeProject, dict.Scope)
+	assert.Equal(t, "eroatta/src-reader", dict.ProjectRef)
+	assert.Equal(t, map[string][]string{
+		"txn": {"transaction"},
+		"svc": {"service"},
+	}, dict.Entries)
+	assert.False(t, dict.CreatedAt.IsZero())
+	assert.Equal(t, dict.CreatedAt, dict.UpdatedAt)
+}
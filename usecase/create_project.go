@@ -23,21 +23,25 @@ var (
 	ErrUnableToCloneSourceCode = errors.New("Unable to access or clone the source code")
 	// ErrUnableToSaveProject indicates that an error occurred while trying to save the imported entity.Project.
 	ErrUnableToSaveProject = errors.New("Unable to store project changes")
+	// ErrInvalidFileFilter indicates that any of the include or exclude patterns isn't a valid glob.
+	ErrInvalidFileFilter = errors.New("Invalid include or exclude pattern")
 )
 
 // CreateProjectUsecase handles the creation and import or a Project.
 type CreateProjectUsecase interface {
-	// Process retrieves a project from GitHub and imports it.
-	Process(ctx context.Context, projectRef string) (entity.Project, error)
+	// Process retrieves a project from GitHub and imports it, analyzing only the files selected by the filter.
+	Process(ctx context.Context, projectRef string, filter entity.FileFilter) (entity.Project, error)
 }
 
-// NewCreateProjectUsecase initializes a new CreateProjectUsecase instance.
+// NewCreateProjectUsecase initializes a new CreateProjectUsecase instance. Repositories larger than the given size,
+// in kilobytes, are rejected with ErrProjectTooLarge before cloning them. A zero size doesn't reject any repository.
 func NewCreateProjectUsecase(pr repository.ProjectRepository, mr repository.MetadataRepository,
-	scr repository.SourceCodeRepository) CreateProjectUsecase {
+	scr repository.SourceCodeRepository, maxRepositorySize int) CreateProjectUsecase {
 	return createProjectUsecase{
 		projectRepository:    pr,
 		metadataRepository:   mr,
 		sourceCodeRepository: scr,
+		maxRepositorySize:    maxRepositorySize,
 	}
 }
 
@@ -45,10 +49,19 @@ type createProjectUsecase struct {
 	projectRepository    repository.ProjectRepository
 	metadataRepository   repository.MetadataRepository
 	sourceCodeRepository repository.SourceCodeRepository
+	maxRepositorySize    int
 }
 
 // Process executes the pipeline to import a project from GitHub. It returns the project information.
-func (uc createProjectUsecase) Process(ctx context.Context, projectRef string) (entity.Project, error) {
+// If the project was previously imported, it's returned as it is, keeping its filter.
+func (uc createProjectUsecase) Process(ctx context.Context, projectRef string,
+	filter entity.FileFilter) (entity.Project, error) {
+	for _, pattern := range append(append([]string{}, filter.Include...), filter.Exclude...) {
+		if !entity.ValidGlob(pattern) {
+			return entity.Project{}, ErrInvalidFileFilter
+		}
+	}
+
 	// check if not previously imported
 	project, err := uc.projectRepository.GetByReference(ctx, projectRef)
 	switch err {
@@ -68,6 +81,11 @@ func (uc createProjectUsecase) Process(ctx context.Context, projectRef string) (
 		return entity.Project{}, ErrUnableToRetrieveMetadata
 	}
 
+	if exceeds(int(metadata.Size), uc.maxRepositorySize) {
+		log.Warnf("import rejected for project %s, with %d KB", projectRef, metadata.Size)
+		return entity.Project{}, ErrProjectTooLarge
+	}
+
 	projectID, _ := uuid.NewUUID()
 	project = entity.Project{
 		ID:        projectID,
@@ -75,6 +93,7 @@ func (uc createProjectUsecase) Process(ctx context.Context, projectRef string) (
 		CreatedAt: time.Now(),
 		Metadata:  metadata,
 		Status:    "in_process",
+		Filter:    filter,
 	}
 
 	// clone the source code
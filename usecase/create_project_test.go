@@ -11,7 +11,7 @@ import (
 )
 
 func TestNewCreateProjectUsecase_ShouldReturnNewInstance(t *testing.T) {
-	uc := usecase.NewCreateProjectUsecase(nil, nil, nil)
+	uc := usecase.NewCreateProjectUsecase(nil, nil, nil, 0)
 
 	assert.NotNil(t, uc)
 }
@@ -34,9 +34,9 @@ func TestProcess_OnCreateProjectUsecase_ShouldReturnImportResults(t *testing.T)
 		},
 		err: nil,
 	}
-	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, scrMock)
+	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, scrMock, 0)
 
-	project, err := uc.Process(context.TODO(), "test/mytest")
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{})
 
 	assert.NoError(t, err)
 	assert.Equal(t, "done", project.Status)
@@ -54,9 +54,9 @@ func TestProcess_OnCreateProjectUsecase_WhenAlreadyImportedProject_ShouldImportR
 		},
 		getErr: nil,
 	}
-	uc := usecase.NewCreateProjectUsecase(prMock, nil, nil)
+	uc := usecase.NewCreateProjectUsecase(prMock, nil, nil, 0)
 
-	project, err := uc.Process(context.TODO(), "test/mytest")
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{})
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, project)
@@ -69,9 +69,9 @@ func TestProcess_OnCreateProjectUsecase_WhenUnableToCheckExistingProject_ShouldR
 		project: entity.Project{},
 		getErr:  repository.ErrProjectUnexpected,
 	}
-	uc := usecase.NewCreateProjectUsecase(prMock, nil, nil)
+	uc := usecase.NewCreateProjectUsecase(prMock, nil, nil, 0)
 
-	project, err := uc.Process(context.TODO(), "test/mytest")
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{})
 
 	assert.EqualError(t, err, usecase.ErrUnableToReadProject.Error())
 	assert.Empty(t, project)
@@ -84,9 +84,9 @@ func TestProcess_OnCreateProjectUsecase_WhenUnableToRetrieveMetadataFromRemoteRe
 	rprMock := metadataRepositoryMock{
 		err: repository.ErrProjectUnexpected,
 	}
-	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, nil)
+	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, nil, 0)
 
-	project, err := uc.Process(context.TODO(), "test/mytest")
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{})
 
 	assert.EqualError(t, err, usecase.ErrUnableToRetrieveMetadata.Error())
 	assert.Empty(t, project)
@@ -105,14 +105,34 @@ func TestProcess_OnCreateProjectUsecase_WhenUnableToCloneSourceCode_ShouldReturn
 	scrMock := sourceCodeRepositoryMock{
 		err: repository.ErrProjectUnexpected,
 	}
-	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, scrMock)
+	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, scrMock, 0)
 
-	project, err := uc.Process(context.TODO(), "test/mytest")
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{})
 
 	assert.EqualError(t, err, usecase.ErrUnableToCloneSourceCode.Error())
 	assert.Empty(t, project)
 }
 
+func TestProcess_OnCreateProjectUsecase_WhenRepositoryTooLarge_ShouldReturnErrorWithoutCloning(t *testing.T) {
+	prMock := projectRepositoryMock{
+		getErr: repository.ErrProjectNoResults,
+	}
+	rprMock := metadataRepositoryMock{
+		metadata: entity.Metadata{
+			Fullname: "test/mytest",
+			Owner:    "test",
+			Size:     2048,
+		},
+	}
+	// a nil source code repository fails the test if the project is cloned
+	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, nil, 1024)
+
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{})
+
+	assert.EqualError(t, err, usecase.ErrProjectTooLarge.Error())
+	assert.Empty(t, project)
+}
+
 func TestProcess_OnCreateProjectUsecase_WhenUnableToSaveImportedProject_ShouldReturnError(t *testing.T) {
 	prMock := projectRepositoryMock{
 		getErr: repository.ErrProjectNoResults,
@@ -127,10 +147,45 @@ func TestProcess_OnCreateProjectUsecase_WhenUnableToSaveImportedProject_ShouldRe
 	scrMock := sourceCodeRepositoryMock{
 		err: nil,
 	}
-	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, scrMock)
+	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, scrMock, 0)
 
-	project, err := uc.Process(context.TODO(), "test/mytest")
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{})
 
 	assert.EqualError(t, err, usecase.ErrUnableToSaveProject.Error())
 	assert.Empty(t, project)
 }
+
+func TestProcess_OnCreateProjectUsecase_WhenInvalidFilter_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewCreateProjectUsecase(nil, nil, nil, 0)
+
+	project, err := uc.Process(context.TODO(), "test/mytest", entity.FileFilter{Exclude: []string{"[a-z*.go"}})
+
+	assert.EqualError(t, err, usecase.ErrInvalidFileFilter.Error())
+	assert.Empty(t, project)
+}
+
+func TestProcess_OnCreateProjectUsecase_WhenFilterProvided_ShouldImportProjectWithFilter(t *testing.T) {
+	prMock := projectRepositoryMock{
+		getErr: repository.ErrProjectNoResults,
+	}
+	rprMock := metadataRepositoryMock{
+		metadata: entity.Metadata{
+			Fullname: "test/mytest",
+			Owner:    "test",
+		},
+	}
+	scrMock := sourceCodeRepositoryMock{
+		sourceCode: entity.SourceCode{
+			Hash:     "asdasda",
+			Location: "/tmp/src-code-location",
+			Files:    []string{"myfile.go", "tools/tools.go"},
+		},
+	}
+	uc := usecase.NewCreateProjectUsecase(prMock, rprMock, scrMock, 0)
+
+	filter := entity.FileFilter{Include: []string{"**/*.go"}, Exclude: []string{"tools/**"}}
+	project, err := uc.Process(context.TODO(), "test/mytest", filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filter, project.Filter)
+}
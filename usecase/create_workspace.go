@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// WorkspaceAdminAPIKeyName is the name given to the first key created for a workspace.
+const WorkspaceAdminAPIKeyName = "admin"
+
+var (
+	// ErrWorkspaceNotAllowed indicates that workspaces can only be managed from the default workspace.
+	ErrWorkspaceNotAllowed = errors.New("workspaces can only be managed from the default workspace")
+	// ErrWorkspaceNotFound indicates that the requested workspace doesn't exist.
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+	// ErrPreviousWorkspaceFound indicates there is an existing workspace with the same name.
+	ErrPreviousWorkspaceFound = errors.New("existing previous workspace with the same name")
+)
+
+// CreateWorkspaceUsecase defines the contract for the use case related to the creation of a workspace.
+type CreateWorkspaceUsecase interface {
+	// Process stores a new workspace with the given name and a random secret for its push notifications, along with
+	// its first API key, granted every scope on the new workspace. Besides the stored Workspace and APIKey, it
+	// returns the key itself, which can't be retrieved again.
+	Process(ctx context.Context, name string) (entity.Workspace, entity.APIKey, string, error)
+}
+
+// NewCreateWorkspaceUsecase initializes a new CreateWorkspaceUsecase instance.
+func NewCreateWorkspaceUsecase(wr repository.WorkspaceRepository, kuc CreateAPIKeyUsecase) CreateWorkspaceUsecase {
+	return createWorkspaceUsecase{
+		workspaceRepository: wr,
+		createAPIKeyUsecase: kuc,
+	}
+}
+
+type createWorkspaceUsecase struct {
+	workspaceRepository repository.WorkspaceRepository
+	createAPIKeyUsecase CreateAPIKeyUsecase
+}
+
+func (uc createWorkspaceUsecase) Process(ctx context.Context, name string) (entity.Workspace, entity.APIKey, string, error) {
+	if entity.WorkspaceFrom(ctx) != entity.DefaultWorkspaceID {
+		return entity.Workspace{}, entity.APIKey{}, "", ErrWorkspaceNotAllowed
+	}
+
+	name = strings.TrimSpace(name)
+	workspaces, err := uc.workspaceRepository.FindAll(ctx)
+	switch err {
+	case nil, repository.ErrWorkspaceNoResults:
+		// do nothing
+	default:
+		log.WithError(err).Errorf("unable to check for previous workspace %s", name)
+		return entity.Workspace{}, entity.APIKey{}, "", ErrUnexpected
+	}
+	for _, workspace := range workspaces {
+		if workspace.Name == name {
+			return entity.Workspace{}, entity.APIKey{}, "", ErrPreviousWorkspaceFound
+		}
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		log.WithError(err).Errorf("unable to generate the webhook secret for workspace %s", name)
+		return entity.Workspace{}, entity.APIKey{}, "", ErrUnexpected
+	}
+
+	workspace := entity.Workspace{
+		Name:          name,
+		WebhookSecret: hex.EncodeToString(secret),
+		CreatedAt:     time.Now(),
+	}
+	workspace.ID, _ = uuid.NewUUID()
+
+	if err := uc.workspaceRepository.Add(ctx, workspace); err != nil {
+		log.WithError(err).Errorf("unable to save workspace %s", name)
+		return entity.Workspace{}, entity.APIKey{}, "", ErrUnexpected
+	}
+
+	key, plain, err := uc.createAPIKeyUsecase.Process(entity.WithWorkspace(ctx, workspace.ID), WorkspaceAdminAPIKeyName,
+		[]string{entity.ScopeRead, entity.ScopeAnalyze, entity.ScopeAdmin})
+	if err != nil {
+		log.WithError(err).Errorf("unable to create the first API key for workspace %s", name)
+		return entity.Workspace{}, entity.APIKey{}, "", ErrUnexpected
+	}
+
+	return workspace, key, plain, nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCreateWorkspaceUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewCreateWorkspaceUsecase(nil, nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnCreateWorkspaceUsecase_WhenNotOnDefaultWorkspace_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewCreateWorkspaceUsecase(workspaceRepositoryMock{}, usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{}))
+
+	workspace, key, plain, err := uc.Process(entity.WithWorkspace(context.TODO(), uuid.New()), "backend")
+
+	assert.Empty(t, workspace)
+	assert.Empty(t, key)
+	assert.Empty(t, plain)
+	assert.EqualError(t, err, usecase.ErrWorkspaceNotAllowed.Error())
+}
+
+func TestProcess_OnCreateWorkspaceUsecase_WhenErrorCheckingPreviousWorkspaces_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewCreateWorkspaceUsecase(workspaceRepositoryMock{getErr: repository.ErrWorkspaceUnexpected},
+		usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{}))
+
+	_, _, _, err := uc.Process(context.TODO(), "backend")
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCreateWorkspaceUsecase_WhenExistingName_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewCreateWorkspaceUsecase(workspaceRepositoryMock{
+		workspaces: []entity.Workspace{{ID: uuid.New(), Name: "backend"}},
+	}, usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{}))
+
+	_, _, _, err := uc.Process(context.TODO(), " backend ")
+
+	assert.EqualError(t, err, usecase.ErrPreviousWorkspaceFound.Error())
+}
+
+func TestProcess_OnCreateWorkspaceUsecase_WhenErrorSavingWorkspace_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewCreateWorkspaceUsecase(workspaceRepositoryMock{addErr: repository.ErrWorkspaceUnexpected},
+		usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{}))
+
+	_, _, _, err := uc.Process(context.TODO(), "backend")
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCreateWorkspaceUsecase_WhenErrorCreatingKey_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewCreateWorkspaceUsecase(workspaceRepositoryMock{},
+		usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{addErr: repository.ErrAPIKeyUnexpected}))
+
+	_, _, _, err := uc.Process(context.TODO(), "backend")
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnCreateWorkspaceUsecase_ShouldStoreWorkspaceAndAdminKey(t *testing.T) {
+	var added entity.Workspace
+	var addedKey entity.APIKey
+	uc := usecase.NewCreateWorkspaceUsecase(workspaceRepositoryMock{added: &added},
+		usecase.NewCreateAPIKeyUsecase(apiKeyRepositoryMock{added: &addedKey}))
+
+	workspace, key, plain, err := uc.Process(context.TODO(), " backend ")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, workspace.ID)
+	assert.Equal(t, "backend", workspace.Name)
+	assert.Len(t, workspace.WebhookSecret, 48)
+	assert.False(t, workspace.CreatedAt.IsZero())
+	assert.Equal(t, workspace, added)
+	assert.NotEmpty(t, plain)
+	assert.Equal(t, workspace.ID, key.WorkspaceID)
+	assert.Equal(t, usecase.WorkspaceAdminAPIKeyName, key.Name)
+	assert.True(t, key.Allows(entity.ScopeAdmin))
+	assert.Equal(t, key, addedKey)
+}
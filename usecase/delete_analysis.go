@@ -19,10 +19,12 @@ type DeleteAnalysisUsecase interface {
 }
 
 // NewDeleteAnalysisUsecase create initializes a DeleteAnalysisUsecase instance.
-func NewDeleteAnalysisUsecase(duc DeleteInsightsUsecase, ir repository.IdentifierRepository, ar repository.AnalysisRepository) DeleteAnalysisUsecase {
+func NewDeleteAnalysisUsecase(duc DeleteInsightsUsecase, ir repository.IdentifierRepository, sr repository.SearchRepository,
+	ar repository.AnalysisRepository) DeleteAnalysisUsecase {
 	return deleteAnalysisUsecase{
 		deleteInsightsUsecase: duc,
 		ir:                    ir,
+		sr:                    sr,
 		ar:                    ar,
 	}
 }
@@ -30,6 +32,7 @@ func NewDeleteAnalysisUsecase(duc DeleteInsightsUsecase, ir repository.Identifie
 type deleteAnalysisUsecase struct {
 	deleteInsightsUsecase DeleteInsightsUsecase
 	ir                    repository.IdentifierRepository
+	sr                    repository.SearchRepository
 	ar                    repository.AnalysisRepository
 }
 
@@ -46,6 +49,12 @@ func (uc deleteAnalysisUsecase) Process(ctx context.Context, analysisID uuid.UUI
 		return ErrUnexpected
 	}
 
+	err = uc.sr.DeleteAllByAnalysisID(ctx, analysisID)
+	if err == repository.ErrSearchUnexpected {
+		log.Errorf("unable to delete indexed identifiers for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
 	err = uc.ar.Delete(ctx, analysisID)
 	switch err {
 	case nil:
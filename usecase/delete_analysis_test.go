@@ -11,7 +11,7 @@ import (
 )
 
 func TestNewDeleteAnalysisUsecase_ShouldReturnNewInstance(t *testing.T) {
-	uc := usecase.NewDeleteAnalysisUsecase(nil, nil, nil)
+	uc := usecase.NewDeleteAnalysisUsecase(nil, nil, nil, nil)
 
 	assert.Empty(t, uc)
 }
@@ -20,7 +20,7 @@ func TestProcess_OnDeleteAnalysisUsecase_WhenErrorDeletingInsights_ShouldReturnE
 	duc := deleteInsightsUsecaseMock{
 		err: usecase.ErrUnexpected,
 	}
-	uc := usecase.NewDeleteAnalysisUsecase(duc, nil, nil)
+	uc := usecase.NewDeleteAnalysisUsecase(duc, nil, nil, nil)
 
 	analysisID, _ := uuid.NewUUID()
 	err := uc.Process(context.TODO(), analysisID)
@@ -35,7 +35,25 @@ func TestProcess_OnDeleteAnalysisUsecase_WhenErrorDeletingIdentifiers_ShouldRetu
 	ir := identifierRepositoryMock{
 		delErr: repository.ErrIdentifierUnexpected,
 	}
-	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, nil)
+	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, nil, nil)
+
+	analysisID, _ := uuid.NewUUID()
+	err := uc.Process(context.TODO(), analysisID)
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnDeleteAnalysisUsecase_WhenErrorDeletingIndexedIdentifiers_ShouldReturnError(t *testing.T) {
+	duc := deleteInsightsUsecaseMock{
+		err: nil,
+	}
+	ir := identifierRepositoryMock{
+		delErr: nil,
+	}
+	sr := searchRepositoryMock{
+		delErr: repository.ErrSearchUnexpected,
+	}
+	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, sr, nil)
 
 	analysisID, _ := uuid.NewUUID()
 	err := uc.Process(context.TODO(), analysisID)
@@ -53,7 +71,7 @@ func TestProcess_OnDeleteAnalysisUsecase_WhenErrorDeletingAnalysis_ShouldReturnE
 	ar := analysisRepositoryMock{
 		delErr: repository.ErrAnalysisUnexpected,
 	}
-	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, ar)
+	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, searchRepositoryMock{}, ar)
 
 	analysisID, _ := uuid.NewUUID()
 	err := uc.Process(context.TODO(), analysisID)
@@ -71,7 +89,7 @@ func TestProcess_OnDeleteAnalysisUsecase_WhenEverythingDeleted_ShouldReturnNoErr
 	ar := analysisRepositoryMock{
 		delErr: nil,
 	}
-	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, ar)
+	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, searchRepositoryMock{}, ar)
 
 	analysisID, _ := uuid.NewUUID()
 	err := uc.Process(context.TODO(), analysisID)
@@ -89,7 +107,7 @@ func TestProcess_OnDeleteAnalysisUsecase_WhenNoInsights_ShouldReturnNoError(t *t
 	ar := analysisRepositoryMock{
 		delErr: nil,
 	}
-	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, ar)
+	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, searchRepositoryMock{}, ar)
 
 	analysisID, _ := uuid.NewUUID()
 	err := uc.Process(context.TODO(), analysisID)
@@ -107,7 +125,7 @@ func TestProcess_OnDeleteAnalysisUsecase_WhenIdentifiersNotFound_ShouldReturnNoE
 	ar := analysisRepositoryMock{
 		delErr: nil,
 	}
-	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, ar)
+	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, searchRepositoryMock{}, ar)
 
 	analysisID, _ := uuid.NewUUID()
 	err := uc.Process(context.TODO(), analysisID)
@@ -125,7 +143,7 @@ func TestProcess_OnDeleteAnalysisUsecase_WhenAnalysisNotFound_ShouldReturnError(
 	ar := analysisRepositoryMock{
 		delErr: repository.ErrAnalysisNoResults,
 	}
-	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, ar)
+	uc := usecase.NewDeleteAnalysisUsecase(duc, ir, searchRepositoryMock{}, ar)
 
 	analysisID, _ := uuid.NewUUID()
 	err := uc.Process(context.TODO(), analysisID)
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeleteAPIKeyUsecase defines the contract for the use case related to revoke an API key.
+type DeleteAPIKeyUsecase interface {
+	// Process handles the process to delete the API key matching the given ID, so it can't be used anymore.
+	Process(ctx context.Context, ID uuid.UUID) error
+}
+
+// NewDeleteAPIKeyUsecase initializes a new DeleteAPIKeyUsecase instance.
+func NewDeleteAPIKeyUsecase(kr repository.APIKeyRepository) DeleteAPIKeyUsecase {
+	return deleteAPIKeyUsecase{
+		apiKeyRepository: kr,
+	}
+}
+
+type deleteAPIKeyUsecase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func (uc deleteAPIKeyUsecase) Process(ctx context.Context, ID uuid.UUID) error {
+	err := uc.apiKeyRepository.Delete(ctx, ID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrAPIKeyNoResults:
+		return ErrAPIKeyNotFound
+	default:
+		log.WithError(err).Errorf("unable to delete API key with ID: %v", ID)
+		return ErrUnexpected
+	}
+
+	return nil
+}
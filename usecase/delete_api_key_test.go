@@ -0,0 +1,41 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeleteAPIKeyUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewDeleteAPIKeyUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnDeleteAPIKeyUsecase_WhenNoExistingKey_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewDeleteAPIKeyUsecase(apiKeyRepositoryMock{delErr: repository.ErrAPIKeyNoResults})
+
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrAPIKeyNotFound.Error())
+}
+
+func TestProcess_OnDeleteAPIKeyUsecase_WhenErrorDeletingKey_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewDeleteAPIKeyUsecase(apiKeyRepositoryMock{delErr: repository.ErrAPIKeyUnexpected})
+
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnDeleteAPIKeyUsecase_ShouldDeleteKey(t *testing.T) {
+	uc := usecase.NewDeleteAPIKeyUsecase(apiKeyRepositoryMock{})
+
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.NoError(t, err)
+}
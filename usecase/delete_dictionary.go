@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeleteDictionaryUsecase defines the contract for the use case related to delete an abbreviation dictionary.
+type DeleteDictionaryUsecase interface {
+	// Process handles the process to delete the Dictionary matching the given ID.
+	Process(ctx context.Context, ID uuid.UUID) error
+}
+
+// NewDeleteDictionaryUsecase initializes a new DeleteDictionaryUsecase instance.
+func NewDeleteDictionaryUsecase(dr repository.DictionaryRepository) DeleteDictionaryUsecase {
+	return deleteDictionaryUsecase{
+		dictionaryRepository: dr,
+	}
+}
+
+type deleteDictionaryUsecase struct {
+	dictionaryRepository repository.DictionaryRepository
+}
+
+func (uc deleteDictionaryUsecase) Process(ctx context.Context, ID uuid.UUID) error {
+	err := uc.dictionaryRepository.Delete(ctx, ID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrDictionaryNoResults:
+		return ErrDictionaryNotFound
+	default:
+		log.WithError(err).Errorf("unable to delete dictionary with ID: %v", ID)
+		return ErrUnexpected
+	}
+
+	return nil
+}
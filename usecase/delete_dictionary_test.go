@@ -0,0 +1,41 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeleteDictionaryUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewDeleteDictionaryUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnDeleteDictionaryUsecase_WhenNoExistingDictionary_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewDeleteDictionaryUsecase(dictionaryRepositoryMock{delErr: repository.ErrDictionaryNoResults})
+
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrDictionaryNotFound.Error())
+}
+
+func TestProcess_OnDeleteDictionaryUsecase_WhenErrorDeletingDictionary_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewDeleteDictionaryUsecase(dictionaryRepositoryMock{delErr: repository.ErrDictionaryUnexpected})
+
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnDeleteDictionaryUsecase_ShouldDeleteDictionary(t *testing.T) {
+	uc := usecase.NewDeleteDictionaryUsecase(dictionaryRepositoryMock{})
+
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.NoError(t, err)
+}
@@ -138,9 +138,13 @@ func TestProcess_OnDeleteProjectUsecase_WhenEverythingDeleted_ShouldReturnProjec
 }
 
 type deleteAnalysisUsecaseMock struct {
-	err error
+	deleted *[]uuid.UUID
+	err     error
 }
 
 func (m deleteAnalysisUsecaseMock) Process(ctx context.Context, analysisID uuid.UUID) error {
+	if m.deleted != nil {
+		*m.deleted = append(*m.deleted, analysisID)
+	}
 	return m.err
 }
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrUnsupportedFormat indicates that the identifiers can't be exported using the requested format.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// ExportAnalysisUsecase defines the contract for the use case that exports the identifiers extracted by an analysis.
+type ExportAnalysisUsecase interface {
+	// Process writes every identifier related to the given analysis on the destination, using the given format.
+	// Nothing is written if the analysis can't be found or the format is not supported.
+	Process(ctx context.Context, analysisID uuid.UUID, format string, w io.Writer) error
+}
+
+// NewExportAnalysisUsecase initializes a new ExportAnalysisUsecase instance.
+func NewExportAnalysisUsecase(ir repository.IdentifierRepository, ar repository.AnalysisRepository,
+	wf repository.IdentifierWriterFactory) ExportAnalysisUsecase {
+	return exportAnalysisUsecase{
+		identifierRepository: ir,
+		analysisRepository:   ar,
+		writerFactory:        wf,
+	}
+}
+
+type exportAnalysisUsecase struct {
+	identifierRepository repository.IdentifierRepository
+	analysisRepository   repository.AnalysisRepository
+	writerFactory        repository.IdentifierWriterFactory
+}
+
+func (uc exportAnalysisUsecase) Process(ctx context.Context, analysisID uuid.UUID, format string, w io.Writer) error {
+	_, err := uc.analysisRepository.Get(ctx, analysisID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrAnalysisNoResults:
+		return ErrAnalysisNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve analysis with ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	writer, err := uc.writerFactory.Make(format, w)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrExportUnsupportedFormat:
+		return ErrUnsupportedFormat
+	default:
+		log.WithError(err).Errorf("unable to create %s writer for analysis ID: %v", format, analysisID)
+		return ErrUnexpected
+	}
+
+	it, err := uc.identifierRepository.IterateByAnalysisID(ctx, analysisID)
+	if err != nil {
+		log.WithError(err).Errorf("unable to retrieve identifiers for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+	defer it.Close(ctx)
+
+	for it.Next(ctx) {
+		if err := writer.Write(it.Identifier()); err != nil {
+			log.WithError(err).Errorf("unable to export identifiers for analysis ID: %v", analysisID)
+			return ErrUnexpected
+		}
+	}
+	if err := it.Err(); err != nil {
+		log.WithError(err).Errorf("unable to iterate identifiers for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	if err := writer.Close(); err != nil {
+		log.WithError(err).Errorf("unable to complete export for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExportAnalysisUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewExportAnalysisUsecase(nil, nil, nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnExportAnalysisUsecase_WhenNoAnalysis_ShouldReturnError(t *testing.T) {
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	uc := usecase.NewExportAnalysisUsecase(identifierRepositoryMock{}, analysisRepositoryMock, identifierWriterFactoryMock{})
+	err := uc.Process(context.TODO(), uuid.New(), entity.ExportFormatCSV, &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrAnalysisNotFound.Error())
+}
+
+func TestProcess_OnExportAnalysisUsecase_WhenErrorRetrievingAnalysis_ShouldReturnError(t *testing.T) {
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisUnexpected,
+	}
+
+	uc := usecase.NewExportAnalysisUsecase(identifierRepositoryMock{}, analysisRepositoryMock, identifierWriterFactoryMock{})
+	err := uc.Process(context.TODO(), uuid.New(), entity.ExportFormatCSV, &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnExportAnalysisUsecase_WhenUnsupportedFormat_ShouldReturnError(t *testing.T) {
+	identifierWriterFactoryMock := identifierWriterFactoryMock{
+		err: repository.ErrExportUnsupportedFormat,
+	}
+
+	uc := usecase.NewExportAnalysisUsecase(identifierRepositoryMock{}, analysisRepositoryMock{}, identifierWriterFactoryMock)
+	err := uc.Process(context.TODO(), uuid.New(), "xlsx", &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnsupportedFormat.Error())
+}
+
+func TestProcess_OnExportAnalysisUsecase_WhenErrorRetrievingIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents:  []entity.Identifier{{Name: "main"}},
+		iterErr: repository.ErrIdentifierUnexpected,
+	}
+
+	uc := usecase.NewExportAnalysisUsecase(identifierRepositoryMock, analysisRepositoryMock{}, identifierWriterFactoryMock{})
+	err := uc.Process(context.TODO(), uuid.New(), entity.ExportFormatCSV, &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnExportAnalysisUsecase_WhenErrorWritingIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{{Name: "main"}},
+	}
+	identifierWriterFactoryMock := identifierWriterFactoryMock{
+		writeErr: repository.ErrExportUnexpected,
+	}
+
+	uc := usecase.NewExportAnalysisUsecase(identifierRepositoryMock, analysisRepositoryMock{}, identifierWriterFactoryMock)
+	err := uc.Process(context.TODO(), uuid.New(), entity.ExportFormatCSV, &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnExportAnalysisUsecase_ShouldWriteEveryIdentifier(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{{Name: "main"}, {Name: "parseCfg"}},
+	}
+
+	var b bytes.Buffer
+	uc := usecase.NewExportAnalysisUsecase(identifierRepositoryMock, analysisRepositoryMock{}, identifierWriterFactoryMock{})
+	err := uc.Process(context.TODO(), uuid.New(), entity.ExportFormatCSV, &b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "csv:main\ncsv:parseCfg\nclosed\n", b.String())
+}
+
+type identifierWriterFactoryMock struct {
+	err      error
+	writeErr error
+}
+
+func (f identifierWriterFactoryMock) Make(format string, w io.Writer) (repository.IdentifierWriter, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return identifierWriterMock{format: format, w: w, err: f.writeErr}, nil
+}
+
+type identifierWriterMock struct {
+	format string
+	w      io.Writer
+	err    error
+}
+
+func (m identifierWriterMock) Write(ident entity.Identifier) error {
+	if m.err != nil {
+		return m.err
+	}
+	_, err := io.WriteString(m.w, m.format+":"+ident.Name+"\n")
+	return err
+}
+
+func (m identifierWriterMock) Close() error {
+	_, err := io.WriteString(m.w, "closed\n")
+	return err
+}
@@ -52,8 +52,8 @@ func (uc gainInsightsUsecase) Process(ctx context.Context, analysisID uuid.UUID)
 		return []entity.Insight{}, ErrUnableToGainInsights
 	}
 
-	// grab each identifier
-	identifiers, err := uc.identr.FindAllByAnalysisID(ctx, analysisID)
+	// grab each identifier, one at a time
+	it, err := uc.identr.IterateByAnalysisID(ctx, analysisID)
 	switch err {
 	case nil:
 		// do nothing
@@ -63,23 +63,38 @@ func (uc gainInsightsUsecase) Process(ctx context.Context, analysisID uuid.UUID)
 		log.WithError(err).Errorf("unable to retrieve identifiers for analysis ID: %v", analysisID)
 		return []entity.Insight{}, ErrUnableToReadIdentifiers
 	}
+	defer it.Close(ctx)
 
-	byPackages := make(map[string]entity.Insight)
-	for _, ident := range identifiers {
-		metrics, ok := byPackages[ident.FullPackageName()]
+	// identifiers from test files are summarized apart from the rest of the package
+	byPackages := make(map[insightKey]entity.Insight)
+	for it.Next(ctx) {
+		ident := it.Identifier()
+		key := insightKey{pkg: ident.FullPackageName(), test: ident.IsTest()}
+		metrics, ok := byPackages[key]
 		if !ok {
 			metrics = entity.Insight{
 				ProjectRef:      ident.ProjectRef,
 				AnalysisID:      analysisID,
 				Package:         ident.Package,
+				Language:        ident.Language,
 				TotalSplits:     make(map[string]int),
 				TotalExpansions: make(map[string]int),
 				Files:           make(map[string]struct{}),
+				Test:            key.test,
 			}
 		}
 
 		metrics.Include(ident)
-		byPackages[ident.FullPackageName()] = metrics
+		byPackages[key] = metrics
+	}
+
+	if err := it.Err(); err != nil {
+		log.WithError(err).Errorf("unable to read identifiers for analysis ID: %v", analysisID)
+		return []entity.Insight{}, ErrUnableToReadIdentifiers
+	}
+
+	if len(byPackages) == 0 {
+		return []entity.Insight{}, ErrIdentifiersNotFound
 	}
 
 	insights = asArray(byPackages)
@@ -92,7 +107,13 @@ func (uc gainInsightsUsecase) Process(ctx context.Context, analysisID uuid.UUID)
 	return insights, nil
 }
 
-func asArray(input map[string]entity.Insight) []entity.Insight {
+// insightKey identifies the Insight summarizing the identifiers of a package, on a scope.
+type insightKey struct {
+	pkg  string
+	test bool
+}
+
+func asArray(input map[insightKey]entity.Insight) []entity.Insight {
 	insights := make([]entity.Insight, 0)
 	for _, v := range input {
 		insights = append(insights, v)
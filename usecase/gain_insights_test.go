@@ -83,6 +83,43 @@ func TestProcess_OnGainInsightsUsecase_WhenErrorReadingIdentifiers_ShouldReturnE
 	assert.Empty(t, insights)
 }
 
+func TestProcess_OnGainInsightsUsecase_WhenErrorIteratingIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{
+			{Package: "main", File: "main.go", Name: "main"},
+		},
+		iterErr: repository.ErrIdentifierUnexpected,
+	}
+	insightsRepositoryMock := insightsRepositoryMock{
+		getErr: repository.ErrInsightNoResults,
+	}
+
+	uc := usecase.NewGainInsightsUsecase(identifierRepositoryMock, insightsRepositoryMock)
+
+	analysisID, _ := uuid.NewUUID()
+	insights, err := uc.Process(context.TODO(), analysisID)
+
+	assert.EqualError(t, err, usecase.ErrUnableToReadIdentifiers.Error())
+	assert.Empty(t, insights)
+}
+
+func TestProcess_OnGainInsightsUsecase_WhenEmptyIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{},
+	}
+	insightsRepositoryMock := insightsRepositoryMock{
+		getErr: repository.ErrInsightNoResults,
+	}
+
+	uc := usecase.NewGainInsightsUsecase(identifierRepositoryMock, insightsRepositoryMock)
+
+	analysisID, _ := uuid.NewUUID()
+	insights, err := uc.Process(context.TODO(), analysisID)
+
+	assert.EqualError(t, err, usecase.ErrIdentifiersNotFound.Error())
+	assert.Empty(t, insights)
+}
+
 func TestProcess_OnGainInsightsUsecase_WhenFailingToSaveInsights_ShouldReturnError(t *testing.T) {
 	identifierRepositoryMock := identifierRepositoryMock{
 		idents: []entity.Identifier{
@@ -248,6 +285,64 @@ func TestProcess_OnGainInsightsUsecase_ShouldReturnInsightsByPackage(t *testing.
 			Files: map[string]struct{}{
 				"main_test.go": {},
 			},
+			Test: true,
+		},
+	}, insights)
+}
+
+func TestProcess_OnGainInsightsUsecase_WhenIdentifiersFromTestFiles_ShouldReturnInsightsByScope(t *testing.T) {
+	analysisID, _ := uuid.NewUUID()
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{
+			{
+				Package:       "main",
+				File:          "main.go",
+				Name:          "main",
+				Normalization: entity.Normalization{Score: 1.0},
+				AnalysisID:    analysisID,
+				ProjectRef:    "test/mytest",
+			},
+			{
+				Package:       "main",
+				File:          "main_test.go",
+				Name:          "TestMain",
+				Normalization: entity.Normalization{Score: 0.5},
+				AnalysisID:    analysisID,
+				ProjectRef:    "test/mytest",
+			},
+		},
+	}
+	insightsRepositoryMock := insightsRepositoryMock{
+		getErr: repository.ErrInsightNoResults,
+	}
+
+	uc := usecase.NewGainInsightsUsecase(identifierRepositoryMock, insightsRepositoryMock)
+
+	insights, err := uc.Process(context.TODO(), analysisID)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []entity.Insight{
+		{
+			ProjectRef:       "test/mytest",
+			AnalysisID:       analysisID,
+			Package:          "main",
+			TotalIdentifiers: 1,
+			TotalSplits:      map[string]int{},
+			TotalExpansions:  map[string]int{},
+			TotalWeight:      0.7,
+			Files:            map[string]struct{}{"main.go": {}},
+		},
+		{
+			ProjectRef:       "test/mytest",
+			AnalysisID:       analysisID,
+			Package:          "main",
+			TotalIdentifiers: 1,
+			TotalExported:    1,
+			TotalSplits:      map[string]int{},
+			TotalExpansions:  map[string]int{},
+			TotalWeight:      0.5,
+			Files:            map[string]struct{}{"main_test.go": {}},
+			Test:             true,
 		},
 	}, insights)
 }
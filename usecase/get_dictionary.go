@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDictionaryUsecase handles the retrieval of an abbreviation Dictionary by its ID.
+type GetDictionaryUsecase interface {
+	// Process retrieves a dictionary and its entries.
+	Process(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error)
+}
+
+// NewGetDictionaryUsecase initializes a new GetDictionaryUsecase instance.
+func NewGetDictionaryUsecase(dr repository.DictionaryRepository) GetDictionaryUsecase {
+	return getDictionaryUsecase{
+		dictionaryRepository: dr,
+	}
+}
+
+type getDictionaryUsecase struct {
+	dictionaryRepository repository.DictionaryRepository
+}
+
+func (uc getDictionaryUsecase) Process(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error) {
+	dict, err := uc.dictionaryRepository.Get(ctx, ID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrDictionaryNoResults:
+		return entity.Dictionary{}, ErrDictionaryNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve dictionary with ID %v", ID)
+		return entity.Dictionary{}, ErrUnexpected
+	}
+
+	return dict, nil
+}
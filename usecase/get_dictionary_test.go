@@ -0,0 +1,60 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGetDictionaryUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewGetDictionaryUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnGetDictionaryUsecase_WhenNoExistingDictionary_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		getErr: repository.ErrDictionaryNoResults,
+	}
+	uc := usecase.NewGetDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), uuid.New())
+
+	assert.Empty(t, dict)
+	assert.EqualError(t, err, usecase.ErrDictionaryNotFound.Error())
+}
+
+func TestProcess_OnGetDictionaryUsecase_WhenErrorRetrievingDictionary_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		getErr: repository.ErrDictionaryUnexpected,
+	}
+	uc := usecase.NewGetDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), uuid.New())
+
+	assert.Empty(t, dict)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnGetDictionaryUsecase_WhenExistingDictionary_ShouldReturnDictionary(t *testing.T) {
+	id := uuid.New()
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		dictionary: entity.Dictionary{
+			ID:      id,
+			Scope:   entity.DictionaryScopeOrganization,
+			Entries: map[string][]string{"cfg": {"config"}},
+		},
+	}
+	uc := usecase.NewGetDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, dict.ID)
+	assert.Equal(t, []string{"config"}, dict.Entries["cfg"])
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetFindingsUsecase handles the retrieval of the naming-convention findings for an analysis.
+type GetFindingsUsecase interface {
+	// Process retrieves the identifiers with findings for the given analysis, optionally
+	// filtered by rule and severity. Only the findings matching the filters are kept.
+	Process(ctx context.Context, analysisID uuid.UUID, rule string, severity entity.Severity) ([]entity.Identifier, error)
+}
+
+// NewGetFindingsUsecase initializes a new GetFindingsUsecase instance.
+func NewGetFindingsUsecase(ir repository.IdentifierRepository) GetFindingsUsecase {
+	return getFindingsUsecase{
+		identifierRepository: ir,
+	}
+}
+
+type getFindingsUsecase struct {
+	identifierRepository repository.IdentifierRepository
+}
+
+func (uc getFindingsUsecase) Process(ctx context.Context, analysisID uuid.UUID, rule string, severity entity.Severity) ([]entity.Identifier, error) {
+	it, err := uc.identifierRepository.IterateByAnalysisID(ctx, analysisID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrIdentifierNoResults:
+		return []entity.Identifier{}, ErrIdentifiersNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve identifiers for analysis ID: %v", analysisID)
+		return []entity.Identifier{}, ErrUnexpected
+	}
+	defer it.Close(ctx)
+
+	// only the identifiers with findings are kept in memory
+	var total int
+	withFindings := make([]entity.Identifier, 0)
+	for it.Next(ctx) {
+		total++
+		ident := it.Identifier()
+		findings := make([]entity.Finding, 0)
+		for _, finding := range ident.Findings {
+			if rule != "" && finding.Rule != rule {
+				continue
+			}
+
+			if severity != "" && finding.Severity != severity {
+				continue
+			}
+
+			findings = append(findings, finding)
+		}
+
+		if len(findings) == 0 {
+			continue
+		}
+
+		ident.Findings = findings
+		withFindings = append(withFindings, ident)
+	}
+
+	if err := it.Err(); err != nil {
+		log.WithError(err).Errorf("unable to read identifiers for analysis ID: %v", analysisID)
+		return []entity.Identifier{}, ErrUnexpected
+	}
+
+	if total == 0 {
+		return []entity.Identifier{}, ErrIdentifiersNotFound
+	}
+
+	return withFindings, nil
+}
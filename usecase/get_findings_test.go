@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGetFindingsUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewGetFindingsUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnGetFindingsUsecase_WhenNoIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		err: repository.ErrIdentifierNoResults,
+	}
+
+	uc := usecase.NewGetFindingsUsecase(identifierRepositoryMock)
+
+	identifiers, err := uc.Process(context.TODO(), uuid.New(), "", "")
+
+	assert.Empty(t, identifiers)
+	assert.EqualError(t, err, usecase.ErrIdentifiersNotFound.Error())
+}
+
+func TestProcess_OnGetFindingsUsecase_WhenErrorRetrievingIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		err: repository.ErrIdentifierUnexpected,
+	}
+
+	uc := usecase.NewGetFindingsUsecase(identifierRepositoryMock)
+
+	identifiers, err := uc.Process(context.TODO(), uuid.New(), "", "")
+
+	assert.Empty(t, identifiers)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnGetFindingsUsecase_WhenFilteringFindings_ShouldReturnMatchingIdentifiers(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{
+			{Name: "parseFile"},
+			{
+				Name: "max_url",
+				Findings: []entity.Finding{
+					{Rule: "snake-case", Severity: entity.SeverityWarning},
+					{Rule: "initialisms", Severity: entity.SeverityError},
+				},
+			},
+			{
+				Name: "GetName",
+				Findings: []entity.Finding{
+					{Rule: "getter-prefix", Severity: entity.SeverityInfo},
+				},
+			},
+		},
+	}
+
+	uc := usecase.NewGetFindingsUsecase(identifierRepositoryMock)
+
+	all, err := uc.Process(context.TODO(), uuid.New(), "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(all))
+
+	byRule, err := uc.Process(context.TODO(), uuid.New(), "snake-case", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(byRule))
+	assert.Equal(t, []entity.Finding{{Rule: "snake-case", Severity: entity.SeverityWarning}}, byRule[0].Findings)
+
+	bySeverity, err := uc.Process(context.TODO(), uuid.New(), "", entity.SeverityInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(bySeverity))
+	assert.Equal(t, "GetName", bySeverity[0].Name)
+}
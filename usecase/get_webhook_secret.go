@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetWebhookSecretUsecase defines the contract for the use case retrieving the secret that signs the push
+// notifications for a workspace.
+type GetWebhookSecretUsecase interface {
+	// Process retrieves the webhook secret of the workspace set on the context. The default workspace uses the
+	// configured secret, while any other workspace uses the secret generated when it was created.
+	Process(ctx context.Context) (string, error)
+}
+
+// NewGetWebhookSecretUsecase initializes a new GetWebhookSecretUsecase instance, using the given secret for the
+// default workspace.
+func NewGetWebhookSecretUsecase(wr repository.WorkspaceRepository, defaultSecret string) GetWebhookSecretUsecase {
+	return getWebhookSecretUsecase{
+		workspaceRepository: wr,
+		defaultSecret:       defaultSecret,
+	}
+}
+
+type getWebhookSecretUsecase struct {
+	workspaceRepository repository.WorkspaceRepository
+	defaultSecret       string
+}
+
+func (uc getWebhookSecretUsecase) Process(ctx context.Context) (string, error) {
+	workspaceID := entity.WorkspaceFrom(ctx)
+	if workspaceID == entity.DefaultWorkspaceID {
+		return uc.defaultSecret, nil
+	}
+
+	workspace, err := uc.workspaceRepository.Get(ctx, workspaceID)
+	switch err {
+	case nil:
+		return workspace.WebhookSecret, nil
+	case repository.ErrWorkspaceNoResults:
+		return "", ErrWorkspaceNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve workspace %v", workspaceID)
+		return "", ErrUnexpected
+	}
+}
@@ -0,0 +1,55 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGetWebhookSecretUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewGetWebhookSecretUsecase(nil, "")
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnGetWebhookSecretUsecase_OnDefaultWorkspace_ShouldReturnConfiguredSecret(t *testing.T) {
+	uc := usecase.NewGetWebhookSecretUsecase(workspaceRepositoryMock{getErr: repository.ErrWorkspaceUnexpected}, "s3cr3t")
+
+	secret, err := uc.Process(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", secret)
+}
+
+func TestProcess_OnGetWebhookSecretUsecase_WhenWorkspaceNotFound_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewGetWebhookSecretUsecase(workspaceRepositoryMock{}, "s3cr3t")
+
+	secret, err := uc.Process(entity.WithWorkspace(context.TODO(), uuid.New()))
+
+	assert.EqualError(t, err, usecase.ErrWorkspaceNotFound.Error())
+	assert.Empty(t, secret)
+}
+
+func TestProcess_OnGetWebhookSecretUsecase_WhenErrorRetrievingWorkspace_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewGetWebhookSecretUsecase(workspaceRepositoryMock{getErr: repository.ErrWorkspaceUnexpected}, "s3cr3t")
+
+	secret, err := uc.Process(entity.WithWorkspace(context.TODO(), uuid.New()))
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+	assert.Empty(t, secret)
+}
+
+func TestProcess_OnGetWebhookSecretUsecase_ShouldReturnWorkspaceSecret(t *testing.T) {
+	workspace := entity.Workspace{ID: uuid.New(), Name: "backend", WebhookSecret: "backend-s3cr3t"}
+	uc := usecase.NewGetWebhookSecretUsecase(workspaceRepositoryMock{workspaces: []entity.Workspace{workspace}}, "s3cr3t")
+
+	secret, err := uc.Process(entity.WithWorkspace(context.TODO(), workspace.ID))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "backend-s3cr3t", secret)
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrPushIgnored indicates that the push doesn't update the default branch of the project, so there's
+	// nothing to analyze.
+	ErrPushIgnored = errors.New("push doesn't update the default branch")
+	// ErrTooManyPendingAnalyses indicates that the queue of pending analyses is full.
+	ErrTooManyPendingAnalyses = errors.New("too many pending analyses")
+)
+
+// HandlePushUsecase defines the contract for the use case related to analyze projects again, after new
+// commits are pushed to their repositories.
+type HandlePushUsecase interface {
+	// Process enqueues the analysis of the Project matching the pushed repository.
+	Process(ctx context.Context, event entity.PushEvent) (entity.Project, error)
+	// Work processes the enqueued analyses, one at a time, until the context is done. The analysis in progress
+	// is completed before returning, while the pending ones are recorded as jobs to be recovered.
+	Work(ctx context.Context)
+}
+
+// NewHandlePushUsecase initializes a new HandlePushUsecase instance, holding up to the given number of pending
+// analyses.
+func NewHandlePushUsecase(pr repository.ProjectRepository, ruc ReanalyzeProjectUsecase, jr repository.JobRepository,
+	size int) HandlePushUsecase {
+	return &handlePushUsecase{
+		pr:      pr,
+		ruc:     ruc,
+		jr:      jr,
+		queue:   make(chan uuid.UUID, size),
+		pending: make(map[uuid.UUID]pendingPush),
+	}
+}
+
+type handlePushUsecase struct {
+	pr    repository.ProjectRepository
+	ruc   ReanalyzeProjectUsecase
+	jr    repository.JobRepository
+	queue chan uuid.UUID
+	mu    sync.Mutex
+	// pending holds the latest pushed commit for each enqueued project, so consecutive pushes trigger
+	// a single analysis.
+	pending map[uuid.UUID]pendingPush
+	// stopped indicates that the enqueued analyses are no longer processed, so new pushes are recorded as jobs.
+	stopped bool
+}
+
+// pendingPush holds the latest pushed commit for an enqueued project, along with the workspace it belongs to.
+type pendingPush struct {
+	commit    string
+	workspace uuid.UUID
+}
+
+// Process maps the pushed repository to an existing Project on the current workspace and enqueues its analysis,
+// unless the push doesn't update the default branch. Once the enqueued analyses are no longer processed, the
+// analysis is recorded as a job instead.
+func (uc *handlePushUsecase) Process(ctx context.Context, event entity.PushEvent) (entity.Project, error) {
+	project, err := uc.pr.GetByReference(ctx, event.Repository)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrProjectNoResults:
+		return entity.Project{}, ErrProjectNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve project %s", event.Repository)
+		return entity.Project{}, ErrUnexpected
+	}
+
+	if event.Deleted() || event.Branch() != project.Metadata.DefaultBranch {
+		return project, ErrPushIgnored
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	push := pendingPush{commit: event.Commit, workspace: entity.WorkspaceFrom(ctx)}
+	if uc.stopped {
+		if err := uc.record(project.ID, push); err != nil {
+			return project, ErrUnexpected
+		}
+		return project, nil
+	}
+
+	if _, ok := uc.pending[project.ID]; ok {
+		uc.pending[project.ID] = push
+		return project, nil
+	}
+
+	select {
+	case uc.queue <- project.ID:
+		uc.pending[project.ID] = push
+		metrics.PushQueueDepth.Set(float64(len(uc.pending)))
+	default:
+		log.Warnf("unable to enqueue analysis for project %s at %s", project.Reference, event.Commit)
+		return project, ErrTooManyPendingAnalyses
+	}
+
+	return project, nil
+}
+
+// Work analyzes the enqueued projects at their latest pushed commit, on the workspace each project belongs to.
+// Analyses don't inherit the cancellation of the context, so a shutdown waits for the one in progress, while the
+// pending ones are recorded as jobs.
+func (uc *handlePushUsecase) Work(ctx context.Context) {
+	for {
+		// no pending analysis is started once the context is done, even if both are ready
+		if ctx.Err() != nil {
+			uc.save()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			uc.save()
+			return
+		case projectID := <-uc.queue:
+			uc.mu.Lock()
+			push := uc.pending[projectID]
+			delete(uc.pending, projectID)
+			metrics.PushQueueDepth.Set(float64(len(uc.pending)))
+			uc.mu.Unlock()
+
+			hash := push.commit
+			analysis, err := uc.ruc.Process(entity.WithWorkspace(context.Background(), push.workspace), projectID, hash)
+			switch err {
+			case nil:
+				log.Infof("analysis %v completed for project %v at %s", analysis.ID, projectID, hash)
+			case ErrPreviousAnalysisFound:
+				log.Infof("project %v already analyzed at %s", projectID, hash)
+			default:
+				log.WithError(err).Errorf("unable to analyze project %v at %s", projectID, hash)
+			}
+		}
+	}
+}
+
+// save records each pending analysis as a job, and stops processing the enqueued analyses.
+func (uc *handlePushUsecase) save() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.stopped = true
+	for projectID, push := range uc.pending {
+		if err := uc.record(projectID, push); err != nil {
+			continue
+		}
+		delete(uc.pending, projectID)
+	}
+	metrics.PushQueueDepth.Set(float64(len(uc.pending)))
+
+	if len(uc.pending) > 0 {
+		log.Warnf("%d pending analyses dropped", len(uc.pending))
+	}
+}
+
+// record adds a running job that was never refreshed for the analysis of the project at the pushed commit, so any
+// instance recovers it.
+func (uc *handlePushUsecase) record(projectID uuid.UUID, push pendingPush) error {
+	job := entity.AnalysisJob{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		AnalysisID:  uuid.New(),
+		Commit:      push.commit,
+		Status:      entity.JobStatusRunning,
+		DateStarted: time.Now(),
+	}
+	if err := uc.jr.Add(entity.WithWorkspace(context.Background(), push.workspace), job); err != nil {
+		log.WithError(err).Errorf("unable to record the pending analysis of project %v at %s", projectID, push.commit)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHandlePushUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewHandlePushUsecase(nil, nil, nil, 1)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnHandlePushUsecase_WhenNoProjectFound_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		getErr: repository.ErrProjectNoResults,
+	}
+	uc := usecase.NewHandlePushUsecase(pr, nil, nil, 1)
+
+	project, err := uc.Process(context.TODO(), pushEvent("refs/heads/master", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"))
+
+	assert.EqualError(t, err, usecase.ErrProjectNotFound.Error())
+	assert.Empty(t, project)
+}
+
+func TestProcess_OnHandlePushUsecase_WhenFailingToRetrieveProject_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		getErr: repository.ErrProjectUnexpected,
+	}
+	uc := usecase.NewHandlePushUsecase(pr, nil, nil, 1)
+
+	project, err := uc.Process(context.TODO(), pushEvent("refs/heads/master", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"))
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+	assert.Empty(t, project)
+}
+
+func TestProcess_OnHandlePushUsecase_WhenNotUpdatingDefaultBranch_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{ID: uuid.New(), Metadata: entity.Metadata{DefaultBranch: "master"}},
+	}
+	uc := usecase.NewHandlePushUsecase(pr, nil, nil, 1)
+
+	tests := []struct {
+		name  string
+		event entity.PushEvent
+	}{
+		{"another_branch", pushEvent("refs/heads/develop", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")},
+		{"tag", pushEvent("refs/tags/master", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")},
+		{"deleted_branch", pushEvent("refs/heads/master", "0000000000000000000000000000000000000000")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := uc.Process(context.TODO(), tt.event)
+
+			assert.EqualError(t, err, usecase.ErrPushIgnored.Error())
+			assert.Equal(t, pr.project, project)
+		})
+	}
+}
+
+func TestProcess_OnHandlePushUsecase_WhenQueueIsFull_ShouldReturnError(t *testing.T) {
+	pr := &sequentialProjectRepositoryMock{
+		projects: []entity.Project{
+			{ID: uuid.New(), Metadata: entity.Metadata{DefaultBranch: "master"}},
+			{ID: uuid.New(), Metadata: entity.Metadata{DefaultBranch: "master"}},
+		},
+	}
+	uc := usecase.NewHandlePushUsecase(pr, nil, nil, 1)
+
+	_, err := uc.Process(context.TODO(), pushEvent("refs/heads/master", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"))
+	assert.NoError(t, err)
+
+	_, err = uc.Process(context.TODO(), pushEvent("refs/heads/master", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"))
+	assert.EqualError(t, err, usecase.ErrTooManyPendingAnalyses.Error())
+}
+
+func TestWork_OnHandlePushUsecase_ShouldAnalyzeLatestPushedCommit(t *testing.T) {
+	project := entity.Project{ID: uuid.New(), Metadata: entity.Metadata{DefaultBranch: "master"}}
+	pr := projectRepositoryMock{
+		project: project,
+	}
+	ruc := reanalyzeProjectUsecaseMock{
+		processed: make(chan string, 2),
+	}
+	uc := usecase.NewHandlePushUsecase(pr, ruc, nil, 1)
+
+	// consecutive pushes for the same project are analyzed once
+	_, err := uc.Process(context.TODO(), pushEvent("refs/heads/master", "asdf1234asdf"))
+	assert.NoError(t, err)
+	_, err = uc.Process(context.TODO(), pushEvent("refs/heads/master", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.PushQueueDepth))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go uc.Work(ctx)
+
+	select {
+	case processed := <-ruc.processed:
+		assert.Equal(t, project.ID.String()+"@bc9968d75e48de59f0870ffb71f5e160bbbdcf52", processed)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "project wasn't analyzed")
+	}
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.PushQueueDepth))
+
+	select {
+	case processed := <-ruc.processed:
+		assert.FailNow(t, "unexpected analysis", processed)
+	case <-time.After(50 * time.Millisecond):
+		// do nothing
+	}
+}
+
+func TestWork_OnHandlePushUsecase_ShouldAnalyzeOnPushedWorkspace(t *testing.T) {
+	project := entity.Project{ID: uuid.New(), Metadata: entity.Metadata{DefaultBranch: "master"}}
+	pr := projectRepositoryMock{
+		project: project,
+	}
+	ruc := reanalyzeProjectUsecaseMock{
+		processed:  make(chan string, 1),
+		workspaces: make(chan uuid.UUID, 1),
+	}
+	uc := usecase.NewHandlePushUsecase(pr, ruc, nil, 1)
+
+	workspaceID := uuid.New()
+	_, err := uc.Process(entity.WithWorkspace(context.TODO(), workspaceID), pushEvent("refs/heads/master", "asdf1234asdf"))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go uc.Work(ctx)
+
+	select {
+	case workspace := <-ruc.workspaces:
+		assert.Equal(t, workspaceID, workspace)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "project wasn't analyzed")
+	}
+}
+
+func TestWork_OnHandlePushUsecase_WhenContextDone_ShouldRecordPendingAnalysesAsJobs(t *testing.T) {
+	project := entity.Project{ID: uuid.New(), Metadata: entity.Metadata{DefaultBranch: "master"}}
+	pr := projectRepositoryMock{
+		project: project,
+	}
+	jr := newJobRepositoryMock()
+	uc := usecase.NewHandlePushUsecase(pr, nil, jr, 1)
+
+	_, err := uc.Process(context.TODO(), pushEvent("refs/heads/master", "asdf1234asdf"))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uc.Work(ctx)
+
+	// pushes received once the pending analyses were recorded are recorded as well
+	_, err = uc.Process(context.TODO(), pushEvent("refs/heads/master", "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(metrics.PushQueueDepth))
+	if assert.Equal(t, 2, len(jr.added)) {
+		assert.Equal(t, project.ID, jr.added[0].ProjectID)
+		assert.Equal(t, "asdf1234asdf", jr.added[0].Commit)
+		assert.Equal(t, entity.JobStatusRunning, jr.added[0].Status)
+		assert.Equal(t, 0, jr.added[0].Attempt)
+		assert.True(t, jr.added[0].DateRefreshed.IsZero())
+		assert.Equal(t, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52", jr.added[1].Commit)
+	}
+}
+
+func TestProcess_OnHandlePushUsecase_WhenStoppedAndErrorRecordingJob_ShouldReturnError(t *testing.T) {
+	project := entity.Project{ID: uuid.New(), Metadata: entity.Metadata{DefaultBranch: "master"}}
+	pr := projectRepositoryMock{
+		project: project,
+	}
+	jr := newJobRepositoryMock()
+	jr.addErr = repository.ErrJobUnexpected
+	uc := usecase.NewHandlePushUsecase(pr, nil, jr, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uc.Work(ctx)
+
+	_, err := uc.Process(context.TODO(), pushEvent("refs/heads/master", "asdf1234asdf"))
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func pushEvent(ref string, commit string) entity.PushEvent {
+	return entity.PushEvent{
+		Provider:   entity.ProviderGitHub,
+		Repository: "eroatta/test",
+		CloneURL:   "https://github.com/eroatta/test.git",
+		Ref:        ref,
+		Commit:     commit,
+	}
+}
+
+type sequentialProjectRepositoryMock struct {
+	projectRepositoryMock
+	projects []entity.Project
+}
+
+func (m *sequentialProjectRepositoryMock) GetByReference(ctx context.Context, projectRef string) (entity.Project, error) {
+	project := m.projects[0]
+	m.projects = m.projects[1:]
+	return project, nil
+}
+
+type reanalyzeProjectUsecaseMock struct {
+	processed  chan string
+	workspaces chan uuid.UUID
+}
+
+func (m reanalyzeProjectUsecaseMock) Process(ctx context.Context, projectID uuid.UUID, hash string) (entity.AnalysisResults, error) {
+	if m.workspaces != nil {
+		m.workspaces <- entity.WorkspaceFrom(ctx)
+	}
+	m.processed <- projectID.String() + "@" + hash
+	return entity.AnalysisResults{}, nil
+}
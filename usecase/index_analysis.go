@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// IndexAnalysisUsecase defines the contract for the use case that makes the identifiers of an analysis searchable.
+type IndexAnalysisUsecase interface {
+	// Process adds every identifier related to the given analysis to the search index, replacing the identifiers
+	// previously indexed for it.
+	Process(ctx context.Context, analysisID uuid.UUID) error
+}
+
+// NewIndexAnalysisUsecase initializes a new IndexAnalysisUsecase instance.
+func NewIndexAnalysisUsecase(ir repository.IdentifierRepository, sr repository.SearchRepository) IndexAnalysisUsecase {
+	return indexAnalysisUsecase{
+		identifierRepository: ir,
+		searchRepository:     sr,
+	}
+}
+
+type indexAnalysisUsecase struct {
+	identifierRepository repository.IdentifierRepository
+	searchRepository     repository.SearchRepository
+}
+
+func (uc indexAnalysisUsecase) Process(ctx context.Context, analysisID uuid.UUID) error {
+	it, err := uc.identifierRepository.IterateByAnalysisID(ctx, analysisID)
+	if err != nil {
+		log.WithError(err).Errorf("unable to retrieve identifiers for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+	defer it.Close(ctx)
+
+	if err := uc.searchRepository.ReplaceAll(ctx, analysisID, it); err != nil {
+		log.WithError(err).Errorf("unable to index identifiers for analysis ID: %v", analysisID)
+		return ErrUnexpected
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIndexAnalysisUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewIndexAnalysisUsecase(nil, nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnIndexAnalysisUsecase_WhenErrorRetrievingIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		iterErr: repository.ErrIdentifierUnexpected,
+	}
+
+	uc := usecase.NewIndexAnalysisUsecase(identifierRepositoryMock, searchRepositoryMock{})
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnIndexAnalysisUsecase_WhenErrorIndexingIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		idents: []entity.Identifier{{Name: "main"}},
+	}
+	searchRepositoryMock := searchRepositoryMock{
+		addErr: repository.ErrSearchUnexpected,
+	}
+
+	uc := usecase.NewIndexAnalysisUsecase(identifierRepositoryMock, searchRepositoryMock)
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnIndexAnalysisUsecase_ShouldIndexIdentifiersAtOnce(t *testing.T) {
+	idents := make([]entity.Identifier, 0)
+	for i := 0; i < 501; i++ {
+		idents = append(idents, entity.Identifier{Name: fmt.Sprintf("ident%d", i)})
+	}
+	added := make([]int, 0)
+
+	uc := usecase.NewIndexAnalysisUsecase(identifierRepositoryMock{idents: idents}, searchRepositoryMock{added: &added})
+	err := uc.Process(context.TODO(), uuid.New())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{501}, added)
+}
+
+type indexAnalysisUsecaseMock struct {
+	indexed *[]uuid.UUID
+	err     error
+}
+
+func (m indexAnalysisUsecaseMock) Process(ctx context.Context, analysisID uuid.UUID) error {
+	if m.indexed != nil {
+		*m.indexed = append(*m.indexed, analysisID)
+	}
+	return m.err
+}
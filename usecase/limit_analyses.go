@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrTooManyAnalyses indicates that the analyses running at the same time already reached the quota.
+	ErrTooManyAnalyses = errors.New("too many analyses running at the same time")
+	// ErrProjectTooLarge indicates that the repository size or its number of files exceed the ones accepted.
+	ErrProjectTooLarge = errors.New("project exceeds the accepted size or number of files")
+)
+
+// NewLimitedAnalyzeProjectUsecase initializes a new AnalyzeProjectUsecase that applies the given limits before
+// analyzing a project using another AnalyzeProjectUsecase. Analyses exceeding the limits are rejected instead of
+// waiting, with ErrTooManyAnalyses or ErrProjectTooLarge.
+func NewLimitedAnalyzeProjectUsecase(auc AnalyzeProjectUsecase, pr repository.ProjectRepository,
+	limits entity.AnalysisLimits) AnalyzeProjectUsecase {
+	return &limitedAnalyzeProjectUsecase{
+		analyzeProjectUsecase: auc,
+		projectRepository:     pr,
+		limits:                limits,
+		running:               make(map[uuid.UUID]int),
+	}
+}
+
+type limitedAnalyzeProjectUsecase struct {
+	analyzeProjectUsecase AnalyzeProjectUsecase
+	projectRepository     repository.ProjectRepository
+	limits                entity.AnalysisLimits
+	mu                    sync.Mutex
+	// total and running hold the number of analyses in progress, on every workspace and on each workspace.
+	total   int
+	running map[uuid.UUID]int
+}
+
+// Process analyzes the given Project with the configured pipeline, if the limits allow it.
+func (uc *limitedAnalyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
+	release, err := uc.acquire(ctx, projectID)
+	if err != nil {
+		return entity.AnalysisResults{}, err
+	}
+	defer release()
+
+	return uc.analyzeProjectUsecase.Process(ctx, projectID)
+}
+
+// ProcessWithPipeline analyzes the given Project with the given pipeline, if the limits allow it.
+func (uc *limitedAnalyzeProjectUsecase) ProcessWithPipeline(ctx context.Context, projectID uuid.UUID,
+	pipeline entity.Pipeline) (entity.AnalysisResults, error) {
+	release, err := uc.acquire(ctx, projectID)
+	if err != nil {
+		return entity.AnalysisResults{}, err
+	}
+	defer release()
+
+	return uc.analyzeProjectUsecase.ProcessWithPipeline(ctx, projectID, pipeline)
+}
+
+// acquire checks the project against the size limits and reserves a slot for its analysis on the current
+// workspace. The returned function frees the slot once the analysis completes.
+func (uc *limitedAnalyzeProjectUsecase) acquire(ctx context.Context, projectID uuid.UUID) (func(), error) {
+	project, err := uc.projectRepository.Get(ctx, projectID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrProjectNoResults:
+		return nil, ErrProjectNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve project %s", projectID.String())
+		return nil, ErrUnexpected
+	}
+
+	if exceeds(int(project.Metadata.Size), uc.limits.MaxRepositorySize) ||
+		exceeds(len(project.SourceCode.Files), uc.limits.MaxFiles) {
+		log.Warnf("analysis rejected for project %s, with %d KB and %d files", project.Reference,
+			project.Metadata.Size, len(project.SourceCode.Files))
+		return nil, ErrProjectTooLarge
+	}
+
+	workspaceID := entity.WorkspaceFrom(ctx)
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if exceeds(uc.total+1, uc.limits.MaxConcurrent) ||
+		exceeds(uc.running[workspaceID]+1, uc.limits.MaxConcurrentPerWorkspace) {
+		log.Warnf("analysis rejected for project %s, with %d analyses in progress", project.Reference, uc.total)
+		return nil, ErrTooManyAnalyses
+	}
+	uc.total++
+	uc.running[workspaceID]++
+
+	return func() {
+		uc.mu.Lock()
+		defer uc.mu.Unlock()
+		uc.total--
+		uc.running[workspaceID]--
+		if uc.running[workspaceID] == 0 {
+			delete(uc.running, workspaceID)
+		}
+	}, nil
+}
+
+// exceeds checks if the value is greater than the limit, where a zero limit means there's no limit.
+func exceeds(value int, limit int) bool {
+	return limit > 0 && value > limit
+}
@@ -0,0 +1,132 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLimitedAnalyzeProjectUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewLimitedAnalyzeProjectUsecase(nil, nil, entity.AnalysisLimits{})
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnLimitedAnalyzeProjectUsecase_WhenNoProjectFound_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		getErr: repository.ErrProjectNoResults,
+	}
+	uc := usecase.NewLimitedAnalyzeProjectUsecase(&analyzeProjectUsecaseMock{}, pr, entity.AnalysisLimits{})
+
+	analysis, err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrProjectNotFound.Error())
+	assert.Empty(t, analysis)
+}
+
+func TestProcess_OnLimitedAnalyzeProjectUsecase_WhenErrorRetrievingProject_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		getErr: repository.ErrProjectUnexpected,
+	}
+	uc := usecase.NewLimitedAnalyzeProjectUsecase(&analyzeProjectUsecaseMock{}, pr, entity.AnalysisLimits{})
+
+	analysis, err := uc.Process(context.TODO(), uuid.New())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+	assert.Empty(t, analysis)
+}
+
+func TestProcess_OnLimitedAnalyzeProjectUsecase_WhenProjectTooLarge_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits entity.AnalysisLimits
+	}{
+		{"repository_size", entity.AnalysisLimits{MaxRepositorySize: 1024}},
+		{"files", entity.AnalysisLimits{MaxFiles: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := projectRepositoryMock{
+				project: entity.Project{
+					Reference:  "eroatta/src-reader",
+					Metadata:   entity.Metadata{Size: 2048},
+					SourceCode: entity.SourceCode{Files: []string{"main.go", "cli.go", "main_test.go"}},
+				},
+			}
+			uc := usecase.NewLimitedAnalyzeProjectUsecase(&analyzeProjectUsecaseMock{}, pr, tt.limits)
+
+			analysis, err := uc.ProcessWithPipeline(context.TODO(), uuid.New(), entity.Pipeline{})
+
+			assert.EqualError(t, err, usecase.ErrProjectTooLarge.Error())
+			assert.Empty(t, analysis)
+		})
+	}
+}
+
+func TestProcess_OnLimitedAnalyzeProjectUsecase_WhenTooManyAnalyses_ShouldReturnError(t *testing.T) {
+	otherWorkspace := entity.WithWorkspace(context.TODO(), uuid.New())
+	tests := []struct {
+		name   string
+		limits entity.AnalysisLimits
+		ctx    context.Context
+		err    error
+	}{
+		{"global_quota", entity.AnalysisLimits{MaxConcurrent: 1}, otherWorkspace, usecase.ErrTooManyAnalyses},
+		{"workspace_quota", entity.AnalysisLimits{MaxConcurrentPerWorkspace: 1}, context.TODO(),
+			usecase.ErrTooManyAnalyses},
+		{"other_workspace", entity.AnalysisLimits{MaxConcurrentPerWorkspace: 1}, otherWorkspace, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auc := &analyzeProjectUsecaseMock{
+				started: make(chan struct{}),
+				release: make(chan struct{}),
+			}
+			uc := usecase.NewLimitedAnalyzeProjectUsecase(auc, projectRepositoryMock{}, tt.limits)
+
+			done := make(chan error, 1)
+			go func() {
+				_, err := uc.Process(context.TODO(), uuid.New())
+				done <- err
+			}()
+			<-auc.started
+
+			if tt.err == nil {
+				// the second analysis also starts, so both are released once it does
+				go func() {
+					<-auc.started
+					close(auc.release)
+				}()
+			} else {
+				defer close(auc.release)
+			}
+			_, err := uc.Process(tt.ctx, uuid.New())
+
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.NoError(t, <-done)
+			}
+		})
+	}
+}
+
+func TestProcess_OnLimitedAnalyzeProjectUsecase_ShouldFreeSlotOnceCompleted(t *testing.T) {
+	auc := &analyzeProjectUsecaseMock{
+		analysis: entity.AnalysisResults{ProjectName: "eroatta/src-reader"},
+	}
+	uc := usecase.NewLimitedAnalyzeProjectUsecase(auc, projectRepositoryMock{}, entity.AnalysisLimits{MaxConcurrent: 1})
+
+	for i := 0; i < 2; i++ {
+		analysis, err := uc.Process(context.TODO(), uuid.New())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "eroatta/src-reader", analysis.ProjectName)
+	}
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListAPIKeysUsecase handles the retrieval of every managed API key on the current workspace.
+type ListAPIKeysUsecase interface {
+	// Process retrieves every API key, without the keys themselves.
+	Process(ctx context.Context) ([]entity.APIKey, error)
+}
+
+// NewListAPIKeysUsecase initializes a new ListAPIKeysUsecase instance.
+func NewListAPIKeysUsecase(kr repository.APIKeyRepository) ListAPIKeysUsecase {
+	return listAPIKeysUsecase{
+		apiKeyRepository: kr,
+	}
+}
+
+type listAPIKeysUsecase struct {
+	apiKeyRepository repository.APIKeyRepository
+}
+
+func (uc listAPIKeysUsecase) Process(ctx context.Context) ([]entity.APIKey, error) {
+	keys, err := uc.apiKeyRepository.FindAll(ctx)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrAPIKeyNoResults:
+		return []entity.APIKey{}, nil
+	default:
+		log.WithError(err).Error("unable to retrieve API keys")
+		return []entity.APIKey{}, ErrUnexpected
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,46 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListAPIKeysUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewListAPIKeysUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnListAPIKeysUsecase_WhenErrorRetrievingKeys_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewListAPIKeysUsecase(apiKeyRepositoryMock{getErr: repository.ErrAPIKeyUnexpected})
+
+	keys, err := uc.Process(context.TODO())
+
+	assert.Empty(t, keys)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnListAPIKeysUsecase_WhenNoKeys_ShouldReturnEmptyResults(t *testing.T) {
+	uc := usecase.NewListAPIKeysUsecase(apiKeyRepositoryMock{getErr: repository.ErrAPIKeyNoResults})
+
+	keys, err := uc.Process(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestProcess_OnListAPIKeysUsecase_ShouldReturnKeys(t *testing.T) {
+	uc := usecase.NewListAPIKeysUsecase(apiKeyRepositoryMock{
+		keys: []entity.APIKey{{Name: "ci"}, {Name: "dashboard"}},
+	})
+
+	keys, err := uc.Process(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.APIKey{{Name: "ci"}, {Name: "dashboard"}}, keys)
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListDictionariesUsecase handles the retrieval of every abbreviation Dictionary.
+type ListDictionariesUsecase interface {
+	// Process retrieves the organization-wide and the project dictionaries.
+	Process(ctx context.Context) ([]entity.Dictionary, error)
+}
+
+// NewListDictionariesUsecase initializes a new ListDictionariesUsecase instance.
+func NewListDictionariesUsecase(dr repository.DictionaryRepository) ListDictionariesUsecase {
+	return listDictionariesUsecase{
+		dictionaryRepository: dr,
+	}
+}
+
+type listDictionariesUsecase struct {
+	dictionaryRepository repository.DictionaryRepository
+}
+
+func (uc listDictionariesUsecase) Process(ctx context.Context) ([]entity.Dictionary, error) {
+	dicts, err := uc.dictionaryRepository.FindAll(ctx)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrDictionaryNoResults:
+		return []entity.Dictionary{}, nil
+	default:
+		log.WithError(err).Error("unable to retrieve dictionaries")
+		return []entity.Dictionary{}, ErrUnexpected
+	}
+
+	return dicts, nil
+}
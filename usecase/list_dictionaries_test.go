@@ -0,0 +1,55 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListDictionariesUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewListDictionariesUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnListDictionariesUsecase_WhenErrorRetrievingDictionaries_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		getErr: repository.ErrDictionaryUnexpected,
+	}
+	uc := usecase.NewListDictionariesUsecase(dictionaryRepositoryMock)
+
+	dicts, err := uc.Process(context.TODO())
+
+	assert.Empty(t, dicts)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnListDictionariesUsecase_WhenNoDictionaries_ShouldReturnEmptyResults(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		getErr: repository.ErrDictionaryNoResults,
+	}
+	uc := usecase.NewListDictionariesUsecase(dictionaryRepositoryMock)
+
+	dicts, err := uc.Process(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Empty(t, dicts)
+}
+
+func TestProcess_OnListDictionariesUsecase_ShouldReturnDictionaries(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		dictionaries: map[string]entity.Dictionary{
+			entity.DictionaryScopeOrganization: {Scope: entity.DictionaryScopeOrganization},
+		},
+	}
+	uc := usecase.NewListDictionariesUsecase(dictionaryRepositoryMock)
+
+	dicts, err := uc.Process(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(dicts))
+}
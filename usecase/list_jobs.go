@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListJobsUsecase handles the retrieval of every recorded analysis job on the current workspace.
+type ListJobsUsecase interface {
+	// Process retrieves the analyses still running or waiting to be recovered, and the ones that failed, along with
+	// the reason.
+	Process(ctx context.Context) ([]entity.AnalysisJob, error)
+}
+
+// NewListJobsUsecase initializes a new ListJobsUsecase instance.
+func NewListJobsUsecase(jr repository.JobRepository) ListJobsUsecase {
+	return listJobsUsecase{
+		jobRepository: jr,
+	}
+}
+
+type listJobsUsecase struct {
+	jobRepository repository.JobRepository
+}
+
+func (uc listJobsUsecase) Process(ctx context.Context) ([]entity.AnalysisJob, error) {
+	jobs, err := uc.jobRepository.FindAll(ctx)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrJobNoResults:
+		return []entity.AnalysisJob{}, nil
+	default:
+		log.WithError(err).Error("unable to retrieve analysis jobs")
+		return []entity.AnalysisJob{}, ErrUnexpected
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,39 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListJobsUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewListJobsUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnListJobsUsecase_WhenErrorRetrievingJobs_ShouldReturnError(t *testing.T) {
+	jobRepositoryMock := newJobRepositoryMock()
+	jobRepositoryMock.findErr = repository.ErrJobUnexpected
+	uc := usecase.NewListJobsUsecase(jobRepositoryMock)
+
+	jobs, err := uc.Process(context.TODO())
+
+	assert.Empty(t, jobs)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnListJobsUsecase_ShouldReturnJobs(t *testing.T) {
+	failed := entity.AnalysisJob{ID: uuid.New(), Status: entity.JobStatusFailed, Error: "analysis interrupted 2 times"}
+	uc := usecase.NewListJobsUsecase(newJobRepositoryMock(failed))
+
+	jobs, err := uc.Process(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.AnalysisJob{failed}, jobs)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultProjectsPageSize is the number of projects retrieved on each page, unless a limit is provided.
+const DefaultProjectsPageSize = 20
+
+// ListProjectsUsecase handles the retrieval of the existing projects.
+type ListProjectsUsecase interface {
+	// Process retrieves a page of the projects matching and sorted by the given query.
+	Process(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error)
+}
+
+// NewListProjectsUsecase initializes a new ListProjectsUsecase instance.
+func NewListProjectsUsecase(pr repository.ProjectRepository) ListProjectsUsecase {
+	return listProjectsUsecase{
+		projectRepository: pr,
+	}
+}
+
+type listProjectsUsecase struct {
+	projectRepository repository.ProjectRepository
+}
+
+func (uc listProjectsUsecase) Process(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error) {
+	if query.Limit < 1 {
+		query.Limit = DefaultProjectsPageSize
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	page, err := uc.projectRepository.Query(ctx, query)
+	if err != nil {
+		log.WithError(err).Errorf("unable to query projects with %+v", query)
+		return entity.ProjectPage{}, ErrUnexpected
+	}
+
+	return page, nil
+}
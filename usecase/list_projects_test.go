@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListProjectsUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewListProjectsUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnListProjectsUsecase_WhenErrorQueryingProjects_ShouldReturnError(t *testing.T) {
+	projectRepositoryMock := projectRepositoryMock{
+		getErr: repository.ErrProjectUnexpected,
+	}
+
+	uc := usecase.NewListProjectsUsecase(projectRepositoryMock)
+	page, err := uc.Process(context.TODO(), entity.ProjectQuery{})
+
+	assert.Empty(t, page.Projects)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnListProjectsUsecase_ShouldApplyDefaultPageSize(t *testing.T) {
+	var query entity.ProjectQuery
+	projectRepositoryMock := projectRepositoryMock{
+		query: &query,
+	}
+
+	uc := usecase.NewListProjectsUsecase(projectRepositoryMock)
+	_, err := uc.Process(context.TODO(), entity.ProjectQuery{Owner: "eroatta", Offset: -1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ProjectQuery{Owner: "eroatta", Limit: usecase.DefaultProjectsPageSize}, query)
+}
+
+func TestProcess_OnListProjectsUsecase_ShouldReturnPage(t *testing.T) {
+	projectID := uuid.New()
+	var query entity.ProjectQuery
+	projectRepositoryMock := projectRepositoryMock{
+		page: entity.ProjectPage{
+			Projects: []entity.Project{{ID: projectID, Reference: "eroatta/test"}},
+			Accuracy: map[uuid.UUID]float64{projectID: 0.8},
+			Total:    11,
+		},
+		query: &query,
+	}
+
+	uc := usecase.NewListProjectsUsecase(projectRepositoryMock)
+	page, err := uc.Process(context.TODO(), entity.ProjectQuery{Sort: entity.ProjectSortStarsDesc, Offset: 10, Limit: 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, projectRepositoryMock.page, page)
+	assert.Equal(t, entity.ProjectQuery{Sort: entity.ProjectSortStarsDesc, Offset: 10, Limit: 5}, query)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListWorkspacesUsecase handles the retrieval of every workspace.
+type ListWorkspacesUsecase interface {
+	// Process retrieves every workspace, besides the default one.
+	Process(ctx context.Context) ([]entity.Workspace, error)
+}
+
+// NewListWorkspacesUsecase initializes a new ListWorkspacesUsecase instance.
+func NewListWorkspacesUsecase(wr repository.WorkspaceRepository) ListWorkspacesUsecase {
+	return listWorkspacesUsecase{
+		workspaceRepository: wr,
+	}
+}
+
+type listWorkspacesUsecase struct {
+	workspaceRepository repository.WorkspaceRepository
+}
+
+func (uc listWorkspacesUsecase) Process(ctx context.Context) ([]entity.Workspace, error) {
+	if entity.WorkspaceFrom(ctx) != entity.DefaultWorkspaceID {
+		return []entity.Workspace{}, ErrWorkspaceNotAllowed
+	}
+
+	workspaces, err := uc.workspaceRepository.FindAll(ctx)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrWorkspaceNoResults:
+		return []entity.Workspace{}, nil
+	default:
+		log.WithError(err).Error("unable to retrieve workspaces")
+		return []entity.Workspace{}, ErrUnexpected
+	}
+
+	return workspaces, nil
+}
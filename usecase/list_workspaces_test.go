@@ -0,0 +1,46 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewListWorkspacesUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewListWorkspacesUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnListWorkspacesUsecase_WhenNotOnDefaultWorkspace_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewListWorkspacesUsecase(workspaceRepositoryMock{})
+
+	workspaces, err := uc.Process(entity.WithWorkspace(context.TODO(), uuid.New()))
+
+	assert.Empty(t, workspaces)
+	assert.EqualError(t, err, usecase.ErrWorkspaceNotAllowed.Error())
+}
+
+func TestProcess_OnListWorkspacesUsecase_WhenErrorRetrievingWorkspaces_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewListWorkspacesUsecase(workspaceRepositoryMock{getErr: repository.ErrWorkspaceUnexpected})
+
+	workspaces, err := uc.Process(context.TODO())
+
+	assert.Empty(t, workspaces)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnListWorkspacesUsecase_ShouldReturnWorkspaces(t *testing.T) {
+	stored := []entity.Workspace{{ID: uuid.New(), Name: "backend"}, {ID: uuid.New(), Name: "frontend"}}
+	uc := usecase.NewListWorkspacesUsecase(workspaceRepositoryMock{workspaces: stored})
+
+	workspaces, err := uc.Process(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored, workspaces)
+}
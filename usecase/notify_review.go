@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrNotificationRateLimited indicates that the review couldn't be posted because too many comments were posted
+	// recently.
+	ErrNotificationRateLimited = errors.New("too many review comments posted recently")
+	// ErrUnableToNotify indicates that an error occurred while trying to post the review.
+	ErrUnableToNotify = errors.New("unable to post review comments")
+)
+
+// NotifyReviewUsecase defines the contract for the use case related to post the results of an analysis as review
+// comments on a pull request.
+type NotifyReviewUsecase interface {
+	// Process posts a comment suggesting a better name for each identifier on the changed files with a low
+	// normalization score, unless the same suggestion was already posted on the pull request.
+	Process(ctx context.Context, analysisID uuid.UUID, request entity.ReviewRequest) (entity.Review, error)
+}
+
+// NewNotifyReviewUsecase initializes a new NotifyReviewUsecase instance.
+func NewNotifyReviewUsecase(pr repository.ProjectRepository, ar repository.AnalysisRepository,
+	ir repository.IdentifierRepository, scr repository.SourceCodeRepository, n repository.Notifier) NotifyReviewUsecase {
+	return notifyReviewUsecase{
+		pr:       pr,
+		ar:       ar,
+		ir:       ir,
+		scr:      scr,
+		notifier: n,
+	}
+}
+
+type notifyReviewUsecase struct {
+	pr       repository.ProjectRepository
+	ar       repository.AnalysisRepository
+	ir       repository.IdentifierRepository
+	scr      repository.SourceCodeRepository
+	notifier repository.Notifier
+}
+
+func (uc notifyReviewUsecase) Process(ctx context.Context, analysisID uuid.UUID, request entity.ReviewRequest) (entity.Review, error) {
+	analysis, err := uc.ar.Get(ctx, analysisID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrAnalysisNoResults:
+		return entity.Review{}, ErrAnalysisNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve analysis ID: %v", analysisID)
+		return entity.Review{}, ErrUnexpected
+	}
+
+	project, err := uc.pr.Get(ctx, analysis.ProjectID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrProjectNoResults:
+		return entity.Review{}, ErrProjectNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve project %v", analysis.ProjectID)
+		return entity.Review{}, ErrUnexpected
+	}
+
+	if request.MaxScore <= 0 {
+		request.MaxScore = entity.DefaultReviewMaxScore
+	}
+	if request.MaxComments <= 0 {
+		request.MaxComments = entity.DefaultReviewMaxComments
+	}
+
+	candidates, err := uc.candidates(ctx, analysisID, request)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	review := entity.Review{
+		ProjectRef:  project.Reference,
+		PullRequest: request.PullRequest,
+		Commit:      request.Commit,
+		Comments:    make([]entity.ReviewComment, 0),
+	}
+	sent, err := uc.notifier.Sent(ctx, review)
+	if err != nil {
+		log.WithError(err).Errorf("unable to retrieve posted comments for pull request %d on %s",
+			request.PullRequest, project.Reference)
+		return entity.Review{}, ErrUnableToNotify
+	}
+
+	// each file is read and parsed once, to find the line declaring its identifiers
+	declarations := make(map[string]map[string]int)
+	lines := make(map[string][]string)
+	for _, ident := range candidates {
+		if len(review.Comments) == request.MaxComments {
+			break
+		}
+
+		if _, ok := declarations[ident.File]; !ok {
+			raw, err := uc.scr.Read(ctx, project.SourceCode.Location, ident.File)
+			if err != nil {
+				log.WithError(err).Warnf("unable to read file %s for project %s", ident.File, project.Reference)
+			}
+			declarations[ident.File] = declarationLines(ident.File, raw)
+			lines[ident.File] = strings.Split(string(raw), "\n")
+		}
+
+		line, ok := declarations[ident.File][declarationKey(ident.Receiver, ident.Name)]
+		if !ok || line > len(lines[ident.File]) {
+			continue
+		}
+
+		comment := entity.ReviewComment{
+			Path:       ident.File,
+			Line:       line,
+			Identifier: ident.Name,
+			Suggestion: ident.Normalization.Word,
+			Score:      ident.Normalization.Score,
+			Original:   lines[ident.File][line-1],
+		}
+		if sent[comment.Fingerprint()] {
+			continue
+		}
+		review.Comments = append(review.Comments, comment)
+	}
+
+	if len(review.Comments) == 0 {
+		return review, nil
+	}
+
+	err = uc.notifier.Notify(ctx, review)
+	if deferred, ok := err.(*repository.DeferredCommentsError); ok {
+		// the deferred comments aren't reported as sent, so they are posted on a later review
+		log.Infof("%d comments deferred for pull request %d on %s", deferred.Deferred, request.PullRequest,
+			project.Reference)
+		review.Comments = review.Comments[:deferred.Posted]
+		err = nil
+	}
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrNotifierRateLimited:
+		return entity.Review{}, ErrNotificationRateLimited
+	default:
+		log.WithError(err).Errorf("unable to post review for pull request %d on %s", request.PullRequest, project.Reference)
+		return entity.Review{}, ErrUnableToNotify
+	}
+
+	return review, nil
+}
+
+// candidates retrieves the identifiers on the reviewed files that could be renamed to a name with a better score,
+// sorted from the lowest score.
+func (uc notifyReviewUsecase) candidates(ctx context.Context, analysisID uuid.UUID,
+	request entity.ReviewRequest) ([]entity.Identifier, error) {
+	files := make(map[string]bool)
+	for _, file := range request.Files {
+		files[file] = true
+	}
+
+	it, err := uc.ir.IterateByAnalysisID(ctx, analysisID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrIdentifierNoResults:
+		return nil, ErrIdentifiersNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve identifiers for analysis ID: %v", analysisID)
+		return nil, ErrUnexpected
+	}
+	defer it.Close(ctx)
+
+	candidates := make([]entity.Identifier, 0)
+	for it.Next(ctx) {
+		ident := it.Identifier()
+		if len(files) > 0 && !files[ident.File] {
+			continue
+		}
+
+		word := ident.Normalization.Word
+		if word == "" || word == ident.Name || ident.Normalization.Score >= request.MaxScore {
+			continue
+		}
+		candidates = append(candidates, ident)
+	}
+
+	if err := it.Err(); err != nil {
+		log.WithError(err).Errorf("unable to read identifiers for analysis ID: %v", analysisID)
+		return nil, ErrUnexpected
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch {
+		case a.Normalization.Score != b.Normalization.Score:
+			return a.Normalization.Score < b.Normalization.Score
+		case a.File != b.File:
+			return a.File < b.File
+		default:
+			return a.Position < b.Position
+		}
+	})
+
+	return candidates, nil
+}
+
+// declarationLines parses the file and retrieves the line declaring each function, method, type, variable and
+// constant. The first declaration is kept when a name is declared more than once.
+func declarationLines(filename string, raw []byte) map[string]int {
+	declarations := make(map[string]int)
+	if len(raw) == 0 {
+		return declarations
+	}
+
+	fs := token.NewFileSet()
+	f, err := parser.ParseFile(fs, filename, raw, 0)
+	if err != nil {
+		log.WithError(err).Warnf("unable to parse file %s", filename)
+		return declarations
+	}
+
+	add := func(key string, pos token.Pos) {
+		if _, ok := declarations[key]; !ok {
+			declarations[key] = fs.Position(pos).Line
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch decl := n.(type) {
+		case *ast.FuncDecl:
+			add(declarationKey(receiverType(decl), decl.Name.Name), decl.Name.Pos())
+		case *ast.TypeSpec:
+			add(declarationKey("", decl.Name.Name), decl.Name.Pos())
+		case *ast.ValueSpec:
+			for _, name := range decl.Names {
+				add(declarationKey("", name.Name), name.Pos())
+			}
+		}
+		return true
+	})
+
+	return declarations
+}
+
+// receiverType retrieves the type name for the receiver of a method, or an empty string for functions.
+func receiverType(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return ""
+	}
+
+	expr := decl.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func declarationKey(receiver string, name string) string {
+	if receiver == "" {
+		return name
+	}
+	return receiver + "." + name
+}
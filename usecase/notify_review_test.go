@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reviewedFile = `package main
+
+type server struct{}
+
+var cfg = 1
+
+func (s *server) strt() {}
+
+func rdCfg() {}
+`
+
+func reviewedIdentifiers() []entity.Identifier {
+	return []entity.Identifier{
+		{File: "main.go", Name: "cfg", Type: 0, Position: 30,
+			Normalization: entity.Normalization{Word: "config", Score: 0.4}},
+		{File: "main.go", Name: "strt", Receiver: "server", Position: 40,
+			Normalization: entity.Normalization{Word: "start", Score: 0.2}},
+		{File: "main.go", Name: "rdCfg", Position: 60,
+			Normalization: entity.Normalization{Word: "readConfig", Score: 0.3}},
+		{File: "main.go", Name: "server", Position: 10,
+			Normalization: entity.Normalization{Word: "server", Score: 0.1}},
+		{File: "main.go", Name: "main", Position: 70,
+			Normalization: entity.Normalization{Word: "mainly", Score: 0.9}},
+		{File: "other.go", Name: "oth", Position: 10,
+			Normalization: entity.Normalization{Word: "other", Score: 0.1}},
+	}
+}
+
+func newNotifyReviewUsecase(ir identifierRepositoryMock, n notifierMock) usecase.NotifyReviewUsecase {
+	pr := projectRepositoryMock{
+		project: entity.Project{Reference: "eroatta/test", SourceCode: entity.SourceCode{Location: "/tmp/eroatta/test"}},
+	}
+	ar := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{ID: uuid.New()},
+	}
+	scr := sourceCodeRepositoryMock{
+		files: map[string][]byte{"main.go": []byte(reviewedFile)},
+	}
+	return usecase.NewNotifyReviewUsecase(pr, ar, ir, scr, n)
+}
+
+func TestNewNotifyReviewUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewNotifyReviewUsecase(nil, nil, nil, nil, nil)
+
+	assert.Empty(t, uc)
+}
+
+func TestProcess_OnNotifyReviewUsecase_WhenNoAnalysisFound_ShouldReturnError(t *testing.T) {
+	ar := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	uc := usecase.NewNotifyReviewUsecase(nil, ar, nil, nil, nil)
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{PullRequest: 7})
+
+	assert.EqualError(t, err, usecase.ErrAnalysisNotFound.Error())
+	assert.Empty(t, review)
+}
+
+func TestProcess_OnNotifyReviewUsecase_WhenNoIdentifiersFound_ShouldReturnError(t *testing.T) {
+	uc := newNotifyReviewUsecase(identifierRepositoryMock{err: repository.ErrIdentifierNoResults}, notifierMock{})
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{PullRequest: 7})
+
+	assert.EqualError(t, err, usecase.ErrIdentifiersNotFound.Error())
+	assert.Empty(t, review)
+}
+
+func TestProcess_OnNotifyReviewUsecase_WhenFailingToRetrievePostedComments_ShouldReturnError(t *testing.T) {
+	uc := newNotifyReviewUsecase(identifierRepositoryMock{idents: reviewedIdentifiers()},
+		notifierMock{sentErr: repository.ErrNotifierUnexpected})
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{PullRequest: 7})
+
+	assert.EqualError(t, err, usecase.ErrUnableToNotify.Error())
+	assert.Empty(t, review)
+}
+
+func TestProcess_OnNotifyReviewUsecase_WhenRateLimited_ShouldReturnError(t *testing.T) {
+	uc := newNotifyReviewUsecase(identifierRepositoryMock{idents: reviewedIdentifiers()},
+		notifierMock{notifyErr: repository.ErrNotifierRateLimited})
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{PullRequest: 7})
+
+	assert.EqualError(t, err, usecase.ErrNotificationRateLimited.Error())
+	assert.Empty(t, review)
+}
+
+func TestProcess_OnNotifyReviewUsecase_WhenCommentsDeferred_ShouldReturnPostedComments(t *testing.T) {
+	uc := newNotifyReviewUsecase(identifierRepositoryMock{idents: reviewedIdentifiers()},
+		notifierMock{notifyErr: &repository.DeferredCommentsError{Posted: 1, Deferred: 2}})
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{PullRequest: 7})
+
+	assert.NoError(t, err)
+	require.Equal(t, 1, len(review.Comments))
+	assert.Equal(t, "strt", review.Comments[0].Identifier)
+}
+
+func TestProcess_OnNotifyReviewUsecase_ShouldPostLowScoreIdentifiersOnChangedFiles(t *testing.T) {
+	notified := make([]entity.Review, 0)
+	uc := newNotifyReviewUsecase(identifierRepositoryMock{idents: reviewedIdentifiers()},
+		notifierMock{notified: &notified})
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{
+		PullRequest: 7,
+		Commit:      "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		Files:       []string{"main.go"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.Review{
+		ProjectRef:  "eroatta/test",
+		PullRequest: 7,
+		Commit:      "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		Comments: []entity.ReviewComment{
+			{Path: "main.go", Line: 7, Identifier: "strt", Suggestion: "start", Score: 0.2,
+				Original: "func (s *server) strt() {}"},
+			{Path: "main.go", Line: 9, Identifier: "rdCfg", Suggestion: "readConfig", Score: 0.3,
+				Original: "func rdCfg() {}"},
+			{Path: "main.go", Line: 5, Identifier: "cfg", Suggestion: "config", Score: 0.4,
+				Original: "var cfg = 1"},
+		},
+	}, review)
+	assert.Equal(t, []entity.Review{review}, notified)
+}
+
+func TestProcess_OnNotifyReviewUsecase_ShouldSkipPostedCommentsAndLimitComments(t *testing.T) {
+	posted := entity.ReviewComment{Path: "main.go", Identifier: "strt", Suggestion: "start"}
+	notified := make([]entity.Review, 0)
+	uc := newNotifyReviewUsecase(identifierRepositoryMock{idents: reviewedIdentifiers()},
+		notifierMock{sent: map[string]bool{posted.Fingerprint(): true}, notified: &notified})
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{PullRequest: 7, MaxComments: 1})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, len(review.Comments))
+	assert.Equal(t, "rdCfg", review.Comments[0].Identifier)
+	assert.Equal(t, 1, len(notified))
+}
+
+func TestProcess_OnNotifyReviewUsecase_WhenEveryCommentWasPosted_ShouldNotNotify(t *testing.T) {
+	notified := make([]entity.Review, 0)
+	uc := newNotifyReviewUsecase(identifierRepositoryMock{idents: reviewedIdentifiers()},
+		notifierMock{notified: &notified})
+
+	review, err := uc.Process(context.TODO(), uuid.New(), entity.ReviewRequest{PullRequest: 7, MaxScore: 0.15})
+
+	assert.NoError(t, err)
+	assert.Empty(t, review.Comments)
+	assert.Empty(t, notified)
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrInvalidCursor indicates that the given cursor doesn't reference a page of identifiers.
+	ErrInvalidCursor = errors.New("invalid cursor for a page of identifiers")
+	// ErrInvalidAlgorithm indicates that the given splitter or expander wasn't applied on the analysis.
+	ErrInvalidAlgorithm = errors.New("splitter or expander not applied on the analysis")
+)
+
+// DefaultIdentifiersPageSize is the number of identifiers retrieved on each page, unless a limit is provided.
+const DefaultIdentifiersPageSize = 50
+
+// QueryIdentifiersUsecase handles the retrieval of the identifiers extracted by an analysis.
+type QueryIdentifiersUsecase interface {
+	// Process retrieves a page of the identifiers for the given analysis, matching and sorted by the given query.
+	Process(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error)
+}
+
+// NewQueryIdentifiersUsecase initializes a new QueryIdentifiersUsecase instance.
+func NewQueryIdentifiersUsecase(ir repository.IdentifierRepository, ar repository.AnalysisRepository) QueryIdentifiersUsecase {
+	return queryIdentifiersUsecase{
+		identifierRepository: ir,
+		analysisRepository:   ar,
+	}
+}
+
+type queryIdentifiersUsecase struct {
+	identifierRepository repository.IdentifierRepository
+	analysisRepository   repository.AnalysisRepository
+}
+
+func (uc queryIdentifiersUsecase) Process(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error) {
+	analysis, err := uc.analysisRepository.Get(ctx, analysisID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrAnalysisNoResults:
+		return entity.IdentifierPage{}, ErrAnalysisNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve analysis with ID: %v", analysisID)
+		return entity.IdentifierPage{}, ErrUnexpected
+	}
+
+	pipeline := analysis.Pipeline()
+	if query.Splitter != "" && !contains(pipeline.Splitters, query.Splitter) ||
+		query.Expander != "" && !contains(pipeline.Expanders, query.Expander) {
+		return entity.IdentifierPage{}, ErrInvalidAlgorithm
+	}
+
+	if query.Limit < 1 {
+		query.Limit = DefaultIdentifiersPageSize
+	}
+
+	page, err := uc.identifierRepository.QueryByAnalysisID(ctx, analysisID, query)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrIdentifierInvalidCursor:
+		return entity.IdentifierPage{}, ErrInvalidCursor
+	default:
+		log.WithError(err).Errorf("unable to query identifiers for analysis ID: %v", analysisID)
+		return entity.IdentifierPage{}, ErrUnexpected
+	}
+
+	return page, nil
+}
+
+// contains checks if the name is one of the given algorithm names.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQueryIdentifiersUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewQueryIdentifiersUsecase(nil, nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnQueryIdentifiersUsecase_WhenNoAnalysis_ShouldReturnError(t *testing.T) {
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	uc := usecase.NewQueryIdentifiersUsecase(identifierRepositoryMock{}, analysisRepositoryMock)
+
+	page, err := uc.Process(context.TODO(), uuid.New(), entity.IdentifierQuery{})
+
+	assert.Empty(t, page.Identifiers)
+	assert.EqualError(t, err, usecase.ErrAnalysisNotFound.Error())
+}
+
+func TestProcess_OnQueryIdentifiersUsecase_WhenErrorRetrievingAnalysis_ShouldReturnError(t *testing.T) {
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisUnexpected,
+	}
+
+	uc := usecase.NewQueryIdentifiersUsecase(identifierRepositoryMock{}, analysisRepositoryMock)
+
+	_, err := uc.Process(context.TODO(), uuid.New(), entity.IdentifierQuery{})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnQueryIdentifiersUsecase_WhenInvalidCursor_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		err: repository.ErrIdentifierInvalidCursor,
+	}
+
+	uc := usecase.NewQueryIdentifiersUsecase(identifierRepositoryMock, analysisRepositoryMock{})
+
+	_, err := uc.Process(context.TODO(), uuid.New(), entity.IdentifierQuery{Cursor: "invalid"})
+
+	assert.EqualError(t, err, usecase.ErrInvalidCursor.Error())
+}
+
+func TestProcess_OnQueryIdentifiersUsecase_WhenAlgorithmNotApplied_ShouldReturnError(t *testing.T) {
+	analysisRepositoryMock := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{
+			PipelineSplitters: []string{"conserv"},
+			PipelineExpanders: []string{"noexp"},
+		},
+	}
+
+	uc := usecase.NewQueryIdentifiersUsecase(identifierRepositoryMock{}, analysisRepositoryMock)
+
+	for _, query := range []entity.IdentifierQuery{
+		{Splitter: "splits.conserv"},
+		{Expander: "$where"},
+		{Splitter: "conserv", Expander: "basic"},
+	} {
+		_, err := uc.Process(context.TODO(), uuid.New(), query)
+
+		assert.EqualError(t, err, usecase.ErrInvalidAlgorithm.Error())
+	}
+}
+
+func TestProcess_OnQueryIdentifiersUsecase_WhenErrorQueryingIdentifiers_ShouldReturnError(t *testing.T) {
+	identifierRepositoryMock := identifierRepositoryMock{
+		err: repository.ErrIdentifierUnexpected,
+	}
+
+	uc := usecase.NewQueryIdentifiersUsecase(identifierRepositoryMock, analysisRepositoryMock{})
+
+	_, err := uc.Process(context.TODO(), uuid.New(), entity.IdentifierQuery{})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnQueryIdentifiersUsecase_ShouldReturnPageOfIdentifiers(t *testing.T) {
+	var query entity.IdentifierQuery
+	identifierRepositoryMock := identifierRepositoryMock{
+		page: entity.IdentifierPage{
+			Identifiers: []entity.Identifier{{Name: "parseFile"}},
+			NextCursor:  "next",
+		},
+		query: &query,
+	}
+
+	uc := usecase.NewQueryIdentifiersUsecase(identifierRepositoryMock, analysisRepositoryMock{})
+
+	page, err := uc.Process(context.TODO(), uuid.New(), entity.IdentifierQuery{Package: "main"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "parseFile", page.Identifiers[0].Name)
+	assert.Equal(t, "next", page.NextCursor)
+	assert.Equal(t, "main", query.Package)
+	assert.Equal(t, usecase.DefaultIdentifiersPageSize, query.Limit)
+}
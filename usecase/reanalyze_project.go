@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// dictionaryExpander is the name of the expander built from the managed dictionaries, which is added on every
+// analysis with dictionary entries, so it's not part of the saved pipeline.
+const dictionaryExpander = entity.DictionaryExpanderName
+
+// ErrUnableToUpdateSourceCode indicates that the source code couldn't be updated to the requested revision.
+var ErrUnableToUpdateSourceCode = errors.New("unable to update source code to the requested revision")
+
+// ReanalyzeProjectUsecase defines the contract for the use case related to analyze a project again, after its
+// source code changed.
+type ReanalyzeProjectUsecase interface {
+	// Process updates the source code of the Project to the given revision, replaces its analysis and gains
+	// insights from the new one.
+	Process(ctx context.Context, projectID uuid.UUID, hash string) (entity.AnalysisResults, error)
+}
+
+// NewReanalyzeProjectUsecase initializes a new ReanalyzeProjectUsecase instance.
+func NewReanalyzeProjectUsecase(pr repository.ProjectRepository, scr repository.SourceCodeRepository,
+	ar repository.AnalysisRepository, duc DeleteAnalysisUsecase, auc AnalyzeProjectUsecase,
+	giuc GainInsightsUsecase) ReanalyzeProjectUsecase {
+	return reanalyzeProjectUsecase{
+		pr:   pr,
+		scr:  scr,
+		ar:   ar,
+		duc:  duc,
+		auc:  auc,
+		giuc: giuc,
+	}
+}
+
+type reanalyzeProjectUsecase struct {
+	pr   repository.ProjectRepository
+	scr  repository.SourceCodeRepository
+	ar   repository.AnalysisRepository
+	duc  DeleteAnalysisUsecase
+	auc  AnalyzeProjectUsecase
+	giuc GainInsightsUsecase
+}
+
+// Process updates the source code of the Project to the given revision and replaces its previous analysis, if any,
+// by a new one applying the same pipeline. The updated source code is saved and the previous analysis is removed only
+// once the new one is completed, so a failed analysis leaves both in place, checking out the previous revision again.
+// If the previous analysis already belongs to the given revision, it's kept and ErrPreviousAnalysisFound is returned.
+func (uc reanalyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID, hash string) (entity.AnalysisResults, error) {
+	project, err := uc.pr.Get(ctx, projectID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrProjectNoResults:
+		return entity.AnalysisResults{}, ErrProjectNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve project %s", projectID.String())
+		return entity.AnalysisResults{}, ErrUnexpected
+	}
+
+	previous, err := uc.ar.GetByProjectID(ctx, projectID)
+	switch err {
+	case nil:
+		if project.SourceCode.Hash == hash {
+			return previous, ErrPreviousAnalysisFound
+		}
+	case repository.ErrAnalysisNoResults:
+		previous = entity.AnalysisResults{}
+	default:
+		log.WithError(err).Errorf("unable to check for previous analysis on project %s", projectID.String())
+		return entity.AnalysisResults{}, ErrUnexpected
+	}
+
+	sourceCode, err := uc.scr.Update(ctx, project.SourceCode.Location, hash)
+	if err != nil {
+		log.WithError(err).Errorf("unable to update source code for project %s to %s", project.Reference, hash)
+		return entity.AnalysisResults{}, ErrUnableToUpdateSourceCode
+	}
+
+	// the updated source code is only saved on the project once it's analyzed, so a failed analysis can be retried
+	// for the same revision
+	analyzeCtx := entity.WithSourceCode(ctx, sourceCode)
+	var analysis entity.AnalysisResults
+	if previous.ID == uuid.Nil {
+		analysis, err = uc.auc.Process(analyzeCtx, projectID)
+	} else {
+		analysis, err = uc.auc.ProcessWithPipeline(entity.WithReplacedAnalysis(analyzeCtx, previous.ID), projectID,
+			savedPipeline(previous))
+	}
+	if err != nil {
+		uc.restore(ctx, project)
+		return entity.AnalysisResults{}, err
+	}
+
+	updated := project
+	updated.SourceCode = sourceCode
+	if err := uc.pr.Update(ctx, updated); err != nil {
+		log.WithError(err).Errorf("unable to update project %s", project.Reference)
+		uc.restore(ctx, project)
+		return analysis, ErrUnexpected
+	}
+
+	if previous.ID != uuid.Nil {
+		if err := uc.duc.Process(ctx, previous.ID); err != nil && err != ErrAnalysisNotFound {
+			log.WithError(err).Errorf("unable to delete previous analysis %v for project %s", previous.ID, project.Reference)
+			return analysis, ErrUnexpected
+		}
+	}
+
+	if _, err := uc.giuc.Process(ctx, analysis.ID); err != nil {
+		log.WithError(err).Errorf("unable to gain insights for analysis %v on project %s", analysis.ID, project.Reference)
+		return analysis, err
+	}
+
+	return analysis, nil
+}
+
+// restore checks out the revision saved on the project again, so the source code on its location matches the
+// stored project after a failed analysis.
+func (uc reanalyzeProjectUsecase) restore(ctx context.Context, project entity.Project) {
+	if project.SourceCode.Hash == "" {
+		return
+	}
+
+	if _, err := uc.scr.Update(ctx, project.SourceCode.Location, project.SourceCode.Hash); err != nil {
+		log.WithError(err).Errorf("unable to restore source code for project %s to %s", project.Reference,
+			project.SourceCode.Hash)
+	}
+}
+
+// savedPipeline retrieves the pipeline applied on the given analysis, excluding the expanders built from
+// the managed dictionaries.
+func savedPipeline(analysis entity.AnalysisResults) entity.Pipeline {
+	pipeline := analysis.Pipeline()
+
+	expanders := make([]string, 0, len(pipeline.Expanders))
+	for _, name := range pipeline.Expanders {
+		if name != dictionaryExpander {
+			expanders = append(expanders, name)
+		}
+	}
+	pipeline.Expanders = expanders
+
+	return pipeline
+}
@@ -0,0 +1,328 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/memory"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReanalyzeProjectUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewReanalyzeProjectUsecase(nil, nil, nil, nil, nil, nil)
+
+	assert.Empty(t, uc)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenNoProjectFound_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		getErr: repository.ErrProjectNoResults,
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, nil, nil, nil, nil, nil)
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrProjectNotFound.Error())
+	assert.Empty(t, analysis)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenAlreadyAnalyzedRevision_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{SourceCode: entity.SourceCode{Hash: "bc9968d75e48de59f0870ffb71f5e160bbbdcf52"}},
+	}
+	previous := entity.AnalysisResults{ID: uuid.New()}
+	ar := analysisRepositoryMock{
+		analysisResults: previous,
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, nil, ar, nil, nil, nil)
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrPreviousAnalysisFound.Error())
+	assert.Equal(t, previous, analysis)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenFailingToUpdateSourceCode_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{SourceCode: entity.SourceCode{Hash: "asdf1234asdf"}},
+	}
+	ar := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	scr := sourceCodeRepositoryMock{
+		updateErr: repository.ErrSourceCodeUnableUpdate,
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, scr, ar, nil, nil, nil)
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrUnableToUpdateSourceCode.Error())
+	assert.Empty(t, analysis)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenFailingToDeletePreviousAnalysis_ShouldReturnAnalysisAndError(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{SourceCode: entity.SourceCode{Hash: "asdf1234asdf"}},
+	}
+	ar := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{ID: uuid.New()},
+	}
+	duc := deleteAnalysisUsecaseMock{
+		err: usecase.ErrUnexpected,
+	}
+	auc := &analyzeProjectUsecaseMock{
+		analysis: entity.AnalysisResults{ID: uuid.New()},
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, sourceCodeRepositoryMock{}, ar, duc, auc, nil)
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+	assert.Equal(t, auc.analysis, analysis)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenFailingToAnalyze_ShouldKeepPreviousAnalysis(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{SourceCode: entity.SourceCode{Hash: "asdf1234asdf"}},
+	}
+	ar := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{ID: uuid.New()},
+	}
+	deleted := make([]uuid.UUID, 0)
+	auc := &analyzeProjectUsecaseMock{
+		err: usecase.ErrUnableToBuildASTs,
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, sourceCodeRepositoryMock{}, ar,
+		deleteAnalysisUsecaseMock{deleted: &deleted}, auc, nil)
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrUnableToBuildASTs.Error())
+	assert.Empty(t, analysis)
+	assert.Empty(t, deleted)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenFailingToAnalyze_ShouldCheckOutPreviousRevision(t *testing.T) {
+	pr := memory.NewInMemoryProjectRepository()
+	project := entity.Project{
+		ID:         uuid.New(),
+		Reference:  "eroatta/test",
+		SourceCode: entity.SourceCode{Hash: "asdf1234asdf", Location: "/tmp/repositories/eroatta/test"},
+	}
+	_ = pr.Add(context.TODO(), project)
+	ar := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{ID: uuid.New()},
+	}
+	checkouts := make([]string, 0)
+	scr := sourceCodeRepositoryMock{
+		updated: entity.SourceCode{
+			Hash:     "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+			Location: "/tmp/repositories/eroatta/test",
+		},
+		checkouts: &checkouts,
+	}
+	auc := &analyzeProjectUsecaseMock{
+		err: usecase.ErrUnableToBuildASTs,
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, scr, ar, nil, auc, nil)
+
+	_, err := uc.Process(context.TODO(), project.ID, "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrUnableToBuildASTs.Error())
+	assert.Equal(t, []string{
+		"/tmp/repositories/eroatta/test@bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		"/tmp/repositories/eroatta/test@asdf1234asdf",
+	}, checkouts)
+	stored, _ := pr.Get(context.TODO(), project.ID)
+	assert.Equal(t, project.SourceCode, stored.SourceCode)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenFailingToAnalyze_ShouldReturnError(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{SourceCode: entity.SourceCode{Hash: "asdf1234asdf"}},
+	}
+	ar := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	auc := &analyzeProjectUsecaseMock{
+		err: usecase.ErrUnableToBuildASTs,
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, sourceCodeRepositoryMock{}, ar, nil, auc, nil)
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrUnableToBuildASTs.Error())
+	assert.Empty(t, analysis)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenRetryingFailedAnalysis_ShouldAnalyzeSameRevision(t *testing.T) {
+	pr := memory.NewInMemoryProjectRepository()
+	project := entity.Project{
+		ID:         uuid.New(),
+		Reference:  "eroatta/test",
+		SourceCode: entity.SourceCode{Hash: "asdf1234asdf", Location: "/tmp/repositories/eroatta/test"},
+	}
+	_ = pr.Add(context.TODO(), project)
+	ar := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{ID: uuid.New()},
+	}
+	sourceCode := entity.SourceCode{
+		Hash:     "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		Location: "/tmp/repositories/eroatta/test",
+		Files:    []string{"main.go"},
+	}
+	scr := sourceCodeRepositoryMock{
+		updated: sourceCode,
+	}
+	auc := &analyzeProjectUsecaseMock{
+		err: usecase.ErrTooManyAnalyses,
+	}
+	deleted := make([]uuid.UUID, 0)
+	uc := usecase.NewReanalyzeProjectUsecase(pr, scr, ar, deleteAnalysisUsecaseMock{deleted: &deleted}, auc,
+		gainInsightsUsecaseMock{})
+
+	_, err := uc.Process(context.TODO(), project.ID, sourceCode.Hash)
+
+	assert.EqualError(t, err, usecase.ErrTooManyAnalyses.Error())
+	assert.Equal(t, sourceCode, auc.sourceCode)
+	stored, _ := pr.Get(context.TODO(), project.ID)
+	assert.Equal(t, project.SourceCode, stored.SourceCode)
+
+	auc.err = nil
+	auc.analysis = entity.AnalysisResults{ID: uuid.New()}
+	analysis, err := uc.Process(context.TODO(), project.ID, sourceCode.Hash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, auc.analysis, analysis)
+	assert.Equal(t, []uuid.UUID{ar.analysisResults.ID}, deleted)
+	stored, _ = pr.Get(context.TODO(), project.ID)
+	assert.Equal(t, sourceCode, stored.SourceCode)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenNoPreviousAnalysis_ShouldApplyDefaultPipeline(t *testing.T) {
+	var updated entity.Project
+	pr := projectRepositoryMock{
+		project: entity.Project{Reference: "eroatta/test", SourceCode: entity.SourceCode{Hash: "asdf1234asdf"}},
+		updated: &updated,
+	}
+	ar := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	sourceCode := entity.SourceCode{
+		Hash:     "bc9968d75e48de59f0870ffb71f5e160bbbdcf52",
+		Location: "/tmp/repositories/eroatta/test",
+		Files:    []string{"main.go"},
+	}
+	scr := sourceCodeRepositoryMock{
+		updated: sourceCode,
+	}
+	auc := &analyzeProjectUsecaseMock{
+		analysis: entity.AnalysisResults{ID: uuid.New()},
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, scr, ar, nil, auc, gainInsightsUsecaseMock{})
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.NoError(t, err)
+	assert.Equal(t, auc.analysis, analysis)
+	assert.Equal(t, sourceCode, updated.SourceCode)
+	assert.Nil(t, auc.pipeline)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenFailingToGainInsights_ShouldReturnAnalysisAndError(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{SourceCode: entity.SourceCode{Hash: "asdf1234asdf"}},
+	}
+	ar := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+	auc := &analyzeProjectUsecaseMock{
+		analysis: entity.AnalysisResults{ID: uuid.New()},
+	}
+	giuc := gainInsightsUsecaseMock{
+		err: usecase.ErrUnableToGainInsights,
+	}
+	uc := usecase.NewReanalyzeProjectUsecase(pr, sourceCodeRepositoryMock{}, ar, nil, auc, giuc)
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.EqualError(t, err, usecase.ErrUnableToGainInsights.Error())
+	assert.Equal(t, auc.analysis, analysis)
+}
+
+func TestProcess_OnReanalyzeProjectUsecase_WhenPreviousAnalysis_ShouldApplySavedPipeline(t *testing.T) {
+	pr := projectRepositoryMock{
+		project: entity.Project{SourceCode: entity.SourceCode{Hash: "asdf1234asdf"}},
+	}
+	ar := analysisRepositoryMock{
+		analysisResults: entity.AnalysisResults{
+			ID:                uuid.New(),
+			PipelineMiners:    []string{"wordcount"},
+			PipelineSplitters: []string{"conserv"},
+			PipelineExpanders: []string{"dictionary", "noexp"},
+			PipelineRules:     []string{"snake-case"},
+			IncludeTests:      true,
+		},
+	}
+	auc := &analyzeProjectUsecaseMock{
+		analysis: entity.AnalysisResults{ID: uuid.New()},
+	}
+	deleted := make([]uuid.UUID, 0)
+	uc := usecase.NewReanalyzeProjectUsecase(pr, sourceCodeRepositoryMock{}, ar,
+		deleteAnalysisUsecaseMock{deleted: &deleted}, auc, gainInsightsUsecaseMock{})
+
+	analysis, err := uc.Process(context.TODO(), uuid.New(), "bc9968d75e48de59f0870ffb71f5e160bbbdcf52")
+
+	assert.NoError(t, err)
+	assert.Equal(t, auc.analysis, analysis)
+	assert.Equal(t, []uuid.UUID{ar.analysisResults.ID}, deleted)
+	assert.Equal(t, ar.analysisResults.ID, auc.replaced)
+	assert.Equal(t, &entity.Pipeline{
+		Miners:       []string{"wordcount"},
+		Splitters:    []string{"conserv"},
+		Expanders:    []string{"noexp"},
+		Rules:        []string{"snake-case"},
+		IncludeTests: true,
+	}, auc.pipeline)
+}
+
+type analyzeProjectUsecaseMock struct {
+	analysis entity.AnalysisResults
+	pipeline *entity.Pipeline
+	replaced uuid.UUID
+	// sourceCode holds the source code given on the context of the last analysis, if any.
+	sourceCode entity.SourceCode
+	err        error
+	// started and release, if provided, notify when an analysis starts and hold it until released.
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *analyzeProjectUsecaseMock) Process(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
+	if m.started != nil {
+		m.started <- struct{}{}
+		<-m.release
+	}
+	m.sourceCode, _ = entity.SourceCodeFrom(ctx)
+	return m.analysis, m.err
+}
+
+func (m *analyzeProjectUsecaseMock) ProcessWithPipeline(ctx context.Context, projectID uuid.UUID,
+	pipeline entity.Pipeline) (entity.AnalysisResults, error) {
+	m.pipeline = &pipeline
+	m.replaced = entity.ReplacedAnalysisFrom(ctx)
+	m.sourceCode, _ = entity.SourceCodeFrom(ctx)
+	return m.analysis, m.err
+}
+
+type gainInsightsUsecaseMock struct {
+	err error
+}
+
+func (m gainInsightsUsecaseMock) Process(ctx context.Context, analysisID uuid.UUID) ([]entity.Insight, error) {
+	return []entity.Insight{}, m.err
+}
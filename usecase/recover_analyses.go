@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxAnalysisAttempts is the number of times an analysis is started before it's considered failed, so an analysis
+// that brings the server down isn't resumed forever.
+const maxAnalysisAttempts = 2
+
+// RecoverAnalysesUsecase defines the contract for the use case that resumes or fails the analyses interrupted by a
+// shutdown or a crash.
+type RecoverAnalysesUsecase interface {
+	// Process looks for the running analyses not refreshed by their owner since the given time on every workspace,
+	// which were interrupted before completion. Their staged identifiers are committed or discarded first, and then
+	// each interrupted analysis is claimed and started again with the same pipeline, or at the pushed commit,
+	// unless it was already resumed, in which case it's recorded as failed. No more analyses are claimed once the
+	// context is done. It returns the number of resumed and failed analyses.
+	Process(ctx context.Context, refreshedBefore time.Time) (int, int, error)
+}
+
+// NewRecoverAnalysesUsecase initializes a new RecoverAnalysesUsecase instance, resuming the analyses for the given
+// owner. The given AnalyzeProjectUsecase must not record the analyses on its own, as the interrupted jobs are
+// claimed and refreshed while they're resumed. The analyses at a pushed commit are handed over to the given
+// ReanalyzeProjectUsecase, which records them on its own.
+func NewRecoverAnalysesUsecase(cuc CleanupAnalysesUsecase, auc AnalyzeProjectUsecase, ruc ReanalyzeProjectUsecase,
+	jr repository.JobRepository, wr repository.WorkspaceRepository, owner string) RecoverAnalysesUsecase {
+	return recoverAnalysesUsecase{
+		cleanupAnalysesUsecase:  cuc,
+		analyzeProjectUsecase:   auc,
+		reanalyzeProjectUsecase: ruc,
+		jr:                      jr,
+		wr:                      wr,
+		owner:                   owner,
+	}
+}
+
+type recoverAnalysesUsecase struct {
+	cleanupAnalysesUsecase  CleanupAnalysesUsecase
+	analyzeProjectUsecase   AnalyzeProjectUsecase
+	reanalyzeProjectUsecase ReanalyzeProjectUsecase
+	jr                      repository.JobRepository
+	wr                      repository.WorkspaceRepository
+	owner                   string
+}
+
+func (uc recoverAnalysesUsecase) Process(ctx context.Context, refreshedBefore time.Time) (int, int, error) {
+	// the analyses still running keep their identifiers, so only the interrupted ones are committed or discarded
+	if _, _, err := uc.cleanupAnalysesUsecase.Process(ctx, refreshedBefore); err != nil {
+		return 0, 0, err
+	}
+
+	workspaces, err := uc.wr.FindAll(ctx)
+	if err != nil {
+		log.WithError(err).Error("unable to retrieve workspaces")
+		return 0, 0, ErrUnexpected
+	}
+
+	workspaceIDs := []uuid.UUID{entity.DefaultWorkspaceID}
+	for _, workspace := range workspaces {
+		workspaceIDs = append(workspaceIDs, workspace.ID)
+	}
+
+	var resumed, failed int
+	for _, workspaceID := range workspaceIDs {
+		r, f, err := uc.process(entity.WithWorkspace(ctx, workspaceID), refreshedBefore)
+		resumed, failed = resumed+r, failed+f
+		if err != nil {
+			return resumed, failed, err
+		}
+	}
+
+	return resumed, failed, nil
+}
+
+// process resumes or fails the interrupted analyses on the workspace set on the context.
+func (uc recoverAnalysesUsecase) process(ctx context.Context, refreshedBefore time.Time) (int, int, error) {
+	jobs, err := uc.jr.FindAll(ctx)
+	if err != nil {
+		log.WithError(err).Error("unable to look for interrupted analyses")
+		return 0, 0, ErrUnexpected
+	}
+
+	var resumed, failed int
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			// no more analyses are claimed once the context is done
+			break
+		}
+		if job.Status != entity.JobStatusRunning || !job.DateRefreshed.Before(refreshedBefore) {
+			continue
+		}
+
+		if job.Attempt >= maxAnalysisAttempts {
+			err := uc.jr.Fail(ctx, job.ID, fmt.Sprintf("analysis interrupted %d times", job.Attempt))
+			if err != nil && err != repository.ErrJobNoResults {
+				log.WithError(err).Errorf("unable to record the failed analysis of project %v", job.ProjectID)
+				return resumed, failed, ErrUnexpected
+			}
+			log.Errorf("analysis of project %v failed, interrupted %d times", job.ProjectID, job.Attempt)
+			failed++
+			continue
+		}
+
+		// the resumed analysis is given a new ID, so it's never mixed up with the identifiers of the interrupted one
+		job.Attempt++
+		job.AnalysisID = uuid.New()
+		job.Owner = uc.owner
+		job.DateRefreshed = time.Now()
+		err := uc.jr.Claim(ctx, job, refreshedBefore)
+		switch err {
+		case nil:
+			// do nothing
+		case repository.ErrJobNoResults:
+			log.Infof("interrupted analysis of project %v already claimed by another instance", job.ProjectID)
+			continue
+		default:
+			log.WithError(err).Errorf("unable to claim the interrupted analysis of project %v", job.ProjectID)
+			return resumed, failed, ErrUnexpected
+		}
+
+		// resumed analyses don't inherit the cancellation of the context, as a cancelled analysis isn't recovered
+		analysis, err := uc.resume(entity.WithWorkspace(context.Background(), entity.WorkspaceFrom(ctx)), job)
+		switch err {
+		case nil:
+			log.Infof("interrupted analysis of project %v resumed as analysis %v", job.ProjectID, analysis.ID)
+			resumed++
+		case ErrPreviousAnalysisFound:
+			log.Infof("interrupted analysis of project %v already replaced by analysis %v", job.ProjectID, analysis.ID)
+		default:
+			log.WithError(err).Errorf("analysis of project %v failed, unable to resume it", job.ProjectID)
+			failed++
+		}
+	}
+
+	return resumed, failed, nil
+}
+
+// resume runs the claimed analysis again. An analysis at a pushed commit is recorded anew while the project is
+// analyzed again, so its claimed job is removed first.
+func (uc recoverAnalysesUsecase) resume(ctx context.Context, job entity.AnalysisJob) (entity.AnalysisResults, error) {
+	if job.Commit == "" {
+		return runJob(ctx, uc.analyzeProjectUsecase, uc.jr, job)
+	}
+
+	if err := uc.jr.Delete(ctx, job.ID); err != nil {
+		log.WithError(err).Errorf("unable to remove the pending analysis of project %v", job.ProjectID)
+		return entity.AnalysisResults{}, ErrUnexpected
+	}
+
+	return uc.reanalyzeProjectUsecase.Process(ctx, job.ProjectID, job.Commit)
+}
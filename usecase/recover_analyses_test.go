@@ -0,0 +1,192 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRecoverAnalysesUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewRecoverAnalysesUsecase(nil, nil, nil, nil, nil, "")
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenErrorCleaningUpAnalyses_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{err: usecase.ErrUnexpected},
+		&analyzeProjectUsecaseMock{}, nil, newJobRepositoryMock(), workspaceRepositoryMock{}, "owner")
+	_, _, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenErrorRetrievingWorkspaces_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{}, &analyzeProjectUsecaseMock{}, nil,
+		newJobRepositoryMock(), workspaceRepositoryMock{getErr: repository.ErrWorkspaceUnexpected}, "owner")
+	_, _, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenErrorFindingJobs_ShouldReturnError(t *testing.T) {
+	jobRepositoryMock := newJobRepositoryMock()
+	jobRepositoryMock.findErr = repository.ErrJobUnexpected
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{}, &analyzeProjectUsecaseMock{}, nil,
+		jobRepositoryMock, workspaceRepositoryMock{}, "owner")
+	_, _, err := uc.Process(context.TODO(), time.Now())
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_ShouldResumeInterruptedAnalyses(t *testing.T) {
+	refreshedBefore := time.Now()
+	pipeline := entity.Pipeline{Splitters: []string{"conserv"}}
+	interrupted := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), AnalysisID: uuid.New(), Pipeline: &pipeline,
+		Attempt: 1, Status: entity.JobStatusRunning, Owner: "previous", DateRefreshed: refreshedBefore.Add(-time.Minute)}
+	running := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), Attempt: 1, Status: entity.JobStatusRunning,
+		DateRefreshed: refreshedBefore.Add(time.Second)}
+	failed := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), Attempt: 1, Status: entity.JobStatusFailed,
+		DateRefreshed: refreshedBefore.Add(-time.Minute)}
+	jobRepositoryMock := newJobRepositoryMock(interrupted, running, failed)
+	analyzeProjectUsecaseMock := &analyzeProjectUsecaseMock{analysis: entity.AnalysisResults{ID: uuid.New()}}
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{}, analyzeProjectUsecaseMock, nil,
+		jobRepositoryMock, workspaceRepositoryMock{}, "owner")
+	resumed, failures, err := uc.Process(context.TODO(), refreshedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assert.Equal(t, 0, failures)
+	assert.Equal(t, &pipeline, analyzeProjectUsecaseMock.pipeline)
+	if assert.Equal(t, 1, len(jobRepositoryMock.claimed)) {
+		assert.Equal(t, interrupted.ID, jobRepositoryMock.claimed[0].ID)
+		assert.Equal(t, 2, jobRepositoryMock.claimed[0].Attempt)
+		assert.Equal(t, "owner", jobRepositoryMock.claimed[0].Owner)
+		assert.NotEqual(t, interrupted.AnalysisID, jobRepositoryMock.claimed[0].AnalysisID)
+	}
+	// the analysis still refreshed by its owner and the failed one remain recorded
+	assert.Equal(t, map[uuid.UUID]entity.AnalysisJob{running.ID: running, failed.ID: failed}, jobRepositoryMock.jobs)
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenClaimedByAnotherInstance_ShouldSkipTheAnalysis(t *testing.T) {
+	refreshedBefore := time.Now()
+	interrupted := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), Attempt: 1, Status: entity.JobStatusRunning,
+		DateRefreshed: refreshedBefore.Add(-time.Minute)}
+	jobRepositoryMock := newJobRepositoryMock(interrupted)
+	jobRepositoryMock.claimErr = repository.ErrJobNoResults
+	analyzeProjectUsecaseMock := &analyzeProjectUsecaseMock{}
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{}, analyzeProjectUsecaseMock, nil,
+		jobRepositoryMock, workspaceRepositoryMock{}, "owner")
+	resumed, failed, err := uc.Process(context.TODO(), refreshedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resumed)
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, map[uuid.UUID]entity.AnalysisJob{interrupted.ID: interrupted}, jobRepositoryMock.jobs)
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenErrorClaimingJob_ShouldReturnError(t *testing.T) {
+	refreshedBefore := time.Now()
+	interrupted := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), Attempt: 1, Status: entity.JobStatusRunning,
+		DateRefreshed: refreshedBefore.Add(-time.Minute)}
+	jobRepositoryMock := newJobRepositoryMock(interrupted)
+	jobRepositoryMock.claimErr = repository.ErrJobUnexpected
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{}, &analyzeProjectUsecaseMock{}, nil,
+		jobRepositoryMock, workspaceRepositoryMock{}, "owner")
+	_, _, err := uc.Process(context.TODO(), refreshedBefore)
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenAlreadyResumed_ShouldFailTheAnalysis(t *testing.T) {
+	refreshedBefore := time.Now()
+	interrupted := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), Attempt: 2, Status: entity.JobStatusRunning,
+		DateRefreshed: refreshedBefore.Add(-time.Minute)}
+	jobRepositoryMock := newJobRepositoryMock(interrupted)
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{},
+		&analyzeProjectUsecaseMock{err: usecase.ErrUnexpected}, nil, jobRepositoryMock, workspaceRepositoryMock{}, "owner")
+	resumed, failed, err := uc.Process(context.TODO(), refreshedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resumed)
+	assert.Equal(t, 1, failed)
+	assert.Empty(t, jobRepositoryMock.claimed)
+	assert.Equal(t, entity.JobStatusFailed, jobRepositoryMock.jobs[interrupted.ID].Status)
+	assert.Equal(t, "analysis interrupted 2 times", jobRepositoryMock.jobs[interrupted.ID].Error)
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenUnableToResume_ShouldFailTheAnalysis(t *testing.T) {
+	refreshedBefore := time.Now()
+	interrupted := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), Attempt: 1, Status: entity.JobStatusRunning,
+		DateRefreshed: refreshedBefore.Add(-time.Minute)}
+	jobRepositoryMock := newJobRepositoryMock(interrupted)
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{},
+		&analyzeProjectUsecaseMock{err: usecase.ErrUnableToBuildASTs}, nil, jobRepositoryMock,
+		workspaceRepositoryMock{workspaces: []entity.Workspace{{ID: uuid.New()}}}, "owner")
+	resumed, failed, err := uc.Process(context.TODO(), refreshedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resumed)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, entity.JobStatusFailed, jobRepositoryMock.jobs[interrupted.ID].Status)
+	assert.Equal(t, usecase.ErrUnableToBuildASTs.Error(), jobRepositoryMock.jobs[interrupted.ID].Error)
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenPushedCommit_ShouldAnalyzeAgainAtTheCommit(t *testing.T) {
+	refreshedBefore := time.Now()
+	pending := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), AnalysisID: uuid.New(), Commit: "asdf1234asdf",
+		Status: entity.JobStatusRunning}
+	jobRepositoryMock := newJobRepositoryMock(pending)
+	reanalyzeProjectUsecaseMock := reanalyzeProjectUsecaseMock{processed: make(chan string, 1)}
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{}, &analyzeProjectUsecaseMock{},
+		reanalyzeProjectUsecaseMock, jobRepositoryMock, workspaceRepositoryMock{}, "owner")
+	resumed, failed, err := uc.Process(context.TODO(), refreshedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, pending.ProjectID.String()+"@asdf1234asdf", <-reanalyzeProjectUsecaseMock.processed)
+	if assert.Equal(t, 1, len(jobRepositoryMock.claimed)) {
+		assert.Equal(t, 1, jobRepositoryMock.claimed[0].Attempt)
+	}
+	// the analysis at the pushed commit is recorded on its own
+	assert.Empty(t, jobRepositoryMock.jobs)
+}
+
+func TestProcess_OnRecoverAnalysesUsecase_WhenContextDone_ShouldNotClaimAnalyses(t *testing.T) {
+	refreshedBefore := time.Now()
+	interrupted := entity.AnalysisJob{ID: uuid.New(), ProjectID: uuid.New(), Attempt: 1, Status: entity.JobStatusRunning,
+		DateRefreshed: refreshedBefore.Add(-time.Minute)}
+	jobRepositoryMock := newJobRepositoryMock(interrupted)
+
+	uc := usecase.NewRecoverAnalysesUsecase(cleanupAnalysesUsecaseMock{}, &analyzeProjectUsecaseMock{}, nil,
+		jobRepositoryMock, workspaceRepositoryMock{}, "owner")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resumed, failed, err := uc.Process(ctx, refreshedBefore)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resumed)
+	assert.Equal(t, 0, failed)
+	assert.Empty(t, jobRepositoryMock.claimed)
+}
+
+type cleanupAnalysesUsecaseMock struct {
+	err error
+}
+
+func (m cleanupAnalysesUsecaseMock) Process(ctx context.Context, stagedBefore time.Time) (int, int, error) {
+	return 0, 0, m.err
+}
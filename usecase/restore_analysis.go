@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrInvalidArchive indicates that the archive is malformed, or its elements are not in the expected order.
+	ErrInvalidArchive = errors.New("invalid archive")
+	// ErrUnsupportedArchiveVersion indicates that the archive was written with a schema version that can't be read.
+	ErrUnsupportedArchiveVersion = errors.New("unsupported archive schema version")
+)
+
+// restoreBatchSize is the number of identifiers stored together while restoring an archive.
+const restoreBatchSize = 500
+
+// RestoreAnalysisUsecase defines the contract for the use case that restores an archived analysis.
+type RestoreAnalysisUsecase interface {
+	// Process reads the archive from the given source and stores its project, analysis, identifiers and insights,
+	// under new IDs. If the project already exists, the analysis is restored for it, unless it already has an
+	// analysis.
+	Process(ctx context.Context, r io.Reader) (entity.AnalysisResults, error)
+}
+
+// NewRestoreAnalysisUsecase initializes a new RestoreAnalysisUsecase instance.
+func NewRestoreAnalysisUsecase(pr repository.ProjectRepository, ar repository.AnalysisRepository,
+	ir repository.IdentifierRepository, insr repository.InsightRepository, iuc IndexAnalysisUsecase,
+	af repository.ArchiveFormat) RestoreAnalysisUsecase {
+	return restoreAnalysisUsecase{
+		projectRepository:    pr,
+		analysisRepository:   ar,
+		identifierRepository: ir,
+		insightRepository:    insr,
+		indexAnalysisUsecase: iuc,
+		archiveFormat:        af,
+	}
+}
+
+type restoreAnalysisUsecase struct {
+	projectRepository    repository.ProjectRepository
+	analysisRepository   repository.AnalysisRepository
+	identifierRepository repository.IdentifierRepository
+	insightRepository    repository.InsightRepository
+	indexAnalysisUsecase IndexAnalysisUsecase
+	archiveFormat        repository.ArchiveFormat
+}
+
+func (uc restoreAnalysisUsecase) Process(ctx context.Context, r io.Reader) (entity.AnalysisResults, error) {
+	reader, err := uc.archiveFormat.NewReader(r)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrArchiveInvalid:
+		return entity.AnalysisResults{}, ErrInvalidArchive
+	case repository.ErrArchiveUnsupportedVersion:
+		return entity.AnalysisResults{}, ErrUnsupportedArchiveVersion
+	default:
+		log.WithError(err).Error("unable to read archive")
+		return entity.AnalysisResults{}, ErrUnexpected
+	}
+
+	project, analysis, err := uc.readHeading(reader)
+	if err != nil {
+		return entity.AnalysisResults{}, err
+	}
+
+	// the archived IDs may already be taken on the storage, such as when the archive is restored on several
+	// workspaces, so the project and the analysis are given new ones
+	archivedID := analysis.ID
+	analysis.ID = entity.AnalysisIDFrom(ctx)
+
+	existing, err := uc.projectRepository.GetByReference(ctx, project.Reference)
+	switch err {
+	case nil:
+		project = existing
+	case repository.ErrProjectNoResults:
+		project.ID = uuid.New()
+	default:
+		log.WithError(err).Errorf("unable to retrieve project %s", project.Reference)
+		return entity.AnalysisResults{}, ErrUnexpected
+	}
+	projectExists := err == nil
+	analysis.ProjectID = project.ID
+
+	if projectExists {
+		_, err = uc.analysisRepository.GetByProjectID(ctx, project.ID)
+		switch err {
+		case nil:
+			return entity.AnalysisResults{}, ErrPreviousAnalysisFound
+		case repository.ErrAnalysisNoResults:
+			// do nothing
+		default:
+			log.WithError(err).Errorf("unable to retrieve analysis for project %s", project.Reference)
+			return entity.AnalysisResults{}, ErrUnexpected
+		}
+	}
+
+	// the restore is in progress until its identifiers are committed, so the cleanup process keeps them meanwhile
+	stopStaging, err := keepStaged(ctx, uc.identifierRepository, analysis.ID)
+	if err != nil {
+		return entity.AnalysisResults{}, ErrUnableToSaveIdentifiers
+	}
+
+	insights, err := uc.stageIdentifiers(ctx, reader, archivedID, analysis)
+	stopStaging()
+	if err != nil {
+		uc.rollback(ctx, analysis, project, false, false)
+		return entity.AnalysisResults{}, err
+	}
+
+	if !projectExists {
+		if err := uc.projectRepository.Add(ctx, project); err != nil {
+			log.WithError(err).Errorf("unable to restore project %s", project.Reference)
+			uc.rollback(ctx, analysis, project, false, false)
+			return entity.AnalysisResults{}, ErrUnableToSaveProject
+		}
+	}
+
+	if err := uc.analysisRepository.Add(ctx, analysis); err != nil {
+		log.WithError(err).Errorf("unable to restore analysis ID: %v", analysis.ID)
+		uc.rollback(ctx, analysis, project, !projectExists, false)
+		return entity.AnalysisResults{}, ErrUnableToSaveAnalysis
+	}
+
+	if err := uc.identifierRepository.Commit(ctx, analysis.ID); err != nil {
+		log.WithError(err).Errorf("unable to commit restored identifiers for analysis ID: %v", analysis.ID)
+		uc.rollback(ctx, analysis, project, !projectExists, true)
+		return entity.AnalysisResults{}, ErrUnableToSaveIdentifiers
+	}
+
+	if len(insights) > 0 {
+		if err := uc.insightRepository.AddAll(ctx, insights); err != nil {
+			log.WithError(err).Errorf("unable to restore insights for analysis ID: %v", analysis.ID)
+			uc.rollback(ctx, analysis, project, !projectExists, true)
+			return entity.AnalysisResults{}, ErrUnexpected
+		}
+	}
+
+	if err := uc.indexAnalysisUsecase.Process(ctx, analysis.ID); err != nil {
+		log.WithError(err).Warnf("unable to index restored identifiers for analysis ID: %v", analysis.ID)
+	}
+
+	return analysis, nil
+}
+
+// readHeading reads the project and the analysis, which must be the first elements on the archive.
+func (uc restoreAnalysisUsecase) readHeading(reader repository.ArchiveReader) (entity.Project, entity.AnalysisResults, error) {
+	first, err := reader.Read()
+	if err != nil || first.Project == nil {
+		log.WithError(err).Warn("archive doesn't start with a project")
+		return entity.Project{}, entity.AnalysisResults{}, ErrInvalidArchive
+	}
+
+	second, err := reader.Read()
+	if err != nil || second.Analysis == nil {
+		log.WithError(err).Warn("archive doesn't include an analysis after its project")
+		return entity.Project{}, entity.AnalysisResults{}, ErrInvalidArchive
+	}
+
+	if second.Analysis.ID != reader.Header().AnalysisID {
+		log.Warnf("archive header references analysis ID %v, but holds analysis ID %v",
+			reader.Header().AnalysisID, second.Analysis.ID)
+		return entity.Project{}, entity.AnalysisResults{}, ErrInvalidArchive
+	}
+
+	return *first.Project, *second.Analysis, nil
+}
+
+// stageIdentifiers stores every identifier on the archive in batches, without committing them, and collects the
+// insights found along the way. Both are moved from the archived analysis to the restored one.
+func (uc restoreAnalysisUsecase) stageIdentifiers(ctx context.Context, reader repository.ArchiveReader,
+	archivedID uuid.UUID, analysis entity.AnalysisResults) ([]entity.Insight, error) {
+	insights := make([]entity.Insight, 0)
+	batch := make([]entity.Identifier, 0, restoreBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := uc.identifierRepository.AddAll(ctx, analysis, batch); err != nil {
+			log.WithError(err).Errorf("unable to restore identifiers for analysis ID: %v", analysis.ID)
+			return ErrUnableToSaveIdentifiers
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.WithError(err).Warnf("unable to read archive of analysis ID: %v", archivedID)
+			return nil, ErrInvalidArchive
+		}
+
+		switch {
+		case record.Identifier != nil && record.Identifier.AnalysisID == archivedID:
+			identifier := *record.Identifier
+			identifier.AnalysisID = analysis.ID
+			batch = append(batch, identifier)
+			if len(batch) == restoreBatchSize {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+		case record.Insight != nil && record.Insight.AnalysisID == archivedID:
+			insight := *record.Insight
+			insight.ID = ""
+			insight.AnalysisID = analysis.ID
+			insights = append(insights, insight)
+		default:
+			log.Warnf("unexpected element on archive of analysis ID: %v", archivedID)
+			return nil, ErrInvalidArchive
+		}
+	}
+
+	return insights, flush()
+}
+
+// rollback removes whatever was restored before a failure.
+func (uc restoreAnalysisUsecase) rollback(ctx context.Context, analysis entity.AnalysisResults, project entity.Project,
+	projectAdded bool, analysisAdded bool) {
+	if err := uc.identifierRepository.DeleteAllByAnalysisID(ctx, analysis.ID); err != nil &&
+		err != repository.ErrIdentifierNoResults {
+		log.WithError(err).Errorf("unable to remove restored identifiers for analysis ID: %v", analysis.ID)
+	}
+
+	if analysisAdded {
+		if err := uc.analysisRepository.Delete(ctx, analysis.ID); err != nil {
+			log.WithError(err).Errorf("unable to remove restored analysis ID: %v", analysis.ID)
+		}
+	}
+
+	if projectAdded {
+		if err := uc.projectRepository.Delete(ctx, project.ID); err != nil {
+			log.WithError(err).Errorf("unable to remove restored project ID: %v", project.ID)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/port/outgoing/adapter/repository/memory"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func archive(analysisID uuid.UUID, identifiers int) archiveFormatMock {
+	projectID := uuid.New()
+	records := []entity.ArchiveRecord{
+		{Project: &entity.Project{ID: projectID, Reference: "eroatta/test"}},
+		{Analysis: &entity.AnalysisResults{ID: analysisID, ProjectID: projectID, ProjectName: "eroatta/test"}},
+		{Insight: &entity.Insight{AnalysisID: analysisID, Package: "main"}},
+	}
+	for i := 0; i < identifiers; i++ {
+		records = append(records, entity.ArchiveRecord{Identifier: &entity.Identifier{AnalysisID: analysisID}})
+	}
+
+	return archiveFormatMock{
+		header:  entity.ArchiveHeader{SchemaVersion: entity.ArchiveSchemaVersion, AnalysisID: analysisID},
+		records: records,
+	}
+}
+
+func TestNewRestoreAnalysisUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewRestoreAnalysisUsecase(nil, nil, nil, nil, nil, nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenInvalidHeader_ShouldReturnError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"invalid_archive", repository.ErrArchiveInvalid, usecase.ErrInvalidArchive},
+		{"unsupported_version", repository.ErrArchiveUnsupportedVersion, usecase.ErrUnsupportedArchiveVersion},
+		{"unexpected_error", repository.ErrArchiveUnexpected, usecase.ErrUnexpected},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{}, analysisRepositoryMock{},
+				identifierRepositoryMock{}, insightsRepositoryMock{}, indexAnalysisUsecaseMock{}, archiveFormatMock{newErr: c.err})
+			analysis, err := uc.Process(context.TODO(), &bytes.Buffer{})
+
+			assert.EqualError(t, err, c.expected.Error())
+			assert.Equal(t, entity.AnalysisResults{}, analysis)
+		})
+	}
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenUnexpectedOrder_ShouldReturnError(t *testing.T) {
+	analysisID := uuid.New()
+	valid := archive(analysisID, 1)
+	cases := []struct {
+		name    string
+		records []entity.ArchiveRecord
+	}{
+		{"empty", []entity.ArchiveRecord{}},
+		{"missing_project", valid.records[1:]},
+		{"missing_analysis", []entity.ArchiveRecord{valid.records[0], valid.records[2]}},
+		{"another_analysis", []entity.ArchiveRecord{valid.records[0], valid.records[1], valid.records[1]}},
+		{"another_analysis_identifier", append(valid.records[:2:2],
+			entity.ArchiveRecord{Identifier: &entity.Identifier{AnalysisID: uuid.New()}})},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			format := archive(analysisID, 0)
+			format.records = c.records
+			deleted := make([]uuid.UUID, 0)
+
+			uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{getErr: repository.ErrProjectNoResults},
+				analysisRepositoryMock{}, identifierRepositoryMock{deleted: &deleted}, insightsRepositoryMock{},
+				indexAnalysisUsecaseMock{}, format)
+			_, err := uc.Process(context.TODO(), &bytes.Buffer{})
+
+			assert.EqualError(t, err, usecase.ErrInvalidArchive.Error())
+		})
+	}
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenHeaderReferencesAnotherAnalysis_ShouldReturnError(t *testing.T) {
+	format := archive(uuid.New(), 1)
+	format.header.AnalysisID = uuid.New()
+
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{getErr: repository.ErrProjectNoResults},
+		analysisRepositoryMock{}, identifierRepositoryMock{}, insightsRepositoryMock{}, indexAnalysisUsecaseMock{}, format)
+	_, err := uc.Process(context.TODO(), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrInvalidArchive.Error())
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenProjectHasAnalysis_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{}, analysisRepositoryMock{}, identifierRepositoryMock{},
+		insightsRepositoryMock{}, indexAnalysisUsecaseMock{}, archive(uuid.New(), 1))
+	_, err := uc.Process(context.TODO(), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrPreviousAnalysisFound.Error())
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenErrorReadingIdentifiers_ShouldRollback(t *testing.T) {
+	analysisID := uuid.New()
+	restoredID := uuid.New()
+	format := archive(analysisID, 2)
+	format.readErr = repository.ErrArchiveInvalid
+	deleted := make([]uuid.UUID, 0)
+
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{getErr: repository.ErrProjectNoResults},
+		analysisRepositoryMock{}, identifierRepositoryMock{deleted: &deleted}, insightsRepositoryMock{},
+		indexAnalysisUsecaseMock{}, format)
+	_, err := uc.Process(entity.WithAnalysisID(context.TODO(), restoredID), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrInvalidArchive.Error())
+	assert.Equal(t, []uuid.UUID{restoredID}, deleted)
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenErrorSavingIdentifiers_ShouldRollback(t *testing.T) {
+	analysisID := uuid.New()
+	restoredID := uuid.New()
+	deleted := make([]uuid.UUID, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		err:     repository.ErrIdentifierUnexpected,
+		deleted: &deleted,
+	}
+
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{getErr: repository.ErrProjectNoResults},
+		analysisRepositoryMock{}, identifierRepositoryMock, insightsRepositoryMock{}, indexAnalysisUsecaseMock{},
+		archive(analysisID, 2))
+	_, err := uc.Process(entity.WithAnalysisID(context.TODO(), restoredID), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnableToSaveIdentifiers.Error())
+	assert.Equal(t, []uuid.UUID{restoredID}, deleted)
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenErrorSavingAnalysis_ShouldRollback(t *testing.T) {
+	analysisID := uuid.New()
+	restoredID := uuid.New()
+	deleted := make([]uuid.UUID, 0)
+
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{getErr: repository.ErrProjectNoResults},
+		analysisRepositoryMock{addErr: repository.ErrAnalysisUnexpected}, identifierRepositoryMock{deleted: &deleted},
+		insightsRepositoryMock{}, indexAnalysisUsecaseMock{}, archive(analysisID, 2))
+	_, err := uc.Process(entity.WithAnalysisID(context.TODO(), restoredID), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnableToSaveAnalysis.Error())
+	assert.Equal(t, []uuid.UUID{restoredID}, deleted)
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenErrorCommitting_ShouldRollback(t *testing.T) {
+	analysisID := uuid.New()
+	restoredID := uuid.New()
+	deleted := make([]uuid.UUID, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		commitErr: repository.ErrIdentifierUnexpected,
+		deleted:   &deleted,
+	}
+
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{getErr: repository.ErrProjectNoResults},
+		analysisRepositoryMock{}, identifierRepositoryMock, insightsRepositoryMock{}, indexAnalysisUsecaseMock{},
+		archive(analysisID, 2))
+	_, err := uc.Process(entity.WithAnalysisID(context.TODO(), restoredID), &bytes.Buffer{})
+
+	assert.EqualError(t, err, usecase.ErrUnableToSaveIdentifiers.Error())
+	assert.Equal(t, []uuid.UUID{restoredID}, deleted)
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_ShouldRestoreEveryElement(t *testing.T) {
+	analysisID := uuid.New()
+	restoredID := uuid.New()
+	batches := make([]int, 0)
+	committed := make([]uuid.UUID, 0)
+	indexed := make([]uuid.UUID, 0)
+	identifierRepositoryMock := identifierRepositoryMock{
+		batches:   &batches,
+		committed: &committed,
+	}
+	format := archive(analysisID, 1201)
+
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{getErr: repository.ErrProjectNoResults},
+		analysisRepositoryMock{}, identifierRepositoryMock, insightsRepositoryMock{},
+		indexAnalysisUsecaseMock{indexed: &indexed}, format)
+	analysis, err := uc.Process(entity.WithAnalysisID(context.TODO(), restoredID), &bytes.Buffer{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, restoredID, analysis.ID)
+	assert.NotEqual(t, format.records[0].Project.ID, analysis.ProjectID)
+	assert.Equal(t, "eroatta/test", analysis.ProjectName)
+	assert.Equal(t, []int{500, 500, 201}, batches)
+	assert.Equal(t, []uuid.UUID{restoredID}, committed)
+	assert.Equal(t, []uuid.UUID{restoredID}, indexed)
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenExistingProject_ShouldRestoreForIt(t *testing.T) {
+	existing := entity.Project{ID: uuid.New(), Reference: "eroatta/test"}
+	analysisRepositoryMock := analysisRepositoryMock{
+		getErr: repository.ErrAnalysisNoResults,
+	}
+
+	uc := usecase.NewRestoreAnalysisUsecase(projectRepositoryMock{project: existing}, analysisRepositoryMock,
+		identifierRepositoryMock{}, insightsRepositoryMock{}, indexAnalysisUsecaseMock{}, archive(uuid.New(), 1))
+	analysis, err := uc.Process(context.TODO(), &bytes.Buffer{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing.ID, analysis.ProjectID)
+}
+
+func TestProcess_OnRestoreAnalysisUsecase_WhenRestoredOnSeveralWorkspaces_ShouldRestoreEachUnderNewIDs(t *testing.T) {
+	analysisID := uuid.New()
+	format := archive(analysisID, 2)
+	pr := memory.NewInMemoryProjectRepository()
+	ar := memory.NewInMemoryAnalysisRepository()
+	ir := memory.NewInMemoryIdentifierRepository()
+	insr := memory.NewInMemoryInsightRepository()
+	uc := usecase.NewRestoreAnalysisUsecase(pr, ar, ir, insr, indexAnalysisUsecaseMock{}, format)
+
+	workspaces := []uuid.UUID{uuid.New(), uuid.New()}
+	restored := make([]entity.AnalysisResults, 0)
+	for _, workspaceID := range workspaces {
+		analysis, err := uc.Process(entity.WithWorkspace(context.TODO(), workspaceID), &bytes.Buffer{})
+		require.NoError(t, err)
+		restored = append(restored, analysis)
+	}
+
+	assert.NotEqual(t, restored[0].ID, restored[1].ID)
+	assert.NotEqual(t, restored[0].ProjectID, restored[1].ProjectID)
+	for i, workspaceID := range workspaces {
+		ctx := entity.WithWorkspace(context.TODO(), workspaceID)
+		assert.NotEqual(t, analysisID, restored[i].ID)
+		assert.NotEqual(t, format.records[0].Project.ID, restored[i].ProjectID)
+
+		project, err := pr.Get(ctx, restored[i].ProjectID)
+		require.NoError(t, err)
+		assert.Equal(t, "eroatta/test", project.Reference)
+
+		analysis, err := ar.GetByProjectID(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, restored[i], analysis)
+
+		identifiers, err := ir.FindAllByAnalysisID(ctx, analysis.ID)
+		require.NoError(t, err)
+		require.Len(t, identifiers, 2)
+		assert.Equal(t, analysis.ID, identifiers[0].AnalysisID)
+
+		insights, err := insr.GetByAnalysisID(ctx, analysis.ID)
+		require.NoError(t, err)
+		require.Len(t, insights, 1)
+		assert.Equal(t, analysis.ID, insights[0].AnalysisID)
+	}
+}
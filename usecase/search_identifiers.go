@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultSearchResults is the number of results retrieved on each search, unless a limit is provided.
+const DefaultSearchResults = 20
+
+// SearchIdentifiersUsecase handles the search of identifiers across every analyzed project.
+type SearchIdentifiersUsecase interface {
+	// Process retrieves the identifiers matching the given query, sorted by relevance.
+	Process(ctx context.Context, query entity.SearchQuery) ([]entity.SearchResult, error)
+}
+
+// NewSearchIdentifiersUsecase initializes a new SearchIdentifiersUsecase instance.
+func NewSearchIdentifiersUsecase(sr repository.SearchRepository) SearchIdentifiersUsecase {
+	return searchIdentifiersUsecase{
+		searchRepository: sr,
+	}
+}
+
+type searchIdentifiersUsecase struct {
+	searchRepository repository.SearchRepository
+}
+
+func (uc searchIdentifiersUsecase) Process(ctx context.Context, query entity.SearchQuery) ([]entity.SearchResult, error) {
+	if query.Limit < 1 {
+		query.Limit = DefaultSearchResults
+	}
+
+	results, err := uc.searchRepository.Search(ctx, query)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrSearchNoResults:
+		return []entity.SearchResult{}, nil
+	default:
+		log.WithError(err).Errorf("unable to search identifiers matching: %s", query.Text)
+		return []entity.SearchResult{}, ErrUnexpected
+	}
+
+	return results, nil
+}
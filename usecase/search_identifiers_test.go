@@ -0,0 +1,50 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSearchIdentifiersUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewSearchIdentifiersUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnSearchIdentifiersUsecase_WhenErrorSearching_ShouldReturnError(t *testing.T) {
+	uc := usecase.NewSearchIdentifiersUsecase(searchRepositoryMock{err: repository.ErrSearchUnexpected})
+
+	results, err := uc.Process(context.TODO(), entity.SearchQuery{Text: "cfg"})
+
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+	assert.Empty(t, results)
+}
+
+func TestProcess_OnSearchIdentifiersUsecase_WhenNoResults_ShouldReturnEmptyResults(t *testing.T) {
+	uc := usecase.NewSearchIdentifiersUsecase(searchRepositoryMock{err: repository.ErrSearchNoResults})
+
+	results, err := uc.Process(context.TODO(), entity.SearchQuery{Text: "cfg"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.SearchResult{}, results)
+}
+
+func TestProcess_OnSearchIdentifiersUsecase_ShouldReturnResults(t *testing.T) {
+	var query entity.SearchQuery
+	searchRepositoryMock := searchRepositoryMock{
+		results: []entity.SearchResult{{Name: "readCfg", Score: 2.0}},
+		query:   &query,
+	}
+
+	uc := usecase.NewSearchIdentifiersUsecase(searchRepositoryMock)
+	results, err := uc.Process(context.TODO(), entity.SearchQuery{Text: "cfg", Prefix: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.SearchResult{{Name: "readCfg", Score: 2.0}}, results)
+	assert.Equal(t, entity.SearchQuery{Text: "cfg", Prefix: true, Limit: usecase.DefaultSearchResults}, query)
+}
@@ -1,28 +1,37 @@
 package step
 
 import (
+	"context"
+
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Expand returns a channel of entity.Identifier where each element has been processed by
-// every provided Expander.
-func Expand(identc <-chan entity.Identifier, expanders ...entity.Expander) chan entity.Identifier {
-	expandedc := make(chan entity.Identifier)
-	go func() {
-		for ident := range identc {
-			for _, expander := range expanders {
-				if _, processable := ident.Splits[expander.ApplicableOn()]; !processable {
-					continue
-				}
+// every provided Expander. Elements keep the order they were received, and the time spent by each
+// Expander is traced and observed.
+func Expand(ctx context.Context, cfg entity.StageConfig, identc <-chan entity.Identifier, expanders ...entity.Expander) chan entity.Identifier {
+	names := make([]string, 0, len(expanders))
+	latencies := make(map[string]prometheus.Observer, len(expanders))
+	for _, expander := range expanders {
+		names = append(names, expander.Name())
+		latencies[expander.Name()] = metrics.ExpansionLatency.WithLabelValues(expander.Name())
+	}
 
-				ident.Expansions[expander.Name()] = expander.Expand(ident)
+	stage := startStage(ctx, "Expand", cfg, names...)
+	return processIdentifiers(ctx, cfg, stage, identc, func(ident entity.Identifier) entity.Identifier {
+		for _, expander := range expanders {
+			if _, processable := ident.Splits[expander.ApplicableOn()]; !processable {
+				continue
 			}
 
-			expandedc <- ident
+			elapsed := stage.measure(expander.Name(), func() {
+				ident.Expansions[expander.Name()] = expander.Expand(ident)
+			})
+			latencies[expander.Name()].Observe(elapsed.Seconds())
 		}
 
-		close(expandedc)
-	}()
-
-	return expandedc
+		return ident
+	})
 }
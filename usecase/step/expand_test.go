@@ -1,7 +1,11 @@
 package step_test
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/usecase/step"
@@ -12,7 +16,7 @@ func TestExpand_OnClosedChannel_ShouldSendNoElements(t *testing.T) {
 	identc := make(chan entity.Identifier)
 	close(identc)
 
-	expandedc := step.Expand(identc, expander{})
+	expandedc := step.Expand(context.TODO(), entity.StageConfig{}, identc, expander{})
 
 	var identifiers int
 	for range expandedc {
@@ -38,7 +42,7 @@ func TestExpand_OnEmptyExpander_ShouldSendElementsWithoutExpansions(t *testing.T
 		close(identc)
 	}()
 
-	expandedc := step.Expand(identc, []entity.Expander{}...)
+	expandedc := step.Expand(context.TODO(), entity.StageConfig{}, identc, []entity.Expander{}...)
 
 	expanded := make([]entity.Identifier, 0)
 	for ident := range expandedc {
@@ -89,7 +93,7 @@ func TestExpand_OnOneIdentifierAndTwoExpanders_ShouldSendElementsWithOneExpansio
 		},
 	}
 
-	expandec := step.Expand(identc, custom, skipped)
+	expandec := step.Expand(context.TODO(), entity.StageConfig{}, identc, custom, skipped)
 
 	expandidents := make([]entity.Identifier, 0)
 	for ident := range expandec {
@@ -106,6 +110,86 @@ func TestExpand_OnOneIdentifierAndTwoExpanders_ShouldSendElementsWithOneExpansio
 	assert.False(t, found)
 }
 
+func TestExpand_OnMultipleWorkers_ShouldSendElementsInOrder(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	go func() {
+		for i := 0; i < 100; i++ {
+			identc <- entity.Identifier{
+				Name:       fmt.Sprintf("ident%d", i),
+				Splits:     map[string][]entity.Split{"custom": {{Order: 1, Value: fmt.Sprintf("%d", i)}}},
+				Expansions: make(map[string][]entity.Expansion),
+			}
+		}
+		close(identc)
+	}()
+
+	custom := expander{
+		name:    "custom",
+		worksOn: "custom",
+		efunc: func(splits []entity.Split) []entity.Expansion {
+			if splits[0].Value == "0" {
+				time.Sleep(10 * time.Millisecond)
+			}
+			return []entity.Expansion{{From: splits[0].Value, Values: []string{splits[0].Value}}}
+		},
+	}
+
+	expandedc := step.Expand(context.TODO(), entity.StageConfig{Workers: 8}, identc, custom)
+
+	expanded := make([]entity.Identifier, 0)
+	for ident := range expandedc {
+		expanded = append(expanded, ident)
+	}
+
+	assert.Equal(t, 100, len(expanded))
+	for i, ident := range expanded {
+		assert.Equal(t, fmt.Sprintf("ident%d", i), ident.Name)
+		assert.Equal(t, fmt.Sprintf("%d", i), ident.Expansions["custom"][0].From)
+	}
+}
+
+func BenchmarkExpand(b *testing.B) {
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			benchmarkExpand(b, entity.StageConfig{Workers: workers, Buffer: workers})
+		})
+	}
+}
+
+func benchmarkExpand(b *testing.B, cfg entity.StageConfig) {
+	costly := expander{
+		name:    "costly",
+		worksOn: "custom",
+		efunc: func(splits []entity.Split) []entity.Expansion {
+			// simulates the cost of an expander scoring candidates, such as amap
+			sum := 0
+			for i := 0; i < 50000; i++ {
+				sum += i % (len(splits) + 1)
+			}
+			sink = sum
+			return []entity.Expansion{{From: splits[0].Value, Values: []string{strings.ToLower(splits[0].Value)}}}
+		},
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		identc := make(chan entity.Identifier)
+		go func() {
+			for i := 0; i < 1000; i++ {
+				identc <- entity.Identifier{
+					Name:       "ctrlDel",
+					Splits:     map[string][]entity.Split{"custom": {{Order: 1, Value: "ctrl"}, {Order: 2, Value: "del"}}},
+					Expansions: make(map[string][]entity.Expansion),
+				}
+			}
+			close(identc)
+		}()
+
+		for range step.Expand(context.TODO(), cfg, identc, costly) {
+		}
+	}
+}
+
 type expander struct {
 	name    string
 	worksOn string
@@ -1,16 +1,19 @@
 package step
 
 import (
+	"context"
 	"go/ast"
 
 	"github.com/eroatta/src-reader/entity"
 )
 
 // Extract traverses each Abstract Syntax Tree and applies an extractor
-// to retrieve the identifiers that are interest of us.
-func Extract(files []entity.File, factory entity.ExtractorFactory) chan entity.Identifier {
+// to retrieve the identifiers that are interest of us, along with the line and column declaring them.
+// It stops if the context is cancelled.
+func Extract(ctx context.Context, files []entity.File, factory entity.ExtractorFactory) chan entity.Identifier {
 	identc := make(chan entity.Identifier)
 	go func() {
+		defer close(identc)
 		for _, f := range files {
 			if f.AST == nil {
 				continue
@@ -20,11 +23,18 @@ func Extract(files []entity.File, factory entity.ExtractorFactory) chan entity.I
 			ast.Walk(extractor, f.AST)
 
 			for _, ident := range extractor.Identifiers() {
-				identc <- ident
+				if f.FileSet != nil {
+					position := f.FileSet.Position(ident.Position)
+					ident.Line, ident.Column = position.Line, position.Column
+				}
+
+				select {
+				case identc <- ident:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
-
-		close(identc)
 	}()
 
 	return identc
@@ -1,6 +1,7 @@
 package step_test
 
 import (
+	"context"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -12,7 +13,7 @@ import (
 )
 
 func TestExtract_OnNoFiles_ShouldReturnZeroIdentifiers(t *testing.T) {
-	identc := step.Extract([]entity.File{}, newExtractor)
+	identc := step.Extract(context.TODO(), []entity.File{}, newExtractor)
 
 	var identifiers int
 	for range identc {
@@ -27,7 +28,7 @@ func TestExtract_OnFileWithoutAST_ShouldReturnZeroIdentifiers(t *testing.T) {
 		Name: "main.go",
 		AST:  nil,
 	}
-	identc := step.Extract([]entity.File{fileWithoutAST}, newExtractor)
+	identc := step.Extract(context.TODO(), []entity.File{fileWithoutAST}, newExtractor)
 
 	var identifiers int
 	for range identc {
@@ -66,7 +67,7 @@ func TestExtract_OnFileWithAST_ShouldReturnFoundIdentifiers(t *testing.T) {
 		FileSet: testFileset,
 	}
 
-	identc := step.Extract([]entity.File{file}, newExtractor)
+	identc := step.Extract(context.TODO(), []entity.File{file}, newExtractor)
 
 	identifiers := make(map[string]entity.Identifier)
 	for ident := range identc {
@@ -75,6 +76,8 @@ func TestExtract_OnFileWithAST_ShouldReturnFoundIdentifiers(t *testing.T) {
 
 	assert.Equal(t, 1, len(identifiers))
 	assert.Equal(t, "main", identifiers["main"].Name)
+	assert.Equal(t, 1, identifiers["main"].Line)
+	assert.Equal(t, 1, identifiers["main"].Column)
 }
 
 func newExtractor(filename string) entity.Extractor {
@@ -0,0 +1,43 @@
+package step
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// DetectLanguages groups the comments of every parsed file by package, and applies the detector
+// to find the dominant language of each package.
+func DetectLanguages(files []entity.File, detect entity.LanguageDetector) map[string]entity.Language {
+	comments := make(map[string][]string)
+	for _, f := range files {
+		if f.AST == nil {
+			continue
+		}
+
+		pkg := entity.Identifier{Package: f.AST.Name.String(), File: f.Name}.FullPackageName()
+		for _, group := range f.AST.Comments {
+			comments[pkg] = append(comments[pkg], group.Text())
+		}
+		if _, ok := comments[pkg]; !ok {
+			comments[pkg] = []string{}
+		}
+	}
+
+	languages := make(map[string]entity.Language, len(comments))
+	for pkg, texts := range comments {
+		languages[pkg] = detect(texts)
+	}
+
+	return languages
+}
+
+// Localize returns a channel of entity.Identifier where each element includes the language
+// detected for its package.
+func Localize(ctx context.Context, identc <-chan entity.Identifier, languages map[string]entity.Language) chan entity.Identifier {
+	stage := startStage(ctx, "Localize", entity.StageConfig{})
+	return processIdentifiers(ctx, entity.StageConfig{}, stage, identc, func(ident entity.Identifier) entity.Identifier {
+		ident.Language = languages[ident.FullPackageName()]
+		return ident
+	})
+}
@@ -0,0 +1,64 @@
+package step_test
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase/step"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguages_OnFilesFromDifferentPackages_ShouldReturnLanguagePerPackage(t *testing.T) {
+	fs := token.NewFileSet()
+	english, _ := parser.ParseFile(fs, "cmd/main.go", `package main
+
+		// main is the entry point
+		func main() {}`, parser.ParseComments)
+	spanish, _ := parser.ParseFile(fs, "pkg/datos/datos.go", `package datos
+
+		// guardar los datos del usuario
+		func guardar() {}`, parser.ParseComments)
+	files := []entity.File{
+		{Name: "cmd/main.go", AST: english},
+		{Name: "pkg/datos/datos.go", AST: spanish},
+		{Name: "broken.go"},
+	}
+
+	detect := func(texts []string) entity.Language {
+		if strings.Contains(strings.Join(texts, " "), "usuario") {
+			return entity.LanguageSpanish
+		}
+		return entity.LanguageEnglish
+	}
+
+	languages := step.DetectLanguages(files, detect)
+
+	assert.Equal(t, map[string]entity.Language{
+		"cmd/main":  entity.LanguageEnglish,
+		"pkg/datos": entity.LanguageSpanish,
+	}, languages)
+}
+
+func TestLocalize_OnIdentifiers_ShouldSetLanguageFromPackage(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	go func() {
+		identc <- entity.Identifier{Name: "guardar", Package: "datos", File: "pkg/datos/datos.go"}
+		identc <- entity.Identifier{Name: "main", Package: "main", File: "main.go"}
+		close(identc)
+	}()
+
+	localizedc := step.Localize(context.TODO(), identc, map[string]entity.Language{"pkg/datos": entity.LanguageSpanish})
+
+	localized := make([]entity.Identifier, 0)
+	for ident := range localizedc {
+		localized = append(localized, ident)
+	}
+
+	assert.Equal(t, 2, len(localized))
+	assert.Equal(t, entity.LanguageSpanish, localized[0].Language)
+	assert.Equal(t, entity.Language(""), localized[1].Language)
+}
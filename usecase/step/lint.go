@@ -0,0 +1,26 @@
+package step
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+)
+
+// Lint returns a channel of entity.Identifier where each element has been checked by
+// every provided Rule, and includes the reported findings.
+func Lint(ctx context.Context, identc <-chan entity.Identifier, rules ...entity.Rule) chan entity.Identifier {
+	stage := startStage(ctx, "Lint", entity.StageConfig{})
+	return processIdentifiers(ctx, entity.StageConfig{}, stage, identc, func(ident entity.Identifier) entity.Identifier {
+		findings := make([]entity.Finding, 0)
+		for _, rule := range rules {
+			for _, finding := range rule.Check(ident) {
+				finding.Rule = rule.Name()
+				finding.Severity = rule.Severity()
+				findings = append(findings, finding)
+			}
+		}
+		ident.Findings = findings
+
+		return ident
+	})
+}
@@ -0,0 +1,105 @@
+package step_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/usecase/step"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint_OnClosedChannel_ShouldSendNoElements(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	close(identc)
+
+	lintedc := step.Lint(context.TODO(), identc, rule{})
+
+	var identifiers int
+	for range lintedc {
+		identifiers++
+	}
+
+	assert.Equal(t, 0, identifiers)
+}
+
+func TestLint_OnEmptyRules_ShouldSendElementsWithoutFindings(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	go func() {
+		identc <- entity.Identifier{Name: "max_length"}
+		close(identc)
+	}()
+
+	lintedc := step.Lint(context.TODO(), identc, []entity.Rule{}...)
+
+	linted := make([]entity.Identifier, 0)
+	for ident := range lintedc {
+		linted = append(linted, ident)
+	}
+
+	assert.Equal(t, 1, len(linted))
+	assert.Equal(t, 0, len(linted[0].Findings))
+}
+
+func TestLint_OnOneIdentifierAndTwoRules_ShouldSendElementsWithFindings(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	go func() {
+		identc <- entity.Identifier{Name: "max_length"}
+		close(identc)
+	}()
+
+	underscores := rule{
+		name:     "underscores",
+		severity: entity.SeverityWarning,
+		cfunc: func(name string) bool {
+			return strings.Contains(name, "_")
+		},
+	}
+
+	hyphens := rule{
+		name:     "hyphens",
+		severity: entity.SeverityError,
+		cfunc: func(name string) bool {
+			return strings.Contains(name, "-")
+		},
+	}
+
+	lintedc := step.Lint(context.TODO(), identc, underscores, hyphens)
+
+	linted := make([]entity.Identifier, 0)
+	for ident := range lintedc {
+		linted = append(linted, ident)
+	}
+
+	assert.Equal(t, 1, len(linted))
+	assert.Equal(t, []entity.Finding{
+		{Rule: "underscores", Severity: entity.SeverityWarning, Message: "invalid name max_length"},
+	}, linted[0].Findings)
+}
+
+type rule struct {
+	name     string
+	severity entity.Severity
+	cfunc    func(string) bool
+}
+
+func (r rule) Name() string {
+	if r.name != "" {
+		return r.name
+	}
+
+	return "test"
+}
+
+func (r rule) Severity() entity.Severity {
+	return r.severity
+}
+
+func (r rule) Check(ident entity.Identifier) []entity.Finding {
+	if r.cfunc != nil && r.cfunc(ident.Name) {
+		return []entity.Finding{{Message: "invalid name " + ident.Name}}
+	}
+
+	return []entity.Finding{}
+}
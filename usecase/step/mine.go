@@ -1,15 +1,27 @@
 package step
 
 import (
+	"context"
 	"go/ast"
 	"sync"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
+	"github.com/eroatta/src-reader/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Mine traverses each Abstract Syntax Tree and applies every given miner to extract
 // the required pre-processing information. It returns a map of miners after work is done.
-func Mine(parsed []entity.File, miners ...entity.Miner) map[string]entity.Miner {
+// Each miner is traced on its own span, and the duration of the stage is observed.
+func Mine(ctx context.Context, parsed []entity.File, miners ...entity.Miner) map[string]entity.Miner {
+	ctx, stage := tracing.Start(ctx, "Mine")
+	defer stage.End()
+	defer func(start time.Time) {
+		metrics.StageDuration.WithLabelValues("Mine").Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	minersc := make(chan entity.Miner)
 
 	var wg sync.WaitGroup
@@ -18,6 +30,8 @@ func Mine(parsed []entity.File, miners ...entity.Miner) map[string]entity.Miner
 		go func(miner entity.Miner) {
 			defer wg.Done()
 
+			_, span := tracing.Start(ctx, "Mine "+miner.Name())
+			files := 0
 			for _, f := range parsed {
 				if f.AST == nil {
 					continue
@@ -25,7 +39,10 @@ func Mine(parsed []entity.File, miners ...entity.Miner) map[string]entity.Miner
 
 				miner.SetCurrentFile(f.Name)
 				ast.Walk(miner, f.AST)
+				files++
 			}
+			span.SetAttributes(attribute.Int("files", files))
+			span.End()
 
 			minersc <- miner
 		}(miner)
@@ -1,6 +1,7 @@
 package step_test
 
 import (
+	"context"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -12,7 +13,7 @@ import (
 )
 
 func TestMine_OnNoFiles_ShouldReturnMinersWithoutResults(t *testing.T) {
-	processed := step.Mine([]entity.File{}, &miner{typ: "empty"})
+	processed := step.Mine(context.TODO(), []entity.File{}, &miner{typ: "empty"})
 
 	assert.Equal(t, 1, len(processed))
 
@@ -23,13 +24,13 @@ func TestMine_OnNoFiles_ShouldReturnMinersWithoutResults(t *testing.T) {
 }
 
 func TestMine_OnEmptyMiners_ShouldReturnNoResults(t *testing.T) {
-	processed := step.Mine([]entity.File{}, []entity.Miner{}...)
+	processed := step.Mine(context.TODO(), []entity.File{}, []entity.Miner{}...)
 
 	assert.Equal(t, 0, len(processed))
 }
 
 func TestMine_OnFileWithNilAST_ShouldReturnMinersWithoutResults(t *testing.T) {
-	processed := step.Mine([]entity.File{{Name: "main.go"}}, &miner{typ: "empty"})
+	processed := step.Mine(context.TODO(), []entity.File{{Name: "main.go"}}, &miner{typ: "empty"})
 
 	assert.Equal(t, 1, len(processed))
 
@@ -79,7 +80,7 @@ func TestMine_OnTwoMiners_ShouldReturnResultsBothMiners(t *testing.T) {
 	first := &miner{typ: "first"}
 	second := &miner{typ: "second"}
 
-	processed := step.Mine([]entity.File{file1, file2}, first, second)
+	processed := step.Mine(context.TODO(), []entity.File{file1, file2}, first, second)
 
 	assert.Equal(t, 2, len(processed))
 
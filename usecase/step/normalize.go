@@ -1,20 +1,16 @@
 package step
 
 import (
+	"context"
+
 	"github.com/eroatta/src-reader/entity"
 )
 
 // Normalize returns a channel of entity.Identifier where each element has been normalized.
-func Normalize(identc chan entity.Identifier) chan entity.Identifier {
-	normalizedc := make(chan entity.Identifier)
-	go func() {
-		for ident := range identc {
-			ident.Normalize()
-			normalizedc <- ident
-		}
-
-		close(normalizedc)
-	}()
-
-	return normalizedc
+// Elements keep the order they were received.
+func Normalize(ctx context.Context, cfg entity.StageConfig, identc <-chan entity.Identifier) chan entity.Identifier {
+	return processIdentifiers(ctx, cfg, startStage(ctx, "Normalize", cfg), identc, func(ident entity.Identifier) entity.Identifier {
+		ident.Normalize()
+		return ident
+	})
 }
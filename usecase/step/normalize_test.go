@@ -1,6 +1,7 @@
 package step_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/eroatta/src-reader/entity"
@@ -12,7 +13,7 @@ func TestNormalize_OnClosedChannel_ShouldSendNoElements(t *testing.T) {
 	identc := make(chan entity.Identifier)
 	close(identc)
 
-	normalizedc := step.Normalize(identc)
+	normalizedc := step.Normalize(context.TODO(), entity.StageConfig{}, identc)
 
 	var identifiers int
 	for range normalizedc {
@@ -43,7 +44,7 @@ func TestNormalize_OnIdentifier_ShouldSendNormalizedElement(t *testing.T) {
 		close(identc)
 	}()
 
-	normalizedc := step.Normalize(identc)
+	normalizedc := step.Normalize(context.TODO(), entity.StageConfig{}, identc)
 
 	normalized := make([]entity.Identifier, 0)
 	for ident := range normalizedc {
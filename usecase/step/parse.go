@@ -1,32 +1,30 @@
 package step
 
 import (
+	"context"
 	"go/parser"
 	"go/token"
 
 	"github.com/eroatta/src-reader/entity"
 )
 
-// Parse parses a file and creates an Abstract Syntax Tree (AST) representation.
-// It handles and returns a channel of entity.File elements.
-func Parse(filesc <-chan entity.File) chan entity.File {
+// Parse parses a file and creates an Abstract Syntax Tree (AST) representation, unless the file was skipped.
+// It handles and returns a channel of entity.File elements, keeping the order they were received.
+func Parse(ctx context.Context, cfg entity.StageConfig, filesc <-chan entity.File) chan entity.File {
 	fset := token.NewFileSet()
 
-	parsedc := make(chan entity.File)
-	go func() {
-		for file := range filesc {
-			node, err := parser.ParseFile(fset, file.Name, file.Raw, parser.ParseComments)
-
-			file.AST = node
-			file.FileSet = fset
-			file.Error = err
-			parsedc <- file
+	return processFiles(ctx, cfg, startStage(ctx, "Parse", cfg), filesc, func(file entity.File) entity.File {
+		if file.Skipped {
+			return file
 		}
 
-		close(parsedc)
-	}()
+		node, err := parser.ParseFile(fset, file.Name, file.Raw, parser.ParseComments)
 
-	return parsedc
+		file.AST = node
+		file.FileSet = fset
+		file.Error = err
+		return file
+	})
 }
 
 // Merge joins files when necessary.
@@ -1,6 +1,7 @@
 package step_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/eroatta/src-reader/entity"
@@ -12,7 +13,7 @@ func TestParse_OnClosedChannel_ShouldSendNoElements(t *testing.T) {
 	filesc := make(chan entity.File)
 	close(filesc)
 
-	parsedc := step.Parse(filesc)
+	parsedc := step.Parse(context.TODO(), entity.StageConfig{}, filesc)
 
 	var parsedFiles int
 	for range parsedc {
@@ -32,7 +33,7 @@ func TestParse_OnFileWithError_ShouldSendFileWithErrorMessage(t *testing.T) {
 		close(filesc)
 	}()
 
-	parsedc := step.Parse(filesc)
+	parsedc := step.Parse(context.TODO(), entity.StageConfig{}, filesc)
 
 	files := make([]entity.File, 0)
 	for file := range parsedc {
@@ -59,7 +60,7 @@ func TestParse_OnTwoFiles_ShouldSendTwoParsedFilesWithSameFileset(t *testing.T)
 		close(filesc)
 	}()
 
-	parsedc := step.Parse(filesc)
+	parsedc := step.Parse(context.TODO(), entity.StageConfig{}, filesc)
 
 	files := make(map[string]entity.File)
 	for file := range parsedc {
@@ -102,3 +103,22 @@ func TestMerge_OnTwoFiles_ShouldReturnTwoFiles(t *testing.T) {
 
 	assert.Equal(t, 2, len(got))
 }
+
+func TestParse_OnSkippedFile_ShouldSendFileWithoutAST(t *testing.T) {
+	filesc := make(chan entity.File)
+	go func() {
+		filesc <- entity.File{
+			Name:    "vendor/errors/errors.go",
+			Skipped: true,
+		}
+		close(filesc)
+	}()
+
+	parsedc := step.Parse(context.TODO(), entity.StageConfig{}, filesc)
+
+	files := step.Merge(parsedc)
+	assert.Equal(t, 1, len(files))
+	assert.True(t, files[0].Skipped)
+	assert.Nil(t, files[0].AST)
+	assert.NoError(t, files[0].Error)
+}
@@ -0,0 +1,196 @@
+package step
+
+import (
+	"context"
+
+	"github.com/eroatta/src-reader/entity"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// bounds returns the number of workers and the buffer size for a stage, falling back to a single
+// worker and an unbuffered output.
+func bounds(cfg entity.StageConfig) (int, int) {
+	workers, buffer := cfg.Workers, cfg.Buffer
+	if workers < 1 {
+		workers = 1
+	}
+	if buffer < 0 {
+		buffer = 0
+	}
+
+	return workers, buffer
+}
+
+// processIdentifiers applies fn to each entity.Identifier received from identc, using a bounded pool of workers.
+// Results are sent in the same order they were received. If the context is cancelled, the stage stops
+// receiving and the returned channel is closed. The stage span ends along with the channel, counting the
+// identifiers sent.
+func processIdentifiers(ctx context.Context, cfg entity.StageConfig, stage *stageTrace, identc <-chan entity.Identifier,
+	fn func(entity.Identifier) entity.Identifier) chan entity.Identifier {
+	workers, buffer := bounds(cfg)
+
+	type job struct {
+		ident   entity.Identifier
+		resultc chan entity.Identifier
+	}
+	jobs := make(chan job)
+	pending := make(chan chan entity.Identifier, workers+buffer)
+	outc := make(chan entity.Identifier, buffer)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.resultc <- fn(j.ident)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for {
+			select {
+			case ident, ok := <-identc:
+				if !ok {
+					return
+				}
+
+				resultc := make(chan entity.Identifier, 1)
+				select {
+				case pending <- resultc:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case jobs <- job{ident, resultc}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		sent, failed := 0, 0
+		defer func() {
+			stage.end(attribute.Int("identifiers", sent), attribute.Int("identifiers.error", failed))
+		}()
+		defer close(outc)
+		for resultc := range pending {
+			select {
+			case ident := <-resultc:
+				sent++
+				if ident.Error != nil {
+					failed++
+				}
+
+				select {
+				case outc <- ident:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return outc
+}
+
+// processFiles applies fn to each entity.File received from filesc, using a bounded pool of workers.
+// Results are sent in the same order they were received. If the context is cancelled, the stage stops
+// receiving and the returned channel is closed. The stage span ends along with the channel, counting the
+// files sent.
+func processFiles(ctx context.Context, cfg entity.StageConfig, stage *stageTrace, filesc <-chan entity.File,
+	fn func(entity.File) entity.File) chan entity.File {
+	return processFilesInOrder(ctx, cfg, stage, filesc, fn, nil)
+}
+
+// processFilesInOrder works as processFiles, applying inOrder, if provided, to each result of fn following the
+// order the files were received, before sending it.
+func processFilesInOrder(ctx context.Context, cfg entity.StageConfig, stage *stageTrace, filesc <-chan entity.File,
+	fn func(entity.File) entity.File, inOrder func(entity.File) entity.File) chan entity.File {
+	workers, buffer := bounds(cfg)
+
+	type job struct {
+		file    entity.File
+		resultc chan entity.File
+	}
+	jobs := make(chan job)
+	pending := make(chan chan entity.File, workers+buffer)
+	outc := make(chan entity.File, buffer)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.resultc <- fn(j.file)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for {
+			select {
+			case file, ok := <-filesc:
+				if !ok {
+					return
+				}
+
+				resultc := make(chan entity.File, 1)
+				select {
+				case pending <- resultc:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case jobs <- job{file, resultc}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		sent, skipped, failed := 0, 0, 0
+		defer func() {
+			stage.end(attribute.Int("files", sent), attribute.Int("files.skipped", skipped),
+				attribute.Int("files.error", failed))
+		}()
+		defer close(outc)
+		for resultc := range pending {
+			select {
+			case file := <-resultc:
+				if inOrder != nil {
+					file = inOrder(file)
+				}
+				sent++
+				switch {
+				case file.Skipped:
+					skipped++
+				case file.Error != nil:
+					failed++
+				}
+
+				select {
+				case outc <- file:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return outc
+}
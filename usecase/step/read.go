@@ -2,42 +2,123 @@ package step
 
 import (
 	"context"
+	"regexp"
 	"strings"
+	"sync/atomic"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/repository"
 )
 
-// Read filters only .go files and reads them.
-func Read(ctx context.Context, sc repository.SourceCodeRepository, location string, filenames []string) <-chan entity.File {
-	namesc := make(chan string)
+// generatedHeader matches the comment identifying generated Go files.
+var generatedHeader = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// Read filters only .go files and reads them, using as many workers as the stage config allows. Test files are
+// left out unless includeTests is set. Files left out by the filter or the guards are sent without content and
+// marked as skipped. The size guards are checked before reading each file, so huge files are never loaded.
+//
+// The maximum number of files and the total size budget are applied following the order of the filenames, counting
+// only the files that are kept, so the same files are analyzed on every run. Files are still read in parallel: the
+// files that can't fit on what was already kept are skipped without reading them, and the rest are accepted in order
+// once read.
+func Read(ctx context.Context, cfg entity.StageConfig, sc repository.SourceCodeRepository, location string,
+	filenames []string, filter entity.FileFilter, guards entity.FileGuards, includeTests bool) <-chan entity.File {
+	// kept and keptBytes only grow, so any file that doesn't fit on them won't fit once the previous files are
+	// accepted either
+	var kept, keptBytes int64
+
+	namesc := make(chan entity.File)
 	go func() {
+		defer close(namesc)
 		for _, f := range filenames {
-			if strings.HasSuffix(f, "_test.go") || !strings.HasSuffix(f, ".go") {
+			if !strings.HasSuffix(f, ".go") || (!includeTests && strings.HasSuffix(f, "_test.go")) {
 				continue
 			}
 
-			namesc <- f
-		}
+			file := entity.File{Name: f}
+			if guards.SkipsDir(f) || !filter.Allows(f) ||
+				(guards.MaxFiles > 0 && atomic.LoadInt64(&kept) >= int64(guards.MaxFiles)) {
+				file.Skipped = true
+			}
 
-		close(namesc)
+			select {
+			case namesc <- file:
+			case <-ctx.Done():
+				return
+			}
+		}
 	}()
 
-	filesc := make(chan entity.File)
-	go func() {
-		for n := range namesc {
-			rawFile, err := sc.Read(ctx, location, n)
+	read := func(file entity.File) entity.File {
+		if file.Skipped {
+			return file
+		}
 
-			file := entity.File{
-				Name:  n,
-				Raw:   rawFile,
-				Error: err,
-			}
-			filesc <- file
+		file = checkSize(ctx, sc, location, file, guards, atomic.LoadInt64(&keptBytes))
+		if file.Skipped || file.Error != nil {
+			return file
 		}
 
-		close(filesc)
-	}()
+		return readFile(ctx, sc, location, file, guards)
+	}
+
+	accept := func(file entity.File) entity.File {
+		if file.Skipped || file.Error != nil {
+			return file
+		}
+
+		size := int64(len(file.Raw))
+		if (guards.MaxFiles > 0 && kept >= int64(guards.MaxFiles)) ||
+			(guards.MaxTotalBytes > 0 && keptBytes+size > guards.MaxTotalBytes) {
+			file.Raw = nil
+			file.Skipped = true
+			return file
+		}
+
+		atomic.AddInt64(&kept, 1)
+		atomic.AddInt64(&keptBytes, size)
+		return file
+	}
+
+	return processFilesInOrder(ctx, cfg, startStage(ctx, "Read", cfg), namesc, read, accept)
+}
+
+// checkSize marks the file as skipped if it's larger than the maximum file size, or if it doesn't fit on the
+// remaining total size budget, given the bytes already kept.
+func checkSize(ctx context.Context, sc repository.SourceCodeRepository, location string, file entity.File,
+	guards entity.FileGuards, totalBytes int64) entity.File {
+	if guards.MaxFileSize <= 0 && guards.MaxTotalBytes <= 0 {
+		return file
+	}
+
+	size, err := sc.Size(ctx, location, file.Name)
+	if err != nil {
+		file.Error = err
+		return file
+	}
+
+	if (guards.MaxFileSize > 0 && size > guards.MaxFileSize) ||
+		(guards.MaxTotalBytes > 0 && totalBytes+size > guards.MaxTotalBytes) {
+		file.Skipped = true
+	}
+
+	return file
+}
+
+// readFile reads the content of the file, marking it as skipped if it's generated or it grew over the maximum
+// file size since its size was checked.
+func readFile(ctx context.Context, sc repository.SourceCodeRepository, location string, file entity.File,
+	guards entity.FileGuards) entity.File {
+	file.Raw, file.Error = sc.Read(ctx, location, file.Name)
+	if file.Error != nil {
+		return file
+	}
+
+	if (guards.MaxFileSize > 0 && int64(len(file.Raw)) > guards.MaxFileSize) ||
+		(guards.SkipGenerated && generatedHeader.Match(file.Raw)) {
+		file.Raw = nil
+		file.Skipped = true
+	}
 
-	return filesc
+	return file
 }
@@ -3,7 +3,9 @@ package step_test
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
 	"github.com/eroatta/src-reader/usecase/step"
@@ -15,7 +17,8 @@ func TestRead_OnErrorWhileRetrievingFile_ShouldReturnFileContainingError(t *test
 		err: errors.New("error reading file"),
 	}
 
-	filesc := step.Read(context.TODO(), scMock, "/tmp/", []string{"main.go"})
+	filesc := step.Read(context.TODO(), entity.StageConfig{}, scMock, "/tmp/", []string{"main.go"},
+		entity.FileFilter{}, entity.FileGuards{}, false)
 
 	assert.NotNil(t, filesc)
 	for file := range filesc {
@@ -24,7 +27,8 @@ func TestRead_OnErrorWhileRetrievingFile_ShouldReturnFileContainingError(t *test
 }
 
 func TestClone_OnNonGolangRepository_ShouldReturnZeroFiles(t *testing.T) {
-	filesc := step.Read(context.TODO(), nil, "/tmp/", []string{"README.md"})
+	filesc := step.Read(context.TODO(), entity.StageConfig{}, nil, "/tmp/", []string{"README.md"},
+		entity.FileFilter{}, entity.FileGuards{}, false)
 
 	assert.NotNil(t, filesc)
 
@@ -43,7 +47,8 @@ func TestClone_OnGolangRepository_ShouldReturnAllGolangFiles(t *testing.T) {
 		},
 	}
 
-	filesc := step.Read(context.TODO(), scMock, "/tmp", []string{"main.go", "main_test.go"})
+	filesc := step.Read(context.TODO(), entity.StageConfig{}, scMock, "/tmp", []string{"main.go", "main_test.go"},
+		entity.FileFilter{}, entity.FileGuards{}, false)
 
 	assert.NotNil(t, filesc)
 
@@ -56,20 +61,226 @@ func TestClone_OnGolangRepository_ShouldReturnAllGolangFiles(t *testing.T) {
 	assert.Equal(t, []byte("package main"), files["main.go"].Raw)
 }
 
+func TestRead_OnIncludedTests_ShouldReturnTestFiles(t *testing.T) {
+	scMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go":      []byte("package main"),
+			"main_test.go": []byte("package main"),
+		},
+	}
+
+	filesc := step.Read(context.TODO(), entity.StageConfig{}, scMock, "/tmp", []string{"main.go", "main_test.go"},
+		entity.FileFilter{}, entity.FileGuards{}, true)
+
+	files := make(map[string]entity.File)
+	for file := range filesc {
+		files[file.Name] = file
+	}
+
+	assert.Equal(t, 2, len(files))
+	assert.Equal(t, []byte("package main"), files["main_test.go"].Raw)
+}
+
+func TestRead_OnGuardedFiles_ShouldMarkFilesAsSkipped(t *testing.T) {
+	scMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go":                   []byte("package main"),
+			"large.go":                  []byte("package main\n\nvar large = \"0123456789abcdef\""),
+			"parser_gen.go":             []byte("// Code generated by goyacc. DO NOT EDIT.\n\npackage parser"),
+			"vendor/errors/errors.go":   []byte("package errors"),
+			"parser/testdata/sample.go": []byte("package sample"),
+			"tools/tools.go":            []byte("package tools"),
+		},
+	}
+	filenames := []string{"main.go", "large.go", "parser_gen.go", "vendor/errors/errors.go",
+		"parser/testdata/sample.go", "tools/tools.go"}
+	filter := entity.FileFilter{Exclude: []string{"tools/**"}}
+	guards := entity.FileGuards{
+		MaxFileSize:   32,
+		SkipDirs:      []string{"vendor", "testdata"},
+		SkipGenerated: true,
+	}
+
+	filesc := step.Read(context.TODO(), entity.StageConfig{}, scMock, "/tmp", filenames, filter, guards, false)
+
+	skipped := make([]string, 0)
+	for file := range filesc {
+		if file.Skipped {
+			assert.Empty(t, file.Raw)
+			skipped = append(skipped, file.Name)
+			continue
+		}
+		assert.Equal(t, "main.go", file.Name)
+	}
+	assert.Equal(t, []string{"large.go", "parser_gen.go", "vendor/errors/errors.go",
+		"parser/testdata/sample.go", "tools/tools.go"}, skipped)
+}
+
+func TestRead_OnLargeFiles_ShouldSkipThemWithoutReading(t *testing.T) {
+	read := make([]string, 0)
+	scMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"main.go":  []byte("package main"),
+			"large.go": []byte("package main\n\nvar large = \"0123456789abcdef\""),
+		},
+		read: &read,
+	}
+
+	filesc := step.Read(context.TODO(), entity.StageConfig{}, scMock, "/tmp", []string{"main.go", "large.go"},
+		entity.FileFilter{}, entity.FileGuards{MaxFileSize: 32}, false)
+
+	for file := range filesc {
+		assert.Equal(t, file.Name == "large.go", file.Skipped)
+	}
+	assert.Equal(t, []string{"main.go"}, read)
+}
+
+func TestRead_OnMaxFilesAndTotalBytes_ShouldSkipRemainingFiles(t *testing.T) {
+	scMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"a.go": []byte("package a"),
+			"b.go": []byte("package b"),
+			"c.go": []byte("package c"),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		guards entity.FileGuards
+	}{
+		{"max_files", entity.FileGuards{MaxFiles: 2}},
+		{"max_total_bytes", entity.FileGuards{MaxTotalBytes: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesc := step.Read(context.TODO(), entity.StageConfig{}, scMock, "/tmp", []string{"a.go", "b.go", "c.go"},
+				entity.FileFilter{}, tt.guards, false)
+
+			read := make([]string, 0)
+			for file := range filesc {
+				if !file.Skipped {
+					read = append(read, file.Name)
+				}
+			}
+			assert.Equal(t, []string{"a.go", "b.go"}, read)
+		})
+	}
+}
+
+func TestRead_OnMaxTotalBytes_ShouldOnlyCountTheFilesReadInOrder(t *testing.T) {
+	scMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"a.go":     []byte("package a"),
+			"gen.go":   []byte("// Code generated by stringer. DO NOT EDIT.\n\npackage a"),
+			"large.go": []byte("package a\n\nvar large = \"0123456789abcdef\""),
+			"b.go":     []byte("package b"),
+			"c.go":     []byte("package c"),
+		},
+	}
+	guards := entity.FileGuards{MaxFileSize: 32, MaxTotalBytes: 20, SkipGenerated: true}
+
+	for i := 0; i < 10; i++ {
+		filesc := step.Read(context.TODO(), entity.StageConfig{Workers: 4}, scMock, "/tmp",
+			[]string{"a.go", "gen.go", "large.go", "b.go", "c.go"}, entity.FileFilter{}, guards, false)
+
+		read := make([]string, 0)
+		for file := range filesc {
+			if !file.Skipped {
+				read = append(read, file.Name)
+			}
+		}
+		assert.Equal(t, []string{"a.go", "b.go"}, read)
+	}
+}
+
+func TestRead_OnMaxFiles_ShouldOnlyCountTheFilesKept(t *testing.T) {
+	scMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"gen.go":   []byte("// Code generated by stringer. DO NOT EDIT.\n\npackage a"),
+			"large.go": []byte("package a\n\nvar large = \"0123456789abcdef\""),
+			"a.go":     []byte("package a"),
+			"b.go":     []byte("package b"),
+			"c.go":     []byte("package c"),
+		},
+	}
+	guards := entity.FileGuards{MaxFiles: 2, MaxFileSize: 32, SkipGenerated: true}
+
+	filesc := step.Read(context.TODO(), entity.StageConfig{Workers: 4}, scMock, "/tmp",
+		[]string{"gen.go", "large.go", "a.go", "b.go", "c.go"}, entity.FileFilter{}, guards, false)
+
+	read := make([]string, 0)
+	for file := range filesc {
+		if !file.Skipped {
+			read = append(read, file.Name)
+		}
+	}
+	assert.Equal(t, []string{"a.go", "b.go"}, read)
+}
+
+func TestRead_OnMaxTotalBytes_ShouldReadFilesInParallel(t *testing.T) {
+	together := &sync.WaitGroup{}
+	together.Add(3)
+	scMock := sourceCodeFileReaderMock{
+		files: map[string][]byte{
+			"a.go": []byte("package a"),
+			"b.go": []byte("package b"),
+			"c.go": []byte("package c"),
+		},
+		together: together,
+	}
+
+	done := make(chan []string)
+	go func() {
+		filesc := step.Read(context.TODO(), entity.StageConfig{Workers: 3}, scMock, "/tmp",
+			[]string{"a.go", "b.go", "c.go"}, entity.FileFilter{}, entity.FileGuards{MaxTotalBytes: 20}, false)
+
+		read := make([]string, 0)
+		for file := range filesc {
+			if !file.Skipped {
+				read = append(read, file.Name)
+			}
+		}
+		done <- read
+	}()
+
+	select {
+	case read := <-done:
+		assert.Equal(t, []string{"a.go", "b.go"}, read)
+	case <-time.After(5 * time.Second):
+		t.Fatal("files weren't read in parallel")
+	}
+}
+
 type sourceCodeFileReaderMock struct {
 	files map[string][]byte
 	err   error
+	// read holds the names of the files read, if provided.
+	read *[]string
+	// together, if provided, makes each read wait until as many reads as its count are in progress.
+	together *sync.WaitGroup
 }
 
 func (m sourceCodeFileReaderMock) Clone(ctx context.Context, fullname string, cloneURL string) (entity.SourceCode, error) {
 	return entity.SourceCode{}, errors.New("shouldn't be called")
 }
 
+func (m sourceCodeFileReaderMock) Update(ctx context.Context, location string, hash string) (entity.SourceCode, error) {
+	return entity.SourceCode{}, errors.New("shouldn't be called")
+}
+
 func (m sourceCodeFileReaderMock) Remove(ctx context.Context, location string) error {
 	return errors.New("shouldn't be called")
 }
 
 func (m sourceCodeFileReaderMock) Read(ctx context.Context, location string, filename string) ([]byte, error) {
+	if m.together != nil {
+		m.together.Done()
+		m.together.Wait()
+	}
+	if m.read != nil {
+		*m.read = append(*m.read, filename)
+	}
 	if m.err != nil {
 		return []byte{}, m.err
 	}
@@ -81,3 +292,16 @@ func (m sourceCodeFileReaderMock) Read(ctx context.Context, location string, fil
 
 	return b, nil
 }
+
+func (m sourceCodeFileReaderMock) Size(ctx context.Context, location string, filename string) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+
+	b, ok := m.files[filename]
+	if !ok {
+		return 0, errors.New("not found")
+	}
+
+	return int64(len(b)), nil
+}
@@ -1,24 +1,37 @@
 package step
 
 import (
+	"context"
+
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Split returns a channel of entity.Identifier where each element has been processed by
-// every provided Splitter.
-func Split(identc <-chan entity.Identifier, splitters ...entity.Splitter) chan entity.Identifier {
-	splittedc := make(chan entity.Identifier)
-	go func() {
-		for ident := range identc {
-			for _, splitter := range splitters {
-				ident.Splits[splitter.Name()] = splitter.Split(ident.Name)
-			}
+// every provided Splitter. Elements keep the order they were received, and the time spent by each
+// Splitter is traced and observed. Splitters adapting to the language of the identifier receive it.
+func Split(ctx context.Context, cfg entity.StageConfig, identc <-chan entity.Identifier, splitters ...entity.Splitter) chan entity.Identifier {
+	names := make([]string, 0, len(splitters))
+	latencies := make(map[string]prometheus.Observer, len(splitters))
+	for _, splitter := range splitters {
+		names = append(names, splitter.Name())
+		latencies[splitter.Name()] = metrics.SplitLatency.WithLabelValues(splitter.Name())
+	}
 
-			splittedc <- ident
+	stage := startStage(ctx, "Split", cfg, names...)
+	return processIdentifiers(ctx, cfg, stage, identc, func(ident entity.Identifier) entity.Identifier {
+		for _, splitter := range splitters {
+			elapsed := stage.measure(splitter.Name(), func() {
+				if languageSplitter, ok := splitter.(entity.LanguageSplitter); ok {
+					ident.Splits[splitter.Name()] = languageSplitter.SplitInLanguage(ident.Name, ident.Language)
+					return
+				}
+				ident.Splits[splitter.Name()] = splitter.Split(ident.Name)
+			})
+			latencies[splitter.Name()].Observe(elapsed.Seconds())
 		}
 
-		close(splittedc)
-	}()
-
-	return splittedc
+		return ident
+	})
 }
@@ -1,19 +1,30 @@
 package step_test
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
 	"github.com/eroatta/src-reader/usecase/step"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestSplit_OnClosedChannel_ShouldSendNoElements(t *testing.T) {
 	identc := make(chan entity.Identifier)
 	close(identc)
 
-	splitc := step.Split(identc, splitter{})
+	splitc := step.Split(context.TODO(), entity.StageConfig{}, identc, splitter{})
 
 	var identifiers int
 	for range splitc {
@@ -33,7 +44,7 @@ func TestSplit_OnEmptySplitter_ShouldSendElementsWithoutSplits(t *testing.T) {
 		close(identc)
 	}()
 
-	splitc := step.Split(identc, []entity.Splitter{}...)
+	splitc := step.Split(context.TODO(), entity.StageConfig{}, identc, []entity.Splitter{}...)
 
 	splits := make([]entity.Identifier, 0)
 	for ident := range splitc {
@@ -68,7 +79,7 @@ func TestSplit_OnOneIdentifierAndTwoSplitters_ShouldSendElementsWithTwoSplits(t
 		},
 	}
 
-	splitc := step.Split(identc, byHyphen, byUnderscore)
+	splitc := step.Split(context.TODO(), entity.StageConfig{}, identc, byHyphen, byUnderscore)
 
 	splitidents := make([]entity.Identifier, 0)
 	for ident := range splitc {
@@ -82,6 +93,211 @@ func TestSplit_OnOneIdentifierAndTwoSplitters_ShouldSendElementsWithTwoSplits(t
 	assert.Equal(t, []entity.Split{{Order: 1, Value: "star"}, {Order: 2, Value: "wars-II"}}, splits["underscore"])
 }
 
+func TestSplit_OnLanguageSplitter_ShouldSplitInTheLanguageOfTheIdentifier(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	go func() {
+		identc <- entity.Identifier{
+			Name:     "numCuenta",
+			Splits:   make(map[string][]entity.Split),
+			Language: entity.LanguageSpanish,
+		}
+		close(identc)
+	}()
+
+	splitc := step.Split(context.TODO(), entity.StageConfig{}, identc, languageSplitter{splitter{name: "localized"}})
+
+	ident := <-splitc
+	assert.Equal(t, []entity.Split{{Order: 1, Value: "numCuenta@es"}}, ident.Splits["localized"])
+}
+
+func TestSplit_OnMultipleWorkers_ShouldSendElementsInOrder(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	go func() {
+		for i := 0; i < 100; i++ {
+			identc <- entity.Identifier{
+				Name:   fmt.Sprintf("ident_%d", i),
+				Splits: make(map[string][]entity.Split),
+			}
+		}
+		close(identc)
+	}()
+
+	byUnderscore := splitter{
+		name: "underscore",
+		sfunc: func(token string) string {
+			// identifiers received first take longer, so workers finish out of order
+			if strings.HasSuffix(token, "_1") {
+				time.Sleep(10 * time.Millisecond)
+			}
+			return strings.ReplaceAll(token, "_", " ")
+		},
+	}
+
+	splitc := step.Split(context.TODO(), entity.StageConfig{Workers: 8, Buffer: 16}, identc, byUnderscore)
+
+	names := make([]string, 0)
+	for ident := range splitc {
+		names = append(names, ident.Name)
+	}
+
+	assert.Equal(t, 100, len(names))
+	for i, name := range names {
+		assert.Equal(t, fmt.Sprintf("ident_%d", i), name)
+	}
+}
+
+func TestSplit_OnCancelledContext_ShouldStopSendingElements(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	done := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			select {
+			case identc <- entity.Identifier{Name: "ident", Splits: make(map[string][]entity.Split)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		close(identc)
+	}()
+
+	splitc := step.Split(ctx, entity.StageConfig{Workers: 4}, identc, splitter{})
+
+	<-splitc
+	cancel()
+
+	var identifiers int
+	for range splitc {
+		identifiers++
+	}
+	<-done
+
+	assert.True(t, identifiers < 99)
+}
+
+// sink keeps the benchmarked work from being optimized away.
+var sink int
+
+// recordedSpans holds every span ended on the tests, since the global tracer provider can only be set once.
+var recordedSpans = func() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}()
+
+func TestSplit_ShouldTraceStageAndEachSplitter(t *testing.T) {
+	ctx, parent := otel.Tracer("test").Start(context.TODO(), "AnalyzeProject")
+	identc := make(chan entity.Identifier)
+	go func() {
+		for _, name := range []string{"star_wars", "main"} {
+			identc <- entity.Identifier{Name: name, Splits: make(map[string][]entity.Split)}
+		}
+		close(identc)
+	}()
+
+	slow := splitter{
+		name: "slow",
+		sfunc: func(token string) string {
+			time.Sleep(10 * time.Millisecond)
+			return token
+		},
+	}
+
+	for range step.Split(ctx, entity.StageConfig{Workers: 2}, identc, slow, splitter{name: "fast"}) {
+	}
+	parent.End()
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recordedSpans.Ended() {
+		if span.SpanContext().TraceID() == parent.SpanContext().TraceID() {
+			spans[span.Name()] = span
+		}
+	}
+	require.Len(t, spans, 2)
+
+	stage := spans["Split"]
+	require.NotNil(t, stage)
+	assert.Equal(t, parent.SpanContext().SpanID(), stage.Parent().SpanID())
+	assert.Contains(t, stage.Attributes(), attribute.Int("identifiers", 2))
+	assert.Contains(t, stage.Attributes(), attribute.Int("workers", 2))
+
+	events := make(map[string]sdktrace.Event)
+	for _, event := range stage.Events() {
+		events[event.Name] = event
+	}
+	for _, name := range []string{"slow", "fast"} {
+		require.Contains(t, events, "Split "+name)
+		assert.Contains(t, events["Split "+name].Attributes, attribute.String("algorithm", name))
+		assert.Contains(t, events["Split "+name].Attributes, attribute.Int64("identifiers", 2))
+	}
+	var busy float64
+	for _, attr := range events["Split slow"].Attributes {
+		if attr.Key == "busy_seconds" {
+			busy = attr.Value.AsFloat64()
+		}
+	}
+	assert.True(t, busy >= 0.02, "expected at least 20ms busy, found %vs", busy)
+}
+
+func TestSplit_ShouldObserveLatencyOfEachSplitter(t *testing.T) {
+	identc := make(chan entity.Identifier)
+	go func() {
+		for _, name := range []string{"star_wars", "main"} {
+			identc <- entity.Identifier{Name: name, Splits: make(map[string][]entity.Split)}
+		}
+		close(identc)
+	}()
+
+	for range step.Split(context.TODO(), entity.StageConfig{}, identc, splitter{name: "observed"}) {
+	}
+
+	observed := &dto.Metric{}
+	require.NoError(t, metrics.SplitLatency.WithLabelValues("observed").(prometheus.Metric).Write(observed))
+	assert.Equal(t, uint64(2), observed.GetHistogram().GetSampleCount())
+
+	stage := &dto.Metric{}
+	require.NoError(t, metrics.StageDuration.WithLabelValues("Split").(prometheus.Metric).Write(stage))
+	assert.NotZero(t, stage.GetHistogram().GetSampleCount())
+}
+
+func BenchmarkSplit(b *testing.B) {
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers_%d", workers), func(b *testing.B) {
+			benchmarkSplit(b, entity.StageConfig{Workers: workers, Buffer: workers})
+		})
+	}
+}
+
+func benchmarkSplit(b *testing.B, cfg entity.StageConfig) {
+	costly := splitter{
+		name: "costly",
+		sfunc: func(token string) string {
+			// simulates the cost of a dictionary based splitter
+			sum := 0
+			for i := 0; i < 20000; i++ {
+				sum += i % (len(token) + 1)
+			}
+			sink = sum
+			return strings.ReplaceAll(token, "_", " ")
+		},
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		identc := make(chan entity.Identifier)
+		go func() {
+			for i := 0; i < 1000; i++ {
+				identc <- entity.Identifier{Name: "max_length", Splits: make(map[string][]entity.Split)}
+			}
+			close(identc)
+		}()
+
+		for range step.Split(context.TODO(), cfg, identc, costly) {
+		}
+	}
+}
+
 type splitter struct {
 	name  string
 	sfunc func(string) string
@@ -107,3 +323,11 @@ func (s splitter) Split(token string) []entity.Split {
 
 	return []entity.Split{}
 }
+
+type languageSplitter struct {
+	splitter
+}
+
+func (s languageSplitter) SplitInLanguage(token string, lang entity.Language) []entity.Split {
+	return []entity.Split{{Order: 1, Value: token + "@" + string(lang)}}
+}
@@ -0,0 +1,84 @@
+package step
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/metrics"
+	"github.com/eroatta/src-reader/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// stageTrace records the span of a stage, from its start until its output channel is closed, along with the time
+// spent by each algorithm applied on the stage.
+type stageTrace struct {
+	name       string
+	span       trace.Span
+	start      time.Time
+	names      []string
+	algorithms map[string]*algorithmTrace
+}
+
+// algorithmTrace accumulates the time an algorithm was busy, across every worker of the stage.
+type algorithmTrace struct {
+	busy  int64
+	calls int64
+}
+
+// startStage starts the span of a stage, on which the given algorithms are applied.
+func startStage(ctx context.Context, name string, cfg entity.StageConfig, algorithms ...string) *stageTrace {
+	workers, _ := bounds(cfg)
+	start := time.Now()
+	_, span := tracing.Start(ctx, name, trace.WithTimestamp(start),
+		trace.WithAttributes(attribute.Int("workers", workers)))
+
+	stage := &stageTrace{
+		name:       name,
+		span:       span,
+		start:      start,
+		algorithms: make(map[string]*algorithmTrace, len(algorithms)),
+	}
+	for _, algorithm := range algorithms {
+		if _, ok := stage.algorithms[algorithm]; !ok {
+			stage.names = append(stage.names, algorithm)
+			stage.algorithms[algorithm] = &algorithmTrace{}
+		}
+	}
+
+	return stage
+}
+
+// measure applies fn, adding the elapsed time to the given algorithm, and returns it. It's safe to call from
+// every worker.
+func (s *stageTrace) measure(algorithm string, fn func()) time.Duration {
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+	if t, ok := s.algorithms[algorithm]; ok {
+		atomic.AddInt64(&t.busy, int64(elapsed))
+		atomic.AddInt64(&t.calls, 1)
+	}
+
+	return elapsed
+}
+
+// end ends the span of the stage with the given attributes, and observes the duration of the stage. Since the
+// algorithms run interleaved on each worker, each one is recorded as an event on the stage span, such as
+// "Split conserv", carrying the number of identifiers and the time it was busy.
+func (s *stageTrace) end(attributes ...attribute.KeyValue) {
+	for _, name := range s.names {
+		t := s.algorithms[name]
+		busy := time.Duration(atomic.LoadInt64(&t.busy))
+		s.span.AddEvent(s.name+" "+name, trace.WithAttributes(
+			attribute.String("algorithm", name),
+			attribute.Int64("identifiers", atomic.LoadInt64(&t.calls)),
+			attribute.Float64("busy_seconds", busy.Seconds())))
+	}
+
+	metrics.StageDuration.WithLabelValues(s.name).Observe(time.Since(s.start).Seconds())
+	s.span.SetAttributes(attributes...)
+	s.span.End()
+}
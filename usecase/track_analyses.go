@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// jobHeartbeat is how often the owner of a running job refreshes it.
+	jobHeartbeat = 30 * time.Second
+	// JobLease is how long a running job is held by its owner without being refreshed. Jobs not refreshed for longer
+	// were interrupted, and they can be recovered by any server instance.
+	JobLease = 4 * jobHeartbeat
+)
+
+// NewTrackedAnalyzeProjectUsecase initializes a new AnalyzeProjectUsecase that records each analysis as an
+// AnalysisJob held by the given owner while another AnalyzeProjectUsecase runs it. The job is removed once the
+// analysis is over, or kept as failed if the analysis failed, so only the analyses interrupted by a shutdown or a
+// crash remain running without being refreshed.
+func NewTrackedAnalyzeProjectUsecase(auc AnalyzeProjectUsecase, jr repository.JobRepository,
+	owner string) AnalyzeProjectUsecase {
+	return trackedAnalyzeProjectUsecase{
+		analyzeProjectUsecase: auc,
+		jobRepository:         jr,
+		owner:                 owner,
+	}
+}
+
+type trackedAnalyzeProjectUsecase struct {
+	analyzeProjectUsecase AnalyzeProjectUsecase
+	jobRepository         repository.JobRepository
+	owner                 string
+}
+
+// Process analyzes the given Project with the configured pipeline, recording the analysis while it runs.
+func (uc trackedAnalyzeProjectUsecase) Process(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
+	return uc.track(ctx, entity.AnalysisJob{ProjectID: projectID})
+}
+
+// ProcessWithPipeline analyzes the given Project with the given pipeline, recording the analysis while it runs.
+func (uc trackedAnalyzeProjectUsecase) ProcessWithPipeline(ctx context.Context, projectID uuid.UUID,
+	pipeline entity.Pipeline) (entity.AnalysisResults, error) {
+	return uc.track(ctx, entity.AnalysisJob{ProjectID: projectID, Pipeline: &pipeline})
+}
+
+// track records the first attempt of the job and runs it. If the job can't be recorded, the project is analyzed
+// anyway.
+func (uc trackedAnalyzeProjectUsecase) track(ctx context.Context, job entity.AnalysisJob) (entity.AnalysisResults, error) {
+	now := time.Now()
+	job.ID = uuid.New()
+	job.AnalysisID = uuid.New()
+	job.Attempt = 1
+	job.Status = entity.JobStatusRunning
+	job.Owner = uc.owner
+	job.DateStarted = now
+	job.DateRefreshed = now
+
+	if err := uc.jobRepository.Add(ctx, job); err != nil {
+		log.WithError(err).Warnf("unable to record the analysis of project %v, it won't be recovered if interrupted",
+			job.ProjectID)
+		return analyzeJob(entity.WithAnalysisID(ctx, job.AnalysisID), uc.analyzeProjectUsecase, job)
+	}
+
+	return runJob(ctx, uc.analyzeProjectUsecase, uc.jobRepository, job)
+}
+
+// runJob analyzes the project of a job recorded for its owner, refreshing the job while the analysis runs. If the
+// job is taken over by another owner meanwhile, the analysis is cancelled. Once the analysis is over, the job is
+// removed, or recorded as failed if the analysis failed, keeping only the workspace from the analysis context, which
+// could be already cancelled.
+func runJob(ctx context.Context, auc AnalyzeProjectUsecase, jr repository.JobRepository,
+	job entity.AnalysisJob) (entity.AnalysisResults, error) {
+	ctx, cancel := context.WithCancel(entity.WithAnalysisID(ctx, job.AnalysisID))
+	defer cancel()
+
+	done := make(chan struct{})
+	lost := make(chan bool, 1)
+	go func() {
+		ticker := time.NewTicker(jobHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				lost <- false
+				return
+			case <-ticker.C:
+				err := jr.Refresh(ctx, job)
+				switch err {
+				case nil:
+					// do nothing
+				case repository.ErrJobNoResults:
+					log.Warnf("analysis of project %v taken over by another instance, cancelling it", job.ProjectID)
+					cancel()
+					lost <- true
+					return
+				default:
+					log.WithError(err).Warnf("unable to refresh the record of the analysis of project %v", job.ProjectID)
+				}
+			}
+		}
+	}()
+
+	analysis, err := analyzeJob(ctx, auc, job)
+	close(done)
+	if <-lost {
+		return analysis, err
+	}
+
+	ctx = entity.WithWorkspace(context.Background(), entity.WorkspaceFrom(ctx))
+	switch err {
+	case nil, ErrProjectNotFound, ErrPreviousAnalysisFound, ErrAnalysisCanceled:
+		if err := jr.Delete(ctx, job.ID); err != nil {
+			log.WithError(err).Warnf("unable to remove the record of the analysis of project %v", job.ProjectID)
+		}
+	default:
+		if err := jr.Fail(ctx, job.ID, err.Error()); err != nil {
+			log.WithError(err).Warnf("unable to record the failed analysis of project %v", job.ProjectID)
+		}
+	}
+
+	return analysis, err
+}
+
+// analyzeJob analyzes the project of a job, applying the pipeline on the job, if any.
+func analyzeJob(ctx context.Context, auc AnalyzeProjectUsecase, job entity.AnalysisJob) (entity.AnalysisResults, error) {
+	if job.Pipeline == nil {
+		return auc.Process(ctx, job.ProjectID)
+	}
+
+	return auc.ProcessWithPipeline(ctx, job.ProjectID, *job.Pipeline)
+}
@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTrackedAnalyzeProjectUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewTrackedAnalyzeProjectUsecase(nil, nil, "")
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnTrackedAnalyzeProjectUsecase_ShouldRecordTheAnalysisWhileItRuns(t *testing.T) {
+	projectID := uuid.New()
+	jobRepositoryMock := newJobRepositoryMock()
+	analyzeProjectUsecaseMock := &analyzeProjectUsecaseMock{
+		analysis: entity.AnalysisResults{ID: uuid.New()},
+		started:  make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+
+	uc := usecase.NewTrackedAnalyzeProjectUsecase(analyzeProjectUsecaseMock, jobRepositoryMock, "owner")
+	done := make(chan error)
+	go func() {
+		_, err := uc.Process(context.TODO(), projectID)
+		done <- err
+	}()
+
+	<-analyzeProjectUsecaseMock.started
+	jobs, _ := jobRepositoryMock.FindAll(context.TODO())
+	require.Equal(t, 1, len(jobs))
+	assert.Equal(t, projectID, jobs[0].ProjectID)
+	assert.Nil(t, jobs[0].Pipeline)
+	assert.Equal(t, 1, jobs[0].Attempt)
+	assert.Equal(t, entity.JobStatusRunning, jobs[0].Status)
+	assert.Equal(t, "owner", jobs[0].Owner)
+	assert.False(t, jobs[0].DateStarted.IsZero())
+	assert.Equal(t, jobs[0].DateStarted, jobs[0].DateRefreshed)
+
+	close(analyzeProjectUsecaseMock.release)
+	assert.NoError(t, <-done)
+	assert.Empty(t, jobRepositoryMock.jobs)
+}
+
+func TestProcessWithPipeline_OnTrackedAnalyzeProjectUsecase_ShouldRecordThePipelineAndTheFailure(t *testing.T) {
+	pipeline := entity.Pipeline{Splitters: []string{"conserv"}, IncludeTests: true}
+	jobRepositoryMock := newJobRepositoryMock()
+	analyzeProjectUsecaseMock := &analyzeProjectUsecaseMock{err: usecase.ErrUnableToBuildASTs}
+
+	uc := usecase.NewTrackedAnalyzeProjectUsecase(analyzeProjectUsecaseMock, jobRepositoryMock, "owner")
+	_, err := uc.ProcessWithPipeline(context.TODO(), uuid.New(), pipeline)
+
+	assert.EqualError(t, err, usecase.ErrUnableToBuildASTs.Error())
+	assert.Equal(t, &pipeline, analyzeProjectUsecaseMock.pipeline)
+	if assert.Equal(t, 1, len(jobRepositoryMock.added)) {
+		assert.Equal(t, &pipeline, jobRepositoryMock.added[0].Pipeline)
+		job := jobRepositoryMock.jobs[jobRepositoryMock.added[0].ID]
+		assert.Equal(t, entity.JobStatusFailed, job.Status)
+		assert.Equal(t, usecase.ErrUnableToBuildASTs.Error(), job.Error)
+	}
+}
+
+func TestProcess_OnTrackedAnalyzeProjectUsecase_WhenErrorRecordingTheAnalysis_ShouldAnalyzeAnyway(t *testing.T) {
+	analysis := entity.AnalysisResults{ID: uuid.New()}
+	jobRepositoryMock := newJobRepositoryMock()
+	jobRepositoryMock.addErr = repository.ErrJobUnexpected
+
+	uc := usecase.NewTrackedAnalyzeProjectUsecase(&analyzeProjectUsecaseMock{analysis: analysis}, jobRepositoryMock, "owner")
+	results, err := uc.Process(context.TODO(), uuid.New())
+
+	assert.NoError(t, err)
+	assert.Equal(t, analysis, results)
+}
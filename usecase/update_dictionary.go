@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// UpdateDictionaryUsecase defines the contract for the use case related to the replacement of
+// the entries on an existing abbreviation dictionary.
+type UpdateDictionaryUsecase interface {
+	// Process replaces the entries of the Dictionary matching the given ID.
+	Process(ctx context.Context, ID uuid.UUID, entries map[string][]string) (entity.Dictionary, error)
+}
+
+// NewUpdateDictionaryUsecase initializes a new UpdateDictionaryUsecase instance.
+func NewUpdateDictionaryUsecase(dr repository.DictionaryRepository) UpdateDictionaryUsecase {
+	return updateDictionaryUsecase{
+		dictionaryRepository: dr,
+	}
+}
+
+type updateDictionaryUsecase struct {
+	dictionaryRepository repository.DictionaryRepository
+}
+
+func (uc updateDictionaryUsecase) Process(ctx context.Context, ID uuid.UUID, entries map[string][]string) (entity.Dictionary, error) {
+	dict, err := uc.dictionaryRepository.Get(ctx, ID)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrDictionaryNoResults:
+		return entity.Dictionary{}, ErrDictionaryNotFound
+	default:
+		log.WithError(err).Errorf("unable to retrieve dictionary with ID %v", ID)
+		return entity.Dictionary{}, ErrUnexpected
+	}
+
+	dict.Entries = normalizeEntries(entries)
+	dict.UpdatedAt = time.Now()
+
+	err = uc.dictionaryRepository.Update(ctx, dict)
+	switch err {
+	case nil:
+		// do nothing
+	case repository.ErrDictionaryNoResults:
+		return entity.Dictionary{}, ErrDictionaryNotFound
+	default:
+		log.WithError(err).Errorf("unable to update dictionary with ID %v", ID)
+		return entity.Dictionary{}, ErrUnexpected
+	}
+
+	return dict, nil
+}
@@ -0,0 +1,61 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
+	"github.com/eroatta/src-reader/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUpdateDictionaryUsecase_ShouldReturnNewInstance(t *testing.T) {
+	uc := usecase.NewUpdateDictionaryUsecase(nil)
+
+	assert.NotNil(t, uc)
+}
+
+func TestProcess_OnUpdateDictionaryUsecase_WhenNoExistingDictionary_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		getErr: repository.ErrDictionaryNoResults,
+	}
+	uc := usecase.NewUpdateDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), uuid.New(), map[string][]string{})
+
+	assert.Empty(t, dict)
+	assert.EqualError(t, err, usecase.ErrDictionaryNotFound.Error())
+}
+
+func TestProcess_OnUpdateDictionaryUsecase_WhenErrorUpdatingDictionary_ShouldReturnError(t *testing.T) {
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		updErr: repository.ErrDictionaryUnexpected,
+	}
+	uc := usecase.NewUpdateDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), uuid.New(), map[string][]string{})
+
+	assert.Empty(t, dict)
+	assert.EqualError(t, err, usecase.ErrUnexpected.Error())
+}
+
+func TestProcess_OnUpdateDictionaryUsecase_ShouldReturnUpdatedDictionary(t *testing.T) {
+	id := uuid.New()
+	dictionaryRepositoryMock := dictionaryRepositoryMock{
+		dictionary: entity.Dictionary{
+			ID:      id,
+			Scope:   entity.DictionaryScopeOrganization,
+			Entries: map[string][]string{"cfg": {"config"}},
+		},
+	}
+	uc := usecase.NewUpdateDictionaryUsecase(dictionaryRepositoryMock)
+
+	dict, err := uc.Process(context.TODO(), id, map[string][]string{"Svc": {"service"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, dict.ID)
+	assert.Equal(t, map[string][]string{"svc": {"service"}}, dict.Entries)
+	assert.False(t, dict.UpdatedAt.IsZero())
+}
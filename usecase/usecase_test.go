@@ -3,17 +3,25 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/eroatta/src-reader/entity"
+	"github.com/eroatta/src-reader/repository"
 	"github.com/google/uuid"
 )
 
 // project repository mock
 type projectRepositoryMock struct {
 	project entity.Project
+	page    entity.ProjectPage
+	query   *entity.ProjectQuery
 	getErr  error
 	addErr  error
 	delErr  error
+	// updated holds the last updated project, if provided.
+	updated   *entity.Project
+	updateErr error
 }
 
 func (m projectRepositoryMock) Add(ctx context.Context, p entity.Project) error {
@@ -28,6 +36,20 @@ func (m projectRepositoryMock) GetByReference(ctx context.Context, projectRef st
 	return m.project, m.getErr
 }
 
+func (m projectRepositoryMock) Query(ctx context.Context, query entity.ProjectQuery) (entity.ProjectPage, error) {
+	if m.query != nil {
+		*m.query = query
+	}
+	return m.page, m.getErr
+}
+
+func (m projectRepositoryMock) Update(ctx context.Context, p entity.Project) error {
+	if m.updated != nil {
+		*m.updated = p
+	}
+	return m.updateErr
+}
+
 func (m projectRepositoryMock) Delete(ctx context.Context, ID uuid.UUID) error {
 	return m.delErr
 }
@@ -51,12 +73,26 @@ type sourceCodeRepositoryMock struct {
 	sourceCode entity.SourceCode
 	files      map[string][]byte
 	err        error
+	updated    entity.SourceCode
+	updateErr  error
+	checkouts  *[]string
 }
 
 func (m sourceCodeRepositoryMock) Clone(ctx context.Context, fullname string, url string) (entity.SourceCode, error) {
 	return m.sourceCode, m.err
 }
 
+func (m sourceCodeRepositoryMock) Update(ctx context.Context, location string, hash string) (entity.SourceCode, error) {
+	if m.checkouts != nil {
+		*m.checkouts = append(*m.checkouts, location+"@"+hash)
+	}
+	if m.updateErr != nil {
+		return entity.SourceCode{}, m.updateErr
+	}
+
+	return m.updated, nil
+}
+
 func (m sourceCodeRepositoryMock) Remove(ctx context.Context, location string) error {
 	return m.err
 }
@@ -74,36 +110,122 @@ func (m sourceCodeRepositoryMock) Read(ctx context.Context, location string, fil
 	return b, nil
 }
 
+func (m sourceCodeRepositoryMock) Size(ctx context.Context, location string, filename string) (int64, error) {
+	b, err := m.Read(ctx, location, filename)
+	return int64(len(b)), err
+}
+
 // end source code repository mock
 
 // identifier repository mock
 type identifierRepositoryMock struct {
-	idents []entity.Identifier
-	err    error
-	delErr error
+	idents    []entity.Identifier
+	err       error
+	iterErr   error
+	delErr    error
+	commitErr error
+	stageErr  error
+	batches   *[]int
+	saved     *[]entity.Identifier
+	staged    []uuid.UUID
+	committed *[]uuid.UUID
+	deleted   *[]uuid.UUID
+	page      entity.IdentifierPage
+	query     *entity.IdentifierQuery
 }
 
 func (i identifierRepositoryMock) Add(ctx context.Context, analysis entity.AnalysisResults, ident entity.Identifier) error {
 	return i.err
 }
 
+func (i identifierRepositoryMock) AddAll(ctx context.Context, analysis entity.AnalysisResults, idents []entity.Identifier) error {
+	if i.batches != nil {
+		*i.batches = append(*i.batches, len(idents))
+	}
+	if i.saved != nil {
+		*i.saved = append(*i.saved, idents...)
+	}
+	return i.err
+}
+
+func (i identifierRepositoryMock) IterateByAnalysisID(ctx context.Context, analysisID uuid.UUID) (repository.IdentifierIterator, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+
+	return &identifierIteratorMock{idents: i.idents, pos: -1, err: i.iterErr}, nil
+}
+
 func (i identifierRepositoryMock) FindAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]entity.Identifier, error) {
 	return i.idents, i.err
 }
 
+func (i identifierRepositoryMock) QueryByAnalysisID(ctx context.Context, analysisID uuid.UUID, query entity.IdentifierQuery) (entity.IdentifierPage, error) {
+	if i.query != nil {
+		*i.query = query
+	}
+	return i.page, i.err
+}
+
 func (i identifierRepositoryMock) FindAllByProjectAndFile(ctx context.Context, projectRef string, filename string) ([]entity.Identifier, error) {
 	return i.idents, i.err
 }
 
 func (i identifierRepositoryMock) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	if i.deleted != nil {
+		*i.deleted = append(*i.deleted, analysisID)
+	}
 	return i.delErr
 }
 
+func (i identifierRepositoryMock) Commit(ctx context.Context, analysisID uuid.UUID) error {
+	if i.committed != nil && i.commitErr == nil {
+		*i.committed = append(*i.committed, analysisID)
+	}
+	return i.commitErr
+}
+
+func (i identifierRepositoryMock) Stage(ctx context.Context, analysisID uuid.UUID) error {
+	return i.stageErr
+}
+
+func (i identifierRepositoryMock) FindStagedAnalysisIDs(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	return i.staged, i.err
+}
+
+type identifierIteratorMock struct {
+	idents []entity.Identifier
+	pos    int
+	err    error
+}
+
+func (it *identifierIteratorMock) Next(ctx context.Context) bool {
+	if it.pos+1 >= len(it.idents) {
+		return false
+	}
+
+	it.pos++
+	return true
+}
+
+func (it *identifierIteratorMock) Identifier() entity.Identifier {
+	return it.idents[it.pos]
+}
+
+func (it *identifierIteratorMock) Err() error {
+	return it.err
+}
+
+func (it *identifierIteratorMock) Close(ctx context.Context) error {
+	return nil
+}
+
 // end identifier repository mock
 
 // analysis repository mock
 type analysisRepositoryMock struct {
 	analysisResults entity.AnalysisResults
+	analyses        map[uuid.UUID]entity.AnalysisResults
 	addErr          error
 	getErr          error
 	delErr          error
@@ -113,6 +235,18 @@ func (a analysisRepositoryMock) Add(ctx context.Context, analysis entity.Analysi
 	return a.addErr
 }
 
+func (a analysisRepositoryMock) Get(ctx context.Context, id uuid.UUID) (entity.AnalysisResults, error) {
+	if a.analyses == nil {
+		return a.analysisResults, a.getErr
+	}
+
+	analysis, ok := a.analyses[id]
+	if !ok {
+		return entity.AnalysisResults{}, repository.ErrAnalysisNoResults
+	}
+	return analysis, nil
+}
+
 func (a analysisRepositoryMock) GetByProjectID(ctx context.Context, projectID uuid.UUID) (entity.AnalysisResults, error) {
 	return a.analysisResults, a.getErr
 }
@@ -144,3 +278,280 @@ func (i insightsRepositoryMock) DeleteAllByAnalysisID(ctx context.Context, analy
 }
 
 // end insights repository mock
+
+// search repository mock
+type searchRepositoryMock struct {
+	results []entity.SearchResult
+	query   *entity.SearchQuery
+	added   *[]int
+	addErr  error
+	err     error
+	delErr  error
+}
+
+func (s searchRepositoryMock) AddAll(ctx context.Context, idents []entity.Identifier) error {
+	if s.added != nil && s.addErr == nil {
+		*s.added = append(*s.added, len(idents))
+	}
+	return s.addErr
+}
+
+func (s searchRepositoryMock) ReplaceAll(ctx context.Context, analysisID uuid.UUID,
+	it repository.IdentifierIterator) error {
+	count := 0
+	for it.Next(ctx) {
+		count++
+	}
+	if err := it.Err(); err != nil {
+		return repository.ErrSearchUnexpected
+	}
+	return s.AddAll(ctx, make([]entity.Identifier, count))
+}
+
+func (s searchRepositoryMock) Search(ctx context.Context, query entity.SearchQuery) ([]entity.SearchResult, error) {
+	if s.query != nil {
+		*s.query = query
+	}
+	return s.results, s.err
+}
+
+func (s searchRepositoryMock) DeleteAllByAnalysisID(ctx context.Context, analysisID uuid.UUID) error {
+	return s.delErr
+}
+
+// end search repository mock
+
+// dictionary repository mock
+type dictionaryRepositoryMock struct {
+	dictionary   entity.Dictionary
+	dictionaries map[string]entity.Dictionary
+	addErr       error
+	getErr       error
+	updErr       error
+	delErr       error
+}
+
+func (d dictionaryRepositoryMock) Add(ctx context.Context, dict entity.Dictionary) error {
+	return d.addErr
+}
+
+func (d dictionaryRepositoryMock) Get(ctx context.Context, ID uuid.UUID) (entity.Dictionary, error) {
+	return d.dictionary, d.getErr
+}
+
+func (d dictionaryRepositoryMock) GetByScope(ctx context.Context, scope string, projectRef string) (entity.Dictionary, error) {
+	if d.getErr != nil {
+		return entity.Dictionary{}, d.getErr
+	}
+
+	dict, ok := d.dictionaries[scope]
+	if !ok {
+		return entity.Dictionary{}, repository.ErrDictionaryNoResults
+	}
+	return dict, nil
+}
+
+func (d dictionaryRepositoryMock) FindAll(ctx context.Context) ([]entity.Dictionary, error) {
+	dicts := make([]entity.Dictionary, 0)
+	for _, dict := range d.dictionaries {
+		dicts = append(dicts, dict)
+	}
+	return dicts, d.getErr
+}
+
+func (d dictionaryRepositoryMock) Update(ctx context.Context, dict entity.Dictionary) error {
+	return d.updErr
+}
+
+func (d dictionaryRepositoryMock) Delete(ctx context.Context, ID uuid.UUID) error {
+	return d.delErr
+}
+
+// end dictionary repository mock
+
+// notifier mock
+type notifierMock struct {
+	sent      map[string]bool
+	sentErr   error
+	notified  *[]entity.Review
+	notifyErr error
+}
+
+func (n notifierMock) Sent(ctx context.Context, review entity.Review) (map[string]bool, error) {
+	if n.sentErr != nil {
+		return nil, n.sentErr
+	}
+	if n.sent == nil {
+		return map[string]bool{}, nil
+	}
+	return n.sent, nil
+}
+
+func (n notifierMock) Notify(ctx context.Context, review entity.Review) error {
+	if n.notifyErr != nil {
+		return n.notifyErr
+	}
+	if n.notified != nil {
+		*n.notified = append(*n.notified, review)
+	}
+	return nil
+}
+
+// end notifier mock
+
+// api key repository mock
+type apiKeyRepositoryMock struct {
+	keys   []entity.APIKey
+	added  *entity.APIKey
+	addErr error
+	getErr error
+	delErr error
+}
+
+func (k apiKeyRepositoryMock) Add(ctx context.Context, key entity.APIKey) error {
+	if k.added != nil {
+		*k.added = key
+	}
+	return k.addErr
+}
+
+func (k apiKeyRepositoryMock) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	if k.getErr != nil {
+		return entity.APIKey{}, k.getErr
+	}
+
+	for _, key := range k.keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+	return entity.APIKey{}, repository.ErrAPIKeyNoResults
+}
+
+func (k apiKeyRepositoryMock) FindAll(ctx context.Context) ([]entity.APIKey, error) {
+	return k.keys, k.getErr
+}
+
+func (k apiKeyRepositoryMock) Delete(ctx context.Context, ID uuid.UUID) error {
+	return k.delErr
+}
+
+// end api key repository mock
+
+// workspace repository mock
+type workspaceRepositoryMock struct {
+	workspaces []entity.Workspace
+	added      *entity.Workspace
+	addErr     error
+	getErr     error
+}
+
+func (w workspaceRepositoryMock) Add(ctx context.Context, workspace entity.Workspace) error {
+	if w.added != nil {
+		*w.added = workspace
+	}
+	return w.addErr
+}
+
+func (w workspaceRepositoryMock) Get(ctx context.Context, ID uuid.UUID) (entity.Workspace, error) {
+	if w.getErr != nil {
+		return entity.Workspace{}, w.getErr
+	}
+
+	for _, workspace := range w.workspaces {
+		if workspace.ID == ID {
+			return workspace, nil
+		}
+	}
+	return entity.Workspace{}, repository.ErrWorkspaceNoResults
+}
+
+func (w workspaceRepositoryMock) FindAll(ctx context.Context) ([]entity.Workspace, error) {
+	return w.workspaces, w.getErr
+}
+
+// end workspace repository mock
+
+// job repository mock
+type jobRepositoryMock struct {
+	mu       sync.Mutex
+	jobs     map[uuid.UUID]entity.AnalysisJob
+	added    []entity.AnalysisJob
+	claimed  []entity.AnalysisJob
+	addErr   error
+	findErr  error
+	claimErr error
+	delErr   error
+}
+
+func newJobRepositoryMock(jobs ...entity.AnalysisJob) *jobRepositoryMock {
+	m := &jobRepositoryMock{jobs: make(map[uuid.UUID]entity.AnalysisJob)}
+	for _, job := range jobs {
+		m.jobs[job.ID] = job
+	}
+	return m
+}
+
+func (m *jobRepositoryMock) Add(ctx context.Context, job entity.AnalysisJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.addErr != nil {
+		return m.addErr
+	}
+	m.jobs[job.ID] = job
+	m.added = append(m.added, job)
+	return nil
+}
+
+func (m *jobRepositoryMock) FindAll(ctx context.Context) ([]entity.AnalysisJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]entity.AnalysisJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, m.findErr
+}
+
+func (m *jobRepositoryMock) Refresh(ctx context.Context, job entity.AnalysisJob) error {
+	return nil
+}
+
+func (m *jobRepositoryMock) Claim(ctx context.Context, job entity.AnalysisJob, refreshedBefore time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.claimErr != nil {
+		return m.claimErr
+	}
+	m.jobs[job.ID] = job
+	m.claimed = append(m.claimed, job)
+	return nil
+}
+
+func (m *jobRepositoryMock) Fail(ctx context.Context, ID uuid.UUID, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[ID]
+	if !ok {
+		return repository.ErrJobNoResults
+	}
+	job.Status = entity.JobStatusFailed
+	job.Error = reason
+	m.jobs[ID] = job
+	return nil
+}
+
+func (m *jobRepositoryMock) Delete(ctx context.Context, ID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.delErr != nil {
+		return m.delErr
+	}
+	if _, ok := m.jobs[ID]; !ok {
+		return repository.ErrJobNoResults
+	}
+	delete(m.jobs, ID)
+	return nil
+}
+
+// end job repository mock